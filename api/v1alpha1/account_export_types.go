@@ -89,6 +89,10 @@ type AccountExportRule struct {
 	Advertise *bool `json:"advertise,omitempty"`
 	// +optional
 	AllowTrace *bool `json:"allowTrace,omitempty"`
+	// AllowWildcard acknowledges that Subject has wildcards. Exports with a `*` or `>` token are rejected without it,
+	// as broad wildcard exports easily share internal subjects.
+	// +optional
+	AllowWildcard *bool `json:"allowWildcard,omitempty"`
 }
 
 // AccountExportStatus defines the observed state of AccountExport.
@@ -74,6 +74,8 @@ type AccountImportSpec struct {
 	Rules []AccountImportRule `json:"rules"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.share) || !self.share || self.type == 'service'",message="share only applies to service imports"
+// +kubebuilder:validation:XValidation:rule="!has(self.allowTrace) || !self.allowTrace || self.type == 'stream'",message="allowTrace only applies to stream imports"
 type AccountImportRule struct {
 	// +optional
 	Name string `json:"name,omitempty"`
@@ -87,8 +89,12 @@ type AccountImportRule struct {
 	// Type defines whether the import is a stream or service import.
 	// +required
 	Type ExportType `json:"type,omitempty"`
+	// Share shares the latency information of the requests of the importing account with the exporter, for service
+	// latency tracking. Only applies to service imports.
 	// +optional
 	Share *bool `json:"share,omitempty"`
+	// AllowTrace lets message traces started in the exporting account continue into the importing account, for
+	// distributed tracing across accounts. Only applies to stream imports.
 	// +optional
 	AllowTrace *bool `json:"allowTrace,omitempty"`
 }
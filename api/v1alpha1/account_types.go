@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +35,74 @@ const (
 	AccountManagementPolicyObserve = "observe"
 )
 
+// Keys of the ConfigMap holding the public keys of an Account, see Account.GetPublicConfigMapName. Lists hold one item
+// per line.
+const (
+	// AccountPublicKeyKey holds the account public key, the account ID.
+	AccountPublicKeyKey = "account.pub"
+	// AccountSigningKeysKey holds the public signing keys of the account.
+	AccountSigningKeysKey = "signing-keys.pub"
+	// AccountExportsKey holds the subjects exported by the account.
+	AccountExportsKey = "exports"
+)
+
+// AccountUserDeletionPolicy controls how an Account with Users is deleted.
+// +kubebuilder:validation:Enum=Block;Cascade
+type AccountUserDeletionPolicy string
+
+const (
+	// AccountUserDeletionPolicyBlock keeps the Account until all of its Users are deleted.
+	AccountUserDeletionPolicyBlock AccountUserDeletionPolicy = "Block"
+	// AccountUserDeletionPolicyCascade deletes the Users of the Account before the Account.
+	AccountUserDeletionPolicyCascade AccountUserDeletionPolicy = "Cascade"
+)
+
+// AccountDeletionPolicy controls what happens to the account in NATS and its Secrets when the Account is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+type AccountDeletionPolicy string
+
+const (
+	// AccountDeletionPolicyDelete deletes the account JWT from NATS and deletes the account Secrets.
+	AccountDeletionPolicyDelete AccountDeletionPolicy = "Delete"
+	// AccountDeletionPolicyRetain keeps the account JWT in NATS and keeps the account Secrets, so the account can be
+	// recreated from them.
+	AccountDeletionPolicyRetain AccountDeletionPolicy = "Retain"
+	// AccountDeletionPolicyOrphan keeps the account JWT in NATS but deletes the account Secrets.
+	AccountDeletionPolicyOrphan AccountDeletionPolicy = "Orphan"
+)
+
+// AccountClusterTraffic selects the account whose connections carry the traffic of an account between the servers of a
+// cluster.
+// +kubebuilder:validation:Enum=system;owner
+type AccountClusterTraffic string
+
+const (
+	// AccountClusterTrafficSystem sends the traffic through the connections of the system account.
+	AccountClusterTrafficSystem AccountClusterTraffic = "system"
+	// AccountClusterTrafficOwner sends the traffic through connections of the account itself.
+	AccountClusterTrafficOwner AccountClusterTraffic = "owner"
+)
+
+// AccountClaimField selects a part of the account claims.
+// +kubebuilder:validation:Enum=Exports;Imports;Limits;Mappings
+type AccountClaimField string
+
+const (
+	AccountClaimFieldExports AccountClaimField = "Exports"
+	AccountClaimFieldImports AccountClaimField = "Imports"
+	// AccountClaimFieldLimits covers the account, JetStream and NATS limits.
+	AccountClaimFieldLimits   AccountClaimField = "Limits"
+	AccountClaimFieldMappings AccountClaimField = "Mappings"
+)
+
+// AccountAnnotationRepushRequestedAt forces a push of the account JWT to NATS on the next reconcile, even though NATS
+// already has equivalent claims. Every new value forces one push.
+const AccountAnnotationRepushRequestedAt = "account.nauth.io/repush-requested-at"
+
+// AccountAnnotationOperatorSigningKey names the Secret of the operator signing key the Account is signed with, when its
+// NatsCluster selects operator signing keys by annotation.
+const AccountAnnotationOperatorSigningKey = "account.nauth.io/operator-signing-key"
+
 // NatsClusterRef references a NatsCluster resource
 type NatsClusterRef struct {
 	// Name of the NatsCluster
@@ -44,6 +113,11 @@ type NatsClusterRef struct {
 }
 
 // AccountSpec defines the desired state of Account.
+// +kubebuilder:validation:XValidation:rule="!(has(self.jetStreamLimits) && has(self.jetStreamTieredLimits))",message="jetStreamLimits and jetStreamTieredLimits are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.observedClaims) || !('Exports' in self.observedClaims) || !has(self.exports)",message="exports must not be set when Exports are observed"
+// +kubebuilder:validation:XValidation:rule="!has(self.observedClaims) || !('Imports' in self.observedClaims) || !has(self.imports)",message="imports must not be set when Imports are observed"
+// +kubebuilder:validation:XValidation:rule="!has(self.observedClaims) || !('Limits' in self.observedClaims) || !(has(self.accountLimits) || has(self.jetStreamEnabled) || has(self.jetStreamLimits) || has(self.jetStreamTieredLimits) || has(self.natsLimits))",message="limits must not be set when Limits are observed"
+// +kubebuilder:validation:XValidation:rule="!has(self.observedClaims) || !('Mappings' in self.observedClaims) || !has(self.mappings)",message="mappings must not be set when Mappings are observed"
 type AccountSpec struct {
 	// NatsClusterRef references the NatsCluster to use for this account.
 	// If not specified, the controller uses the operator-level NATS_CLUSTER_REF when configured.
@@ -53,6 +127,22 @@ type AccountSpec struct {
 	// DisplayName is an optional name for the NATS resource representing the account. May be derived if absent.
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
+	// Description describes the account in its JWT, e.g. for inventory tooling.
+	// +kubebuilder:validation:MaxLength=8192
+	// +optional
+	Description string `json:"description,omitempty"`
+	// InfoURL links to more information about the account.
+	// +kubebuilder:validation:MaxLength=8192
+	// +optional
+	InfoURL string `json:"infoURL,omitempty"`
+	// Tags label the account in its JWT. NATS stores tags in lower case.
+	// +listType=set
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// TagValues are added to the tags of the account JWT as "key:value" tags, e.g. for authorization proxies reading
+	// the JWT.
+	// +optional
+	TagValues TagValues `json:"tagValues,omitempty"`
 	// JetStreamEnabled indicates whether JetStream should be explicitly enabled or disabled.
 	// If absent, JetStream will be implicitly enabled/disabled based on the effective JetStreamLimits.
 	// +optional
@@ -65,8 +155,89 @@ type AccountSpec struct {
 	Imports Imports `json:"imports,omitempty"`
 	// +optional
 	JetStreamLimits *JetStreamLimits `json:"jetStreamLimits,omitempty"`
+	// JetStreamTieredLimits are JetStream limits per replication tier, keyed by tier name (R1, R3, ...). A stream
+	// counts against the tier of its replica count. Mutually exclusive with JetStreamLimits.
+	// +kubebuilder:validation:XValidation:rule="self.all(tier, tier.matches('^R[1-9][0-9]*$'))",message="tier names must be R followed by the replica count, for example R1 or R3"
+	// +optional
+	JetStreamTieredLimits JetStreamTieredLimits `json:"jetStreamTieredLimits,omitempty"`
+	// +optional
+	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+	// Mappings map subjects published in the account to weighted destination subjects, e.g. for canary routing.
+	// +listType=map
+	// +listMapKey=subject
+	// +optional
+	Mappings []SubjectMapping `json:"mappings,omitempty"`
+	// DisallowBearer makes NATS reject connections of users of the account with bearer JWTs, including Users with
+	// spec.bearer set.
+	// +optional
+	DisallowBearer bool `json:"disallowBearer,omitempty"`
+	// DefaultPermissions apply to users of the account whose JWT has no permissions of its own.
+	// +optional
+	DefaultPermissions *Permissions `json:"defaultPermissions,omitempty"`
+	// Trace sends traces of messages published in the account with a sampled traceparent header to a subject.
+	// +optional
+	Trace *MessageTrace `json:"trace,omitempty"`
+	// ClusterTraffic selects the account whose connections carry the traffic of the account between the servers of a
+	// cluster: system (default) or owner.
+	// +optional
+	ClusterTraffic AccountClusterTraffic `json:"clusterTraffic,omitempty"`
+	// UserDefaults are applied to every User of this account that does not set the same field in its own spec.
+	// +optional
+	UserDefaults *UserDefaults `json:"userDefaults,omitempty"`
+	// UserDeletionPolicy controls deletion of the Account while Users bound to it exist. Block (default) keeps the
+	// Account with a DeletionBlocked condition until the Users are deleted, Cascade deletes the Users first.
+	// +optional
+	UserDeletionPolicy AccountUserDeletionPolicy `json:"userDeletionPolicy,omitempty"`
+	// DeletionPolicy controls what happens to the account in NATS and its Secrets when the Account is deleted. Delete
+	// (default) deletes both, Retain keeps both and Orphan keeps the account JWT in NATS but deletes the Secrets.
+	// +optional
+	DeletionPolicy AccountDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// AdoptExisting binds a new Account with the account.nauth.io/id label to the Secrets of that account stored for
+	// another, deleted Account, e.g. one retained before moving the Account to another name or namespace. The Secrets
+	// are moved to this Account and the account keeps its keys and JWT. Adopting an account of another namespace
+	// requires a ReferenceGrant in that namespace allowing Accounts of this namespace to reference the previous Account.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+	// KeySecrets references Secrets holding account keys generated outside of NAuth, e.g. in an HSM backed key
+	// ceremony, to create the account with instead of generating its keys. The Secrets are only read when the account is
+	// created: NAuth then stores the keys in the Secrets of the account like generated keys, and the referenced Secrets
+	// can be deleted.
+	// +optional
+	KeySecrets *AccountKeySecrets `json:"keySecrets,omitempty"`
+	// Paused stops NAuth from changing the account in NATS and its Secrets, including on deletion, until it is unset.
+	// A paused Account reports the Paused condition.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// ObservedClaims are the parts of the account claims NAuth keeps as they are in the account JWT in NATS, e.g.
+	// imports and exports maintained with nsc, while it manages the rest of the claims from this spec. The spec fields
+	// of observed claims must not be set. Ignored with the observe management policy, which observes all claims.
+	// +kubebuilder:validation:MaxItems=4
+	// +listType=set
+	// +optional
+	ObservedClaims []AccountClaimField `json:"observedClaims,omitempty"`
+}
+
+// AccountKeySecrets references Secrets in the namespace of the Account holding the seeds of account keys. The key in
+// the Secrets defaults to "default".
+type AccountKeySecrets struct {
+	// Root references the seed of the account root key, whose public key is the account ID. When the Account has the
+	// account.nauth.io/id label, the root key must match it.
+	Root SecretKeyReference `json:"root"`
+	// Signing references the seed of the account signing key user JWTs are signed with. It must differ from the root
+	// key.
+	Signing SecretKeyReference `json:"signing"`
+}
+
+// UserDefaults holds User settings inherited from the Account. A field set in the UserSpec replaces the default
+// as a whole.
+type UserDefaults struct {
+	// +optional
+	Permissions *Permissions `json:"permissions,omitempty"`
 	// +optional
 	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+	// ExpiresAt is the default expiry of user JWTs.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 type AccountClaims struct {
@@ -75,6 +246,12 @@ type AccountClaims struct {
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
 	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	InfoURL string `json:"infoURL,omitempty"`
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// +optional
 	SigningKeys SigningKeys `json:"signingKeys,omitempty"`
 	// +optional
 	Exports Exports `json:"exports,omitempty"`
@@ -85,7 +262,32 @@ type AccountClaims struct {
 	// +optional
 	JetStreamLimits *JetStreamLimits `json:"jetStreamLimits,omitempty"`
 	// +optional
+	JetStreamTieredLimits JetStreamTieredLimits `json:"jetStreamTieredLimits,omitempty"`
+	// +optional
 	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+	// +optional
+	Mappings SubjectMappings `json:"mappings,omitempty"`
+	// +optional
+	DisallowBearer bool `json:"disallowBearer,omitempty"`
+	// +optional
+	DefaultPermissions *Permissions `json:"defaultPermissions,omitempty"`
+	// +optional
+	Trace *MessageTrace `json:"trace,omitempty"`
+	// +optional
+	ClusterTraffic AccountClusterTraffic `json:"clusterTraffic,omitempty"`
+}
+
+// MessageTrace configures distributed message tracing of an account.
+type MessageTrace struct {
+	// Destination is the subject traces are published to. It must not contain wildcards.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="!self.split('.').exists(t, t == '*' || t == '>')",message="destination must not contain wildcards"
+	Destination Subject `json:"destination"`
+	// Sampling is the percentage of messages with a sampled traceparent header that are traced. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Sampling int `json:"sampling,omitempty"`
 }
 
 // AccountStatus defines the observed state of Account.
@@ -95,6 +297,9 @@ type AccountStatus struct {
 	// ClaimsHash is a hash of the Account JWT claims, used to determine if the claims have changed and a new JWT needs to be generated.
 	// +optional
 	ClaimsHash string `json:"claimsHash,omitempty"`
+	// JWT describes the account JWT last pushed to NATS, or found up to date in NATS.
+	// +optional
+	JWT *JWTMetadata `json:"jwt,omitempty"`
 	// +optional
 	Adoptions *AccountAdoptions `json:"adoptions,omitempty"`
 	// +listType=map
@@ -109,12 +314,61 @@ type AccountStatus struct {
 	ReconcileTimestamp metav1.Time `json:"reconcileTimestamp,omitempty"`
 	// +optional
 	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// ObservedRepushRequestedAt is the value of the repush annotation the account JWT was last force pushed for.
+	// +optional
+	ObservedRepushRequestedAt string `json:"observedRepushRequestedAt,omitempty"`
+	// Usage is a snapshot of the current usage of the account, summed over the servers of its cluster.
+	// +optional
+	Usage *AccountUsage `json:"usage,omitempty"`
+	// PendingChanges describes the claims waiting for the end of a maintenance window of the NatsCluster to be pushed.
+	// Claims, ClaimsHash and JWT keep describing the account JWT in NATS until then.
+	// +optional
+	PendingChanges *AccountPendingChanges `json:"pendingChanges,omitempty"`
+	// OperatorSigningKeySecretName is the Secret of the operator signing key selected for the Account, when its
+	// NatsCluster has several operator signing keys. status.jwt.issuer is the public key of the key that signed it.
+	// +optional
+	OperatorSigningKeySecretName string `json:"operatorSigningKeySecretName,omitempty"`
+}
+
+// AccountPendingChanges describes account claims not pushed to NATS because a maintenance window is active.
+type AccountPendingChanges struct {
+	// ClaimsHash is the hash of the claims waiting to be pushed.
+	ClaimsHash string `json:"claimsHash"`
+	// Fields lists the claims that differ from the account JWT in NATS. Empty when the account is not in NATS yet.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+	// Until is the end of the maintenance window, when the claims are pushed.
+	Until metav1.Time `json:"until"`
+}
+
+// AccountUsage is a snapshot of the usage of an account in NATS.
+type AccountUsage struct {
+	// Connections is the number of client connections.
+	Connections int32 `json:"connections"`
+	// LeafNodes is the number of leaf node connections.
+	LeafNodes int32 `json:"leafNodes"`
+	// Subscriptions is the number of subscriptions.
+	Subscriptions int32 `json:"subscriptions"`
+	// JetStream is the JetStream storage in use, unset when JetStream is not enabled for the account.
+	// +optional
+	JetStream *AccountJetStreamUsage `json:"jetStream,omitempty"`
+	// ObservedAt is when the usage was read from NATS.
+	ObservedAt metav1.Time `json:"observedAt"`
+}
+
+// AccountJetStreamUsage is the JetStream storage used by an account.
+type AccountJetStreamUsage struct {
+	// MemoryBytes is the memory storage in use.
+	MemoryBytes int64 `json:"memoryBytes"`
+	// StorageBytes is the file storage in use.
+	StorageBytes int64 `json:"storageBytes"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Connections",type=integer,JSONPath=`.status.usage.connections`,priority=1
 
 // Account is the composite resource for the accounts API.
 type Account struct {
@@ -140,6 +394,12 @@ func (a *Account) SetLabel(label AccountLabel, value string) {
 	a.Labels[string(label)] = value
 }
 
+// GetPublicConfigMapName returns the name of the ConfigMap holding the public keys and exported subjects of the
+// account, which workloads can read without access to Secrets.
+func (a *Account) GetPublicConfigMapName() string {
+	return fmt.Sprintf("%s-nats-account-public", a.GetName())
+}
+
 // AccountAdoptions defines the status of child resources that have been adopted or are candidates for adoption by this account.
 type AccountAdoptions struct {
 	// Exports defines adoptions of type `AccountExport` that are bound to the account.
@@ -220,6 +480,43 @@ type JetStreamLimits struct {
 	MaxBytesRequired *bool `json:"maxBytesRequired,omitempty"` // Max bytes required by all Streams
 }
 
+// JetStreamTieredLimits holds JetStream limits by replication tier name.
+type JetStreamTieredLimits map[string]JetStreamLimits
+
+// TagValues are custom data encoded in a JWT as "key:value" tags, since JWTs have no field for custom data. Keys and
+// values are lower case, as NATS stores tags in lower case, and keys cannot contain ':'.
+// +kubebuilder:validation:MaxProperties=32
+// +kubebuilder:validation:XValidation:rule="self.all(key, key.matches('^[a-z0-9]([a-z0-9._/-]{0,61}[a-z0-9])?$'))",message="tag value keys must be at most 63 lower case alphanumerics, '.', '_', '/' or '-', starting and ending with an alphanumeric"
+type TagValues map[string]TagValue
+
+// TagValue is the value of a tag encoded as "key:value".
+// +kubebuilder:validation:MaxLength=256
+// +kubebuilder:validation:Pattern=`^[a-z0-9._/:@+=-]*$`
+type TagValue string
+
+type SubjectMappings []SubjectMapping
+
+// SubjectMapping maps messages published to Subject to one of its destinations, chosen by weight.
+type SubjectMapping struct {
+	// Subject is the published subject. Wildcards can be referenced in the destination subjects.
+	Subject Subject `json:"subject"`
+	// +kubebuilder:validation:MinItems=1
+	Destinations []WeightedDestination `json:"destinations"`
+}
+
+type WeightedDestination struct {
+	Subject Subject `json:"subject"`
+	// Weight is the percentage of messages mapped to this destination. The weights of a mapping must not exceed 100
+	// in total, per cluster; messages not mapped keep their subject. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// Cluster limits the destination to messages published in the named NATS cluster.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+}
+
 type AccountLimits struct {
 	// +optional
 	// +kubebuilder:default=-1
@@ -269,9 +566,24 @@ type Export struct {
 	AccountTokenPosition uint            `json:"accountTokenPosition,omitempty"`
 	Advertise            bool            `json:"advertise,omitempty"`
 	AllowTrace           bool            `json:"allowTrace,omitempty"`
+	// AllowWildcard acknowledges that Subject has wildcards. Exports with a `*` or `>` token are rejected without it,
+	// as broad wildcard exports easily share internal subjects.
+	AllowWildcard bool `json:"allowWildcard,omitempty"`
+}
+
+// Revoke revokes the activation tokens of the export issued to the account with public key accountID before at.
+// accountID "*" revokes the activation tokens of all accounts.
+func (e *Export) Revoke(accountID string, at time.Time) {
+	if e.Revocations == nil {
+		e.Revocations = make(RevocationList)
+	}
+	e.Revocations[accountID] = at.Unix()
 }
 
 type Imports []*Import
+
+// +kubebuilder:validation:XValidation:rule="!has(self.share) || !self.share || (has(self.type) && self.type == 'service')",message="share only applies to service imports"
+// +kubebuilder:validation:XValidation:rule="!has(self.allowTrace) || !self.allowTrace || !has(self.type) || self.type == 'stream'",message="allowTrace only applies to stream imports"
 type Import struct {
 	// AccountRefName references the account used to create the user.
 	AccountRef AccountRef `json:"accountRef"`
@@ -289,8 +601,12 @@ type Import struct {
 	// The sum of wildcard reference and * tokens needs to match the number of * token in Subject.
 	LocalSubject RenamingSubject `json:"localSubject,omitempty"`
 	Type         ExportType      `json:"type,omitempty"`
-	Share        bool            `json:"share,omitempty"`
-	AllowTrace   bool            `json:"allowTrace,omitempty"`
+	// Share shares the latency information of the requests of the importing account with the exporter, for service
+	// latency tracking. Only applies to service imports.
+	Share bool `json:"share,omitempty"`
+	// AllowTrace lets message traces started in the exporting account continue into the importing account, for
+	// distributed tracing across accounts. Only applies to stream imports.
+	AllowTrace bool `json:"allowTrace,omitempty"`
 }
 
 type ServiceLatency struct {
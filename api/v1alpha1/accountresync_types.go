@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultAccountResyncBatchSize is the number of Accounts repushed per batch when spec.batchSize is not set.
+	DefaultAccountResyncBatchSize = 10
+	// DefaultAccountResyncBatchInterval is the pause between batches when spec.batchInterval is not set.
+	DefaultAccountResyncBatchInterval = 10 * time.Second
+)
+
+// AccountResyncSpec selects the Accounts to resync and paces the resync.
+type AccountResyncSpec struct {
+	// Selector selects the Accounts to resync by their labels. All Accounts are resynced when it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// BatchSize is the number of Accounts requested to repush their JWT per batch.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +kubebuilder:default=10
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+	// BatchInterval is the pause between two batches.
+	// +kubebuilder:default="10s"
+	// +optional
+	BatchInterval *metav1.Duration `json:"batchInterval,omitempty"`
+}
+
+// AccountResyncStatus reports the progress of an AccountResync.
+type AccountResyncStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// StartedAt is when the first batch was requested.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// LastBatchAt is when the last batch was requested.
+	// +optional
+	LastBatchAt *metav1.Time `json:"lastBatchAt,omitempty"`
+	// CompletedAt is when the last Account was requested to repush.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// Checkpoint is the namespace/name of the last Account requested to repush. The resync continues with the
+	// Accounts sorted after it, also after a restart of the operator.
+	// +optional
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// Requested is the number of Accounts requested to repush so far.
+	// +optional
+	Requested int64 `json:"requested,omitempty"`
+	// Remaining is the number of selected Accounts not yet requested to repush.
+	// +optional
+	Remaining int64 `json:"remaining,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Requested",type=integer,JSONPath=`.status.requested`
+// +kubebuilder:printcolumn:name="Remaining",type=integer,JSONPath=`.status.remaining`
+
+// AccountResync re-signs and repushes the JWTs of all selected Accounts once, in rate-limited batches, for example
+// after restoring from backup or rotating the operator signing key. Accounts with the observe management policy are
+// skipped. Create a new AccountResync to resync again.
+type AccountResync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountResyncSpec   `json:"spec,omitempty"`
+	Status AccountResyncStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountResyncList contains a list of AccountResync
+type AccountResyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccountResync `json:"items"`
+}
+
+func (r *AccountResync) GetConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
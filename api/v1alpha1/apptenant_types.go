@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type AppTenantLabel string
+
+const (
+	// AppTenantLabelName is the name of the AppTenant an Account or User was generated for.
+	AppTenantLabelName AppTenantLabel = "apptenant.nauth.io/name"
+	// AppTenantLabelRole is the role a User was generated for.
+	AppTenantLabelRole AppTenantLabel = "apptenant.nauth.io/role"
+)
+
+// AppTenantUserRole is a standard User generated for an AppTenant.
+// +kubebuilder:validation:Enum=admin;service;telemetry
+type AppTenantUserRole string
+
+const (
+	// AppTenantUserRoleAdmin may publish and subscribe to every subject of the account.
+	AppTenantUserRoleAdmin AppTenantUserRole = "admin"
+	// AppTenantUserRoleService may publish and subscribe to the subjects of the tenant and receive replies.
+	AppTenantUserRoleService AppTenantUserRole = "service"
+	// AppTenantUserRoleTelemetry may only publish to the telemetry subjects of the tenant.
+	AppTenantUserRoleTelemetry AppTenantUserRole = "telemetry"
+)
+
+// AppTenantSpec defines the Account and Users generated for an application tenant.
+type AppTenantSpec struct {
+	// Account is the spec of the generated Account, which is named like the AppTenant.
+	// +optional
+	Account *AccountSpec `json:"account,omitempty"`
+	// Users are the roles a User is generated for, named <tenant>-<role>. Removing a role deletes its User.
+	// +kubebuilder:default={admin,service,telemetry}
+	// +listType=set
+	// +optional
+	Users []AppTenantUserRole `json:"users,omitempty"`
+	// SubjectPrefix is the subject namespace of the tenant, granted to the service and telemetry users. Defaults to
+	// the name of the AppTenant.
+	// +kubebuilder:validation:Pattern=`^[^.*> ]+(\.[^.*> ]+)*$`
+	// +optional
+	SubjectPrefix string `json:"subjectPrefix,omitempty"`
+}
+
+// AppTenantStatus defines the observed state of AppTenant.
+type AppTenantStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// AccountName is the name of the generated Account.
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+	// UserNames are the names of the generated Users.
+	// +optional
+	UserNames []string `json:"userNames,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Account",type=string,JSONPath=`.status.accountName`
+
+// AppTenant generates an Account and a standard set of Users for an application tenant. The generated resources are
+// owned by the AppTenant: changes to them are reverted and they are deleted with it.
+type AppTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AppTenantSpec   `json:"spec,omitempty"`
+	Status AppTenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AppTenantList contains a list of AppTenant
+type AppTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AppTenant `json:"items"`
+}
+
+func (t *AppTenant) GetConditions() *[]metav1.Condition {
+	return &t.Status.Conditions
+}
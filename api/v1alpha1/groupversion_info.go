@@ -44,8 +44,24 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AccountExportList{},
 		&AccountImport{},
 		&AccountImportList{},
+		&AccountResync{},
+		&AccountResyncList{},
+		&AppTenant{},
+		&AppTenantList{},
 		&NatsCluster{},
 		&NatsClusterList{},
+		&NauthDefaults{},
+		&NauthDefaultsList{},
+		&NauthPolicy{},
+		&NauthPolicyList{},
+		&NauthQuota{},
+		&NauthQuotaList{},
+		&NauthVersion{},
+		&NauthVersionList{},
+		&PermissionSet{},
+		&PermissionSetList{},
+		&ReferenceGrant{},
+		&ReferenceGrantList{},
 		&User{},
 		&UserList{},
 	)
@@ -40,6 +40,17 @@ type SecretKeyReference struct {
 	Key string `json:"key,omitempty"`
 }
 
+// ConfigMapKeyReference contains information to locate a ConfigMap key in the same namespace
+type ConfigMapKeyReference struct {
+	// Name of the ConfigMap.
+	// +required
+	Name string `json:"name"`
+
+	// Key in the ConfigMap, when not specified an implementation-specific default key is used.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
 // URLFromReference describes how to load the NATS URL from a ConfigMap or Secret.
 type URLFromReference struct {
 	// Kind is the type of resource to load from: ConfigMap or Secret.
@@ -59,8 +70,158 @@ type URLFromReference struct {
 	Key string `json:"key"`
 }
 
+// SystemAccountSpec describes the NATS system account of a cluster. nauth never modifies the system account, it is
+// only observed to verify access and to prevent Accounts from colliding with it.
+type SystemAccountSpec struct {
+	// AccountID is the expected public key of the system account. When set, reconciliation fails unless the system
+	// account user credentials belong to this account.
+	// +kubebuilder:validation:Pattern=`^A[A-Z2-7]{55}$`
+	// +optional
+	AccountID string `json:"accountID,omitempty"`
+}
+
+// TLSSecretReference references a Secret of type kubernetes.io/tls in the same namespace. The certificate and private
+// key are read from the tls.crt and tls.key entries.
+type TLSSecretReference struct {
+	// Name of the Secret.
+	// +required
+	Name string `json:"name"`
+}
+
+// NatsTLSSpec configures TLS for connections from nauth to the NATS cluster.
+type NatsTLSSpec struct {
+	// CASecretRef references a PEM encoded CA bundle used to verify the NATS server certificate. When not specified,
+	// the system trust store is used. The key defaults to "ca.crt".
+	// +optional
+	CASecretRef *SecretKeyReference `json:"caSecretRef,omitempty"`
+
+	// CertSecretRef references a kubernetes.io/tls Secret holding the client certificate and key for mutual TLS.
+	// +optional
+	CertSecretRef *TLSSecretReference `json:"certSecretRef,omitempty"`
+
+	// InsecureSkipVerify disables verification of the NATS server certificate. Only intended for testing.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// OperatorSignerSpec configures an external signing service, such as a sidecar or KMS plugin, that holds the operator
+// signing key and signs JWTs on behalf of nauth.
+type OperatorSignerSpec struct {
+	// URL of the signing service. JWTs are signed through POST requests to <url>/sign.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	URL string `json:"url"`
+
+	// PublicKey is the public key of the operator signing key held by the signing service.
+	// +kubebuilder:validation:Pattern=`^O[A-Z2-7]{55}$`
+	// +required
+	PublicKey string `json:"publicKey"`
+}
+
+// OperatorSigningKeySelectionPolicy is how the operator signing key of an Account is chosen from several keys.
+// +kubebuilder:validation:Enum=Label;Annotation;RoundRobin
+type OperatorSigningKeySelectionPolicy string
+
+const (
+	// OperatorSigningKeySelectionLabel signs an Account with the key of the Secret whose value of selectionLabel equals
+	// the value of the same label on the Account.
+	OperatorSigningKeySelectionLabel OperatorSigningKeySelectionPolicy = "Label"
+	// OperatorSigningKeySelectionAnnotation signs an Account with the key of the Secret named by its
+	// account.nauth.io/operator-signing-key annotation.
+	OperatorSigningKeySelectionAnnotation OperatorSigningKeySelectionPolicy = "Annotation"
+	// OperatorSigningKeySelectionRoundRobin spreads the Accounts over the keys. An Account keeps the key it is signed
+	// with while that key is selected.
+	OperatorSigningKeySelectionRoundRobin OperatorSigningKeySelectionPolicy = "RoundRobin"
+)
+
+// OperatorSigningKeysSpec selects several operator signing keys, for example one per environment or team, from Secrets
+// in the namespace of the NatsCluster.
+type OperatorSigningKeysSpec struct {
+	// SecretLabels selects the Secrets holding the operator signing key seeds.
+	// +kubebuilder:validation:MinProperties=1
+	// +required
+	SecretLabels map[string]string `json:"secretLabels"`
+
+	// Key in each Secret, when not specified an implementation-specific default key is used.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// SelectionPolicy is how the key of an Account is chosen. Accounts without the label or annotation the policy
+	// selects by are spread over the keys as by RoundRobin.
+	// +kubebuilder:default=RoundRobin
+	// +optional
+	SelectionPolicy OperatorSigningKeySelectionPolicy `json:"selectionPolicy,omitempty"`
+
+	// SelectionLabel is the label compared between Accounts and Secrets by the Label selection policy.
+	// +optional
+	SelectionLabel string `json:"selectionLabel,omitempty"`
+}
+
+const (
+	// ResolverConfigKey is the ConfigMap key the resolver configuration is written to.
+	ResolverConfigKey = "resolver.conf"
+	// DefaultOperatorJWTKey is the ConfigMap key the operator JWT is read from when the reference sets no key.
+	DefaultOperatorJWTKey = "operator.jwt"
+)
+
+// ResolverConfigSpec configures a ConfigMap holding the resolver configuration of the NATS servers, rendered from the
+// state managed by nauth. Servers including it run a MEMORY resolver preloaded with the operator, the system account
+// and the JWTs of all Accounts bound to the cluster, without a running full resolver.
+type ResolverConfigSpec struct {
+	// ConfigMapName is the ConfigMap in the namespace of the NatsCluster the configuration is written to, under the key
+	// resolver.conf. The ConfigMap is owned by the NatsCluster.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ConfigMapName string `json:"configMapName"`
+
+	// OperatorJWTConfigMapRef references the operator JWT, which is not managed by nauth. The key defaults to
+	// "operator.jwt".
+	// +required
+	OperatorJWTConfigMapRef ConfigMapKeyReference `json:"operatorJWTConfigMapRef"`
+}
+
+// ResolverStrategyType selects where account JWTs are written for the NATS servers to resolve accounts from.
+// +kubebuilder:validation:Enum=push;kv;directory
+type ResolverStrategyType string
+
+const (
+	// ResolverStrategyPush uploads account JWTs to the NATS servers with $SYS.REQ.CLAIMS.UPDATE.
+	ResolverStrategyPush ResolverStrategyType = "push"
+	// ResolverStrategyKV writes account JWTs to a JetStream key-value bucket of the system account.
+	ResolverStrategyKV ResolverStrategyType = "kv"
+	// ResolverStrategyDirectory writes account JWTs to the directory of a full resolver, shared with the NATS servers
+	// through a volume mounted into the nauth controller.
+	ResolverStrategyDirectory ResolverStrategyType = "directory"
+)
+
+// DefaultResolverKVBucket is the key-value bucket of the kv resolver strategy when none is set.
+const DefaultResolverKVBucket = "nauth-account-jwts"
+
+// ResolverStrategySpec configures how account JWTs reach the NATS servers.
+// +kubebuilder:validation:XValidation:rule="self.type != 'directory' || has(self.directory)",message="directory is required for the directory strategy"
+type ResolverStrategySpec struct {
+	// Type is the resolver strategy.
+	// +kubebuilder:default=push
+	// +required
+	Type ResolverStrategyType `json:"type"`
+
+	// KVBucket is the key-value bucket of the system account the kv strategy writes account JWTs to, keyed by account
+	// ID. The bucket must exist. Defaults to "nauth-account-jwts".
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// +optional
+	KVBucket string `json:"kvBucket,omitempty"`
+
+	// Directory is the path, in the nauth controller container, of the resolver directory the directory strategy
+	// writes account JWTs to as <account ID>.jwt files.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Directory string `json:"directory,omitempty"`
+}
+
 // NatsClusterSpec defines the desired state of NatsCluster
 // +kubebuilder:validation:XValidation:rule="has(self.url) != has(self.urlFrom)",message="exactly one of url or urlFrom must be specified"
+// +kubebuilder:validation:XValidation:rule="[has(self.operatorSigningKeySecretRef), has(self.signer), has(self.operatorSigningKeys)].filter(x, x).size() == 1",message="exactly one of operatorSigningKeySecretRef, signer or operatorSigningKeys must be specified"
+// +kubebuilder:validation:XValidation:rule="!has(self.operatorSigningKeys) || self.operatorSigningKeys.selectionPolicy != 'Label' || has(self.operatorSigningKeys.selectionLabel)",message="operatorSigningKeys.selectionLabel is required by the Label selection policy"
 type NatsClusterSpec struct {
 	// URL is the NATS server URL for this cluster. Mutually exclusive with urlFrom.
 	// +optional
@@ -70,8 +231,90 @@ type NatsClusterSpec struct {
 	// +optional
 	URLFrom *URLFromReference `json:"urlFrom,omitempty"`
 
-	OperatorSigningKeySecretRef     SecretKeyReference `json:"operatorSigningKeySecretRef"`
+	// OperatorSigningKeySecretRef references the seed of the operator signing key. Mutually exclusive with signer and
+	// operatorSigningKeys.
+	// +optional
+	OperatorSigningKeySecretRef *SecretKeyReference `json:"operatorSigningKeySecretRef,omitempty"`
+
+	// Signer signs JWTs through an external signing service instead, so the operator signing key seed is not stored
+	// in a Secret. Mutually exclusive with operatorSigningKeySecretRef and operatorSigningKeys.
+	// +optional
+	Signer *OperatorSignerSpec `json:"signer,omitempty"`
+
+	// OperatorSigningKeys selects several operator signing keys instead, each Account is signed with one of them.
+	// Mutually exclusive with operatorSigningKeySecretRef and signer.
+	// +optional
+	OperatorSigningKeys *OperatorSigningKeysSpec `json:"operatorSigningKeys,omitempty"`
+
 	SystemAccountUserCredsSecretRef SecretKeyReference `json:"systemAccountUserCredsSecretRef"`
+
+	// SystemAccount describes the system account of the cluster.
+	// +optional
+	SystemAccount *SystemAccountSpec `json:"systemAccount,omitempty"`
+
+	// TLS configures TLS for connections to the NATS cluster. The NATS URL should use the tls:// scheme.
+	// +optional
+	TLS *NatsTLSSpec `json:"tls,omitempty"`
+
+	// ObserveInterval is how often Accounts with the observe management policy bound to this cluster read their claims
+	// from NATS again, so claims changed outside nauth are reflected in their status. Defaults to 5m.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s')",message="observeInterval must be at least 10s"
+	// +optional
+	ObserveInterval *metav1.Duration `json:"observeInterval,omitempty"`
+
+	// ResolverStrategy configures how account JWTs reach the NATS servers. Defaults to pushing them through the system
+	// account.
+	// +optional
+	ResolverStrategy *ResolverStrategySpec `json:"resolverStrategy,omitempty"`
+
+	// ResolverConfig renders the resolver configuration of the NATS servers to a ConfigMap and keeps it updated as
+	// Accounts change.
+	// +optional
+	ResolverConfig *ResolverConfigSpec `json:"resolverConfig,omitempty"`
+
+	// MaintenanceWindows are recurring periods, e.g. change freezes during peak traffic, during which NAuth does not
+	// push account JWTs to or delete accounts from the cluster. Accounts are still validated and report the changes
+	// waiting for the end of the window in status.pendingChanges.
+	// +listType=atomic
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// AccountDefaults are limits applied to the Accounts bound to this cluster that do not set them, so cluster
+	// operators own the default ceilings of the cluster.
+	// +optional
+	AccountDefaults *ClusterAccountDefaults `json:"accountDefaults,omitempty"`
+}
+
+// ClusterAccountDefaults holds limits applied to the Accounts bound to a NatsCluster. A group of limits set by the
+// Account, or by the NauthDefaults, takes precedence over the cluster default: the default is applied first and every
+// field set by the Account replaces the field of the default. As the API server sets every field of a group an Account
+// sets, a group set by the Account in practice replaces the cluster default as a whole.
+type ClusterAccountDefaults struct {
+	// +optional
+	AccountLimits *AccountLimits `json:"accountLimits,omitempty"`
+	// JetStreamLimits apply to Accounts that do not disable JetStream and set no jetStreamTieredLimits.
+	// +optional
+	JetStreamLimits *JetStreamLimits `json:"jetStreamLimits,omitempty"`
+	// +optional
+	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period during which NAuth does not change the accounts of a NatsCluster.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with five fields (minute, hour, day of month, month and day of week) giving the
+	// start of each occurrence of the window, e.g. "0 18 * * 5" for Fridays at 18:00.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Schedule string `json:"schedule"`
+
+	// Duration is how long each occurrence of the window lasts.
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="duration must be positive"
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. "Europe/Stockholm". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // NatsClusterStatus defines the observed state of NatsCluster.
@@ -88,6 +331,96 @@ type NatsClusterStatus struct {
 	ReconcileTimestamp metav1.Time `json:"reconcileTimestamp,omitempty"`
 	// +optional
 	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// SystemAccountID is the public key of the observed system account.
+	// +optional
+	SystemAccountID string `json:"systemAccountID,omitempty"`
+	// OperatorSigningKey is the public key of the operator signing key Accounts are signed with.
+	// +optional
+	OperatorSigningKey string `json:"operatorSigningKey,omitempty"`
+	// OperatorSigningKeyRotation reports the last rotation of the operator signing key.
+	// +optional
+	OperatorSigningKeyRotation *OperatorSigningKeyRotationStatus `json:"operatorSigningKeyRotation,omitempty"`
+	// RetiredOperatorSigningKeys lists the public keys of operator signing keys no Account is signed with anymore. They
+	// can be removed from the operator JWT.
+	// +optional
+	RetiredOperatorSigningKeys []string `json:"retiredOperatorSigningKeys,omitempty"`
+	// Health reports the NATS servers of the cluster as last checked through the system account. The Healthy condition
+	// reports whether the check succeeded.
+	// +optional
+	Health *NatsClusterHealthStatus `json:"health,omitempty"`
+}
+
+// NatsClusterHealthStatus reports the NATS servers of a cluster.
+type NatsClusterHealthStatus struct {
+	// ServerCount is the number of servers that responded to the last check.
+	ServerCount int32 `json:"serverCount"`
+	// Servers lists the servers that responded to the last check.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Servers []NatsServerStatus `json:"servers,omitempty"`
+	// ResolverType is how account JWTs reach the servers: push, kv or directory.
+	// +optional
+	ResolverType string `json:"resolverType,omitempty"`
+	// JetStreamCapacity is the JetStream storage of the servers with JetStream enabled, summed over the servers. An
+	// Account with JetStream limits beyond it is not pushed to the cluster.
+	// +optional
+	JetStreamCapacity *NatsClusterJetStreamCapacity `json:"jetStreamCapacity,omitempty"`
+	// LastCheckedAt is when the servers were last checked.
+	LastCheckedAt metav1.Time `json:"lastCheckedAt"`
+}
+
+// NatsClusterJetStreamCapacity is the JetStream storage of a cluster, in bytes.
+type NatsClusterJetStreamCapacity struct {
+	// MemoryStorage is the JetStream memory storage of the cluster.
+	MemoryStorage int64 `json:"memStorage"`
+	// DiskStorage is the JetStream disk storage of the cluster.
+	DiskStorage int64 `json:"diskStorage"`
+}
+
+// NatsServerStatus describes a NATS server that responded to a health check.
+type NatsServerStatus struct {
+	// Name is the server name.
+	Name string `json:"name"`
+	// Version is the NATS server version.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// JetStream reports whether JetStream is enabled on the server.
+	// +optional
+	JetStream bool `json:"jetStream,omitempty"`
+	// Connections is the number of client connections to the server.
+	// +optional
+	Connections int32 `json:"connections,omitempty"`
+}
+
+// OperatorSigningKeyRotationPhase is the progress of an operator signing key rotation.
+// +kubebuilder:validation:Enum=Resigning;Completed
+type OperatorSigningKeyRotationPhase string
+
+const (
+	// OperatorSigningKeyRotationResigning waits for all Accounts to be signed with the new key and accepted by NATS.
+	OperatorSigningKeyRotationResigning OperatorSigningKeyRotationPhase = "Resigning"
+	// OperatorSigningKeyRotationCompleted has all Accounts signed with the new key and the previous key retired.
+	OperatorSigningKeyRotationCompleted OperatorSigningKeyRotationPhase = "Completed"
+)
+
+// OperatorSigningKeyRotationStatus reports the rotation of the operator signing key, started when the key referenced
+// by operatorSigningKeySecretRef or signer changes.
+type OperatorSigningKeyRotationStatus struct {
+	// PreviousSigningKey is the public key of the operator signing key rotated from.
+	PreviousSigningKey string `json:"previousSigningKey"`
+	// SigningKey is the public key of the operator signing key rotated to.
+	SigningKey string `json:"signingKey"`
+	// Phase is the progress of the rotation.
+	Phase OperatorSigningKeyRotationPhase `json:"phase"`
+	// StartedAt is when the new key was observed.
+	StartedAt metav1.Time `json:"startedAt"`
+	// CompletedAt is when the last Account signed with the new key was accepted by NATS.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// PendingAccounts is the number of Accounts bound to the cluster not yet signed with the new key.
+	// +optional
+	PendingAccounts int64 `json:"pendingAccounts,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -95,6 +428,9 @@ type NatsClusterStatus struct {
 // +kubebuilder:resource:scope=Namespaced
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="Healthy")].status`
+// +kubebuilder:printcolumn:name="Servers",type=integer,JSONPath=`.status.health.serverCount`
+// +kubebuilder:printcolumn:name="System Account",type=string,JSONPath=`.status.systemAccountID`,priority=1
 
 // NatsCluster is the Schema for the natsclusters API
 type NatsCluster struct {
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NauthDefaultsName is the name of the NauthDefaults resource applied by the operator.
+const NauthDefaultsName = "default"
+
+// NauthDefaultsSpec defines organization-wide defaults for Accounts and Users.
+type NauthDefaultsSpec struct {
+	// Account holds defaults for managed Accounts.
+	// +optional
+	Account *AccountPlatformDefaults `json:"account,omitempty"`
+	// User holds defaults for Users.
+	// +optional
+	User *UserPlatformDefaults `json:"user,omitempty"`
+	// RequiredLabels lists label keys every managed Account and every User must have. Resources missing one of them
+	// are not reconciled and report the reason RequiredLabelsMissing.
+	// +optional
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// Finalizers controls the finalizers NAuth adds to Accounts and Users, e.g. for GitOps tooling that prunes
+	// resources without waiting for finalizers.
+	// +optional
+	Finalizers *FinalizerDefaults `json:"finalizers,omitempty"`
+	// KeyPolicy constrains the account keys NAuth signs with.
+	// +optional
+	KeyPolicy *KeyPolicy `json:"keyPolicy,omitempty"`
+}
+
+// UserSigningPolicy selects the account keys user JWTs may be signed with.
+// +kubebuilder:validation:Enum=Any;SigningKeyOnly
+type UserSigningPolicy string
+
+const (
+	// UserSigningPolicyAny allows signing user JWTs with the account signing key or the account root key.
+	UserSigningPolicyAny UserSigningPolicy = "Any"
+	// UserSigningPolicySigningKeyOnly forbids signing user JWTs with the account root key.
+	UserSigningPolicySigningKeyOnly UserSigningPolicy = "SigningKeyOnly"
+)
+
+// KeyPolicy constrains how NAuth uses account keys.
+type KeyPolicy struct {
+	// UserSigning selects the account keys user JWTs may be signed with, defaults to Any. With SigningKeyOnly, Users of
+	// an Account whose signing key Secret holds the account root seed, e.g. a Secret created by hand, are not issued
+	// credentials and report the reason KeyPolicyViolation. Users signed with the root key before are signed again when
+	// the policy is set.
+	// +kubebuilder:default=Any
+	// +optional
+	UserSigning UserSigningPolicy `json:"userSigning,omitempty"`
+}
+
+// FinalizerDefaults holds the finalizer policy of each kind of resource NAuth cleans up after.
+type FinalizerDefaults struct {
+	// +optional
+	Account *FinalizerPolicy `json:"account,omitempty"`
+	// +optional
+	User *FinalizerPolicy `json:"user,omitempty"`
+}
+
+// FinalizerMode selects the finalizer NAuth adds to a resource.
+// +kubebuilder:validation:Enum=Standard;None;Custom
+type FinalizerMode string
+
+const (
+	// FinalizerModeStandard adds the finalizer <kind>.nauth.io/finalizer.
+	FinalizerModeStandard FinalizerMode = "Standard"
+	// FinalizerModeNone adds no finalizer. Deleting the resource skips the cleanup of NAuth.
+	FinalizerModeNone FinalizerMode = "None"
+	// FinalizerModeCustom adds the finalizer named by FinalizerPolicy.Name.
+	FinalizerModeCustom FinalizerMode = "Custom"
+)
+
+// FinalizerPolicy controls the finalizer NAuth adds to a kind of resource. Resources keep a finalizer they got before
+// the policy changed until NAuth reconciles them again, and a previous custom finalizer until it is removed manually.
+// +kubebuilder:validation:XValidation:rule="(self.mode == 'Custom') == has(self.name)",message="name must be set exactly when mode is Custom"
+type FinalizerPolicy struct {
+	Mode FinalizerMode `json:"mode"`
+	// Name of the finalizer with mode Custom, a domain prefixed name such as example.com/nauth.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?/[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// AccountPlatformDefaults holds limits applied to Accounts that do not set them. A field set in the AccountSpec replaces
+// the default as a whole.
+type AccountPlatformDefaults struct {
+	// +optional
+	AccountLimits *AccountLimits `json:"accountLimits,omitempty"`
+	// JetStreamLimits apply to Accounts that set neither jetStreamLimits nor jetStreamTieredLimits and do not disable
+	// JetStream.
+	// +optional
+	JetStreamLimits *JetStreamLimits `json:"jetStreamLimits,omitempty"`
+	// +optional
+	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+}
+
+// UserPlatformDefaults holds settings applied to every User.
+type UserPlatformDefaults struct {
+	// NatsLimits apply to Users that set no natsLimits and whose Account has no userDefaults.natsLimits.
+	// +optional
+	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+	// DenySubjects are added to the deny lists of every User, on top of its own permissions.
+	// +optional
+	DenySubjects *DenySubjects `json:"denySubjects,omitempty"`
+}
+
+// DenySubjects lists subjects users may not publish or subscribe to.
+type DenySubjects struct {
+	// +optional
+	Pub StringList `json:"pub,omitempty"`
+	// +optional
+	Sub StringList `json:"sub,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="the NauthDefaults resource must be named default"
+
+// NauthDefaults holds organization-wide defaults the operator applies to Accounts and Users. Only the NauthDefaults
+// named default is used.
+type NauthDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NauthDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NauthDefaultsList contains a list of NauthDefaults
+type NauthDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NauthDefaults `json:"items"`
+}
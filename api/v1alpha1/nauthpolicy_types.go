@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NauthPolicySpec restricts the subjects the selected Accounts may export and import. An Account is selected when it
+// is in one of the namespaces and matches the account selector.
+type NauthPolicySpec struct {
+	// Namespaces are the namespaces of the Accounts the policy applies to. Empty selects all namespaces.
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// AccountSelector selects the Accounts the policy applies to by their labels. Unset selects all Accounts.
+	// +optional
+	AccountSelector *metav1.LabelSelector `json:"accountSelector,omitempty"`
+	// Exports restricts the subjects the selected Accounts may export, inline and through AccountExports.
+	// +optional
+	Exports *SubjectPolicy `json:"exports,omitempty"`
+	// Imports restricts the subjects the selected Accounts may import, inline and through AccountImports.
+	// +optional
+	Imports *SubjectPolicy `json:"imports,omitempty"`
+}
+
+// SubjectPolicy is an allowlist and a denylist of subject patterns. A subject is allowed when it is contained in an
+// allowed pattern, or the allowlist is empty, and it shares no subject with a denied pattern. Patterns may use the
+// NATS wildcards `*` and `>`, and `{namespace}` is replaced by the namespace of the Account.
+type SubjectPolicy struct {
+	// Allow are the patterns of the allowed subjects. Empty allows all subjects that are not denied.
+	// +listType=set
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+	// Deny are the patterns of the denied subjects.
+	// +listType=set
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// NauthPolicy restricts the exports and imports of Accounts to subject patterns. Accounts with an export or import
+// not allowed by every NauthPolicy selecting them are not reconciled and report the reason PolicyViolation.
+type NauthPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NauthPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NauthPolicyList contains a list of NauthPolicy
+type NauthPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NauthPolicy `json:"items"`
+}
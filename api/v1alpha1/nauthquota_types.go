@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NauthQuotaSpec caps the Accounts and Users of a namespace. Unset fields are not limited.
+type NauthQuotaSpec struct {
+	// MaxAccounts is the number of managed Accounts the namespace may have.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAccounts *int64 `json:"maxAccounts,omitempty"`
+	// MaxUsers is the number of Users the namespace may have.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUsers *int64 `json:"maxUsers,omitempty"`
+	// MaxConnections caps the sum of accountLimits.conn of the managed Accounts. An Account without a connection
+	// limit counts as unlimited and exceeds it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+	// MaxJetStreamDiskStorage caps the sum of JetStream disk storage of the managed Accounts, over all tiers. An
+	// Account with JetStream enabled and without a disk storage limit counts as unlimited and exceeds it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxJetStreamDiskStorage *int64 `json:"maxJetStreamDiskStorage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NauthQuota caps how many Accounts and Users a namespace may have and the limits they may claim together. Accounts
+// and Users are admitted in creation order; those that do not fit are not reconciled and report the reason
+// QuotaExceeded.
+type NauthQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NauthQuotaSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NauthQuotaList contains a list of NauthQuota
+type NauthQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NauthQuota `json:"items"`
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NauthVersionStatus describes a running nauth operator installation.
+type NauthVersionStatus struct {
+	// OperatorVersion is the version of the running operator.
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// OperatorNamespace is the namespace the operator is deployed to.
+	// +optional
+	OperatorNamespace string `json:"operatorNamespace,omitempty"`
+	// FeatureGates lists the optional operator features and whether they are enabled.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// APIVersions lists the nauth.io API versions served by the operator.
+	// +optional
+	APIVersions []string `json:"apiVersions,omitempty"`
+	// ProviderTypes lists the account providers supported by the operator.
+	// +optional
+	ProviderTypes []string `json:"providerTypes,omitempty"`
+	// CryptoPolicy is the active crypto policy.
+	// +optional
+	CryptoPolicy string `json:"cryptoPolicy,omitempty"`
+	// StartTimestamp is the time the reporting operator instance started.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.operatorVersion`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.status.operatorNamespace`
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTimestamp`
+
+// NauthVersion is a read-only, operator-maintained description of a nauth installation. The operator creates one
+// per installation at startup, named after the operator namespace.
+type NauthVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status NauthVersionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NauthVersionList contains a list of NauthVersion
+type NauthVersionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NauthVersion `json:"items"`
+}
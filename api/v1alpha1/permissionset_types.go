@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PermissionSetSpec defines reusable user permissions.
+type PermissionSetSpec struct {
+	// Permissions are merged into the permissions of every User referencing this PermissionSet.
+	// +optional
+	Permissions Permissions `json:"permissions,omitempty"`
+}
+
+// PermissionSetReference references a PermissionSet in the namespace of the referencing resource.
+type PermissionSetReference struct {
+	// Name of the PermissionSet.
+	// +required
+	Name string `json:"name"`
+}
+
+// ObservedPermissionSet records the generation of a PermissionSet a User was last signed with.
+type ObservedPermissionSet struct {
+	// Name of the PermissionSet.
+	Name string `json:"name"`
+	// ObservedGeneration is the generation of the PermissionSet the User was signed with.
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+// +kubebuilder:object:root=true
+
+// PermissionSet is a reusable set of NATS subject permissions that Users can reference.
+type PermissionSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PermissionSetSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PermissionSetList contains a list of PermissionSet.
+type PermissionSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PermissionSet `json:"items"`
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReferenceGrantFromKind is a kind of resource allowed to reference resources across namespaces.
+// +kubebuilder:validation:Enum=Account;AccountImport;User
+type ReferenceGrantFromKind string
+
+const (
+	ReferenceGrantFromKindAccount       ReferenceGrantFromKind = "Account"
+	ReferenceGrantFromKindAccountImport ReferenceGrantFromKind = "AccountImport"
+	ReferenceGrantFromKindUser          ReferenceGrantFromKind = "User"
+)
+
+// ReferenceGrantToKind is a kind of resource that can be referenced across namespaces.
+// +kubebuilder:validation:Enum=Account
+type ReferenceGrantToKind string
+
+const (
+	ReferenceGrantToKindAccount ReferenceGrantToKind = "Account"
+)
+
+// ReferenceGrantSpec defines which resources in other namespaces may reference resources in the namespace of the
+// ReferenceGrant.
+type ReferenceGrantSpec struct {
+	// From lists the resources allowed to reference the resources listed in To.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	From []ReferenceGrantFrom `json:"from"`
+	// To lists the resources in this namespace that may be referenced.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+// ReferenceGrantFrom describes resources allowed to reference across namespaces.
+type ReferenceGrantFrom struct {
+	// Kind of the referencing resource.
+	// +required
+	Kind ReferenceGrantFromKind `json:"kind"`
+	// Namespace of the referencing resource.
+	// +required
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo describes resources that may be referenced.
+type ReferenceGrantTo struct {
+	// Kind of the referenced resource.
+	// +required
+	Kind ReferenceGrantToKind `json:"kind"`
+	// Name of the referenced resource. All resources of Kind in this namespace may be referenced when empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReferenceGrant allows resources in other namespaces to reference resources in the namespace of the ReferenceGrant.
+// Cross-namespace Account references without a matching ReferenceGrant are rejected.
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReferenceGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReferenceGrantList contains a list of ReferenceGrant.
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceGrant `json:"items"`
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"strings"
 	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const MaxInfoLength = 8 * 1024
@@ -54,13 +56,30 @@ type Info struct {
 	InfoURL     string `json:"info_url,omitempty"`
 }
 
+// JWTMetadata describes a signed JWT.
+type JWTMetadata struct {
+	// ID is the unique ID (jti) of the JWT.
+	ID string `json:"id"`
+	// IssuedAt is when the JWT was signed.
+	IssuedAt metav1.Time `json:"issuedAt"`
+	// ExpiresAt is when the JWT expires. Unset for JWTs without expiry.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// Issuer is the public key of the key that signed the JWT.
+	Issuer string `json:"issuer"`
+}
+
 // Subject is a string that represents a NATS subject
 type Subject string
 
 // TimeRange is used to represent a start and end time
 type TimeRange struct {
+	// Start is the time of day the range starts, formatted as HH:MM:SS.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`
 	Start string `json:"start,omitempty"`
-	End   string `json:"end,omitempty"`
+	// End is the time of day the range ends, formatted as HH:MM:SS.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`
+	End string `json:"end,omitempty"`
 }
 
 type NatsLimits struct {
@@ -213,3 +232,8 @@ func (c *CIDRList) UnmarshalJSON(body []byte) (err error) {
 		return err
 	}
 }
+
+// AnnotationShard names the nauth instance that owns an Account or User when Accounts and Users are split across
+// multiple nauth deployments. Other instances leave the resource alone; remove the annotation to hand the resource
+// over to another instance.
+const AnnotationShard = "nauth.io/shard"
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,22 +32,198 @@ const (
 	UserLabelSignedBy  UserLabel = "user.nauth.io/signed-by"
 )
 
+// UserAnnotationCredentialsPrefix prefixes the name of a User in the pod template annotation of its restart targets,
+// which holds the checksum of the credentials the pods were started with.
+const UserAnnotationCredentialsPrefix = "credentials.nauth.io/"
+
+// DefaultBearerTTL is how long a bearer JWT is valid when spec.bearer.ttl is not set.
+const DefaultBearerTTL = time.Hour
+
 // UserSpec defines the desired state of User.
+// +kubebuilder:validation:XValidation:rule="!has(self.credentialsTTL) || !has(self.credentialsSink)",message="credentialsTTL cannot be combined with credentialsSink"
+// +kubebuilder:validation:XValidation:rule="!has(self.bearer) || !(has(self.expiresAt) || has(self.credentialsSink) || has(self.credentialsTTL))",message="bearer cannot be combined with expiresAt, credentialsSink or credentialsTTL"
+// +kubebuilder:validation:XValidation:rule="has(self.nkey) ? !has(self.accountName) && !has(self.accountRef) : has(self.accountName) != has(self.accountRef)",message="exactly one of accountName or accountRef must be set, or neither with nkey"
+// +kubebuilder:validation:XValidation:rule="!has(self.nkey) || !(has(self.bearer) || has(self.bearerToken) || has(self.expiresAt) || has(self.credentialsSink) || has(self.userLimits) || has(self.natsLimits) || has(self.tags) || has(self.tagValues))",message="nkey cannot be combined with bearer, bearerToken, expiresAt, credentialsSink, userLimits, natsLimits, tags or tagValues"
+// +kubebuilder:validation:XValidation:rule="!has(self.credentialsFormat) || !(has(self.bearer) || has(self.nkey))",message="credentialsFormat cannot be combined with bearer or nkey"
 type UserSpec struct {
-	// AccountName references the account used to create the user.
-	AccountName string `json:"accountName"`
+	// AccountName references the account in the namespace of the user used to create the user.
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+	// AccountRef references the account used to create the user by name or label selector, optionally in another
+	// namespace. A reference to an Account in another namespace must be allowed by a ReferenceGrant in that namespace.
+	// +optional
+	AccountRef *UserAccountRef `json:"accountRef,omitempty"`
 	// DisplayName is an optional name for the NATS resource representing the user. May be derived if absent.
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
+	// Tags label the user in its JWT, e.g. for authorization proxies reading the JWT. NATS stores tags in lower case.
+	// +listType=set
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// TagValues are added to the tags of the user JWT as "key:value" tags.
+	// +optional
+	TagValues TagValues `json:"tagValues,omitempty"`
 	// ExpiresAt is an optional absolute time when the generated user JWT expires.
 	// +optional
 	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 	// +optional
 	Permissions *Permissions `json:"permissions,omitempty"`
+	// PermissionSetRefs references PermissionSets in the namespace of the User. Their permissions are merged with the
+	// inline permissions, in the order listed.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	PermissionSetRefs []PermissionSetReference `json:"permissionSetRefs,omitempty"`
 	// +optional
 	UserLimits *UserLimits `json:"userLimits,omitempty"`
 	// +optional
 	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
+	// BearerToken makes the user JWT a bearer token: the server skips the nonce signature check on connect, so the
+	// JWT alone is sufficient to authenticate.
+	// +optional
+	BearerToken bool `json:"bearerToken,omitempty"`
+	// AllowedConnectionTypes restricts the connection types the user can connect with. All types are allowed when empty.
+	// +listType=set
+	// +optional
+	AllowedConnectionTypes []ConnectionType `json:"allowedConnectionTypes,omitempty"`
+	// Bearer issues the user as short-lived bearer JWTs, e.g. for web and mobile clients connecting over WebSocket. The
+	// credentials Secret holds only the user JWT, under the key user.jwt, and no seed. A new JWT is issued before the
+	// current one expires.
+	// +optional
+	Bearer *BearerCredentials `json:"bearer,omitempty"`
+	// CredentialsSink delivers the user credentials to a secret store outside the cluster, in addition to the
+	// credentials Secret. Rotated credentials are delivered again.
+	// +optional
+	CredentialsSink *CredentialsSink `json:"credentialsSink,omitempty"`
+	// CredentialsFormat selects the keys of the credentials Secret. Creds, the default, writes the credentials file under
+	// user.creds. Expanded also writes the user JWT under user.jwt, the seed under user.nk and the public key under
+	// user.pub, for clients configured with the JWT and seed separately.
+	// +optional
+	CredentialsFormat CredentialsFormat `json:"credentialsFormat,omitempty"`
+	// CredentialsTTL makes the credentials Secret short-lived: NAuth deletes it once the TTL has passed since the
+	// credentials were issued, so they are retrieved once instead of being kept in the cluster. The user JWT stays valid
+	// until expiresAt.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s')",message="credentialsTTL must be at least 10s"
+	// +optional
+	CredentialsTTL *metav1.Duration `json:"credentialsTTL,omitempty"`
+	// NKey issues the user as a bare NKey without a JWT, for NATS servers that authorize users in their config file
+	// instead of in operator mode. The credentials Secret holds the seed under user.nk and the public key under
+	// user.pub, and the user references no Account.
+	// +optional
+	NKey *NKeyUser `json:"nkey,omitempty"`
+	// Paused stops NAuth from signing the user and writing its credentials Secret, including on deletion, until it is
+	// unset. A paused User reports the Paused condition.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// RestartTargets are Deployments and StatefulSets in the namespace of the User that are rolled out when the
+	// credentials change. NAuth sets the pod template annotation credentials.nauth.io/<user name> to the checksum of the
+	// credentials Secret.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	// +optional
+	RestartTargets []RestartTarget `json:"restartTargets,omitempty"`
+}
+
+// CredentialsFormat selects the keys of the credentials Secret of a User.
+// +kubebuilder:validation:Enum=Creds;Expanded
+type CredentialsFormat string
+
+const (
+	// CredentialsFormatCreds writes the credentials file of the user.
+	CredentialsFormatCreds CredentialsFormat = "Creds"
+	// CredentialsFormatExpanded writes the credentials file, the user JWT, the seed and the public key of the user.
+	CredentialsFormatExpanded CredentialsFormat = "Expanded"
+)
+
+// RestartTarget references a workload in the namespace of the User.
+type RestartTarget struct {
+	// Kind of the workload.
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	Kind RestartTargetKind `json:"kind"`
+	// Name of the workload.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+type RestartTargetKind string
+
+const (
+	RestartTargetKindDeployment  RestartTargetKind = "Deployment"
+	RestartTargetKindStatefulSet RestartTargetKind = "StatefulSet"
+)
+
+// UserAccountRef references the Account of a User.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.selector)",message="exactly one of name or selector must be set"
+type UserAccountRef struct {
+	// Name of the Account.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace of the Account. Defaults to the namespace of the User.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Selector selects the Account by its labels. It must match exactly one Account in the namespace.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// NKeyUser configures a User issued as a bare NKey.
+type NKeyUser struct {
+	// Authorization adds the entry of the user for the users list of an authorization or account block of the NATS
+	// server config to the credentials Secret, under the key authorization.conf. It holds the public key, permissions
+	// and allowed connection types of the user.
+	// +optional
+	Authorization bool `json:"authorization,omitempty"`
+}
+
+// BearerCredentials configures the bearer JWTs issued for a User.
+type BearerCredentials struct {
+	// TTL is how long each bearer JWT is valid. A new JWT is issued once two thirds of it have passed. Defaults to 1h.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="bearer ttl must be at least 1m"
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// GetTTL returns how long each bearer JWT is valid.
+func (b *BearerCredentials) GetTTL() time.Duration {
+	if b.TTL != nil {
+		return b.TTL.Duration
+	}
+	return DefaultBearerTTL
+}
+
+// CredentialsSink configures where user credentials are delivered outside the cluster. Exactly one sink must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.externalSecretsPush)",message="exactly one credentials sink must be set"
+type CredentialsSink struct {
+	// ExternalSecretsPush pushes the credentials Secret to a secret store, e.g. AWS Secrets Manager, GCP Secret Manager
+	// or Azure Key Vault, with a PushSecret of the External Secrets Operator.
+	// +optional
+	ExternalSecretsPush *ExternalSecretsPushSink `json:"externalSecretsPush,omitempty"`
+}
+
+// ExternalSecretsPushSink pushes the credentials Secret with a PushSecret of the External Secrets Operator.
+type ExternalSecretsPushSink struct {
+	// SecretStoreRef references the SecretStore or ClusterSecretStore to push the credentials to.
+	SecretStoreRef SecretStoreReference `json:"secretStoreRef"`
+	// RemoteKey is the name of the secret in the secret store.
+	// +kubebuilder:validation:MinLength=1
+	RemoteKey string `json:"remoteKey"`
+	// RefreshInterval is how often the credentials are pushed again, bounding how long rotated credentials take to reach
+	// the secret store. Defaults to 1m.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// SecretStoreReference references a secret store of the External Secrets Operator.
+type SecretStoreReference struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +kubebuilder:default=SecretStore
+	// +optional
+	Kind string `json:"kind,omitempty"`
 }
 
 type UserClaims struct {
@@ -65,6 +242,13 @@ type UserClaims struct {
 	NatsLimits *NatsLimits `json:"natsLimits,omitempty"`
 	// +optional
 	UserLimits *UserLimits `json:"userLimits,omitempty"`
+	// +optional
+	BearerToken bool `json:"bearerToken,omitempty"`
+	// +optional
+	AllowedConnectionTypes []ConnectionType `json:"allowedConnectionTypes,omitempty"`
+	// Tags are the tags of the user JWT, including the encoded tag values.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
 }
 
 // UserStatus defines the observed state of User.
@@ -77,12 +261,34 @@ type UserStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 	// +optional
 	Claims UserClaims `json:"claims,omitempty"`
+	// ClaimsHash is a hash of the user JWT claims, excluding the JWT ID and issue time.
+	// +optional
+	ClaimsHash string `json:"claimsHash,omitempty"`
+	// JWT describes the user JWT of the current credentials.
+	// +optional
+	JWT *JWTMetadata `json:"jwt,omitempty"`
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +optional
 	ReconcileTimestamp metav1.Time `json:"reconcileTimestamp,omitempty"`
 	// +optional
 	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// PermissionSets lists the referenced PermissionSets, and their generations, the user was last signed with.
+	// +optional
+	PermissionSets []ObservedPermissionSet `json:"permissionSets,omitempty"`
+	// AccountUserDefaults are the account user defaults the user was last signed with.
+	// +optional
+	AccountUserDefaults *UserDefaults `json:"accountUserDefaults,omitempty"`
+	// PlatformUserDefaults are the NauthDefaults user defaults the user was last signed with.
+	// +optional
+	PlatformUserDefaults *UserPlatformDefaults `json:"platformUserDefaults,omitempty"`
+	// CredentialsExpireAt is when the credentials Secret of a user with spec.credentialsTTL is deleted. Unset once the
+	// Secret is deleted.
+	// +optional
+	CredentialsExpireAt *metav1.Time `json:"credentialsExpireAt,omitempty"`
+	// AccountRef is the Account resolved from spec.accountRef.
+	// +optional
+	AccountRef *AccountRef `json:"accountRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -91,6 +297,7 @@ type UserStatus struct {
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 
 // User is the Schema for the users API.
+// +kubebuilder:validation:XValidation:rule="!has(self.spec.restartTargets) || size(self.metadata.name) <= 63",message="users with restartTargets must have a name of at most 63 characters"
 type User struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -114,10 +321,35 @@ func (u *User) SetLabel(label UserLabel, value string) {
 	u.Labels[string(label)] = value
 }
 
+// GetAccountRef returns the Account of the user. The Account of a spec.accountRef selector is the one recorded in
+// status.accountRef, and has an empty name until it is resolved.
+func (u *User) GetAccountRef() AccountRef {
+	ref := u.Spec.AccountRef
+	if ref == nil {
+		return AccountRef{Name: u.Spec.AccountName, Namespace: u.GetNamespace()}
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = u.GetNamespace()
+	}
+	if ref.Selector == nil {
+		return AccountRef{Name: ref.Name, Namespace: namespace}
+	}
+	if resolved := u.Status.AccountRef; resolved != nil && resolved.Namespace == namespace {
+		return *resolved
+	}
+	return AccountRef{Namespace: namespace}
+}
+
 func (u *User) GetUserSecretName() string {
 	return fmt.Sprintf("%s-nats-user-creds", u.GetName())
 }
 
+// GetRestartAnnotation returns the pod template annotation of the restart targets of the user.
+func (u *User) GetRestartAnnotation() string {
+	return UserAnnotationCredentialsPrefix + u.GetName()
+}
+
 // +kubebuilder:object:root=true
 
 // UserList contains a list of User.
@@ -127,12 +359,28 @@ type UserList struct {
 	Items           []User `json:"items"`
 }
 
+// ConnectionType is a NATS client connection type.
+// +kubebuilder:validation:Enum=STANDARD;WEBSOCKET;LEAFNODE;LEAFNODE_WS;MQTT;MQTT_WS;IN_PROCESS
+type ConnectionType string
+
+const (
+	ConnectionTypeStandard   ConnectionType = "STANDARD"
+	ConnectionTypeWebsocket  ConnectionType = "WEBSOCKET"
+	ConnectionTypeLeafnode   ConnectionType = "LEAFNODE"
+	ConnectionTypeLeafnodeWS ConnectionType = "LEAFNODE_WS"
+	ConnectionTypeMqtt       ConnectionType = "MQTT"
+	ConnectionTypeMqttWS     ConnectionType = "MQTT_WS"
+	ConnectionTypeInProcess  ConnectionType = "IN_PROCESS"
+)
+
 type UserLimits struct {
 	// +optional
 	// Src is a comma separated list of CIDR specifications
 	Src CIDRList `json:"src,omitempty"`
+	// Times restricts the times of day the user can connect. The user can connect at any time when empty.
 	// +optional
 	Times []TimeRange `json:"times,omitempty"`
+	// Locale is the IANA time zone, e.g. Europe/Stockholm, that Times are evaluated in. Defaults to the server time zone.
 	// +optional
 	Locale string `json:"timesLocation,omitempty"`
 }
@@ -126,6 +126,11 @@ func (in *AccountClaims) DeepCopyInto(out *AccountClaims) {
 		*out = new(AccountLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SigningKeys != nil {
 		in, out := &in.SigningKeys, &out.SigningKeys
 		*out = make(SigningKeys, len(*in))
@@ -169,11 +174,35 @@ func (in *AccountClaims) DeepCopyInto(out *AccountClaims) {
 		*out = new(JetStreamLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JetStreamTieredLimits != nil {
+		in, out := &in.JetStreamTieredLimits, &out.JetStreamTieredLimits
+		*out = make(JetStreamTieredLimits, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.NatsLimits != nil {
 		in, out := &in.NatsLimits, &out.NatsLimits
 		*out = new(NatsLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make(SubjectMappings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPermissions != nil {
+		in, out := &in.DefaultPermissions, &out.DefaultPermissions
+		*out = new(Permissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trace != nil {
+		in, out := &in.Trace, &out.Trace
+		*out = new(MessageTrace)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountClaims.
@@ -295,6 +324,11 @@ func (in *AccountExportRule) DeepCopyInto(out *AccountExportRule) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllowWildcard != nil {
+		in, out := &in.AllowWildcard, &out.AllowWildcard
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountExportRule.
@@ -530,6 +564,38 @@ func (in *AccountImportStatus) DeepCopy() *AccountImportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountJetStreamUsage) DeepCopyInto(out *AccountJetStreamUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountJetStreamUsage.
+func (in *AccountJetStreamUsage) DeepCopy() *AccountJetStreamUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountJetStreamUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountKeySecrets) DeepCopyInto(out *AccountKeySecrets) {
+	*out = *in
+	out.Root = in.Root
+	out.Signing = in.Signing
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountKeySecrets.
+func (in *AccountKeySecrets) DeepCopy() *AccountKeySecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountKeySecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountLimits) DeepCopyInto(out *AccountLimits) {
 	*out = *in
@@ -602,6 +668,36 @@ func (in *AccountList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlatformDefaults) DeepCopyInto(out *AccountPlatformDefaults) {
+	*out = *in
+	if in.AccountLimits != nil {
+		in, out := &in.AccountLimits, &out.AccountLimits
+		*out = new(AccountLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JetStreamLimits != nil {
+		in, out := &in.JetStreamLimits, &out.JetStreamLimits
+		*out = new(JetStreamLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NatsLimits != nil {
+		in, out := &in.NatsLimits, &out.NatsLimits
+		*out = new(NatsLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlatformDefaults.
+func (in *AccountPlatformDefaults) DeepCopy() *AccountPlatformDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlatformDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountRef) DeepCopyInto(out *AccountRef) {
 	*out = *in
@@ -617,6 +713,145 @@ func (in *AccountRef) DeepCopy() *AccountRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPendingChanges) DeepCopyInto(out *AccountPendingChanges) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPendingChanges.
+func (in *AccountPendingChanges) DeepCopy() *AccountPendingChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPendingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountResync) DeepCopyInto(out *AccountResync) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountResync.
+func (in *AccountResync) DeepCopy() *AccountResync {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountResync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountResync) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountResyncList) DeepCopyInto(out *AccountResyncList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccountResync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountResyncList.
+func (in *AccountResyncList) DeepCopy() *AccountResyncList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountResyncList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountResyncList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountResyncSpec) DeepCopyInto(out *AccountResyncSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BatchInterval != nil {
+		in, out := &in.BatchInterval, &out.BatchInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountResyncSpec.
+func (in *AccountResyncSpec) DeepCopy() *AccountResyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountResyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountResyncStatus) DeepCopyInto(out *AccountResyncStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBatchAt != nil {
+		in, out := &in.LastBatchAt, &out.LastBatchAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountResyncStatus.
+func (in *AccountResyncStatus) DeepCopy() *AccountResyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountResyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountSpec) DeepCopyInto(out *AccountSpec) {
 	*out = *in
@@ -625,6 +860,18 @@ func (in *AccountSpec) DeepCopyInto(out *AccountSpec) {
 		*out = new(NatsClusterRef)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagValues != nil {
+		in, out := &in.TagValues, &out.TagValues
+		*out = make(TagValues, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.JetStreamEnabled != nil {
 		in, out := &in.JetStreamEnabled, &out.JetStreamEnabled
 		*out = new(bool)
@@ -662,11 +909,50 @@ func (in *AccountSpec) DeepCopyInto(out *AccountSpec) {
 		*out = new(JetStreamLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JetStreamTieredLimits != nil {
+		in, out := &in.JetStreamTieredLimits, &out.JetStreamTieredLimits
+		*out = make(JetStreamTieredLimits, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.NatsLimits != nil {
 		in, out := &in.NatsLimits, &out.NatsLimits
 		*out = new(NatsLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]SubjectMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPermissions != nil {
+		in, out := &in.DefaultPermissions, &out.DefaultPermissions
+		*out = new(Permissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trace != nil {
+		in, out := &in.Trace, &out.Trace
+		*out = new(MessageTrace)
+		**out = **in
+	}
+	if in.UserDefaults != nil {
+		in, out := &in.UserDefaults, &out.UserDefaults
+		*out = new(UserDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeySecrets != nil {
+		in, out := &in.KeySecrets, &out.KeySecrets
+		*out = new(AccountKeySecrets)
+		**out = **in
+	}
+	if in.ObservedClaims != nil {
+		in, out := &in.ObservedClaims, &out.ObservedClaims
+		*out = make([]AccountClaimField, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountSpec.
@@ -687,6 +973,11 @@ func (in *AccountStatus) DeepCopyInto(out *AccountStatus) {
 		*out = new(AccountClaims)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Adoptions != nil {
 		in, out := &in.Adoptions, &out.Adoptions
 		*out = new(AccountAdoptions)
@@ -700,6 +991,16 @@ func (in *AccountStatus) DeepCopyInto(out *AccountStatus) {
 		}
 	}
 	in.ReconcileTimestamp.DeepCopyInto(&out.ReconcileTimestamp)
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(AccountUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(AccountPendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountStatus.
@@ -713,208 +1014,1378 @@ func (in *AccountStatus) DeepCopy() *AccountStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in CIDRList) DeepCopyInto(out *CIDRList) {
-	{
-		in := &in
-		*out = make(CIDRList, len(*in))
-		copy(*out, *in)
+func (in *AccountUsage) DeepCopyInto(out *AccountUsage) {
+	*out = *in
+	if in.JetStream != nil {
+		in, out := &in.JetStream, &out.JetStream
+		*out = new(AccountJetStreamUsage)
+		**out = **in
 	}
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CIDRList.
-func (in CIDRList) DeepCopy() CIDRList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountUsage.
+func (in *AccountUsage) DeepCopy() *AccountUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(CIDRList)
+	out := new(AccountUsage)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Export) DeepCopyInto(out *Export) {
+func (in *AppTenant) DeepCopyInto(out *AppTenant) {
 	*out = *in
-	if in.Revocations != nil {
-		in, out := &in.Revocations, &out.Revocations
-		*out = make(RevocationList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Latency != nil {
-		in, out := &in.Latency, &out.Latency
-		*out = new(ServiceLatency)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Export.
-func (in *Export) DeepCopy() *Export {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppTenant.
+func (in *AppTenant) DeepCopy() *AppTenant {
 	if in == nil {
 		return nil
 	}
-	out := new(Export)
+	out := new(AppTenant)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AppTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Exports) DeepCopyInto(out *Exports) {
-	{
-		in := &in
-		*out = make(Exports, len(*in))
+func (in *AppTenantList) DeepCopyInto(out *AppTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AppTenant, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(Export)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exports.
-func (in Exports) DeepCopy() Exports {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppTenantList.
+func (in *AppTenantList) DeepCopy() *AppTenantList {
 	if in == nil {
 		return nil
 	}
-	out := new(Exports)
+	out := new(AppTenantList)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Import) DeepCopyInto(out *Import) {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AppTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppTenantSpec) DeepCopyInto(out *AppTenantSpec) {
+	*out = *in
+	if in.Account != nil {
+		in, out := &in.Account, &out.Account
+		*out = new(AccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]AppTenantUserRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppTenantSpec.
+func (in *AppTenantSpec) DeepCopy() *AppTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AppTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppTenantStatus) DeepCopyInto(out *AppTenantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UserNames != nil {
+		in, out := &in.UserNames, &out.UserNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppTenantStatus.
+func (in *AppTenantStatus) DeepCopy() *AppTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AppTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BearerCredentials) DeepCopyInto(out *BearerCredentials) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BearerCredentials.
+func (in *BearerCredentials) DeepCopy() *BearerCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(BearerCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in CIDRList) DeepCopyInto(out *CIDRList) {
+	{
+		in := &in
+		*out = make(CIDRList, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CIDRList.
+func (in CIDRList) DeepCopy() CIDRList {
+	if in == nil {
+		return nil
+	}
+	out := new(CIDRList)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccountDefaults) DeepCopyInto(out *ClusterAccountDefaults) {
+	*out = *in
+	if in.AccountLimits != nil {
+		in, out := &in.AccountLimits, &out.AccountLimits
+		*out = new(AccountLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JetStreamLimits != nil {
+		in, out := &in.JetStreamLimits, &out.JetStreamLimits
+		*out = new(JetStreamLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NatsLimits != nil {
+		in, out := &in.NatsLimits, &out.NatsLimits
+		*out = new(NatsLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccountDefaults.
+func (in *ClusterAccountDefaults) DeepCopy() *ClusterAccountDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccountDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSink) DeepCopyInto(out *CredentialsSink) {
+	*out = *in
+	if in.ExternalSecretsPush != nil {
+		in, out := &in.ExternalSecretsPush, &out.ExternalSecretsPush
+		*out = new(ExternalSecretsPushSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSink.
+func (in *CredentialsSink) DeepCopy() *CredentialsSink {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenySubjects) DeepCopyInto(out *DenySubjects) {
+	*out = *in
+	if in.Pub != nil {
+		in, out := &in.Pub, &out.Pub
+		*out = make(StringList, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sub != nil {
+		in, out := &in.Sub, &out.Sub
+		*out = make(StringList, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenySubjects.
+func (in *DenySubjects) DeepCopy() *DenySubjects {
+	if in == nil {
+		return nil
+	}
+	out := new(DenySubjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Export) DeepCopyInto(out *Export) {
+	*out = *in
+	if in.Revocations != nil {
+		in, out := &in.Revocations, &out.Revocations
+		*out = make(RevocationList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(ServiceLatency)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Export.
+func (in *Export) DeepCopy() *Export {
+	if in == nil {
+		return nil
+	}
+	out := new(Export)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Exports) DeepCopyInto(out *Exports) {
+	{
+		in := &in
+		*out = make(Exports, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Export)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exports.
+func (in Exports) DeepCopy() Exports {
+	if in == nil {
+		return nil
+	}
+	out := new(Exports)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretsPushSink) DeepCopyInto(out *ExternalSecretsPushSink) {
+	*out = *in
+	out.SecretStoreRef = in.SecretStoreRef
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretsPushSink.
+func (in *ExternalSecretsPushSink) DeepCopy() *ExternalSecretsPushSink {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretsPushSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinalizerDefaults) DeepCopyInto(out *FinalizerDefaults) {
+	*out = *in
+	if in.Account != nil {
+		in, out := &in.Account, &out.Account
+		*out = new(FinalizerPolicy)
+		**out = **in
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(FinalizerPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinalizerDefaults.
+func (in *FinalizerDefaults) DeepCopy() *FinalizerDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(FinalizerDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinalizerPolicy) DeepCopyInto(out *FinalizerPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinalizerPolicy.
+func (in *FinalizerPolicy) DeepCopy() *FinalizerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FinalizerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Import) DeepCopyInto(out *Import) {
+	*out = *in
+	out.AccountRef = in.AccountRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Import.
+func (in *Import) DeepCopy() *Import {
+	if in == nil {
+		return nil
+	}
+	out := new(Import)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Imports) DeepCopyInto(out *Imports) {
+	{
+		in := &in
+		*out = make(Imports, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Import)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Imports.
+func (in Imports) DeepCopy() Imports {
+	if in == nil {
+		return nil
+	}
+	out := new(Imports)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Info) DeepCopyInto(out *Info) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Info.
+func (in *Info) DeepCopy() *Info {
+	if in == nil {
+		return nil
+	}
+	out := new(Info)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTMetadata) DeepCopyInto(out *JWTMetadata) {
+	*out = *in
+	in.IssuedAt.DeepCopyInto(&out.IssuedAt)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTMetadata.
+func (in *JWTMetadata) DeepCopy() *JWTMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStreamLimits) DeepCopyInto(out *JetStreamLimits) {
+	*out = *in
+	if in.MemoryStorage != nil {
+		in, out := &in.MemoryStorage, &out.MemoryStorage
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskStorage != nil {
+		in, out := &in.DiskStorage, &out.DiskStorage
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Consumer != nil {
+		in, out := &in.Consumer, &out.Consumer
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxAckPending != nil {
+		in, out := &in.MaxAckPending, &out.MaxAckPending
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryMaxStreamBytes != nil {
+		in, out := &in.MemoryMaxStreamBytes, &out.MemoryMaxStreamBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskMaxStreamBytes != nil {
+		in, out := &in.DiskMaxStreamBytes, &out.DiskMaxStreamBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxBytesRequired != nil {
+		in, out := &in.MaxBytesRequired, &out.MaxBytesRequired
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamLimits.
+func (in *JetStreamLimits) DeepCopy() *JetStreamLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStreamLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in JetStreamTieredLimits) DeepCopyInto(out *JetStreamTieredLimits) {
+	{
+		in := &in
+		*out = make(JetStreamTieredLimits, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamTieredLimits.
+func (in JetStreamTieredLimits) DeepCopy() JetStreamTieredLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStreamTieredLimits)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPolicy) DeepCopyInto(out *KeyPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPolicy.
+func (in *KeyPolicy) DeepCopy() *KeyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTrace) DeepCopyInto(out *MessageTrace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTrace.
+func (in *MessageTrace) DeepCopy() *MessageTrace {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTrace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NKeyUser) DeepCopyInto(out *NKeyUser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NKeyUser.
+func (in *NKeyUser) DeepCopy() *NKeyUser {
+	if in == nil {
+		return nil
+	}
+	out := new(NKeyUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsCluster) DeepCopyInto(out *NatsCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsCluster.
+func (in *NatsCluster) DeepCopy() *NatsCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NatsCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsClusterHealthStatus) DeepCopyInto(out *NatsClusterHealthStatus) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]NatsServerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.JetStreamCapacity != nil {
+		in, out := &in.JetStreamCapacity, &out.JetStreamCapacity
+		*out = new(NatsClusterJetStreamCapacity)
+		**out = **in
+	}
+	in.LastCheckedAt.DeepCopyInto(&out.LastCheckedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterHealthStatus.
+func (in *NatsClusterHealthStatus) DeepCopy() *NatsClusterHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsClusterHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsClusterJetStreamCapacity) DeepCopyInto(out *NatsClusterJetStreamCapacity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterJetStreamCapacity.
+func (in *NatsClusterJetStreamCapacity) DeepCopy() *NatsClusterJetStreamCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsClusterJetStreamCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsClusterList) DeepCopyInto(out *NatsClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NatsCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterList.
+func (in *NatsClusterList) DeepCopy() *NatsClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NatsClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsClusterRef) DeepCopyInto(out *NatsClusterRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterRef.
+func (in *NatsClusterRef) DeepCopy() *NatsClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsClusterSpec) DeepCopyInto(out *NatsClusterSpec) {
+	*out = *in
+	if in.URLFrom != nil {
+		in, out := &in.URLFrom, &out.URLFrom
+		*out = new(URLFromReference)
+		**out = **in
+	}
+	if in.OperatorSigningKeySecretRef != nil {
+		in, out := &in.OperatorSigningKeySecretRef, &out.OperatorSigningKeySecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.Signer != nil {
+		in, out := &in.Signer, &out.Signer
+		*out = new(OperatorSignerSpec)
+		**out = **in
+	}
+	if in.OperatorSigningKeys != nil {
+		in, out := &in.OperatorSigningKeys, &out.OperatorSigningKeys
+		*out = new(OperatorSigningKeysSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.SystemAccountUserCredsSecretRef = in.SystemAccountUserCredsSecretRef
+	if in.SystemAccount != nil {
+		in, out := &in.SystemAccount, &out.SystemAccount
+		*out = new(SystemAccountSpec)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(NatsTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObserveInterval != nil {
+		in, out := &in.ObserveInterval, &out.ObserveInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResolverStrategy != nil {
+		in, out := &in.ResolverStrategy, &out.ResolverStrategy
+		*out = new(ResolverStrategySpec)
+		**out = **in
+	}
+	if in.ResolverConfig != nil {
+		in, out := &in.ResolverConfig, &out.ResolverConfig
+		*out = new(ResolverConfigSpec)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccountDefaults != nil {
+		in, out := &in.AccountDefaults, &out.AccountDefaults
+		*out = new(ClusterAccountDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterSpec.
+func (in *NatsClusterSpec) DeepCopy() *NatsClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsClusterStatus) DeepCopyInto(out *NatsClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ReconcileTimestamp.DeepCopyInto(&out.ReconcileTimestamp)
+	if in.OperatorSigningKeyRotation != nil {
+		in, out := &in.OperatorSigningKeyRotation, &out.OperatorSigningKeyRotation
+		*out = new(OperatorSigningKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetiredOperatorSigningKeys != nil {
+		in, out := &in.RetiredOperatorSigningKeys, &out.RetiredOperatorSigningKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(NatsClusterHealthStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterStatus.
+func (in *NatsClusterStatus) DeepCopy() *NatsClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsLimits) DeepCopyInto(out *NatsLimits) {
+	*out = *in
+	if in.Subs != nil {
+		in, out := &in.Subs, &out.Subs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsLimits.
+func (in *NatsLimits) DeepCopy() *NatsLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsServerStatus) DeepCopyInto(out *NatsServerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsServerStatus.
+func (in *NatsServerStatus) DeepCopy() *NatsServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatsTLSSpec) DeepCopyInto(out *NatsTLSSpec) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.CertSecretRef != nil {
+		in, out := &in.CertSecretRef, &out.CertSecretRef
+		*out = new(TLSSecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsTLSSpec.
+func (in *NatsTLSSpec) DeepCopy() *NatsTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NatsTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthDefaults) DeepCopyInto(out *NauthDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthDefaults.
+func (in *NauthDefaults) DeepCopy() *NauthDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthDefaultsList) DeepCopyInto(out *NauthDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NauthDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthDefaultsList.
+func (in *NauthDefaultsList) DeepCopy() *NauthDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthDefaultsSpec) DeepCopyInto(out *NauthDefaultsSpec) {
+	*out = *in
+	if in.Account != nil {
+		in, out := &in.Account, &out.Account
+		*out = new(AccountPlatformDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(UserPlatformDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredLabels != nil {
+		in, out := &in.RequiredLabels, &out.RequiredLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Finalizers != nil {
+		in, out := &in.Finalizers, &out.Finalizers
+		*out = new(FinalizerDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyPolicy != nil {
+		in, out := &in.KeyPolicy, &out.KeyPolicy
+		*out = new(KeyPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthDefaultsSpec.
+func (in *NauthDefaultsSpec) DeepCopy() *NauthDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthPolicy) DeepCopyInto(out *NauthPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthPolicy.
+func (in *NauthPolicy) DeepCopy() *NauthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthPolicyList) DeepCopyInto(out *NauthPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NauthPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthPolicyList.
+func (in *NauthPolicyList) DeepCopy() *NauthPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthPolicySpec) DeepCopyInto(out *NauthPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccountSelector != nil {
+		in, out := &in.AccountSelector, &out.AccountSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exports != nil {
+		in, out := &in.Exports, &out.Exports
+		*out = new(SubjectPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = new(SubjectPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthPolicySpec.
+func (in *NauthPolicySpec) DeepCopy() *NauthPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthQuota) DeepCopyInto(out *NauthQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthQuota.
+func (in *NauthQuota) DeepCopy() *NauthQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthQuotaList) DeepCopyInto(out *NauthQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NauthQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthQuotaList.
+func (in *NauthQuotaList) DeepCopy() *NauthQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthQuotaSpec) DeepCopyInto(out *NauthQuotaSpec) {
+	*out = *in
+	if in.MaxAccounts != nil {
+		in, out := &in.MaxAccounts, &out.MaxAccounts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxUsers != nil {
+		in, out := &in.MaxUsers, &out.MaxUsers
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxJetStreamDiskStorage != nil {
+		in, out := &in.MaxJetStreamDiskStorage, &out.MaxJetStreamDiskStorage
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthQuotaSpec.
+func (in *NauthQuotaSpec) DeepCopy() *NauthQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NauthQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NauthVersion) DeepCopyInto(out *NauthVersion) {
 	*out = *in
-	out.AccountRef = in.AccountRef
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Import.
-func (in *Import) DeepCopy() *Import {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthVersion.
+func (in *NauthVersion) DeepCopy() *NauthVersion {
 	if in == nil {
 		return nil
 	}
-	out := new(Import)
+	out := new(NauthVersion)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthVersion) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Imports) DeepCopyInto(out *Imports) {
-	{
-		in := &in
-		*out = make(Imports, len(*in))
+func (in *NauthVersionList) DeepCopyInto(out *NauthVersionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NauthVersion, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(Import)
-				**out = **in
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Imports.
-func (in Imports) DeepCopy() Imports {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthVersionList.
+func (in *NauthVersionList) DeepCopy() *NauthVersionList {
 	if in == nil {
 		return nil
 	}
-	out := new(Imports)
+	out := new(NauthVersionList)
 	in.DeepCopyInto(out)
-	return *out
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NauthVersionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Info) DeepCopyInto(out *Info) {
+func (in *NauthVersionStatus) DeepCopyInto(out *NauthVersionStatus) {
 	*out = *in
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.APIVersions != nil {
+		in, out := &in.APIVersions, &out.APIVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProviderTypes != nil {
+		in, out := &in.ProviderTypes, &out.ProviderTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Info.
-func (in *Info) DeepCopy() *Info {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NauthVersionStatus.
+func (in *NauthVersionStatus) DeepCopy() *NauthVersionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Info)
+	out := new(NauthVersionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JetStreamLimits) DeepCopyInto(out *JetStreamLimits) {
+func (in *ObservedPermissionSet) DeepCopyInto(out *ObservedPermissionSet) {
 	*out = *in
-	if in.MemoryStorage != nil {
-		in, out := &in.MemoryStorage, &out.MemoryStorage
-		*out = new(int64)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedPermissionSet.
+func (in *ObservedPermissionSet) DeepCopy() *ObservedPermissionSet {
+	if in == nil {
+		return nil
 	}
-	if in.DiskStorage != nil {
-		in, out := &in.DiskStorage, &out.DiskStorage
-		*out = new(int64)
-		**out = **in
+	out := new(ObservedPermissionSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorSignerSpec) DeepCopyInto(out *OperatorSignerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSignerSpec.
+func (in *OperatorSignerSpec) DeepCopy() *OperatorSignerSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Streams != nil {
-		in, out := &in.Streams, &out.Streams
-		*out = new(int64)
-		**out = **in
+	out := new(OperatorSignerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorSigningKeyRotationStatus) DeepCopyInto(out *OperatorSigningKeyRotationStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
 	}
-	if in.Consumer != nil {
-		in, out := &in.Consumer, &out.Consumer
-		*out = new(int64)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSigningKeyRotationStatus.
+func (in *OperatorSigningKeyRotationStatus) DeepCopy() *OperatorSigningKeyRotationStatus {
+	if in == nil {
+		return nil
 	}
-	if in.MaxAckPending != nil {
-		in, out := &in.MaxAckPending, &out.MaxAckPending
-		*out = new(int64)
-		**out = **in
+	out := new(OperatorSigningKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorSigningKeysSpec) DeepCopyInto(out *OperatorSigningKeysSpec) {
+	*out = *in
+	if in.SecretLabels != nil {
+		in, out := &in.SecretLabels, &out.SecretLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.MemoryMaxStreamBytes != nil {
-		in, out := &in.MemoryMaxStreamBytes, &out.MemoryMaxStreamBytes
-		*out = new(int64)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSigningKeysSpec.
+func (in *OperatorSigningKeysSpec) DeepCopy() *OperatorSigningKeysSpec {
+	if in == nil {
+		return nil
 	}
-	if in.DiskMaxStreamBytes != nil {
-		in, out := &in.DiskMaxStreamBytes, &out.DiskMaxStreamBytes
-		*out = new(int64)
-		**out = **in
+	out := new(OperatorSigningKeysSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Permission) DeepCopyInto(out *Permission) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make(StringList, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxBytesRequired != nil {
-		in, out := &in.MaxBytesRequired, &out.MaxBytesRequired
-		*out = new(bool)
-		**out = **in
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make(StringList, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamLimits.
-func (in *JetStreamLimits) DeepCopy() *JetStreamLimits {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permission.
+func (in *Permission) DeepCopy() *Permission {
 	if in == nil {
 		return nil
 	}
-	out := new(JetStreamLimits)
+	out := new(Permission)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatsCluster) DeepCopyInto(out *NatsCluster) {
+func (in *PermissionSet) DeepCopyInto(out *PermissionSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsCluster.
-func (in *NatsCluster) DeepCopy() *NatsCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSet.
+func (in *PermissionSet) DeepCopy() *PermissionSet {
 	if in == nil {
 		return nil
 	}
-	out := new(NatsCluster)
+	out := new(PermissionSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NatsCluster) DeepCopyObject() runtime.Object {
+func (in *PermissionSet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -922,31 +2393,31 @@ func (in *NatsCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatsClusterList) DeepCopyInto(out *NatsClusterList) {
+func (in *PermissionSetList) DeepCopyInto(out *PermissionSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NatsCluster, len(*in))
+		*out = make([]PermissionSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterList.
-func (in *NatsClusterList) DeepCopy() *NatsClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSetList.
+func (in *PermissionSetList) DeepCopy() *PermissionSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(NatsClusterList)
+	out := new(PermissionSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NatsClusterList) DeepCopyObject() runtime.Object {
+func (in *PermissionSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -954,138 +2425,198 @@ func (in *NatsClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatsClusterRef) DeepCopyInto(out *NatsClusterRef) {
+func (in *PermissionSetReference) DeepCopyInto(out *PermissionSetReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterRef.
-func (in *NatsClusterRef) DeepCopy() *NatsClusterRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSetReference.
+func (in *PermissionSetReference) DeepCopy() *PermissionSetReference {
 	if in == nil {
 		return nil
 	}
-	out := new(NatsClusterRef)
+	out := new(PermissionSetReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatsClusterSpec) DeepCopyInto(out *NatsClusterSpec) {
+func (in *PermissionSetSpec) DeepCopyInto(out *PermissionSetSpec) {
 	*out = *in
-	if in.URLFrom != nil {
-		in, out := &in.URLFrom, &out.URLFrom
-		*out = new(URLFromReference)
+	in.Permissions.DeepCopyInto(&out.Permissions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSetSpec.
+func (in *PermissionSetSpec) DeepCopy() *PermissionSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Permissions) DeepCopyInto(out *Permissions) {
+	*out = *in
+	in.Pub.DeepCopyInto(&out.Pub)
+	in.Sub.DeepCopyInto(&out.Sub)
+	if in.Resp != nil {
+		in, out := &in.Resp, &out.Resp
+		*out = new(ResponsePermission)
 		**out = **in
 	}
-	out.OperatorSigningKeySecretRef = in.OperatorSigningKeySecretRef
-	out.SystemAccountUserCredsSecretRef = in.SystemAccountUserCredsSecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterSpec.
-func (in *NatsClusterSpec) DeepCopy() *NatsClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permissions.
+func (in *Permissions) DeepCopy() *Permissions {
 	if in == nil {
 		return nil
 	}
-	out := new(NatsClusterSpec)
+	out := new(Permissions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatsClusterStatus) DeepCopyInto(out *NatsClusterStatus) {
+func (in *ReferenceGrant) DeepCopyInto(out *ReferenceGrant) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrant.
+func (in *ReferenceGrant) DeepCopy() *ReferenceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantFrom) DeepCopyInto(out *ReferenceGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantFrom.
+func (in *ReferenceGrantFrom) DeepCopy() *ReferenceGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantList) DeepCopyInto(out *ReferenceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReferenceGrant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.ReconcileTimestamp.DeepCopyInto(&out.ReconcileTimestamp)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsClusterStatus.
-func (in *NatsClusterStatus) DeepCopy() *NatsClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantList.
+func (in *ReferenceGrantList) DeepCopy() *ReferenceGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(NatsClusterStatus)
+	out := new(ReferenceGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatsLimits) DeepCopyInto(out *NatsLimits) {
+func (in *ReferenceGrantSpec) DeepCopyInto(out *ReferenceGrantSpec) {
 	*out = *in
-	if in.Subs != nil {
-		in, out := &in.Subs, &out.Subs
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Payload != nil {
-		in, out := &in.Payload, &out.Payload
-		*out = new(int64)
-		**out = **in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ReferenceGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]ReferenceGrantTo, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatsLimits.
-func (in *NatsLimits) DeepCopy() *NatsLimits {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantSpec.
+func (in *ReferenceGrantSpec) DeepCopy() *ReferenceGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NatsLimits)
+	out := new(ReferenceGrantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Permission) DeepCopyInto(out *Permission) {
+func (in *ReferenceGrantTo) DeepCopyInto(out *ReferenceGrantTo) {
 	*out = *in
-	if in.Allow != nil {
-		in, out := &in.Allow, &out.Allow
-		*out = make(StringList, len(*in))
-		copy(*out, *in)
-	}
-	if in.Deny != nil {
-		in, out := &in.Deny, &out.Deny
-		*out = make(StringList, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permission.
-func (in *Permission) DeepCopy() *Permission {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantTo.
+func (in *ReferenceGrantTo) DeepCopy() *ReferenceGrantTo {
 	if in == nil {
 		return nil
 	}
-	out := new(Permission)
+	out := new(ReferenceGrantTo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Permissions) DeepCopyInto(out *Permissions) {
+func (in *ResolverConfigSpec) DeepCopyInto(out *ResolverConfigSpec) {
 	*out = *in
-	in.Pub.DeepCopyInto(&out.Pub)
-	in.Sub.DeepCopyInto(&out.Sub)
-	if in.Resp != nil {
-		in, out := &in.Resp, &out.Resp
-		*out = new(ResponsePermission)
-		**out = **in
+	out.OperatorJWTConfigMapRef = in.OperatorJWTConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverConfigSpec.
+func (in *ResolverConfigSpec) DeepCopy() *ResolverConfigSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(ResolverConfigSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permissions.
-func (in *Permissions) DeepCopy() *Permissions {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverStrategySpec) DeepCopyInto(out *ResolverStrategySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverStrategySpec.
+func (in *ResolverStrategySpec) DeepCopy() *ResolverStrategySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Permissions)
+	out := new(ResolverStrategySpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1105,6 +2636,21 @@ func (in *ResponsePermission) DeepCopy() *ResponsePermission {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartTarget) DeepCopyInto(out *RestartTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartTarget.
+func (in *RestartTarget) DeepCopy() *RestartTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in RevocationList) DeepCopyInto(out *RevocationList) {
 	{
@@ -1141,6 +2687,21 @@ func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreReference) DeepCopyInto(out *SecretStoreReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreReference.
+func (in *SecretStoreReference) DeepCopy() *SecretStoreReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceLatency) DeepCopyInto(out *ServiceLatency) {
 	*out = *in
@@ -1215,6 +2776,125 @@ func (in StringList) DeepCopy() StringList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectMapping) DeepCopyInto(out *SubjectMapping) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]WeightedDestination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMapping.
+func (in *SubjectMapping) DeepCopy() *SubjectMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in SubjectMappings) DeepCopyInto(out *SubjectMappings) {
+	{
+		in := &in
+		*out = make(SubjectMappings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMappings.
+func (in SubjectMappings) DeepCopy() SubjectMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMappings)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectPolicy) DeepCopyInto(out *SubjectPolicy) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPolicy.
+func (in *SubjectPolicy) DeepCopy() *SubjectPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemAccountSpec) DeepCopyInto(out *SystemAccountSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemAccountSpec.
+func (in *SystemAccountSpec) DeepCopy() *SystemAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in TagValues) DeepCopyInto(out *TagValues) {
+	{
+		in := &in
+		*out = make(TagValues, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagValues.
+func (in TagValues) DeepCopy() TagValues {
+	if in == nil {
+		return nil
+	}
+	out := new(TagValues)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSecretReference) DeepCopyInto(out *TLSSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSecretReference.
+func (in *TLSSecretReference) DeepCopy() *TLSSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in TagList) DeepCopyInto(out *TagList) {
 	{
@@ -1291,6 +2971,26 @@ func (in *User) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserAccountRef) DeepCopyInto(out *UserAccountRef) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserAccountRef.
+func (in *UserAccountRef) DeepCopy() *UserAccountRef {
+	if in == nil {
+		return nil
+	}
+	out := new(UserAccountRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserClaims) DeepCopyInto(out *UserClaims) {
 	*out = *in
@@ -1313,6 +3013,16 @@ func (in *UserClaims) DeepCopyInto(out *UserClaims) {
 		*out = new(UserLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AllowedConnectionTypes != nil {
+		in, out := &in.AllowedConnectionTypes, &out.AllowedConnectionTypes
+		*out = make([]ConnectionType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserClaims.
@@ -1325,6 +3035,35 @@ func (in *UserClaims) DeepCopy() *UserClaims {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDefaults) DeepCopyInto(out *UserDefaults) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(Permissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NatsLimits != nil {
+		in, out := &in.NatsLimits, &out.NatsLimits
+		*out = new(NatsLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDefaults.
+func (in *UserDefaults) DeepCopy() *UserDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserLimits) DeepCopyInto(out *UserLimits) {
 	*out = *in
@@ -1382,9 +3121,51 @@ func (in *UserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserPlatformDefaults) DeepCopyInto(out *UserPlatformDefaults) {
+	*out = *in
+	if in.NatsLimits != nil {
+		in, out := &in.NatsLimits, &out.NatsLimits
+		*out = new(NatsLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DenySubjects != nil {
+		in, out := &in.DenySubjects, &out.DenySubjects
+		*out = new(DenySubjects)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserPlatformDefaults.
+func (in *UserPlatformDefaults) DeepCopy() *UserPlatformDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(UserPlatformDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 	*out = *in
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(UserAccountRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagValues != nil {
+		in, out := &in.TagValues, &out.TagValues
+		*out = make(TagValues, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ExpiresAt != nil {
 		in, out := &in.ExpiresAt, &out.ExpiresAt
 		*out = (*in).DeepCopy()
@@ -1394,6 +3175,11 @@ func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 		*out = new(Permissions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PermissionSetRefs != nil {
+		in, out := &in.PermissionSetRefs, &out.PermissionSetRefs
+		*out = make([]PermissionSetReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.UserLimits != nil {
 		in, out := &in.UserLimits, &out.UserLimits
 		*out = new(UserLimits)
@@ -1404,6 +3190,36 @@ func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 		*out = new(NatsLimits)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AllowedConnectionTypes != nil {
+		in, out := &in.AllowedConnectionTypes, &out.AllowedConnectionTypes
+		*out = make([]ConnectionType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bearer != nil {
+		in, out := &in.Bearer, &out.Bearer
+		*out = new(BearerCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsSink != nil {
+		in, out := &in.CredentialsSink, &out.CredentialsSink
+		*out = new(CredentialsSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsTTL != nil {
+		in, out := &in.CredentialsTTL, &out.CredentialsTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NKey != nil {
+		in, out := &in.NKey, &out.NKey
+		*out = new(NKeyUser)
+		**out = **in
+	}
+	if in.RestartTargets != nil {
+		in, out := &in.RestartTargets, &out.RestartTargets
+		*out = make([]RestartTarget, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSpec.
@@ -1427,7 +3243,36 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 		}
 	}
 	in.Claims.DeepCopyInto(&out.Claims)
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ReconcileTimestamp.DeepCopyInto(&out.ReconcileTimestamp)
+	if in.PermissionSets != nil {
+		in, out := &in.PermissionSets, &out.PermissionSets
+		*out = make([]ObservedPermissionSet, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccountUserDefaults != nil {
+		in, out := &in.AccountUserDefaults, &out.AccountUserDefaults
+		*out = new(UserDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlatformUserDefaults != nil {
+		in, out := &in.PlatformUserDefaults, &out.PlatformUserDefaults
+		*out = new(UserPlatformDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsExpireAt != nil {
+		in, out := &in.CredentialsExpireAt, &out.CredentialsExpireAt
+		*out = (*in).DeepCopy()
+	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
@@ -1439,3 +3284,23 @@ func (in *UserStatus) DeepCopy() *UserStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedDestination) DeepCopyInto(out *WeightedDestination) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedDestination.
+func (in *WeightedDestination) DeepCopy() *WeightedDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedDestination)
+	in.DeepCopyInto(out)
+	return out
+}
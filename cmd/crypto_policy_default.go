@@ -0,0 +1,9 @@
+//go:build !nauth_strict_crypto
+
+package main
+
+import "github.com/WirelessCar/nauth/internal/core"
+
+// buildCryptoPolicy is the crypto policy enforced by this build. Build with the nauth_strict_crypto tag to produce a
+// binary that always runs with the strict policy.
+const buildCryptoPolicy = core.CryptoPolicyDefault
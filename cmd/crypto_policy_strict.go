@@ -0,0 +1,8 @@
+//go:build nauth_strict_crypto
+
+package main
+
+import "github.com/WirelessCar/nauth/internal/core"
+
+// buildCryptoPolicy is the crypto policy enforced by this build. The runtime CRYPTO_POLICY setting cannot relax it.
+const buildCryptoPolicy = core.CryptoPolicyStrict
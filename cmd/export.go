@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/nats"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/nsc"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/signer"
+	"github.com/WirelessCar/nauth/internal/core"
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+const (
+	exportCommand        = "export"
+	decryptBundleCommand = "decrypt-bundle"
+)
+
+// runExport exports the operator signing keys, accounts and users managed by NAuth into an nsc store directory, or
+// into a bundle file encrypted to a public curve key.
+// The Kubernetes connection is configured through KUBECONFIG or the in-cluster service account, the NATS cluster and
+// crypto policy settings use the same environment variables as the manager.
+func runExport(args []string) int {
+	var outputDir string
+	var outputFile string
+	var encryptTo string
+	var namespace string
+	var accountSecretsNamespace string
+	flags := flag.NewFlagSet(exportCommand, flag.ContinueOnError)
+	flags.StringVar(&outputDir, "output-dir", "", "The directory to write the nsc store to, e.g. a mounted volume.")
+	flags.StringVar(&outputFile, "output-file", "", "The file to write the encrypted bundle to, requires --encrypt-to.")
+	flags.StringVar(&encryptTo, "encrypt-to", "", "The public curve key (X...) to encrypt the bundle to. "+
+		"The bundle is opened with the decrypt-bundle command and the seed of the key.")
+	flags.StringVar(&namespace, "namespace", "", "Limits the export to a single namespace. "+
+		"If not specified, all namespaces are exported.")
+	bindAccountSecretsNamespaceFlag(flags, &accountSecretsNamespace)
+	opts := zap.Options{}
+	opts.BindFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	log := ctrl.Log.WithName("export")
+
+	var err error
+	switch {
+	case encryptTo == "" && outputFile == "":
+		err = export(outputDir, domain.Namespace(namespace), domain.Namespace(accountSecretsNamespace))
+	case encryptTo == "" || outputFile == "" || outputDir != "":
+		err = fmt.Errorf("--encrypt-to and --output-file must be used together, without --output-dir")
+	default:
+		err = exportBundle(outputFile, encryptTo, domain.Namespace(namespace), domain.Namespace(accountSecretsNamespace))
+	}
+	if err != nil {
+		log.Error(err, "nsc store export failed")
+		return 1
+	}
+	return 0
+}
+
+// runDecryptBundle extracts the nsc store of a bundle written by the export command.
+func runDecryptBundle(args []string) int {
+	var inputFile string
+	var keyFile string
+	var outputDir string
+	flags := flag.NewFlagSet(decryptBundleCommand, flag.ContinueOnError)
+	flags.StringVar(&inputFile, "input-file", "", "The bundle written by export --encrypt-to.")
+	flags.StringVar(&keyFile, "key-file", "", "The file holding the curve seed (SX...) of the key the bundle was encrypted to.")
+	flags.StringVar(&outputDir, "output-dir", "", "The directory to extract the nsc store to.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := decryptBundle(inputFile, keyFile, outputDir); err != nil {
+		fmt.Fprintf(os.Stderr, "bundle decryption failed: %s\n", err)
+		return 1
+	}
+	fmt.Printf("extracted nsc store to %s\n", outputDir)
+	return 0
+}
+
+func exportBundle(outputFile string, encryptTo string, namespace domain.Namespace, accountSecretsNamespace domain.Namespace) error {
+	// The unencrypted store only exists in a private temporary directory while the bundle is written
+	storeDir, err := os.MkdirTemp("", "nauth-export-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary nsc store directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(storeDir) }()
+
+	if err := export(storeDir, namespace, accountSecretsNamespace); err != nil {
+		return err
+	}
+	bundle, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	if err := nsc.SealBundle(storeDir, encryptTo, bundle); err != nil {
+		_ = bundle.Close()
+		return err
+	}
+	if err := bundle.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle file: %w", err)
+	}
+	fmt.Printf("wrote bundle encrypted to %s to %s\n", encryptTo, outputFile)
+	return nil
+}
+
+func decryptBundle(inputFile string, keyFile string, outputDir string) error {
+	if inputFile == "" || keyFile == "" || outputDir == "" {
+		return fmt.Errorf("--input-file, --key-file and --output-dir are required")
+	}
+	seed, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %w", err)
+	}
+	bundle, err := os.Open(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle file: %w", err)
+	}
+	defer func() { _ = bundle.Close() }()
+	if err := os.MkdirAll(outputDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return nsc.OpenBundle(bundle, bytes.TrimSpace(seed), outputDir)
+}
+
+func export(outputDir string, namespace domain.Namespace, accountSecretsNamespace domain.Namespace) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ctx = ctrl.LoggerInto(ctx, ctrl.Log.WithName("export"))
+
+	store, err := nsc.NewStoreWriter(outputDir)
+	if err != nil {
+		return err
+	}
+	operatorNatsCluster, err := operatorNatsClusterFromEnv()
+	if err != nil {
+		return err
+	}
+	cryptoPolicy, err := resolveCryptoPolicy(os.Getenv("CRYPTO_POLICY"))
+	if err != nil {
+		return fmt.Errorf("invalid CRYPTO_POLICY value: %w", err)
+	}
+	config, err := core.NewConfig(operatorNatsCluster, "", cryptoPolicy,
+		accountSecretsNamespace,
+		core.SecretNameTemplate(strings.TrimSpace(os.Getenv("ACCOUNT_SECRET_NAME_TEMPLATE"))))
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load Kubernetes configuration: %w", err)
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	secretClient := k8s.NewSecretClient(k8sClient)
+	clusterClient := k8s.NewClusterClient(k8sClient, secretClient, k8s.NewConfigMapClient(k8sClient), signer.NewClient())
+
+	exportManager, err := core.NewNscExportManager(
+		k8s.NewAccountClient(k8sClient),
+		k8s.NewUserClient(k8sClient),
+		clusterClient,
+		nats.NewSysClient(),
+		secretClient,
+		config,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create nsc export manager: %w", err)
+	}
+
+	result, err := exportManager.Export(ctx, namespace, store)
+	if result != nil {
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(os.Stderr, "skipped %s\n", skipped)
+		}
+		fmt.Printf("exported %d operators, %d accounts and %d users to %s\n",
+			result.Operators, result.Accounts, result.Users, outputDir)
+	}
+	return err
+}
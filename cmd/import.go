@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/nsc"
+	"github.com/WirelessCar/nauth/internal/core"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+)
+
+const importNscCommand = "import-nsc"
+
+// runImportNsc converts the accounts and users of an nsc store into Account and User manifests together with their
+// Secrets, to migrate accounts managed with nsc to NAuth. No Kubernetes connection is needed, the crypto policy and
+// account secret settings use the same environment variables as the manager.
+func runImportNsc(args []string) int {
+	var storeDir string
+	var operator string
+	var namespace string
+	var observe bool
+	var outputFile string
+	var accountSecretsNamespace string
+	flags := flag.NewFlagSet(importNscCommand, flag.ContinueOnError)
+	flags.StringVar(&storeDir, "store-dir", "", "The nsc directory holding the stores and keys directories, "+
+		"e.g. ~/.local/share/nats/nsc or a directory written by the export command.")
+	flags.StringVar(&operator, "operator", "", "The operator to import. Required when the store holds several operators.")
+	flags.StringVar(&namespace, "namespace", "", "The namespace of the generated resources.")
+	flags.BoolVar(&observe, "observe", false, "Generates Accounts with the observe management policy, so NAuth "+
+		"reads the accounts from NATS instead of pushing new JWTs.")
+	flags.StringVar(&outputFile, "output-file", "", "The file to write the manifests to. "+
+		"If not specified, the manifests are written to stdout.")
+	bindAccountSecretsNamespaceFlag(flags, &accountSecretsNamespace)
+	opts := zap.Options{}
+	opts.BindFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts), zap.WriteTo(os.Stderr)))
+
+	request := nauth.NscImportRequest{
+		Operator:  operator,
+		Namespace: domain.Namespace(namespace),
+		Observe:   observe,
+	}
+	if err := importNsc(storeDir, request, outputFile, domain.Namespace(accountSecretsNamespace)); err != nil {
+		ctrl.Log.WithName("import-nsc").Error(err, "nsc store import failed")
+		return 1
+	}
+	return 0
+}
+
+func importNsc(storeDir string, request nauth.NscImportRequest, outputFile string, accountSecretsNamespace domain.Namespace) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ctx = ctrl.LoggerInto(ctx, ctrl.Log.WithName("import-nsc"))
+
+	if request.Namespace == "" {
+		return fmt.Errorf("--namespace is required")
+	}
+	store, err := nsc.NewStoreReader(storeDir)
+	if err != nil {
+		return err
+	}
+	cryptoPolicy, err := resolveCryptoPolicy(os.Getenv("CRYPTO_POLICY"))
+	if err != nil {
+		return fmt.Errorf("invalid CRYPTO_POLICY value: %w", err)
+	}
+	config, err := core.NewConfig(nil, "", cryptoPolicy,
+		accountSecretsNamespace,
+		core.SecretNameTemplate(strings.TrimSpace(os.Getenv("ACCOUNT_SECRET_NAME_TEMPLATE"))))
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	importManager, err := core.NewNscImportManager(config)
+	if err != nil {
+		return fmt.Errorf("failed to create nsc import manager: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if outputFile != "" {
+		// The manifests hold the account seeds
+		file, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	result, err := importManager.Import(ctx, request, store, k8s.NewManifestWriter(out))
+	if result != nil {
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(os.Stderr, "skipped %s\n", skipped)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+		}
+		fmt.Fprintf(os.Stderr, "imported %d accounts and %d users\n", result.Accounts, result.Users)
+	}
+	return err
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"crypto/fips140"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -24,6 +26,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -32,11 +35,14 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -44,10 +50,18 @@ import (
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
 	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/graph"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/metrics"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/version"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/audit"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/claimhook"
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/nats"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/notify"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/signer"
 	"github.com/WirelessCar/nauth/internal/core"
 	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
 )
 
 var (
@@ -61,8 +75,31 @@ func init() {
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 }
 
+// bindAccountSecretsNamespaceFlag binds the --account-secrets-namespace flag shared by the manager and the subcommands
+// reading or writing account secrets. It defaults to ACCOUNT_SECRETS_NAMESPACE, so the subcommands pick up the same
+// environment as the manager, like the other account secret settings.
+func bindAccountSecretsNamespaceFlag(flags *flag.FlagSet, value *string) {
+	flags.StringVar(value, "account-secrets-namespace", strings.TrimSpace(os.Getenv("ACCOUNT_SECRETS_NAMESPACE")),
+		"Namespace storing the root and signing key seeds of all accounts, instead of the namespace of each Account. "+
+			"Defaults to the ACCOUNT_SECRETS_NAMESPACE environment variable.")
+}
+
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == exportCommand {
+		os.Exit(runExport(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == decryptBundleCommand {
+		os.Exit(runDecryptBundle(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == migrateSecretsCommand {
+		os.Exit(runMigrateSecrets(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == importNscCommand {
+		os.Exit(runImportNsc(os.Args[2:]))
+	}
+
+	startTime := time.Now()
 	var namespace string
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
@@ -71,6 +108,13 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var requeuePolicies controller.RequeuePolicies
+	var maxConcurrentReconciles controller.MaxConcurrentReconciles
+	var disableClusterTargetCache bool
+	var detectImportConflicts bool
+	var shardName, watchNamespaces, watchLabelSelector string
+	var dryRun bool
+	var accountSecretsNamespace string
 	flag.StringVar(&namespace, "namespace", "", "Limits the scope of nauth to a single namespace. "+
 		"If not specified, all namespaces will be watched.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -87,6 +131,28 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics server")
+	flag.Var(&requeuePolicies, "requeue-policy", "Retry policy of failed reconciles as "+
+		"<kind>:baseDelay=<duration>,maxDelay=<duration>,jitter=<fraction>,maxRetries=<count>. "+
+		"Kind is default, account, accountexport, accountimport, user or natscluster. May be repeated.")
+	flag.Var(&maxConcurrentReconciles, "max-concurrent-reconciles", "Number of resources of a kind reconciled in "+
+		"parallel as <kind>=<count>, with the kinds of --requeue-policy. Defaults to 1. May be repeated.")
+	flag.StringVar(&shardName, "shard-name", "", "Name of this instance when Accounts and Users are split across "+
+		"multiple nauth deployments. Reconciled resources are claimed with the nauth.io/shard annotation, resources "+
+		"claimed by another instance are left alone.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "", "Comma separated list of the namespaces of the Accounts "+
+		"and Users reconciled by this instance. If not specified, all namespaces are reconciled.")
+	flag.StringVar(&watchLabelSelector, "watch-label-selector", "", "Label selector of the Accounts and Users "+
+		"reconciled by this instance. If not specified, all Accounts and Users are reconciled.")
+	flag.BoolVar(&dryRun, "dry-run", false, "If set, all resources are reconciled but account JWTs are not uploaded "+
+		"to or deleted from NATS, Secrets are not written and credentials are not pushed to external secret stores. "+
+		"The skipped changes are logged and reported by the events of the resources.")
+	flag.BoolVar(&disableClusterTargetCache, "disable-cluster-target-cache", false,
+		"If set, the system account user creds and operator signing key of a NatsCluster are resolved on every "+
+			"reconcile instead of being kept in memory until the NatsCluster or its Secrets change.")
+	flag.BoolVar(&detectImportConflicts, "detect-import-conflicts", false,
+		"If set, Accounts importing overlapping local subjects of the same type from the same exporting account as "+
+			"another Account are reported in their ImportConflict condition.")
+	bindAccountSecretsNamespaceFlag(flag.CommandLine, &accountSecretsNamespace)
 	opts := zap.Options{
 		Development: true,
 	}
@@ -95,6 +161,16 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	shard, err := controller.NewShard(shardName, watchNamespaces, watchLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid shard configuration")
+		os.Exit(1)
+	}
+	if namespace != "" && len(shard.Namespaces()) > 0 {
+		setupLog.Error(fmt.Errorf("--namespace and --watch-namespaces are mutually exclusive"), "invalid shard configuration")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -155,7 +231,23 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	tracerProvider, err := tracerProviderFromEnv(context.Background())
+	if err != nil {
+		setupLog.Error(err, "failed to set up tracing")
+		os.Exit(1)
+	}
+	restConfig := ctrl.GetConfigOrDie()
+	if tracerProvider != nil {
+		withKubernetesTracing(restConfig, tracerProvider)
+	}
+
+	// Secrets are only watched in the namespace of the operator, where the manager is allowed to list and watch them
+	secretWatchNamespace := namespace
+	if secretWatchNamespace == "" {
+		secretWatchNamespace = operatorNamespace()
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		HealthProbeBindAddress: probeAddr,
@@ -173,12 +265,32 @@ func main() {
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
 
+		// The Secret informer only holds the labelled Secrets of the operator namespace (see k8s.SecretInformerSelector),
+		// so Secrets are read directly from the API server. A cached Get of e.g. an account secret would not find it.
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}},
+		},
 		Cache: func() cache.Options {
-			opts := cache.Options{}
+			opts := cache.Options{
+				ByObject: map[client.Object]cache.ByObject{
+					&corev1.Secret{}: {
+						Label:      k8s.SecretInformerSelector(),
+						Namespaces: map[string]cache.Config{secretWatchNamespace: {}},
+					},
+				},
+			}
 			if namespace != "" {
 				opts.DefaultNamespaces = map[string]cache.Config{
 					namespace: {},
 				}
+			} else if shardNamespaces := shard.Namespaces(); len(shardNamespaces) > 0 {
+				// The operator namespace holds the NatsClusters and account secrets shared by all shards
+				opts.DefaultNamespaces = map[string]cache.Config{
+					operatorNamespace(): {},
+				}
+				for _, shardNamespace := range shardNamespaces {
+					opts.DefaultNamespaces[shardNamespace] = cache.Config{}
+				}
 			}
 			return opts
 		}(),
@@ -188,57 +300,208 @@ func main() {
 		os.Exit(1)
 	}
 
-	var operatorNatsCluster *core.OperatorNatsCluster
-	natsClusterRef := strings.TrimSpace(os.Getenv("NATS_CLUSTER_REF"))
-	natsClusterRefOptional := false
-	if rawOptional, ok := os.LookupEnv("NATS_CLUSTER_REF_OPTIONAL"); ok {
-		natsClusterRefOptional, err = strconv.ParseBool(strings.TrimSpace(rawOptional))
+	operatorNatsCluster, err := operatorNatsClusterFromEnv()
+	if err != nil {
+		setupLog.Error(err, "invalid operator NATS cluster configuration")
+		os.Exit(1)
+	}
+	if operatorNatsCluster != nil {
+		setupLog.Info("manager configured with operator NATS cluster",
+			"natsClusterRef", operatorNatsCluster.ClusterRef, "natsClusterRefOptional", operatorNatsCluster.Optional)
+	}
+	if namespace != "" {
+		setupLog.Info("manager configured to watch and manage resources in a single namespace",
+			"namespace", namespace)
+	} else {
+		namespace = operatorNamespace()
+	}
+	if shard != nil {
+		setupLog.Info("manager configured to reconcile a shard of the Accounts and Users",
+			"shardName", shard.Name(), "watchNamespaces", shard.Namespaces(), "watchLabelSelector", watchLabelSelector)
+	}
+
+	cryptoPolicy, err := resolveCryptoPolicy(os.Getenv("CRYPTO_POLICY"))
+	if err != nil {
+		setupLog.Error(err, "invalid CRYPTO_POLICY value", "CRYPTO_POLICY", os.Getenv("CRYPTO_POLICY"))
+		os.Exit(1)
+	}
+	setupLog.Info("manager configured with crypto policy",
+		"cryptoPolicy", cryptoPolicy, "buildCryptoPolicy", buildCryptoPolicy, "fips140", fips140.Enabled())
+	if cryptoPolicy.IsStrict() && !fips140.Enabled() {
+		setupLog.Info("strict crypto policy is active but the Go FIPS 140-3 mode is not enabled, " +
+			"set GODEBUG=fips140=on to use the FIPS 140-3 validated module")
+	}
+	metrics.ReportCryptoPolicy(string(cryptoPolicy), fips140.Enabled())
+
+	natsConnectionIdleTimeout := nats.DefaultPoolIdleTimeout
+	if rawIdleTimeout, ok := os.LookupEnv("NATS_CONNECTION_IDLE_TIMEOUT"); ok {
+		natsConnectionIdleTimeout, err = time.ParseDuration(strings.TrimSpace(rawIdleTimeout))
 		if err != nil {
-			setupLog.Error(err, "invalid NATS_CLUSTER_REF_OPTIONAL value", "NATS_CLUSTER_REF_OPTIONAL", rawOptional)
+			setupLog.Error(err, "invalid NATS_CONNECTION_IDLE_TIMEOUT value", "NATS_CONNECTION_IDLE_TIMEOUT", rawIdleTimeout)
 			os.Exit(1)
 		}
 	}
-	if natsClusterRef != "" {
-		setupLog.Info("manager configured with operator NATS cluster",
-			"natsClusterRef", natsClusterRef, "natsClusterRefOptional", natsClusterRefOptional)
-		operatorClusterRef, err := parseNatsClusterRef(natsClusterRef)
+	natsJWTPushWindow := nats.DefaultClaimsPushWindow
+	if rawPushWindow, ok := os.LookupEnv("NATS_JWT_PUSH_WINDOW"); ok {
+		natsJWTPushWindow, err = time.ParseDuration(strings.TrimSpace(rawPushWindow))
 		if err != nil {
-			setupLog.Error(err, "invalid NATS_CLUSTER_REF value", "NATS_CLUSTER_REF", natsClusterRef)
+			setupLog.Error(err, "invalid NATS_JWT_PUSH_WINDOW value", "NATS_JWT_PUSH_WINDOW", rawPushWindow)
 			os.Exit(1)
 		}
-		operatorNatsCluster, err = core.NewOperatorNatsCluster(*operatorClusterRef, natsClusterRefOptional)
+	}
+	natsJWTPushRate := nats.DefaultClaimsPushRate
+	if rawPushRate, ok := os.LookupEnv("NATS_JWT_PUSH_RATE"); ok {
+		natsJWTPushRate, err = strconv.ParseFloat(strings.TrimSpace(rawPushRate), 64)
 		if err != nil {
-			setupLog.Error(err, "invalid operator NATS cluster", "natsClusterRef", natsClusterRef)
+			setupLog.Error(err, "invalid NATS_JWT_PUSH_RATE value", "NATS_JWT_PUSH_RATE", rawPushRate)
 			os.Exit(1)
 		}
 	}
-	if namespace != "" {
-		setupLog.Info("manager configured to watch and manage resources in a single namespace",
-			"namespace", namespace)
-	} else {
-		controllerNamespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	accountUsageInterval := controller.DefaultAccountUsageInterval
+	if rawUsageInterval, ok := os.LookupEnv("ACCOUNT_USAGE_INTERVAL"); ok {
+		accountUsageInterval, err = time.ParseDuration(strings.TrimSpace(rawUsageInterval))
 		if err != nil {
-			setupLog.Error(err, "failed to read operator namespace")
+			setupLog.Error(err, "invalid ACCOUNT_USAGE_INTERVAL value", "ACCOUNT_USAGE_INTERVAL", rawUsageInterval)
 			os.Exit(1)
 		}
-		namespace = string(controllerNamespace)
+	}
+	accountUsageWarningThreshold := controller.DefaultAccountUsageWarningThreshold
+	if rawWarningThreshold, ok := os.LookupEnv("ACCOUNT_USAGE_WARNING_THRESHOLD"); ok {
+		accountUsageWarningThreshold, err = strconv.Atoi(strings.TrimSpace(rawWarningThreshold))
+		if err == nil && (accountUsageWarningThreshold < 0 || accountUsageWarningThreshold > 100) {
+			err = fmt.Errorf("must be a percentage from 0 to 100")
+		}
+		if err != nil {
+			setupLog.Error(err, "invalid ACCOUNT_USAGE_WARNING_THRESHOLD value",
+				"ACCOUNT_USAGE_WARNING_THRESHOLD", rawWarningThreshold)
+			os.Exit(1)
+		}
+	}
+
+	if err := requeuePolicies.Validate(); err != nil {
+		setupLog.Error(err, "invalid --requeue-policy value")
+		os.Exit(1)
+	}
+
+	if accountSecretsNamespace != "" {
+		setupLog.Info("manager configured to store account secrets in a single namespace",
+			"accountSecretsNamespace", accountSecretsNamespace)
 	}
 
-	config, err := core.NewConfig(operatorNatsCluster, domain.Namespace(namespace))
+	config, err := core.NewConfig(operatorNatsCluster, domain.Namespace(namespace), cryptoPolicy,
+		domain.Namespace(accountSecretsNamespace), core.SecretNameTemplate(strings.TrimSpace(os.Getenv("ACCOUNT_SECRET_NAME_TEMPLATE"))))
 	if err != nil {
 		setupLog.Error(err, "invalid configuration")
 		os.Exit(1)
 	}
 
-	secretClient := k8s.NewSecretClient(mgr.GetClient())
+	auditRecorder, err := auditRecorderFromEnv()
+	if err != nil {
+		setupLog.Error(err, "invalid audit configuration")
+		os.Exit(1)
+	}
+
+	claimsMutator, err := claimsMutatorFromEnv()
+	if err != nil {
+		setupLog.Error(err, "invalid claims mutation webhook configuration")
+		os.Exit(1)
+	}
+
+	notifier, err := notifierFromEnv()
+	if err != nil {
+		setupLog.Error(err, "invalid notification configuration")
+		os.Exit(1)
+	}
+	eventRecorder := mgr.GetEventRecorder
+	if dryRun {
+		setupLog.Info("manager configured to run without writing to NATS or Secrets, " +
+			"audit records and notifications are disabled")
+		// Changes that are only simulated must not reach the audit log or external systems
+		auditRecorder = audit.Discard
+		notifier = nil
+		eventRecorder = func(name string) events.EventRecorder {
+			return controller.NewDryRunEventRecorder(mgr.GetEventRecorder(name))
+		}
+	}
+	var lifecycleNotifier outbound.Notifier = notify.Discard
+	if notifier != nil {
+		if err := mgr.Add(notifier); err != nil {
+			setupLog.Error(err, "unable to add notifier to manager")
+			os.Exit(1)
+		}
+		lifecycleNotifier = notifier
+	}
+
+	var secretClient outbound.SecretClient = k8s.NewSecretClient(mgr.GetClient())
+	var credentialsSink outbound.CredentialsSink = k8s.NewPushSecretClient(mgr.GetClient())
+	var workloadRestarter outbound.WorkloadRestarter = k8s.NewWorkloadClient(mgr.GetClient())
+	if dryRun {
+		secretClient = k8s.NewDryRunSecretClient(secretClient)
+		credentialsSink = k8s.NewDryRunCredentialsSink()
+		workloadRestarter = k8s.NewDryRunWorkloadRestarter()
+	}
 	configMapClient := k8s.NewConfigMapClient(mgr.GetClient())
 	accountClient := k8s.NewAccountClient(mgr.GetClient())
-	clusterClient := k8s.NewClusterClient(mgr.GetClient(), secretClient, configMapClient)
-	natsSysClient := nats.NewSysClient()
-	natsAccClient := nats.NewAccountClient()
+	referenceGrantClient := k8s.NewReferenceGrantClient(mgr.GetClient())
+	nauthDefaultsClient := k8s.NewNauthDefaultsClient(mgr.GetClient())
+	clusterClient := k8s.NewClusterClient(mgr.GetClient(), secretClient, configMapClient, signer.NewClient())
+	claimsPushBatcher, err := nats.NewClaimsPushBatcher(natsJWTPushWindow, natsJWTPushRate)
+	if err != nil {
+		setupLog.Error(err, "failed to create NATS JWT push batcher")
+		os.Exit(1)
+	}
+	natsSysPool, err := nats.NewSysConnectionPool(
+		natsConnectionIdleTimeout, nats.DefaultPoolHealthCheckInterval, claimsPushBatcher)
+	if err != nil {
+		setupLog.Error(err, "failed to create NATS connection pool")
+		os.Exit(1)
+	}
+	if err := mgr.Add(natsSysPool); err != nil {
+		setupLog.Error(err, "unable to add NATS connection pool to manager")
+		os.Exit(1)
+	}
+	if name := strings.TrimSpace(os.Getenv("NATS_JWT_PUSH_HANDOFF_CONFIGMAP")); name != "" && !dryRun {
+		claimsPushHandoff, err := nats.NewClaimsPushHandoff(claimsPushBatcher,
+			k8s.NewClaimsPushConfigMapStore(mgr.GetClient(), domain.NewNamespacedName(operatorNamespace(), name)),
+			nats.DefaultClaimsPushHandoffInterval, nats.DefaultClaimsPushHandoffMaxAge)
+		if err != nil {
+			setupLog.Error(err, "failed to create NATS JWT push handoff")
+			os.Exit(1)
+		}
+		if err := mgr.Add(claimsPushHandoff); err != nil {
+			setupLog.Error(err, "unable to add NATS JWT push handoff to manager")
+			os.Exit(1)
+		}
+	}
+	natsSysClient, natsAccClient, err := withNatsFaults(natsSysPool, nats.NewAccountClient())
+	if err != nil {
+		setupLog.Error(err, "failed to configure NATS fault injection")
+		os.Exit(1)
+	}
+	if dryRun {
+		natsSysClient = nats.NewDryRunSysClient(natsSysClient)
+	}
+	if tracerProvider != nil {
+		natsSysClient = nats.NewTracingSysClient(natsSysClient, tracerProvider)
+		natsAccClient = nats.NewTracingAccountClient(natsAccClient, tracerProvider)
+	}
+
+	var clusterReader outbound.ClusterReader = clusterClient
+	// In dry run the Secrets referenced by NatsClusters are not labelled, so their changes would not be observed
+	if disableClusterTargetCache || dryRun {
+		setupLog.Info("manager configured to resolve NatsCluster targets on every reconcile")
+	} else {
+		cachingClusterClient := k8s.NewCachingClusterClient(mgr.GetClient(), mgr.GetCache(), clusterClient,
+			secretClient, secretWatchNamespace)
+		if err := mgr.Add(cachingClusterClient); err != nil {
+			setupLog.Error(err, "unable to add NatsCluster target cache to manager")
+			os.Exit(1)
+		}
+		clusterReader = cachingClusterClient
+	}
 
 	clusterManager, err := core.NewClusterManager(
-		clusterClient,
+		clusterReader,
 		natsSysClient,
 		config,
 	)
@@ -252,6 +515,9 @@ func main() {
 		natsAccClient,
 		accountClient,
 		secretClient,
+		auditRecorder,
+		claimsMutator,
+		config,
 	)
 	if err != nil {
 		setupLog.Error(err, "failed to create account manager")
@@ -264,9 +530,14 @@ func main() {
 		accountManager,
 		clusterManager,
 		accountClient,
-		mgr.GetEventRecorder("account-controller"),
+		referenceGrantClient,
+		nauthDefaultsClient,
+		controller.NewNotifyingEventRecorder(eventRecorder("account-controller"), lifecycleNotifier),
+		shard,
+		dryRun,
 	)
-	if err = accountReconciler.SetupWithManager(mgr); err != nil {
+	if err = accountReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccount),
+		maxConcurrentReconciles.For(controller.RequeueKindAccount)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Account")
 		os.Exit(1)
 	}
@@ -277,7 +548,8 @@ func main() {
 		mgr.GetScheme(),
 		accountExportManager,
 	)
-	if err = accountExportReconciler.SetupWithManager(mgr); err != nil {
+	if err = accountExportReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccountExport),
+		maxConcurrentReconciles.For(controller.RequeueKindAccountExport)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AccountExport")
 		os.Exit(1)
 	}
@@ -287,20 +559,32 @@ func main() {
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		accountImportManager,
+		referenceGrantClient,
 	)
-	if err = accountImportReconciler.SetupWithManager(mgr); err != nil {
+	if err = accountImportReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccountImport),
+		maxConcurrentReconciles.For(controller.RequeueKindAccountImport)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AccountImport")
 		os.Exit(1)
 	}
 
-	userManager := core.NewUserManager(accountManager, secretClient)
+	userManager, err := core.NewUserManager(accountManager, secretClient, credentialsSink, workloadRestarter,
+		k8s.NewPermissionSetClient(mgr.GetClient()), accountClient,
+		nauthDefaultsClient, auditRecorder, config)
+	if err != nil {
+		setupLog.Error(err, "failed to create user manager")
+		os.Exit(1)
+	}
 	userReconciler := controller.NewUserReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		userManager,
-		mgr.GetEventRecorder("user-controller"),
+		referenceGrantClient,
+		nauthDefaultsClient,
+		controller.NewNotifyingEventRecorder(eventRecorder("user-controller"), lifecycleNotifier),
+		shard,
 	)
-	if err = userReconciler.SetupWithManager(mgr); err != nil {
+	if err = userReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindUser),
+		maxConcurrentReconciles.For(controller.RequeueKindUser)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "User")
 		os.Exit(1)
 	}
@@ -310,12 +594,111 @@ func main() {
 		mgr.GetScheme(),
 		clusterManager,
 		clusterClient,
-		mgr.GetEventRecorder("natscluster-controller"),
+		eventRecorder("natscluster-controller"),
 	)
-	if err = natsClusterReconciler.SetupWithManager(mgr); err != nil {
+	if err = natsClusterReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindNatsCluster),
+		maxConcurrentReconciles.For(controller.RequeueKindNatsCluster)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NatsCluster")
 		os.Exit(1)
 	}
+
+	resolverConfigManager, err := core.NewResolverConfigManager(natsSysClient)
+	if err != nil {
+		setupLog.Error(err, "failed to create resolver config manager")
+		os.Exit(1)
+	}
+	resolverConfigReconciler := controller.NewResolverConfigReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		resolverConfigManager,
+		clusterClient,
+		eventRecorder("resolverconfig-controller"),
+	)
+	if err = resolverConfigReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindNatsCluster),
+		maxConcurrentReconciles.For(controller.RequeueKindNatsCluster)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ResolverConfig")
+		os.Exit(1)
+	}
+
+	operatorKeyRotationReconciler := controller.NewOperatorKeyRotationReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		clusterClient,
+		eventRecorder("operatorkeyrotation-controller"),
+	)
+	if err = operatorKeyRotationReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindNatsCluster),
+		maxConcurrentReconciles.For(controller.RequeueKindNatsCluster)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OperatorKeyRotation")
+		os.Exit(1)
+	}
+
+	natsClusterHealthReconciler := controller.NewNatsClusterHealthReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		clusterManager,
+		clusterClient,
+		eventRecorder("natsclusterhealth-controller"),
+	)
+	if err = natsClusterHealthReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindNatsCluster),
+		maxConcurrentReconciles.For(controller.RequeueKindNatsCluster)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NatsClusterHealth")
+		os.Exit(1)
+	}
+
+	if accountUsageInterval > 0 {
+		accountUsageManager, err := core.NewAccountUsageManager(natsSysClient)
+		if err != nil {
+			setupLog.Error(err, "failed to create account usage manager")
+			os.Exit(1)
+		}
+		accountUsageReconciler := controller.NewAccountUsageReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			clusterManager,
+			accountUsageManager,
+			accountUsageInterval,
+			accountUsageWarningThreshold,
+			eventRecorder("accountusage-controller"),
+		)
+		if err = accountUsageReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccount),
+			maxConcurrentReconciles.For(controller.RequeueKindAccount)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AccountUsage")
+			os.Exit(1)
+		}
+	}
+
+	if detectImportConflicts {
+		importConflictReconciler := controller.NewImportConflictReconciler(
+			mgr.GetClient(),
+			eventRecorder("importconflict-controller"),
+		)
+		if err = importConflictReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccount),
+			maxConcurrentReconciles.For(controller.RequeueKindAccount)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ImportConflict")
+			os.Exit(1)
+		}
+	}
+
+	accountResyncReconciler := controller.NewAccountResyncReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		eventRecorder("accountresync-controller"),
+	)
+	if err = accountResyncReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccount),
+		maxConcurrentReconciles.For(controller.RequeueKindAccount)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AccountResync")
+		os.Exit(1)
+	}
+	appTenantReconciler := controller.NewAppTenantReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		eventRecorder("apptenant-controller"),
+	)
+	if err = appTenantReconciler.SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccount),
+		maxConcurrentReconciles.For(controller.RequeueKindAccount)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AppTenant")
+		os.Exit(1)
+	}
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -324,6 +707,40 @@ func main() {
 		}
 	}
 
+	capabilities := core.NewCapabilities(os.Getenv("OPERATOR_VERSION"), config, startTime)
+	if err := mgr.AddMetricsServerExtraHandler(version.Path, version.NewHandler(capabilities)); err != nil {
+		setupLog.Error(err, "unable to add version handler to metrics server")
+		os.Exit(1)
+	}
+	accountGraphManager, err := core.NewAccountGraphManager(accountClient)
+	if err != nil {
+		setupLog.Error(err, "failed to create account graph manager")
+		os.Exit(1)
+	}
+	if err := mgr.AddMetricsServerExtraHandler(graph.Path, graph.NewHandler(accountGraphManager)); err != nil {
+		setupLog.Error(err, "unable to add account graph handler to metrics server")
+		os.Exit(1)
+	}
+	versionPublisher, err := version.NewPublisher(k8s.NewNauthVersionClient(mgr.GetClient(), mgr.GetAPIReader()), capabilities)
+	if err != nil {
+		setupLog.Error(err, "failed to create version publisher")
+		os.Exit(1)
+	}
+	if err := mgr.Add(versionPublisher); err != nil {
+		setupLog.Error(err, "unable to add version publisher to manager")
+		os.Exit(1)
+	}
+	var startupReportWriter outbound.StartupReportWriter
+	if name := strings.TrimSpace(os.Getenv("STARTUP_REPORT_CONFIGMAP")); name != "" {
+		startupReportWriter = k8s.NewStartupReportConfigMapWriter(mgr.GetClient(),
+			domain.NewNamespacedName(operatorNamespace(), name))
+	}
+	startupReporter := controller.NewStartupReporter(mgr.GetClient(), clusterManager, accountManager, startupReportWriter)
+	if err := mgr.Add(startupReporter); err != nil {
+		setupLog.Error(err, "unable to add startup reporter to manager")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -334,12 +751,53 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if tracerProvider != nil {
+		// Export the spans still buffered before exiting
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+			setupLog.Error(err, "failed to flush traces")
+		}
+		cancel()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
+// operatorNamespace returns the namespace the operator runs in, exiting when it cannot be read.
+func operatorNamespace() string {
+	controllerNamespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		setupLog.Error(err, "failed to read operator namespace")
+		os.Exit(1)
+	}
+	return string(controllerNamespace)
+}
+
+// operatorNatsClusterFromEnv reads the operator NATS cluster from NATS_CLUSTER_REF and NATS_CLUSTER_REF_OPTIONAL.
+// It returns nil when no operator NATS cluster is configured.
+func operatorNatsClusterFromEnv() (*core.OperatorNatsCluster, error) {
+	natsClusterRef := strings.TrimSpace(os.Getenv("NATS_CLUSTER_REF"))
+	natsClusterRefOptional := false
+	if rawOptional, ok := os.LookupEnv("NATS_CLUSTER_REF_OPTIONAL"); ok {
+		var err error
+		natsClusterRefOptional, err = strconv.ParseBool(strings.TrimSpace(rawOptional))
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS_CLUSTER_REF_OPTIONAL value %q: %w", rawOptional, err)
+		}
+	}
+	if natsClusterRef == "" {
+		return nil, nil
+	}
+	operatorClusterRef, err := parseNatsClusterRef(natsClusterRef)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS_CLUSTER_REF value %q: %w", natsClusterRef, err)
+	}
+	return core.NewOperatorNatsCluster(*operatorClusterRef, natsClusterRefOptional)
+}
+
 func parseNatsClusterRef(refStr string) (*nauth.ClusterRef, error) {
 	parts := strings.Split(refStr, "/")
 	if len(parts) != 2 {
@@ -359,3 +817,113 @@ func parseNatsClusterRef(refStr string) (*nauth.ClusterRef, error) {
 
 	return &result, nil
 }
+
+// auditRecorderFromEnv creates the audit recorder of the sink selected by AUDIT_SINK: log writes records to stdout,
+// nats and jetstream publish them to AUDIT_NATS_SUBJECT at AUDIT_NATS_URL, authenticating with AUDIT_NATS_CREDS_FILE.
+// Auditing is disabled when AUDIT_SINK is empty.
+func auditRecorderFromEnv() (outbound.AuditRecorder, error) {
+	var sink audit.Sink
+	switch auditSink := strings.TrimSpace(os.Getenv("AUDIT_SINK")); auditSink {
+	case "":
+		return audit.Discard, nil
+	case "log":
+		sink = audit.NewWriterSink(os.Stdout)
+	case "nats", "jetstream":
+		subject := strings.TrimSpace(os.Getenv("AUDIT_NATS_SUBJECT"))
+		if subject == "" {
+			subject = audit.DefaultNatsSubject
+		}
+		natsSink, err := audit.NewNatsSink(strings.TrimSpace(os.Getenv("AUDIT_NATS_URL")),
+			strings.TrimSpace(os.Getenv("AUDIT_NATS_CREDS_FILE")), subject, auditSink == "jetstream")
+		if err != nil {
+			return nil, err
+		}
+		sink = natsSink
+	default:
+		return nil, fmt.Errorf("invalid AUDIT_SINK value %q, expected log, nats or jetstream", auditSink)
+	}
+	setupLog.Info("manager configured with audit sink", "auditSink", os.Getenv("AUDIT_SINK"))
+	return audit.NewRecorder(sink)
+}
+
+// claimsMutatorFromEnv creates the claims mutation webhook CLAIMS_MUTATION_WEBHOOK_URL, called with
+// CLAIMS_MUTATION_WEBHOOK_AUTHORIZATION as the Authorization header and failing after CLAIMS_MUTATION_WEBHOOK_TIMEOUT.
+// Returns nil when no webhook is configured.
+func claimsMutatorFromEnv() (outbound.AccountClaimsMutator, error) {
+	webhookURL := strings.TrimSpace(os.Getenv("CLAIMS_MUTATION_WEBHOOK_URL"))
+	if webhookURL == "" {
+		return nil, nil
+	}
+	timeout := claimhook.DefaultTimeout
+	if rawTimeout, ok := os.LookupEnv("CLAIMS_MUTATION_WEBHOOK_TIMEOUT"); ok {
+		var err error
+		if timeout, err = time.ParseDuration(strings.TrimSpace(rawTimeout)); err != nil {
+			return nil, fmt.Errorf("invalid CLAIMS_MUTATION_WEBHOOK_TIMEOUT value %q: %w", rawTimeout, err)
+		}
+	}
+	webhook, err := claimhook.NewWebhook(webhookURL,
+		strings.TrimSpace(os.Getenv("CLAIMS_MUTATION_WEBHOOK_AUTHORIZATION")), timeout)
+	if err != nil {
+		return nil, err
+	}
+	setupLog.Info("manager configured with claims mutation webhook", "url", webhookURL, "timeout", timeout)
+	return webhook, nil
+}
+
+// notifierFromEnv creates the notifier of the configured notification sinks: NOTIFICATION_WEBHOOK_URL posts JSON
+// notifications, with NOTIFICATION_WEBHOOK_AUTHORIZATION as the Authorization header, NOTIFICATION_SLACK_WEBHOOK_URL
+// posts Slack messages and NOTIFICATION_NATS_URL publishes JSON notifications to NOTIFICATION_NATS_SUBJECT,
+// authenticating with NOTIFICATION_NATS_CREDS_FILE. Returns nil when no sink is configured.
+func notifierFromEnv() (*notify.Notifier, error) {
+	var sinks []notify.Sink
+	if webhookURL := strings.TrimSpace(os.Getenv("NOTIFICATION_WEBHOOK_URL")); webhookURL != "" {
+		sink, err := notify.NewWebhookSink(webhookURL, strings.TrimSpace(os.Getenv("NOTIFICATION_WEBHOOK_AUTHORIZATION")))
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if slackURL := strings.TrimSpace(os.Getenv("NOTIFICATION_SLACK_WEBHOOK_URL")); slackURL != "" {
+		sink, err := notify.NewSlackSink(slackURL)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if natsURL := strings.TrimSpace(os.Getenv("NOTIFICATION_NATS_URL")); natsURL != "" {
+		subject := strings.TrimSpace(os.Getenv("NOTIFICATION_NATS_SUBJECT"))
+		if subject == "" {
+			subject = notify.DefaultNatsSubject
+		}
+		sink, err := notify.NewNatsSink(natsURL, strings.TrimSpace(os.Getenv("NOTIFICATION_NATS_CREDS_FILE")), subject)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	sinkNames := make([]string, 0, len(sinks))
+	for _, sink := range sinks {
+		sinkNames = append(sinkNames, sink.Name())
+	}
+	setupLog.Info("manager configured with notification sinks", "notificationSinks", sinkNames)
+	return notify.NewNotifier(sinks...)
+}
+
+// resolveCryptoPolicy combines the runtime CRYPTO_POLICY setting with the policy enforced by the build.
+// A strict build can never be relaxed to the default policy at runtime.
+func resolveCryptoPolicy(value string) (core.CryptoPolicy, error) {
+	policy, err := core.ParseCryptoPolicy(value)
+	if err != nil {
+		return "", err
+	}
+	if buildCryptoPolicy.IsStrict() && !policy.IsStrict() {
+		if strings.TrimSpace(value) != "" {
+			return "", fmt.Errorf("crypto policy %q is not allowed, this build enforces %q", policy, buildCryptoPolicy)
+		}
+		return buildCryptoPolicy, nil
+	}
+	return policy, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/core"
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+const migrateSecretsCommand = "migrate-secrets"
+
+// runMigrateSecrets renames the account secrets managed by NAuth to the names of the configured secret name template.
+// The Kubernetes connection is configured through KUBECONFIG or the in-cluster service account, the crypto policy and
+// account secret settings use the same environment variables as the manager.
+func runMigrateSecrets(args []string) int {
+	var namespace string
+	var accountSecretsNamespace string
+	flags := flag.NewFlagSet(migrateSecretsCommand, flag.ContinueOnError)
+	flags.StringVar(&namespace, "namespace", "", "Limits the migration to the Accounts of a single namespace. "+
+		"If not specified, the Accounts of all namespaces are migrated.")
+	bindAccountSecretsNamespaceFlag(flags, &accountSecretsNamespace)
+	opts := zap.Options{}
+	opts.BindFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if err := migrateSecrets(domain.Namespace(namespace), domain.Namespace(accountSecretsNamespace)); err != nil {
+		ctrl.Log.WithName("migrate-secrets").Error(err, "account secret migration failed")
+		return 1
+	}
+	return 0
+}
+
+func migrateSecrets(namespace domain.Namespace, accountSecretsNamespace domain.Namespace) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ctx = ctrl.LoggerInto(ctx, ctrl.Log.WithName("migrate-secrets"))
+
+	cryptoPolicy, err := resolveCryptoPolicy(os.Getenv("CRYPTO_POLICY"))
+	if err != nil {
+		return fmt.Errorf("invalid CRYPTO_POLICY value: %w", err)
+	}
+	config, err := core.NewConfig(nil, "", cryptoPolicy,
+		accountSecretsNamespace,
+		core.SecretNameTemplate(strings.TrimSpace(os.Getenv("ACCOUNT_SECRET_NAME_TEMPLATE"))))
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load Kubernetes configuration: %w", err)
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	migrationManager, err := core.NewSecretMigrationManager(k8s.NewAccountClient(k8sClient), k8s.NewSecretClient(k8sClient), config)
+	if err != nil {
+		return fmt.Errorf("failed to create secret migration manager: %w", err)
+	}
+
+	result, err := migrationManager.Migrate(ctx, namespace)
+	if result != nil {
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(os.Stderr, "skipped %s\n", skipped)
+		}
+		fmt.Printf("renamed the secrets of %d of %d accounts to %s\n", result.Migrated, result.Accounts,
+			config.AccountSecretNameTemplate)
+	}
+	return err
+}
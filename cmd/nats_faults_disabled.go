@@ -0,0 +1,18 @@
+//go:build !nauth_fault_injection
+
+package main
+
+import (
+	"os"
+
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+)
+
+// withNatsFaults returns the NATS clients unchanged. Build with the nauth_fault_injection tag to inject the faults
+// configured by NATS_FAULT_INJECTION.
+func withNatsFaults(sysClient outbound.NatsSysClient, accountClient outbound.NatsAccountClient) (outbound.NatsSysClient, outbound.NatsAccountClient, error) {
+	if _, ok := os.LookupEnv("NATS_FAULT_INJECTION"); ok {
+		setupLog.Info("ignoring NATS_FAULT_INJECTION, the binary is built without the nauth_fault_injection tag")
+	}
+	return sysClient, accountClient, nil
+}
@@ -0,0 +1,26 @@
+//go:build nauth_fault_injection
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/nats"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+)
+
+// withNatsFaults wraps the NATS clients to inject the faults configured by NATS_FAULT_INJECTION, for soak tests of
+// test builds. Release builds are built without the nauth_fault_injection tag and never inject faults.
+func withNatsFaults(sysClient outbound.NatsSysClient, accountClient outbound.NatsAccountClient) (outbound.NatsSysClient, outbound.NatsAccountClient, error) {
+	rawFaults, ok := os.LookupEnv("NATS_FAULT_INJECTION")
+	if !ok {
+		return sysClient, accountClient, nil
+	}
+	faults, err := nats.ParseFaultConfig(rawFaults)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid NATS_FAULT_INJECTION value: %w", err)
+	}
+	setupLog.Info("manager configured to inject NATS faults", "faults", rawFaults)
+	return nats.NewFaultInjectingSysClient(sysClient, faults), nats.NewFaultInjectingAccountClient(accountClient, faults), nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// nauthctl inspects the resources managed by NAuth and performs break-glass operations during incidents.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/nauthctl"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/nats"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/signer"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+)
+
+const usage = `Usage: nauthctl <command> [flags] [namespace/name]
+
+Commands:
+  account jwt <account>     Print the decoded account JWT as stored in NATS
+  account repush <account>  Push the account JWT to NATS again on the next reconcile
+  account revoke <account>  Revoke the activation tokens of --importer for the export named --export
+  user jwt <user>           Print the decoded user JWT
+  user creds <user>         Print the NATS credentials file of the user
+  validate                  Check that account and user Secrets match their labels
+
+Resources are given as namespace/name, or as name together with --namespace.
+The Kubernetes connection is configured through KUBECONFIG or ~/.kube/config.
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	command, args := splitCommand(args)
+
+	var namespace string
+	var natsClusterRef string
+	var exportName string
+	var importer string
+	flags := flag.NewFlagSet("nauthctl "+command, flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	flags.StringVar(&namespace, "namespace", "", "The namespace of the resource, or the namespace to validate. "+
+		"If not specified, validate checks all namespaces.")
+	flags.StringVar(&natsClusterRef, "nats-cluster", os.Getenv("NATS_CLUSTER_REF"),
+		"The NatsCluster (namespace/name) used for accounts without spec.natsClusterRef. Defaults to NATS_CLUSTER_REF.")
+	flags.StringVar(&exportName, "export", "", "The name of the export to revoke activation tokens of.")
+	flags.StringVar(&importer, "importer", "", "The account whose activation tokens are revoked: an account public key, "+
+		"namespace/name of an Account, or \"*\" for all accounts.")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	switch command {
+	case "account jwt", "account repush", "account revoke", "user jwt", "user creds", "validate":
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	commands, err := newCommands(out)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "account jwt":
+		accountRef, err := resourceRef(flags.Args(), namespace)
+		if err != nil {
+			return err
+		}
+		var defaultClusterRef *nauth.ClusterRef
+		if natsClusterRef != "" {
+			clusterRef, err := nauth.NewClusterRef(strings.TrimSpace(natsClusterRef))
+			if err != nil {
+				return fmt.Errorf("invalid NATS cluster reference: %w", err)
+			}
+			defaultClusterRef = &clusterRef
+		}
+		return commands.AccountJWT(ctx, accountRef, defaultClusterRef)
+	case "account repush":
+		accountRef, err := resourceRef(flags.Args(), namespace)
+		if err != nil {
+			return err
+		}
+		return commands.RepushAccount(ctx, accountRef)
+	case "account revoke":
+		accountRef, err := resourceRef(flags.Args(), namespace)
+		if err != nil {
+			return err
+		}
+		if exportName == "" || importer == "" {
+			return errors.New("account revoke requires --export and --importer")
+		}
+		return commands.RevokeActivations(ctx, accountRef, exportName, importer)
+	case "user jwt":
+		userRef, err := resourceRef(flags.Args(), namespace)
+		if err != nil {
+			return err
+		}
+		return commands.UserJWT(ctx, userRef)
+	case "user creds":
+		userRef, err := resourceRef(flags.Args(), namespace)
+		if err != nil {
+			return err
+		}
+		return commands.UserCreds(ctx, userRef)
+	case "validate":
+		if flags.NArg() > 0 {
+			return fmt.Errorf("validate does not take arguments")
+		}
+		return commands.ValidateSecrets(ctx, domain.Namespace(namespace))
+	}
+	return nil
+}
+
+// splitCommand splits args into the command, e.g. "account jwt", and the remaining flags and arguments.
+func splitCommand(args []string) (string, []string) {
+	var words []string
+	for len(args) > 0 && len(words) < 2 && !strings.HasPrefix(args[0], "-") {
+		words = append(words, args[0])
+		args = args[1:]
+		if words[0] == "validate" {
+			break
+		}
+	}
+	return strings.Join(words, " "), args
+}
+
+func resourceRef(args []string, namespace string) (domain.NamespacedName, error) {
+	if len(args) != 1 {
+		return domain.NamespacedName{}, errors.New("expected exactly one resource as namespace/name")
+	}
+	if strings.Contains(args[0], "/") {
+		return domain.ParseNamespacedName(args[0])
+	}
+	if namespace == "" {
+		return domain.NamespacedName{}, fmt.Errorf("resource %q has no namespace, use namespace/name or --namespace", args[0])
+	}
+	result := domain.NewNamespacedName(namespace, args[0])
+	return result, result.Validate()
+}
+
+func newCommands(out io.Writer) (*nauthctl.Commands, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Kubernetes configuration: %w", err)
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	secretClient := k8s.NewSecretClient(k8sClient)
+	clusterClient := k8s.NewClusterClient(k8sClient, secretClient, k8s.NewConfigMapClient(k8sClient), signer.NewClient())
+	return nauthctl.NewCommands(k8sClient, clusterClient, nats.NewSysClient(), out)
+}
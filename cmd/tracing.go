@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+)
+
+// tracerProviderFromEnv sets up the global tracer provider to export the spans of reconciles through OTLP over gRPC
+// when OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set. The exporter, sampler and resource are
+// configured with the standard OTEL_* environment variables, the service name defaults to nauth. Returns nil when
+// tracing is not configured or OTEL_SDK_DISABLED is true.
+func tracerProviderFromEnv(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	if rawDisabled, ok := os.LookupEnv("OTEL_SDK_DISABLED"); ok {
+		disabled, err := strconv.ParseBool(strings.TrimSpace(rawDisabled))
+		if err != nil {
+			return nil, fmt.Errorf("invalid OTEL_SDK_DISABLED value %q: %w", rawDisabled, err)
+		}
+		if disabled {
+			return nil, nil
+		}
+	}
+	endpoint := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"))
+	if endpoint == "" {
+		endpoint = strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
+	}
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	// Attributes detected later take precedence, so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the default
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", "nauth")),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	setupLog.Info("manager configured to export traces", "endpoint", endpoint)
+	return tracerProvider, nil
+}
+
+// withKubernetesTracing records the requests to the Kubernetes API server made during a traced reconcile. Requests of
+// the informers, e.g. long-running watches, are not part of a trace and are left out.
+func withKubernetesTracing(config *rest.Config, tracerProvider trace.TracerProvider) {
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return otelhttp.NewTransport(rt,
+			otelhttp.WithTracerProvider(tracerProvider),
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return trace.SpanContextFromContext(r.Context()).IsValid()
+			}),
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return "kubernetes " + r.Method
+			}))
+	})
+}
@@ -23,12 +23,17 @@ import (
 	"math/rand/v2"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,7 +43,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -56,7 +60,11 @@ type AccountReconciler struct {
 	manager        inbound.AccountManager
 	clusterManager inbound.ClusterManager
 	accountReader  k8s.AccountReader
+	grantReader    k8s.ReferenceGrantReader
+	defaultsReader outbound.NauthDefaultsReader
 	reporter       *statusReporter
+	shard          *Shard
+	dryRun         bool
 }
 
 func NewAccountReconciler(
@@ -65,7 +73,11 @@ func NewAccountReconciler(
 	manager inbound.AccountManager,
 	clusterManager inbound.ClusterManager,
 	accountReader k8s.AccountReader,
+	grantReader k8s.ReferenceGrantReader,
+	defaultsReader outbound.NauthDefaultsReader,
 	recorder events.EventRecorder,
+	shard *Shard,
+	dryRun bool,
 ) *AccountReconciler {
 	return &AccountReconciler{
 		kubernetes:     newKubernetesClient(k8sClient),
@@ -73,7 +85,11 @@ func NewAccountReconciler(
 		manager:        manager,
 		clusterManager: clusterManager,
 		accountReader:  accountReader,
+		grantReader:    grantReader,
+		defaultsReader: defaultsReader,
 		reporter:       newStatusReporter(k8sClient, recorder),
+		shard:          shard,
+		dryRun:         dryRun,
 	}
 }
 
@@ -81,7 +97,12 @@ func NewAccountReconciler(
 // +kubebuilder:rbac:groups=nauth.io,resources=accounts/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nauth.io,resources=accounts/finalizers,verbs=update
 // +kubebuilder:rbac:groups=nauth.io,resources=natsclusters,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=users,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=nauth.io,resources=referencegrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthdefaults,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
@@ -104,6 +125,14 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	if owned, err := r.ownedByShard(ctx, natsAccount); !owned {
+		return ctrl.Result{}, err
+	}
+
+	if natsAccount.Spec.Paused {
+		return r.reporter.paused(ctx, natsAccount)
+	}
+
 	accountClusterRef, err := toNAuthClusterRef(natsAccount.Spec.NatsClusterRef, natsAccount.Namespace)
 	if err != nil {
 		return r.reporter.error(ctx, natsAccount, err)
@@ -115,16 +144,21 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	managementPolicy := natsAccount.GetLabel(v1alpha1.AccountLabelManagementPolicy)
 	accountRef := toAccountReference(natsAccount, *clusterTarget)
+	nauthDefaults, err := r.defaultsReader.GetNauthDefaults(ctx)
+	if err != nil {
+		return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to get NauthDefaults: %w", err))
+	}
+	finalizer := accountFinalizerPolicy(nauthDefaults)
 
 	// ACCOUNT MARKED FOR DELETION
 	if !natsAccount.DeletionTimestamp.IsZero() {
-		return r.deleteAccount(ctx, natsAccount, accountRef, managementPolicy)
+		return r.deleteAccount(ctx, natsAccount, accountRef, managementPolicy, finalizer)
 	}
 
 	// RECONCILE ACCOUNT - Set status & base properties
 
-	// Add finalizer if not present
-	if added := controllerutil.AddFinalizer(natsAccount, finalizerAccount); added {
+	// Apply the finalizer of the NauthDefaults
+	if changed := finalizer.apply(natsAccount); changed {
 		if err := r.kubernetes.Update(ctx, natsAccount); err != nil {
 			log.Info("Failed to add finalizer", "name", natsAccount.Name, "error", err)
 			return ctrl.Result{}, err
@@ -147,19 +181,44 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Manage NATS resources
 	var result *nauth.AccountResult
 	var adoptions *v1alpha1.AccountAdoptions
+	var operatorSigningKeyName string
 	if managementPolicy == v1alpha1.AccountManagementPolicyObserve {
 		var err error
 		result, err = r.manager.Import(ctx, accountRef)
 		if err != nil {
 			return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to import the observed account: %w", err))
 		}
+		if previousHash := natsAccount.Status.ClaimsHash; previousHash != "" && previousHash != result.ClaimsHash {
+			r.reporter.warning(natsAccount, eventReasonDriftDetected, actionObserved,
+				"Claims of observed account %s changed in NATS", result.AccountID)
+		}
 	} else {
+		if err = checkRequiredLabels(natsAccount, nauthDefaults); err != nil {
+			return r.reporter.error(ctx, natsAccount, err)
+		}
+		if err = r.checkQuotas(ctx, natsAccount, nauthDefaults); err != nil {
+			return r.reporter.error(ctx, natsAccount, err)
+		}
+		operatorSigningKeyName, err = accountRef.ClusterTarget.SelectOperatorSigningKey(toOperatorSigningKeySelector(natsAccount))
+		if err != nil {
+			return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to select operator signing key: %w", err))
+		}
+
 		if accountRef.AccountID == "" {
+			if r.dryRun {
+				// Keys that are not stored cannot be bound, the account is bootstrapped once the dry run ends and the
+				// manager restarts
+				r.reporter.event(natsAccount, eventReasonDryRun, actionCreated, "Skipped creating account")
+				return ctrl.Result{}, nil
+			}
 			// Bootstrap the account
-			result, err = r.manager.CreateOrUpdate(ctx, toBootstrapAccountRequest(natsAccount, accountRef))
+			request := toBootstrapAccountRequest(natsAccount, accountRef)
+			applyAccountPlatformDefaults(&request, nauthDefaults)
+			result, err = r.manager.CreateOrUpdate(ctx, request)
 			if err != nil {
 				return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to bootstrap account: %w", err))
 			}
+			r.recordAccountResult(natsAccount, result)
 			natsAccount.SetLabel(v1alpha1.AccountLabelAccountID, result.AccountID)
 			natsAccount.SetLabel(v1alpha1.AccountLabelSignedBy, result.AccountSignedBy)
 			if err := r.kubernetes.PatchLabels(ctx, natsAccount); err != nil {
@@ -169,15 +228,26 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{RequeueAfter: requeueImmediately}, nil
 		}
 
+		if natsAccount.Spec.AdoptExisting && natsAccount.Status.ClaimsHash == "" {
+			if err = r.adoptExisting(ctx, natsAccount, accountRef); err != nil {
+				return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to adopt existing account: %w", err))
+			}
+		}
+
 		// Full manage
 		request, adoptionRefs, err := r.toAccountRequest(ctx, natsAccount, accountRef)
 		if err != nil {
 			return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to create account request: %w", err))
 		}
+		if err = r.checkPolicies(ctx, natsAccount, request); err != nil {
+			return r.reporter.error(ctx, natsAccount, err)
+		}
+		applyAccountPlatformDefaults(&request, nauthDefaults)
 		result, err = r.manager.CreateOrUpdate(ctx, request)
 		if err != nil {
 			return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to apply account: %w", err))
 		}
+		r.recordAccountResult(natsAccount, result)
 		adoptions = toAPIAdoptions(result.Adoptions, adoptionRefs)
 	}
 
@@ -191,38 +261,132 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// UPDATE ACCOUNT STATUS
-	if result.Claims != nil {
-		claims, err := toAPIAccountClaims(result.Claims)
-		if err != nil {
-			return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to convert account claims: %w", err))
+	previousStatus := natsAccount.Status.DeepCopy()
+	conditions.ClearPaused(natsAccount)
+	finalizer.report(natsAccount, conditionMessageAccountFinalizerDisabled)
+	if result.PendingChanges == nil {
+		// Claims, claims hash and JWT describe the account in NATS, which pending changes have not reached yet
+		if result.Claims != nil {
+			claims, err := toAPIAccountClaims(result.Claims)
+			if err != nil {
+				return r.reporter.error(ctx, natsAccount, fmt.Errorf("failed to convert account claims: %w", err))
+			}
+			natsAccount.Status.Claims = claims
+		}
+		natsAccount.Status.ClaimsHash = result.ClaimsHash
+		if result.JWT != nil {
+			natsAccount.Status.JWT = toAPIJWTMetadata(result.JWT)
 		}
-		natsAccount.Status.Claims = claims
+		natsAccount.Status.ObservedRepushRequestedAt = natsAccount.GetAnnotations()[v1alpha1.AccountAnnotationRepushRequestedAt]
 	}
+	natsAccount.Status.PendingChanges = toAPIPendingChanges(result.PendingChanges)
 	natsAccount.Status.Adoptions = adoptions
-	natsAccount.Status.ClaimsHash = result.ClaimsHash
+	natsAccount.Status.OperatorSigningKeySecretName = operatorSigningKeyName
 	natsAccount.Status.ObservedGeneration = natsAccount.Generation
-	natsAccount.Status.ReconcileTimestamp = metav1.Now()
 	natsAccount.Status.OperatorVersion = os.Getenv(envOperatorVersion)
 
-	if err := r.kubernetes.UpdateReadyStatusReconciled(ctx, natsAccount); err != nil {
-		log.Info("Failed to update the account status", "name", natsAccount.Name, "err", err)
-		return ctrl.Result{}, err
+	if err := r.applyPublicConfigMap(ctx, natsAccount); err != nil {
+		return r.reporter.error(ctx, natsAccount, err)
+	}
+
+	if natsAccount.Status.PendingChanges != nil {
+		return r.reportPendingChanges(ctx, natsAccount, previousStatus)
+	}
+	if !result.JWTPushed && accountStatusUnchanged(previousStatus, natsAccount) {
+		log.V(1).Info("Account unchanged, skipping status update", "name", natsAccount.Name)
+	} else {
+		natsAccount.Status.ReconcileTimestamp = metav1.Now()
+		if err := r.kubernetes.UpdateReadyStatusReconciled(ctx, natsAccount); err != nil {
+			log.Info("Failed to update the account status", "name", natsAccount.Name, "err", err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	return ctrl.Result{
-		RequeueAfter: time.Duration(float64(5*time.Minute) * (0.9 + 0.2*rand.Float64())),
+		RequeueAfter: time.Duration(float64(accountResyncInterval(natsAccount, clusterTarget)) * (0.9 + 0.2*rand.Float64())),
 	}, nil
 }
 
-func (r *AccountReconciler) deleteAccount(ctx context.Context, state *v1alpha1.Account, accountRef nauth.AccountReference, managementPolicy string) (ctrl.Result, error) {
+// adoptExisting moves the secrets of the account of state to state when they are held for another Account, see
+// spec.adoptExisting. That Account must be deleted, and grant the reference when it is in another namespace.
+func (r *AccountReconciler) adoptExisting(ctx context.Context, state *v1alpha1.Account, accountRef nauth.AccountReference) error {
+	source, err := r.manager.FindAdoptionSource(ctx, accountRef)
+	if err != nil || source == nil {
+		return err
+	}
+	previous := &v1alpha1.Account{}
+	err = r.kubernetes.Get(ctx, types.NamespacedName(*source), previous)
+	if err == nil {
+		return domain.ErrAccountStillBound.WithCause(fmt.Errorf(
+			"account %s is still bound to Account %s, delete it with deletionPolicy Retain first", accountRef.AccountID, source))
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get Account %s: %w", source, err)
+	}
+	if err = r.grantReader.CheckAccountReference(ctx, v1alpha1.ReferenceGrantFromKindAccount, domain.Namespace(state.Namespace), *source); err != nil {
+		return err
+	}
+	if err = r.manager.Adopt(ctx, accountRef, *source); err != nil {
+		return err
+	}
+	r.reporter.event(state, eventReasonAccountAdopted, actionAdopted, "Adopted account %s from deleted Account %s",
+		accountRef.AccountID, source)
+	return nil
+}
+
+// reportPendingChanges reports the claims of state waiting for the end of a maintenance window of its NatsCluster, and
+// reconciles state again when the window ends.
+func (r *AccountReconciler) reportPendingChanges(ctx context.Context, state *v1alpha1.Account, previousStatus *v1alpha1.AccountStatus) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	meta.SetStatusCondition(&state.Status.Conditions, metav1.Condition{
-		Type:    conditionTypeReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  conditionReasonReconciling,
-		Message: "Deleting account",
-	})
+	pending := state.Status.PendingChanges
+	until := pending.Until.UTC().Format(time.RFC3339)
+	if previousStatus.PendingChanges == nil || previousStatus.PendingChanges.ClaimsHash != pending.ClaimsHash {
+		r.reporter.event(state, eventReasonPushDeferred, actionPushed,
+			"Deferred push of account %s with claims hash %s until the maintenance window ends at %s",
+			state.GetLabel(v1alpha1.AccountLabelAccountID), pending.ClaimsHash, until)
+	}
+	message := fmt.Sprintf("Changes are pushed when the maintenance window ends at %s", until)
+	conditions.MarkPending(state, conditionReasonMaintenanceWindow, message)
+	if state.Status.ClaimsHash == "" {
+		// The account is not in NATS yet
+		conditions.Set(state, conditionTypeReady, metav1.ConditionFalse, conditionReasonMaintenanceWindow, message)
+	}
+	state.Status.ReconcileTimestamp = metav1.Now()
+	if err := r.kubernetes.Status().Update(ctx, state); err != nil {
+		log.Info("Failed to update the account status", "name", state.Name, "err", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: time.Until(pending.Until.Time) + requeueImmediately}, nil
+}
+
+func toAPIPendingChanges(pending *nauth.AccountPendingChanges) *v1alpha1.AccountPendingChanges {
+	if pending == nil {
+		return nil
+	}
+	return &v1alpha1.AccountPendingChanges{
+		ClaimsHash: pending.ClaimsHash,
+		Fields:     pending.Fields,
+		Until:      metav1.NewTime(pending.Until),
+	}
+}
+
+// accountResyncInterval returns how often an Account is reconciled again. Observed Accounts, and Accounts observing
+// some of their claims, are read again at the observe interval of their NatsCluster, so claims changed outside NAuth
+// are reflected in their status.
+func accountResyncInterval(state *v1alpha1.Account, clusterTarget *nauth.ClusterTarget) time.Duration {
+	observed := state.GetLabel(v1alpha1.AccountLabelManagementPolicy) == v1alpha1.AccountManagementPolicyObserve ||
+		len(state.Spec.ObservedClaims) > 0
+	if observed && clusterTarget.ObserveInterval > 0 {
+		return clusterTarget.ObserveInterval
+	}
+	return 5 * time.Minute
+}
+
+func (r *AccountReconciler) deleteAccount(ctx context.Context, state *v1alpha1.Account, accountRef nauth.AccountReference, managementPolicy string, finalizer finalizerPolicy) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	conditions.MarkDeleting(state, "Deleting account")
 
 	if err := r.kubernetes.Status().Update(ctx, state); err != nil {
 		log.Info("Failed to update the account status", "name", state.Name, "error", err)
@@ -239,6 +403,14 @@ func (r *AccountReconciler) deleteAccount(ctx context.Context, state *v1alpha1.A
 		}
 	}
 
+	waitForUsers, err := r.deleteUsers(ctx, state, accountRef.AccountID)
+	if err != nil {
+		return r.reporter.error(ctx, state, err)
+	}
+	if waitForUsers {
+		return ctrl.Result{}, nil
+	}
+
 	if err := r.validateAccountDeletion(ctx, accountRef.AccountID, domain.Namespace(state.Namespace)); err != nil {
 		if errors.Is(err, domain.ErrUnknownError) {
 			return ctrl.Result{}, err
@@ -246,14 +418,25 @@ func (r *AccountReconciler) deleteAccount(ctx context.Context, state *v1alpha1.A
 		return r.reporter.error(ctx, state, err)
 	}
 
-	if controllerutil.ContainsFinalizer(state, finalizerAccount) {
+	if finalizer.present(state) {
 		if managementPolicy != v1alpha1.AccountManagementPolicyObserve && accountRef.AccountID != "" {
-			if err := r.manager.Delete(ctx, accountRef); err != nil {
-				return r.reporter.error(ctx, state, fmt.Errorf("failed to delete account: %w", err))
+			switch state.Spec.DeletionPolicy {
+			case v1alpha1.AccountDeletionPolicyRetain:
+				r.reporter.event(state, eventReasonAccountRetained, actionDeleted, "Retained account %s in NATS and its Secrets", accountRef.AccountID)
+			case v1alpha1.AccountDeletionPolicyOrphan:
+				if err := r.manager.Orphan(ctx, accountRef); err != nil {
+					return r.reporter.error(ctx, state, fmt.Errorf("failed to orphan account: %w", err))
+				}
+				r.reporter.event(state, eventReasonAccountOrphaned, actionDeleted, "Deleted Secrets of account %s, retained it in NATS", accountRef.AccountID)
+			default:
+				if err := r.manager.Delete(ctx, accountRef); err != nil {
+					return r.reporter.error(ctx, state, fmt.Errorf("failed to delete account: %w", err))
+				}
+				r.reporter.event(state, eventReasonAccountDeleted, actionDeleted, "Deleted account %s from NATS", accountRef.AccountID)
 			}
 		}
 
-		controllerutil.RemoveFinalizer(state, finalizerAccount)
+		finalizer.remove(state)
 		if err := r.kubernetes.Update(ctx, state); err != nil {
 			log.Info("failed to remove finalizer", "name", state.Name, "error", err)
 			return ctrl.Result{}, err
@@ -263,6 +446,47 @@ func (r *AccountReconciler) deleteAccount(ctx context.Context, state *v1alpha1.A
 	return ctrl.Result{}, nil
 }
 
+// checkQuotas returns domain.ErrQuotaExceeded when the Account does not fit the NauthQuotas of its namespace.
+func (r *AccountReconciler) checkQuotas(ctx context.Context, state *v1alpha1.Account, defaults *v1alpha1.NauthDefaultsSpec) error {
+	quotas := &v1alpha1.NauthQuotaList{}
+	if err := r.kubernetes.List(ctx, quotas, client.InNamespace(state.Namespace)); err != nil {
+		return fmt.Errorf("failed to list NauthQuotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+	accounts := &v1alpha1.AccountList{}
+	if err := r.kubernetes.List(ctx, accounts, client.InNamespace(state.Namespace)); err != nil {
+		return fmt.Errorf("failed to list Accounts: %w", err)
+	}
+	return checkAccountQuotas(state, accounts.Items, quotas.Items, defaults)
+}
+
+// checkPolicies returns domain.ErrPolicyViolation when the exports or imports of request are not allowed by the
+// NauthPolicies selecting the Account.
+func (r *AccountReconciler) checkPolicies(ctx context.Context, state *v1alpha1.Account, request nauth.AccountRequest) error {
+	policies := &v1alpha1.NauthPolicyList{}
+	if err := r.kubernetes.List(ctx, policies); err != nil {
+		return fmt.Errorf("failed to list NauthPolicies: %w", err)
+	}
+	return checkAccountPolicies(state, request, policies.Items)
+}
+
+// recordAccountResult records events for the changes applied to NATS by an account operation.
+func (r *AccountReconciler) recordAccountResult(state *v1alpha1.Account, result *nauth.AccountResult) {
+	if result.SigningKeyCreated {
+		r.reporter.event(state, eventReasonSigningKeyCreated, actionCreated, "Created keys for account %s", result.AccountID)
+	}
+	if result.JWTPushed {
+		r.reporter.event(state, eventReasonJWTPushed, actionPushed, "Pushed JWT of account %s with claims hash %s", result.AccountID, result.ClaimsHash)
+	}
+	if size := result.JWTSize; size != nil {
+		r.reporter.warning(state, eventReasonJWTSizeLimitApproaching, actionSigned,
+			"JWT of account %s is %d bytes, %d%% of the %d bytes accepted by the NATS servers",
+			result.AccountID, size.Bytes, int64(size.Bytes)*100/size.Limit, size.Limit)
+	}
+}
+
 func toAccountReference(state *v1alpha1.Account, clusterTarget nauth.ClusterTarget) nauth.AccountReference {
 	return nauth.AccountReference{
 		AccountRef: domain.NamespacedName{
@@ -274,17 +498,52 @@ func toAccountReference(state *v1alpha1.Account, clusterTarget nauth.ClusterTarg
 	}
 }
 
+func toOperatorSigningKeySelector(state *v1alpha1.Account) nauth.OperatorSigningKeySelector {
+	return nauth.OperatorSigningKeySelector{
+		AccountRef: domain.NewNamespacedName(state.Namespace, state.Name),
+		Labels:     state.GetLabels(),
+		Annotation: state.GetAnnotations()[v1alpha1.AccountAnnotationOperatorSigningKey],
+		SignedBy:   state.GetLabel(v1alpha1.AccountLabelSignedBy),
+	}
+}
+
+// accountStatusUnchanged reports whether reconciling account would only refresh its reconcile timestamp.
+func accountStatusUnchanged(previous *v1alpha1.AccountStatus, account *v1alpha1.Account) bool {
+	reconciled := account.DeepCopy()
+	conditions.MarkReconciled(reconciled, conditionMessageReconciled)
+	reconciled.Status.ReconcileTimestamp = previous.ReconcileTimestamp
+	return equality.Semantic.DeepEqual(*previous, reconciled.Status)
+}
+
+// repushRequested reports whether the repush annotation of state has a value the account JWT was not force pushed for.
+func repushRequested(state *v1alpha1.Account) bool {
+	requestedAt := state.GetAnnotations()[v1alpha1.AccountAnnotationRepushRequestedAt]
+	return requestedAt != "" && requestedAt != state.Status.ObservedRepushRequestedAt
+}
+
 func toBootstrapAccountRequest(state *v1alpha1.Account, accountReference nauth.AccountReference) nauth.AccountRequest {
 	return nauth.AccountRequest{
-		AccountRef:       domain.NewNamespacedName(state.Namespace, state.Name),
-		AccountID:        accountReference.AccountID,
-		ClaimsHash:       state.Status.ClaimsHash,
-		DisplayName:      state.Spec.DisplayName,
-		ClusterTarget:    accountReference.ClusterTarget,
-		AccountLimits:    toNAuthAccountLimits(state.Spec.AccountLimits),
-		JetStreamEnabled: state.Spec.JetStreamEnabled,
-		JetStreamLimits:  toNAuthJetStreamLimits(state.Spec.JetStreamLimits),
-		NatsLimits:       toNAuthNatsLimits(state.Spec.NatsLimits),
+		AccountRef:            domain.NewNamespacedName(state.Namespace, state.Name),
+		AccountID:             accountReference.AccountID,
+		ClaimsHash:            state.Status.ClaimsHash,
+		DisplayName:           state.Spec.DisplayName,
+		Description:           state.Spec.Description,
+		InfoURL:               state.Spec.InfoURL,
+		Tags:                  nauth.EncodeTags(state.Spec.Tags, state.Spec.TagValues),
+		ClusterTarget:         accountReference.ClusterTarget,
+		AccountLimits:         toNAuthAccountLimits(state.Spec.AccountLimits),
+		JetStreamEnabled:      state.Spec.JetStreamEnabled,
+		JetStreamLimits:       toNAuthJetStreamLimits(state.Spec.JetStreamLimits),
+		JetStreamTieredLimits: toNAuthJetStreamTieredLimits(state.Spec.JetStreamTieredLimits),
+		NatsLimits:            toNAuthNatsLimits(state.Spec.NatsLimits),
+		Mappings:              toNAuthSubjectMappings(state.Spec.Mappings),
+		DisallowBearer:        state.Spec.DisallowBearer,
+		DefaultPermissions:    toNAuthPermissions(state.Spec.DefaultPermissions),
+		Trace:                 toNAuthMessageTrace(state.Spec.Trace),
+		ClusterTraffic:        string(state.Spec.ClusterTraffic),
+		ObservedClaims:        toNAuthAccountClaimFields(state.Spec.ObservedClaims),
+		ForcePush:             repushRequested(state),
+		KeySecrets:            toNAuthAccountKeySecrets(state.Spec.KeySecrets),
 	}
 }
 
@@ -293,7 +552,7 @@ func (r *AccountReconciler) toAccountRequest(ctx context.Context, state *v1alpha
 	adoptionRefs := accountAdoptionRefs{}
 
 	namespace := domain.Namespace(state.Namespace)
-	cachedAccountIDReader := newCachedAccountIDReader(ctx, r.accountReader)
+	cachedAccountIDReader := newCachedAccountIDReader(ctx, r.accountReader, r.grantReader, namespace)
 
 	inlineExportGroup, err := toNAuthExportGroup(GroupNameInline, true, state.Spec.Exports)
 	if err != nil {
@@ -340,7 +599,9 @@ func (r *AccountReconciler) toAccountRequest(ctx context.Context, state *v1alpha
 	return request, adoptionRefs, nil
 }
 
-func newCachedAccountIDReader(ctx context.Context, accountIDReader k8s.AccountReader) ResolveAccountIDFn {
+// newCachedAccountIDReader resolves Account IDs of Accounts referenced from namespace. References to Accounts in other
+// namespaces must be allowed by a ReferenceGrant.
+func newCachedAccountIDReader(ctx context.Context, accountIDReader k8s.AccountReader, grantReader k8s.ReferenceGrantReader, namespace domain.Namespace) ResolveAccountIDFn {
 	type cachedResult struct {
 		accountID nauth.AccountID
 		err       error
@@ -350,7 +611,11 @@ func newCachedAccountIDReader(ctx context.Context, accountIDReader k8s.AccountRe
 		var result cachedResult
 		var cached bool
 		if result, cached = cache[accountRef]; !cached {
-			accountID, err := accountIDReader.GetAccountID(ctx, accountRef)
+			var accountID nauth.AccountID
+			err := grantReader.CheckAccountReference(ctx, v1alpha1.ReferenceGrantFromKindAccount, namespace, accountRef)
+			if err == nil {
+				accountID, err = accountIDReader.GetAccountID(ctx, accountRef)
+			}
 			result = cachedResult{accountID: accountID, err: err}
 			cache[accountRef] = result
 		}
@@ -386,13 +651,33 @@ func (r *AccountReconciler) findImportsByAccountID(ctx context.Context, namespac
 	return imports, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *AccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+// ownedByShard reports whether the shard of this instance owns account, claiming it when no instance does.
+func (r *AccountReconciler) ownedByShard(ctx context.Context, account *v1alpha1.Account) (bool, error) {
+	owned, owner, err := r.shard.claim(ctx, r.kubernetes, account)
+	if owner != "" {
+		r.reporter.warning(account, eventReasonShardConflict, actionReconciled,
+			"Account is in the shard of %s but owned by %s", r.shard.Name(), owner)
+	}
+	return owned, err
+}
+
+// SetupWithManager sets up the controller with the Manager. Failed reconciles are retried according to requeuePolicy and
+// up to maxConcurrentReconciles Accounts are reconciled in parallel.
+func (r *AccountReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	rateLimiter := requeuePolicy.newRateLimiter()
+	r.reporter.tolerateFailures(rateLimiter, requeuePolicy.MaxRetries)
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.Account{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&v1alpha1.Account{}, builder.WithPredicates(r.shard.predicate(), predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			repushRequestedPredicate(),
+		))).
+		Watches(&v1alpha1.Account{}, enqueueDeletionFirst(), builder.WithPredicates(r.shard.predicate())).
+		Owns(&corev1.ConfigMap{}).
 		Named("account").
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1,
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
 		}).
 		Watches(
 			&v1alpha1.AccountExport{},
@@ -404,7 +689,82 @@ func (r *AccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.mapAccountImportToAccounts),
 			builder.WithPredicates(accountImportWatchPredicateForAccounts()),
 		).
-		Complete(r)
+		Watches(
+			&v1alpha1.User{},
+			handler.EnqueueRequestsFromMapFunc(r.mapUserToDeletingAccounts),
+			builder.WithPredicates(userDeletedPredicate()),
+		).
+		Watches(
+			&v1alpha1.NauthDefaults{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNauthDefaultsToAccounts),
+		).
+		Watches(
+			&v1alpha1.NatsCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNatsClusterToAccounts),
+			builder.WithPredicates(accountDefaultsChangedPredicate()),
+		).
+		Watches(
+			&v1alpha1.NauthQuota{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNauthQuotaToAccounts),
+		).
+		Watches(
+			&v1alpha1.NauthPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNauthPolicyToAccounts),
+		).
+		Complete(traced("account", r))
+}
+
+// repushRequestedPredicate reconciles an Account when a repush of its JWT is requested through an annotation.
+func repushRequestedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			oldValue := e.ObjectOld.GetAnnotations()[v1alpha1.AccountAnnotationRepushRequestedAt]
+			newValue := e.ObjectNew.GetAnnotations()[v1alpha1.AccountAnnotationRepushRequestedAt]
+			return newValue != "" && newValue != oldValue
+		},
+	}
+}
+
+// accountDefaultsChangedPredicate reconciles the Accounts of a NatsCluster when its account defaults change.
+func accountDefaultsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, okOld := e.ObjectOld.(*v1alpha1.NatsCluster)
+			newCluster, okNew := e.ObjectNew.(*v1alpha1.NatsCluster)
+			if !okOld || !okNew {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldCluster.Spec.AccountDefaults, newCluster.Spec.AccountDefaults)
+		},
+	}
+}
+
+// mapNatsClusterToAccounts reconciles the Accounts bound to a NatsCluster.
+func (r *AccountReconciler) mapNatsClusterToAccounts(ctx context.Context, obj client.Object) []reconcile.Request {
+	accounts := &v1alpha1.AccountList{}
+	if err := r.kubernetes.List(ctx, accounts,
+		client.MatchingLabels{string(v1alpha1.AccountLabelNatsClusterID): string(obj.GetUID())},
+	); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Accounts for NatsCluster watch", "natsCluster", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&account),
+		})
+	}
+	return requests
 }
 
 func (r *AccountReconciler) mapAccountExportToAccounts(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -437,6 +797,105 @@ func (r *AccountReconciler) mapAccountExportToAccounts(ctx context.Context, obj
 	return requests
 }
 
+func (r *AccountReconciler) mapNauthDefaultsToAccounts(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != v1alpha1.NauthDefaultsName {
+		return nil
+	}
+	accounts := &v1alpha1.AccountList{}
+	if err := r.kubernetes.List(ctx, accounts); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Accounts for NauthDefaults watch")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&account),
+		})
+	}
+	return requests
+}
+
+// mapNauthPolicyToAccounts reconciles all Accounts, as a NauthPolicy may select Accounts in any namespace.
+func (r *AccountReconciler) mapNauthPolicyToAccounts(ctx context.Context, _ client.Object) []reconcile.Request {
+	accounts := &v1alpha1.AccountList{}
+	if err := r.kubernetes.List(ctx, accounts); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Accounts for NauthPolicy watch")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&account),
+		})
+	}
+	return requests
+}
+
+// mapNauthQuotaToAccounts reconciles the Accounts in the namespace of a NauthQuota.
+func (r *AccountReconciler) mapNauthQuotaToAccounts(ctx context.Context, obj client.Object) []reconcile.Request {
+	accounts := &v1alpha1.AccountList{}
+	if err := r.kubernetes.List(ctx, accounts, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Accounts for NauthQuota watch", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&account),
+		})
+	}
+	return requests
+}
+
+// mapUserToDeletingAccounts reconciles the Accounts being deleted that a User is bound to.
+func (r *AccountReconciler) mapUserToDeletingAccounts(ctx context.Context, obj client.Object) []reconcile.Request {
+	user, ok := obj.(*v1alpha1.User)
+	if !ok {
+		return nil
+	}
+
+	accountID := user.GetLabel(v1alpha1.UserLabelAccountID)
+	if accountID == "" {
+		return nil
+	}
+
+	accounts := &v1alpha1.AccountList{}
+	if err := r.kubernetes.List(ctx, accounts,
+		client.InNamespace(user.Namespace),
+		client.MatchingLabels{string(v1alpha1.AccountLabelAccountID): accountID},
+	); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Accounts for User watch", "accountID", accountID, "namespace", user.Namespace)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		if account.DeletionTimestamp.IsZero() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&account),
+		})
+	}
+
+	return requests
+}
+
+func userDeletedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			user, ok := e.Object.(*v1alpha1.User)
+			return ok && user.GetLabel(v1alpha1.UserLabelAccountID) != ""
+		},
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
 func accountExportWatchPredicateForAccounts() predicate.Funcs {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -523,21 +982,67 @@ func (r *AccountReconciler) mapAccountImportToAccounts(ctx context.Context, obj
 	return requests
 }
 
-func (r *AccountReconciler) validateAccountDeletion(ctx context.Context, accountID nauth.AccountID, namespace domain.Namespace) error {
+// deleteUsers reports whether the deletion of the account must wait for Users bound to it. With the Cascade deletion
+// policy the Users are deleted, otherwise the deletion is blocked until they are deleted by their owner. The account is
+// reconciled again when a bound User is deleted.
+func (r *AccountReconciler) deleteUsers(ctx context.Context, state *v1alpha1.Account, accountID nauth.AccountID) (bool, error) {
 	log := logf.FromContext(ctx)
 	if accountID == "" {
-		return nil
+		return false, nil
 	}
 
-	// Check for bound users
-	userList := &v1alpha1.UserList{}
-	err := r.kubernetes.List(ctx, userList, client.MatchingLabels{string(v1alpha1.UserLabelAccountID): string(accountID)}, client.InNamespace(namespace))
+	users, err := r.findUsersByAccountID(ctx, domain.Namespace(state.Namespace), accountID)
 	if err != nil {
-		log.Error(err, "Failed to list users bound to account")
-		return domain.ErrUnknownError.WithCause(err)
+		return false, err
+	}
+	if len(users.Items) == 0 {
+		return false, nil
+	}
+
+	userNames := make([]string, 0, len(users.Items))
+	for _, user := range users.Items {
+		userNames = append(userNames, user.Name)
+	}
+	if state.Spec.UserDeletionPolicy == v1alpha1.AccountUserDeletionPolicyCascade {
+		for i := range users.Items {
+			user := &users.Items[i]
+			if !user.DeletionTimestamp.IsZero() {
+				continue
+			}
+			if err := r.kubernetes.Delete(ctx, user); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to delete user %s: %w", user.Name, err)
+			}
+		}
+		meta.RemoveStatusCondition(&state.Status.Conditions, conditions.TypeDeletionBlocked)
+		conditions.MarkDeleting(state, fmt.Sprintf("Waiting for %d users to be deleted: %s", len(userNames), strings.Join(userNames, ", ")))
+	} else {
+		message := fmt.Sprintf("Account has %d users, delete them or set spec.userDeletionPolicy to Cascade: %s", len(userNames), strings.Join(userNames, ", "))
+		conditions.Set(state, conditions.TypeDeletionBlocked, metav1.ConditionTrue, conditions.ReasonUsersExist, message)
+		r.reporter.warning(state, eventReasonDeletionBlocked, actionDeleted, message)
+	}
+
+	if err := r.kubernetes.Status().Update(ctx, state); err != nil {
+		log.Info("Failed to update the account status", "name", state.Name, "error", err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *AccountReconciler) findUsersByAccountID(ctx context.Context, namespace domain.Namespace, accountID nauth.AccountID) (*v1alpha1.UserList, error) {
+	users := &v1alpha1.UserList{}
+	err := r.kubernetes.List(ctx, users, client.InNamespace(namespace), client.MatchingLabels{
+		string(v1alpha1.UserLabelAccountID): string(accountID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users bound to account: %w", err)
 	}
-	if len(userList.Items) > 0 {
-		return fmt.Errorf("cannot delete an account with associated users, found %d users", len(userList.Items))
+	return users, nil
+}
+
+func (r *AccountReconciler) validateAccountDeletion(ctx context.Context, accountID nauth.AccountID, namespace domain.Namespace) error {
+	log := logf.FromContext(ctx)
+	if accountID == "" {
+		return nil
 	}
 
 	// check for bound exports
@@ -45,6 +45,93 @@ func toNAuthJetStreamLimits(source *v1alpha1.JetStreamLimits) *nauth.JetStreamLi
 	}
 }
 
+func toNAuthJetStreamTieredLimits(source v1alpha1.JetStreamTieredLimits) nauth.JetStreamTieredLimits {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make(nauth.JetStreamTieredLimits, len(source))
+	for tier, limits := range source {
+		result[tier] = *toNAuthJetStreamLimits(&limits)
+	}
+	return result
+}
+
+func toNAuthPermissions(source *v1alpha1.Permissions) *nauth.Permissions {
+	if source == nil {
+		return nil
+	}
+	result := &nauth.Permissions{
+		Pub: nauth.Permission{Allow: toNAuthSubjects(source.Pub.Allow), Deny: toNAuthSubjects(source.Pub.Deny)},
+		Sub: nauth.Permission{Allow: toNAuthSubjects(source.Sub.Allow), Deny: toNAuthSubjects(source.Sub.Deny)},
+	}
+	if source.Resp != nil {
+		result.Resp = &nauth.ResponsePermission{MaxMsgs: source.Resp.MaxMsgs, Expires: source.Resp.Expires}
+	}
+	return result
+}
+
+func toNAuthMessageTrace(source *v1alpha1.MessageTrace) *nauth.MessageTrace {
+	if source == nil {
+		return nil
+	}
+	return &nauth.MessageTrace{Destination: nauth.Subject(source.Destination), Sampling: source.Sampling}
+}
+
+func toNAuthSubjects(source v1alpha1.StringList) []nauth.Subject {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make([]nauth.Subject, 0, len(source))
+	for _, subject := range source {
+		result = append(result, nauth.Subject(subject))
+	}
+	return result
+}
+
+func toNAuthSubjectMappings(source v1alpha1.SubjectMappings) nauth.SubjectMappings {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make(nauth.SubjectMappings, 0, len(source))
+	for _, mapping := range source {
+		destinations := make([]nauth.WeightedDestination, 0, len(mapping.Destinations))
+		for _, destination := range mapping.Destinations {
+			var weight uint8
+			if destination.Weight != nil {
+				weight = uint8(*destination.Weight)
+			}
+			destinations = append(destinations, nauth.WeightedDestination{
+				Subject: nauth.Subject(destination.Subject),
+				Weight:  weight,
+				Cluster: destination.Cluster,
+			})
+		}
+		result = append(result, nauth.SubjectMapping{Subject: nauth.Subject(mapping.Subject), Destinations: destinations})
+	}
+	return result
+}
+
+func toNAuthAccountClaimFields(source []v1alpha1.AccountClaimField) []nauth.AccountClaimField {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make([]nauth.AccountClaimField, 0, len(source))
+	for _, field := range source {
+		result = append(result, nauth.AccountClaimField(field))
+	}
+	return result
+}
+
+func toNAuthAccountKeySecrets(source *v1alpha1.AccountKeySecrets) *nauth.AccountKeySecrets {
+	if source == nil {
+		return nil
+	}
+	return &nauth.AccountKeySecrets{
+		Root:    nauth.SecretKeyRef{Name: source.Root.Name, Key: source.Root.Key},
+		Signing: nauth.SecretKeyRef{Name: source.Signing.Name, Key: source.Signing.Key},
+	}
+}
+
 func toNAuthNatsLimits(source *v1alpha1.NatsLimits) *nauth.NatsLimits {
 	if source == nil {
 		return nil
@@ -104,6 +191,7 @@ func toNAuthExportGroup(groupRef nauth.Ref, required bool, sources v1alpha1.Expo
 			AccountTokenPosition: source.AccountTokenPosition,
 			Advertise:            source.Advertise,
 			AllowTrace:           source.AllowTrace,
+			AllowWildcard:        source.AllowWildcard,
 		})
 	}
 	return &result, nil
@@ -344,6 +432,9 @@ func toNAuthExportFromRule(source v1alpha1.AccountExportRule) (*nauth.Export, er
 	if source.AllowTrace != nil {
 		result.AllowTrace = *source.AllowTrace
 	}
+	if source.AllowWildcard != nil {
+		result.AllowWildcard = *source.AllowWildcard
+	}
 	if source.Latency != nil {
 		result.Latency = &nauth.ServiceLatency{
 			Sampling: nauth.SamplingRate(source.Latency.Sampling),
@@ -441,17 +532,41 @@ func toAPIAccountClaims(claims *nauth.AccountClaims) (*v1alpha1.AccountClaims, e
 		return nil, fmt.Errorf("failed to convert imports: %w", err)
 	}
 	return &v1alpha1.AccountClaims{
-		AccountLimits:    toAPIAccountLimits(claims.AccountLimits),
-		DisplayName:      claims.DisplayName,
-		SigningKeys:      toAPISigningKeys(claims.SigningKeys),
-		Exports:          exports,
-		Imports:          imports,
-		JetStreamEnabled: claims.JetStreamEnabled,
-		JetStreamLimits:  toAPIAJetStreamLimits(claims.JetStreamLimits),
-		NatsLimits:       toAPINatsLimits(claims.NatsLimits),
+		AccountLimits:         toAPIAccountLimits(claims.AccountLimits),
+		DisplayName:           claims.DisplayName,
+		Description:           claims.Description,
+		InfoURL:               claims.InfoURL,
+		Tags:                  claims.Tags,
+		SigningKeys:           toAPISigningKeys(claims.SigningKeys),
+		Exports:               exports,
+		Imports:               imports,
+		JetStreamEnabled:      claims.JetStreamEnabled,
+		JetStreamLimits:       toAPIAJetStreamLimits(claims.JetStreamLimits),
+		JetStreamTieredLimits: toAPIJetStreamTieredLimits(claims.JetStreamTieredLimits),
+		NatsLimits:            toAPINatsLimits(claims.NatsLimits),
+		Mappings:              toAPISubjectMappings(claims.Mappings),
+		DisallowBearer:        claims.DisallowBearer,
+		DefaultPermissions:    toAPIPermissions(claims.DefaultPermissions),
+		Trace:                 toAPIMessageTrace(claims.Trace),
+		ClusterTraffic:        v1alpha1.AccountClusterTraffic(claims.ClusterTraffic),
 	}, nil
 }
 
+func toAPIJWTMetadata(source *nauth.JWTMetadata) *v1alpha1.JWTMetadata {
+	if source == nil {
+		return nil
+	}
+	result := &v1alpha1.JWTMetadata{
+		ID:       source.ID,
+		IssuedAt: metav1.NewTime(source.IssuedAt),
+		Issuer:   source.Issuer,
+	}
+	if source.ExpiresAt != nil {
+		result.ExpiresAt = new(metav1.NewTime(*source.ExpiresAt))
+	}
+	return result
+}
+
 func toAPIAccountLimits(source *nauth.AccountLimits) *v1alpha1.AccountLimits {
 	if source == nil {
 		return nil
@@ -483,6 +598,40 @@ func toAPIAJetStreamLimits(source *nauth.JetStreamLimits) *v1alpha1.JetStreamLim
 	}
 }
 
+func toAPIJetStreamTieredLimits(source nauth.JetStreamTieredLimits) v1alpha1.JetStreamTieredLimits {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make(v1alpha1.JetStreamTieredLimits, len(source))
+	for tier, limits := range source {
+		result[tier] = *toAPIAJetStreamLimits(&limits)
+	}
+	return result
+}
+
+func toAPISubjectMappings(source nauth.SubjectMappings) v1alpha1.SubjectMappings {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make(v1alpha1.SubjectMappings, 0, len(source))
+	for _, mapping := range source {
+		destinations := make([]v1alpha1.WeightedDestination, 0, len(mapping.Destinations))
+		for _, destination := range mapping.Destinations {
+			apiDestination := v1alpha1.WeightedDestination{
+				Subject: v1alpha1.Subject(destination.Subject),
+				Cluster: destination.Cluster,
+			}
+			if destination.Weight != 0 {
+				weight := int32(destination.Weight)
+				apiDestination.Weight = &weight
+			}
+			destinations = append(destinations, apiDestination)
+		}
+		result = append(result, v1alpha1.SubjectMapping{Subject: v1alpha1.Subject(mapping.Subject), Destinations: destinations})
+	}
+	return result
+}
+
 func toAPINatsLimits(source *nauth.NatsLimits) *v1alpha1.NatsLimits {
 	if source == nil {
 		return nil
@@ -541,11 +690,14 @@ func toAPIExports(exports nauth.Exports) (v1alpha1.Exports, error) {
 			Name:                 exp.Name,
 			Subject:              v1alpha1.Subject(exp.Subject),
 			Type:                 exportType,
+			TokenReq:             exp.TokenReq,
+			Revocations:          v1alpha1.RevocationList(exp.Revocations),
 			ResponseType:         responseType,
 			ResponseThreshold:    exp.ResponseThreshold,
 			AccountTokenPosition: exp.AccountTokenPosition,
 			Advertise:            exp.Advertise,
 			AllowTrace:           exp.AllowTrace,
+			AllowWildcard:        exp.AllowWildcard,
 			Latency:              toAPIServiceLatency(exp.Latency),
 		}
 		result[i] = &export
@@ -597,3 +749,35 @@ func toAPIResponseType(source nauth.ResponseType) (v1alpha1.ResponseType, error)
 	}
 	return result, nil
 }
+
+func toAPIPermissions(source *nauth.Permissions) *v1alpha1.Permissions {
+	if source == nil {
+		return nil
+	}
+	result := &v1alpha1.Permissions{
+		Pub: v1alpha1.Permission{Allow: toAPISubjects(source.Pub.Allow), Deny: toAPISubjects(source.Pub.Deny)},
+		Sub: v1alpha1.Permission{Allow: toAPISubjects(source.Sub.Allow), Deny: toAPISubjects(source.Sub.Deny)},
+	}
+	if source.Resp != nil {
+		result.Resp = &v1alpha1.ResponsePermission{MaxMsgs: source.Resp.MaxMsgs, Expires: source.Resp.Expires}
+	}
+	return result
+}
+
+func toAPISubjects(source []nauth.Subject) v1alpha1.StringList {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make(v1alpha1.StringList, 0, len(source))
+	for _, subject := range source {
+		result = append(result, string(subject))
+	}
+	return result
+}
+
+func toAPIMessageTrace(source *nauth.MessageTrace) *v1alpha1.MessageTrace {
+	if source == nil {
+		return nil
+	}
+	return &v1alpha1.MessageTrace{Destination: v1alpha1.Subject(source.Destination), Sampling: source.Sampling}
+}
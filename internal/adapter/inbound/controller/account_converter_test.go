@@ -250,3 +250,65 @@ func Test_toNAuthImportGroups_ShouldUseNameAsTieBreakerWhenCreationTimesMatch(t
 	require.Equal(t, "uid-a", string(refs[0].UID))
 	require.Equal(t, "uid-b", string(refs[1].UID))
 }
+
+func Test_toAPIExports_ShouldRoundTripAllExportFields(t *testing.T) {
+	exports := v1alpha1.Exports{
+		{
+			Name:                 "orders",
+			Subject:              "orders.*.get",
+			Type:                 v1alpha1.Service,
+			TokenReq:             true,
+			Revocations:          v1alpha1.RevocationList{"*": 1700000000},
+			ResponseType:         v1alpha1.ResponseTypeStream,
+			ResponseThreshold:    3 * time.Second,
+			Latency:              &v1alpha1.ServiceLatency{Sampling: 50, Results: "latency.orders"},
+			AccountTokenPosition: 2,
+			Advertise:            true,
+			AllowTrace:           true,
+		},
+	}
+
+	group, err := toNAuthExportGroup(GroupNameInline, true, exports)
+	require.NoError(t, err)
+	result, err := toAPIExports(group.Exports)
+
+	require.NoError(t, err)
+	require.Equal(t, exports, result)
+}
+
+func Test_toAPISubjectMappings_ShouldRoundTripAllMappingFields(t *testing.T) {
+	weight := int32(90)
+	mappings := v1alpha1.SubjectMappings{
+		{
+			Subject: "orders.*",
+			Destinations: []v1alpha1.WeightedDestination{
+				{Subject: "orders.v1.{{wildcard(1)}}", Weight: &weight, Cluster: "eu-west"},
+				{Subject: "orders.v2.{{wildcard(1)}}"},
+			},
+		},
+	}
+
+	result := toAPISubjectMappings(toNAuthSubjectMappings(mappings))
+
+	require.Equal(t, mappings, result)
+}
+
+func Test_toAPIPermissions_ShouldRoundTripAllPermissionFields(t *testing.T) {
+	permissions := &v1alpha1.Permissions{
+		Pub:  v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+		Sub:  v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}, Deny: v1alpha1.StringList{"orders.internal.>"}},
+		Resp: &v1alpha1.ResponsePermission{MaxMsgs: 1, Expires: time.Minute},
+	}
+
+	result := toAPIPermissions(toNAuthPermissions(permissions))
+
+	require.Equal(t, permissions, result)
+}
+
+func Test_toAPIMessageTrace_ShouldRoundTripAllTraceFields(t *testing.T) {
+	trace := &v1alpha1.MessageTrace{Destination: "traces.orders", Sampling: 25}
+
+	result := toAPIMessageTrace(toNAuthMessageTrace(trace))
+
+	require.Equal(t, trace, result)
+}
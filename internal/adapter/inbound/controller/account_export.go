@@ -22,10 +22,10 @@ import (
 	"reflect"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -77,10 +77,10 @@ func (r *AccountExportReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	updateConditionFalse := func(condType string, reason string, msg string) {
-		meta.SetStatusCondition(state.GetConditions(), newCondition(condType, metav1.ConditionFalse, reason, msg))
+		conditions.Set(state, condType, metav1.ConditionFalse, reason, msg)
 	}
 	updateConditionTrue := func(condType string) {
-		meta.SetStatusCondition(state.GetConditions(), newCondition(condType, metav1.ConditionTrue, conditionReasonOK, ""))
+		conditions.Set(state, condType, metav1.ConditionTrue, conditionReasonOK, "")
 	}
 
 	var exports nauth.Exports
@@ -208,6 +208,9 @@ func mapToExport(rule v1alpha1.AccountExportRule) nauth.Export {
 	if rule.AllowTrace != nil {
 		result.AllowTrace = *rule.AllowTrace
 	}
+	if rule.AllowWildcard != nil {
+		result.AllowWildcard = *rule.AllowWildcard
+	}
 
 	return result
 }
@@ -223,7 +226,9 @@ func mapExportType(t v1alpha1.ExportType) nauth.ExportType {
 	return nauth.ExportTypeUnknown
 }
 
-func (r *AccountExportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *AccountExportReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	rateLimiter := requeuePolicy.newRateLimiter()
+
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.AccountExport{},
@@ -237,14 +242,15 @@ func (r *AccountExportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&v1alpha1.AccountExport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("accountexport").
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1,
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
 		}).
 		Watches(
 			&v1alpha1.Account{},
 			handler.EnqueueRequestsFromMapFunc(r.mapAccountToAccountExports),
 			builder.WithPredicates(accountExportAccountWatchPredicate()),
 		).
-		Complete(r)
+		Complete(traced("accountexport", r))
 }
 
 func exportBySpecAccountNameIndexFunc(rawObj client.Object) []string {
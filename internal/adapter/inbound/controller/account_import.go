@@ -18,10 +18,12 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
@@ -46,21 +48,24 @@ const importExportAccountRefIndexKey string = "import.spec.exportAccountRef"
 
 // AccountImportReconciler reconciles an AccountImport object.
 type AccountImportReconciler struct {
-	kubernetes *kubernetesClient
-	Scheme     *runtime.Scheme
-	manager    inbound.AccountImportManager
+	kubernetes  *kubernetesClient
+	Scheme      *runtime.Scheme
+	manager     inbound.AccountImportManager
+	grantReader k8s.ReferenceGrantReader
 }
 
-func NewAccountImportReconciler(k8sClient client.Client, scheme *runtime.Scheme, manager inbound.AccountImportManager) *AccountImportReconciler {
+func NewAccountImportReconciler(k8sClient client.Client, scheme *runtime.Scheme, manager inbound.AccountImportManager, grantReader k8s.ReferenceGrantReader) *AccountImportReconciler {
 	return &AccountImportReconciler{
-		kubernetes: newKubernetesClient(k8sClient),
-		Scheme:     scheme,
-		manager:    manager,
+		kubernetes:  newKubernetesClient(k8sClient),
+		Scheme:      scheme,
+		manager:     manager,
+		grantReader: grantReader,
 	}
 }
 
 // +kubebuilder:rbac:groups=nauth.io,resources=accountimports,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=nauth.io,resources=accountimports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nauth.io,resources=referencegrants,verbs=get;list;watch
 
 func (r *AccountImportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
@@ -91,7 +96,14 @@ func (r *AccountImportReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 	exportAccountRef := domain.NewNamespacedName(exportAccountNamespace, state.Spec.ExportAccountRef.Name)
 	exportAccountID := state.GetLabel(v1alpha1.AccountImportLabelExportAccountID)
-	exportAccount, exportAccountCondition := r.getConditionedAccount(ctx, exportAccountRef, exportAccountID)
+	var exportAccount *v1alpha1.Account
+	var exportAccountCondition metav1.Condition
+	grantErr := r.grantReader.CheckAccountReference(ctx, v1alpha1.ReferenceGrantFromKindAccountImport, domain.Namespace(state.Namespace), exportAccountRef)
+	if grantErr != nil {
+		exportAccountCondition = toReferenceGrantCondition(exportAccountRef, grantErr)
+	} else {
+		exportAccount, exportAccountCondition = r.getConditionedAccount(ctx, exportAccountRef, exportAccountID)
+	}
 	exportAccountCondition.Type = conditionTypeBoundToExportAccount
 	if exportAccount != nil {
 		exportAccountID = exportAccount.GetLabel(v1alpha1.AccountLabelAccountID)
@@ -127,6 +139,10 @@ func (r *AccountImportReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			Rules:              derivedRules,
 		}
 	}
+	if grantErr != nil {
+		// Withdraw the claim, so the importing Account stops adopting rules of an Account it may no longer reference
+		state.Status.DesiredClaim = nil
+	}
 
 	adoptedByAccountCondition := metav1.Condition{
 		Type:    conditionTypeAdoptedByAccount,
@@ -195,7 +211,9 @@ func (r *AccountImportReconciler) validateImports(importAccountID string, export
 	return result, nil
 }
 
-func (r *AccountImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *AccountImportReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	rateLimiter := requeuePolicy.newRateLimiter()
+
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.AccountImport{},
@@ -218,7 +236,8 @@ func (r *AccountImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&v1alpha1.AccountImport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("accountimport").
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1,
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
 		}).
 		Watches(
 			&v1alpha1.Account{},
@@ -230,7 +249,41 @@ func (r *AccountImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.mapExportAccountToAccountImports),
 			builder.WithPredicates(accountImportExportAccountWatchPredicate()),
 		).
-		Complete(r)
+		Watches(
+			&v1alpha1.ReferenceGrant{},
+			handler.EnqueueRequestsFromMapFunc(r.mapReferenceGrantToAccountImports),
+		).
+		Complete(traced("accountimport", r))
+}
+
+// mapReferenceGrantToAccountImports enqueues the AccountImports in the namespaces granted by the ReferenceGrant that
+// import from an Account in the namespace of the ReferenceGrant.
+func (r *AccountImportReconciler) mapReferenceGrantToAccountImports(ctx context.Context, obj client.Object) []reconcile.Request {
+	grant, ok := obj.(*v1alpha1.ReferenceGrant)
+	if !ok {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, from := range grant.Spec.From {
+		if from.Kind != v1alpha1.ReferenceGrantFromKindAccountImport {
+			continue
+		}
+		imports := &v1alpha1.AccountImportList{}
+		if err := r.kubernetes.List(ctx, imports, client.InNamespace(from.Namespace)); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list AccountImports for ReferenceGrant watch",
+				"referenceGrant", grant.Name, "namespace", from.Namespace)
+			continue
+		}
+		for _, imp := range imports.Items {
+			if imp.Spec.ExportAccountRef.Namespace == grant.Namespace {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: imp.Namespace, Name: imp.Name},
+				})
+			}
+		}
+	}
+	return requests
 }
 
 func byAccountNameIndexFunc(rawObj client.Object) []string {
@@ -388,6 +441,21 @@ func accountImportAdoptionSnapshot(account *v1alpha1.Account) map[string]adoptio
 	return adoptions
 }
 
+func toReferenceGrantCondition(accountRef domain.NamespacedName, err error) metav1.Condition {
+	if errors.Is(err, domain.ErrReferenceNotGranted) {
+		return metav1.Condition{
+			Status:  metav1.ConditionFalse,
+			Reason:  conditionReasonReferenceNotGranted,
+			Message: fmt.Sprintf("Reference to Account %s is not allowed by a ReferenceGrant in namespace %s", accountRef, accountRef.Namespace),
+		}
+	}
+	return metav1.Condition{
+		Status:  metav1.ConditionFalse,
+		Reason:  string(metav1.StatusReasonInternalError),
+		Message: fmt.Sprintf("Failed to check ReferenceGrants for Account %s: %q", accountRef, err),
+	}
+}
+
 func (r *AccountImportReconciler) getConditionedAccount(ctx context.Context, accountRef domain.NamespacedName, boundAccountID string) (*v1alpha1.Account, metav1.Condition) {
 	if err := accountRef.Validate(); err != nil {
 		return nil, metav1.Condition{
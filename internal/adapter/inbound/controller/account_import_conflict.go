@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// importedAccountIDIndexKey indexes Accounts by the IDs of the accounts their claims import from.
+const importedAccountIDIndexKey string = "account.status.claims.imports.account"
+
+// ImportConflictReconciler reports in the ImportConflict condition of an Account when it imports subjects of the same
+// type from the same exporting account as another Account, with overlapping local subjects. Conflicts are only warned
+// about, the Accounts are still reconciled. Accounts are found by an index of the accounts their claims import from.
+type ImportConflictReconciler struct {
+	client.Client
+	reporter *statusReporter
+}
+
+func NewImportConflictReconciler(k8sClient client.Client, recorder events.EventRecorder) *ImportConflictReconciler {
+	return &ImportConflictReconciler{
+		Client:   k8sClient,
+		reporter: newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *ImportConflictReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	natsAccount := &v1alpha1.Account{}
+	if err := r.Get(ctx, req.NamespacedName, natsAccount); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	if !natsAccount.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	conflicts, err := r.findImportConflicts(ctx, natsAccount)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	patch := client.MergeFrom(natsAccount.DeepCopy())
+	wasConflict := meta.IsStatusConditionTrue(natsAccount.Status.Conditions, conditions.TypeImportConflict)
+	if len(conflicts) == 0 {
+		conditions.Set(natsAccount, conditions.TypeImportConflict, metav1.ConditionFalse, conditions.ReasonNoConflicts,
+			"No imports overlap the imports of other Accounts")
+	} else {
+		message := strings.Join(conflicts, ", ")
+		conditions.Set(natsAccount, conditions.TypeImportConflict, metav1.ConditionTrue, conditions.ReasonSubjectsOverlap, message)
+		if !wasConflict {
+			r.reporter.warning(natsAccount, eventReasonImportConflictDetected, actionObserved, "%s", message)
+		}
+	}
+	if err := r.Status().Patch(ctx, natsAccount, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update Account import conflict status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// findImportConflicts describes the imports of natsAccount of the same type from the same exporting account as an
+// import of another Account, with overlapping local subjects.
+func (r *ImportConflictReconciler) findImportConflicts(ctx context.Context, natsAccount *v1alpha1.Account) ([]string, error) {
+	var conflicts []string
+	for _, exporterID := range importedAccountIDs(natsAccount) {
+		accounts := &v1alpha1.AccountList{}
+		if err := r.List(ctx, accounts, client.MatchingFields{importedAccountIDIndexKey: exporterID}); err != nil {
+			return nil, fmt.Errorf("failed to list Accounts importing from account %s: %w", exporterID, err)
+		}
+		for _, other := range accounts.Items {
+			if other.Namespace == natsAccount.Namespace && other.Name == natsAccount.Name {
+				continue
+			}
+			for _, imp := range natsAccount.Status.Claims.Imports {
+				if imp == nil || imp.Account != exporterID {
+					continue
+				}
+				for _, otherImp := range other.Status.Claims.Imports {
+					if otherImp == nil || otherImp.Account != exporterID || otherImp.Type != imp.Type {
+						continue
+					}
+					if importLocalSubject(imp).Overlaps(importLocalSubject(otherImp)) {
+						conflicts = append(conflicts, fmt.Sprintf("%s import %q overlaps import %q of Account %s/%s from account %s",
+							imp.Type, importLocalSubject(imp), importLocalSubject(otherImp), other.Namespace, other.Name, exporterID))
+					}
+				}
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// importLocalSubject returns the subject imp is available under in the importing account. Wildcard references of a
+// renaming local subject match any token.
+func importLocalSubject(imp *v1alpha1.Import) nauth.Subject {
+	if imp.LocalSubject == "" {
+		return nauth.Subject(imp.Subject)
+	}
+	tokens := strings.Split(string(imp.LocalSubject), ".")
+	for i, token := range tokens {
+		if strings.HasPrefix(token, "$") {
+			tokens[i] = "*"
+		}
+	}
+	return nauth.Subject(strings.Join(tokens, "."))
+}
+
+// importedAccountIDs returns the sorted IDs of the accounts the claims of natsAccount import from.
+func importedAccountIDs(natsAccount *v1alpha1.Account) []string {
+	if natsAccount.Status.Claims == nil {
+		return nil
+	}
+	var accountIDs []string
+	for _, imp := range natsAccount.Status.Claims.Imports {
+		if imp != nil && imp.Account != "" && !slices.Contains(accountIDs, imp.Account) {
+			accountIDs = append(accountIDs, imp.Account)
+		}
+	}
+	slices.Sort(accountIDs)
+	return accountIDs
+}
+
+func byImportedAccountIDIndexFunc(rawObj client.Object) []string {
+	return importedAccountIDs(rawObj.(*v1alpha1.Account))
+}
+
+// enqueueImportingAccounts enqueues the Accounts importing from the accounts an Account imports from, before and after
+// a change of its imports, so their conflicts with it are reported again.
+func (r *ImportConflictReconciler) enqueueImportingAccounts() handler.EventHandler {
+	enqueue := func(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], objects ...client.Object) {
+		for _, obj := range objects {
+			for _, exporterID := range importedAccountIDs(obj.(*v1alpha1.Account)) {
+				accounts := &v1alpha1.AccountList{}
+				if err := r.List(ctx, accounts, client.MatchingFields{importedAccountIDIndexKey: exporterID}); err != nil {
+					logf.FromContext(ctx).Error(err, "Failed to list Accounts for import conflict watch", "accountID", exporterID)
+					continue
+				}
+				for _, account := range accounts.Items {
+					q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&account)})
+				}
+			}
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+	}
+}
+
+// importsChangedPredicate passes the Accounts whose imported claims changed, so the conditions written by the
+// ImportConflictReconciler do not trigger it again.
+func importsChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAccount, okOld := e.ObjectOld.(*v1alpha1.Account)
+			newAccount, okNew := e.ObjectNew.(*v1alpha1.Account)
+			if !okOld || !okNew {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(accountImportClaims(oldAccount), accountImportClaims(newAccount))
+		},
+	}
+}
+
+func accountImportClaims(natsAccount *v1alpha1.Account) v1alpha1.Imports {
+	if natsAccount.Status.Claims == nil {
+		return nil
+	}
+	return natsAccount.Status.Claims.Imports
+}
+
+func (r *ImportConflictReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.Account{},
+		importedAccountIDIndexKey,
+		byImportedAccountIDIndexFunc,
+	); err != nil {
+		return fmt.Errorf("failed to index Account by imported account ID: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Account{}, builder.WithPredicates(importsChangedPredicate())).
+		Named("importconflict").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Watches(
+			&v1alpha1.Account{},
+			r.enqueueImportingAccounts(),
+			builder.WithPredicates(importsChangedPredicate()),
+		).
+		Complete(traced("importconflict", r))
+}
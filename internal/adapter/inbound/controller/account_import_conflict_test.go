@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+)
+
+const importConflictTestExporterID = "AEXPORTER"
+
+func importConflictTestAccount(namespace string, name string, imports ...*v1alpha1.Import) *v1alpha1.Account {
+	return &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     v1alpha1.AccountStatus{Claims: &v1alpha1.AccountClaims{Imports: imports}},
+	}
+}
+
+func newImportConflictTestReconciler(t *testing.T, objects ...client.Object) (*ImportConflictReconciler, client.Client, *events.FakeRecorder) {
+	t.Helper()
+	k8sClient := newFakeClientBuilder(t, objects...).
+		WithStatusSubresource(&v1alpha1.Account{}).
+		WithIndex(&v1alpha1.Account{}, importedAccountIDIndexKey, byImportedAccountIDIndexFunc).
+		Build()
+	recorder := events.NewFakeRecorder(5)
+	return NewImportConflictReconciler(k8sClient, recorder), k8sClient, recorder
+}
+
+func TestImportConflictReconciler_Reconcile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		otherImport    *v1alpha1.Import
+		expectConflict bool
+	}{
+		{
+			name:           "overlapping local subject of the same type",
+			otherImport:    &v1alpha1.Import{Account: importConflictTestExporterID, Subject: "orders.eu.>", Type: v1alpha1.Stream},
+			expectConflict: true,
+		},
+		{
+			name: "overlapping renamed local subject",
+			otherImport: &v1alpha1.Import{Account: importConflictTestExporterID, Subject: "shop.*", LocalSubject: "orders.$1",
+				Type: v1alpha1.Stream},
+			expectConflict: true,
+		},
+		{
+			name:        "disjoint local subject",
+			otherImport: &v1alpha1.Import{Account: importConflictTestExporterID, Subject: "invoices.>", Type: v1alpha1.Stream},
+		},
+		{
+			name:        "other import type",
+			otherImport: &v1alpha1.Import{Account: importConflictTestExporterID, Subject: "orders.>", Type: v1alpha1.Service},
+		},
+		{
+			name:        "other exporting account",
+			otherImport: &v1alpha1.Import{Account: "AOTHER", Subject: "orders.>", Type: v1alpha1.Stream},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			account := importConflictTestAccount("team-a", "orders",
+				&v1alpha1.Import{Account: importConflictTestExporterID, Subject: "orders.>", Type: v1alpha1.Stream})
+			other := importConflictTestAccount("team-b", "shipping", tc.otherImport)
+			unitUnderTest, k8sClient, recorder := newImportConflictTestReconciler(t, account, other)
+
+			// When
+			result, err := unitUnderTest.Reconcile(context.Background(),
+				ctrl.Request{NamespacedName: client.ObjectKeyFromObject(account)})
+
+			// Then
+			require.NoError(t, err)
+			assert.Zero(t, result.RequeueAfter)
+			updated := &v1alpha1.Account{}
+			require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(account), updated))
+			condition := meta.FindStatusCondition(updated.Status.Conditions, conditions.TypeImportConflict)
+			require.NotNil(t, condition)
+			if tc.expectConflict {
+				assert.Equal(t, metav1.ConditionTrue, condition.Status)
+				assert.Equal(t, conditions.ReasonSubjectsOverlap, condition.Reason)
+				assert.Contains(t, condition.Message, "of Account team-b/shipping from account AEXPORTER")
+				assert.Len(t, recorder.Events, 1)
+			} else {
+				assert.Equal(t, metav1.ConditionFalse, condition.Status)
+				assert.Equal(t, conditions.ReasonNoConflicts, condition.Reason)
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestImportConflictReconciler_Reconcile_ShouldNotReportConflictWithItself(t *testing.T) {
+	// Given
+	account := importConflictTestAccount("team-a", "orders",
+		&v1alpha1.Import{Account: importConflictTestExporterID, Subject: "orders.>", Type: v1alpha1.Stream},
+		&v1alpha1.Import{Account: importConflictTestExporterID, Subject: "orders.eu.>", LocalSubject: "eu.>",
+			Type: v1alpha1.Stream})
+	unitUnderTest, k8sClient, _ := newImportConflictTestReconciler(t, account)
+
+	// When
+	_, err := unitUnderTest.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(account)})
+
+	// Then
+	require.NoError(t, err)
+	updated := &v1alpha1.Account{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(account), updated))
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, conditions.TypeImportConflict))
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
@@ -56,6 +57,7 @@ func (t *AccountImportControllerTestSuite) SetupTest() {
 		k8sClient,
 		k8sClient.Scheme(),
 		t.accountImportManagerMock,
+		k8s.NewReferenceGrantClient(k8sClient),
 	)
 }
 
@@ -69,6 +71,7 @@ func (t *AccountImportControllerTestSuite) Test_Reconcile_ShouldSucceed() {
 	t.ensureAccount(t.namespace, t.importAccountName, importAccountID)
 	exportAccountID := testutil.AnyNatsTestAccountID()
 	t.ensureAccount(t.foreignNamespace, t.exportAccountName, exportAccountID)
+	t.ensureReferenceGrant(t.foreignNamespace, v1alpha1.ReferenceGrantFromKindAccountImport, t.namespace)
 
 	resourceInput := v1alpha1.AccountImport{
 		ObjectMeta: metav1.ObjectMeta{
@@ -306,6 +309,7 @@ func (t *AccountImportControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenRule
 	t.ensureAccount(t.namespace, t.importAccountName, importAccountID)
 	exportAccountID := testutil.AnyNatsTestAccountID()
 	t.ensureAccount(t.foreignNamespace, t.exportAccountName, exportAccountID)
+	t.ensureReferenceGrant(t.foreignNamespace, v1alpha1.ReferenceGrantFromKindAccountImport, t.namespace)
 
 	resourceInput := v1alpha1.AccountImport{
 		ObjectMeta: metav1.ObjectMeta{
@@ -359,6 +363,75 @@ func (t *AccountImportControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenRule
 	t.Require().Empty(result.RequeueAfter, "no reconcile requeue expected after successful status update")
 }
 
+func (t *AccountImportControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenExportAccountReferenceNotGranted() {
+	// Given
+	importAccountID := testutil.AnyNatsTestAccountID()
+	t.ensureAccount(t.namespace, t.importAccountName, importAccountID)
+	t.ensureAccount(t.foreignNamespace, t.exportAccountName, testutil.AnyNatsTestAccountID())
+	t.ensureReferenceGrant(t.foreignNamespace, v1alpha1.ReferenceGrantFromKindAccount, t.namespace)
+
+	resourceInput := v1alpha1.AccountImport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.importName,
+			Namespace: t.namespace,
+		},
+		Spec: v1alpha1.AccountImportSpec{
+			AccountName: t.importAccountName,
+			ExportAccountRef: v1alpha1.AccountRef{
+				Name:      t.exportAccountName,
+				Namespace: t.foreignNamespace,
+			},
+			Rules: []v1alpha1.AccountImportRule{
+				{
+					Subject: "foo.*",
+					Type:    v1alpha1.Stream,
+				},
+			},
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, &resourceInput))
+
+	// When
+	result, err := t.runReconcileLoopForNewResource(importAccountID, "")
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().NotNil(result)
+
+	resource := &v1alpha1.AccountImport{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.importNamespacedName, resource))
+	condition := t.assertCondition(resource, conditionTypeBoundToExportAccount, metav1.ConditionFalse, conditionReasonReferenceNotGranted)
+	t.Contains(condition.Message, "is not allowed by a ReferenceGrant")
+	t.assertCondition(resource, conditionTypeReady, metav1.ConditionFalse, conditionReasonNotReady)
+	t.Nil(resource.Status.DesiredClaim, "expected no claim")
+}
+
+func (t *AccountImportControllerTestSuite) Test_mapReferenceGrantToAccountImports_ShouldReturnImportsOfGrantedNamespaces() {
+	// Given
+	t.Require().NoError(ensureNamespace(t.ctx, t.foreignNamespace))
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.AccountImport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.importName,
+			Namespace: t.namespace,
+		},
+		Spec: v1alpha1.AccountImportSpec{
+			AccountName: t.importAccountName,
+			ExportAccountRef: v1alpha1.AccountRef{
+				Name:      t.exportAccountName,
+				Namespace: t.foreignNamespace,
+			},
+			Rules: []v1alpha1.AccountImportRule{{Subject: "foo.*", Type: v1alpha1.Stream}},
+		},
+	}))
+	grant := t.ensureReferenceGrant(t.foreignNamespace, v1alpha1.ReferenceGrantFromKindAccountImport, t.namespace)
+
+	// When
+	requests := t.unitUnderTest.mapReferenceGrantToAccountImports(t.ctx, grant)
+
+	// Then
+	t.Equal([]ctrl.Request{{NamespacedName: t.importNamespacedName}}, requests)
+}
+
 func (t *AccountImportControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenResourceNotFound() {
 	// When
 	_, err := t.unitUnderTest.Reconcile(t.ctx, ctrl.Request{NamespacedName: t.importNamespacedName})
@@ -473,6 +546,22 @@ func (t *AccountImportControllerTestSuite) ensureAccount(namespace, name, accoun
 	}))
 }
 
+func (t *AccountImportControllerTestSuite) ensureReferenceGrant(namespace string, fromKind v1alpha1.ReferenceGrantFromKind, fromNamespace string) *v1alpha1.ReferenceGrant {
+	t.Require().NoError(ensureNamespace(t.ctx, namespace))
+	grant := &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "grant-" + fromNamespace,
+		},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: fromKind, Namespace: fromNamespace}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: v1alpha1.ReferenceGrantToKindAccount}},
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, grant))
+	return grant
+}
+
 type accountImportManagerMock struct {
 	mock.Mock
 }
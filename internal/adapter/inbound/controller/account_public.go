@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// applyPublicConfigMap writes the account ID, public signing keys and exported subjects in the status of state to the
+// ConfigMap named by Account.GetPublicConfigMapName, so that workloads can reference them without reading Secrets. The
+// ConfigMap is owned by state and deleted with it. An existing ConfigMap of that name owned by anything else is not
+// changed.
+func (r *AccountReconciler) applyPublicConfigMap(ctx context.Context, state *v1alpha1.Account) error {
+	configMap := &corev1.ConfigMap{}
+	configMap.Name = state.GetPublicConfigMapName()
+	configMap.Namespace = state.Namespace
+	if r.dryRun {
+		logf.FromContext(ctx).Info("Dry run: skipped applying public ConfigMap",
+			"namespace", configMap.Namespace, "name", configMap.Name)
+		return nil
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.kubernetes, configMap, func() error {
+		if configMap.ResourceVersion != "" && !metav1.IsControlledBy(configMap, state) {
+			return domain.ErrConflict.WithCause(fmt.Errorf("ConfigMap %s exists and is not owned by the Account", configMap.Name))
+		}
+		if configMap.Labels == nil {
+			configMap.Labels = map[string]string{}
+		}
+		configMap.Labels[string(v1alpha1.AccountLabelAccountID)] = state.GetLabel(v1alpha1.AccountLabelAccountID)
+		configMap.Data = toPublicConfigMapData(state)
+		return controllerutil.SetControllerReference(state, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply public ConfigMap %s: %w", configMap.Name, err)
+	}
+	return nil
+}
+
+func toPublicConfigMapData(state *v1alpha1.Account) map[string]string {
+	data := map[string]string{
+		v1alpha1.AccountPublicKeyKey: state.GetLabel(v1alpha1.AccountLabelAccountID),
+	}
+	claims := state.Status.Claims
+	if claims == nil {
+		return data
+	}
+	signingKeys := make([]string, 0, len(claims.SigningKeys))
+	for _, signingKey := range claims.SigningKeys {
+		if signingKey != nil {
+			signingKeys = append(signingKeys, signingKey.Key)
+		}
+	}
+	exports := make([]string, 0, len(claims.Exports))
+	for _, export := range claims.Exports {
+		if export != nil {
+			exports = append(exports, string(export.Subject))
+		}
+	}
+	data[v1alpha1.AccountSigningKeysKey] = strings.Join(signingKeys, "\n")
+	data[v1alpha1.AccountExportsKey] = strings.Join(exports, "\n")
+	return data
+}
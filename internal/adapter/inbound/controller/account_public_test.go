@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newPublicConfigMapTestReconciler(t *testing.T, dryRun bool, objects ...client.Object) (*AccountReconciler, client.Client) {
+	t.Helper()
+	k8sClient := newFakeClientBuilder(t, objects...).Build()
+	return &AccountReconciler{kubernetes: newKubernetesClient(k8sClient), Scheme: k8sClient.Scheme(), dryRun: dryRun}, k8sClient
+}
+
+func publicConfigMapTestAccount() *v1alpha1.Account {
+	return &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team-a",
+			Name:      "orders",
+			UID:       "orders-uid",
+			Labels:    map[string]string{string(v1alpha1.AccountLabelAccountID): "AORDERS"},
+		},
+		Status: v1alpha1.AccountStatus{
+			Claims: &v1alpha1.AccountClaims{
+				SigningKeys: v1alpha1.SigningKeys{{Key: "ASIGN1"}, {Key: "ASIGN2"}},
+				Exports: v1alpha1.Exports{
+					{Name: "orders", Subject: "orders.>", Type: v1alpha1.Stream},
+					{Name: "status", Subject: "orders.status", Type: v1alpha1.Service},
+				},
+			},
+		},
+	}
+}
+
+func TestAccountReconciler_applyPublicConfigMap_ShouldWritePublicKeysAndExports(t *testing.T) {
+	// Given
+	account := publicConfigMapTestAccount()
+	unitUnderTest, k8sClient := newPublicConfigMapTestReconciler(t, false, account)
+
+	// When
+	err := unitUnderTest.applyPublicConfigMap(context.Background(), account)
+
+	// Then
+	require.NoError(t, err)
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(),
+		client.ObjectKey{Namespace: "team-a", Name: "orders-nats-account-public"}, configMap))
+	assert.Equal(t, map[string]string{
+		v1alpha1.AccountPublicKeyKey:   "AORDERS",
+		v1alpha1.AccountSigningKeysKey: "ASIGN1\nASIGN2",
+		v1alpha1.AccountExportsKey:     "orders.>\norders.status",
+	}, configMap.Data)
+	assert.Equal(t, "AORDERS", configMap.Labels[string(v1alpha1.AccountLabelAccountID)])
+	assert.True(t, metav1.IsControlledBy(configMap, account))
+}
+
+func TestAccountReconciler_applyPublicConfigMap_ShouldUpdateOwnedConfigMap(t *testing.T) {
+	// Given
+	account := publicConfigMapTestAccount()
+	unitUnderTest, k8sClient := newPublicConfigMapTestReconciler(t, false, account)
+	require.NoError(t, unitUnderTest.applyPublicConfigMap(context.Background(), account))
+	account.Status.Claims.Exports = account.Status.Claims.Exports[:1]
+
+	// When
+	err := unitUnderTest.applyPublicConfigMap(context.Background(), account)
+
+	// Then
+	require.NoError(t, err)
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(),
+		client.ObjectKey{Namespace: "team-a", Name: "orders-nats-account-public"}, configMap))
+	assert.Equal(t, "orders.>", configMap.Data[v1alpha1.AccountExportsKey])
+}
+
+func TestAccountReconciler_applyPublicConfigMap_ShouldFail_WhenConfigMapIsNotOwnedByAccount(t *testing.T) {
+	// Given
+	account := publicConfigMapTestAccount()
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "orders-nats-account-public"},
+		Data:       map[string]string{"app": "config"},
+	}
+	unitUnderTest, k8sClient := newPublicConfigMapTestReconciler(t, false, account, existing)
+
+	// When
+	err := unitUnderTest.applyPublicConfigMap(context.Background(), account)
+
+	// Then
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(existing), configMap))
+	assert.Equal(t, map[string]string{"app": "config"}, configMap.Data)
+}
+
+func TestAccountReconciler_applyPublicConfigMap_ShouldSkipWrite_WhenDryRun(t *testing.T) {
+	// Given
+	account := publicConfigMapTestAccount()
+	unitUnderTest, k8sClient := newPublicConfigMapTestReconciler(t, true, account)
+
+	// When
+	err := unitUnderTest.applyPublicConfigMap(context.Background(), account)
+
+	// Then
+	require.NoError(t, err)
+	configMaps := &corev1.ConfigMapList{}
+	require.NoError(t, k8sClient.List(context.Background(), configMaps))
+	assert.Empty(t, configMaps.Items)
+}
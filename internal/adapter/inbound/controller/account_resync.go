@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// AccountResyncReconciler requests a repush of the JWT of every Account selected by an AccountResync, one batch per
+// batch interval. The Accounts are processed in namespace/name order and the last one requested is checkpointed in
+// the status, so a resync continues where it stopped after a restart.
+type AccountResyncReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	reporter *statusReporter
+}
+
+func NewAccountResyncReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	recorder events.EventRecorder,
+) *AccountResyncReconciler {
+	return &AccountResyncReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme,
+		reporter: newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=accountresyncs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=accountresyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *AccountResyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	resync := &v1alpha1.AccountResync{}
+	if err := r.Get(ctx, req.NamespacedName, resync); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	if !resync.DeletionTimestamp.IsZero() || resync.Status.CompletedAt != nil {
+		return ctrl.Result{}, nil
+	}
+
+	// Also holds when the resync is reconciled early, after a change of its spec or a restart of the operator
+	batchInterval := accountResyncBatchInterval(resync)
+	if lastBatchAt := resync.Status.LastBatchAt; lastBatchAt != nil {
+		if wait := time.Until(lastBatchAt.Add(batchInterval)); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	accountRefs, err := r.listAccountRefs(ctx, resync)
+	if err != nil {
+		return r.reporter.error(ctx, resync, err)
+	}
+	pending := accountRefs
+	if checkpoint := resync.Status.Checkpoint; checkpoint != "" {
+		pending = slices.DeleteFunc(pending, func(accountRef domain.NamespacedName) bool {
+			return accountRef.String() <= checkpoint
+		})
+	}
+
+	now := metav1.Now()
+	if resync.Status.StartedAt == nil {
+		resync.Status.StartedAt = &now
+		r.reporter.event(resync, eventReasonResyncStarted, actionReconciled, "Started resync of %d accounts", len(pending))
+	}
+	resync.Status.LastBatchAt = &now
+	resync.Status.ObservedGeneration = resync.Generation
+
+	batch := pending[:min(len(pending), accountResyncBatchSize(resync))]
+	requestedAt := now.UTC().Format(time.RFC3339Nano)
+	for _, accountRef := range batch {
+		if err := r.requestRepush(ctx, accountRef, requestedAt); err != nil {
+			resync.Status.Remaining = int64(len(pending))
+			return r.reporter.error(ctx, resync, err)
+		}
+		resync.Status.Checkpoint = accountRef.String()
+		resync.Status.Requested++
+		pending = pending[1:]
+	}
+	resync.Status.Remaining = int64(len(pending))
+
+	if len(pending) > 0 {
+		conditions.MarkReconciling(resync, fmt.Sprintf("Requested repush of %d accounts, %d remaining",
+			resync.Status.Requested, resync.Status.Remaining))
+		if err := r.Status().Update(ctx, resync); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: max(batchInterval, requeueImmediately)}, nil
+	}
+
+	resync.Status.CompletedAt = &now
+	conditions.MarkReconciled(resync, fmt.Sprintf("Requested repush of %d accounts", resync.Status.Requested))
+	if err := r.Status().Update(ctx, resync); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.reporter.event(resync, eventReasonResyncCompleted, actionReconciled, "Requested repush of %d accounts", resync.Status.Requested)
+	return ctrl.Result{}, nil
+}
+
+// listAccountRefs returns the Accounts selected by resync that NAuth pushes the JWT of, sorted by namespace/name.
+func (r *AccountResyncReconciler) listAccountRefs(ctx context.Context, resync *v1alpha1.AccountResync) ([]domain.NamespacedName, error) {
+	selector := labels.Everything()
+	if resync.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(resync.Spec.Selector); err != nil {
+			return nil, domain.ErrBadRequest.WithCause(fmt.Errorf("invalid account selector: %w", err))
+		}
+	}
+	accounts := &v1alpha1.AccountList{}
+	if err := r.List(ctx, accounts, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	accountRefs := make([]domain.NamespacedName, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		if !account.DeletionTimestamp.IsZero() ||
+			account.GetLabel(v1alpha1.AccountLabelManagementPolicy) == v1alpha1.AccountManagementPolicyObserve {
+			continue
+		}
+		accountRefs = append(accountRefs, domain.NewNamespacedName(account.Namespace, account.Name))
+	}
+	slices.SortFunc(accountRefs, func(a, b domain.NamespacedName) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+	return accountRefs, nil
+}
+
+// requestRepush sets the repush annotation of an Account, which makes the Account controller re-sign and push its JWT.
+func (r *AccountResyncReconciler) requestRepush(ctx context.Context, accountRef domain.NamespacedName, requestedAt string) error {
+	account := &v1alpha1.Account{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: accountRef.Namespace, Name: accountRef.Name}, account); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get account %s: %w", accountRef, err)
+	}
+
+	patch := client.MergeFrom(account.DeepCopy())
+	annotations := account.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[v1alpha1.AccountAnnotationRepushRequestedAt] = requestedAt
+	account.SetAnnotations(annotations)
+	if err := r.Patch(ctx, account, patch); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to request repush of account %s: %w", accountRef, err)
+	}
+	return nil
+}
+
+func accountResyncBatchSize(resync *v1alpha1.AccountResync) int {
+	if resync.Spec.BatchSize > 0 {
+		return int(resync.Spec.BatchSize)
+	}
+	return v1alpha1.DefaultAccountResyncBatchSize
+}
+
+func accountResyncBatchInterval(resync *v1alpha1.AccountResync) time.Duration {
+	if resync.Spec.BatchInterval != nil {
+		return resync.Spec.BatchInterval.Duration
+	}
+	return v1alpha1.DefaultAccountResyncBatchInterval
+}
+
+func (r *AccountResyncReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Status updates of the checkpoint must not trigger the next batch before the batch interval
+		For(&v1alpha1.AccountResync{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("accountresync").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Complete(traced("accountresync", r))
+}
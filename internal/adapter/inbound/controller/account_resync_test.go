@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const accountResyncTestLabel = "nauth.io/resync-test"
+
+type AccountResyncControllerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	fakeRecorder *events.FakeRecorder
+
+	resourceName ktypes.NamespacedName
+	namespace    string
+	testLabel    string
+
+	unitUnderTest *AccountResyncReconciler
+}
+
+func TestAccountResyncController_TestSuite(t *testing.T) {
+	suite.Run(t, new(AccountResyncControllerTestSuite))
+}
+
+func (t *AccountResyncControllerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+
+	testName := t.T().Name()
+	t.resourceName = ktypes.NamespacedName{Name: testutil.ScopedTestName("test-resync", testName)}
+	t.namespace = testutil.ScopedTestName("accountresync", testName)
+	// Accounts of other tests are listed too, the resyncs of each test only select its own
+	t.testLabel = testutil.ShortHash(testName)
+
+	t.fakeRecorder = events.NewFakeRecorder(5)
+	t.unitUnderTest = NewAccountResyncReconciler(k8sClient, k8sClient.Scheme(), t.fakeRecorder)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.namespace))
+}
+
+func (t *AccountResyncControllerTestSuite) Test_Reconcile_ShouldRequestRepushInBatches() {
+	// Given
+	t.setupAccount("account-c", nil)
+	t.setupAccount("account-a", nil)
+	t.setupAccount("account-b", nil)
+	t.setupAccount("observed", map[string]string{
+		string(v1alpha1.AccountLabelManagementPolicy): v1alpha1.AccountManagementPolicyObserve,
+	})
+	t.setupAccountResync(v1alpha1.AccountResyncSpec{
+		BatchSize:     2,
+		BatchInterval: &metav1.Duration{Duration: 0},
+	})
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(requeueImmediately, result.RequeueAfter)
+	t.Equal([]bool{true, true, false, false}, t.repushRequested("account-a", "account-b", "account-c", "observed"))
+	resync := t.getAccountResync()
+	t.Equal(t.namespace+"/account-b", resync.Status.Checkpoint)
+	t.Equal(int64(2), resync.Status.Requested)
+	t.Equal(int64(1), resync.Status.Remaining)
+	t.NotNil(resync.Status.StartedAt)
+	t.Nil(resync.Status.CompletedAt)
+	t.False(meta.IsStatusConditionTrue(resync.Status.Conditions, conditions.TypeReady))
+	t.Contains(<-t.fakeRecorder.Events, eventReasonResyncStarted)
+
+	// When
+	result, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Zero(result)
+	t.Equal([]bool{true, true, true, false}, t.repushRequested("account-a", "account-b", "account-c", "observed"))
+	resync = t.getAccountResync()
+	t.Equal(t.namespace+"/account-c", resync.Status.Checkpoint)
+	t.Equal(int64(3), resync.Status.Requested)
+	t.Equal(int64(0), resync.Status.Remaining)
+	t.NotNil(resync.Status.CompletedAt)
+	t.True(meta.IsStatusConditionTrue(resync.Status.Conditions, conditions.TypeReady))
+	t.Contains(<-t.fakeRecorder.Events, eventReasonResyncCompleted)
+}
+
+func (t *AccountResyncControllerTestSuite) Test_Reconcile_ShouldContinueAfterCheckpoint() {
+	// Given
+	t.setupAccount("account-a", nil)
+	t.setupAccount("account-b", nil)
+	resync := t.setupAccountResync(v1alpha1.AccountResyncSpec{})
+	resync.Status.StartedAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	resync.Status.Checkpoint = t.namespace + "/account-a"
+	resync.Status.Requested = 1
+	t.Require().NoError(k8sClient.Status().Update(t.ctx, resync))
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal([]bool{false, true}, t.repushRequested("account-a", "account-b"))
+	resync = t.getAccountResync()
+	t.Equal(int64(2), resync.Status.Requested)
+	t.NotNil(resync.Status.CompletedAt)
+}
+
+func (t *AccountResyncControllerTestSuite) Test_Reconcile_ShouldWaitForBatchInterval() {
+	// Given
+	t.setupAccount("account-a", nil)
+	resync := t.setupAccountResync(v1alpha1.AccountResyncSpec{BatchInterval: &metav1.Duration{Duration: time.Hour}})
+	resync.Status.LastBatchAt = &metav1.Time{Time: time.Now()}
+	t.Require().NoError(k8sClient.Status().Update(t.ctx, resync))
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Greater(result.RequeueAfter, 59*time.Minute)
+	t.Equal([]bool{false}, t.repushRequested("account-a"))
+}
+
+func (t *AccountResyncControllerTestSuite) setupAccount(name string, labels map[string]string) {
+	account := &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: t.namespace,
+			Labels:    map[string]string{accountResyncTestLabel: t.testLabel},
+		},
+	}
+	for key, value := range labels {
+		account.Labels[key] = value
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, account))
+}
+
+func (t *AccountResyncControllerTestSuite) setupAccountResync(spec v1alpha1.AccountResyncSpec) *v1alpha1.AccountResync {
+	spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{accountResyncTestLabel: t.testLabel}}
+	resync := &v1alpha1.AccountResync{
+		ObjectMeta: metav1.ObjectMeta{Name: t.resourceName.Name},
+		Spec:       spec,
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, resync))
+	return resync
+}
+
+func (t *AccountResyncControllerTestSuite) getAccountResync() *v1alpha1.AccountResync {
+	resync := &v1alpha1.AccountResync{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.resourceName, resync))
+	return resync
+}
+
+// repushRequested reports for each of the named Accounts whether a repush of its JWT is requested.
+func (t *AccountResyncControllerTestSuite) repushRequested(names ...string) []bool {
+	result := make([]bool, 0, len(names))
+	for _, name := range names {
+		account := &v1alpha1.Account{}
+		t.Require().NoError(k8sClient.Get(t.ctx, ktypes.NamespacedName{Namespace: t.namespace, Name: name}, account))
+		result = append(result, repushRequested(account))
+	}
+	return result
+}
@@ -21,14 +21,17 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
 	"github.com/WirelessCar/nauth/internal/testutil"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	corev1 "k8s.io/api/core/v1"
 	k8err "k8s.io/apimachinery/pkg/api/errors"
@@ -85,7 +88,11 @@ func (t *AccountControllerTestSuite) SetupTest() {
 		t.accountManagerMock,
 		t.clusterManagerMock,
 		accountClient,
+		k8s.NewReferenceGrantClient(k8sClient),
+		k8s.NewNauthDefaultsClient(k8sClient),
 		t.fakeRecorder,
+		nil,
+		false,
 	)
 
 	t.Require().NoError(ensureNamespace(t.ctx, t.operatorNamespace))
@@ -152,6 +159,33 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldSetFinalizer() {
 	t.Contains(account.Finalizers, finalizerAccount)
 }
 
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldNotManageAccount_WhilePaused() {
+	// Given
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, testutil.AnyNatsTestAccountID())
+			account.Spec.Paused = true
+		}),
+	)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.Zero(result.RequeueAfter)
+	t.clusterManagerMock.AssertNotCalled(t.T(), "GetClusterTarget", mock.Anything, mock.Anything)
+	t.accountManagerMock.AssertNotCalled(t.T(), "CreateOrUpdate", mock.Anything, mock.Anything)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	c := meta.FindStatusCondition(account.Status.Conditions, conditions.TypePaused)
+	t.Require().NotNil(c)
+	t.Equal(metav1.ConditionTrue, c.Status)
+	t.Equal(conditions.ReasonPaused, c.Reason)
+}
+
 func (t *AccountControllerTestSuite) Test_Reconcile_ShouldBootstrap_WhenCreatingAccount() {
 	// Given
 	importLimit := int64(3)
@@ -254,6 +288,31 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldBootstrap_WhenCreating
 	t.Empty(t.fakeRecorder.Events)
 }
 
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldNotBootstrap_WhenDryRun() {
+	// Given
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+		}),
+	)
+	t.unitUnderTest.dryRun = true
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.Zero(result.RequeueAfter)
+	t.accountManagerMock.AssertNotCalled(t.T(), "CreateOrUpdate", mock.Anything, mock.Anything)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	t.Empty(account.GetLabel(v1alpha1.AccountLabelAccountID))
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonDryRun)
+}
+
 func (t *AccountControllerTestSuite) Test_Reconcile_ShouldFail_WhenCreateOrUpdateFails() {
 	// Given
 	t.setupAccount(
@@ -276,14 +335,40 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldFail_WhenCreateOrUpdat
 	account := &v1alpha1.Account{}
 	err = k8sClient.Get(t.ctx, t.accountNamespacedRef, account)
 	t.Require().NoError(err)
-	for _, c := range account.Status.Conditions {
-		t.Equal(metav1.ConditionFalse, c.Status)
-		t.Equal(conditionReasonErrored, c.Reason)
-	}
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, conditions.ReasonErrored)
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeSynced, metav1.ConditionFalse, conditions.ReasonErrored)
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeDegraded, metav1.ConditionFalse, conditions.ReasonErrored)
 	t.Len(t.fakeRecorder.Events, 1)
 	t.Contains(<-t.fakeRecorder.Events, "failed to bootstrap account: a test error")
 }
 
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldNotRetry_WhenCreateOrUpdateFailsPermanently() {
+	// Given
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+		}),
+	)
+
+	accountsManagerErr := domain.ErrInvalidConfig.WithCause(fmt.Errorf("malformed seed"))
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.accountManagerMock.mockCreateOrUpdateError(t.ctx, mock.Anything, accountsManagerErr).Once()
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.NoError(err)
+	t.Positive(result.RequeueAfter)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, string(domain.ErrInvalidConfig))
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeSynced, metav1.ConditionFalse, string(domain.ErrInvalidConfig))
+	t.Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, "malformed seed")
+}
+
 func (t *AccountControllerTestSuite) Test_Reconcile_ShouldFail_WhenChangingNatsCluster() {
 	// Given
 	t.setupAccount(
@@ -369,6 +454,65 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldDeleteAccountMarkedFor
 	t.True(k8err.IsNotFound(err))
 }
 
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldApplyDeletionPolicy() {
+	testCases := []struct {
+		testName       string
+		deletionPolicy v1alpha1.AccountDeletionPolicy
+		setupMocks     func()
+		expectedReason string
+	}{
+		{
+			testName:       "delete",
+			deletionPolicy: v1alpha1.AccountDeletionPolicyDelete,
+			setupMocks:     func() { t.accountManagerMock.mockDelete(t.ctx, mock.Anything, nil).Once() },
+			expectedReason: eventReasonAccountDeleted,
+		},
+		{
+			testName:       "retain",
+			deletionPolicy: v1alpha1.AccountDeletionPolicyRetain,
+			setupMocks:     func() {},
+			expectedReason: eventReasonAccountRetained,
+		},
+		{
+			testName:       "orphan",
+			deletionPolicy: v1alpha1.AccountDeletionPolicyOrphan,
+			setupMocks:     func() { t.accountManagerMock.mockOrphan(t.ctx, mock.Anything, nil).Once() },
+			expectedReason: eventReasonAccountOrphaned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func() {
+			// Given
+			t.accountName = "account-" + tc.testName
+			t.accountNamespacedRef.Name = t.accountName
+			t.setupAccount(
+				t.defaultAccount(func(account *v1alpha1.Account) {
+					account.Finalizers = append(account.Finalizers, finalizerAccount)
+					account.SetLabel(v1alpha1.AccountLabelAccountID, testutil.AnyNatsTestAccountID())
+					account.Spec.DeletionPolicy = tc.deletionPolicy
+				}),
+			)
+			account := &v1alpha1.Account{}
+			t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+			t.Require().NoError(k8sClient.Delete(t.ctx, account))
+
+			t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+			tc.setupMocks()
+
+			// When
+			_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+			// Then
+			t.Require().NoError(err)
+			err = k8sClient.Get(t.ctx, t.accountNamespacedRef, account)
+			t.True(k8err.IsNotFound(err))
+			t.Require().Len(t.fakeRecorder.Events, 1)
+			t.Contains(<-t.fakeRecorder.Events, "Normal "+tc.expectedReason)
+		})
+	}
+}
+
 func (t *AccountControllerTestSuite) Test_Reconcile_ShouldDeleteAccountMarkedForDeletion_WhenAccountIDCanBeFound() {
 	// Given
 	accountID := nauth.AccountID(testutil.AnyNatsTestAccountID())
@@ -468,10 +612,7 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldFail_WhenDeleteFails()
 
 	err = k8sClient.Get(t.ctx, t.accountNamespacedRef, account)
 	t.Require().NoError(err)
-	for _, c := range account.Status.Conditions {
-		t.Equal(metav1.ConditionFalse, c.Status)
-		t.Equal(conditionReasonErrored, c.Reason)
-	}
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, conditions.ReasonErrored)
 	t.Require().Len(t.fakeRecorder.Events, 1)
 	t.Contains(<-t.fakeRecorder.Events, deletionErr.Error())
 }
@@ -502,6 +643,259 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldImportObservedAccount(
 	t.NoError(err)
 }
 
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldRecordDriftDetected_WhenObservedClaimsChange() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelManagementPolicy, v1alpha1.AccountManagementPolicyObserve)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+			account.Status.ClaimsHash = "previous-hash"
+		}),
+	)
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.accountManagerMock.mockImport(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:       accountID,
+		AccountSignedBy: "OPERATOR_SIGNING_KEY",
+		Claims:          &nauth.AccountClaims{},
+		ClaimsHash:      "changed-hash",
+	}).Once()
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, "Warning "+eventReasonDriftDetected)
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldRecordEvents_WhenAccountIsCreatedAndPushed() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+		}),
+	)
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.accountManagerMock.mockCreateOrUpdate(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:         accountID,
+		AccountSignedBy:   "OPERATOR_SIGNING_KEY",
+		ClaimsHash:        "claims-hash",
+		SigningKeyCreated: true,
+		JWTPushed:         true,
+	}).Once()
+
+	// When (bootstrap)
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().Len(t.fakeRecorder.Events, 2)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonSigningKeyCreated)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonJWTPushed)
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldForcePushOnce_WhenRepushIsRequested() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	requestedAt := "2025-01-02T03:04:05Z"
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+			account.SetAnnotations(map[string]string{v1alpha1.AccountAnnotationRepushRequestedAt: requestedAt})
+		}),
+	)
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	var forcePushes []bool
+	t.accountManagerMock.mockCreateOrUpdateFn(t.ctx, mock.Anything, func(request nauth.AccountRequest) (*nauth.AccountResult, error) {
+		forcePushes = append(forcePushes, request.ForcePush)
+		return &nauth.AccountResult{
+			AccountID:       accountID,
+			AccountSignedBy: "OPERATOR_SIGNING_KEY",
+			ClaimsHash:      "claims-hash",
+			JWTPushed:       request.ForcePush,
+		}, nil
+	}).Twice()
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+	t.Require().NoError(err)
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal([]bool{true, false}, forcePushes)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	t.Equal(requestedAt, account.Status.ObservedRepushRequestedAt)
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldAdoptExistingAccount_WhenPreviousAccountIsDeleted() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+			account.Spec.AdoptExisting = true
+		}),
+	)
+	source := domain.NewNamespacedName(t.accountNamespace, "previous-account")
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.accountManagerMock.mockFindAdoptionSource(t.ctx, mock.Anything, &source).Once()
+	t.accountManagerMock.mockAdopt(t.ctx, mock.Anything, source, nil).Once()
+	t.accountManagerMock.mockCreateOrUpdate(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:       accountID,
+		AccountSignedBy: "OPERATOR_SIGNING_KEY",
+		ClaimsHash:      "claims-hash",
+	}).Once()
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonAccountAdopted)
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldFail_WhenAdoptedAccountIsStillBound() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: "previous-account", Namespace: t.accountNamespace},
+	}))
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+			account.Spec.AdoptExisting = true
+		}),
+	)
+	source := domain.NewNamespacedName(t.accountNamespace, "previous-account")
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.accountManagerMock.mockFindAdoptionSource(t.ctx, mock.Anything, &source).Once()
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.NoError(err)
+	t.Positive(result.RequeueAfter)
+	t.accountManagerMock.AssertNotCalled(t.T(), "Adopt", mock.Anything, mock.Anything, mock.Anything)
+	t.accountManagerMock.AssertNotCalled(t.T(), "CreateOrUpdate", mock.Anything, mock.Anything)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, string(domain.ErrAccountStillBound))
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldReportPendingChanges_WhenMaintenanceWindowActive() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+		}),
+	)
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	for range 3 {
+		t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	}
+	t.accountManagerMock.mockCreateOrUpdate(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:       accountID,
+		AccountSignedBy: "OPERATOR_SIGNING_KEY",
+		ClaimsHash:      "claims-hash",
+		JWTPushed:       true,
+	}).Once()
+	t.accountManagerMock.mockCreateOrUpdate(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:       accountID,
+		AccountSignedBy: "OPERATOR_SIGNING_KEY",
+		ClaimsHash:      "new-claims-hash",
+		PendingChanges:  &nauth.AccountPendingChanges{ClaimsHash: "new-claims-hash", Fields: []string{"limits"}, Until: until},
+	}).Twice()
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+	t.Require().NoError(err)
+	<-t.fakeRecorder.Events
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+	t.Require().NoError(err)
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.InDelta(time.Hour, result.RequeueAfter, float64(time.Minute))
+	t.Require().Len(t.fakeRecorder.Events, 1, "deferred push should only be recorded once")
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonPushDeferred)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	t.Equal("claims-hash", account.Status.ClaimsHash)
+	t.Require().NotNil(account.Status.PendingChanges)
+	t.Equal("new-claims-hash", account.Status.PendingChanges.ClaimsHash)
+	t.Equal([]string{"limits"}, account.Status.PendingChanges.Fields)
+	t.True(until.Equal(account.Status.PendingChanges.Until.Time))
+	t.True(meta.IsStatusConditionTrue(account.Status.Conditions, conditionTypeReady))
+	synced := meta.FindStatusCondition(account.Status.Conditions, conditions.TypeSynced)
+	t.Require().NotNil(synced)
+	t.Equal(metav1.ConditionFalse, synced.Status)
+	t.Equal(conditionReasonMaintenanceWindow, synced.Reason)
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldKeepJWTMetadata_WhenLiveJWTUnknown() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+		}),
+	)
+
+	issuedAt := time.Date(2026, time.October, 16, 8, 0, 0, 0, time.UTC)
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.accountManagerMock.mockCreateOrUpdate(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:       accountID,
+		AccountSignedBy: "OPERATOR_SIGNING_KEY",
+		ClaimsHash:      "claims-hash",
+		JWT:             &nauth.JWTMetadata{ID: "JWT-ID", IssuedAt: issuedAt, Issuer: "OPERATOR_SIGNING_KEY"},
+		JWTPushed:       true,
+	}).Once()
+	t.accountManagerMock.mockCreateOrUpdate(t.ctx, mock.Anything, &nauth.AccountResult{
+		AccountID:       accountID,
+		AccountSignedBy: "OPERATOR_SIGNING_KEY",
+		ClaimsHash:      "claims-hash",
+	}).Once()
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+	t.Require().NoError(err)
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	t.Require().NotNil(account.Status.JWT)
+	t.Equal("JWT-ID", account.Status.JWT.ID)
+	t.True(issuedAt.Equal(account.Status.JWT.IssuedAt.Time))
+	t.Equal("OPERATOR_SIGNING_KEY", account.Status.JWT.Issuer)
+	t.Nil(account.Status.JWT.ExpiresAt)
+}
+
 func (t *AccountControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenOperatorVersionChanges() {
 	// Given
 	accountID := testutil.AnyNatsTestAccountID()
@@ -627,6 +1021,41 @@ func (t *AccountControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenAccountExp
 	t.Require().Equal(expectAdoptions, account.Status.Adoptions)
 }
 
+func (t *AccountControllerTestSuite) Test_newCachedAccountIDReader_ShouldRequireReferenceGrant_WhenCrossNamespace() {
+	// Given
+	exportNamespace := testutil.ScopedTestName("export", t.T().Name())
+	t.Require().NoError(ensureNamespace(t.ctx, exportNamespace))
+	exportAccountID := testutil.AnyNatsTestAccountID()
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "export-account",
+			Namespace: exportNamespace,
+			Labels:    map[string]string{string(v1alpha1.AccountLabelAccountID): exportAccountID},
+		},
+	}))
+	exportAccountRef := domain.NewNamespacedName(exportNamespace, "export-account")
+	grantReader := k8s.NewReferenceGrantClient(k8sClient)
+	newReader := func() ResolveAccountIDFn {
+		return newCachedAccountIDReader(t.ctx, k8s.NewAccountClient(k8sClient), grantReader, domain.Namespace(t.accountNamespace))
+	}
+
+	// When
+	_, notGrantedErr := newReader()(exportAccountRef)
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: exportNamespace},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: v1alpha1.ReferenceGrantFromKindAccount, Namespace: t.accountNamespace}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: v1alpha1.ReferenceGrantToKindAccount, Name: "export-account"}},
+		},
+	}))
+	grantedAccountID, grantedErr := newReader()(exportAccountRef)
+
+	// Then
+	t.ErrorIs(notGrantedErr, domain.ErrReferenceNotGranted)
+	t.NoError(grantedErr)
+	t.Equal(nauth.AccountID(exportAccountID), grantedAccountID)
+}
+
 func (t *AccountControllerTestSuite) anyExportClaim(observedGeneration int64) *v1alpha1.AccountExportClaim {
 	subject := v1alpha1.Subject(fmt.Sprintf("foo.%d.>", observedGeneration))
 	return &v1alpha1.AccountExportClaim{
@@ -758,10 +1187,175 @@ func (o *accountManagerMock) mockDelete(ctx interface{}, state interface{}, err
 	return call
 }
 
+func (o *accountManagerMock) Orphan(ctx context.Context, reference nauth.AccountReference) error {
+	args := o.Called(ctx, reference)
+	return args.Error(0)
+}
+
+func (o *accountManagerMock) mockOrphan(ctx interface{}, state interface{}, err error) *mock.Call {
+	call := o.On("Orphan", ctx, state)
+	call.Return(err)
+	return call
+}
+
 func (o *accountManagerMock) mockImport(ctx interface{}, state interface{}, result *nauth.AccountResult) *mock.Call {
 	call := o.On("Import", ctx, state)
 	call.Return(result, nil)
 	return call
 }
 
+func (o *accountManagerMock) FindAdoptionSource(ctx context.Context, reference nauth.AccountReference) (*domain.NamespacedName, error) {
+	args := o.Called(ctx, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NamespacedName), args.Error(1)
+}
+
+func (o *accountManagerMock) mockFindAdoptionSource(ctx interface{}, state interface{}, result *domain.NamespacedName) *mock.Call {
+	call := o.On("FindAdoptionSource", ctx, state)
+	call.Return(result, nil)
+	return call
+}
+
+func (o *accountManagerMock) Adopt(ctx context.Context, reference nauth.AccountReference, source domain.NamespacedName) error {
+	args := o.Called(ctx, reference, source)
+	return args.Error(0)
+}
+
+func (o *accountManagerMock) mockAdopt(ctx interface{}, state interface{}, source interface{}, err error) *mock.Call {
+	call := o.On("Adopt", ctx, state, source)
+	call.Return(err)
+	return call
+}
+
 var _ inbound.AccountManager = (*accountManagerMock)(nil)
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldBlockDeletion_WhenUsersExist() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+		}),
+	)
+	user := t.createBoundUser("bound-user", accountID)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	t.Require().NoError(k8sClient.Delete(t.ctx, account))
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.accountManagerMock.AssertNotCalled(t.T(), "Delete", mock.Anything, mock.Anything)
+
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeDeletionBlocked, metav1.ConditionTrue, conditions.ReasonUsersExist)
+	t.Contains(meta.FindStatusCondition(account.Status.Conditions, conditions.TypeDeletionBlocked).Message, user.Name)
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKeyFromObject(user), user))
+}
+
+func (t *AccountControllerTestSuite) Test_Reconcile_ShouldDeleteUsersFirst_WhenUserDeletionPolicyIsCascade() {
+	// Given
+	accountID := testutil.AnyNatsTestAccountID()
+	t.setupAccount(
+		t.defaultAccount(func(account *v1alpha1.Account) {
+			account.Finalizers = append(account.Finalizers, finalizerAccount)
+			account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+			account.Spec.UserDeletionPolicy = v1alpha1.AccountUserDeletionPolicyCascade
+		}),
+	)
+	user := t.createBoundUser("bound-user", accountID)
+
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	t.Require().NoError(k8sClient.Delete(t.ctx, account))
+
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+
+	// When (users are deleted, the account waits for them)
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	t.accountManagerMock.AssertNotCalled(t.T(), "Delete", mock.Anything, mock.Anything)
+	err = k8sClient.Get(t.ctx, client.ObjectKeyFromObject(user), user)
+	t.True(k8err.IsNotFound(err))
+	t.Require().NoError(k8sClient.Get(t.ctx, t.accountNamespacedRef, account))
+	assertCondition(t.T(), account.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, conditions.ReasonDeleting)
+
+	// When (users are gone)
+	t.accountManagerMock.mockDelete(t.ctx, mock.Anything, nil).Once()
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.accountNamespacedRef})
+
+	// Then
+	t.Require().NoError(err)
+	err = k8sClient.Get(t.ctx, t.accountNamespacedRef, account)
+	t.True(k8err.IsNotFound(err))
+}
+
+func (t *AccountControllerTestSuite) createBoundUser(name string, accountID string) *v1alpha1.User {
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: t.accountNamespace,
+			Labels: map[string]string{
+				string(v1alpha1.UserLabelAccountID): accountID,
+			},
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: t.accountName,
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, user))
+	return user
+}
+
+func Test_accountResyncInterval(t *testing.T) {
+	testCases := []struct {
+		name             string
+		managementPolicy string
+		observedClaims   []v1alpha1.AccountClaimField
+		observeInterval  time.Duration
+		expected         time.Duration
+	}{
+		{
+			name:             "observed_with_observe_interval",
+			managementPolicy: v1alpha1.AccountManagementPolicyObserve,
+			observeInterval:  time.Minute,
+			expected:         time.Minute,
+		},
+		{
+			name:             "observed_without_observe_interval",
+			managementPolicy: v1alpha1.AccountManagementPolicyObserve,
+			expected:         5 * time.Minute,
+		},
+		{
+			name:            "managed_with_observe_interval",
+			observeInterval: time.Minute,
+			expected:        5 * time.Minute,
+		},
+		{
+			name:            "observed_claims_with_observe_interval",
+			observedClaims:  []v1alpha1.AccountClaimField{v1alpha1.AccountClaimFieldImports},
+			observeInterval: time.Minute,
+			expected:        time.Minute,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := &v1alpha1.Account{Spec: v1alpha1.AccountSpec{ObservedClaims: tc.observedClaims}}
+			if tc.managementPolicy != "" {
+				account.SetLabel(v1alpha1.AccountLabelManagementPolicy, tc.managementPolicy)
+			}
+			clusterTarget := &nauth.ClusterTarget{ObserveInterval: tc.observeInterval}
+			require.Equal(t, tc.expected, accountResyncInterval(account, clusterTarget))
+		})
+	}
+}
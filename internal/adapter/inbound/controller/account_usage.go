@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/metrics"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// DefaultAccountUsageInterval is how often the usage of an Account is read when not configured.
+const DefaultAccountUsageInterval = time.Minute
+
+// DefaultAccountUsageWarningThreshold is the percentage of a JetStream limit at which the usage of an Account is
+// warned about when not configured.
+const DefaultAccountUsageWarningThreshold = 90
+
+// AccountUsageReconciler periodically reads the usage of every Account from its NATS cluster and reports it in
+// status.usage and as Prometheus metrics. Accounts using at least warningThreshold percent of a JetStream storage
+// limit are reported in the UsageWarning condition.
+type AccountUsageReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	clusterManager   inbound.ClusterManager
+	usageManager     inbound.AccountUsageManager
+	interval         time.Duration
+	warningThreshold int
+	reporter         *statusReporter
+}
+
+// NewAccountUsageReconciler creates an AccountUsageReconciler. A warningThreshold of 0 disables the UsageWarning
+// condition.
+func NewAccountUsageReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	clusterManager inbound.ClusterManager,
+	usageManager inbound.AccountUsageManager,
+	interval time.Duration,
+	warningThreshold int,
+	recorder events.EventRecorder,
+) *AccountUsageReconciler {
+	return &AccountUsageReconciler{
+		Client:           k8sClient,
+		Scheme:           scheme,
+		clusterManager:   clusterManager,
+		usageManager:     usageManager,
+		interval:         interval,
+		warningThreshold: warningThreshold,
+		reporter:         newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *AccountUsageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	natsAccount := &v1alpha1.Account{}
+	if err := r.Get(ctx, req.NamespacedName, natsAccount); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.ForgetAccountUsage(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	if !natsAccount.DeletionTimestamp.IsZero() {
+		metrics.ForgetAccountUsage(req.Namespace, req.Name)
+		return ctrl.Result{}, nil
+	}
+	requeue := ctrl.Result{RequeueAfter: time.Duration(float64(r.interval) * (0.9 + 0.2*rand.Float64()))}
+
+	// Accounts that have not been created in NATS yet are checked again on the next interval
+	accountID := natsAccount.GetLabel(v1alpha1.AccountLabelAccountID)
+	if accountID == "" {
+		return requeue, nil
+	}
+
+	usage, err := r.getUsage(ctx, natsAccount)
+	if err != nil {
+		// The Account controller reports an unreachable cluster in the Ready condition
+		log.Info("Failed to read account usage", "error", err.Error())
+		return requeue, nil
+	}
+
+	patch := client.MergeFrom(natsAccount.DeepCopy())
+	natsAccount.Status.Usage = toAPIAccountUsage(usage)
+	warnings := r.reportUsageWarnings(natsAccount, usage)
+	if err := r.Status().Patch(ctx, natsAccount, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update Account usage status: %w", err)
+	}
+	metrics.ReportAccountUsage(natsAccount.Namespace, natsAccount.Name, accountID, *usage)
+	metrics.ReportAccountUsageWarning(natsAccount.Namespace, natsAccount.Name, accountID, len(warnings) > 0)
+	return requeue, nil
+}
+
+// reportUsageWarnings sets the UsageWarning condition of natsAccount and records a Warning event when its JetStream
+// usage reaches the warning threshold. It returns the storage limits approached.
+func (r *AccountUsageReconciler) reportUsageWarnings(natsAccount *v1alpha1.Account, usage *domain.NatsAccountUsage) []string {
+	if r.warningThreshold <= 0 {
+		meta.RemoveStatusCondition(&natsAccount.Status.Conditions, conditions.TypeUsageWarning)
+		return nil
+	}
+	wasWarning := meta.IsStatusConditionTrue(natsAccount.Status.Conditions, conditions.TypeUsageWarning)
+	warnings := jetStreamUsageWarnings(natsAccount.Status.Claims, usage, r.warningThreshold)
+	if len(warnings) == 0 {
+		conditions.Set(natsAccount, conditions.TypeUsageWarning, metav1.ConditionFalse, conditions.ReasonWithinLimits,
+			fmt.Sprintf("JetStream usage is below %d%% of its limits", r.warningThreshold))
+		return nil
+	}
+	message := strings.Join(warnings, ", ")
+	conditions.Set(natsAccount, conditions.TypeUsageWarning, metav1.ConditionTrue, conditions.ReasonLimitApproached, message)
+	if !wasWarning {
+		r.reporter.warning(natsAccount, eventReasonUsageLimitApproached, actionObserved, "%s", message)
+	}
+	return warnings
+}
+
+// jetStreamUsageWarnings describes the JetStream storage of usage at or above threshold percent of its limit in
+// claims. Tiered limits are summed over the tiers, as usage is not reported per tier. Unlimited and disabled storage
+// is never warned about.
+func jetStreamUsageWarnings(claims *v1alpha1.AccountClaims, usage *domain.NatsAccountUsage, threshold int) []string {
+	if claims == nil || usage.JetStream == nil {
+		return nil
+	}
+	memoryLimit, diskLimit := jetStreamStorageLimits(claims)
+	var warnings []string
+	for _, storage := range []struct {
+		name  string
+		used  uint64
+		limit int64
+	}{
+		{name: "memory", used: usage.JetStream.MemoryBytes, limit: memoryLimit},
+		{name: "disk", used: usage.JetStream.StorageBytes, limit: diskLimit},
+	} {
+		if storage.limit <= 0 {
+			continue
+		}
+		if percent := storage.used * 100 / uint64(storage.limit); percent >= uint64(threshold) {
+			warnings = append(warnings, fmt.Sprintf("JetStream %s storage at %d%% of its limit (%d of %d bytes)",
+				storage.name, percent, storage.used, storage.limit))
+		}
+	}
+	return warnings
+}
+
+// jetStreamStorageLimits returns the memory and disk storage limits of claims, or -1 when unlimited.
+func jetStreamStorageLimits(claims *v1alpha1.AccountClaims) (int64, int64) {
+	if len(claims.JetStreamTieredLimits) == 0 {
+		if claims.JetStreamLimits == nil {
+			return -1, -1
+		}
+		return storageLimit(claims.JetStreamLimits.MemoryStorage), storageLimit(claims.JetStreamLimits.DiskStorage)
+	}
+	var memoryLimit, diskLimit int64
+	for _, limits := range claims.JetStreamTieredLimits {
+		memoryLimit = addStorageLimit(memoryLimit, storageLimit(limits.MemoryStorage))
+		diskLimit = addStorageLimit(diskLimit, storageLimit(limits.DiskStorage))
+	}
+	return memoryLimit, diskLimit
+}
+
+func storageLimit(limit *int64) int64 {
+	if limit == nil || *limit < 0 {
+		return -1
+	}
+	return *limit
+}
+
+func addStorageLimit(sum int64, limit int64) int64 {
+	if sum < 0 || limit < 0 {
+		return -1
+	}
+	return sum + limit
+}
+
+func (r *AccountUsageReconciler) getUsage(ctx context.Context, natsAccount *v1alpha1.Account) (*domain.NatsAccountUsage, error) {
+	accountClusterRef, err := toNAuthClusterRef(natsAccount.Spec.NatsClusterRef, natsAccount.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterTarget, err := r.clusterManager.GetClusterTarget(ctx, accountClusterRef)
+	if err != nil {
+		return nil, err
+	}
+	return r.usageManager.GetUsage(ctx, toAccountReference(natsAccount, *clusterTarget))
+}
+
+func toAPIAccountUsage(usage *domain.NatsAccountUsage) *v1alpha1.AccountUsage {
+	result := &v1alpha1.AccountUsage{
+		Connections:   int32(usage.Connections),
+		LeafNodes:     int32(usage.LeafNodes),
+		Subscriptions: int32(usage.Subscriptions),
+		ObservedAt:    metav1.Now(),
+	}
+	if usage.JetStream != nil {
+		result.JetStream = &v1alpha1.AccountJetStreamUsage{
+			MemoryBytes:  int64(usage.JetStream.MemoryBytes),
+			StorageBytes: int64(usage.JetStream.StorageBytes),
+		}
+	}
+	return result
+}
+
+func (r *AccountUsageReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Its own status updates must not trigger a reconcile
+		For(&v1alpha1.Account{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("accountusage").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Complete(traced("accountusage", r))
+}
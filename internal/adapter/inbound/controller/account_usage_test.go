@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type AccountUsageControllerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	clusterManagerMock *clusterManagerMock
+	usageManagerMock   *accountUsageManagerMock
+	fakeRecorder       *events.FakeRecorder
+
+	resourceName ktypes.NamespacedName
+
+	unitUnderTest *AccountUsageReconciler
+}
+
+func TestAccountUsageController_TestSuite(t *testing.T) {
+	suite.Run(t, new(AccountUsageControllerTestSuite))
+}
+
+func (t *AccountUsageControllerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+
+	testName := t.T().Name()
+	t.resourceName = ktypes.NamespacedName{
+		Name:      testutil.ScopedTestName("test-account", testName),
+		Namespace: testutil.ScopedTestName("usage", testName),
+	}
+
+	t.clusterManagerMock = &clusterManagerMock{}
+	t.usageManagerMock = &accountUsageManagerMock{}
+	t.fakeRecorder = events.NewFakeRecorder(5)
+	t.unitUnderTest = NewAccountUsageReconciler(
+		k8sClient,
+		k8sClient.Scheme(),
+		t.clusterManagerMock,
+		t.usageManagerMock,
+		time.Minute,
+		DefaultAccountUsageWarningThreshold,
+		t.fakeRecorder,
+	)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.resourceName.Namespace))
+}
+
+func (t *AccountUsageControllerTestSuite) TearDownTest() {
+	t.clusterManagerMock.AssertExpectations(t.T())
+	t.usageManagerMock.AssertExpectations(t.T())
+}
+
+func (t *AccountUsageControllerTestSuite) Test_Reconcile_ShouldReportUsage_WhenAccountIsCreated() {
+	// Given
+	t.createAccount(testutil.AnyNatsTestAccountID())
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.usageManagerMock.mockGetUsage(&domain.NatsAccountUsage{
+		Connections:   3,
+		LeafNodes:     1,
+		Subscriptions: 12,
+		JetStream:     &domain.NatsJetStreamUsage{MemoryBytes: 1024, StorageBytes: 4096},
+	}, nil)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Positive(result.RequeueAfter)
+	account := t.getAccount()
+	t.Require().NotNil(account.Status.Usage)
+	t.Equal(int32(3), account.Status.Usage.Connections)
+	t.Equal(int32(1), account.Status.Usage.LeafNodes)
+	t.Equal(int32(12), account.Status.Usage.Subscriptions)
+	t.Require().NotNil(account.Status.Usage.JetStream)
+	t.Equal(int64(4096), account.Status.Usage.JetStream.StorageBytes)
+}
+
+func (t *AccountUsageControllerTestSuite) Test_Reconcile_ShouldWarn_WhenJetStreamUsageApproachesLimits() {
+	// Given
+	t.createAccount(testutil.AnyNatsTestAccountID())
+	diskStorage := int64(4096)
+	account := t.getAccount()
+	account.Status.Claims = &v1alpha1.AccountClaims{
+		JetStreamLimits: &v1alpha1.JetStreamLimits{DiskStorage: &diskStorage},
+	}
+	t.Require().NoError(k8sClient.Status().Update(t.ctx, account))
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.usageManagerMock.mockGetUsage(&domain.NatsAccountUsage{
+		JetStream: &domain.NatsJetStreamUsage{StorageBytes: 3900},
+	}, nil)
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	condition := meta.FindStatusCondition(t.getAccount().Status.Conditions, conditions.TypeUsageWarning)
+	t.Require().NotNil(condition)
+	t.Equal(metav1.ConditionTrue, condition.Status)
+	t.Equal(conditions.ReasonLimitApproached, condition.Reason)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonUsageLimitApproached)
+}
+
+func (t *AccountUsageControllerTestSuite) Test_Reconcile_ShouldRequeueWithoutUsage_WhenAccountIDIsMissing() {
+	// Given
+	t.createAccount("")
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Positive(result.RequeueAfter)
+	t.Nil(t.getAccount().Status.Usage)
+	t.usageManagerMock.AssertNotCalled(t.T(), "GetUsage", mock.Anything, mock.Anything)
+}
+
+func (t *AccountUsageControllerTestSuite) Test_Reconcile_ShouldKeepPreviousUsage_WhenClusterIsUnreachable() {
+	// Given
+	t.createAccount(testutil.AnyNatsTestAccountID())
+	t.clusterManagerMock.mockGetClusterTarget(createDummyClusterTarget(), nil)
+	t.usageManagerMock.mockGetUsage(nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("connection refused")))
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Positive(result.RequeueAfter)
+	t.Nil(t.getAccount().Status.Usage)
+}
+
+func (t *AccountUsageControllerTestSuite) createAccount(accountID string) {
+	account := &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.resourceName.Name,
+			Namespace: t.resourceName.Namespace,
+		},
+	}
+	if accountID != "" {
+		account.Labels = map[string]string{string(v1alpha1.AccountLabelAccountID): accountID}
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, account))
+}
+
+func (t *AccountUsageControllerTestSuite) getAccount() *v1alpha1.Account {
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.resourceName, account))
+	return account
+}
+
+type accountUsageManagerMock struct {
+	mock.Mock
+}
+
+func (m *accountUsageManagerMock) GetUsage(ctx context.Context, reference nauth.AccountReference) (*domain.NatsAccountUsage, error) {
+	args := m.Called(ctx, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NatsAccountUsage), args.Error(1)
+}
+
+func (m *accountUsageManagerMock) mockGetUsage(result *domain.NatsAccountUsage, err error) {
+	m.On("GetUsage", mock.Anything, mock.Anything).Return(result, err).Once()
+}
+
+var _ inbound.AccountUsageManager = (*accountUsageManagerMock)(nil)
+
+func Test_jetStreamUsageWarnings(t *testing.T) {
+	limit := func(value int64) *int64 {
+		return &value
+	}
+	testCases := []struct {
+		testName         string
+		claims           *v1alpha1.AccountClaims
+		usage            domain.NatsJetStreamUsage
+		expectedWarnings []string
+	}{
+		{
+			testName: "should_not_warn_without_claims",
+			usage:    domain.NatsJetStreamUsage{StorageBytes: 1024},
+		},
+		{
+			testName: "should_not_warn_below_threshold",
+			claims:   &v1alpha1.AccountClaims{JetStreamLimits: &v1alpha1.JetStreamLimits{DiskStorage: limit(1000)}},
+			usage:    domain.NatsJetStreamUsage{StorageBytes: 899},
+		},
+		{
+			testName:         "should_warn_at_threshold",
+			claims:           &v1alpha1.AccountClaims{JetStreamLimits: &v1alpha1.JetStreamLimits{DiskStorage: limit(1000)}},
+			usage:            domain.NatsJetStreamUsage{StorageBytes: 900},
+			expectedWarnings: []string{"JetStream disk storage at 90% of its limit (900 of 1000 bytes)"},
+		},
+		{
+			testName: "should_warn_for_memory_and_disk",
+			claims: &v1alpha1.AccountClaims{JetStreamLimits: &v1alpha1.JetStreamLimits{
+				MemoryStorage: limit(100),
+				DiskStorage:   limit(1000),
+			}},
+			usage: domain.NatsJetStreamUsage{MemoryBytes: 100, StorageBytes: 950},
+			expectedWarnings: []string{
+				"JetStream memory storage at 100% of its limit (100 of 100 bytes)",
+				"JetStream disk storage at 95% of its limit (950 of 1000 bytes)",
+			},
+		},
+		{
+			testName: "should_not_warn_when_unlimited_or_disabled",
+			claims: &v1alpha1.AccountClaims{JetStreamLimits: &v1alpha1.JetStreamLimits{
+				MemoryStorage: limit(0),
+				DiskStorage:   limit(-1),
+			}},
+			usage: domain.NatsJetStreamUsage{MemoryBytes: 100, StorageBytes: 1000},
+		},
+		{
+			testName: "should_sum_tiered_limits",
+			claims: &v1alpha1.AccountClaims{JetStreamTieredLimits: v1alpha1.JetStreamTieredLimits{
+				"R1": {DiskStorage: limit(1000)},
+				"R3": {DiskStorage: limit(1000)},
+			}},
+			usage:            domain.NatsJetStreamUsage{StorageBytes: 1900},
+			expectedWarnings: []string{"JetStream disk storage at 95% of its limit (1900 of 2000 bytes)"},
+		},
+		{
+			testName: "should_not_warn_when_a_tier_is_unlimited",
+			claims: &v1alpha1.AccountClaims{JetStreamTieredLimits: v1alpha1.JetStreamTieredLimits{
+				"R1": {DiskStorage: limit(1000)},
+				"R3": {DiskStorage: limit(-1)},
+			}},
+			usage: domain.NatsJetStreamUsage{StorageBytes: 1900},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			usage := &domain.NatsAccountUsage{JetStream: &tc.usage}
+
+			warnings := jetStreamUsageWarnings(tc.claims, usage, DefaultAccountUsageWarningThreshold)
+
+			assert.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -172,3 +173,35 @@ func TestAccountReconciler_MapAccountExportToAccounts(t *testing.T) {
 		NamespacedName: client.ObjectKeyFromObject(accountA),
 	}, requests[0])
 }
+
+func TestAccountReconciler_ShouldReconcileWhenRepushIsRequested(t *testing.T) {
+	withAnnotation := func(value string) *v1alpha1.Account {
+		account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Name: "account-a", Namespace: "ns-a"}}
+		if value != "" {
+			account.SetAnnotations(map[string]string{v1alpha1.AccountAnnotationRepushRequestedAt: value})
+		}
+		return account
+	}
+
+	tests := []struct {
+		name          string
+		oldValue      string
+		newValue      string
+		expectRequeue bool
+	}{
+		{name: "repush_requested", oldValue: "", newValue: "2025-01-02T03:04:05Z", expectRequeue: true},
+		{name: "repush_requested_again", oldValue: "2025-01-02T03:04:05Z", newValue: "2025-01-03T03:04:05Z", expectRequeue: true},
+		{name: "repush_unchanged", oldValue: "2025-01-02T03:04:05Z", newValue: "2025-01-02T03:04:05Z", expectRequeue: false},
+		{name: "repush_annotation_removed", oldValue: "2025-01-02T03:04:05Z", newValue: "", expectRequeue: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := repushRequestedPredicate().Update(event.UpdateEvent{
+				ObjectOld: withAnnotation(tt.oldValue),
+				ObjectNew: withAnnotation(tt.newValue),
+			})
+
+			assert.Equal(t, tt.expectRequeue, result)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// appTenantDefaultUserRoles are the roles a User is generated for when an AppTenant does not list any.
+var appTenantDefaultUserRoles = []v1alpha1.AppTenantUserRole{
+	v1alpha1.AppTenantUserRoleAdmin,
+	v1alpha1.AppTenantUserRoleService,
+	v1alpha1.AppTenantUserRoleTelemetry,
+}
+
+// AppTenantReconciler expands an AppTenant into an Account and a User per role. The generated resources are
+// controlled by the AppTenant, so they are garbage collected with it and their changes are reverted.
+type AppTenantReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	reporter *statusReporter
+}
+
+func NewAppTenantReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	recorder events.EventRecorder,
+) *AppTenantReconciler {
+	return &AppTenantReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme,
+		reporter: newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=apptenants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=apptenants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=nauth.io,resources=users,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *AppTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	tenant := &v1alpha1.AppTenant{}
+	if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	// The generated resources are garbage collected through their owner references
+	if !tenant.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	account, err := r.applyAccount(ctx, tenant)
+	if err != nil {
+		return r.reporter.error(ctx, tenant, err)
+	}
+
+	roles := tenant.Spec.Users
+	if len(roles) == 0 {
+		roles = appTenantDefaultUserRoles
+	}
+	users := make([]*v1alpha1.User, 0, len(roles))
+	for _, role := range roles {
+		user, err := r.applyUser(ctx, tenant, role)
+		if err != nil {
+			return r.reporter.error(ctx, tenant, err)
+		}
+		users = append(users, user)
+	}
+	if err := r.deleteRemovedUsers(ctx, tenant, roles); err != nil {
+		return r.reporter.error(ctx, tenant, err)
+	}
+
+	tenant.Status.ObservedGeneration = tenant.Generation
+	tenant.Status.AccountName = account.Name
+	tenant.Status.UserNames = make([]string, 0, len(users))
+	var pending []string
+	if !isReady(account) {
+		pending = append(pending, "Account "+account.Name)
+	}
+	for _, user := range users {
+		tenant.Status.UserNames = append(tenant.Status.UserNames, user.Name)
+		if !isReady(user) {
+			pending = append(pending, "User "+user.Name)
+		}
+	}
+	slices.Sort(tenant.Status.UserNames)
+
+	if len(pending) > 0 {
+		// The generated resources are watched, their Ready condition reconciles the AppTenant again
+		conditions.MarkReconciling(tenant, "Waiting for "+strings.Join(pending, ", "))
+		if err := r.Status().Update(ctx, tenant); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	return r.reporter.status(ctx, tenant)
+}
+
+func (r *AppTenantReconciler) applyAccount(ctx context.Context, tenant *v1alpha1.AppTenant) (*v1alpha1.Account, error) {
+	account := &v1alpha1.Account{}
+	account.Name = tenant.Name
+	account.Namespace = tenant.Namespace
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, account, func() error {
+		if err := checkGenerated(tenant, account, "Account"); err != nil {
+			return err
+		}
+		account.Spec = v1alpha1.AccountSpec{}
+		if tenant.Spec.Account != nil {
+			account.Spec = *tenant.Spec.Account.DeepCopy()
+		}
+		setAppTenantLabels(account, tenant, "")
+		return controllerutil.SetControllerReference(tenant, account, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply account %s: %w", account.Name, err)
+	}
+	return account, nil
+}
+
+func (r *AppTenantReconciler) applyUser(ctx context.Context, tenant *v1alpha1.AppTenant, role v1alpha1.AppTenantUserRole) (*v1alpha1.User, error) {
+	user := &v1alpha1.User{}
+	user.Name = fmt.Sprintf("%s-%s", tenant.Name, role)
+	user.Namespace = tenant.Namespace
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, user, func() error {
+		if err := checkGenerated(tenant, user, "User"); err != nil {
+			return err
+		}
+		user.Spec = v1alpha1.UserSpec{
+			AccountName: tenant.Name,
+			Permissions: appTenantUserPermissions(role, appTenantSubjectPrefix(tenant)),
+		}
+		setAppTenantLabels(user, tenant, role)
+		return controllerutil.SetControllerReference(tenant, user, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply user %s: %w", user.Name, err)
+	}
+	return user, nil
+}
+
+// checkGenerated returns domain.ErrBadRequest when obj exists but was not generated for tenant, so an AppTenant never
+// takes over an Account or User created by hand.
+func checkGenerated(tenant *v1alpha1.AppTenant, obj client.Object, kind string) error {
+	if obj.GetResourceVersion() == "" || metav1.IsControlledBy(obj, tenant) {
+		return nil
+	}
+	return domain.ErrBadRequest.WithCause(fmt.Errorf("%s %s exists and was not generated by the AppTenant", kind, obj.GetName()))
+}
+
+// deleteRemovedUsers deletes the Users generated for tenant for roles it no longer lists.
+func (r *AppTenantReconciler) deleteRemovedUsers(ctx context.Context, tenant *v1alpha1.AppTenant, roles []v1alpha1.AppTenantUserRole) error {
+	users := &v1alpha1.UserList{}
+	if err := r.List(ctx, users, client.InNamespace(tenant.Namespace),
+		client.MatchingLabels{string(v1alpha1.AppTenantLabelName): tenant.Name}); err != nil {
+		return fmt.Errorf("failed to list users of AppTenant: %w", err)
+	}
+	for i := range users.Items {
+		user := &users.Items[i]
+		role := v1alpha1.AppTenantUserRole(user.GetLabels()[string(v1alpha1.AppTenantLabelRole)])
+		if slices.Contains(roles, role) || !metav1.IsControlledBy(user, tenant) {
+			continue
+		}
+		if err := r.Delete(ctx, user); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete user %s: %w", user.Name, err)
+		}
+	}
+	return nil
+}
+
+func setAppTenantLabels(obj client.Object, tenant *v1alpha1.AppTenant, role v1alpha1.AppTenantUserRole) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[string(v1alpha1.AppTenantLabelName)] = tenant.Name
+	if role != "" {
+		labels[string(v1alpha1.AppTenantLabelRole)] = string(role)
+	}
+	obj.SetLabels(labels)
+}
+
+func appTenantSubjectPrefix(tenant *v1alpha1.AppTenant) string {
+	if tenant.Spec.SubjectPrefix != "" {
+		return tenant.Spec.SubjectPrefix
+	}
+	return tenant.Name
+}
+
+// appTenantUserPermissions returns the standard permissions of role within the subject namespace prefix.
+func appTenantUserPermissions(role v1alpha1.AppTenantUserRole, prefix string) *v1alpha1.Permissions {
+	switch role {
+	case v1alpha1.AppTenantUserRoleAdmin:
+		return &v1alpha1.Permissions{
+			Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{">"}},
+			Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{">"}},
+		}
+	case v1alpha1.AppTenantUserRoleService:
+		return &v1alpha1.Permissions{
+			Pub:  v1alpha1.Permission{Allow: v1alpha1.StringList{prefix + ".>"}},
+			Sub:  v1alpha1.Permission{Allow: v1alpha1.StringList{prefix + ".>", "_INBOX.>"}},
+			Resp: &v1alpha1.ResponsePermission{MaxMsgs: 1},
+		}
+	case v1alpha1.AppTenantUserRoleTelemetry:
+		return &v1alpha1.Permissions{
+			Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{prefix + ".telemetry.>"}},
+			Sub: v1alpha1.Permission{Deny: v1alpha1.StringList{">"}},
+		}
+	default:
+		return nil
+	}
+}
+
+func isReady(obj Object) bool {
+	return meta.IsStatusConditionTrue(*obj.GetConditions(), conditions.TypeReady)
+}
+
+func (r *AppTenantReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Its own status updates must not trigger a reconcile
+		For(&v1alpha1.AppTenant{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&v1alpha1.Account{}).
+		Owns(&v1alpha1.User{}).
+		Named("apptenant").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Complete(traced("apptenant", r))
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type AppTenantControllerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	fakeRecorder *events.FakeRecorder
+
+	resourceName ktypes.NamespacedName
+
+	unitUnderTest *AppTenantReconciler
+}
+
+func TestAppTenantController_TestSuite(t *testing.T) {
+	suite.Run(t, new(AppTenantControllerTestSuite))
+}
+
+func (t *AppTenantControllerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+
+	testName := t.T().Name()
+	t.resourceName = ktypes.NamespacedName{
+		Name:      "orders",
+		Namespace: testutil.ScopedTestName("apptenant", testName),
+	}
+
+	t.fakeRecorder = events.NewFakeRecorder(5)
+	t.unitUnderTest = NewAppTenantReconciler(k8sClient, k8sClient.Scheme(), t.fakeRecorder)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.resourceName.Namespace))
+}
+
+func (t *AppTenantControllerTestSuite) Test_Reconcile_ShouldGenerateAccountAndUsers() {
+	// Given
+	t.setupAppTenant(v1alpha1.AppTenantSpec{
+		Account:       &v1alpha1.AccountSpec{DisplayName: "Orders"},
+		SubjectPrefix: "shop.orders",
+	})
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Zero(result)
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.resourceName, account))
+	t.Equal("Orders", account.Spec.DisplayName)
+	t.Equal("orders", account.Labels[string(v1alpha1.AppTenantLabelName)])
+	t.True(metav1.IsControlledBy(account, t.getAppTenant()))
+
+	service := t.getUser("orders-service")
+	t.Equal("orders", service.Spec.AccountName)
+	t.Equal(v1alpha1.StringList{"shop.orders.>"}, service.Spec.Permissions.Pub.Allow)
+	t.Equal("service", service.Labels[string(v1alpha1.AppTenantLabelRole)])
+	t.Equal(v1alpha1.StringList{">"}, t.getUser("orders-admin").Spec.Permissions.Pub.Allow)
+	t.Equal(v1alpha1.StringList{"shop.orders.telemetry.>"}, t.getUser("orders-telemetry").Spec.Permissions.Pub.Allow)
+
+	tenant := t.getAppTenant()
+	t.Equal("orders", tenant.Status.AccountName)
+	t.Equal([]string{"orders-admin", "orders-service", "orders-telemetry"}, tenant.Status.UserNames)
+	ready := meta.FindStatusCondition(tenant.Status.Conditions, conditions.TypeReady)
+	t.Require().NotNil(ready)
+	t.Equal(metav1.ConditionFalse, ready.Status)
+	t.Contains(ready.Message, "Waiting for Account orders")
+}
+
+func (t *AppTenantControllerTestSuite) Test_Reconcile_ShouldDeleteUserOfRemovedRole() {
+	// Given
+	t.setupAppTenant(v1alpha1.AppTenantSpec{})
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+	t.Require().NoError(err)
+	tenant := t.getAppTenant()
+	tenant.Spec.Users = []v1alpha1.AppTenantUserRole{v1alpha1.AppTenantUserRoleService}
+	t.Require().NoError(k8sClient.Update(t.ctx, tenant))
+
+	// When
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.getUser("orders-service")
+	for _, name := range []string{"orders-admin", "orders-telemetry"} {
+		err := k8sClient.Get(t.ctx, ktypes.NamespacedName{Namespace: t.resourceName.Namespace, Name: name}, &v1alpha1.User{})
+		t.True(apierrors.IsNotFound(err), "expected user %s to be deleted, got %v", name, err)
+	}
+	t.Equal([]string{"orders-service"}, t.getAppTenant().Status.UserNames)
+}
+
+func (t *AppTenantControllerTestSuite) Test_Reconcile_ShouldFail_WhenAccountWasNotGenerated() {
+	// Given
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: t.resourceName.Name, Namespace: t.resourceName.Namespace},
+	}))
+	t.setupAppTenant(v1alpha1.AppTenantSpec{})
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().ErrorContains(err, "Account orders exists and was not generated by the AppTenant")
+	ready := meta.FindStatusCondition(t.getAppTenant().Status.Conditions, conditions.TypeReady)
+	t.Require().NotNil(ready)
+	t.Equal(metav1.ConditionFalse, ready.Status)
+}
+
+func (t *AppTenantControllerTestSuite) setupAppTenant(spec v1alpha1.AppTenantSpec) {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.AppTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: t.resourceName.Name, Namespace: t.resourceName.Namespace},
+		Spec:       spec,
+	}))
+}
+
+func (t *AppTenantControllerTestSuite) getAppTenant() *v1alpha1.AppTenant {
+	tenant := &v1alpha1.AppTenant{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.resourceName, tenant))
+	return tenant
+}
+
+func (t *AppTenantControllerTestSuite) getUser(name string) *v1alpha1.User {
+	user := &v1alpha1.User{}
+	t.Require().NoError(k8sClient.Get(t.ctx, ktypes.NamespacedName{Namespace: t.resourceName.Namespace, Name: name}, user))
+	return user
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxConcurrentReconciles is the number of concurrent reconciles of a resource kind when none is configured.
+const DefaultMaxConcurrentReconciles = 1
+
+// MaxConcurrentReconciles holds the number of concurrent reconciles configured per resource kind. It implements
+// flag.Value, accepting <kind>=<count> with the same kinds as RequeuePolicies. The count of the default kind applies to
+// all kinds that do not set their own.
+type MaxConcurrentReconciles struct {
+	counts map[string]int
+}
+
+func (m *MaxConcurrentReconciles) String() string {
+	if m == nil {
+		return ""
+	}
+	kinds := make([]string, 0, len(m.counts))
+	for kind := range m.counts {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	values := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		values = append(values, fmt.Sprintf("%s=%d", kind, m.counts[kind]))
+	}
+	return strings.Join(values, " ")
+}
+
+func (m *MaxConcurrentReconciles) Set(value string) error {
+	kind, rawCount, found := strings.Cut(value, "=")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !found {
+		return fmt.Errorf("max concurrent reconciles %q must have the format <kind>=<count>", value)
+	}
+	if !slices.Contains(requeueKinds, kind) {
+		return fmt.Errorf("unknown max concurrent reconciles kind %q, expected one of %s", kind, strings.Join(requeueKinds, ", "))
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(rawCount))
+	if err != nil {
+		return fmt.Errorf("invalid max concurrent reconciles of %s: %w", kind, err)
+	}
+	if count < 1 {
+		return fmt.Errorf("max concurrent reconciles of %s must be at least 1", kind)
+	}
+
+	if m.counts == nil {
+		m.counts = make(map[string]int)
+	}
+	m.counts[kind] = count
+	return nil
+}
+
+// For returns the effective number of concurrent reconciles of kind.
+func (m *MaxConcurrentReconciles) For(kind string) int {
+	if m == nil {
+		return DefaultMaxConcurrentReconciles
+	}
+	if count, ok := m.counts[kind]; ok {
+		return count
+	}
+	if count, ok := m.counts[RequeueKindDefault]; ok {
+		return count
+	}
+	return DefaultMaxConcurrentReconciles
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxConcurrentReconciles_For(t *testing.T) {
+	testCases := []struct {
+		testName string
+		values   []string
+		kind     string
+		expected int
+	}{
+		{
+			testName: "should_use_one_when_not_configured",
+			kind:     RequeueKindAccount,
+			expected: 1,
+		},
+		{
+			testName: "should_apply_default_kind_to_all_kinds",
+			values:   []string{"default=4"},
+			kind:     RequeueKindUser,
+			expected: 4,
+		},
+		{
+			testName: "should_override_default_kind_per_kind",
+			values:   []string{"account=16", "default=4"},
+			kind:     RequeueKindAccount,
+			expected: 16,
+		},
+		{
+			testName: "should_use_last_value_of_repeated_kind",
+			values:   []string{"user=2", "user=8"},
+			kind:     RequeueKindUser,
+			expected: 8,
+		},
+		{
+			testName: "should_not_apply_other_kinds",
+			values:   []string{"account=16"},
+			kind:     RequeueKindNatsCluster,
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			maxConcurrentReconciles := MaxConcurrentReconciles{}
+			for _, value := range tc.values {
+				require.NoError(t, maxConcurrentReconciles.Set(value))
+			}
+
+			assert.Equal(t, tc.expected, maxConcurrentReconciles.For(tc.kind))
+		})
+	}
+}
+
+func TestMaxConcurrentReconciles_Set_ShouldFail(t *testing.T) {
+	testCases := []struct {
+		testName string
+		value    string
+		expected string
+	}{
+		{testName: "missing_count", value: "account", expected: "must have the format"},
+		{testName: "unknown_kind", value: "secret=2", expected: "unknown max concurrent reconciles kind"},
+		{testName: "invalid_count", value: "user=many", expected: "invalid max concurrent reconciles"},
+		{testName: "count_not_positive", value: "user=0", expected: "must be at least 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			maxConcurrentReconciles := MaxConcurrentReconciles{}
+
+			assert.ErrorContains(t, maxConcurrentReconciles.Set(tc.value), tc.expected)
+		})
+	}
+}
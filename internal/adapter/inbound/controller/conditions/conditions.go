@@ -0,0 +1,145 @@
+// Package conditions sets the status conditions shared by all NAuth resources.
+//
+// Every reconciled resource reports:
+//   - Ready: the resource is reconciled and usable.
+//   - Synced: the last reconciliation applied the desired state.
+//   - Degraded: the last reconciliation failed while a previously synced state remains in use.
+//
+// Users additionally report CredentialsIssued once their credentials Secret has been written, and Accounts report
+// DeletionBlocked while their deletion waits for Users bound to them, UsageWarning when their JetStream usage
+// approaches its limits and ImportConflict when their imports overlap those of another Account. Paused Accounts and Users report Paused instead of being reconciled, and FinalizerDisabled while the NauthDefaults disable their finalizer. Every condition records
+// the generation it was computed for in observedGeneration.
+package conditions
+
+import (
+	"errors"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Types
+const (
+	TypeReady             = "Ready"
+	TypeSynced            = "Synced"
+	TypeDegraded          = "Degraded"
+	TypeCredentialsIssued = "CredentialsIssued"
+	TypeDeletionBlocked   = "DeletionBlocked"
+	TypePaused            = "Paused"
+	// TypeHealthy reports whether the NATS servers of a NatsCluster respond through its system account.
+	TypeHealthy = "Healthy"
+	// TypeUsageWarning reports whether the JetStream usage of an Account approaches its limits.
+	TypeUsageWarning = "UsageWarning"
+	// TypeImportConflict reports whether an Account imports subjects overlapping the imports of another Account from
+	// the same exporting account.
+	TypeImportConflict = "ImportConflict"
+	// TypeFinalizerDisabled reports that NAuth adds no finalizer to a resource, so deleting it skips the cleanup of
+	// NAuth.
+	TypeFinalizerDisabled = "FinalizerDisabled"
+)
+
+// Reasons
+const (
+	ReasonReconciling = "Reconciling"
+	ReasonReconciled  = "Reconciled"
+	ReasonDeleting    = "Deleting"
+	ReasonErrored     = "Errored"
+	ReasonHealthy     = "Healthy"
+	ReasonIssued      = "Issued"
+	ReasonNotIssued   = "NotIssued"
+	ReasonExpired     = "Expired"
+	ReasonUsersExist  = "UsersExist"
+	ReasonPaused      = "Paused"
+	// ReasonLimitApproached and ReasonWithinLimits are the reasons of the UsageWarning condition.
+	ReasonLimitApproached = "LimitApproached"
+	ReasonWithinLimits    = "WithinLimits"
+	// ReasonSubjectsOverlap and ReasonNoConflicts are the reasons of the ImportConflict condition.
+	ReasonSubjectsOverlap = "SubjectsOverlap"
+	ReasonNoConflicts     = "NoConflicts"
+	// ReasonFinalizerPolicy is the reason of the FinalizerDisabled condition.
+	ReasonFinalizerPolicy = "FinalizerPolicy"
+)
+
+type Object interface {
+	GetGeneration() int64
+	GetConditions() *[]metav1.Condition
+}
+
+// Set sets the condition of conditionType for the current generation of obj.
+func Set(obj Object, conditionType string, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// MarkReconciling marks obj as not ready while it is reconciled. Synced and Degraded keep the outcome of the previous
+// reconciliation.
+func MarkReconciling(obj Object, message string) {
+	Set(obj, TypeReady, metav1.ConditionFalse, ReasonReconciling, message)
+}
+
+// MarkDeleting marks obj as not ready while it is deleted.
+func MarkDeleting(obj Object, message string) {
+	Set(obj, TypeReady, metav1.ConditionFalse, ReasonDeleting, message)
+}
+
+// MarkPaused marks obj as paused. Ready, Synced and Degraded keep the outcome of the last reconciliation before the
+// pause.
+func MarkPaused(obj Object, message string) {
+	Set(obj, TypePaused, metav1.ConditionTrue, ReasonPaused, message)
+}
+
+// ClearPaused removes the Paused condition of obj once it is reconciled again.
+func ClearPaused(obj Object) {
+	meta.RemoveStatusCondition(obj.GetConditions(), TypePaused)
+}
+
+// MarkReconciled marks obj as ready, synced and not degraded.
+func MarkReconciled(obj Object, message string) {
+	Set(obj, TypeReady, metav1.ConditionTrue, ReasonReconciled, message)
+	Set(obj, TypeSynced, metav1.ConditionTrue, ReasonReconciled, message)
+	Set(obj, TypeDegraded, metav1.ConditionFalse, ReasonHealthy, "")
+}
+
+// MarkPending marks obj as ready, not degraded and not synced, while its desired state waits to be applied, e.g. for
+// the end of a maintenance window.
+func MarkPending(obj Object, reason string, message string) {
+	Set(obj, TypeReady, metav1.ConditionTrue, ReasonReconciled, message)
+	Set(obj, TypeSynced, metav1.ConditionFalse, reason, message)
+	Set(obj, TypeDegraded, metav1.ConditionFalse, ReasonHealthy, "")
+}
+
+// MarkRetrying marks obj as not ready after a failure that is retried before it counts. Synced and Degraded keep the
+// outcome of the last counted reconciliation.
+func MarkRetrying(obj Object, reason string, message string) {
+	Set(obj, TypeReady, metav1.ConditionFalse, reason, message)
+}
+
+// MarkFailed marks obj as neither ready nor synced. obj is degraded when it had been synced before, or already was
+// degraded, since the previously applied state is still in use.
+func MarkFailed(obj Object, reason string, message string) {
+	conditions := *obj.GetConditions()
+	degraded := meta.IsStatusConditionTrue(conditions, TypeSynced) || meta.IsStatusConditionTrue(conditions, TypeDegraded)
+
+	Set(obj, TypeReady, metav1.ConditionFalse, reason, message)
+	Set(obj, TypeSynced, metav1.ConditionFalse, reason, message)
+	if degraded {
+		Set(obj, TypeDegraded, metav1.ConditionTrue, reason, message)
+	} else {
+		Set(obj, TypeDegraded, metav1.ConditionFalse, reason, message)
+	}
+}
+
+// ReasonForError returns a machine-readable reason for err: the name of the domain error it wraps, or ReasonErrored.
+func ReasonForError(err error) string {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) && domainErr != domain.ErrUnknownError {
+		return string(domainErr)
+	}
+	return ReasonErrored
+}
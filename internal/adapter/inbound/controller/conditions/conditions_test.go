@@ -0,0 +1,173 @@
+package conditions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testObject struct {
+	generation int64
+	conditions []metav1.Condition
+}
+
+func (o *testObject) GetGeneration() int64 {
+	return o.generation
+}
+
+func (o *testObject) GetConditions() *[]metav1.Condition {
+	return &o.conditions
+}
+
+func TestMarkReconciled_ShouldSetReadySyncedAndNotDegraded(t *testing.T) {
+	obj := &testObject{generation: 3}
+
+	MarkReconciled(obj, "done")
+
+	assertCondition(t, obj, TypeReady, metav1.ConditionTrue, ReasonReconciled)
+	assertCondition(t, obj, TypeSynced, metav1.ConditionTrue, ReasonReconciled)
+	assertCondition(t, obj, TypeDegraded, metav1.ConditionFalse, ReasonHealthy)
+	for _, condition := range obj.conditions {
+		assert.Equal(t, int64(3), condition.ObservedGeneration, condition.Type)
+	}
+}
+
+func TestMarkFailed(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		setup            func(obj *testObject)
+		expectedDegraded metav1.ConditionStatus
+	}{
+		{
+			testName:         "should_not_be_degraded_when_never_synced",
+			setup:            func(*testObject) {},
+			expectedDegraded: metav1.ConditionFalse,
+		},
+		{
+			testName: "should_be_degraded_when_synced_before",
+			setup: func(obj *testObject) {
+				MarkReconciled(obj, "done")
+				MarkReconciling(obj, "reconciling")
+			},
+			expectedDegraded: metav1.ConditionTrue,
+		},
+		{
+			testName: "should_stay_degraded_when_failing_again",
+			setup: func(obj *testObject) {
+				MarkReconciled(obj, "done")
+				MarkFailed(obj, ReasonErrored, "first failure")
+			},
+			expectedDegraded: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			obj := &testObject{generation: 1}
+			tc.setup(obj)
+
+			MarkFailed(obj, "AccountNotFound", "account not found")
+
+			assertCondition(t, obj, TypeReady, metav1.ConditionFalse, "AccountNotFound")
+			assertCondition(t, obj, TypeSynced, metav1.ConditionFalse, "AccountNotFound")
+			assertCondition(t, obj, TypeDegraded, tc.expectedDegraded, "AccountNotFound")
+		})
+	}
+}
+
+func TestMarkReconciling_ShouldKeepSynced(t *testing.T) {
+	obj := &testObject{generation: 1}
+	MarkReconciled(obj, "done")
+	obj.generation = 2
+
+	MarkReconciling(obj, "reconciling")
+
+	assertCondition(t, obj, TypeReady, metav1.ConditionFalse, ReasonReconciling)
+	assertCondition(t, obj, TypeSynced, metav1.ConditionTrue, ReasonReconciled)
+	assert.Equal(t, int64(2), meta.FindStatusCondition(obj.conditions, TypeReady).ObservedGeneration)
+	assert.Equal(t, int64(1), meta.FindStatusCondition(obj.conditions, TypeSynced).ObservedGeneration)
+}
+
+func TestMarkRetrying_ShouldKeepSyncedAndDegraded(t *testing.T) {
+	obj := &testObject{generation: 1}
+	MarkReconciled(obj, "done")
+
+	MarkRetrying(obj, "AccountNotReady", "account not ready")
+
+	assertCondition(t, obj, TypeReady, metav1.ConditionFalse, "AccountNotReady")
+	assertCondition(t, obj, TypeSynced, metav1.ConditionTrue, ReasonReconciled)
+	assertCondition(t, obj, TypeDegraded, metav1.ConditionFalse, ReasonHealthy)
+}
+
+func TestMarkPaused_ShouldKeepReadyUntilCleared(t *testing.T) {
+	obj := &testObject{generation: 1}
+	MarkReconciled(obj, "done")
+
+	MarkPaused(obj, "paused")
+
+	assertCondition(t, obj, TypePaused, metav1.ConditionTrue, ReasonPaused)
+	assertCondition(t, obj, TypeReady, metav1.ConditionTrue, ReasonReconciled)
+
+	ClearPaused(obj)
+
+	assert.Nil(t, meta.FindStatusCondition(obj.conditions, TypePaused))
+	assertCondition(t, obj, TypeReady, metav1.ConditionTrue, ReasonReconciled)
+}
+
+func TestMarkPending_ShouldBeReadyButNotSynced(t *testing.T) {
+	obj := &testObject{generation: 2}
+
+	MarkPending(obj, "MaintenanceWindow", "waiting")
+
+	assertCondition(t, obj, TypeReady, metav1.ConditionTrue, ReasonReconciled)
+	assertCondition(t, obj, TypeSynced, metav1.ConditionFalse, "MaintenanceWindow")
+	assertCondition(t, obj, TypeDegraded, metav1.ConditionFalse, ReasonHealthy)
+}
+
+func TestReasonForError(t *testing.T) {
+	testCases := []struct {
+		testName string
+		err      error
+		expected string
+	}{
+		{
+			testName: "should_use_domain_error",
+			err:      fmt.Errorf("failed to sign user: %w", domain.ErrAccountNotReady),
+			expected: "AccountNotReady",
+		},
+		{
+			testName: "should_use_domain_error_with_cause",
+			err:      domain.ErrReferenceNotGranted.WithCause(fmt.Errorf("no grant")),
+			expected: "ReferenceNotGranted",
+		},
+		{
+			testName: "should_use_errored_for_unknown_error",
+			err:      domain.ErrUnknownError.WithCause(fmt.Errorf("boom")),
+			expected: ReasonErrored,
+		},
+		{
+			testName: "should_use_errored_for_other_errors",
+			err:      fmt.Errorf("boom"),
+			expected: ReasonErrored,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ReasonForError(tc.err))
+		})
+	}
+}
+
+func assertCondition(t *testing.T, obj *testObject, conditionType string, status metav1.ConditionStatus, reason string) {
+	t.Helper()
+	condition := meta.FindStatusCondition(obj.conditions, conditionType)
+	require.NotNil(t, condition, "condition %s not found", conditionType)
+	assert.Equal(t, status, condition.Status, conditionType)
+	assert.Equal(t, reason, condition.Reason, conditionType)
+}
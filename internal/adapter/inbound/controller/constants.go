@@ -1,10 +1,15 @@
 package controller
 
-import "time"
+import (
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
+)
 
 const ( // Conditions
 	// Types
-	conditionTypeReady                = "Ready"
+	conditionTypeReady                = conditions.TypeReady
 	conditionTypeBoundToAccount       = "BoundToAccount"
 	conditionTypeBoundToExportAccount = "BoundToExportAccount"
 	conditionTypeValidRules           = "ValidRules"
@@ -13,25 +18,82 @@ const ( // Conditions
 	// Reasons
 	conditionReasonReady       = "Ready"
 	conditionReasonNotReady    = "NotReady"
-	conditionReasonReconciling = "Reconciling"
-	conditionReasonReconciled  = "Reconciled"
+	conditionReasonReconciling = conditions.ReasonReconciling
+	conditionReasonReconciled  = conditions.ReasonReconciled
 	conditionReasonOK          = "OK"
 	conditionReasonNOK         = "NOK"
-	conditionReasonErrored     = "Errored"
+	conditionReasonErrored     = conditions.ReasonErrored
 	conditionReasonInvalid     = "Invalid"
 	conditionReasonConflict    = "Conflict"
 	conditionReasonBinding     = "Binding"
 	conditionReasonNotFound    = "NotFound"
 	conditionReasonAdopting    = "Adopting"
 	conditionReasonFailed      = "Failed"
+	// conditionReasonReferenceNotGranted is used when a cross-namespace reference has no matching ReferenceGrant.
+	conditionReasonReferenceNotGranted = string(domain.ErrReferenceNotGranted)
+	// conditionReasonMaintenanceWindow is used while changes wait for the end of a maintenance window of a NatsCluster.
+	conditionReasonMaintenanceWindow = string(domain.ErrMaintenanceWindow)
 
 	// Messages
-	conditionMessageAdopted = "Adopted"
+	conditionMessageAdopted    = "Adopted"
+	conditionMessageReconciled = "Successfully reconciled"
+	conditionMessagePaused     = "Reconciliation is paused by spec.paused"
+	// conditionMessageAccountFinalizerDisabled and conditionMessageUserFinalizerDisabled describe what is left to the
+	// operator after deleting a resource without finalizer.
+	conditionMessageAccountFinalizerDisabled = "Finalizer disabled by NauthDefaults: deleting the Account leaves the account JWT in NATS and the account Secrets to be cleaned up manually"
+	conditionMessageUserFinalizerDisabled    = "Finalizer disabled by NauthDefaults: deleting the User leaves its credentials Secret to garbage collection and records no audit event"
 )
 
 const ( // Events
 	// Actions
 	actionReconciled = "Reconciled"
+	actionCreated    = "Created"
+	actionPushed     = "Pushed"
+	actionObserved   = "Observed"
+	actionDeleted    = "Deleted"
+	actionSigned     = "Signed"
+	actionAdopted    = "Adopted"
+
+	// Reasons
+	eventReasonSigningKeyCreated  = "SigningKeyCreated"
+	eventReasonJWTPushed          = "JWTPushed"
+	eventReasonPushDeferred       = "PushDeferred"
+	eventReasonDriftDetected      = "DriftDetected"
+	eventReasonAccountDeleted     = "AccountDeleted"
+	eventReasonAccountRetained    = "AccountRetained"
+	eventReasonAccountOrphaned    = "AccountOrphaned"
+	eventReasonAccountAdopted     = "AccountAdopted"
+	eventReasonDeletionBlocked    = "DeletionBlocked"
+	eventReasonCredentialsIssued  = "CredentialsIssued"
+	eventReasonCredentialsRotated = "CredentialsRotated"
+	eventReasonCredentialsExpired = "CredentialsExpired"
+	// eventReasonResolverConfigUpdated is recorded when the resolver config ConfigMap of a NatsCluster is written.
+	eventReasonResolverConfigUpdated = "ResolverConfigUpdated"
+	// eventReasonResolverConfigFailed is recorded when the resolver config of a NatsCluster cannot be written.
+	eventReasonResolverConfigFailed = "ResolverConfigFailed"
+	eventReasonResyncStarted        = "ResyncStarted"
+	eventReasonResyncCompleted      = "ResyncCompleted"
+	// eventReasonDryRun is recorded when a change is skipped since the manager runs with --dry-run.
+	eventReasonDryRun = "DryRun"
+	// eventReasonJWTSizeLimitApproaching is recorded when an account JWT is close to the size the NATS servers accept.
+	eventReasonJWTSizeLimitApproaching = "JWTSizeLimitApproaching"
+	// eventReasonOperatorKeyRotationStarted is recorded when the operator signing key of a NatsCluster changes.
+	eventReasonOperatorKeyRotationStarted = "OperatorKeyRotationStarted"
+	// eventReasonOperatorKeyRetired is recorded when all Accounts of a NatsCluster are signed with the new operator
+	// signing key.
+	eventReasonOperatorKeyRetired = "OperatorKeyRetired"
+	// eventReasonClusterUnhealthy is recorded when the NATS servers of a NatsCluster stop responding.
+	eventReasonClusterUnhealthy = "ClusterUnhealthy"
+	// eventReasonClusterRecovered is recorded when the NATS servers of an unhealthy NatsCluster respond again.
+	eventReasonClusterRecovered = "ClusterRecovered"
+	// eventReasonUsageLimitApproached is recorded when the JetStream usage of an Account reaches the warning threshold
+	// of its limits.
+	eventReasonUsageLimitApproached = "UsageLimitApproached"
+	// eventReasonShardConflict is recorded when a resource in the shard of this instance is owned by another instance.
+	eventReasonShardConflict = "ShardConflict"
+	// eventReasonImportConflictDetected is recorded when an Account imports subjects overlapping the imports of another
+	// Account from the same exporting account.
+	eventReasonImportConflictDetected = "ImportConflictDetected"
 )
 
 const ( // Finalizers
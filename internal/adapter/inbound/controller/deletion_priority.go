@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// deletionPriority is the work queue priority of resources marked for deletion. It is above the default priority of
+// creates and updates, so finalizers blocking a namespace teardown are not stuck behind a burst of creates.
+const deletionPriority = 100
+
+// enqueueDeletionFirst enqueues the resources marked for deletion ahead of the other requests in the work queue.
+// Controllers watch their own resource with it next to For, which raises the priority of a request For already queued.
+func enqueueDeletionFirst() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueIfDeleting(e.Object, q)
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueIfDeleting(e.ObjectNew, q)
+		},
+	}
+}
+
+func enqueueIfDeleting(obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if obj == nil || obj.GetDeletionTimestamp().IsZero() {
+		return
+	}
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)}
+	if priorityQueue, ok := q.(priorityqueue.PriorityQueue[reconcile.Request]); ok {
+		priorityQueue.AddWithOpts(priorityqueue.AddOpts{Priority: new(deletionPriority)}, request)
+		return
+	}
+	q.Add(request)
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func Test_enqueueDeletionFirst(t *testing.T) {
+	now := metav1.Now()
+	created := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "created"}}
+	deleting := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "deleting", DeletionTimestamp: &now}}
+
+	queue := priorityqueue.New[reconcile.Request]("test")
+	defer queue.ShutDown()
+	queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(created)})
+	queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(deleting)})
+
+	handler := enqueueDeletionFirst()
+	handler.Create(context.Background(), event.CreateEvent{Object: created}, queue)
+	handler.Update(context.Background(), event.UpdateEvent{ObjectOld: deleting, ObjectNew: deleting}, queue)
+
+	require.Equal(t, 2, queue.Len())
+	request, priority, _ := queue.GetWithPriority()
+	require.Equal(t, "deleting", request.Name)
+	require.Equal(t, deletionPriority, priority)
+	request, priority, _ = queue.GetWithPriority()
+	require.Equal(t, "created", request.Name)
+	require.Zero(t, priority)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// dryRunEventRecorder marks the events of a manager running with --dry-run, whose events report the changes that
+// would have been made.
+type dryRunEventRecorder struct {
+	events.EventRecorder
+}
+
+// NewDryRunEventRecorder returns an EventRecorder that records events with recorder, prefixing their notes with
+// "[dry run]".
+func NewDryRunEventRecorder(recorder events.EventRecorder) events.EventRecorder {
+	return &dryRunEventRecorder{EventRecorder: recorder}
+}
+
+func (r *dryRunEventRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action,
+	note string, args ...interface{}) {
+	r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, "[dry run] "+note, args...)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+)
+
+func TestDryRunEventRecorder_ShouldMarkEvents(t *testing.T) {
+	account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "team"}}
+	fakeRecorder := events.NewFakeRecorder(1)
+	unitUnderTest := NewDryRunEventRecorder(fakeRecorder)
+
+	unitUnderTest.Eventf(account, nil, v1.EventTypeNormal, eventReasonJWTPushed, actionPushed, "Pushed account %s", "ACC")
+
+	require.Len(t, fakeRecorder.Events, 1)
+	require.Equal(t, "Normal JWTPushed [dry run] Pushed account ACC", <-fakeRecorder.Events)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newFakeClientBuilder returns a fake client builder knowing the Kubernetes and NAuth types, holding objects.
+func newFakeClientBuilder(t *testing.T, objects ...client.Object) *fake.ClientBuilder {
+	t.Helper()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(testScheme))
+	require.NoError(t, v1alpha1.AddToScheme(testScheme))
+	return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objects...)
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// finalizerPolicy is the finalizer NAuth adds to a kind of resource, as configured by the NauthDefaults.
+type finalizerPolicy struct {
+	// standard is the finalizer of the kind of resource with mode Standard.
+	standard string
+	// name is the finalizer added to resources, empty with mode None.
+	name string
+}
+
+func newFinalizerPolicy(policy *v1alpha1.FinalizerPolicy, standard string) finalizerPolicy {
+	result := finalizerPolicy{standard: standard, name: standard}
+	if policy == nil {
+		return result
+	}
+	switch policy.Mode {
+	case v1alpha1.FinalizerModeNone:
+		result.name = ""
+	case v1alpha1.FinalizerModeCustom:
+		result.name = policy.Name
+	}
+	return result
+}
+
+func accountFinalizerPolicy(defaults *v1alpha1.NauthDefaultsSpec) finalizerPolicy {
+	var policy *v1alpha1.FinalizerPolicy
+	if defaults != nil && defaults.Finalizers != nil {
+		policy = defaults.Finalizers.Account
+	}
+	return newFinalizerPolicy(policy, finalizerAccount)
+}
+
+func userFinalizerPolicy(defaults *v1alpha1.NauthDefaultsSpec) finalizerPolicy {
+	var policy *v1alpha1.FinalizerPolicy
+	if defaults != nil && defaults.Finalizers != nil {
+		policy = defaults.Finalizers.User
+	}
+	return newFinalizerPolicy(policy, finalizerUser)
+}
+
+// present reports whether obj has a finalizer of NAuth, the standard or the configured one, so NAuth has to clean up
+// after it when it is deleted.
+func (p finalizerPolicy) present(obj client.Object) bool {
+	return controllerutil.ContainsFinalizer(obj, p.standard) ||
+		(p.name != "" && controllerutil.ContainsFinalizer(obj, p.name))
+}
+
+// remove removes the finalizers of NAuth from obj, reporting whether obj changed.
+func (p finalizerPolicy) remove(obj client.Object) bool {
+	removed := controllerutil.RemoveFinalizer(obj, p.standard)
+	if p.name != "" && controllerutil.RemoveFinalizer(obj, p.name) {
+		removed = true
+	}
+	return removed
+}
+
+// apply adds the configured finalizer to obj and removes the standard finalizer when another one is configured,
+// reporting whether obj changed.
+func (p finalizerPolicy) apply(obj client.Object) bool {
+	changed := false
+	if p.name != p.standard && controllerutil.RemoveFinalizer(obj, p.standard) {
+		changed = true
+	}
+	if p.name != "" && controllerutil.AddFinalizer(obj, p.name) {
+		changed = true
+	}
+	return changed
+}
+
+// report sets the FinalizerDisabled condition of obj while finalizers are disabled, with message describing what the
+// operator has to clean up after deleting obj.
+func (p finalizerPolicy) report(obj conditions.Object, message string) {
+	if p.name == "" {
+		conditions.Set(obj, conditions.TypeFinalizerDisabled, metav1.ConditionTrue, conditions.ReasonFinalizerPolicy, message)
+	} else {
+		meta.RemoveStatusCondition(obj.GetConditions(), conditions.TypeFinalizerDisabled)
+	}
+}
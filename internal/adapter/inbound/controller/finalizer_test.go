@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_accountFinalizerPolicy_ShouldApplyConfiguredFinalizer(t *testing.T) {
+	testCases := []struct {
+		name               string
+		defaults           *v1alpha1.NauthDefaultsSpec
+		existing           []string
+		expectedFinalizers []string
+		expectedChanged    bool
+		expectedDisabled   bool
+	}{
+		{
+			name:               "no defaults adds standard finalizer",
+			expectedFinalizers: []string{finalizerAccount},
+			expectedChanged:    true,
+		},
+		{
+			name: "standard mode keeps standard finalizer",
+			defaults: &v1alpha1.NauthDefaultsSpec{Finalizers: &v1alpha1.FinalizerDefaults{
+				Account: &v1alpha1.FinalizerPolicy{Mode: v1alpha1.FinalizerModeStandard},
+			}},
+			existing:           []string{finalizerAccount},
+			expectedFinalizers: []string{finalizerAccount},
+		},
+		{
+			name: "custom mode replaces standard finalizer",
+			defaults: &v1alpha1.NauthDefaultsSpec{Finalizers: &v1alpha1.FinalizerDefaults{
+				Account: &v1alpha1.FinalizerPolicy{Mode: v1alpha1.FinalizerModeCustom, Name: "example.com/nauth"},
+			}},
+			existing:           []string{finalizerAccount, "other"},
+			expectedFinalizers: []string{"other", "example.com/nauth"},
+			expectedChanged:    true,
+		},
+		{
+			name: "none mode removes standard finalizer",
+			defaults: &v1alpha1.NauthDefaultsSpec{Finalizers: &v1alpha1.FinalizerDefaults{
+				Account: &v1alpha1.FinalizerPolicy{Mode: v1alpha1.FinalizerModeNone},
+			}},
+			existing:         []string{finalizerAccount},
+			expectedChanged:  true,
+			expectedDisabled: true,
+		},
+		{
+			name: "user policy does not apply to accounts",
+			defaults: &v1alpha1.NauthDefaultsSpec{Finalizers: &v1alpha1.FinalizerDefaults{
+				User: &v1alpha1.FinalizerPolicy{Mode: v1alpha1.FinalizerModeNone},
+			}},
+			existing:           []string{finalizerAccount},
+			expectedFinalizers: []string{finalizerAccount},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Finalizers: tc.existing}}
+			finalizer := accountFinalizerPolicy(tc.defaults)
+
+			changed := finalizer.apply(account)
+			finalizer.report(account, conditionMessageAccountFinalizerDisabled)
+
+			require.Equal(t, tc.expectedChanged, changed)
+			require.ElementsMatch(t, tc.expectedFinalizers, account.Finalizers)
+			require.Equal(t, tc.expectedDisabled,
+				meta.IsStatusConditionTrue(account.Status.Conditions, conditions.TypeFinalizerDisabled))
+		})
+	}
+}
+
+func Test_userFinalizerPolicy_ShouldRemoveStandardAndCustomFinalizers(t *testing.T) {
+	finalizer := userFinalizerPolicy(&v1alpha1.NauthDefaultsSpec{Finalizers: &v1alpha1.FinalizerDefaults{
+		User: &v1alpha1.FinalizerPolicy{Mode: v1alpha1.FinalizerModeCustom, Name: "example.com/nauth"},
+	}})
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{
+		Finalizers: []string{finalizerUser, "example.com/nauth", "other"},
+	}}
+
+	require.True(t, finalizer.present(user))
+	require.True(t, finalizer.remove(user))
+	require.Equal(t, []string{"other"}, user.Finalizers)
+	require.False(t, finalizer.present(user))
+	require.False(t, finalizer.remove(user))
+}
+
+func Test_userFinalizerPolicy_ShouldClearFinalizerDisabled_WhenFinalizerEnabled(t *testing.T) {
+	user := &v1alpha1.User{}
+	userFinalizerPolicy(&v1alpha1.NauthDefaultsSpec{Finalizers: &v1alpha1.FinalizerDefaults{
+		User: &v1alpha1.FinalizerPolicy{Mode: v1alpha1.FinalizerModeNone},
+	}}).report(user, conditionMessageUserFinalizerDisabled)
+	require.NotNil(t, meta.FindStatusCondition(user.Status.Conditions, conditions.TypeFinalizerDisabled))
+
+	userFinalizerPolicy(nil).report(user, conditionMessageUserFinalizerDisabled)
+
+	require.Nil(t, meta.FindStatusCondition(user.Status.Conditions, conditions.TypeFinalizerDisabled))
+}
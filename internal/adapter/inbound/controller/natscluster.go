@@ -22,10 +22,10 @@ import (
 	"os"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
@@ -129,12 +129,7 @@ func (r *NatsClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	meta.SetStatusCondition(&natsCluster.Status.Conditions, metav1.Condition{
-		Type:    conditionTypeReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  conditionReasonReconciling,
-		Message: "Reconciling NatsCluster",
-	})
+	conditions.MarkReconciling(natsCluster, "Reconciling NatsCluster")
 	if err := r.Status().Update(ctx, natsCluster); err != nil {
 		log.Info("Failed to update the NatsCluster status", "name", natsCluster.Name, "error", err)
 		return ctrl.Result{}, err
@@ -152,24 +147,30 @@ func (r *NatsClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	natsCluster.Status.ObservedGeneration = natsCluster.Generation
 	natsCluster.Status.ReconcileTimestamp = metav1.Now()
 	natsCluster.Status.OperatorVersion = operatorVersion
+	natsCluster.Status.SystemAccountID = string(clusterTarget.SystemAccountID())
 
 	return r.reporter.status(ctx, natsCluster)
 }
 
-func (r *NatsClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *NatsClusterReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	rateLimiter := requeuePolicy.newRateLimiter()
+	r.reporter.tolerateFailures(rateLimiter, requeuePolicy.MaxRetries)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.NatsCluster{}).
+		Watches(&v1alpha1.NatsCluster{}, enqueueDeletionFirst()).
 		Named("natscluster").
 		WithEventFilter(predicate.GenerationChangedPredicate{}).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1,
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
 		}).
 		Watches(
 			&v1alpha1.Account{},
 			handler.EnqueueRequestsFromMapFunc(r.mapAccountToCluster),
 			builder.WithPredicates(deleteOnlyPredicate()),
 		).
-		Complete(r)
+		Complete(traced("natscluster", r))
 }
 
 func deleteOnlyPredicate() predicate.Predicate {
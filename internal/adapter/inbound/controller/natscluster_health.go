@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// natsClusterHealthPollInterval is how often the servers of a NatsCluster are checked.
+const natsClusterHealthPollInterval = time.Minute
+
+// NatsClusterHealthReconciler periodically checks the NATS servers of a NatsCluster through its system account and
+// reports them in status.health and the Healthy condition.
+type NatsClusterHealthReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	manager  inbound.ClusterManager
+	resolver ClusterResolver
+	reporter *statusReporter
+}
+
+func NewNatsClusterHealthReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	manager inbound.ClusterManager,
+	resolver ClusterResolver,
+	recorder events.EventRecorder,
+) *NatsClusterHealthReconciler {
+	return &NatsClusterHealthReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme,
+		manager:  manager,
+		resolver: resolver,
+		reporter: newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=natsclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=natsclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *NatsClusterHealthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	natsCluster := &v1alpha1.NatsCluster{}
+	if err := r.Get(ctx, req.NamespacedName, natsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	if !natsCluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(natsCluster.DeepCopy())
+	wasUnhealthy := meta.IsStatusConditionFalse(natsCluster.Status.Conditions, conditions.TypeHealthy)
+	health, err := r.checkHealth(ctx, natsCluster)
+	if err != nil {
+		natsCluster.Status.Health = &v1alpha1.NatsClusterHealthStatus{LastCheckedAt: metav1.Now()}
+		conditions.Set(natsCluster, conditions.TypeHealthy, metav1.ConditionFalse, conditions.ReasonForError(err), err.Error())
+		if !wasUnhealthy {
+			r.reporter.warning(natsCluster, eventReasonClusterUnhealthy, actionObserved, "NATS cluster is unhealthy: %s", err)
+		}
+	} else {
+		natsCluster.Status.Health = toAPIClusterHealth(health)
+		conditions.Set(natsCluster, conditions.TypeHealthy, metav1.ConditionTrue, conditions.ReasonHealthy,
+			fmt.Sprintf("%d NATS servers responding", len(health.Servers)))
+		if wasUnhealthy {
+			r.reporter.event(natsCluster, eventReasonClusterRecovered, actionObserved, "%d NATS servers responding",
+				len(health.Servers))
+		}
+	}
+
+	if err := r.Status().Patch(ctx, natsCluster, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update NatsCluster health status: %w", err)
+	}
+	return ctrl.Result{
+		RequeueAfter: time.Duration(float64(natsClusterHealthPollInterval) * (0.9 + 0.2*rand.Float64())),
+	}, nil
+}
+
+func (r *NatsClusterHealthReconciler) checkHealth(ctx context.Context, natsCluster *v1alpha1.NatsCluster) (*nauth.ClusterHealth, error) {
+	clusterTarget, err := r.resolver.ResolveClusterTarget(ctx, natsCluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve NatsCluster target: %w", err)
+	}
+	return r.manager.CheckHealth(ctx, *clusterTarget)
+}
+
+func toAPIClusterHealth(health *nauth.ClusterHealth) *v1alpha1.NatsClusterHealthStatus {
+	servers := make([]v1alpha1.NatsServerStatus, 0, len(health.Servers))
+	for _, server := range health.Servers {
+		servers = append(servers, v1alpha1.NatsServerStatus{
+			Name:        server.Name,
+			Version:     server.Version,
+			JetStream:   server.JetStream,
+			Connections: int32(server.Connections),
+		})
+	}
+	result := &v1alpha1.NatsClusterHealthStatus{
+		ServerCount:   int32(len(servers)),
+		Servers:       servers,
+		ResolverType:  string(health.ResolverType),
+		LastCheckedAt: metav1.Now(),
+	}
+	if capacity := health.JetStreamCapacity(); capacity != nil {
+		result.JetStreamCapacity = &v1alpha1.NatsClusterJetStreamCapacity{
+			MemoryStorage: capacity.MemoryStorage,
+			DiskStorage:   capacity.DiskStorage,
+		}
+	}
+	return result
+}
+
+func (r *NatsClusterHealthReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Its own status updates must not trigger a reconcile
+		For(&v1alpha1.NatsCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("natsclusterhealth").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Complete(traced("natsclusterhealth", r))
+}
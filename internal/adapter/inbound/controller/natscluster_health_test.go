@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type NatsClusterHealthControllerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	managerMock  *clusterManagerMock
+	resolverMock *ClusterResolverMock
+	fakeRecorder *events.FakeRecorder
+
+	resourceName ktypes.NamespacedName
+
+	unitUnderTest *NatsClusterHealthReconciler
+}
+
+func TestNatsClusterHealthController_TestSuite(t *testing.T) {
+	suite.Run(t, new(NatsClusterHealthControllerTestSuite))
+}
+
+func (t *NatsClusterHealthControllerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+
+	testName := t.T().Name()
+	t.resourceName = ktypes.NamespacedName{
+		Name:      testutil.ScopedTestName("test-nats-cluster", testName),
+		Namespace: testutil.ScopedTestName("health", testName),
+	}
+
+	t.managerMock = &clusterManagerMock{}
+	t.resolverMock = &ClusterResolverMock{}
+	t.fakeRecorder = events.NewFakeRecorder(5)
+	t.unitUnderTest = NewNatsClusterHealthReconciler(
+		k8sClient,
+		k8sClient.Scheme(),
+		t.managerMock,
+		t.resolverMock,
+		t.fakeRecorder,
+	)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.resourceName.Namespace))
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.NatsCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.resourceName.Name,
+			Namespace: t.resourceName.Namespace,
+		},
+		Spec: v1alpha1.NatsClusterSpec{
+			URL:                             "nats://my-cluster:4222",
+			OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+			SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds"},
+		},
+	}))
+}
+
+func (t *NatsClusterHealthControllerTestSuite) TearDownTest() {
+	t.managerMock.AssertExpectations(t.T())
+	t.resolverMock.AssertExpectations(t.T())
+}
+
+func (t *NatsClusterHealthControllerTestSuite) Test_Reconcile_ShouldReportServers_WhenClusterResponds() {
+	// Given
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{}, nil)
+	t.managerMock.mockCheckHealth(&nauth.ClusterHealth{
+		Servers: []domain.NatsServerInfo{
+			{Name: "nats-0", Version: "2.12.0", JetStream: true, Connections: 3, JetStreamMaxMemory: 1024, JetStreamMaxStorage: 4096},
+			{Name: "nats-1", Version: "2.12.0", JetStream: true, Connections: 2, JetStreamMaxMemory: 1024, JetStreamMaxStorage: 4096},
+		},
+		ResolverType: domain.NatsResolverTypePush,
+	}, nil)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Positive(result.RequeueAfter)
+	cluster := t.getNatsCluster()
+	t.Require().NotNil(cluster.Status.Health)
+	t.Equal(int32(2), cluster.Status.Health.ServerCount)
+	t.Equal("nats-0", cluster.Status.Health.Servers[0].Name)
+	t.Equal(int32(3), cluster.Status.Health.Servers[0].Connections)
+	t.Equal("push", cluster.Status.Health.ResolverType)
+	t.Equal(&v1alpha1.NatsClusterJetStreamCapacity{MemoryStorage: 2048, DiskStorage: 8192}, cluster.Status.Health.JetStreamCapacity)
+	assertCondition(t.T(), cluster.Status.Conditions, conditions.TypeHealthy, metav1.ConditionTrue, conditions.ReasonHealthy)
+	t.Empty(t.fakeRecorder.Events)
+}
+
+func (t *NatsClusterHealthControllerTestSuite) Test_Reconcile_ShouldReportUnreachable_UntilClusterRecovers() {
+	// Given
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{}, nil)
+	t.managerMock.mockCheckHealth(nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("connection refused")))
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Positive(result.RequeueAfter)
+	cluster := t.getNatsCluster()
+	t.Require().NotNil(cluster.Status.Health)
+	t.Zero(cluster.Status.Health.ServerCount)
+	assertCondition(t.T(), cluster.Status.Conditions, conditions.TypeHealthy, metav1.ConditionFalse, string(domain.ErrClusterUnreachable))
+	t.Contains(<-t.fakeRecorder.Events, eventReasonClusterUnhealthy)
+
+	// Given the cluster responds again
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{}, nil)
+	t.managerMock.mockCheckHealth(&nauth.ClusterHealth{
+		Servers:      []domain.NatsServerInfo{{Name: "nats-0"}},
+		ResolverType: domain.NatsResolverTypePush,
+	}, nil)
+
+	// When
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	cluster = t.getNatsCluster()
+	t.Equal(int32(1), cluster.Status.Health.ServerCount)
+	assertCondition(t.T(), cluster.Status.Conditions, conditions.TypeHealthy, metav1.ConditionTrue, conditions.ReasonHealthy)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonClusterRecovered)
+}
+
+func (t *NatsClusterHealthControllerTestSuite) getNatsCluster() *v1alpha1.NatsCluster {
+	cluster := &v1alpha1.NatsCluster{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.resourceName, cluster))
+	return cluster
+}
@@ -95,7 +95,7 @@ func (t *NatsClusterControllerTestSuite) defaultNatsCluster(options ...natsClust
 		},
 		Spec: v1alpha1.NatsClusterSpec{
 			URL:                             "nats://my-cluster:4222",
-			OperatorSigningKeySecretRef:     v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+			OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
 			SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds"},
 		},
 	}
@@ -314,6 +314,18 @@ func (m *clusterManagerMock) mockValidateSpy(spy func(target nauth.ClusterTarget
 	call.Run(func(args mock.Arguments) { call.Return(spy(args.Get(1).(nauth.ClusterTarget))) })
 }
 
+func (m *clusterManagerMock) CheckHealth(ctx context.Context, target nauth.ClusterTarget) (*nauth.ClusterHealth, error) {
+	args := m.Called(ctx, target)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nauth.ClusterHealth), args.Error(1)
+}
+
+func (m *clusterManagerMock) mockCheckHealth(result *nauth.ClusterHealth, err error) {
+	m.On("CheckHealth", mock.Anything, mock.Anything).Return(result, err).Once()
+}
+
 var _ inbound.ClusterManager = (*clusterManagerMock)(nil)
 
 type ClusterResolverMock struct {
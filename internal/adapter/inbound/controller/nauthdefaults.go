@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkRequiredLabels returns domain.ErrRequiredLabelsMissing when obj lacks a label required by the NauthDefaults.
+func checkRequiredLabels(obj client.Object, defaults *v1alpha1.NauthDefaultsSpec) error {
+	if defaults == nil {
+		return nil
+	}
+	var missing []string
+	for _, key := range defaults.RequiredLabels {
+		if _, ok := obj.GetLabels()[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+	return domain.ErrRequiredLabelsMissing.WithCause(fmt.Errorf("missing labels required by NauthDefaults: %s",
+		strings.Join(missing, ", ")))
+}
+
+// userKeyPolicyViolated reports whether the credentials of user were signed with the account root key while the
+// KeyPolicy of the NauthDefaults requires a signing key, so that the user is signed again.
+func userKeyPolicyViolated(user *v1alpha1.User, defaults *v1alpha1.NauthDefaultsSpec) bool {
+	if defaults == nil || defaults.KeyPolicy == nil ||
+		defaults.KeyPolicy.UserSigning != v1alpha1.UserSigningPolicySigningKeyOnly {
+		return false
+	}
+	signedBy := user.GetLabel(v1alpha1.UserLabelSignedBy)
+	return signedBy != "" && signedBy == user.GetLabel(v1alpha1.UserLabelAccountID)
+}
+
+// applyAccountPlatformDefaults sets the limits the Account does not set from the NauthDefaults. JetStream limits are
+// only applied when the Account sets no JetStream limits of its own and does not disable JetStream.
+func applyAccountPlatformDefaults(request *nauth.AccountRequest, defaults *v1alpha1.NauthDefaultsSpec) {
+	if defaults == nil || defaults.Account == nil {
+		return
+	}
+	if request.AccountLimits == nil {
+		request.AccountLimits = toNAuthAccountLimits(defaults.Account.AccountLimits)
+	}
+	if request.JetStreamLimits == nil && len(request.JetStreamTieredLimits) == 0 &&
+		(request.JetStreamEnabled == nil || *request.JetStreamEnabled) {
+		request.JetStreamLimits = toNAuthJetStreamLimits(defaults.Account.JetStreamLimits)
+	}
+	if request.NatsLimits == nil {
+		request.NatsLimits = toNAuthNatsLimits(defaults.Account.NatsLimits)
+	}
+}
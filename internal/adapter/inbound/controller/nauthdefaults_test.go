@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkRequiredLabels(t *testing.T) {
+	defaults := &v1alpha1.NauthDefaultsSpec{RequiredLabels: []string{"team", "cost-center"}}
+
+	testCases := []struct {
+		testName      string
+		labels        map[string]string
+		defaults      *v1alpha1.NauthDefaultsSpec
+		expectedError string
+	}{
+		{
+			testName: "should_succeed_without_defaults",
+		},
+		{
+			testName: "should_succeed_when_all_labels_present",
+			labels:   map[string]string{"team": "orders", "cost-center": "1234"},
+			defaults: defaults,
+		},
+		{
+			testName:      "should_fail_listing_missing_labels",
+			labels:        map[string]string{"app": "orders"},
+			defaults:      defaults,
+			expectedError: "missing labels required by NauthDefaults: cost-center, team",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Name: "my-account", Labels: tc.labels}}
+
+			err := checkRequiredLabels(account, tc.defaults)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, domain.ErrRequiredLabelsMissing)
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_applyAccountPlatformDefaults(t *testing.T) {
+	conn := int64(10)
+	defaultConn := int64(100)
+	diskStorage := int64(1024)
+	subs := int64(1000)
+	jetStreamDisabled := false
+	defaults := &v1alpha1.NauthDefaultsSpec{
+		Account: &v1alpha1.AccountPlatformDefaults{
+			AccountLimits:   &v1alpha1.AccountLimits{Conn: &defaultConn},
+			JetStreamLimits: &v1alpha1.JetStreamLimits{DiskStorage: &diskStorage},
+			NatsLimits:      &v1alpha1.NatsLimits{Subs: &subs},
+		},
+	}
+
+	testCases := []struct {
+		testName string
+		request  nauth.AccountRequest
+		defaults *v1alpha1.NauthDefaultsSpec
+		expected nauth.AccountRequest
+	}{
+		{
+			testName: "should_keep_request_without_defaults",
+			request:  nauth.AccountRequest{DisplayName: "my-account"},
+			expected: nauth.AccountRequest{DisplayName: "my-account"},
+		},
+		{
+			testName: "should_apply_defaults_to_unset_limits",
+			request:  nauth.AccountRequest{},
+			defaults: defaults,
+			expected: nauth.AccountRequest{
+				AccountLimits:   &nauth.AccountLimits{Conn: &defaultConn},
+				JetStreamLimits: &nauth.JetStreamLimits{DiskStorage: &diskStorage},
+				NatsLimits:      &nauth.NatsLimits{Subs: &subs},
+			},
+		},
+		{
+			testName: "should_keep_limits_set_in_spec",
+			request: nauth.AccountRequest{
+				AccountLimits: &nauth.AccountLimits{Conn: &conn},
+			},
+			defaults: defaults,
+			expected: nauth.AccountRequest{
+				AccountLimits:   &nauth.AccountLimits{Conn: &conn},
+				JetStreamLimits: &nauth.JetStreamLimits{DiskStorage: &diskStorage},
+				NatsLimits:      &nauth.NatsLimits{Subs: &subs},
+			},
+		},
+		{
+			testName: "should_not_apply_jetstream_limits_with_tiered_limits",
+			request: nauth.AccountRequest{
+				JetStreamTieredLimits: nauth.JetStreamTieredLimits{"R1": {DiskStorage: &diskStorage}},
+			},
+			defaults: defaults,
+			expected: nauth.AccountRequest{
+				AccountLimits:         &nauth.AccountLimits{Conn: &defaultConn},
+				JetStreamTieredLimits: nauth.JetStreamTieredLimits{"R1": {DiskStorage: &diskStorage}},
+				NatsLimits:            &nauth.NatsLimits{Subs: &subs},
+			},
+		},
+		{
+			testName: "should_not_apply_jetstream_limits_when_jetstream_disabled",
+			request:  nauth.AccountRequest{JetStreamEnabled: &jetStreamDisabled},
+			defaults: defaults,
+			expected: nauth.AccountRequest{
+				JetStreamEnabled: &jetStreamDisabled,
+				AccountLimits:    &nauth.AccountLimits{Conn: &defaultConn},
+				NatsLimits:       &nauth.NatsLimits{Subs: &subs},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			request := tc.request
+
+			applyAccountPlatformDefaults(&request, tc.defaults)
+
+			require.Equal(t, tc.expected, request)
+		})
+	}
+}
+
+func Test_userKeyPolicyViolated(t *testing.T) {
+	signingKeyOnly := &v1alpha1.NauthDefaultsSpec{
+		KeyPolicy: &v1alpha1.KeyPolicy{UserSigning: v1alpha1.UserSigningPolicySigningKeyOnly},
+	}
+	rootSigned := map[string]string{
+		string(v1alpha1.UserLabelAccountID): "ACCOUNT",
+		string(v1alpha1.UserLabelSignedBy):  "ACCOUNT",
+	}
+
+	testCases := []struct {
+		testName string
+		labels   map[string]string
+		defaults *v1alpha1.NauthDefaultsSpec
+		expected bool
+	}{
+		{
+			testName: "should_allow_root_key_without_defaults",
+			labels:   rootSigned,
+		},
+		{
+			testName: "should_allow_root_key_with_policy_any",
+			labels:   rootSigned,
+			defaults: &v1alpha1.NauthDefaultsSpec{KeyPolicy: &v1alpha1.KeyPolicy{UserSigning: v1alpha1.UserSigningPolicyAny}},
+		},
+		{
+			testName: "should_allow_signing_key",
+			labels: map[string]string{
+				string(v1alpha1.UserLabelAccountID): "ACCOUNT",
+				string(v1alpha1.UserLabelSignedBy):  "SIGNING_KEY",
+			},
+			defaults: signingKeyOnly,
+		},
+		{
+			testName: "should_allow_user_not_signed_yet",
+			defaults: signingKeyOnly,
+		},
+		{
+			testName: "should_report_root_key",
+			labels:   rootSigned,
+			defaults: signingKeyOnly,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "my-user", Labels: tc.labels}}
+			require.Equal(t, tc.expected, userKeyPolicyViolated(user, tc.defaults))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/nats-io/jwt/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const nauthPolicyNamespacePlaceholder = "{namespace}"
+
+// checkAccountPolicies returns domain.ErrPolicyViolation when request exports or imports a subject not allowed by a
+// NauthPolicy selecting account.
+func checkAccountPolicies(account *v1alpha1.Account, request nauth.AccountRequest, policies []v1alpha1.NauthPolicy) error {
+	var violations []string
+	for _, policy := range policies {
+		selected, err := nauthPolicySelects(policy.Spec, account)
+		if err != nil {
+			return domain.ErrBadRequest.WithCause(fmt.Errorf("invalid account selector of NauthPolicy %s: %w", policy.Name, err))
+		}
+		if !selected {
+			continue
+		}
+		for _, group := range request.ExportGroups {
+			for _, export := range group.Exports {
+				if reason := checkSubjectPolicy(policy.Spec.Exports, export.Subject, account.Namespace); reason != "" {
+					violations = append(violations, fmt.Sprintf("%s (export %q of %s %s)", policy.Name, export.Subject, group.Name, reason))
+				}
+			}
+		}
+		for _, group := range request.ImportGroups {
+			for _, imp := range group.Imports {
+				if reason := checkSubjectPolicy(policy.Spec.Imports, imp.Subject, account.Namespace); reason != "" {
+					violations = append(violations, fmt.Sprintf("%s (import %q of %s %s)", policy.Name, imp.Subject, group.Name, reason))
+				}
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return domain.ErrPolicyViolation.WithCause(fmt.Errorf("violates NauthPolicy %s", strings.Join(violations, ", ")))
+}
+
+func nauthPolicySelects(spec v1alpha1.NauthPolicySpec, account *v1alpha1.Account) (bool, error) {
+	if len(spec.Namespaces) > 0 && !slices.Contains(spec.Namespaces, account.Namespace) {
+		return false, nil
+	}
+	if spec.AccountSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.AccountSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(account.GetLabels())), nil
+}
+
+// checkSubjectPolicy returns why policy does not allow subject for an Account in namespace, or an empty string when it
+// is allowed.
+func checkSubjectPolicy(policy *v1alpha1.SubjectPolicy, subject nauth.Subject, namespace string) string {
+	if policy == nil {
+		return ""
+	}
+	for _, pattern := range policy.Deny {
+		pattern = strings.ReplaceAll(pattern, nauthPolicyNamespacePlaceholder, namespace)
+		if subjectsOverlap(string(subject), pattern) {
+			return fmt.Sprintf("is denied by %q", pattern)
+		}
+	}
+	if len(policy.Allow) == 0 {
+		return ""
+	}
+	for _, pattern := range policy.Allow {
+		pattern = strings.ReplaceAll(pattern, nauthPolicyNamespacePlaceholder, namespace)
+		if jwt.Subject(subject).IsContainedIn(jwt.Subject(pattern)) {
+			return ""
+		}
+	}
+	return "is not allowed"
+}
+
+// subjectsOverlap reports whether a subject is matched by both a and b, which may contain wildcards.
+func subjectsOverlap(a string, b string) bool {
+	aTokens, bTokens := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aTokens) && i < len(bTokens); i++ {
+		switch {
+		case aTokens[i] == ">" || bTokens[i] == ">":
+			return true
+		case aTokens[i] != bTokens[i] && aTokens[i] != "*" && bTokens[i] != "*":
+			return false
+		}
+	}
+	return len(aTokens) == len(bTokens)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkAccountPolicies(t *testing.T) {
+	account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{
+		Name:      "orders",
+		Namespace: "team-a",
+		Labels:    map[string]string{"tier": "critical"},
+	}}
+	request := nauth.AccountRequest{
+		ExportGroups: nauth.ExportGroups{{
+			Name: GroupNameInline,
+			Exports: nauth.Exports{
+				{Subject: "team-a.orders.>", Type: nauth.ExportTypeStream},
+			},
+		}},
+		ImportGroups: nauth.ImportGroups{{
+			Name: "billing",
+			Imports: nauth.Imports{
+				{AccountID: "ACCOUNT_BILLING", Subject: "billing.invoices", Type: nauth.ExportTypeStream},
+			},
+		}},
+	}
+
+	testCases := []struct {
+		testName      string
+		policy        v1alpha1.NauthPolicySpec
+		expectedError string
+	}{
+		{
+			testName: "should_allow_subjects_in_allowlist_with_namespace",
+			policy: v1alpha1.NauthPolicySpec{
+				Exports: &v1alpha1.SubjectPolicy{Allow: []string{"{namespace}.>"}},
+				Imports: &v1alpha1.SubjectPolicy{Allow: []string{"billing.*"}},
+			},
+		},
+		{
+			testName:      "should_fail_when_export_not_in_allowlist",
+			policy:        v1alpha1.NauthPolicySpec{Exports: &v1alpha1.SubjectPolicy{Allow: []string{"{namespace}.orders.created"}}},
+			expectedError: `violates NauthPolicy strict (export "team-a.orders.>" of inline is not allowed)`,
+		},
+		{
+			testName:      "should_fail_when_wildcard_export_overlaps_denylist",
+			policy:        v1alpha1.NauthPolicySpec{Exports: &v1alpha1.SubjectPolicy{Deny: []string{"*.orders.internal.>"}}},
+			expectedError: `violates NauthPolicy strict (export "team-a.orders.>" of inline is denied by "*.orders.internal.>")`,
+		},
+		{
+			testName: "should_fail_when_denied_in_allowlist",
+			policy: v1alpha1.NauthPolicySpec{Imports: &v1alpha1.SubjectPolicy{
+				Allow: []string{"billing.>"},
+				Deny:  []string{"billing.invoices"},
+			}},
+			expectedError: `violates NauthPolicy strict (import "billing.invoices" of billing is denied by "billing.invoices")`,
+		},
+		{
+			testName: "should_ignore_other_namespaces",
+			policy: v1alpha1.NauthPolicySpec{
+				Namespaces: []string{"team-b"},
+				Exports:    &v1alpha1.SubjectPolicy{Deny: []string{">"}},
+			},
+		},
+		{
+			testName: "should_ignore_accounts_not_matching_selector",
+			policy: v1alpha1.NauthPolicySpec{
+				AccountSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "sandbox"}},
+				Exports:         &v1alpha1.SubjectPolicy{Deny: []string{">"}},
+			},
+		},
+		{
+			testName: "should_fail_for_selected_accounts",
+			policy: v1alpha1.NauthPolicySpec{
+				Namespaces:      []string{"team-a"},
+				AccountSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+				Imports:         &v1alpha1.SubjectPolicy{Deny: []string{">"}},
+			},
+			expectedError: `violates NauthPolicy strict (import "billing.invoices" of billing is denied by ">")`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			policies := []v1alpha1.NauthPolicy{{ObjectMeta: metav1.ObjectMeta{Name: "strict"}, Spec: tc.policy}}
+
+			err := checkAccountPolicies(account, request, policies)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, domain.ErrPolicyViolation)
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_subjectsOverlap(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "orders.created", b: "orders.created", expected: true},
+		{a: "orders.created", b: "orders.deleted", expected: false},
+		{a: "orders.*", b: "orders.created", expected: true},
+		{a: "orders.>", b: "orders.internal.audit", expected: true},
+		{a: "*.created", b: "orders.*", expected: true},
+		{a: "orders.*", b: "orders.created.v1", expected: false},
+		{a: "orders", b: "orders.>", expected: false},
+		{a: ">", b: "billing", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expected, subjectsOverlap(tc.a, tc.b))
+			assert.Equal(t, tc.expected, subjectsOverlap(tc.b, tc.a))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// quotaTotal is the sum of a limit over the admitted resources of a namespace. A single unlimited resource makes the
+// total unlimited.
+type quotaTotal struct {
+	sum       int64
+	unlimited bool
+}
+
+func (t *quotaTotal) add(limit *int64) {
+	if limit == nil || *limit < 0 {
+		t.unlimited = true
+		return
+	}
+	t.sum += *limit
+}
+
+func (t quotaTotal) exceeds(maximum *int64) bool {
+	return maximum != nil && (t.unlimited || t.sum > *maximum)
+}
+
+func (t quotaTotal) String() string {
+	if t.unlimited {
+		return "unlimited"
+	}
+	return strconv.FormatInt(t.sum, 10)
+}
+
+// checkAccountQuotas returns domain.ErrQuotaExceeded when account does not fit the quotas of its namespace. The managed
+// Accounts are admitted in creation order, so an Account never pushes an Account created before it out of quota.
+// Limits the Account does not set are taken from the NauthDefaults.
+func checkAccountQuotas(account *v1alpha1.Account, accounts []v1alpha1.Account, quotas []v1alpha1.NauthQuota,
+	defaults *v1alpha1.NauthDefaultsSpec) error {
+	if len(quotas) == 0 {
+		return nil
+	}
+	admitted := []*v1alpha1.Account{account}
+	for i := range accounts {
+		peer := &accounts[i]
+		if admittedBefore(peer, account) &&
+			peer.GetLabel(v1alpha1.AccountLabelManagementPolicy) != v1alpha1.AccountManagementPolicyObserve {
+			admitted = append(admitted, peer)
+		}
+	}
+
+	var connections, diskStorage quotaTotal
+	for _, peer := range admitted {
+		request := toBootstrapAccountRequest(peer, nauth.AccountReference{})
+		applyAccountPlatformDefaults(&request, defaults)
+		if request.AccountLimits != nil {
+			connections.add(request.AccountLimits.Conn)
+		} else {
+			connections.add(nil)
+		}
+		addJetStreamDiskStorage(&diskStorage, request)
+	}
+	return checkQuotas(quotas, map[string]quotaTotal{
+		"maxAccounts":             {sum: int64(len(admitted))},
+		"maxConnections":          connections,
+		"maxJetStreamDiskStorage": diskStorage,
+	})
+}
+
+// checkUserQuotas returns domain.ErrQuotaExceeded when user does not fit the quotas of its namespace. Users are
+// admitted in creation order.
+func checkUserQuotas(user *v1alpha1.User, users []v1alpha1.User, quotas []v1alpha1.NauthQuota) error {
+	if len(quotas) == 0 {
+		return nil
+	}
+	count := int64(1)
+	for i := range users {
+		if admittedBefore(&users[i], user) {
+			count++
+		}
+	}
+	return checkQuotas(quotas, map[string]quotaTotal{
+		"maxUsers": {sum: count},
+	})
+}
+
+func addJetStreamDiskStorage(total *quotaTotal, request nauth.AccountRequest) {
+	switch {
+	case request.JetStreamEnabled != nil && !*request.JetStreamEnabled:
+	case len(request.JetStreamTieredLimits) > 0:
+		for _, tier := range request.JetStreamTieredLimits {
+			total.add(tier.DiskStorage)
+		}
+	case request.JetStreamLimits != nil:
+		total.add(request.JetStreamLimits.DiskStorage)
+	default:
+		// Accounts without JetStream limits get unlimited JetStream
+		total.add(nil)
+	}
+}
+
+// admittedBefore reports whether peer is admitted to the quotas of its namespace before obj. Resources being deleted
+// are not admitted.
+func admittedBefore(peer client.Object, obj client.Object) bool {
+	if peer.GetName() == obj.GetName() || peer.GetDeletionTimestamp() != nil {
+		return false
+	}
+	peerCreated, objCreated := peer.GetCreationTimestamp(), obj.GetCreationTimestamp()
+	if !peerCreated.Equal(&objCreated) {
+		return peerCreated.Before(&objCreated)
+	}
+	return peer.GetName() < obj.GetName()
+}
+
+func checkQuotas(quotas []v1alpha1.NauthQuota, usage map[string]quotaTotal) error {
+	fields := slices.Sorted(maps.Keys(usage))
+	var exceeded []string
+	for _, quota := range quotas {
+		maximums := map[string]*int64{
+			"maxAccounts":             quota.Spec.MaxAccounts,
+			"maxUsers":                quota.Spec.MaxUsers,
+			"maxConnections":          quota.Spec.MaxConnections,
+			"maxJetStreamDiskStorage": quota.Spec.MaxJetStreamDiskStorage,
+		}
+		for _, field := range fields {
+			if total := usage[field]; total.exceeds(maximums[field]) {
+				exceeded = append(exceeded, fmt.Sprintf("%s (%s %d, requested %s)", quota.Name, field, *maximums[field], total))
+			}
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return domain.ErrQuotaExceeded.WithCause(fmt.Errorf("exceeds NauthQuota %s", strings.Join(exceeded, ", ")))
+}
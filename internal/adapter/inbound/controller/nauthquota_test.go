@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkAccountQuotas(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newAccount := func(name string, age time.Duration, conn int64, diskStorage int64) v1alpha1.Account {
+		return v1alpha1.Account{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-team", CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec: v1alpha1.AccountSpec{
+				AccountLimits:   &v1alpha1.AccountLimits{Conn: &conn},
+				JetStreamLimits: &v1alpha1.JetStreamLimits{DiskStorage: &diskStorage},
+			},
+		}
+	}
+	maximum := func(value int64) *int64 {
+		return &value
+	}
+	older := newAccount("older", time.Hour, 100, 1024)
+	account := newAccount("account", 0, 100, 1024)
+	newer := newAccount("newer", -time.Hour, 100, 1024)
+	deleting := newAccount("deleting", time.Hour, 100, 1024)
+	deleting.DeletionTimestamp = &metav1.Time{Time: created}
+	observed := newAccount("observed", time.Hour, 100, 1024)
+	observed.Labels = map[string]string{string(v1alpha1.AccountLabelManagementPolicy): v1alpha1.AccountManagementPolicyObserve}
+	unlimited := v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Name: "unlimited", Namespace: "my-team"}}
+	jetStreamDisabled := false
+	withoutJetStream := newAccount("account", 0, 100, 0)
+	withoutJetStream.Spec.JetStreamLimits = nil
+	withoutJetStream.Spec.JetStreamEnabled = &jetStreamDisabled
+	tiered := newAccount("account", 0, 100, 0)
+	tiered.Spec.JetStreamLimits = nil
+	tiered.Spec.JetStreamTieredLimits = v1alpha1.JetStreamTieredLimits{
+		"R1": {DiskStorage: maximum(1024)},
+		"R3": {DiskStorage: maximum(512)},
+	}
+
+	testCases := []struct {
+		testName      string
+		account       v1alpha1.Account
+		accounts      []v1alpha1.Account
+		quota         v1alpha1.NauthQuotaSpec
+		defaults      *v1alpha1.NauthDefaultsSpec
+		expectedError string
+	}{
+		{
+			testName: "should_succeed_within_quota",
+			account:  account,
+			accounts: []v1alpha1.Account{older, account},
+			quota:    v1alpha1.NauthQuotaSpec{MaxAccounts: maximum(2), MaxConnections: maximum(200), MaxJetStreamDiskStorage: maximum(2048)},
+		},
+		{
+			testName:      "should_fail_when_account_count_exceeded",
+			account:       account,
+			accounts:      []v1alpha1.Account{older, account},
+			quota:         v1alpha1.NauthQuotaSpec{MaxAccounts: maximum(1)},
+			expectedError: "exceeds NauthQuota team (maxAccounts 1, requested 2)",
+		},
+		{
+			testName: "should_not_count_accounts_created_later",
+			account:  account,
+			accounts: []v1alpha1.Account{account, newer},
+			quota:    v1alpha1.NauthQuotaSpec{MaxAccounts: maximum(1)},
+		},
+		{
+			testName: "should_not_count_deleting_or_observed_accounts",
+			account:  account,
+			accounts: []v1alpha1.Account{deleting, observed},
+			quota:    v1alpha1.NauthQuotaSpec{MaxAccounts: maximum(1)},
+		},
+		{
+			testName:      "should_fail_when_connections_exceeded",
+			account:       account,
+			accounts:      []v1alpha1.Account{older},
+			quota:         v1alpha1.NauthQuotaSpec{MaxConnections: maximum(150)},
+			expectedError: "exceeds NauthQuota team (maxConnections 150, requested 200)",
+		},
+		{
+			testName:      "should_fail_when_account_is_unlimited",
+			account:       unlimited,
+			quota:         v1alpha1.NauthQuotaSpec{MaxConnections: maximum(150), MaxJetStreamDiskStorage: maximum(2048)},
+			expectedError: "exceeds NauthQuota team (maxConnections 150, requested unlimited), team (maxJetStreamDiskStorage 2048, requested unlimited)",
+		},
+		{
+			testName: "should_count_limits_from_defaults",
+			account:  unlimited,
+			quota:    v1alpha1.NauthQuotaSpec{MaxConnections: maximum(150), MaxJetStreamDiskStorage: maximum(2048)},
+			defaults: &v1alpha1.NauthDefaultsSpec{Account: &v1alpha1.AccountPlatformDefaults{
+				AccountLimits:   &v1alpha1.AccountLimits{Conn: maximum(100)},
+				JetStreamLimits: &v1alpha1.JetStreamLimits{DiskStorage: maximum(1024)},
+			}},
+		},
+		{
+			testName: "should_not_count_disk_storage_when_jetstream_disabled",
+			account:  withoutJetStream,
+			quota:    v1alpha1.NauthQuotaSpec{MaxJetStreamDiskStorage: maximum(0)},
+		},
+		{
+			testName:      "should_sum_disk_storage_of_all_tiers",
+			account:       tiered,
+			accounts:      []v1alpha1.Account{older},
+			quota:         v1alpha1.NauthQuotaSpec{MaxJetStreamDiskStorage: maximum(2048)},
+			expectedError: "exceeds NauthQuota team (maxJetStreamDiskStorage 2048, requested 2560)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			quotas := []v1alpha1.NauthQuota{{ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "my-team"}, Spec: tc.quota}}
+
+			err := checkAccountQuotas(&tc.account, tc.accounts, quotas, tc.defaults)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, domain.ErrQuotaExceeded)
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_checkAccountQuotas_ShouldSucceedWithoutQuotas(t *testing.T) {
+	account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Name: "account", Namespace: "my-team"}}
+
+	require.NoError(t, checkAccountQuotas(account, nil, nil, nil))
+}
+
+func Test_checkUserQuotas(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newUser := func(name string, age time.Duration) v1alpha1.User {
+		return v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-team", CreationTimestamp: metav1.NewTime(created.Add(-age))}}
+	}
+	maxUsers := int64(2)
+	quotas := []v1alpha1.NauthQuota{{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "my-team"},
+		Spec:       v1alpha1.NauthQuotaSpec{MaxUsers: &maxUsers},
+	}}
+
+	testCases := []struct {
+		testName      string
+		user          v1alpha1.User
+		expectedError string
+	}{
+		{
+			testName: "should_succeed_for_first_users",
+			user:     newUser("b", time.Hour),
+		},
+		{
+			testName: "should_order_users_created_at_the_same_time_by_name",
+			user:     newUser("b", 2*time.Hour),
+		},
+		{
+			testName:      "should_fail_for_users_over_quota",
+			user:          newUser("d", 0),
+			expectedError: "exceeds NauthQuota team (maxUsers 2, requested 4)",
+		},
+	}
+
+	users := []v1alpha1.User{newUser("a", 2*time.Hour), newUser("b", 2*time.Hour), newUser("c", time.Hour), newUser("d", 0)}
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := checkUserQuotas(&tc.user, users, quotas)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, domain.ErrQuotaExceeded)
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+)
+
+// notificationTypes are the event reasons sent to external systems as notifications.
+var notificationTypes = map[string]domain.NotificationType{
+	eventReasonSigningKeyCreated:  domain.NotificationTypeAccountCreated,
+	eventReasonAccountDeleted:     domain.NotificationTypeAccountDeleted,
+	eventReasonCredentialsRotated: domain.NotificationTypeCredentialsRotated,
+	eventReasonDriftDetected:      domain.NotificationTypeDriftDetected,
+	eventReasonDeletionBlocked:    domain.NotificationTypeDeletionBlocked,
+}
+
+// notifyingEventRecorder records events and sends the lifecycle events among them as notifications.
+type notifyingEventRecorder struct {
+	events.EventRecorder
+	notifier outbound.Notifier
+}
+
+// NewNotifyingEventRecorder returns an EventRecorder that records events with recorder and also sends account
+// creation and deletion, credential rotation, drift and blocked deletion events to notifier.
+func NewNotifyingEventRecorder(recorder events.EventRecorder, notifier outbound.Notifier) events.EventRecorder {
+	return &notifyingEventRecorder{
+		EventRecorder: recorder,
+		notifier:      notifier,
+	}
+}
+
+func (r *notifyingEventRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action,
+	note string, args ...interface{}) {
+	r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+
+	notificationType, ok := notificationTypes[reason]
+	if !ok {
+		return
+	}
+	object, err := meta.Accessor(regarding)
+	if err != nil {
+		return
+	}
+	// Notify only queues the notification, the reconcile context is not available to the recorder
+	r.notifier.Notify(context.Background(), domain.Notification{
+		Type: notificationType,
+		Resource: domain.AuditResource{
+			Kind:      notificationKind(regarding),
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+		},
+		Warning: eventtype == v1.EventTypeWarning,
+		Message: fmt.Sprintf(note, args...),
+	})
+}
+
+// notificationKind returns the kind of object, which objects read through the client do not have in their TypeMeta.
+func notificationKind(object runtime.Object) string {
+	switch object.(type) {
+	case *v1alpha1.Account:
+		return "Account"
+	case *v1alpha1.User:
+		return "User"
+	default:
+		return object.GetObjectKind().GroupVersionKind().Kind
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+func TestNotifyingEventRecorder_Eventf(t *testing.T) {
+	account := &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "team"}}
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team"}}
+
+	testCases := []struct {
+		testName  string
+		regarding Object
+		eventType string
+		reason    string
+		expected  []domain.Notification
+	}{
+		{
+			testName:  "should_notify_drift",
+			regarding: account,
+			eventType: v1.EventTypeWarning,
+			reason:    eventReasonDriftDetected,
+			expected: []domain.Notification{{
+				Type:     domain.NotificationTypeDriftDetected,
+				Resource: domain.AuditResource{Kind: "Account", Namespace: "team", Name: "orders"},
+				Warning:  true,
+				Message:  "note ACC",
+			}},
+		},
+		{
+			testName:  "should_notify_credential_rotation",
+			regarding: user,
+			eventType: v1.EventTypeNormal,
+			reason:    eventReasonCredentialsRotated,
+			expected: []domain.Notification{{
+				Type:     domain.NotificationTypeCredentialsRotated,
+				Resource: domain.AuditResource{Kind: "User", Namespace: "team", Name: "app"},
+				Message:  "note ACC",
+			}},
+		},
+		{
+			testName:  "should_not_notify_other_events",
+			regarding: account,
+			eventType: v1.EventTypeNormal,
+			reason:    eventReasonJWTPushed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			fakeRecorder := events.NewFakeRecorder(1)
+			notifier := &notifierFake{}
+			unitUnderTest := NewNotifyingEventRecorder(fakeRecorder, notifier)
+
+			unitUnderTest.Eventf(tc.regarding, nil, tc.eventType, tc.reason, actionReconciled, "note %s", "ACC")
+
+			require.Len(t, fakeRecorder.Events, 1, "the event should be recorded")
+			assert.Equal(t, tc.expected, notifier.notifications)
+		})
+	}
+}
+
+type notifierFake struct {
+	notifications []domain.Notification
+}
+
+func (n *notifierFake) Notify(_ context.Context, notification domain.Notification) {
+	n.notifications = append(n.notifications, notification)
+}
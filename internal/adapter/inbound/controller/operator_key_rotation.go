@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// operatorKeyRotationPollInterval is how often a rotation in progress checks the Accounts bound to the cluster.
+const operatorKeyRotationPollInterval = 10 * time.Second
+
+// OperatorKeyRotationReconciler drives the rotation of the operator signing key of a NatsCluster. When the key referenced
+// by the NatsCluster changes, it requests a repush of every Account bound to the cluster, so they are signed with the
+// new key, and retires the previous key once NATS accepted all of them.
+type OperatorKeyRotationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	resolver ClusterResolver
+	reporter *statusReporter
+}
+
+func NewOperatorKeyRotationReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	resolver ClusterResolver,
+	recorder events.EventRecorder,
+) *OperatorKeyRotationReconciler {
+	return &OperatorKeyRotationReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme,
+		resolver: resolver,
+		reporter: newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=natsclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=natsclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *OperatorKeyRotationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	natsCluster := &v1alpha1.NatsCluster{}
+	if err := r.Get(ctx, req.NamespacedName, natsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	if !natsCluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	clusterTarget, err := r.resolver.ResolveClusterTarget(ctx, natsCluster)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to resolve NatsCluster target: %w", err)
+	}
+	signingKey, err := clusterTarget.OperatorSigningKey.PublicKey()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get operator signing public key: %w", err)
+	}
+	// A NatsCluster with several operator signing keys signs accounts with any of them
+	signingKeys, err := clusterTarget.OperatorSigningPublicKeys()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	patch := client.MergeFrom(natsCluster.DeepCopy())
+	status := &natsCluster.Status
+	if previousSigningKey := status.OperatorSigningKey; previousSigningKey != signingKey {
+		status.OperatorSigningKey = signingKey
+		if previousSigningKey != "" && !slices.Contains(signingKeys, previousSigningKey) {
+			status.OperatorSigningKeyRotation = &v1alpha1.OperatorSigningKeyRotationStatus{
+				PreviousSigningKey: previousSigningKey,
+				SigningKey:         signingKey,
+				Phase:              v1alpha1.OperatorSigningKeyRotationResigning,
+				StartedAt:          metav1.Now(),
+			}
+			status.RetiredOperatorSigningKeys = slices.DeleteFunc(status.RetiredOperatorSigningKeys, func(key string) bool {
+				return key == signingKey
+			})
+			r.reporter.event(natsCluster, eventReasonOperatorKeyRotationStarted, actionReconciled,
+				"Started rotation of operator signing key %s to %s", previousSigningKey, signingKey)
+		}
+	}
+
+	rotation := status.OperatorSigningKeyRotation
+	if rotation != nil && rotation.Phase == v1alpha1.OperatorSigningKeyRotationResigning {
+		pending, err := r.resignAccounts(ctx, natsCluster, rotation, signingKeys)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		rotation.PendingAccounts = pending
+		if pending == 0 {
+			rotation.Phase = v1alpha1.OperatorSigningKeyRotationCompleted
+			rotation.CompletedAt = new(metav1.Now())
+			if !slices.Contains(status.RetiredOperatorSigningKeys, rotation.PreviousSigningKey) {
+				status.RetiredOperatorSigningKeys = append(status.RetiredOperatorSigningKeys, rotation.PreviousSigningKey)
+			}
+			r.reporter.event(natsCluster, eventReasonOperatorKeyRetired, actionReconciled,
+				"All accounts are signed with operator signing key %s, retired %s", rotation.SigningKey, rotation.PreviousSigningKey)
+		}
+	}
+
+	if err := r.Status().Patch(ctx, natsCluster, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update operator signing key rotation status: %w", err)
+	}
+
+	if rotation != nil && rotation.Phase == v1alpha1.OperatorSigningKeyRotationResigning {
+		return ctrl.Result{RequeueAfter: operatorKeyRotationPollInterval}, nil
+	}
+	// The referenced signing key may also change through the content of its Secret
+	return ctrl.Result{
+		RequeueAfter: time.Duration(float64(5*time.Minute) * (0.9 + 0.2*rand.Float64())),
+	}, nil
+}
+
+// resignAccounts requests a repush of the Accounts bound to natsCluster not yet signed with one of signingKeys and
+// returns how many of them remain. An Account is only labelled with the new signing key once NATS accepted its JWT.
+func (r *OperatorKeyRotationReconciler) resignAccounts(ctx context.Context, natsCluster *v1alpha1.NatsCluster, rotation *v1alpha1.OperatorSigningKeyRotationStatus, signingKeys []string) (int64, error) {
+	accounts := &v1alpha1.AccountList{}
+	if err := r.List(ctx, accounts, client.MatchingLabels{string(v1alpha1.AccountLabelNatsClusterID): string(natsCluster.UID)}); err != nil {
+		return 0, fmt.Errorf("failed to list accounts bound to NatsCluster: %w", err)
+	}
+
+	requestedAt := rotation.StartedAt.UTC().Format(time.RFC3339Nano)
+	var pending int64
+	for i := range accounts.Items {
+		account := &accounts.Items[i]
+		if !account.DeletionTimestamp.IsZero() ||
+			account.GetLabel(v1alpha1.AccountLabelManagementPolicy) == v1alpha1.AccountManagementPolicyObserve ||
+			slices.Contains(signingKeys, account.GetLabel(v1alpha1.AccountLabelSignedBy)) {
+			continue
+		}
+		pending++
+		if account.GetAnnotations()[v1alpha1.AccountAnnotationRepushRequestedAt] == requestedAt {
+			continue
+		}
+
+		patch := client.MergeFrom(account.DeepCopy())
+		annotations := account.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[v1alpha1.AccountAnnotationRepushRequestedAt] = requestedAt
+		account.SetAnnotations(annotations)
+		if err := r.Patch(ctx, account, patch); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to request repush of account %s/%s: %w", account.Namespace, account.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// mapAccountToOperatorKeyRotation reconciles the NatsCluster an Account is bound to while it rotates its signing key.
+func (r *OperatorKeyRotationReconciler) mapAccountToOperatorKeyRotation(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterID := obj.GetLabels()[string(v1alpha1.AccountLabelNatsClusterID)]
+	if clusterID == "" {
+		return nil
+	}
+	clusters := &v1alpha1.NatsClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list NatsClusters for Account watch", "clusterID", clusterID)
+		return nil
+	}
+	for _, cluster := range clusters.Items {
+		rotation := cluster.Status.OperatorSigningKeyRotation
+		if string(cluster.UID) == clusterID && rotation != nil && rotation.Phase == v1alpha1.OperatorSigningKeyRotationResigning {
+			return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(&cluster)}}
+		}
+	}
+	return nil
+}
+
+func (r *OperatorKeyRotationReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Its own status updates must not trigger a reconcile
+		For(&v1alpha1.NatsCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("operatorkeyrotation").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Watches(
+			&v1alpha1.Account{},
+			handler.EnqueueRequestsFromMapFunc(r.mapAccountToOperatorKeyRotation),
+		).
+		Complete(traced("operatorkeyrotation", r))
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type OperatorKeyRotationControllerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	resolverMock *ClusterResolverMock
+	fakeRecorder *events.FakeRecorder
+
+	resourceName ktypes.NamespacedName
+
+	unitUnderTest *OperatorKeyRotationReconciler
+}
+
+func TestOperatorKeyRotationController_TestSuite(t *testing.T) {
+	suite.Run(t, new(OperatorKeyRotationControllerTestSuite))
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+
+	testName := t.T().Name()
+	t.resourceName = ktypes.NamespacedName{
+		Name:      testutil.ScopedTestName("test-nats-cluster", testName),
+		Namespace: testutil.ScopedTestName("keyrotation", testName),
+	}
+
+	t.resolverMock = &ClusterResolverMock{}
+	t.fakeRecorder = events.NewFakeRecorder(5)
+	t.unitUnderTest = NewOperatorKeyRotationReconciler(
+		k8sClient,
+		k8sClient.Scheme(),
+		t.resolverMock,
+		t.fakeRecorder,
+	)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.resourceName.Namespace))
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) TearDownTest() {
+	t.resolverMock.AssertExpectations(t.T())
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) Test_Reconcile_ShouldRecordSigningKey_WhenFirstObserved() {
+	// Given
+	signingKey := testutil.NatsTestOperatorA.Sign
+	t.setupNatsCluster("")
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{OperatorSigningKey: signingKey.Key}, nil)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Greater(result.RequeueAfter, operatorKeyRotationPollInterval)
+	cluster := t.getNatsCluster()
+	t.Equal(signingKey.PublicKey, cluster.Status.OperatorSigningKey)
+	t.Nil(cluster.Status.OperatorSigningKeyRotation)
+	t.Empty(t.fakeRecorder.Events)
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) Test_Reconcile_ShouldRetirePreviousKey_WhenAllAccountsResigned() {
+	// Given
+	previousKey := testutil.NatsTestOperatorA.Sign
+	newKey := testutil.CreateNatsTestOperator().Sign
+	cluster := t.setupNatsCluster(previousKey.PublicKey)
+	t.setupAccount("signed-previous", map[string]string{
+		string(v1alpha1.AccountLabelNatsClusterID): string(cluster.UID),
+		string(v1alpha1.AccountLabelSignedBy):      previousKey.PublicKey,
+	})
+	t.setupAccount("signed-new", map[string]string{
+		string(v1alpha1.AccountLabelNatsClusterID): string(cluster.UID),
+		string(v1alpha1.AccountLabelSignedBy):      newKey.PublicKey,
+	})
+	t.setupAccount("observed", map[string]string{
+		string(v1alpha1.AccountLabelNatsClusterID):    string(cluster.UID),
+		string(v1alpha1.AccountLabelSignedBy):         "OTHER_OPERATOR_SIGNING_KEY",
+		string(v1alpha1.AccountLabelManagementPolicy): v1alpha1.AccountManagementPolicyObserve,
+	})
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{OperatorSigningKey: newKey.Key}, nil)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(operatorKeyRotationPollInterval, result.RequeueAfter)
+	rotation := t.getNatsCluster().Status.OperatorSigningKeyRotation
+	t.Require().NotNil(rotation)
+	t.Equal(previousKey.PublicKey, rotation.PreviousSigningKey)
+	t.Equal(newKey.PublicKey, rotation.SigningKey)
+	t.Equal(v1alpha1.OperatorSigningKeyRotationResigning, rotation.Phase)
+	t.Equal(int64(1), rotation.PendingAccounts)
+	t.True(repushRequested(t.getAccount("signed-previous")))
+	t.False(repushRequested(t.getAccount("signed-new")))
+	t.False(repushRequested(t.getAccount("observed")))
+	t.Contains(<-t.fakeRecorder.Events, eventReasonOperatorKeyRotationStarted)
+
+	// Given NATS accepted the account JWT signed with the new key
+	account := t.getAccount("signed-previous")
+	account.SetLabel(v1alpha1.AccountLabelSignedBy, newKey.PublicKey)
+	t.Require().NoError(k8sClient.Update(t.ctx, account))
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{OperatorSigningKey: newKey.Key}, nil)
+
+	// When
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	status := t.getNatsCluster().Status
+	t.Equal(newKey.PublicKey, status.OperatorSigningKey)
+	t.Require().NotNil(status.OperatorSigningKeyRotation)
+	t.Equal(v1alpha1.OperatorSigningKeyRotationCompleted, status.OperatorSigningKeyRotation.Phase)
+	t.Zero(status.OperatorSigningKeyRotation.PendingAccounts)
+	t.NotNil(status.OperatorSigningKeyRotation.CompletedAt)
+	t.Equal([]string{previousKey.PublicKey}, status.RetiredOperatorSigningKeys)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonOperatorKeyRetired)
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) Test_Reconcile_ShouldNotStartRotation_WhenPreviousKeyIsStillSelectable() {
+	// Given
+	previousKey := testutil.NatsTestOperatorA.Sign
+	addedKey := testutil.CreateNatsTestOperator().Sign
+	t.setupNatsCluster(previousKey.PublicKey)
+	t.resolverMock.mockResolveClusterTarget(&nauth.ClusterTarget{
+		OperatorSigningKey: addedKey.Key,
+		OperatorSigningKeySelection: &nauth.OperatorSigningKeySelection{
+			Policy: nauth.OperatorSigningKeySelectionRoundRobin,
+			Candidates: []nauth.OperatorSigningKeyCandidate{
+				{Name: "op-sign-a", Key: addedKey.Key},
+				{Name: "op-sign-b", Key: previousKey.Key},
+			},
+		},
+	}, nil)
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	cluster := t.getNatsCluster()
+	t.Equal(addedKey.PublicKey, cluster.Status.OperatorSigningKey)
+	t.Nil(cluster.Status.OperatorSigningKeyRotation)
+	t.Empty(t.fakeRecorder.Events)
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) setupNatsCluster(signingKey string) *v1alpha1.NatsCluster {
+	cluster := &v1alpha1.NatsCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.resourceName.Name,
+			Namespace: t.resourceName.Namespace,
+		},
+		Spec: v1alpha1.NatsClusterSpec{
+			URL:                             "nats://my-cluster:4222",
+			OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+			SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds"},
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, cluster))
+	if signingKey != "" {
+		cluster.Status.OperatorSigningKey = signingKey
+		t.Require().NoError(k8sClient.Status().Update(t.ctx, cluster))
+	}
+	return cluster
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) setupAccount(name string, labels map[string]string) {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: t.resourceName.Namespace, Labels: labels},
+	}))
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) getNatsCluster() *v1alpha1.NatsCluster {
+	cluster := &v1alpha1.NatsCluster{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.resourceName, cluster))
+	return cluster
+}
+
+func (t *OperatorKeyRotationControllerTestSuite) getAccount(name string) *v1alpha1.Account {
+	account := &v1alpha1.Account{}
+	t.Require().NoError(k8sClient.Get(t.ctx, ktypes.NamespacedName{Namespace: t.resourceName.Namespace, Name: name}, account))
+	return account
+}
@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Resource kinds a RequeuePolicy can be configured for.
+const (
+	RequeueKindDefault       = "default"
+	RequeueKindAccount       = "account"
+	RequeueKindAccountExport = "accountexport"
+	RequeueKindAccountImport = "accountimport"
+	RequeueKindUser          = "user"
+	RequeueKindNatsCluster   = "natscluster"
+)
+
+var requeueKinds = []string{
+	RequeueKindDefault,
+	RequeueKindAccount,
+	RequeueKindAccountExport,
+	RequeueKindAccountImport,
+	RequeueKindUser,
+	RequeueKindNatsCluster,
+}
+
+// RequeuePolicy configures how failed reconciles of a resource kind are retried.
+type RequeuePolicy struct {
+	// BaseDelay is the delay before the first retry, doubled for every following failure.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries.
+	MaxDelay time.Duration
+	// Jitter spreads each delay randomly by up to this fraction of it, between 0 and 1.
+	Jitter float64
+	// MaxRetries is the number of failed retries tolerated before a previously synced resource is reported Degraded.
+	MaxRetries int
+}
+
+// DefaultRequeuePolicy matches the default backoff of controller-runtime and reports failures as Degraded immediately.
+func DefaultRequeuePolicy() RequeuePolicy {
+	return RequeuePolicy{
+		BaseDelay: 5 * time.Millisecond,
+		MaxDelay:  1000 * time.Second,
+	}
+}
+
+func (p RequeuePolicy) validate() error {
+	if p.BaseDelay <= 0 {
+		return fmt.Errorf("base delay must be positive")
+	}
+	if p.MaxDelay < p.BaseDelay {
+		return fmt.Errorf("max delay must not be less than base delay")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	if p.MaxRetries < 0 {
+		return fmt.Errorf("max retries must not be negative")
+	}
+	return nil
+}
+
+// newRateLimiter returns the rate limiter of the controller work queue. Like the controller-runtime default, the
+// exponential backoff per item is combined with an overall rate limit.
+func (p RequeuePolicy) newRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	return &jitterRateLimiter{
+		TypedRateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](p.BaseDelay, p.MaxDelay),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+		),
+		jitter: p.Jitter,
+	}
+}
+
+type jitterRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+	jitter float64
+}
+
+func (l *jitterRateLimiter) When(item reconcile.Request) time.Duration {
+	delay := l.TypedRateLimiter.When(item)
+	if l.jitter == 0 {
+		return delay
+	}
+	return time.Duration(float64(delay) * (1 - l.jitter + 2*l.jitter*rand.Float64()))
+}
+
+// RequeuePolicies holds the requeue policies configured per resource kind. It implements flag.Value, accepting
+// <kind>:<key>=<value>,... where keys are baseDelay, maxDelay, jitter and maxRetries. Settings of the default kind
+// apply to all kinds that do not set them.
+type RequeuePolicies struct {
+	overrides map[string]requeuePolicyOverride
+}
+
+type requeuePolicyOverride struct {
+	baseDelay  *time.Duration
+	maxDelay   *time.Duration
+	jitter     *float64
+	maxRetries *int
+}
+
+func (o requeuePolicyOverride) applyTo(policy *RequeuePolicy) {
+	if o.baseDelay != nil {
+		policy.BaseDelay = *o.baseDelay
+	}
+	if o.maxDelay != nil {
+		policy.MaxDelay = *o.maxDelay
+	}
+	if o.jitter != nil {
+		policy.Jitter = *o.jitter
+	}
+	if o.maxRetries != nil {
+		policy.MaxRetries = *o.maxRetries
+	}
+}
+
+func (p *RequeuePolicies) String() string {
+	if p == nil {
+		return ""
+	}
+	kinds := make([]string, 0, len(p.overrides))
+	for kind := range p.overrides {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	values := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		policy := p.For(kind)
+		values = append(values, fmt.Sprintf("%s:baseDelay=%s,maxDelay=%s,jitter=%g,maxRetries=%d",
+			kind, policy.BaseDelay, policy.MaxDelay, policy.Jitter, policy.MaxRetries))
+	}
+	return strings.Join(values, " ")
+}
+
+func (p *RequeuePolicies) Set(value string) error {
+	kind, settings, found := strings.Cut(value, ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !found || settings == "" {
+		return fmt.Errorf("requeue policy %q must have the format <kind>:<key>=<value>,...", value)
+	}
+	if !slices.Contains(requeueKinds, kind) {
+		return fmt.Errorf("unknown requeue policy kind %q, expected one of %s", kind, strings.Join(requeueKinds, ", "))
+	}
+
+	if p.overrides == nil {
+		p.overrides = make(map[string]requeuePolicyOverride)
+	}
+	override := p.overrides[kind]
+	for setting := range strings.SplitSeq(settings, ",") {
+		key, rawValue, found := strings.Cut(setting, "=")
+		if !found {
+			return fmt.Errorf("requeue policy setting %q must have the format <key>=<value>", setting)
+		}
+		rawValue = strings.TrimSpace(rawValue)
+		switch strings.TrimSpace(key) {
+		case "baseDelay":
+			delay, err := time.ParseDuration(rawValue)
+			if err != nil {
+				return fmt.Errorf("invalid baseDelay of requeue policy %s: %w", kind, err)
+			}
+			override.baseDelay = &delay
+		case "maxDelay":
+			delay, err := time.ParseDuration(rawValue)
+			if err != nil {
+				return fmt.Errorf("invalid maxDelay of requeue policy %s: %w", kind, err)
+			}
+			override.maxDelay = &delay
+		case "jitter":
+			jitter, err := strconv.ParseFloat(rawValue, 64)
+			if err != nil {
+				return fmt.Errorf("invalid jitter of requeue policy %s: %w", kind, err)
+			}
+			override.jitter = &jitter
+		case "maxRetries":
+			maxRetries, err := strconv.Atoi(rawValue)
+			if err != nil {
+				return fmt.Errorf("invalid maxRetries of requeue policy %s: %w", kind, err)
+			}
+			override.maxRetries = &maxRetries
+		default:
+			return fmt.Errorf("unknown requeue policy setting %q, expected baseDelay, maxDelay, jitter or maxRetries", key)
+		}
+	}
+	p.overrides[kind] = override
+	return nil
+}
+
+// For returns the effective requeue policy of kind.
+func (p *RequeuePolicies) For(kind string) RequeuePolicy {
+	policy := DefaultRequeuePolicy()
+	if p == nil {
+		return policy
+	}
+	p.overrides[RequeueKindDefault].applyTo(&policy)
+	if kind != RequeueKindDefault {
+		p.overrides[kind].applyTo(&policy)
+	}
+	return policy
+}
+
+// Validate validates the effective requeue policy of every kind.
+func (p *RequeuePolicies) Validate() error {
+	for _, kind := range requeueKinds {
+		if err := p.For(kind).validate(); err != nil {
+			return fmt.Errorf("invalid requeue policy %s: %w", kind, err)
+		}
+	}
+	return nil
+}
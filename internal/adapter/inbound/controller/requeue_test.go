@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRequeuePolicies_For(t *testing.T) {
+	testCases := []struct {
+		testName string
+		values   []string
+		kind     string
+		expected RequeuePolicy
+	}{
+		{
+			testName: "should_use_default_policy_when_not_configured",
+			kind:     RequeueKindAccount,
+			expected: DefaultRequeuePolicy(),
+		},
+		{
+			testName: "should_apply_default_kind_to_all_kinds",
+			values:   []string{"default:baseDelay=1s,maxRetries=3"},
+			kind:     RequeueKindUser,
+			expected: RequeuePolicy{BaseDelay: time.Second, MaxDelay: 1000 * time.Second, MaxRetries: 3},
+		},
+		{
+			testName: "should_override_default_kind_per_kind",
+			values:   []string{"user:maxRetries=0,jitter=0.2", "default:baseDelay=1s,maxRetries=3"},
+			kind:     RequeueKindUser,
+			expected: RequeuePolicy{BaseDelay: time.Second, MaxDelay: 1000 * time.Second, Jitter: 0.2, MaxRetries: 0},
+		},
+		{
+			testName: "should_merge_repeated_kind",
+			values:   []string{"account:baseDelay=2s", "account:maxDelay=1m"},
+			kind:     RequeueKindAccount,
+			expected: RequeuePolicy{BaseDelay: 2 * time.Second, MaxDelay: time.Minute},
+		},
+		{
+			testName: "should_not_apply_other_kinds",
+			values:   []string{"account:baseDelay=2s"},
+			kind:     RequeueKindNatsCluster,
+			expected: DefaultRequeuePolicy(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			policies := RequeuePolicies{}
+			for _, value := range tc.values {
+				require.NoError(t, policies.Set(value))
+			}
+
+			assert.Equal(t, tc.expected, policies.For(tc.kind))
+			assert.NoError(t, policies.Validate())
+		})
+	}
+}
+
+func TestRequeuePolicies_Set_ShouldFail(t *testing.T) {
+	testCases := []struct {
+		testName string
+		value    string
+		expected string
+	}{
+		{testName: "missing_kind", value: "baseDelay=1s", expected: "must have the format"},
+		{testName: "unknown_kind", value: "secret:baseDelay=1s", expected: "unknown requeue policy kind"},
+		{testName: "unknown_setting", value: "user:delay=1s", expected: "unknown requeue policy setting"},
+		{testName: "invalid_duration", value: "user:baseDelay=fast", expected: "invalid baseDelay"},
+		{testName: "invalid_jitter", value: "user:jitter=some", expected: "invalid jitter"},
+		{testName: "invalid_max_retries", value: "user:maxRetries=1.5", expected: "invalid maxRetries"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			policies := RequeuePolicies{}
+
+			assert.ErrorContains(t, policies.Set(tc.value), tc.expected)
+		})
+	}
+}
+
+func TestRequeuePolicies_Validate_ShouldFail(t *testing.T) {
+	testCases := []struct {
+		testName string
+		value    string
+		expected string
+	}{
+		{testName: "base_delay_not_positive", value: "user:baseDelay=0s", expected: "base delay must be positive"},
+		{testName: "max_delay_below_base_delay", value: "default:baseDelay=1m,maxDelay=1s", expected: "max delay must not be less than base delay"},
+		{testName: "jitter_out_of_range", value: "account:jitter=1.5", expected: "jitter must be between 0 and 1"},
+		{testName: "negative_max_retries", value: "natscluster:maxRetries=-1", expected: "max retries must not be negative"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			policies := RequeuePolicies{}
+			require.NoError(t, policies.Set(tc.value))
+
+			assert.ErrorContains(t, policies.Validate(), tc.expected)
+		})
+	}
+}
+
+func TestRequeuePolicy_RateLimiter_ShouldBackOffWithinJitter(t *testing.T) {
+	policy := RequeuePolicy{BaseDelay: time.Second, MaxDelay: 4 * time.Second, Jitter: 0.5}
+	rateLimiter := policy.newRateLimiter()
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "name"}}
+
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		delay := rateLimiter.When(item)
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected*3/2)
+	}
+	assert.Equal(t, 4, rateLimiter.NumRequeues(item))
+
+	rateLimiter.Forget(item)
+	assert.Equal(t, 0, rateLimiter.NumRequeues(item))
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ResolverConfigReconciler writes the resolver configuration of a NatsCluster with spec.resolverConfig to a ConfigMap
+// and keeps it updated as the Accounts bound to the cluster change.
+type ResolverConfigReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	manager  inbound.ResolverConfigManager
+	resolver ClusterResolver
+	reporter *statusReporter
+}
+
+func NewResolverConfigReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	manager inbound.ResolverConfigManager,
+	resolver ClusterResolver,
+	recorder events.EventRecorder,
+) *ResolverConfigReconciler {
+	return &ResolverConfigReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme,
+		manager:  manager,
+		resolver: resolver,
+		reporter: newStatusReporter(k8sClient, recorder),
+	}
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=natsclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=accounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *ResolverConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	natsCluster := &v1alpha1.NatsCluster{}
+	if err := r.Get(ctx, req.NamespacedName, natsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+	spec := natsCluster.Spec.ResolverConfig
+	if spec == nil || !natsCluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	config, err := r.renderResolverConfig(ctx, natsCluster, spec)
+	if err != nil {
+		r.reporter.warning(natsCluster, eventReasonResolverConfigFailed, actionReconciled, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMap.Name = spec.ConfigMapName
+	configMap.Namespace = natsCluster.Namespace
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{v1alpha1.ResolverConfigKey: config}
+		return controllerutil.SetControllerReference(natsCluster, configMap, r.Scheme)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to write resolver config to ConfigMap %s: %w", spec.ConfigMapName, err)
+		r.reporter.warning(natsCluster, eventReasonResolverConfigFailed, actionReconciled, err.Error())
+		return ctrl.Result{}, err
+	}
+	if op != controllerutil.OperationResultNone {
+		r.reporter.event(natsCluster, eventReasonResolverConfigUpdated, actionReconciled,
+			"Wrote resolver config to ConfigMap %s", spec.ConfigMapName)
+	}
+
+	// Account JWTs may also change in NATS without a change of the Account resources
+	return ctrl.Result{
+		RequeueAfter: time.Duration(float64(5*time.Minute) * (0.9 + 0.2*rand.Float64())),
+	}, nil
+}
+
+func (r *ResolverConfigReconciler) renderResolverConfig(ctx context.Context, natsCluster *v1alpha1.NatsCluster, spec *v1alpha1.ResolverConfigSpec) (string, error) {
+	clusterTarget, err := r.resolver.ResolveClusterTarget(ctx, natsCluster)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve NatsCluster target: %w", err)
+	}
+
+	operatorJWT, err := r.getOperatorJWT(ctx, natsCluster.Namespace, spec.OperatorJWTConfigMapRef)
+	if err != nil {
+		return "", err
+	}
+
+	accounts := &v1alpha1.AccountList{}
+	if err := r.List(ctx, accounts, client.MatchingLabels{string(v1alpha1.AccountLabelNatsClusterID): string(natsCluster.UID)}); err != nil {
+		return "", fmt.Errorf("failed to list accounts bound to NatsCluster: %w", err)
+	}
+	accountIDs := make([]nauth.AccountID, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		if accountID := account.GetLabel(v1alpha1.AccountLabelAccountID); accountID != "" {
+			accountIDs = append(accountIDs, nauth.AccountID(accountID))
+		}
+	}
+
+	config, err := r.manager.Render(ctx, nauth.ResolverConfigRequest{
+		ClusterTarget: *clusterTarget,
+		OperatorJWT:   operatorJWT,
+		AccountIDs:    accountIDs,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render resolver config: %w", err)
+	}
+	return config, nil
+}
+
+func (r *ResolverConfigReconciler) getOperatorJWT(ctx context.Context, namespace string, ref v1alpha1.ConfigMapKeyReference) (string, error) {
+	key := ref.Key
+	if key == "" {
+		key = v1alpha1.DefaultOperatorJWTKey
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+		return "", fmt.Errorf("failed to get operator JWT ConfigMap %s: %w", ref.Name, err)
+	}
+	operatorJWT, ok := configMap.Data[key]
+	if !ok {
+		return "", fmt.Errorf("operator JWT ConfigMap %s has no key %q", ref.Name, key)
+	}
+	return operatorJWT, nil
+}
+
+// mapAccountToResolverConfigs reconciles the NatsCluster an Account is bound to.
+func (r *ResolverConfigReconciler) mapAccountToResolverConfigs(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterID := obj.GetLabels()[string(v1alpha1.AccountLabelNatsClusterID)]
+	if clusterID == "" {
+		return nil
+	}
+	clusters := &v1alpha1.NatsClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list NatsClusters for Account watch", "clusterID", clusterID)
+		return nil
+	}
+	for _, cluster := range clusters.Items {
+		if string(cluster.UID) == clusterID && cluster.Spec.ResolverConfig != nil {
+			return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(&cluster)}}
+		}
+	}
+	return nil
+}
+
+func (r *ResolverConfigReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.NatsCluster{}).
+		Owns(&corev1.ConfigMap{}).
+		Named("resolverconfig").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             requeuePolicy.newRateLimiter(),
+		}).
+		Watches(
+			&v1alpha1.Account{},
+			handler.EnqueueRequestsFromMapFunc(r.mapAccountToResolverConfigs),
+		).
+		Complete(traced("resolverconfig", r))
+}
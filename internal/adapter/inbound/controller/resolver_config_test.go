@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type ResolverConfigControllerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	managerMock  *resolverConfigManagerMock
+	resolverMock *ClusterResolverMock
+	fakeRecorder *events.FakeRecorder
+
+	resourceName ktypes.NamespacedName
+
+	unitUnderTest *ResolverConfigReconciler
+}
+
+func TestResolverConfigController_TestSuite(t *testing.T) {
+	suite.Run(t, new(ResolverConfigControllerTestSuite))
+}
+
+func (t *ResolverConfigControllerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+
+	testName := t.T().Name()
+	t.resourceName = ktypes.NamespacedName{
+		Name:      testutil.ScopedTestName("test-nats-cluster", testName),
+		Namespace: testutil.ScopedTestName("resolverconfig", testName),
+	}
+
+	t.managerMock = &resolverConfigManagerMock{}
+	t.resolverMock = &ClusterResolverMock{}
+	t.fakeRecorder = events.NewFakeRecorder(5)
+	t.unitUnderTest = NewResolverConfigReconciler(
+		k8sClient,
+		k8sClient.Scheme(),
+		t.managerMock,
+		t.resolverMock,
+		t.fakeRecorder,
+	)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.resourceName.Namespace))
+}
+
+func (t *ResolverConfigControllerTestSuite) TearDownTest() {
+	t.managerMock.AssertExpectations(t.T())
+	t.resolverMock.AssertExpectations(t.T())
+}
+
+func (t *ResolverConfigControllerTestSuite) Test_Reconcile_ShouldWriteResolverConfig() {
+	// Given
+	cluster := t.setupNatsCluster(&v1alpha1.ResolverConfigSpec{
+		ConfigMapName:           "nats-resolver",
+		OperatorJWTConfigMapRef: v1alpha1.ConfigMapKeyReference{Name: "nats-operator"},
+	})
+	t.setupConfigMap("nats-operator", map[string]string{v1alpha1.DefaultOperatorJWTKey: "OPERATOR-JWT"})
+	t.setupAccount("bound", map[string]string{
+		string(v1alpha1.AccountLabelNatsClusterID): string(cluster.UID),
+		string(v1alpha1.AccountLabelAccountID):     "ACCOUNT-ID",
+	})
+	t.setupAccount("other-cluster", map[string]string{
+		string(v1alpha1.AccountLabelNatsClusterID): "other-uid",
+		string(v1alpha1.AccountLabelAccountID):     "OTHER-ACCOUNT-ID",
+	})
+
+	target := nauth.ClusterTarget{NatsURL: fmt.Sprintf("nats://%s.my-cluster:4222", testutil.ShortHash(t.T().Name()))}
+	t.resolverMock.mockResolveClusterTarget(&target, nil)
+	t.managerMock.mockRender(nauth.ResolverConfigRequest{
+		ClusterTarget: target,
+		OperatorJWT:   "OPERATOR-JWT",
+		AccountIDs:    []nauth.AccountID{"ACCOUNT-ID"},
+	}, "resolver: MEMORY\n", nil)
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	configMap := &corev1.ConfigMap{}
+	t.Require().NoError(k8sClient.Get(t.ctx, ktypes.NamespacedName{Namespace: t.resourceName.Namespace, Name: "nats-resolver"}, configMap))
+	t.Equal(map[string]string{v1alpha1.ResolverConfigKey: "resolver: MEMORY\n"}, configMap.Data)
+	t.Require().Len(configMap.OwnerReferences, 1)
+	t.Equal(cluster.UID, configMap.OwnerReferences[0].UID)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonResolverConfigUpdated)
+}
+
+func (t *ResolverConfigControllerTestSuite) Test_Reconcile_ShouldFail_WhenOperatorJWTNotFound() {
+	// Given
+	t.setupNatsCluster(&v1alpha1.ResolverConfigSpec{
+		ConfigMapName:           "nats-resolver",
+		OperatorJWTConfigMapRef: v1alpha1.ConfigMapKeyReference{Name: "nats-operator", Key: "jwt"},
+	})
+	t.setupConfigMap("nats-operator", map[string]string{v1alpha1.DefaultOperatorJWTKey: "OPERATOR-JWT"})
+	target := nauth.ClusterTarget{}
+	t.resolverMock.mockResolveClusterTarget(&target, nil)
+
+	// When
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().ErrorContains(err, `operator JWT ConfigMap nats-operator has no key "jwt"`)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonResolverConfigFailed)
+}
+
+func (t *ResolverConfigControllerTestSuite) Test_Reconcile_ShouldIgnore_WhenResolverConfigNotSet() {
+	// Given
+	t.setupNatsCluster(nil)
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.resourceName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Zero(result)
+	t.Empty(t.fakeRecorder.Events)
+}
+
+func (t *ResolverConfigControllerTestSuite) setupNatsCluster(resolverConfig *v1alpha1.ResolverConfigSpec) *v1alpha1.NatsCluster {
+	cluster := &v1alpha1.NatsCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.resourceName.Name,
+			Namespace: t.resourceName.Namespace,
+		},
+		Spec: v1alpha1.NatsClusterSpec{
+			URL:                             "nats://my-cluster:4222",
+			OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+			SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds"},
+			ResolverConfig:                  resolverConfig,
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, cluster))
+	return cluster
+}
+
+func (t *ResolverConfigControllerTestSuite) setupConfigMap(name string, data map[string]string) {
+	t.Require().NoError(k8sClient.Create(t.ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: t.resourceName.Namespace},
+		Data:       data,
+	}))
+}
+
+func (t *ResolverConfigControllerTestSuite) setupAccount(name string, labels map[string]string) {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: t.resourceName.Namespace, Labels: labels},
+	}))
+}
+
+type resolverConfigManagerMock struct {
+	mock.Mock
+}
+
+func (m *resolverConfigManagerMock) Render(ctx context.Context, request nauth.ResolverConfigRequest) (string, error) {
+	args := m.Called(ctx, request)
+	return args.String(0), args.Error(1)
+}
+
+func (m *resolverConfigManagerMock) mockRender(request nauth.ResolverConfigRequest, result string, err error) {
+	m.On("Render", mock.Anything, request).Return(result, err).Once()
+}
+
+var _ inbound.ResolverConfigManager = (*resolverConfigManagerMock)(nil)
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Shard is the part of the Accounts and Users reconciled by this nauth instance, so that very large fleets can be
+// split across multiple nauth deployments. A nil Shard contains all Accounts and Users.
+//
+// Instances with a name claim the resources they reconcile with the v1alpha1.AnnotationShard annotation, and leave
+// resources claimed by another instance alone, so that overlapping shards do not fight over the same resources.
+type Shard struct {
+	name       string
+	namespaces []string
+	selector   labels.Selector
+}
+
+// NewShard creates the shard of the instance name. namespaces is a comma separated list of the namespaces of the
+// shard and selector a label selector of its resources, both are unrestricted when empty. Returns nil when neither
+// restricts the shard and the instance has no name.
+func NewShard(name string, namespaces string, selector string) (*Shard, error) {
+	shard := &Shard{
+		name:     strings.TrimSpace(name),
+		selector: labels.Everything(),
+	}
+	for _, namespace := range strings.Split(namespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace != "" && !slices.Contains(shard.namespaces, namespace) {
+			shard.namespaces = append(shard.namespaces, namespace)
+		}
+	}
+	if selector = strings.TrimSpace(selector); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shard label selector %q: %w", selector, err)
+		}
+		shard.selector = parsed
+	}
+	if shard.name == "" && len(shard.namespaces) == 0 && shard.selector.Empty() {
+		return nil, nil
+	}
+	return shard, nil
+}
+
+// Name returns the name of the instance owning the shard.
+func (s *Shard) Name() string {
+	if s == nil {
+		return ""
+	}
+	return s.name
+}
+
+// Namespaces returns the namespaces of the shard, empty when the shard spans all namespaces.
+func (s *Shard) Namespaces() []string {
+	if s == nil {
+		return nil
+	}
+	return slices.Clone(s.namespaces)
+}
+
+// Contains reports whether obj is in the namespaces of the shard and matches its label selector.
+func (s *Shard) Contains(obj client.Object) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.namespaces) > 0 && !slices.Contains(s.namespaces, obj.GetNamespace()) {
+		return false
+	}
+	return s.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+func (s *Shard) predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(s.Contains)
+}
+
+// claim returns whether this instance owns obj, claiming it when no instance does. The name of the owning instance is
+// returned when it is another instance.
+func (s *Shard) claim(ctx context.Context, k8sClient client.Writer, obj client.Object) (bool, string, error) {
+	if !s.Contains(obj) {
+		return false, "", nil
+	}
+	if s.Name() == "" {
+		return true, "", nil
+	}
+	owner := obj.GetAnnotations()[v1alpha1.AnnotationShard]
+	if owner == s.name {
+		return true, "", nil
+	}
+	if owner != "" {
+		return false, owner, nil
+	}
+
+	patchData, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations":     map[string]string{v1alpha1.AnnotationShard: s.name},
+			"resourceVersion": obj.GetResourceVersion(),
+		},
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to generate shard annotation patch: %w", err)
+	}
+	// The resource version makes concurrent claims by overlapping shards conflict, so only one of them succeeds
+	if err := k8sClient.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patchData)); err != nil {
+		return false, "", fmt.Errorf("failed to claim resource for shard %s: %w", s.name, err)
+	}
+	return true, "", nil
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewShard_ShouldReturnNil_WhenUnrestricted(t *testing.T) {
+	shard, err := NewShard(" ", " , ", "")
+
+	require.NoError(t, err)
+	assert.Nil(t, shard)
+	assert.True(t, shard.Contains(shardTestAccount("team-a", nil, nil)))
+}
+
+func TestNewShard_ShouldFail_WhenLabelSelectorInvalid(t *testing.T) {
+	_, err := NewShard("", "", "shard in (a")
+
+	assert.ErrorContains(t, err, "invalid shard label selector")
+}
+
+func TestShard_Contains(t *testing.T) {
+	testCases := []struct {
+		testName   string
+		namespaces string
+		selector   string
+		account    *v1alpha1.Account
+		expected   bool
+	}{
+		{
+			testName:   "should_contain_resource_in_watched_namespace",
+			namespaces: "team-a,team-b",
+			account:    shardTestAccount("team-b", nil, nil),
+			expected:   true,
+		},
+		{
+			testName:   "should_not_contain_resource_in_other_namespace",
+			namespaces: "team-a,team-b",
+			account:    shardTestAccount("team-c", nil, nil),
+			expected:   false,
+		},
+		{
+			testName: "should_contain_resource_matching_label_selector",
+			selector: "shard=blue",
+			account:  shardTestAccount("team-a", map[string]string{"shard": "blue"}, nil),
+			expected: true,
+		},
+		{
+			testName: "should_not_contain_resource_not_matching_label_selector",
+			selector: "shard=blue",
+			account:  shardTestAccount("team-a", map[string]string{"shard": "green"}, nil),
+			expected: false,
+		},
+		{
+			testName:   "should_require_both_namespace_and_label_selector",
+			namespaces: "team-a",
+			selector:   "shard=blue",
+			account:    shardTestAccount("team-b", map[string]string{"shard": "blue"}, nil),
+			expected:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			shard, err := NewShard("", tc.namespaces, tc.selector)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, shard.Contains(tc.account))
+		})
+	}
+}
+
+func TestShard_claim(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		annotations   map[string]string
+		expectedOwned bool
+		expectedOwner string
+	}{
+		{
+			testName:      "should_claim_unowned_resource",
+			expectedOwned: true,
+		},
+		{
+			testName:      "should_own_resource_claimed_by_shard",
+			annotations:   map[string]string{v1alpha1.AnnotationShard: "blue"},
+			expectedOwned: true,
+		},
+		{
+			testName:      "should_not_own_resource_claimed_by_other_shard",
+			annotations:   map[string]string{v1alpha1.AnnotationShard: "green"},
+			expectedOwned: false,
+			expectedOwner: "green",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctx := context.Background()
+			account := shardTestAccount("team-a", nil, tc.annotations)
+			fakeClient := newFakeClientBuilder(t, account).Build()
+			shard, err := NewShard("blue", "", "")
+			require.NoError(t, err)
+
+			owned, owner, err := shard.claim(ctx, fakeClient, account)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOwned, owned)
+			assert.Equal(t, tc.expectedOwner, owner)
+			stored := &v1alpha1.Account{}
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(account), stored))
+			if tc.expectedOwner == "" {
+				assert.Equal(t, "blue", stored.Annotations[v1alpha1.AnnotationShard])
+			} else {
+				assert.Equal(t, tc.expectedOwner, stored.Annotations[v1alpha1.AnnotationShard])
+			}
+		})
+	}
+}
+
+func TestShard_claim_ShouldFail_WhenClaimedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	account := shardTestAccount("team-a", nil, nil)
+	fakeClient := newFakeClientBuilder(t, account).Build()
+	stale := account.DeepCopy()
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(account), stale))
+	green, err := NewShard("green", "", "")
+	require.NoError(t, err)
+	blue, err := NewShard("blue", "", "")
+	require.NoError(t, err)
+	owned, _, err := green.claim(ctx, fakeClient, stale.DeepCopy())
+	require.NoError(t, err)
+	require.True(t, owned)
+
+	owned, _, err = blue.claim(ctx, fakeClient, stale)
+
+	assert.ErrorContains(t, err, "failed to claim resource for shard blue")
+	assert.False(t, owned)
+}
+
+func shardTestAccount(namespace string, labels map[string]string, annotations map[string]string) *v1alpha1.Account {
+	return &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "account",
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// StartupReporter reports the state of all Accounts and Users once, when the manager starts and the instance is
+// elected leader. The report is logged and, when a writer is given, written for tooling to pick up.
+type StartupReporter struct {
+	client         client.Reader
+	clusterManager inbound.ClusterManager
+	syncChecker    inbound.AccountSyncChecker
+	writer         outbound.StartupReportWriter
+	now            func() time.Time
+}
+
+func NewStartupReporter(client client.Reader, clusterManager inbound.ClusterManager, syncChecker inbound.AccountSyncChecker, writer outbound.StartupReportWriter) *StartupReporter {
+	return &StartupReporter{
+		client:         client,
+		clusterManager: clusterManager,
+		syncChecker:    syncChecker,
+		writer:         writer,
+		now:            time.Now,
+	}
+}
+
+// Start reports the state. Failing to report is logged but never stops the manager.
+func (r *StartupReporter) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("startup-report")
+	report, err := r.Report(ctx)
+	if err != nil {
+		log.Error(err, "Failed to report startup state")
+		return nil
+	}
+	log.Info("Startup state",
+		"accounts", report.Accounts.Total,
+		"accountsInSync", report.Accounts.InSync,
+		"accountsMissingSecrets", report.Accounts.MissingSecrets,
+		"accountsDrifted", report.Accounts.Drifted,
+		"accountsUnchecked", report.Accounts.Unchecked,
+		"users", report.Users.Total,
+		"usersInSync", report.Users.InSync,
+		"usersMissingSecrets", report.Users.MissingSecrets)
+	if r.writer != nil {
+		if err := r.writer.WriteStartupReport(ctx, *report); err != nil {
+			log.Error(err, "Failed to write startup report")
+		}
+	}
+	return nil
+}
+
+// Report compares every Account with NATS and checks the credentials Secret of every User.
+func (r *StartupReporter) Report(ctx context.Context) (*nauth.StartupReport, error) {
+	report := &nauth.StartupReport{GeneratedAt: r.now().UTC()}
+
+	accounts := &v1alpha1.AccountList{}
+	if err := r.client.List(ctx, accounts); err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	for i := range accounts.Items {
+		account := &accounts.Items[i]
+		report.Accounts.Total++
+		state, err := r.checkAccount(ctx, account)
+		if err != nil {
+			logf.FromContext(ctx).V(1).Info("Failed to check account", "account", client.ObjectKeyFromObject(account), "error", err.Error())
+			report.Accounts.Unchecked++
+			continue
+		}
+		switch {
+		case state == nauth.AccountSyncStateMissingSecrets:
+			report.Accounts.MissingSecrets++
+		case state == nauth.AccountSyncStateDrifted:
+			report.Accounts.Drifted++
+		case readyForGeneration(account):
+			report.Accounts.InSync++
+		}
+	}
+
+	users := &v1alpha1.UserList{}
+	if err := r.client.List(ctx, users); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	for i := range users.Items {
+		user := &users.Items[i]
+		report.Users.Total++
+		missing, err := r.userSecretMissing(ctx, user)
+		if err != nil {
+			logf.FromContext(ctx).V(1).Info("Failed to check user", "user", client.ObjectKeyFromObject(user), "error", err.Error())
+			report.Users.Unchecked++
+			continue
+		}
+		switch {
+		case missing:
+			report.Users.MissingSecrets++
+		case readyForGeneration(user):
+			report.Users.InSync++
+		}
+	}
+	return report, nil
+}
+
+func (r *StartupReporter) checkAccount(ctx context.Context, account *v1alpha1.Account) (nauth.AccountSyncState, error) {
+	if account.GetLabel(v1alpha1.AccountLabelAccountID) == "" {
+		return "", fmt.Errorf("account has not been created yet")
+	}
+	clusterRef, err := toNAuthClusterRef(account.Spec.NatsClusterRef, account.Namespace)
+	if err != nil {
+		return "", err
+	}
+	clusterTarget, err := r.clusterManager.GetClusterTarget(ctx, clusterRef)
+	if err != nil {
+		return "", err
+	}
+	return r.syncChecker.CheckSync(ctx, toAccountReference(account, *clusterTarget), account.Status.ClaimsHash)
+}
+
+// userSecretMissing reports whether the credentials Secret of user is gone. Users with short-lived credentials are
+// never missing theirs, as NAuth deletes the Secret once the TTL has passed.
+func (r *StartupReporter) userSecretMissing(ctx context.Context, user *v1alpha1.User) (bool, error) {
+	if user.Spec.CredentialsTTL != nil {
+		return false, nil
+	}
+	secret := &v1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: user.Namespace, Name: user.GetUserSecretName()}, secret)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+func readyForGeneration(obj conditions.Object) bool {
+	ready := meta.FindStatusCondition(*obj.GetConditions(), conditions.TypeReady)
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == obj.GetGeneration()
+}
+
+func (r *StartupReporter) NeedLeaderElection() bool {
+	return true
+}
+
+var _ manager.Runnable = (*StartupReporter)(nil)
+var _ manager.LeaderElectionRunnable = (*StartupReporter)(nil)
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type accountSyncCheckerStub struct {
+	states map[string]nauth.AccountSyncState
+}
+
+func (s *accountSyncCheckerStub) CheckSync(_ context.Context, reference nauth.AccountReference, _ string) (nauth.AccountSyncState, error) {
+	state, found := s.states[reference.AccountRef.Name]
+	if !found {
+		return "", errors.New("cluster unreachable")
+	}
+	return state, nil
+}
+
+type startupReportWriterStub struct {
+	written *nauth.StartupReport
+}
+
+func (s *startupReportWriterStub) WriteStartupReport(_ context.Context, report nauth.StartupReport) error {
+	s.written = &report
+	return nil
+}
+
+func TestStartupReporter_Start(t *testing.T) {
+	// Given
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	k8sClient := newFakeClientBuilder(t,
+		startupReportTestAccount("in-sync", "AINSYNC", true),
+		startupReportTestAccount("not-ready", "ANOTREADY", false),
+		startupReportTestAccount("missing-secrets", "AMISSING", true),
+		startupReportTestAccount("drifted", "ADRIFTED", true),
+		startupReportTestAccount("unreachable", "AUNREACHABLE", true),
+		startupReportTestAccount("not-created", "", false),
+		startupReportTestUser("with-secret", true, nil),
+		startupReportTestUser("without-secret", true, nil),
+		startupReportTestUser("short-lived", true, &metav1.Duration{Duration: time.Hour}),
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "with-secret-nats-user-creds"}},
+	).Build()
+	clusterManager := &clusterManagerMock{}
+	clusterManager.On("GetClusterTarget", mock.Anything, mock.Anything).Return(createDummyClusterTarget(), nil)
+	syncChecker := &accountSyncCheckerStub{states: map[string]nauth.AccountSyncState{
+		"in-sync":         nauth.AccountSyncStateInSync,
+		"not-ready":       nauth.AccountSyncStateInSync,
+		"missing-secrets": nauth.AccountSyncStateMissingSecrets,
+		"drifted":         nauth.AccountSyncStateDrifted,
+	}}
+	writer := &startupReportWriterStub{}
+	unitUnderTest := NewStartupReporter(k8sClient, clusterManager, syncChecker, writer)
+	unitUnderTest.now = func() time.Time { return now }
+
+	// When
+	err := unitUnderTest.Start(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, writer.written)
+	assert.Equal(t, nauth.StartupReport{
+		GeneratedAt: now,
+		Accounts:    nauth.StartupReportCounts{Total: 6, InSync: 1, MissingSecrets: 1, Drifted: 1, Unchecked: 2},
+		Users:       nauth.StartupReportCounts{Total: 3, InSync: 2, MissingSecrets: 1},
+	}, *writer.written)
+}
+
+func startupReportTestAccount(name string, accountID string, ready bool) *v1alpha1.Account {
+	account := &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name, Generation: 1},
+	}
+	if accountID != "" {
+		account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+	}
+	if ready {
+		account.Status.Conditions = []metav1.Condition{{Type: conditionTypeReady, Status: metav1.ConditionTrue, ObservedGeneration: 1}}
+	}
+	return account
+}
+
+func startupReportTestUser(name string, ready bool, credentialsTTL *metav1.Duration) *v1alpha1.User {
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name, Generation: 1},
+		Spec:       v1alpha1.UserSpec{AccountName: "in-sync", CredentialsTTL: credentialsTTL},
+	}
+	if ready {
+		user.Status.Conditions = []metav1.Condition{{Type: conditionTypeReady, Status: metav1.ConditionTrue, ObservedGeneration: 1}}
+	}
+	return user
+}
@@ -6,13 +6,16 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
 )
 
 type Object interface {
@@ -24,6 +27,14 @@ type Object interface {
 type statusReporter struct {
 	client   client.StatusClient
 	Recorder events.EventRecorder
+
+	failures   failureCounter
+	maxRetries int
+}
+
+// failureCounter counts the failed reconciles of a request since it last succeeded.
+type failureCounter interface {
+	NumRequeues(item reconcile.Request) int
 }
 
 func newStatusReporter(k8sClient client.StatusClient, recorder events.EventRecorder) *statusReporter {
@@ -36,40 +47,78 @@ func newStatusReporter(k8sClient client.StatusClient, recorder events.EventRecor
 func (s *statusReporter) status(ctx context.Context, object Object) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	meta.SetStatusCondition(object.GetConditions(), metav1.Condition{
-		Type:    conditionTypeReady,
-		Status:  metav1.ConditionTrue,
-		Reason:  conditionReasonReconciled,
-		Message: "Successfully reconciled",
-	})
+	conditions.MarkReconciled(object, conditionMessageReconciled)
 
 	if err := s.client.Status().Update(ctx, object); err != nil {
 		log.Info("Failed to update reconciled condition", "name", object.GetGenerateName(), "updateError", err)
 		return ctrl.Result{}, err
 	}
 
-	// Spreading out the requeue to avoid all being queued at the same time
-	return ctrl.Result{
-		RequeueAfter: time.Duration(float64(5*time.Minute) * (0.9 + 0.2*rand.Float64())),
-	}, nil
+	return ctrl.Result{RequeueAfter: resyncInterval()}, nil
+}
+
+// resyncInterval returns the delay before a resource is reconciled again without changes, spread out to avoid all
+// being queued at the same time.
+func resyncInterval() time.Duration {
+	return time.Duration(float64(5*time.Minute) * (0.9 + 0.2*rand.Float64()))
+}
+
+// paused reports object as paused. It is not requeued, since unpausing it changes its spec.
+func (s *statusReporter) paused(ctx context.Context, object Object) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	conditions.MarkPaused(object, conditionMessagePaused)
+
+	if err := s.client.Status().Update(ctx, object); err != nil {
+		log.Info("Failed to update paused condition", "name", object.GetName(), "updateError", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// tolerateFailures keeps resources from being reported Degraded until maxRetries retries counted by failures have
+// failed.
+func (s *statusReporter) tolerateFailures(failures failureCounter, maxRetries int) {
+	s.failures = failures
+	s.maxRetries = maxRetries
+}
+
+// event records a Normal event for a milestone of regarding.
+func (s *statusReporter) event(regarding Object, reason string, action string, note string, args ...interface{}) {
+	s.Recorder.Eventf(regarding, nil, v1.EventTypeNormal, reason, action, note, args...)
+}
+
+// warning records a Warning event for a condition of regarding that needs attention but is not a failure.
+func (s *statusReporter) warning(regarding Object, reason string, action string, note string, args ...interface{}) {
+	s.Recorder.Eventf(regarding, nil, v1.EventTypeWarning, reason, action, note, args...)
 }
 
 func (s *statusReporter) error(ctx context.Context, regarding Object, err error) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	s.Recorder.Eventf(regarding, nil, v1.EventTypeWarning, conditionReasonErrored, actionReconciled, err.Error())
+	reason := conditions.ReasonForError(err)
 
-	meta.SetStatusCondition(regarding.GetConditions(), metav1.Condition{
-		Type:    conditionTypeReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  conditionReasonErrored,
-		Message: err.Error(),
-	})
+	s.Recorder.Eventf(regarding, nil, v1.EventTypeWarning, reason, actionReconciled, err.Error())
+
+	conditions.ClearPaused(regarding)
+	transient := domain.IsTransient(err)
+	if transient && s.failures != nil && s.failures.NumRequeues(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(regarding)}) < s.maxRetries {
+		conditions.MarkRetrying(regarding, reason, err.Error())
+	} else {
+		conditions.MarkFailed(regarding, reason, err.Error())
+	}
 
 	if updateErr := s.client.Status().Update(ctx, regarding); updateErr != nil {
 		log.Info("Failed to update error condition", "name", regarding.GetGenerateName(), "updateError", updateErr, "originalError", err)
 		return ctrl.Result{}, updateErr
 	}
 
+	if !transient {
+		// Retrying cannot succeed before the resource or its dependencies change. Watched changes reconcile it right
+		// away, the resync picks up the others.
+		log.Error(err, "Reconcile failed permanently, retrying at the next resync", "reason", reason,
+			"class", domain.ClassOf(err))
+		return ctrl.Result{RequeueAfter: resyncInterval()}, nil
+	}
 	return ctrl.Result{}, err
 }
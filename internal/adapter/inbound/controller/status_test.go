@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+func TestStatusReporter_error(t *testing.T) {
+	testCases := []struct {
+		name             string
+		err              error
+		expectRetry      bool
+		expectSyncedFail bool
+	}{
+		{
+			name:        "transient error is retried before it counts",
+			err:         domain.ErrClusterUnreachable.WithCause(errors.New("timeout")),
+			expectRetry: true,
+		},
+		{
+			name:             "invalid config is not retried",
+			err:              domain.ErrInvalidConfig.WithCause(errors.New("malformed seed")),
+			expectSyncedFail: true,
+		},
+		{
+			name:             "conflict is not retried",
+			err:              fmt.Errorf("failed to apply secrets: %w", domain.ErrSecretNameCollision),
+			expectSyncedFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "team"}}
+			conditions.MarkReconciled(user, conditionMessageReconciled)
+			k8sClient := newFakeClientBuilder(t, user).WithStatusSubresource(user).Build()
+			reporter := newStatusReporter(k8sClient, events.NewFakeRecorder(5))
+			reporter.tolerateFailures(fixedFailureCounter(0), 3)
+
+			// When
+			result, err := reporter.error(context.Background(), user, tc.err)
+
+			// Then
+			if tc.expectRetry {
+				require.ErrorIs(t, err, tc.err)
+				require.Zero(t, result.RequeueAfter)
+			} else {
+				require.NoError(t, err)
+				require.Positive(t, result.RequeueAfter)
+			}
+			require.Equal(t, tc.expectSyncedFail, meta.IsStatusConditionFalse(user.Status.Conditions, conditions.TypeSynced))
+			require.Equal(t, tc.expectSyncedFail, meta.IsStatusConditionTrue(user.Status.Conditions, conditions.TypeDegraded))
+		})
+	}
+}
@@ -23,8 +23,10 @@ import (
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
 	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -92,3 +94,14 @@ func ensureNamespace(ctx context.Context, namespace string) error {
 	}
 	return nil
 }
+
+// assertCondition asserts the status and reason of the condition of conditionType.
+func assertCondition(t *testing.T, conditions []metav1.Condition, conditionType string, status metav1.ConditionStatus, reason string) {
+	t.Helper()
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	if !assert.NotNil(t, condition, "condition %s not found", conditionType) {
+		return
+	}
+	assert.Equal(t, status, condition.Status, "status of condition %s", conditionType)
+	assert.Equal(t, reason, condition.Reason, "reason of condition %s", conditionType)
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const tracerName = "github.com/WirelessCar/nauth/internal/adapter/inbound/controller"
+
+// tracingReconciler runs every reconcile of a controller in a span, the root of the spans of the Kubernetes API, NATS
+// and claims operations of the reconcile. Spans are dropped unless a tracer provider is set up, see cmd/main.go.
+type tracingReconciler struct {
+	controllerName string
+	reconciler     reconcile.Reconciler
+	tracer         trace.Tracer
+}
+
+func traced(controllerName string, reconciler reconcile.Reconciler) reconcile.Reconciler {
+	return &tracingReconciler{
+		controllerName: controllerName,
+		reconciler:     reconciler,
+		tracer:         otel.Tracer(tracerName),
+	}
+}
+
+func (r *tracingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := r.tracer.Start(ctx, "reconcile "+r.controllerName, trace.WithAttributes(
+		attribute.String("nauth.controller", r.controllerName),
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("nauth.resource.name", req.Name),
+	))
+	defer span.End()
+
+	result, err := r.reconciler.Reconcile(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if result.RequeueAfter > 0 {
+		span.SetAttributes(attribute.String("nauth.requeue_after", result.RequeueAfter.String()))
+	}
+	return result, err
+}
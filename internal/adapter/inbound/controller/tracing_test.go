@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestTracingReconciler_ShouldRunReconcileInSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	var reconcileSpan trace.SpanContext
+	unitUnderTest := &tracingReconciler{
+		controllerName: "account",
+		reconciler: reconcile.Func(func(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+			reconcileSpan = trace.SpanContextFromContext(ctx)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}),
+		tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName),
+	}
+
+	result, err := unitUnderTest.Reconcile(context.Background(),
+		ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "orders"}})
+
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, result.RequeueAfter)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "reconcile account", spans[0].Name())
+	require.Equal(t, spans[0].SpanContext(), reconcileSpan)
+	require.Subset(t, spans[0].Attributes(), []attribute.KeyValue{
+		attribute.String("nauth.controller", "account"),
+		attribute.String("k8s.namespace.name", "team-a"),
+		attribute.String("nauth.resource.name", "orders"),
+		attribute.String("nauth.requeue_after", "1m0s"),
+	})
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestTracingReconciler_ShouldMarkSpanFailed_WhenReconcileFails(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	unitUnderTest := &tracingReconciler{
+		controllerName: "user",
+		reconciler: reconcile.Func(func(context.Context, ctrl.Request) (ctrl.Result, error) {
+			return ctrl.Result{}, errors.New("account not ready")
+		}),
+		tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName),
+	}
+
+	_, err := unitUnderTest.Reconcile(context.Background(), ctrl.Request{})
+
+	require.EqualError(t, err, "account not ready")
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "account not ready", spans[0].Status().Description)
+}
@@ -20,15 +20,24 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,23 +50,37 @@ import (
 // UserReconciler reconciles a User object
 type UserReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	manager  inbound.UserManager
-	reporter *statusReporter
+	Scheme         *runtime.Scheme
+	manager        inbound.UserManager
+	grantReader    k8s.ReferenceGrantReader
+	defaultsReader outbound.NauthDefaultsReader
+	reporter       *statusReporter
+	shard          *Shard
 }
 
-func NewUserReconciler(k8sClient client.Client, scheme *runtime.Scheme, manager inbound.UserManager, recorder events.EventRecorder) *UserReconciler {
+func NewUserReconciler(k8sClient client.Client, scheme *runtime.Scheme, manager inbound.UserManager,
+	grantReader k8s.ReferenceGrantReader, defaultsReader outbound.NauthDefaultsReader, recorder events.EventRecorder,
+	shard *Shard) *UserReconciler {
 	return &UserReconciler{
-		Client:   k8sClient,
-		Scheme:   scheme,
-		manager:  manager,
-		reporter: newStatusReporter(k8sClient, recorder),
+		Client:         k8sClient,
+		Scheme:         scheme,
+		manager:        manager,
+		grantReader:    grantReader,
+		defaultsReader: defaultsReader,
+		reporter:       newStatusReporter(k8sClient, recorder),
+		shard:          shard,
 	}
 }
 
 // +kubebuilder:rbac:groups=nauth.io,resources=users,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nauth.io,resources=users/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nauth.io,resources=users/finalizers,verbs=update
+// +kubebuilder:rbac:groups=nauth.io,resources=permissionsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthdefaults,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nauth.io,resources=referencegrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=external-secrets.io,resources=pushsecrets,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
@@ -82,15 +105,28 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
+	if owned, owner, err := r.shard.claim(ctx, r.Client, user); !owned {
+		if owner != "" {
+			r.reporter.warning(user, eventReasonShardConflict, actionReconciled,
+				"User is in the shard of %s but owned by %s", r.shard.Name(), owner)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if user.Spec.Paused {
+		return r.reporter.paused(ctx, user)
+	}
+
+	nauthDefaults, err := r.defaultsReader.GetNauthDefaults(ctx)
+	if err != nil {
+		return r.reporter.error(ctx, user, fmt.Errorf("failed to get NauthDefaults: %w", err))
+	}
+	finalizer := userFinalizerPolicy(nauthDefaults)
+
 	// USER MARKED FOR DELETION
 	if !user.DeletionTimestamp.IsZero() {
 		// The user is being deleted
-		meta.SetStatusCondition(&user.Status.Conditions, metav1.Condition{
-			Type:    conditionTypeReady,
-			Status:  metav1.ConditionFalse,
-			Reason:  conditionReasonReconciling,
-			Message: "Deleting user",
-		})
+		conditions.MarkDeleting(user, "Deleting user")
 
 		if err := r.Status().Update(ctx, user); err != nil {
 			log.Info("Failed to update the user status", "name", user.Name, "error", err)
@@ -98,13 +134,13 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 
 		// The user is being deleted
-		if controllerutil.ContainsFinalizer(user, finalizerUser) {
+		if finalizer.present(user) {
 			if err := r.manager.Delete(ctx, user); err != nil {
 				return r.reporter.error(ctx, user, fmt.Errorf("failed to delete user: %w", err))
 			}
 
-			// remove our finalizer from the list and update it.
-			controllerutil.RemoveFinalizer(user, finalizerUser)
+			// remove our finalizers from the list and update it.
+			finalizer.remove(user)
 			if err := r.Update(ctx, user); err != nil {
 				log.Info("failed to remove finalizer", "name", user.Name, "error", err)
 				return ctrl.Result{}, err
@@ -114,37 +150,63 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, nil
 	}
 
-	operatorVersion := os.Getenv(envOperatorVersion)
-
-	// Nothing has changed
-	if user.Status.ObservedGeneration == user.Generation && user.Status.OperatorVersion == operatorVersion {
-		return ctrl.Result{}, nil
+	if err := checkRequiredLabels(user, nauthDefaults); err != nil {
+		return r.reporter.error(ctx, user, err)
+	}
+	if err := r.checkQuotas(ctx, user); err != nil {
+		return r.reporter.error(ctx, user, err)
+	}
+	accountChanged, err := r.resolveAccountRef(ctx, user)
+	if err != nil {
+		return r.reporter.error(ctx, user, err)
 	}
 
-	// RECONCILE USER - Set status & base properties
+	operatorVersion := os.Getenv(envOperatorVersion)
 
-	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(user, finalizerUser) {
-		controllerutil.AddFinalizer(user, finalizerUser)
+	// Apply the finalizer of the NauthDefaults
+	finalizerChanged := finalizer.apply(user)
+	if finalizerChanged {
 		if err := r.Update(ctx, user); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
-	meta.SetStatusCondition(&user.Status.Conditions, metav1.Condition{
-		Type:    conditionTypeReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  conditionReasonReconciling,
-		Message: "Reconciling user",
-	})
+	// Nothing has changed
+	if !finalizerChanged && user.Status.ObservedGeneration == user.Generation && user.Status.OperatorVersion == operatorVersion && !accountChanged &&
+		!r.permissionSetsChanged(ctx, user) && !r.userDefaultsChanged(ctx, user) &&
+		!platformUserDefaultsChanged(user, nauthDefaults) && !userKeyPolicyViolated(user, nauthDefaults) &&
+		!bearerRenewalDue(user) {
+		result, err := r.expireCredentials(ctx, user)
+		return requeueForBearerRenewal(user, result, err)
+	}
+
+	// RECONCILE USER - Set status & base properties
+
+	conditions.ClearPaused(user)
+	finalizer.report(user, conditionMessageUserFinalizerDisabled)
+	conditions.MarkReconciling(user, "Reconciling user")
 	if err := r.Status().Update(ctx, user); err != nil {
 		log.Info("Failed to create the user status", "name", user.Name, "error", err)
 		return ctrl.Result{}, err
 	}
 
+	previousUserID := user.GetLabel(v1alpha1.UserLabelUserID)
 	if err := r.manager.CreateOrUpdate(ctx, user); err != nil {
+		// Credentials issued earlier remain in their Secret
+		if meta.FindStatusCondition(user.Status.Conditions, conditions.TypeCredentialsIssued) == nil {
+			conditions.Set(user, conditions.TypeCredentialsIssued, metav1.ConditionFalse, conditions.ReasonNotIssued,
+				"User credentials have not been issued")
+		}
 		return r.reporter.error(ctx, user, err)
 	}
+	conditions.Set(user, conditions.TypeCredentialsIssued, metav1.ConditionTrue, conditions.ReasonIssued,
+		fmt.Sprintf("User credentials written to Secret %s", user.GetUserSecretName()))
+	if previousUserID == "" {
+		r.reporter.event(user, eventReasonCredentialsIssued, actionSigned, "Issued credentials in Secret %s", user.GetUserSecretName())
+	} else {
+		r.reporter.event(user, eventReasonCredentialsRotated, actionSigned, "Rotated credentials in Secret %s, replacing user %s",
+			user.GetUserSecretName(), previousUserID)
+	}
 
 	// UPDATE USER STATUS
 
@@ -165,16 +227,312 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
-	return r.reporter.status(ctx, user)
+	result, err := r.reporter.status(ctx, user)
+	if err != nil || user.Status.CredentialsExpireAt == nil {
+		return requeueForBearerRenewal(user, result, err)
+	}
+	return r.expireCredentials(ctx, user)
+}
+
+// bearerRenewAt returns when a new bearer JWT is issued for a user with spec.bearer, once two thirds of the lifetime
+// of the current one have passed, so clients fetching the Secret get a new JWT well before the old one expires.
+func bearerRenewAt(user *v1alpha1.User) (time.Time, bool) {
+	if user.Spec.Bearer == nil || user.Status.JWT == nil || user.Status.JWT.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	issuedAt := user.Status.JWT.IssuedAt.Time
+	lifetime := user.Status.JWT.ExpiresAt.Sub(issuedAt)
+	return issuedAt.Add(lifetime * 2 / 3), true
+}
+
+func bearerRenewalDue(user *v1alpha1.User) bool {
+	renewAt, ok := bearerRenewAt(user)
+	return ok && !time.Now().Before(renewAt)
+}
+
+// requeueForBearerRenewal shortens the requeue of a successful reconcile result to when the bearer JWT of the user is
+// renewed.
+func requeueForBearerRenewal(user *v1alpha1.User, result ctrl.Result, err error) (ctrl.Result, error) {
+	renewAt, ok := bearerRenewAt(user)
+	if err != nil || !ok {
+		return result, err
+	}
+	renewAfter := max(time.Until(renewAt), requeueImmediately)
+	if result.RequeueAfter == 0 || renewAfter < result.RequeueAfter {
+		result.RequeueAfter = renewAfter
+	}
+	return result, nil
+}
+
+// expireCredentials deletes the credentials Secret of a user with spec.credentialsTTL once the TTL has passed, and
+// otherwise requeues the user for when it does.
+func (r *UserReconciler) expireCredentials(ctx context.Context, user *v1alpha1.User) (ctrl.Result, error) {
+	expireAt := user.Status.CredentialsExpireAt
+	if expireAt == nil {
+		return ctrl.Result{}, nil
+	}
+	if remaining := time.Until(expireAt.Time); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if err := r.manager.ExpireCredentials(ctx, user); err != nil {
+		return r.reporter.error(ctx, user, fmt.Errorf("failed to expire user credentials: %w", err))
+	}
+	conditions.Set(user, conditions.TypeCredentialsIssued, metav1.ConditionFalse, conditions.ReasonExpired,
+		fmt.Sprintf("User credentials Secret %s deleted after spec.credentialsTTL", user.GetUserSecretName()))
+	if err := r.Status().Update(ctx, user); err != nil {
+		logf.FromContext(ctx).Info("Failed to update the user status", "name", user.Name, "error", err)
+		return ctrl.Result{}, err
+	}
+	r.reporter.event(user, eventReasonCredentialsExpired, actionDeleted, "Deleted credentials Secret %s after its TTL",
+		user.GetUserSecretName())
+	return ctrl.Result{}, nil
+}
+
+// permissionSetsChanged reports whether the referenced PermissionSets differ from the ones the user was last signed
+// with. Unresolvable PermissionSets count as changed, so the failure is reported by the reconciliation.
+func (r *UserReconciler) permissionSetsChanged(ctx context.Context, user *v1alpha1.User) bool {
+	refs := user.Spec.PermissionSetRefs
+	observed := user.Status.PermissionSets
+	if len(refs) != len(observed) {
+		return true
+	}
+	for i, ref := range refs {
+		if observed[i].Name != ref.Name {
+			return true
+		}
+		permissionSet := &v1alpha1.PermissionSet{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: user.Namespace, Name: ref.Name}, permissionSet); err != nil {
+			return true
+		}
+		if permissionSet.Generation != observed[i].ObservedGeneration {
+			return true
+		}
+	}
+	return false
 }
 
-func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+// resolveAccountRef records the Account referenced by spec.accountRef in status.accountRef, and reports whether it
+// differs from the one recorded before. A reference to an Account in another namespace must be allowed by a
+// ReferenceGrant, and a selector must match exactly one Account.
+func (r *UserReconciler) resolveAccountRef(ctx context.Context, user *v1alpha1.User) (bool, error) {
+	previous := user.Status.AccountRef
+	ref := user.Spec.AccountRef
+	if ref == nil {
+		user.Status.AccountRef = nil
+		return previous != nil, nil
+	}
+
+	accountRef := user.GetAccountRef()
+	if ref.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+		if err != nil {
+			return false, domain.ErrBadRequest.WithCause(fmt.Errorf("invalid account selector: %w", err))
+		}
+		accounts := &v1alpha1.AccountList{}
+		if err := r.List(ctx, accounts, client.InNamespace(accountRef.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return false, fmt.Errorf("failed to list Accounts for account selector: %w", err)
+		}
+		switch len(accounts.Items) {
+		case 0:
+			return false, domain.ErrAccountNotFound.WithCause(fmt.Errorf("no Account in namespace %s matches selector %q",
+				accountRef.Namespace, selector))
+		case 1:
+			accountRef.Name = accounts.Items[0].Name
+		default:
+			return false, domain.ErrBadRequest.WithCause(fmt.Errorf("%d Accounts in namespace %s match selector %q, expected exactly one",
+				len(accounts.Items), accountRef.Namespace, selector))
+		}
+	}
+
+	if err := r.grantReader.CheckAccountReference(ctx, v1alpha1.ReferenceGrantFromKindUser, domain.Namespace(user.Namespace),
+		domain.NewNamespacedName(accountRef.Namespace, accountRef.Name)); err != nil {
+		return false, err
+	}
+	user.Status.AccountRef = &accountRef
+	return previous == nil || *previous != accountRef, nil
+}
+
+// userDefaultsChanged reports whether the user defaults of the account differ from the ones the user was last signed
+// with. A missing Account leaves the user as it was signed, other lookup failures count as changed. Users with
+// spec.nkey have no Account.
+func (r *UserReconciler) userDefaultsChanged(ctx context.Context, user *v1alpha1.User) bool {
+	if user.Spec.NKey != nil {
+		return false
+	}
+	accountRef := user.GetAccountRef()
+	account := &v1alpha1.Account{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: accountRef.Namespace, Name: accountRef.Name}, account); err != nil {
+		return !apierrors.IsNotFound(err)
+	}
+	return !equality.Semantic.DeepEqual(account.Spec.UserDefaults, user.Status.AccountUserDefaults)
+}
+
+// checkQuotas returns domain.ErrQuotaExceeded when the User does not fit the NauthQuotas of its namespace.
+func (r *UserReconciler) checkQuotas(ctx context.Context, user *v1alpha1.User) error {
+	quotas := &v1alpha1.NauthQuotaList{}
+	if err := r.List(ctx, quotas, client.InNamespace(user.Namespace)); err != nil {
+		return fmt.Errorf("failed to list NauthQuotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+	users := &v1alpha1.UserList{}
+	if err := r.List(ctx, users, client.InNamespace(user.Namespace)); err != nil {
+		return fmt.Errorf("failed to list Users: %w", err)
+	}
+	return checkUserQuotas(user, users.Items, quotas.Items)
+}
+
+func platformUserDefaultsChanged(user *v1alpha1.User, nauthDefaults *v1alpha1.NauthDefaultsSpec) bool {
+	var platformDefaults *v1alpha1.UserPlatformDefaults
+	if nauthDefaults != nil {
+		platformDefaults = nauthDefaults.User
+	}
+	return !equality.Semantic.DeepEqual(platformDefaults, user.Status.PlatformUserDefaults)
+}
+
+func (r *UserReconciler) mapNauthDefaultsToUsers(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != v1alpha1.NauthDefaultsName {
+		return nil
+	}
+	users := &v1alpha1.UserList{}
+	if err := r.List(ctx, users); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Users for NauthDefaults watch")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(users.Items))
+	for _, user := range users.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&user),
+		})
+	}
+	return requests
+}
+
+// mapNauthQuotaToUsers reconciles the Users in the namespace of a NauthQuota.
+func (r *UserReconciler) mapNauthQuotaToUsers(ctx context.Context, obj client.Object) []reconcile.Request {
+	users := &v1alpha1.UserList{}
+	if err := r.List(ctx, users, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Users for NauthQuota watch", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(users.Items))
+	for _, user := range users.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&user),
+		})
+	}
+	return requests
+}
+
+// mapAccountToUsers reconciles the Users referencing the Account, including Users in other namespaces and Users with
+// an account selector matching it, or that matched it before.
+func (r *UserReconciler) mapAccountToUsers(ctx context.Context, obj client.Object) []reconcile.Request {
+	users := &v1alpha1.UserList{}
+	if err := r.List(ctx, users); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Users for Account watch",
+			"account", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, user := range users.Items {
+		if userReferencesAccount(&user, obj) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&user),
+			})
+		}
+	}
+	return requests
+}
+
+func userReferencesAccount(user *v1alpha1.User, account client.Object) bool {
+	accountRef := user.GetAccountRef()
+	if accountRef.Namespace != account.GetNamespace() {
+		return false
+	}
+	if accountRef.Name == account.GetName() {
+		return true
+	}
+	if ref := user.Spec.AccountRef; ref != nil && ref.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+		return err == nil && selector.Matches(labels.Set(account.GetLabels()))
+	}
+	return false
+}
+
+// mapReferenceGrantToUsers reconciles the Users in the namespaces granted by the ReferenceGrant that reference an
+// Account in the namespace of the ReferenceGrant.
+func (r *UserReconciler) mapReferenceGrantToUsers(ctx context.Context, obj client.Object) []reconcile.Request {
+	grant, ok := obj.(*v1alpha1.ReferenceGrant)
+	if !ok {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, from := range grant.Spec.From {
+		if from.Kind != v1alpha1.ReferenceGrantFromKindUser {
+			continue
+		}
+		users := &v1alpha1.UserList{}
+		if err := r.List(ctx, users, client.InNamespace(from.Namespace)); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list Users for ReferenceGrant watch",
+				"referenceGrant", grant.Name, "namespace", from.Namespace)
+			continue
+		}
+		for _, user := range users.Items {
+			if user.GetAccountRef().Namespace == grant.Namespace {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&user),
+				})
+			}
+		}
+	}
+	return requests
+}
+
+func (r *UserReconciler) mapPermissionSetToUsers(ctx context.Context, obj client.Object) []reconcile.Request {
+	users := &v1alpha1.UserList{}
+	if err := r.List(ctx, users, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Users for PermissionSet watch",
+			"permissionSet", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, user := range users.Items {
+		for _, ref := range user.Spec.PermissionSetRefs {
+			if ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&user),
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager, requeuePolicy RequeuePolicy, maxConcurrentReconciles int) error {
+	rateLimiter := requeuePolicy.newRateLimiter()
+	r.reporter.tolerateFailures(rateLimiter, requeuePolicy.MaxRetries)
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.User{}).
+		For(&v1alpha1.User{}, builder.WithPredicates(r.shard.predicate())).
+		Watches(&v1alpha1.User{}, enqueueDeletionFirst(), builder.WithPredicates(r.shard.predicate())).
+		Watches(&v1alpha1.PermissionSet{}, handler.EnqueueRequestsFromMapFunc(r.mapPermissionSetToUsers)).
+		Watches(&v1alpha1.Account{}, handler.EnqueueRequestsFromMapFunc(r.mapAccountToUsers)).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.mapReferenceGrantToUsers)).
+		Watches(&v1alpha1.NauthDefaults{}, handler.EnqueueRequestsFromMapFunc(r.mapNauthDefaultsToUsers)).
+		Watches(&v1alpha1.NauthQuota{}, handler.EnqueueRequestsFromMapFunc(r.mapNauthQuotaToUsers)).
 		Named("user").
 		WithEventFilter(predicate.GenerationChangedPredicate{}).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1,
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
 		}).
-		Complete(r)
+		Complete(traced("user", r))
 }
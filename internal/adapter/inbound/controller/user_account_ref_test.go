@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUserReconciler_resolveAccountRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountRef  *v1alpha1.UserAccountRef
+		objects     []client.Object
+		expectedRef *v1alpha1.AccountRef
+		expectedErr error
+	}{
+		{
+			name:        "account_name",
+			expectedRef: nil,
+		},
+		{
+			name:        "name_in_same_namespace",
+			accountRef:  &v1alpha1.UserAccountRef{Name: "orders"},
+			expectedRef: &v1alpha1.AccountRef{Name: "orders", Namespace: "team-b"},
+		},
+		{
+			name:        "name_in_other_namespace_not_granted",
+			accountRef:  &v1alpha1.UserAccountRef{Name: "orders", Namespace: "team-a"},
+			expectedErr: domain.ErrReferenceNotGranted,
+		},
+		{
+			name:        "name_in_other_namespace_granted",
+			accountRef:  &v1alpha1.UserAccountRef{Name: "orders", Namespace: "team-a"},
+			objects:     []client.Object{userReferenceGrant("team-a", "team-b")},
+			expectedRef: &v1alpha1.AccountRef{Name: "orders", Namespace: "team-a"},
+		},
+		{
+			name:        "selector_matching_one_account",
+			accountRef:  &v1alpha1.UserAccountRef{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			objects:     []client.Object{userTestAccount("team-b", "orders", "a"), userTestAccount("team-b", "billing", "b")},
+			expectedRef: &v1alpha1.AccountRef{Name: "orders", Namespace: "team-b"},
+		},
+		{
+			name: "selector_in_other_namespace_granted",
+			accountRef: &v1alpha1.UserAccountRef{Namespace: "team-a",
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			objects: []client.Object{userTestAccount("team-a", "orders", "a"), userTestAccount("team-b", "billing", "a"),
+				userReferenceGrant("team-a", "team-b")},
+			expectedRef: &v1alpha1.AccountRef{Name: "orders", Namespace: "team-a"},
+		},
+		{
+			name:        "selector_matching_no_account",
+			accountRef:  &v1alpha1.UserAccountRef{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "c"}}},
+			objects:     []client.Object{userTestAccount("team-b", "orders", "a")},
+			expectedErr: domain.ErrAccountNotFound,
+		},
+		{
+			name:        "selector_matching_multiple_accounts",
+			accountRef:  &v1alpha1.UserAccountRef{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			objects:     []client.Object{userTestAccount("team-b", "orders", "a"), userTestAccount("team-b", "billing", "a")},
+			expectedErr: domain.ErrBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			k8sClient := newFakeClientBuilder(t, tc.objects...).Build()
+			reconciler := &UserReconciler{Client: k8sClient, grantReader: k8s.NewReferenceGrantClient(k8sClient)}
+			user := userTestUser("team-b", "user", tc.accountRef)
+
+			// When
+			changed, err := reconciler.resolveAccountRef(context.Background(), user)
+
+			// Then
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, user.Status.AccountRef)
+			assert.Equal(t, tc.expectedRef != nil, changed)
+		})
+	}
+}
+
+func TestUserReconciler_resolveAccountRef_ShouldReportChange_WhenSelectedAccountChanged(t *testing.T) {
+	// Given
+	k8sClient := newFakeClientBuilder(t, userTestAccount("team-b", "billing", "a")).Build()
+	reconciler := &UserReconciler{Client: k8sClient, grantReader: k8s.NewReferenceGrantClient(k8sClient)}
+	user := userTestUser("team-b", "user",
+		&v1alpha1.UserAccountRef{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}})
+	user.Status.AccountRef = &v1alpha1.AccountRef{Name: "billing", Namespace: "team-b"}
+
+	// When
+	changed, err := reconciler.resolveAccountRef(context.Background(), user)
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Given
+	require.NoError(t, k8sClient.Delete(context.Background(), userTestAccount("team-b", "billing", "a")))
+	require.NoError(t, k8sClient.Create(context.Background(), userTestAccount("team-b", "orders", "a")))
+
+	// When
+	changed, err = reconciler.resolveAccountRef(context.Background(), user)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, &v1alpha1.AccountRef{Name: "orders", Namespace: "team-b"}, user.Status.AccountRef)
+}
+
+func TestUserReconciler_mapAccountToUsers(t *testing.T) {
+	// Given
+	account := userTestAccount("team-a", "orders", "a")
+	byName := userTestUser("team-a", "by-name", nil)
+	byName.Spec.AccountName = "orders"
+	otherName := userTestUser("team-a", "other-name", nil)
+	otherName.Spec.AccountName = "billing"
+	byRef := userTestUser("team-b", "by-ref", &v1alpha1.UserAccountRef{Name: "orders", Namespace: "team-a"})
+	bySelector := userTestUser("team-b", "by-selector", &v1alpha1.UserAccountRef{Namespace: "team-a",
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}})
+	bySelectorOtherNamespace := userTestUser("team-b", "by-selector-other-namespace",
+		&v1alpha1.UserAccountRef{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}})
+	reconciler := &UserReconciler{Client: newFakeClientBuilder(t, account, byName, otherName, byRef, bySelector,
+		bySelectorOtherNamespace).Build()}
+
+	// When
+	requests := reconciler.mapAccountToUsers(context.Background(), account)
+
+	// Then
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "by-name"}},
+		{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "by-ref"}},
+		{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "by-selector"}},
+	}, requests)
+}
+
+func TestUserReconciler_mapReferenceGrantToUsers(t *testing.T) {
+	// Given
+	grant := userReferenceGrant("team-a", "team-b")
+	crossNamespace := userTestUser("team-b", "cross-namespace", &v1alpha1.UserAccountRef{Name: "orders", Namespace: "team-a"})
+	sameNamespace := userTestUser("team-b", "same-namespace", &v1alpha1.UserAccountRef{Name: "orders"})
+	otherNamespace := userTestUser("team-c", "other-namespace", &v1alpha1.UserAccountRef{Name: "orders", Namespace: "team-a"})
+	reconciler := &UserReconciler{Client: newFakeClientBuilder(t, grant, crossNamespace, sameNamespace,
+		otherNamespace).Build()}
+
+	// When
+	requests := reconciler.mapReferenceGrantToUsers(context.Background(), grant)
+
+	// Then
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "cross-namespace"}},
+	}, requests)
+}
+
+func userTestUser(namespace string, name string, accountRef *v1alpha1.UserAccountRef) *v1alpha1.User {
+	return &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountRef: accountRef,
+		},
+	}
+}
+
+func userTestAccount(namespace string, name string, team string) *v1alpha1.Account {
+	return &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"team": team},
+		},
+	}
+}
+
+func userReferenceGrant(namespace string, fromNamespace string) *v1alpha1.ReferenceGrant {
+	return &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-users",
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: v1alpha1.ReferenceGrantFromKindUser, Namespace: fromNamespace}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: v1alpha1.ReferenceGrantToKindAccount}},
+		},
+	}
+}
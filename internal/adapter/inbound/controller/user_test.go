@@ -5,16 +5,22 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller/conditions"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/testutil"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	k8err "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -55,7 +61,10 @@ func (t *UserControllerTestSuite) SetupTest() {
 		k8sClient,
 		k8sClient.Scheme(),
 		t.userManagerMock,
+		k8s.NewReferenceGrantClient(k8sClient),
+		k8s.NewNauthDefaultsClient(k8sClient),
 		t.fakeRecorder,
+		nil,
 	)
 
 	t.Require().NoError(ensureNamespace(t.ctx, namespace))
@@ -86,12 +95,45 @@ func (t *UserControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenCreatingOrUpd
 	err = k8sClient.Get(t.ctx, t.userNamespacedName, user)
 	t.Require().NoError(err)
 
-	for _, c := range user.Status.Conditions {
-		t.Equal(metav1.ConditionTrue, c.Status)
-		t.Equal(conditionReasonReconciled, c.Reason)
-	}
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionTrue, conditions.ReasonReconciled)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeSynced, metav1.ConditionTrue, conditions.ReasonReconciled)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeDegraded, metav1.ConditionFalse, conditions.ReasonHealthy)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeCredentialsIssued, metav1.ConditionTrue, conditions.ReasonIssued)
 	t.Equal(t.operatorVersion, user.Status.OperatorVersion)
-	t.Empty(t.fakeRecorder.Events)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonCredentialsIssued)
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldNotSignUser_WhilePaused() {
+	// Given
+	user := &v1alpha1.User{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	user.Spec.Paused = true
+	t.Require().NoError(k8sClient.Update(t.ctx, user))
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
+	t.Zero(result.RequeueAfter)
+	t.userManagerMock.AssertNotCalled(t.T(), "CreateOrUpdate", mock.Anything, mock.Anything)
+
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypePaused, metav1.ConditionTrue, conditions.ReasonPaused)
+	t.False(controllerutil.ContainsFinalizer(user, finalizerUser))
+
+	// When (resumed)
+	user.Spec.Paused = false
+	t.Require().NoError(k8sClient.Update(t.ctx, user))
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Once()
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	t.Nil(meta.FindStatusCondition(user.Status.Conditions, conditions.TypePaused))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionTrue, conditions.ReasonReconciled)
 }
 
 func (t *UserControllerTestSuite) Test_Reconcile_ShouldFail_WhenCreateOrUpdateFailsBecauseNoAccountExists() {
@@ -109,10 +151,10 @@ func (t *UserControllerTestSuite) Test_Reconcile_ShouldFail_WhenCreateOrUpdateFa
 	err = k8sClient.Get(t.ctx, t.userNamespacedName, user)
 	t.Require().NoError(err)
 
-	for _, c := range user.Status.Conditions {
-		t.Equal(metav1.ConditionFalse, c.Status)
-		t.Equal(conditionReasonErrored, c.Reason)
-	}
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, string(domain.ErrAccountNotFound))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeSynced, metav1.ConditionFalse, string(domain.ErrAccountNotFound))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeDegraded, metav1.ConditionFalse, string(domain.ErrAccountNotFound))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeCredentialsIssued, metav1.ConditionFalse, conditions.ReasonNotIssued)
 
 	t.Len(t.fakeRecorder.Events, 1)
 	t.Contains(<-t.fakeRecorder.Events, errAccountNotFound.Error())
@@ -148,15 +190,13 @@ func (t *UserControllerTestSuite) Test_Reconcile_ShouldDeleteUserMarkedForDeleti
 	// Then
 	t.NoError(err)
 
-	for _, c := range user.Status.Conditions {
-		t.Equal(metav1.ConditionTrue, c.Status)
-		t.Equal(conditionReasonReconciled, c.Reason)
-	}
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionTrue, conditions.ReasonReconciled)
 
 	err = k8sClient.Get(t.ctx, t.userNamespacedName, user)
 	t.Error(err)
 	t.True(k8err.IsNotFound(err))
-	t.Empty(t.fakeRecorder.Events)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonCredentialsIssued, "expected only the event of the setup")
 }
 
 func (t *UserControllerTestSuite) Test_Reconcile_ShouldFail_WhenDeleteFails() {
@@ -193,12 +233,11 @@ func (t *UserControllerTestSuite) Test_Reconcile_ShouldFail_WhenDeleteFails() {
 
 	err = k8sClient.Get(t.ctx, t.userNamespacedName, user)
 	t.Require().NoError(err)
-	for _, c := range user.Status.Conditions {
-		t.Equal(metav1.ConditionFalse, c.Status)
-		t.Equal(conditionReasonErrored, c.Reason)
-	}
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, conditions.ReasonErrored)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeDegraded, metav1.ConditionTrue, conditions.ReasonErrored)
 
-	t.Len(t.fakeRecorder.Events, 1)
+	t.Len(t.fakeRecorder.Events, 2)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonCredentialsIssued)
 	t.Contains(<-t.fakeRecorder.Events, userDeleteError.Error())
 
 	err = k8sClient.Get(t.ctx, t.userNamespacedName, user)
@@ -229,11 +268,182 @@ func (t *UserControllerTestSuite) Test_Reconcile_ShouldSucceed_WhenOperatorVersi
 
 	err = k8sClient.Get(t.ctx, t.userNamespacedName, user)
 	t.Require().NoError(err)
-	for _, c := range user.Status.Conditions {
-		t.Equal(metav1.ConditionTrue, c.Status)
-		t.Equal(conditionReasonReconciled, c.Reason)
-	}
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionTrue, conditions.ReasonReconciled)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeSynced, metav1.ConditionTrue, conditions.ReasonReconciled)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeDegraded, metav1.ConditionFalse, conditions.ReasonHealthy)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeCredentialsIssued, metav1.ConditionTrue, conditions.ReasonIssued)
 	t.Equal(newOperatorVersion, user.Status.OperatorVersion)
+	t.Len(t.fakeRecorder.Events, 2)
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldRecordCredentialsRotated_WhenUserWasSignedBefore() {
+	// Given
+	signUser := func(userID string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			args.Get(1).(*v1alpha1.User).SetLabel(v1alpha1.UserLabelUserID, userID)
+		}
+	}
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(signUser("UFIRST")).Return(nil).Once()
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+	t.Require().NoError(err)
+	t.Contains(<-t.fakeRecorder.Events, eventReasonCredentialsIssued)
+
+	t.Require().NoError(os.Setenv(envOperatorVersion, "1.1-SNAPSHOT"))
+	t.userManagerMock.AssertExpectations(t.T())
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(signUser("USECOND")).Return(nil).Once()
+
+	// When
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().Len(t.fakeRecorder.Events, 1)
+	event := <-t.fakeRecorder.Events
+	t.Contains(event, "Normal "+eventReasonCredentialsRotated)
+	t.Contains(event, "UFIRST")
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldResignUser_WhenPermissionSetChanges() {
+	// Given
+	permissionSet := &v1alpha1.PermissionSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "common",
+			Namespace: t.userNamespacedName.Namespace,
+		},
+		Spec: v1alpha1.PermissionSetSpec{
+			Permissions: v1alpha1.Permissions{Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}}},
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, permissionSet))
+	user := &v1alpha1.User{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	user.Spec.PermissionSetRefs = []v1alpha1.PermissionSetReference{{Name: permissionSet.Name}}
+	t.Require().NoError(k8sClient.Update(t.ctx, user))
+
+	observePermissionSet := func(args mock.Arguments) {
+		current := &v1alpha1.PermissionSet{}
+		t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKeyFromObject(permissionSet), current))
+		args.Get(0).(*v1alpha1.User).Status.PermissionSets = []v1alpha1.ObservedPermissionSet{
+			{Name: current.Name, ObservedGeneration: current.Generation},
+		}
+	}
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything).Run(observePermissionSet).Return(nil).Once()
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+	t.Require().NoError(err)
+
+	// Note: unchanged PermissionSets must not re-sign the user
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+	t.Require().NoError(err)
+	t.userManagerMock.AssertExpectations(t.T())
+
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKeyFromObject(permissionSet), permissionSet))
+	permissionSet.Spec.Permissions.Pub.Allow = v1alpha1.StringList{"orders.>"}
+	t.Require().NoError(k8sClient.Update(t.ctx, permissionSet))
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything).Run(observePermissionSet).Return(nil).Once()
+
+	// When
+	requests := t.unitUnderTest.mapPermissionSetToUsers(t.ctx, permissionSet)
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
+	t.Equal([]reconcile.Request{{NamespacedName: t.userNamespacedName}}, requests)
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	t.Equal([]v1alpha1.ObservedPermissionSet{{Name: "common", ObservedGeneration: 2}}, user.Status.PermissionSets)
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldResignUser_WhenAccountUserDefaultsChange() {
+	// Given
+	account := &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-account",
+			Namespace: t.userNamespacedName.Namespace,
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, account))
+	user := &v1alpha1.User{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	user.Spec.AccountName = account.Name
+	t.Require().NoError(k8sClient.Update(t.ctx, user))
+
+	observeUserDefaults := func(args mock.Arguments) {
+		current := &v1alpha1.Account{}
+		t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKeyFromObject(account), current))
+		args.Get(0).(*v1alpha1.User).Status.AccountUserDefaults = current.Spec.UserDefaults
+	}
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything).Run(observeUserDefaults).Return(nil).Once()
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+	t.Require().NoError(err)
+
+	// Note: unchanged user defaults must not re-sign the user
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+	t.Require().NoError(err)
+	t.userManagerMock.AssertExpectations(t.T())
+
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKeyFromObject(account), account))
+	account.Spec.UserDefaults = &v1alpha1.UserDefaults{
+		Permissions: &v1alpha1.Permissions{Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}}},
+	}
+	t.Require().NoError(k8sClient.Update(t.ctx, account))
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything).Run(observeUserDefaults).Return(nil).Once()
+
+	// When
+	requests := t.unitUnderTest.mapAccountToUsers(t.ctx, account)
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
+	t.Equal([]reconcile.Request{{NamespacedName: t.userNamespacedName}}, requests)
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	t.Require().NotNil(user.Status.AccountUserDefaults)
+	t.Equal(v1alpha1.StringList{"_INBOX.>"}, user.Status.AccountUserDefaults.Permissions.Sub.Allow)
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldRequeue_UntilCredentialsTTLHasPassed() {
+	// Given
+	expireAt := metav1.NewTime(time.Now().Add(time.Hour))
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*v1alpha1.User).Status.CredentialsExpireAt = &expireAt
+	}).Return(nil).Once()
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
+	t.Greater(result.RequeueAfter, 55*time.Minute)
+	t.LessOrEqual(result.RequeueAfter, time.Hour)
+	t.userManagerMock.AssertNotCalled(t.T(), "ExpireCredentials", mock.Anything)
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldExpireCredentials_WhenCredentialsTTLHasPassed() {
+	// Given
+	expireAt := metav1.NewTime(time.Now().Add(-time.Second))
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*v1alpha1.User).Status.CredentialsExpireAt = &expireAt
+	}).Return(nil).Once()
+	t.userManagerMock.On("ExpireCredentials", mock.Anything).Return(nil).Once()
+
+	// When
+	result, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
+	t.Zero(result.RequeueAfter)
+	user := &v1alpha1.User{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	t.Nil(user.Status.CredentialsExpireAt)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeCredentialsIssued, metav1.ConditionFalse, conditions.ReasonExpired)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionTrue, conditions.ReasonReconciled)
+	t.Require().Len(t.fakeRecorder.Events, 2)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonCredentialsIssued)
+	t.Contains(<-t.fakeRecorder.Events, "Normal "+eventReasonCredentialsExpired)
+
+	// When (reconciled again)
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.NoError(err)
 	t.Empty(t.fakeRecorder.Events)
 }
 
@@ -251,3 +461,75 @@ func (u *UserManagerMock) Delete(ctx context.Context, desired *v1alpha1.User) er
 	args := u.Called(desired)
 	return args.Error(0)
 }
+
+func (u *UserManagerMock) ExpireCredentials(ctx context.Context, state *v1alpha1.User) error {
+	args := u.Called(state)
+	if args.Error(0) == nil {
+		state.Status.CredentialsExpireAt = nil
+	}
+	return args.Error(0)
+}
+
+func (t *UserControllerTestSuite) Test_Reconcile_ShouldNotBeDegraded_WhileFailuresAreRetried() {
+	// Given
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Once()
+	_, err := t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+	t.Require().NoError(err)
+
+	t.unitUnderTest.reporter.tolerateFailures(fixedFailureCounter(1), 2)
+	t.Require().NoError(os.Setenv(envOperatorVersion, "1.1-SNAPSHOT"))
+	t.userManagerMock.AssertExpectations(t.T())
+	t.userManagerMock.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(domain.ErrAccountNotReady).Once()
+
+	// When
+	_, err = t.unitUnderTest.Reconcile(t.ctx, reconcile.Request{NamespacedName: t.userNamespacedName})
+
+	// Then
+	t.ErrorIs(err, domain.ErrAccountNotReady)
+
+	user := &v1alpha1.User{}
+	t.Require().NoError(k8sClient.Get(t.ctx, t.userNamespacedName, user))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeReady, metav1.ConditionFalse, string(domain.ErrAccountNotReady))
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeSynced, metav1.ConditionTrue, conditions.ReasonReconciled)
+	assertCondition(t.T(), user.Status.Conditions, conditions.TypeDegraded, metav1.ConditionFalse, conditions.ReasonHealthy)
+}
+
+type fixedFailureCounter int
+
+func (c fixedFailureCounter) NumRequeues(reconcile.Request) int {
+	return int(c)
+}
+
+func Test_requeueForBearerRenewal(t *testing.T) {
+	issuedAt := time.Now()
+	bearerUser := func(lifetime time.Duration) *v1alpha1.User {
+		return &v1alpha1.User{
+			Spec: v1alpha1.UserSpec{Bearer: &v1alpha1.BearerCredentials{}},
+			Status: v1alpha1.UserStatus{JWT: &v1alpha1.JWTMetadata{
+				IssuedAt:  metav1.NewTime(issuedAt),
+				ExpiresAt: new(metav1.NewTime(issuedAt.Add(lifetime))),
+			}},
+		}
+	}
+	testCases := []struct {
+		name          string
+		user          *v1alpha1.User
+		result        reconcile.Result
+		expectedAfter time.Duration
+	}{
+		{name: "not_bearer", user: &v1alpha1.User{}, result: reconcile.Result{RequeueAfter: time.Hour}, expectedAfter: time.Hour},
+		{name: "renewal_before_requeue", user: bearerUser(30 * time.Minute), result: reconcile.Result{RequeueAfter: time.Hour}, expectedAfter: 20 * time.Minute},
+		{name: "requeue_before_renewal", user: bearerUser(3 * time.Hour), result: reconcile.Result{RequeueAfter: time.Hour}, expectedAfter: time.Hour},
+		{name: "no_requeue", user: bearerUser(30 * time.Minute), expectedAfter: 20 * time.Minute},
+		{name: "renewal_due", user: bearerUser(-time.Minute), expectedAfter: requeueImmediately},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := requeueForBearerRenewal(tc.user, tc.result, nil)
+
+			require.NoError(t, err)
+			require.InDelta(t, tc.expectedAfter, result.RequeueAfter, float64(time.Second))
+		})
+	}
+}
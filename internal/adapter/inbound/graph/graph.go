@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const Path = "/graph"
+
+// NewHandler serves the import/export dependency graph of the managed accounts as JSON.
+func NewHandler(reader inbound.AccountGraphReader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		graph, err := reader.GetGraph(r.Context())
+		if err != nil {
+			logf.FromContext(r.Context()).Error(err, "Failed to build account graph")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(graph); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
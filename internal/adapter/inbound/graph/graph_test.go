@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGraph = nauth.AccountGraph{
+	Accounts: []nauth.AccountGraphNode{
+		{AccountID: "ACCOUNT_ORDERS", Namespace: "team-a", Name: "orders",
+			Exports: []nauth.AccountGraphExport{{Subject: "orders.>", Type: nauth.ExportTypeStream, Importers: 1}}},
+		{AccountID: "ACCOUNT_BILLING", Namespace: "team-b", Name: "billing"},
+	},
+	Imports: []nauth.AccountGraphImport{
+		{Importer: "ACCOUNT_BILLING", Exporter: "ACCOUNT_ORDERS", Subject: "orders.created", Type: nauth.ExportTypeStream,
+			Export: "orders.>"},
+	},
+}
+
+type graphReaderStub struct {
+	graph *nauth.AccountGraph
+	err   error
+}
+
+func (s *graphReaderStub) GetGraph(context.Context) (*nauth.AccountGraph, error) {
+	return s.graph, s.err
+}
+
+func Test_Handler(t *testing.T) {
+	t.Run("should_serve_graph_as_json", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		NewHandler(&graphReaderStub{graph: &testGraph}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path, nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		result := nauth.AccountGraph{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.Equal(t, testGraph, result)
+	})
+
+	t.Run("should_fail_when_graph_cannot_be_built", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		NewHandler(&graphReaderStub{err: errors.New("forbidden")}).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, Path, nil))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("should_reject_other_methods", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		NewHandler(&graphReaderStub{graph: &testGraph}).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "nauth"
+
+var cryptoPolicyInfo = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "crypto_policy_info",
+		Help:      "Active crypto policy of the operator. Always 1, the policy is described by the labels.",
+	},
+	[]string{"policy", "fips140"},
+)
+
+var accountUsageLabels = []string{"namespace", "account", "account_id"}
+
+var (
+	accountConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "account",
+		Name:      "connections",
+		Help:      "Client connections of the account, summed over the servers of its cluster.",
+	}, accountUsageLabels)
+	accountLeafNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "account",
+		Name:      "leafnodes",
+		Help:      "Leaf node connections of the account, summed over the servers of its cluster.",
+	}, accountUsageLabels)
+	accountSubscriptions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "account",
+		Name:      "subscriptions",
+		Help:      "Subscriptions of the account, summed over the servers of its cluster.",
+	}, accountUsageLabels)
+	accountJetStreamMemoryBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "account",
+		Name:      "jetstream_memory_bytes",
+		Help:      "JetStream memory storage in use by the account.",
+	}, accountUsageLabels)
+	accountJetStreamStorageBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "account",
+		Name:      "jetstream_storage_bytes",
+		Help:      "JetStream file storage in use by the account.",
+	}, accountUsageLabels)
+	accountUsageWarning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "account",
+		Name:      "usage_warning",
+		Help:      "1 while the JetStream usage of the account is at or above the warning threshold of its limits, else 0.",
+	}, accountUsageLabels)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(cryptoPolicyInfo, accountConnections, accountLeafNodes, accountSubscriptions,
+		accountJetStreamMemoryBytes, accountJetStreamStorageBytes, accountUsageWarning)
+}
+
+// ReportCryptoPolicy publishes the active crypto policy and whether the Go FIPS 140-3 mode is enabled.
+func ReportCryptoPolicy(policy string, fips140Enabled bool) {
+	cryptoPolicyInfo.Reset()
+	cryptoPolicyInfo.WithLabelValues(policy, strconv.FormatBool(fips140Enabled)).Set(1)
+}
+
+// ReportAccountUsage publishes the usage of an Account. The JetStream metrics are only published for accounts with
+// JetStream enabled.
+func ReportAccountUsage(accountNamespace string, accountName string, accountID string, usage domain.NatsAccountUsage) {
+	// Drop the series of a previous account ID of the same Account
+	ForgetAccountUsage(accountNamespace, accountName)
+	labels := []string{accountNamespace, accountName, accountID}
+	accountConnections.WithLabelValues(labels...).Set(float64(usage.Connections))
+	accountLeafNodes.WithLabelValues(labels...).Set(float64(usage.LeafNodes))
+	accountSubscriptions.WithLabelValues(labels...).Set(float64(usage.Subscriptions))
+	if usage.JetStream != nil {
+		accountJetStreamMemoryBytes.WithLabelValues(labels...).Set(float64(usage.JetStream.MemoryBytes))
+		accountJetStreamStorageBytes.WithLabelValues(labels...).Set(float64(usage.JetStream.StorageBytes))
+	}
+}
+
+// ReportAccountUsageWarning publishes whether the JetStream usage of an Account approaches its limits.
+func ReportAccountUsageWarning(accountNamespace string, accountName string, accountID string, warning bool) {
+	value := 0.0
+	if warning {
+		value = 1
+	}
+	accountUsageWarning.WithLabelValues(accountNamespace, accountName, accountID).Set(value)
+}
+
+// ForgetAccountUsage removes the usage metrics of an Account.
+func ForgetAccountUsage(accountNamespace string, accountName string) {
+	labels := prometheus.Labels{"namespace": accountNamespace, "account": accountName}
+	for _, gauge := range []*prometheus.GaugeVec{accountConnections, accountLeafNodes, accountSubscriptions,
+		accountJetStreamMemoryBytes, accountJetStreamStorageBytes, accountUsageWarning} {
+		gauge.DeletePartialMatch(labels)
+	}
+}
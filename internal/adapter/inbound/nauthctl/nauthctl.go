@@ -0,0 +1,265 @@
+// Package nauthctl implements the inspection and break-glass commands of the nauthctl CLI.
+package nauthctl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrValidationFailed is returned by ValidateSecrets when at least one Secret does not match its labels.
+var ErrValidationFailed = errors.New("secret validation failed")
+
+// Commands runs nauthctl commands against a Kubernetes cluster and writes their output to out.
+type Commands struct {
+	client        client.Client
+	clusterReader outbound.ClusterReader
+	natsSysClient outbound.NatsSysClient
+	out           io.Writer
+	now           func() time.Time
+}
+
+func NewCommands(client client.Client, clusterReader outbound.ClusterReader, natsSysClient outbound.NatsSysClient, out io.Writer) (*Commands, error) {
+	c := &Commands{
+		client:        client,
+		clusterReader: clusterReader,
+		natsSysClient: natsSysClient,
+		out:           out,
+		now:           time.Now,
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Commands) validate() error {
+	if c.client == nil {
+		return errors.New("client is required")
+	}
+	if c.clusterReader == nil {
+		return errors.New("clusterReader is required")
+	}
+	if c.natsSysClient == nil {
+		return errors.New("natsSysClient is required")
+	}
+	if c.out == nil {
+		return errors.New("out is required")
+	}
+	return nil
+}
+
+// AccountJWT prints the decoded account JWT as currently stored in NATS. defaultClusterRef is used when the Account
+// does not reference a NatsCluster.
+func (c *Commands) AccountJWT(ctx context.Context, accountRef domain.NamespacedName, defaultClusterRef *nauth.ClusterRef) error {
+	account, accountID, err := c.getReadyAccount(ctx, accountRef)
+	if err != nil {
+		return err
+	}
+	clusterRef := defaultClusterRef
+	if ref := account.Spec.NatsClusterRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = accountRef.Namespace
+		}
+		result, err := nauth.NewClusterRef(domain.NewNamespacedName(namespace, ref.Name).String())
+		if err != nil {
+			return fmt.Errorf("invalid NatsClusterRef of account %s: %w", accountRef, err)
+		}
+		clusterRef = &result
+	}
+	if clusterRef == nil {
+		return fmt.Errorf("account %s does not reference a NatsCluster and no default cluster is configured", accountRef)
+	}
+
+	target, err := c.clusterReader.GetTarget(ctx, *clusterRef)
+	if err != nil {
+		return fmt.Errorf("resolve cluster target %q: %w", *clusterRef, err)
+	}
+	sysConn, err := c.natsSysClient.Connect(ctx, target.NatsURL, target.SystemAdminCreds, target.TLS)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS cluster %q: %w", *clusterRef, err)
+	}
+	defer sysConn.Disconnect()
+	accountJWT, err := sysConn.LookupAccountJWT(accountID)
+	if err != nil {
+		return fmt.Errorf("failed to lookup account jwt for account %s: %w", accountID, err)
+	}
+	if accountJWT == "" {
+		return fmt.Errorf("account jwt for account %s not found", accountID)
+	}
+	claims, err := jwt.DecodeAccountClaims(accountJWT)
+	if err != nil {
+		return fmt.Errorf("failed to decode account jwt for account %s: %w", accountID, err)
+	}
+	return c.printJSON(claims)
+}
+
+// UserJWT prints the decoded user JWT from the credentials Secret of the User.
+func (c *Commands) UserJWT(ctx context.Context, userRef domain.NamespacedName) error {
+	userCreds, err := c.getUserCreds(ctx, userRef)
+	if err != nil {
+		return err
+	}
+	userJWT, err := jwt.ParseDecoratedJWT(userCreds)
+	if err != nil {
+		return fmt.Errorf("failed to parse JWT from credentials of user %s: %w", userRef, err)
+	}
+	claims, err := jwt.DecodeUserClaims(userJWT)
+	if err != nil {
+		return fmt.Errorf("failed to decode user jwt of user %s: %w", userRef, err)
+	}
+	return c.printJSON(claims)
+}
+
+// UserCreds prints the NATS credentials file of the User.
+func (c *Commands) UserCreds(ctx context.Context, userRef domain.NamespacedName) error {
+	userCreds, err := c.getUserCreds(ctx, userRef)
+	if err != nil {
+		return err
+	}
+	_, err = c.out.Write(userCreds)
+	return err
+}
+
+// RepushAccount makes the controller push the account JWT to NATS again on its next reconcile, by setting the repush
+// annotation to the current time.
+func (c *Commands) RepushAccount(ctx context.Context, accountRef domain.NamespacedName) error {
+	account, _, err := c.getReadyAccount(ctx, accountRef)
+	if err != nil {
+		return err
+	}
+	if account.GetLabel(v1alpha1.AccountLabelManagementPolicy) == v1alpha1.AccountManagementPolicyObserve {
+		return fmt.Errorf("account %s is observed, NAuth does not push its JWT", accountRef)
+	}
+
+	patch := client.MergeFrom(account.DeepCopy())
+	annotations := account.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[v1alpha1.AccountAnnotationRepushRequestedAt] = c.now().UTC().Format(time.RFC3339Nano)
+	account.SetAnnotations(annotations)
+	if err := c.client.Patch(ctx, account, patch); err != nil {
+		return fmt.Errorf("failed to request repush of account %s: %w", accountRef, err)
+	}
+	_, err = fmt.Fprintf(c.out, "requested repush of account %s\n", accountRef)
+	return err
+}
+
+// RevokeActivations revokes the activation tokens issued to importer for the export exportName of the Account, by
+// adding a revocation with the current time to its spec. importer is an account public key, "*" for all accounts, or
+// the namespace/name of an Account. The controller pushes the account JWT with the revocation on its next reconcile.
+func (c *Commands) RevokeActivations(ctx context.Context, accountRef domain.NamespacedName, exportName string, importer string) error {
+	account, _, err := c.getReadyAccount(ctx, accountRef)
+	if err != nil {
+		return err
+	}
+	if account.GetLabel(v1alpha1.AccountLabelManagementPolicy) == v1alpha1.AccountManagementPolicyObserve {
+		return fmt.Errorf("account %s is observed, NAuth does not push its JWT", accountRef)
+	}
+	importerID, err := c.resolveImporter(ctx, importer)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(account.DeepCopy())
+	var export *v1alpha1.Export
+	for _, candidate := range account.Spec.Exports {
+		if candidate != nil && candidate.Name == exportName {
+			export = candidate
+			break
+		}
+	}
+	if export == nil {
+		return fmt.Errorf("account %s has no export named %q", accountRef, exportName)
+	}
+	if !export.TokenReq {
+		return fmt.Errorf("export %q of account %s does not require activation tokens", exportName, accountRef)
+	}
+	export.Revoke(importerID, c.now())
+	if err := c.client.Patch(ctx, account, patch); err != nil {
+		return fmt.Errorf("failed to revoke activations of export %q of account %s: %w", exportName, accountRef, err)
+	}
+	_, err = fmt.Fprintf(c.out, "revoked activations of %s for export %q of account %s\n", importerID, exportName, accountRef)
+	return err
+}
+
+// resolveImporter returns the account public key of importer, resolving namespace/name references to Accounts.
+func (c *Commands) resolveImporter(ctx context.Context, importer string) (string, error) {
+	if importer == "*" || nkeys.IsValidPublicAccountKey(importer) {
+		return importer, nil
+	}
+	if !strings.Contains(importer, "/") {
+		return "", fmt.Errorf("importer %q is neither an account public key, \"*\" nor namespace/name of an Account", importer)
+	}
+	importerRef, err := domain.ParseNamespacedName(importer)
+	if err != nil {
+		return "", fmt.Errorf("invalid importer %q: %w", importer, err)
+	}
+	_, accountID, err := c.getReadyAccount(ctx, importerRef)
+	return accountID, err
+}
+
+func (c *Commands) getReadyAccount(ctx context.Context, accountRef domain.NamespacedName) (*v1alpha1.Account, string, error) {
+	if err := accountRef.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid account reference %q: %w", accountRef, err)
+	}
+	account := &v1alpha1.Account{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: accountRef.Namespace, Name: accountRef.Name}, account); err != nil {
+		return nil, "", fmt.Errorf("failed to get account %s: %w", accountRef, err)
+	}
+	accountID := account.GetLabel(v1alpha1.AccountLabelAccountID)
+	if accountID == "" {
+		return nil, "", fmt.Errorf("account %s is not ready", accountRef)
+	}
+	return account, accountID, nil
+}
+
+func (c *Commands) getUserCreds(ctx context.Context, userRef domain.NamespacedName) ([]byte, error) {
+	if err := userRef.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid user reference %q: %w", userRef, err)
+	}
+	user := &v1alpha1.User{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: userRef.Namespace, Name: userRef.Name}, user); err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", userRef, err)
+	}
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: userRef.Namespace, Name: user.GetUserSecretName()}
+	if err := c.client.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to get credentials secret of user %s: %w", userRef, err)
+	}
+	// Bearer users have no credentials file, only the JWT
+	credsKey := k8s.UserCredentialSecretKeyName
+	if user.Spec.Bearer != nil {
+		credsKey = k8s.UserJWTSecretKeyName
+	}
+	userCreds := secret.Data[credsKey]
+	if len(userCreds) == 0 {
+		return nil, fmt.Errorf("credentials secret %s of user %s has no %q key", secretKey.Name, userRef, credsKey)
+	}
+	return userCreds, nil
+}
+
+func (c *Commands) printJSON(value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	_, err = fmt.Fprintln(c.out, string(data))
+	return err
+}
@@ -0,0 +1,417 @@
+package nauthctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "team"
+
+func TestCommands_UserJWT_ShouldPrintDecodedClaims(t *testing.T) {
+	user, userCreds := newTestUser(t, testutil.NatsTestAccountA)
+	commands, out := newTestCommands(t, nil, user, newUserCredsSecret(user, userCreds))
+
+	err := commands.UserJWT(context.Background(), domain.NewNamespacedName(testNamespace, user.Name))
+
+	require.NoError(t, err)
+	require.Contains(t, out.String(), `"sub": "`+user.GetLabel(v1alpha1.UserLabelUserID)+`"`)
+}
+
+func TestCommands_UserCreds_ShouldPrintCredentials(t *testing.T) {
+	user, userCreds := newTestUser(t, testutil.NatsTestAccountA)
+	commands, out := newTestCommands(t, nil, user, newUserCredsSecret(user, userCreds))
+
+	err := commands.UserCreds(context.Background(), domain.NewNamespacedName(testNamespace, user.Name))
+
+	require.NoError(t, err)
+	require.Equal(t, string(userCreds), out.String())
+}
+
+func TestCommands_UserCreds_ShouldFail_WhenSecretIsMissing(t *testing.T) {
+	user, _ := newTestUser(t, testutil.NatsTestAccountA)
+	commands, _ := newTestCommands(t, nil, user)
+
+	err := commands.UserCreds(context.Background(), domain.NewNamespacedName(testNamespace, user.Name))
+
+	require.ErrorContains(t, err, "failed to get credentials secret of user team/app")
+}
+
+func TestCommands_UserCreds_ShouldPrintJWT_WhenUserIsBearer(t *testing.T) {
+	user, userJWT := newTestBearerUser(t, testutil.NatsTestAccountA)
+	commands, out := newTestCommands(t, nil, user, newBearerUserSecret(user, userJWT))
+
+	err := commands.UserCreds(context.Background(), domain.NewNamespacedName(testNamespace, user.Name))
+
+	require.NoError(t, err)
+	require.Equal(t, userJWT, out.String())
+}
+
+func TestCommands_AccountJWT_ShouldPrintClaimsFromNats(t *testing.T) {
+	account := newTestAccount(testutil.NatsTestAccountA)
+	claims := jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID())
+	claims.Name = "team/orders"
+	accountJWT, err := claims.Encode(testutil.NatsTestOperatorA.Sign.Key)
+	require.NoError(t, err)
+	clusterRef := nauth.ClusterRef("nats/cluster")
+	nats := &fakeNats{
+		targets:     map[nauth.ClusterRef]*nauth.ClusterTarget{clusterRef: {NatsURL: "nats://nats:4222"}},
+		accountJWTs: map[string]string{testutil.NatsTestAccountA.AccountID(): accountJWT},
+	}
+	commands, out := newTestCommands(t, nats, account)
+
+	err = commands.AccountJWT(context.Background(), domain.NewNamespacedName(testNamespace, account.Name), &clusterRef)
+
+	require.NoError(t, err)
+	require.Contains(t, out.String(), `"name": "team/orders"`)
+	require.True(t, nats.disconnected)
+}
+
+func TestCommands_AccountJWT_ShouldFail_WhenNoClusterIsKnown(t *testing.T) {
+	account := newTestAccount(testutil.NatsTestAccountA)
+	commands, _ := newTestCommands(t, nil, account)
+
+	err := commands.AccountJWT(context.Background(), domain.NewNamespacedName(testNamespace, account.Name), nil)
+
+	require.ErrorContains(t, err, "does not reference a NatsCluster and no default cluster is configured")
+}
+
+func TestCommands_RepushAccount_ShouldRequestRepush(t *testing.T) {
+	account := newTestAccount(testutil.NatsTestAccountA)
+	account.Status.ClaimsHash = "hash"
+	commands, out := newTestCommands(t, nil, account)
+	commands.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	err := commands.RepushAccount(context.Background(), domain.NewNamespacedName(testNamespace, account.Name))
+
+	require.NoError(t, err)
+	result := &v1alpha1.Account{}
+	require.NoError(t, commands.client.Get(context.Background(), client.ObjectKeyFromObject(account), result))
+	require.Equal(t, "hash", result.Status.ClaimsHash)
+	require.Equal(t, "2025-01-02T03:04:05Z", result.GetAnnotations()[v1alpha1.AccountAnnotationRepushRequestedAt])
+	require.Equal(t, "requested repush of account team/orders\n", out.String())
+}
+
+func TestCommands_RepushAccount_ShouldFail_WhenAccountIsObserved(t *testing.T) {
+	account := newTestAccount(testutil.NatsTestAccountA)
+	account.SetLabel(v1alpha1.AccountLabelManagementPolicy, v1alpha1.AccountManagementPolicyObserve)
+	commands, _ := newTestCommands(t, nil, account)
+
+	err := commands.RepushAccount(context.Background(), domain.NewNamespacedName(testNamespace, account.Name))
+
+	require.ErrorContains(t, err, "account team/orders is observed")
+}
+
+func TestCommands_RevokeActivations_ShouldAddRevocationOfImportingAccount(t *testing.T) {
+	account := newTestAccount(testutil.NatsTestAccountA)
+	account.Spec.Exports = v1alpha1.Exports{
+		{Name: "events", Subject: "orders.events.>", Type: v1alpha1.Stream, TokenReq: true},
+		{Name: "orders", Subject: "orders.>", Type: v1alpha1.Stream, TokenReq: true,
+			Revocations: v1alpha1.RevocationList{"*": 1700000000}},
+	}
+	importerAccount := testutil.CreateNatsTestAccount()
+	importer := newTestAccount(importerAccount)
+	importer.Name = "billing"
+	commands, out := newTestCommands(t, nil, account, importer)
+	commands.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	err := commands.RevokeActivations(context.Background(), domain.NewNamespacedName(testNamespace, account.Name),
+		"orders", testNamespace+"/billing")
+
+	require.NoError(t, err)
+	result := &v1alpha1.Account{}
+	require.NoError(t, commands.client.Get(context.Background(), client.ObjectKeyFromObject(account), result))
+	require.Empty(t, result.Spec.Exports[0].Revocations)
+	require.Equal(t, v1alpha1.RevocationList{
+		"*":                         1700000000,
+		importerAccount.AccountID(): time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC).Unix(),
+	}, result.Spec.Exports[1].Revocations)
+	require.Equal(t, "revoked activations of "+importerAccount.AccountID()+
+		" for export \"orders\" of account team/orders\n", out.String())
+}
+
+func TestCommands_RevokeActivations_ShouldFail(t *testing.T) {
+	testCases := []struct {
+		name       string
+		exportName string
+		importer   string
+		expectErr  string
+	}{
+		{name: "unknown export", exportName: "missing", importer: "*",
+			expectErr: `account team/orders has no export named "missing"`},
+		{name: "export without activation tokens", exportName: "public", importer: "*",
+			expectErr: `export "public" of account team/orders does not require activation tokens`},
+		{name: "invalid importer", exportName: "orders", importer: "billing",
+			expectErr: `importer "billing" is neither an account public key`},
+		{name: "importer account not ready", exportName: "orders", importer: testNamespace + "/billing",
+			expectErr: "failed to get account team/billing"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := newTestAccount(testutil.NatsTestAccountA)
+			account.Spec.Exports = v1alpha1.Exports{
+				{Name: "orders", Subject: "orders.>", Type: v1alpha1.Stream, TokenReq: true},
+				{Name: "public", Subject: "public.>", Type: v1alpha1.Stream},
+			}
+			commands, _ := newTestCommands(t, nil, account)
+
+			err := commands.RevokeActivations(context.Background(), domain.NewNamespacedName(testNamespace, account.Name),
+				tc.exportName, tc.importer)
+
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestCommands_ValidateSecrets(t *testing.T) {
+	otherAccount := testutil.CreateNatsTestAccount()
+
+	tests := []struct {
+		name           string
+		rootSeed       []byte
+		userAccount    testutil.NatsTestAccount
+		expectProblems []string
+	}{
+		{
+			name:        "matching_secrets",
+			rootSeed:    testutil.NatsTestAccountA.Root.Seed,
+			userAccount: testutil.NatsTestAccountA,
+		},
+		{
+			name:        "root_seed_of_other_account",
+			rootSeed:    otherAccount.Root.Seed,
+			userAccount: testutil.NatsTestAccountA,
+			expectProblems: []string{
+				"account team/orders: secret orders-ac-root: root seed is for " + otherAccount.AccountID() +
+					", labelled " + testutil.NatsTestAccountA.AccountID(),
+			},
+		},
+		{
+			name:        "user_signed_by_other_account",
+			rootSeed:    testutil.NatsTestAccountA.Root.Seed,
+			userAccount: otherAccount,
+			expectProblems: []string{
+				"user team/app: labelled with account " + otherAccount.AccountID() + ", account orders has ID " +
+					testutil.NatsTestAccountA.AccountID(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newTestAccount(testutil.NatsTestAccountA)
+			user, userCreds := newTestUser(t, tt.userAccount)
+			commands, out := newTestCommands(t, nil,
+				account,
+				newAccountSecret("orders-ac-root", k8s.SecretTypeAccountRoot, tt.rootSeed),
+				newAccountSecret("orders-ac-sign", k8s.SecretTypeAccountSign, testutil.NatsTestAccountA.Sign.Seed),
+				user,
+				newUserCredsSecret(user, userCreds),
+			)
+
+			err := commands.ValidateSecrets(context.Background(), testNamespace)
+
+			if len(tt.expectProblems) == 0 {
+				require.NoError(t, err)
+				require.Equal(t, "validated 1 accounts and 1 users\n", out.String())
+				return
+			}
+			require.ErrorIs(t, err, ErrValidationFailed)
+			for _, problem := range tt.expectProblems {
+				require.Contains(t, out.String(), problem)
+			}
+		})
+	}
+}
+
+func TestCommands_ValidateSecrets_ShouldAcceptBearerUserWithoutSeed(t *testing.T) {
+	user, userJWT := newTestBearerUser(t, testutil.NatsTestAccountA)
+	commands, out := newTestCommands(t, nil,
+		newTestAccount(testutil.NatsTestAccountA),
+		newAccountSecret("orders-ac-root", k8s.SecretTypeAccountRoot, testutil.NatsTestAccountA.Root.Seed),
+		newAccountSecret("orders-ac-sign", k8s.SecretTypeAccountSign, testutil.NatsTestAccountA.Sign.Seed),
+		user,
+		newBearerUserSecret(user, userJWT),
+	)
+
+	err := commands.ValidateSecrets(context.Background(), testNamespace)
+
+	require.NoError(t, err)
+	require.Equal(t, "validated 1 accounts and 1 users\n", out.String())
+}
+
+func newTestCommands(t *testing.T, nats *fakeNats, objects ...client.Object) (*Commands, *bytes.Buffer) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.Account{}).
+		WithObjects(objects...).
+		Build()
+	if nats == nil {
+		nats = &fakeNats{}
+	}
+	out := &bytes.Buffer{}
+	commands, err := NewCommands(k8sClient, nats, nats, out)
+	require.NoError(t, err)
+	return commands, out
+}
+
+func newTestAccount(account testutil.NatsTestAccount) *v1alpha1.Account {
+	result := &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: testNamespace},
+		Status: v1alpha1.AccountStatus{
+			Claims: &v1alpha1.AccountClaims{
+				SigningKeys: v1alpha1.SigningKeys{{Key: account.Sign.PublicKey}},
+			},
+		},
+	}
+	result.SetLabel(v1alpha1.AccountLabelAccountID, account.AccountID())
+	return result
+}
+
+func newAccountSecret(name string, secretType string, seed []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				string(v1alpha1.AccountLabelAccountID): testutil.NatsTestAccountA.AccountID(),
+				k8s.LabelSecretType:                    secretType,
+				k8s.LabelManaged:                       k8s.LabelManagedValue,
+			},
+		},
+		Data: map[string][]byte{k8s.DefaultSecretKeyName: seed},
+	}
+}
+
+func newTestUser(t *testing.T, account testutil.NatsTestAccount) (*v1alpha1.User, []byte) {
+	t.Helper()
+	userKey := testutil.CreateNatsTestUserKey()
+	claims := jwt.NewUserClaims(userKey.PublicKey)
+	claims.IssuerAccount = account.AccountID()
+	userJWT, err := claims.Encode(account.Sign.Key)
+	require.NoError(t, err)
+	userCreds, err := jwt.FormatUserConfig(userJWT, userKey.Seed)
+	require.NoError(t, err)
+
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: testNamespace},
+		Spec:       v1alpha1.UserSpec{AccountName: "orders"},
+	}
+	user.SetLabel(v1alpha1.UserLabelUserID, userKey.PublicKey)
+	user.SetLabel(v1alpha1.UserLabelAccountID, account.AccountID())
+	return user, userCreds
+}
+
+func newUserCredsSecret(user *v1alpha1.User, userCreds []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: user.GetUserSecretName(), Namespace: user.GetNamespace()},
+		Data:       map[string][]byte{k8s.UserCredentialSecretKeyName: userCreds},
+	}
+}
+
+func newTestBearerUser(t *testing.T, account testutil.NatsTestAccount) (*v1alpha1.User, string) {
+	t.Helper()
+	userKey := testutil.CreateNatsTestUserKey()
+	claims := jwt.NewUserClaims(userKey.PublicKey)
+	claims.IssuerAccount = account.AccountID()
+	claims.BearerToken = true
+	userJWT, err := claims.Encode(account.Sign.Key)
+	require.NoError(t, err)
+
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: testNamespace},
+		Spec:       v1alpha1.UserSpec{AccountName: "orders", Bearer: &v1alpha1.BearerCredentials{}},
+	}
+	user.SetLabel(v1alpha1.UserLabelUserID, userKey.PublicKey)
+	user.SetLabel(v1alpha1.UserLabelAccountID, account.AccountID())
+	return user, userJWT
+}
+
+func newBearerUserSecret(user *v1alpha1.User, userJWT string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: user.GetUserSecretName(), Namespace: user.GetNamespace()},
+		Data:       map[string][]byte{k8s.UserJWTSecretKeyName: []byte(userJWT)},
+	}
+}
+
+// fakeNats serves cluster targets and account JWTs from memory.
+type fakeNats struct {
+	targets      map[nauth.ClusterRef]*nauth.ClusterTarget
+	accountJWTs  map[string]string
+	disconnected bool
+}
+
+func (f *fakeNats) GetTarget(_ context.Context, clusterRef nauth.ClusterRef) (*nauth.ClusterTarget, error) {
+	target, ok := f.targets[clusterRef]
+	if !ok {
+		return nil, domain.ErrBadRequest
+	}
+	return target, nil
+}
+
+func (f *fakeNats) Connect(context.Context, string, domain.NatsUserCreds, *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	return f, nil
+}
+
+func (f *fakeNats) Disconnect() {
+	f.disconnected = true
+}
+
+func (f *fakeNats) EnsureConnected() error {
+	return nil
+}
+
+func (f *fakeNats) VerifySystemAccountAccess() error {
+	return nil
+}
+
+func (f *fakeNats) PingServers() ([]domain.NatsServerInfo, error) {
+	return nil, nil
+}
+
+func (f *fakeNats) AccountUsage(string) (*domain.NatsAccountUsage, error) {
+	return &domain.NatsAccountUsage{}, nil
+}
+
+func (f *fakeNats) LookupAccountJWT(accountID string) (string, error) {
+	return f.accountJWTs[accountID], nil
+}
+
+func (f *fakeNats) UploadAccountJWT(string) error {
+	return nil
+}
+
+func (f *fakeNats) DeleteAccountJWT(string) error {
+	return nil
+}
+
+func (f *fakeNats) MaxPayload() int64 {
+	return 0
+}
+
+func (f *fakeNats) ResolverStore(domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	return f, nil
+}
+
+var _ outbound.ClusterReader = (*fakeNats)(nil)
+var _ outbound.NatsSysClient = (*fakeNats)(nil)
+var _ outbound.NatsSysConnection = (*fakeNats)(nil)
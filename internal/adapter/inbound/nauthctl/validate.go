@@ -0,0 +1,197 @@
+package nauthctl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateSecrets checks that the account and user Secrets in namespace, or in all namespaces when namespace is empty,
+// hold the keys their resources are labelled with. Every mismatch is printed, ErrValidationFailed is returned if any
+// mismatch was found.
+func (c *Commands) ValidateSecrets(ctx context.Context, namespace domain.Namespace) error {
+	accounts := &v1alpha1.AccountList{}
+	if err := c.client.List(ctx, accounts, client.InNamespace(string(namespace))); err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+	users := &v1alpha1.UserList{}
+	if err := c.client.List(ctx, users, client.InNamespace(string(namespace))); err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var problems []string
+	accountIDs := make(map[domain.NamespacedName]string, len(accounts.Items))
+	for i := range accounts.Items {
+		account := &accounts.Items[i]
+		accountRef := domain.NewNamespacedName(account.GetNamespace(), account.GetName())
+		accountID := account.GetLabel(v1alpha1.AccountLabelAccountID)
+		if accountID == "" {
+			continue
+		}
+		accountIDs[accountRef] = accountID
+		accountProblems, err := c.validateAccountSecrets(ctx, account, accountID)
+		if err != nil {
+			return err
+		}
+		for _, problem := range accountProblems {
+			problems = append(problems, fmt.Sprintf("account %s: %s", accountRef, problem))
+		}
+	}
+	for i := range users.Items {
+		user := &users.Items[i]
+		userRef := domain.NewNamespacedName(user.GetNamespace(), user.GetName())
+		if user.GetLabel(v1alpha1.UserLabelUserID) == "" {
+			continue
+		}
+		accountRef := user.GetAccountRef()
+		accountID := accountIDs[domain.NewNamespacedName(accountRef.Namespace, accountRef.Name)]
+		userProblems, err := c.validateUserSecret(ctx, user, accountID)
+		if err != nil {
+			return err
+		}
+		for _, problem := range userProblems {
+			problems = append(problems, fmt.Sprintf("user %s: %s", userRef, problem))
+		}
+	}
+
+	for _, problem := range problems {
+		if _, err := fmt.Fprintln(c.out, problem); err != nil {
+			return err
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %d problems found", ErrValidationFailed, len(problems))
+	}
+	_, err := fmt.Fprintf(c.out, "validated %d accounts and %d users\n", len(accountIDs), len(users.Items))
+	return err
+}
+
+func (c *Commands) validateAccountSecrets(ctx context.Context, account *v1alpha1.Account, accountID string) ([]string, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.client.List(ctx, secrets, client.InNamespace(account.GetNamespace()), client.MatchingLabels{
+		string(v1alpha1.AccountLabelAccountID): accountID,
+		k8s.LabelManaged:                       k8s.LabelManagedValue,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list secrets of account %s/%s: %w", account.GetNamespace(), account.GetName(), err)
+	}
+
+	var signingKeys []string
+	if account.Status.Claims != nil {
+		for _, signingKey := range account.Status.Claims.SigningKeys {
+			if signingKey != nil {
+				signingKeys = append(signingKeys, signingKey.Key)
+			}
+		}
+	}
+
+	var problems []string
+	found := make(map[string]bool, 2)
+	for _, secret := range secrets.Items {
+		secretType := secret.GetLabels()[k8s.LabelSecretType]
+		publicKey, err := publicKeyFromSeed(secret.Data[k8s.DefaultSecretKeyName])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("secret %s: %s", secret.GetName(), err))
+			continue
+		}
+		switch secretType {
+		case k8s.SecretTypeAccountRoot:
+			if publicKey != accountID {
+				problems = append(problems, fmt.Sprintf("secret %s: root seed is for %s, labelled %s", secret.GetName(), publicKey, accountID))
+			}
+		case k8s.SecretTypeAccountSign:
+			if signingKeys != nil && !slices.Contains(signingKeys, publicKey) {
+				problems = append(problems, fmt.Sprintf("secret %s: signing seed %s is not a signing key of the account", secret.GetName(), publicKey))
+			}
+		default:
+			continue
+		}
+		if found[secretType] {
+			problems = append(problems, fmt.Sprintf("secret %s: multiple secrets of type %s", secret.GetName(), secretType))
+		}
+		found[secretType] = true
+	}
+	for _, secretType := range []string{k8s.SecretTypeAccountRoot, k8s.SecretTypeAccountSign} {
+		if !found[secretType] {
+			problems = append(problems, fmt.Sprintf("no secret of type %s labelled with account ID %s", secretType, accountID))
+		}
+	}
+	return problems, nil
+}
+
+func (c *Commands) validateUserSecret(ctx context.Context, user *v1alpha1.User, accountID string) ([]string, error) {
+	userID := user.GetLabel(v1alpha1.UserLabelUserID)
+	userAccountID := user.GetLabel(v1alpha1.UserLabelAccountID)
+
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: user.GetNamespace(), Name: user.GetUserSecretName()}
+	if err := c.client.Get(ctx, secretKey, secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return []string{fmt.Sprintf("credentials secret %s not found", secretKey.Name)}, nil
+		}
+		return nil, fmt.Errorf("failed to get credentials secret %s: %w", secretKey, err)
+	}
+	var problems []string
+	var claims *jwt.UserClaims
+	if user.Spec.Bearer != nil {
+		// Bearer users have no seed, their Secret holds only the JWT
+		var err error
+		claims, err = jwt.DecodeUserClaims(string(secret.Data[k8s.UserJWTSecretKeyName]))
+		if err != nil {
+			return []string{fmt.Sprintf("secret %s: invalid user JWT: %s", secretKey.Name, err)}, nil
+		}
+		if !claims.BearerToken {
+			problems = append(problems, fmt.Sprintf("secret %s: JWT is not a bearer JWT", secretKey.Name))
+		}
+	} else {
+		userCreds := secret.Data[k8s.UserCredentialSecretKeyName]
+		userJWT, err := jwt.ParseDecoratedJWT(userCreds)
+		if err != nil {
+			return []string{fmt.Sprintf("secret %s: invalid credentials: %s", secretKey.Name, err)}, nil
+		}
+		claims, err = jwt.DecodeUserClaims(userJWT)
+		if err != nil {
+			return []string{fmt.Sprintf("secret %s: invalid user JWT: %s", secretKey.Name, err)}, nil
+		}
+		userKeyPair, err := jwt.ParseDecoratedUserNKey(userCreds)
+		if err != nil {
+			return []string{fmt.Sprintf("secret %s: invalid user seed: %s", secretKey.Name, err)}, nil
+		}
+		seedPublicKey, err := userKeyPair.PublicKey()
+		if err != nil {
+			return []string{fmt.Sprintf("secret %s: invalid user seed: %s", secretKey.Name, err)}, nil
+		}
+		if seedPublicKey != claims.Subject {
+			problems = append(problems, fmt.Sprintf("secret %s: seed is for %s, JWT is for %s", secretKey.Name, seedPublicKey, claims.Subject))
+		}
+	}
+
+	if claims.Subject != userID {
+		problems = append(problems, fmt.Sprintf("secret %s: JWT is for user %s, labelled %s", secretKey.Name, claims.Subject, userID))
+	}
+	if claims.IssuerAccount != userAccountID {
+		problems = append(problems, fmt.Sprintf("secret %s: JWT is issued for account %s, labelled %s", secretKey.Name, claims.IssuerAccount, userAccountID))
+	}
+	if accountID != "" && userAccountID != accountID {
+		problems = append(problems, fmt.Sprintf("labelled with account %s, account %s has ID %s", userAccountID, user.GetAccountRef().Name, accountID))
+	}
+	return problems, nil
+}
+
+func publicKeyFromSeed(seed []byte) (string, error) {
+	if len(seed) == 0 {
+		return "", fmt.Errorf("no %q key", k8s.DefaultSecretKeyName)
+	}
+	keyPair, err := nkeys.FromSeed(seed)
+	if err != nil {
+		return "", fmt.Errorf("invalid seed: %w", err)
+	}
+	return keyPair.PublicKey()
+}
@@ -0,0 +1,72 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const Path = "/version"
+
+// NewHandler serves the operator capabilities as JSON.
+func NewHandler(capabilities nauth.Capabilities) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(capabilities); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// Publisher writes the operator capabilities once, when the manager starts and the instance is elected leader.
+type Publisher struct {
+	writer       outbound.CapabilitiesWriter
+	capabilities nauth.Capabilities
+}
+
+func NewPublisher(writer outbound.CapabilitiesWriter, capabilities nauth.Capabilities) (*Publisher, error) {
+	if writer == nil {
+		return nil, fmt.Errorf("capabilities writer is required")
+	}
+	if err := capabilities.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid capabilities: %w", err)
+	}
+	return &Publisher{
+		writer:       writer,
+		capabilities: capabilities,
+	}, nil
+}
+
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthversions,verbs=get;create
+// +kubebuilder:rbac:groups=nauth.io,resources=nauthversions/status,verbs=get;update
+
+// Start publishes the capabilities. Failing to publish is logged but never stops the manager, as installations
+// without cluster-wide permissions cannot write the cluster-scoped NauthVersion resource.
+func (p *Publisher) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("version-publisher")
+	if err := p.writer.WriteCapabilities(ctx, p.capabilities); err != nil {
+		log.Error(err, "Failed to publish NauthVersion", "name", p.capabilities.OperatorNamespace)
+		return nil
+	}
+	log.Info("Published NauthVersion", "name", p.capabilities.OperatorNamespace,
+		"operatorVersion", p.capabilities.OperatorVersion)
+	return nil
+}
+
+func (p *Publisher) NeedLeaderElection() bool {
+	return true
+}
+
+var _ manager.Runnable = (*Publisher)(nil)
+var _ manager.LeaderElectionRunnable = (*Publisher)(nil)
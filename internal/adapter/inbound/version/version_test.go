@@ -0,0 +1,83 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCapabilities = nauth.Capabilities{
+	OperatorVersion:   "1.2.3",
+	OperatorNamespace: "nauth",
+	FeatureGates:      map[string]bool{"StrictCryptoPolicy": true},
+	APIVersions:       []string{"nauth.io/v1alpha1"},
+	ProviderTypes:     []string{"nats-resolver"},
+	CryptoPolicy:      "strict",
+}
+
+type capabilitiesWriterStub struct {
+	written *nauth.Capabilities
+	err     error
+}
+
+func (s *capabilitiesWriterStub) WriteCapabilities(_ context.Context, capabilities nauth.Capabilities) error {
+	s.written = &capabilities
+	return s.err
+}
+
+func Test_Handler(t *testing.T) {
+	t.Run("should_serve_capabilities_as_json", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		NewHandler(testCapabilities).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path, nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		result := nauth.Capabilities{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.Equal(t, testCapabilities, result)
+	})
+
+	t.Run("should_reject_other_methods", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		NewHandler(testCapabilities).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func Test_Publisher(t *testing.T) {
+	t.Run("should_write_capabilities_on_start", func(t *testing.T) {
+		writer := &capabilitiesWriterStub{}
+		publisher, err := NewPublisher(writer, testCapabilities)
+		require.NoError(t, err)
+
+		require.NoError(t, publisher.Start(context.Background()))
+
+		require.NotNil(t, writer.written)
+		assert.Equal(t, testCapabilities, *writer.written)
+		assert.True(t, publisher.NeedLeaderElection())
+	})
+
+	t.Run("should_not_fail_start_when_write_fails", func(t *testing.T) {
+		writer := &capabilitiesWriterStub{err: errors.New("forbidden")}
+		publisher, err := NewPublisher(writer, testCapabilities)
+		require.NoError(t, err)
+
+		assert.NoError(t, publisher.Start(context.Background()))
+	})
+
+	t.Run("should_fail_when_capabilities_are_invalid", func(t *testing.T) {
+		_, err := NewPublisher(&capabilitiesWriterStub{}, nauth.Capabilities{})
+
+		assert.ErrorContains(t, err, "invalid capabilities: operator namespace is required")
+	})
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	recordResultSuccess = "success"
+	recordResultError   = "error"
+)
+
+var recordsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "nauth",
+	Subsystem: "audit",
+	Name:      "records_total",
+	Help:      "Number of audit records written to the audit sink, by result.",
+}, []string{"result"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(recordsTotal)
+}
@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Sink writes serialized audit records.
+type Sink interface {
+	Write(ctx context.Context, record []byte) error
+}
+
+// Record is an audit event as written to a Sink. Records are chained: Hash is the hex encoded SHA-256 of the record
+// serialized without Hash, and PreviousHash is the Hash of the record before it. A removed, reordered or modified
+// record breaks the chain.
+type Record struct {
+	Sequence uint64    `json:"sequence"`
+	Time     time.Time `json:"time"`
+	domain.AuditEvent
+	PreviousHash string `json:"previousHash"`
+	Hash         string `json:"hash,omitempty"`
+}
+
+// Recorder records audit events to a Sink as a hash chain. The chain starts with sequence 1 when the operator starts.
+type Recorder struct {
+	sink Sink
+	now  func() time.Time
+
+	mu           sync.Mutex
+	sequence     uint64
+	previousHash string
+}
+
+func NewRecorder(sink Sink) (*Recorder, error) {
+	r := &Recorder{
+		sink: sink,
+		now:  time.Now,
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recorder) validate() error {
+	if r.sink == nil {
+		return fmt.Errorf("audit sink is required")
+	}
+	return nil
+}
+
+// Record writes event to the sink. Records are written one at a time, in the order of their sequence. A record that
+// cannot be written is logged and counted, and leaves a gap in the chain.
+func (r *Recorder) Record(ctx context.Context, event domain.AuditEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sequence++
+	record := Record{
+		Sequence:     r.sequence,
+		Time:         r.now().UTC(),
+		AuditEvent:   event,
+		PreviousHash: r.previousHash,
+	}
+	data, err := sealRecord(&record)
+	if err == nil {
+		r.previousHash = record.Hash
+		err = r.sink.Write(ctx, data)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to write audit record",
+			"sequence", record.Sequence, "action", event.Action, "subject", event.Subject)
+		recordsTotal.WithLabelValues(recordResultError).Inc()
+		return
+	}
+	recordsTotal.WithLabelValues(recordResultSuccess).Inc()
+}
+
+// sealRecord sets the hash of record and returns its serialized form.
+func sealRecord(record *Record) ([]byte, error) {
+	record.Hash = ""
+	unsealed, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize audit record: %w", err)
+	}
+	hash := sha256.Sum256(unsealed)
+	record.Hash = hex.EncodeToString(hash[:])
+	return json.Marshal(record)
+}
+
+// VerifyChain verifies that records, in the order they were written, form an unbroken hash chain.
+func VerifyChain(records []Record) error {
+	for i, record := range records {
+		expectedHash := record.Hash
+		if _, err := sealRecord(&record); err != nil {
+			return err
+		}
+		if record.Hash != expectedHash {
+			return fmt.Errorf("audit record %d was modified", record.Sequence)
+		}
+		if i > 0 && (record.PreviousHash != records[i-1].Hash || record.Sequence != records[i-1].Sequence+1) {
+			return fmt.Errorf("audit record %d does not follow record %d", record.Sequence, records[i-1].Sequence)
+		}
+	}
+	return nil
+}
+
+type discardRecorder struct{}
+
+func (discardRecorder) Record(context.Context, domain.AuditEvent) {}
+
+// Discard is an AuditRecorder that records nothing, used when no audit sink is configured.
+var Discard outbound.AuditRecorder = discardRecorder{}
+
+var _ outbound.AuditRecorder = (*Recorder)(nil)
@@ -0,0 +1,136 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecorder_ShouldFail_WhenSinkMissing(t *testing.T) {
+	_, err := NewRecorder(nil)
+
+	assert.ErrorContains(t, err, "audit sink is required")
+}
+
+func TestRecorder_Record_ShouldWriteHashChain(t *testing.T) {
+	var output bytes.Buffer
+	recorder := newTestRecorder(t, NewWriterSink(&output))
+
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionAccountKeysCreated, Subject: "A1"})
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionAccountJWTPushed, Subject: "A1"})
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionAccountJWTDeleted, Subject: "A1"})
+
+	records := readRecords(t, output.String())
+	require.Len(t, records, 3)
+	assert.Equal(t, uint64(1), records[0].Sequence)
+	assert.Empty(t, records[0].PreviousHash)
+	assert.Equal(t, domain.AuditActionAccountJWTPushed, records[1].Action)
+	assert.Equal(t, "A1", records[1].Subject)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), records[2].Time)
+	assert.NoError(t, VerifyChain(records))
+}
+
+func TestVerifyChain_ShouldFail_WhenRecordIsTamperedWith(t *testing.T) {
+	testCases := []struct {
+		testName string
+		tamper   func(records []Record) []Record
+		expected string
+	}{
+		{
+			testName: "modified_record",
+			tamper: func(records []Record) []Record {
+				records[1].Subject = "A2"
+				return records
+			},
+			expected: "audit record 2 was modified",
+		},
+		{
+			testName: "removed_record",
+			tamper: func(records []Record) []Record {
+				return append(records[:1], records[2:]...)
+			},
+			expected: "audit record 3 does not follow record 1",
+		},
+		{
+			testName: "reordered_records",
+			tamper: func(records []Record) []Record {
+				records[1], records[2] = records[2], records[1]
+				return records
+			},
+			expected: "audit record 3 does not follow record 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			var output bytes.Buffer
+			recorder := newTestRecorder(t, NewWriterSink(&output))
+			for range 3 {
+				recorder.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionUserJWTSigned, Subject: "A1"})
+			}
+			records := readRecords(t, output.String())
+
+			assert.EqualError(t, VerifyChain(tc.tamper(records)), tc.expected)
+		})
+	}
+}
+
+func TestRecorder_Record_ShouldLeaveGap_WhenSinkFails(t *testing.T) {
+	var output bytes.Buffer
+	sink := &failingOnceSink{Sink: NewWriterSink(&output)}
+	recorder := newTestRecorder(t, sink)
+
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionUserCredentialsCreated})
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionUserCredentialsDeleted})
+
+	records := readRecords(t, output.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(2), records[0].Sequence)
+	assert.NotEmpty(t, records[0].PreviousHash, "the record should chain to the lost record")
+}
+
+func TestDiscard_ShouldRecordNothing(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Discard.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionUserJWTSigned})
+	})
+}
+
+func newTestRecorder(t *testing.T, sink Sink) *Recorder {
+	t.Helper()
+	recorder, err := NewRecorder(sink)
+	require.NoError(t, err)
+	recorder.now = func() time.Time { return time.Date(2026, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600)) }
+	return recorder
+}
+
+func readRecords(t *testing.T, output string) []Record {
+	t.Helper()
+	var records []Record
+	for line := range strings.Lines(output) {
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+type failingOnceSink struct {
+	Sink
+	failed bool
+}
+
+func (s *failingOnceSink) Write(ctx context.Context, record []byte) error {
+	if !s.failed {
+		s.failed = true
+		return errors.New("sink unavailable")
+	}
+	return s.Sink.Write(ctx, record)
+}
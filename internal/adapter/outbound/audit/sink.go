@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DefaultNatsSubject is the subject audit records are published to when none is configured.
+	DefaultNatsSubject = "nauth.audit"
+
+	publishTimeout = 5 * time.Second
+)
+
+// WriterSink writes audit records as JSON lines, for example to stdout for collection as a log stream.
+type WriterSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func NewWriterSink(writer io.Writer) *WriterSink {
+	return &WriterSink{writer: writer}
+}
+
+func (s *WriterSink) Write(_ context.Context, record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(append(record, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// NatsSink publishes audit records to a NATS subject. With JetStream, every record is acknowledged by the stream
+// capturing the subject.
+type NatsSink struct {
+	conn      *nats.Conn
+	jetStream jetstream.JetStream
+	subject   string
+}
+
+// NewNatsSink connects to natsURL, authenticating with the credentials file credsFile unless it is empty.
+func NewNatsSink(natsURL string, credsFile string, subject string, useJetStream bool) (*NatsSink, error) {
+	if natsURL == "" {
+		return nil, fmt.Errorf("NATS URL of the audit sink is required")
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("NATS subject of the audit sink is required")
+	}
+
+	options := []nats.Option{
+		nats.Name("nauth-audit"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(time.Second),
+	}
+	if credsFile != "" {
+		options = append(options, nats.UserCredentials(credsFile))
+	}
+	conn, err := nats.Connect(natsURL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS for the audit sink: %w", err)
+	}
+
+	s := &NatsSink{conn: conn, subject: subject}
+	if useJetStream {
+		s.jetStream, err = jetstream.New(conn)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to create JetStream context for the audit sink: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *NatsSink) Write(ctx context.Context, record []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if s.jetStream != nil {
+		if _, err := s.jetStream.Publish(ctx, s.subject, record); err != nil {
+			return fmt.Errorf("failed to publish audit record to JetStream subject %s: %w", s.subject, err)
+		}
+		return nil
+	}
+	if err := s.conn.Publish(s.subject, record); err != nil {
+		return fmt.Errorf("failed to publish audit record to subject %s: %w", s.subject, err)
+	}
+	if err := s.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush audit record to subject %s: %w", s.subject, err)
+	}
+	return nil
+}
+
+// Close drains the NATS connection.
+func (s *NatsSink) Close() {
+	if err := s.conn.Drain(); err != nil {
+		s.conn.Close()
+	}
+}
+
+var _ Sink = (*WriterSink)(nil)
+var _ Sink = (*NatsSink)(nil)
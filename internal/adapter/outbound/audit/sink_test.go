@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNatsSink_ShouldFail_WhenConfigurationMissing(t *testing.T) {
+	testCases := []struct {
+		testName string
+		natsURL  string
+		subject  string
+		expected string
+	}{
+		{testName: "missing_url", subject: DefaultNatsSubject, expected: "NATS URL of the audit sink is required"},
+		{testName: "missing_subject", natsURL: "nats://localhost:4222", expected: "NATS subject of the audit sink is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			_, err := NewNatsSink(tc.natsURL, "", tc.subject, false)
+
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestNatsSink_Write_ShouldPublishToSubject(t *testing.T) {
+	server := runNatsServer(t)
+	nc, err := nats.Connect(server.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	subscription, err := nc.SubscribeSync(DefaultNatsSubject)
+	require.NoError(t, err)
+	require.NoError(t, nc.Flush())
+
+	sink, err := NewNatsSink(server.ClientURL(), "", DefaultNatsSubject, false)
+	require.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	require.NoError(t, sink.Write(context.Background(), []byte(`{"sequence":1}`)))
+
+	msg, err := subscription.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sequence":1}`, string(msg.Data))
+}
+
+func TestNatsSink_Write_ShouldStoreInJetStream(t *testing.T) {
+	server := runNatsServer(t)
+	nc, err := nats.Connect(server.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+	stream, err := js.CreateStream(context.Background(), jetstream.StreamConfig{Name: "AUDIT", Subjects: []string{"nauth.audit"}})
+	require.NoError(t, err)
+
+	sink, err := NewNatsSink(server.ClientURL(), "", DefaultNatsSubject, true)
+	require.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	require.NoError(t, sink.Write(context.Background(), []byte(`{"sequence":1}`)))
+
+	msg, err := stream.GetLastMsgForSubject(context.Background(), DefaultNatsSubject)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sequence":1}`, string(msg.Data))
+}
+
+func TestNatsSink_Write_ShouldFail_WhenNoStreamCapturesSubject(t *testing.T) {
+	server := runNatsServer(t)
+	sink, err := NewNatsSink(server.ClientURL(), "", DefaultNatsSubject, true)
+	require.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	err = sink.Write(context.Background(), []byte(`{"sequence":1}`))
+
+	assert.ErrorContains(t, err, "failed to publish audit record to JetStream subject nauth.audit")
+}
+
+func runNatsServer(t *testing.T) *natsserver.Server {
+	t.Helper()
+
+	server, err := natsserver.NewServer(&natsserver.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		NoLog:     true,
+		NoSigs:    true,
+		JetStream: true,
+		StoreDir:  filepath.Join(t.TempDir(), "store"),
+	})
+	require.NoError(t, err)
+
+	go server.Start()
+	require.True(t, server.ReadyForConnections(10*time.Second), "nats-server did not become ready in time")
+	t.Cleanup(server.Shutdown)
+	return server
+}
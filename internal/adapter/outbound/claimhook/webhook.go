@@ -0,0 +1,114 @@
+// Package claimhook lets a platform team mutate the account claims built by the operator before they are signed, e.g.
+// to inject mandatory deny subjects or tags.
+//
+// The webhook is called with POST <url> and a JSON body {"resource": {"kind": "Account", "namespace": "<namespace>",
+// "name": "<name>"}, "claims": {<account claims>}}, and must respond with 200 and {"claims": {<account claims>}}, the
+// claims to sign. Responses with fields unknown to the account claims are rejected.
+package claimhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+)
+
+const (
+	// DefaultTimeout bounds a call to the webhook when no timeout is configured.
+	DefaultTimeout = 5 * time.Second
+
+	// maxResponseSize is the default max_payload of NATS servers, which no account JWT can exceed.
+	maxResponseSize = 1024 * 1024
+	// maxErrorMessageSize bounds the part of an error response included in the returned error.
+	maxErrorMessageSize = 1024
+)
+
+type mutationRequest struct {
+	Resource domain.AuditResource `json:"resource"`
+	Claims   *jwt.AccountClaims   `json:"claims"`
+}
+
+type mutationResponse struct {
+	Claims *jwt.AccountClaims `json:"claims"`
+}
+
+// Webhook mutates account claims by posting them to an HTTP endpoint.
+type Webhook struct {
+	client        *http.Client
+	url           string
+	authorization string
+}
+
+var _ outbound.AccountClaimsMutator = (*Webhook)(nil)
+
+// NewWebhook posts to url, sending authorization as the Authorization header unless it is empty. Calls taking longer
+// than timeout fail, DefaultTimeout applies when timeout is not positive.
+func NewWebhook(url string, authorization string, timeout time.Duration) (*Webhook, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("claims mutation webhook URL %q must use the http or https scheme", url)
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Webhook{
+		client:        &http.Client{Timeout: timeout},
+		url:           url,
+		authorization: authorization,
+	}, nil
+}
+
+func (w *Webhook) MutateAccountClaims(ctx context.Context, accountRef domain.NamespacedName, claims *jwt.AccountClaims) (*jwt.AccountClaims, error) {
+	body, err := json.Marshal(mutationRequest{
+		Resource: domain.AuditResource{Kind: "Account", Namespace: accountRef.Namespace, Name: accountRef.Name},
+		Claims:   claims,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal claims mutation request: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create claims mutation request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if w.authorization != "" {
+		request.Header.Set("Authorization", w.authorization)
+	}
+	response, err := w.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call claims mutation webhook: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claims mutation webhook response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(responseBody[:min(len(responseBody), maxErrorMessageSize)]))
+		return nil, fmt.Errorf("claims mutation webhook responded with %s: %s", response.Status, message)
+	}
+	if len(responseBody) > maxResponseSize {
+		return nil, fmt.Errorf("claims mutation webhook response exceeds %d bytes", maxResponseSize)
+	}
+
+	result := mutationResponse{}
+	decoder := json.NewDecoder(bytes.NewReader(responseBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, domain.ErrInvalidMutatedClaims.WithCause(
+			fmt.Errorf("failed to unmarshal claims mutation webhook response: %w", err))
+	}
+	if result.Claims == nil {
+		return nil, domain.ErrInvalidMutatedClaims.WithCause(errors.New("claims mutation webhook response has no claims"))
+	}
+	return result.Claims, nil
+}
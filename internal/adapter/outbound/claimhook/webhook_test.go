@@ -0,0 +1,114 @@
+package claimhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAccountRef = domain.NewNamespacedName("team", "orders")
+
+func newWebhookServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebhook_MutateAccountClaims_ShouldReturnMutatedClaims(t *testing.T) {
+	var caughtRequest mutationRequest
+	var caughtAuthorization string
+	server := newWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		caughtAuthorization = r.Header.Get("Authorization")
+		if json.NewDecoder(r.Body).Decode(&caughtRequest) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		claims := caughtRequest.Claims
+		claims.DefaultPermissions.Pub.Deny.Add("restricted.>")
+		_ = json.NewEncoder(w).Encode(mutationResponse{Claims: claims})
+	})
+	webhook, err := NewWebhook(server.URL, "Bearer token", 0)
+	require.NoError(t, err)
+
+	claims := jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID())
+	mutated, err := webhook.MutateAccountClaims(context.Background(), testAccountRef, claims)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", caughtAuthorization)
+	assert.Equal(t, domain.AuditResource{Kind: "Account", Namespace: "team", Name: "orders"}, caughtRequest.Resource)
+	assert.Equal(t, testutil.NatsTestAccountA.AccountID(), mutated.Subject)
+	assert.Equal(t, jwt.StringList{"restricted.>"}, mutated.DefaultPermissions.Pub.Deny)
+}
+
+func TestWebhook_MutateAccountClaims_ShouldFail_WhenResponseHasUnknownFields(t *testing.T) {
+	server := newWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"claims": {"sub": "ACCOUNT", "nats": {"deny": ["restricted.>"]}}}`))
+	})
+	webhook, err := NewWebhook(server.URL, "", 0)
+	require.NoError(t, err)
+
+	_, err = webhook.MutateAccountClaims(context.Background(), testAccountRef,
+		jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID()))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMutatedClaims)
+	assert.ErrorContains(t, err, `unknown field "deny"`)
+}
+
+func TestWebhook_MutateAccountClaims_ShouldFail_WhenResponseHasNoClaims(t *testing.T) {
+	server := newWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	webhook, err := NewWebhook(server.URL, "", 0)
+	require.NoError(t, err)
+
+	_, err = webhook.MutateAccountClaims(context.Background(), testAccountRef,
+		jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID()))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMutatedClaims)
+}
+
+func TestWebhook_MutateAccountClaims_ShouldFail_WhenWebhookRejectsClaims(t *testing.T) {
+	server := newWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "account is not tagged with a cost center", http.StatusForbidden)
+	})
+	webhook, err := NewWebhook(server.URL, "", 0)
+	require.NoError(t, err)
+
+	_, err = webhook.MutateAccountClaims(context.Background(), testAccountRef,
+		jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID()))
+
+	assert.EqualError(t, err,
+		"claims mutation webhook responded with 403 Forbidden: account is not tagged with a cost center")
+	assert.True(t, domain.IsTransient(err))
+}
+
+func TestWebhook_MutateAccountClaims_ShouldFail_WhenWebhookTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := newWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+	webhook, err := NewWebhook(server.URL, "", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = webhook.MutateAccountClaims(context.Background(), testAccountRef,
+		jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID()))
+
+	assert.ErrorContains(t, err, "failed to call claims mutation webhook")
+}
+
+func TestNewWebhook_ShouldFail_WhenURLIsNotHTTP(t *testing.T) {
+	_, err := NewWebhook("nats://hooks:4222", "", 0)
+
+	assert.EqualError(t, err, `claims mutation webhook URL "nats://hooks:4222" must use the http or https scheme`)
+}
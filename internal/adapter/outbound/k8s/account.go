@@ -53,6 +53,23 @@ func (a *AccountClient) GetAccountID(ctx context.Context, accountRef domain.Name
 	return nauth.AccountID(accountID), nil
 }
 
+func (a *AccountClient) GetUserDefaults(ctx context.Context, accountRef domain.NamespacedName) (*v1alpha1.UserDefaults, error) {
+	account, err := a.get(ctx, accountRef)
+	if err != nil {
+		return nil, err
+	}
+	return account.Spec.UserDefaults, nil
+}
+
+// List returns the Accounts in namespace, or in all namespaces when namespace is empty.
+func (a *AccountClient) List(ctx context.Context, namespace domain.Namespace) ([]v1alpha1.Account, error) {
+	accounts := &v1alpha1.AccountList{}
+	if err := a.client.List(ctx, accounts, client.InNamespace(string(namespace))); err != nil {
+		return nil, domain.ErrUnknownError.WithCause(fmt.Errorf("failed to list accounts in namespace %q: %w", namespace, err))
+	}
+	return accounts.Items, nil
+}
+
 func (a *AccountClient) get(ctx context.Context, accountRef domain.NamespacedName) (*v1alpha1.Account, error) {
 	if err := accountRef.Validate(); err != nil {
 		return nil, domain.ErrBadRequest.WithCause(fmt.Errorf("invalid reference %q: %w", accountRef, err))
@@ -76,3 +93,5 @@ func isReady(account *v1alpha1.Account) bool {
 // Compile-time assertion that implementation satisfies the ports interface
 var _ AccountReader = (*AccountClient)(nil)
 var _ outbound.AccountIDReader = (*AccountClient)(nil)
+var _ outbound.AccountLister = (*AccountClient)(nil)
+var _ outbound.AccountUserDefaultsReader = (*AccountClient)(nil)
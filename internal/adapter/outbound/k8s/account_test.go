@@ -140,3 +140,49 @@ func (t *AccountClientTestSuite) Test_GetAccountID_ShouldFail_WhenAccountReferen
 	t.ErrorIs(err, domain.ErrBadRequest)
 	t.Empty(result)
 }
+
+func (t *AccountClientTestSuite) Test_GetUserDefaults_ShouldSucceed_WhenAccountIsNotReady() {
+	// Given
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.accountRef.Name,
+			Namespace: t.accountRef.Namespace,
+		},
+		Spec: v1alpha1.AccountSpec{
+			UserDefaults: &v1alpha1.UserDefaults{
+				Permissions: &v1alpha1.Permissions{Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}}},
+			},
+		},
+	}))
+
+	// When
+	result, err := t.unitUnderTest.GetUserDefaults(t.ctx, t.accountRef)
+
+	t.Require().NoError(err)
+	t.Require().NotNil(result)
+	t.Equal(v1alpha1.StringList{"_INBOX.>"}, result.Permissions.Sub.Allow)
+}
+
+func (t *AccountClientTestSuite) Test_GetUserDefaults_ShouldFail_WhenAccountIsNotFound() {
+	result, err := t.unitUnderTest.GetUserDefaults(t.ctx, t.accountRef)
+
+	t.ErrorIs(err, domain.ErrAccountNotFound)
+	t.Nil(result)
+}
+
+func (t *AccountClientTestSuite) Test_List_ShouldReturnAccountsOfNamespace() {
+	// Given
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.accountRef.Name,
+			Namespace: t.accountRef.Namespace,
+		},
+	}))
+
+	// When
+	result, err := t.unitUnderTest.List(t.ctx, t.accountRef.GetNamespace())
+
+	t.Require().NoError(err)
+	t.Require().Len(result, 1)
+	t.Equal(t.accountRef.Name, result[0].Name)
+}
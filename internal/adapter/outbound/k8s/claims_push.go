@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ClaimsPushConfigMapKey is the key of the JSON encoded pushes in the claims push ConfigMap.
+	ClaimsPushConfigMapKey = "pushes.json"
+	// claimsPushConfigMapMaxSize keeps the pushes below the 1 MiB limit of a ConfigMap.
+	claimsPushConfigMapMaxSize = 900 * 1024
+)
+
+// ClaimsPushConfigMapStore keeps the account JWT pushes not sent yet in a ConfigMap. Pushes beyond the size limit of
+// a ConfigMap are dropped, their Accounts are reconciled by the next leader anyway.
+type ClaimsPushConfigMapStore struct {
+	client       client.Client
+	configMapRef domain.NamespacedName
+}
+
+func NewClaimsPushConfigMapStore(client client.Client, configMapRef domain.NamespacedName) *ClaimsPushConfigMapStore {
+	return &ClaimsPushConfigMapStore{
+		client:       client,
+		configMapRef: configMapRef,
+	}
+}
+
+func (s *ClaimsPushConfigMapStore) LoadClaimsPushes(ctx context.Context) ([]domain.NatsClaimsPush, error) {
+	configMap, err := s.get(ctx)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+	data, ok := configMap.Data[ClaimsPushConfigMapKey]
+	if !ok {
+		return nil, nil
+	}
+	var pushes []domain.NatsClaimsPush
+	if err := json.Unmarshal([]byte(data), &pushes); err != nil {
+		return nil, fmt.Errorf("failed to decode claims push ConfigMap %s: %w", s.configMapRef, err)
+	}
+	return pushes, nil
+}
+
+func (s *ClaimsPushConfigMapStore) SaveClaimsPushes(ctx context.Context, pushes []domain.NatsClaimsPush) error {
+	data, dropped, err := encodeClaimsPushes(pushes)
+	if err != nil {
+		return err
+	}
+	if dropped > 0 {
+		logf.FromContext(ctx).Info("Dropped account JWT pushes exceeding the size of the claims push ConfigMap",
+			"configMap", s.configMapRef, "dropped", dropped)
+	}
+
+	configMap, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	if configMap == nil {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.configMapRef.Name,
+				Namespace: s.configMapRef.Namespace,
+				Labels: map[string]string{
+					LabelManaged: LabelManagedValue,
+				},
+			},
+			Data: map[string]string{ClaimsPushConfigMapKey: data},
+		}
+		if err := s.client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create claims push ConfigMap %s: %w", s.configMapRef, err)
+		}
+		return nil
+	}
+	configMap.Data = map[string]string{ClaimsPushConfigMapKey: data}
+	if err := s.client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update claims push ConfigMap %s: %w", s.configMapRef, err)
+	}
+	return nil
+}
+
+// get returns the claims push ConfigMap, nil when it does not exist.
+func (s *ClaimsPushConfigMapStore) get(ctx context.Context) (*v1.ConfigMap, error) {
+	configMap := &v1.ConfigMap{}
+	key := client.ObjectKey{Namespace: s.configMapRef.Namespace, Name: s.configMapRef.Name}
+	if err := s.client.Get(ctx, key, configMap); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to get claims push ConfigMap %s: %w", s.configMapRef, err)
+		}
+		return nil, nil
+	}
+	if configMap.GetLabels()[LabelManaged] != LabelManagedValue {
+		return nil, fmt.Errorf("existing ConfigMap %s not managed by nauth", s.configMapRef)
+	}
+	return configMap, nil
+}
+
+// encodeClaimsPushes encodes the leading pushes that fit into a ConfigMap, returning the number of pushes dropped.
+func encodeClaimsPushes(pushes []domain.NatsClaimsPush) (string, int, error) {
+	kept := len(pushes)
+	size := len("[]")
+	for i, push := range pushes {
+		encoded, err := json.Marshal(push)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to encode account JWT push: %w", err)
+		}
+		size += len(encoded) + len(",")
+		if size > claimsPushConfigMapMaxSize {
+			kept = i
+			break
+		}
+	}
+	data, err := json.Marshal(pushes[:kept])
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to encode account JWT pushes: %w", err)
+	}
+	return string(data), len(pushes) - kept, nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.NatsClaimsPushStore = (*ClaimsPushConfigMapStore)(nil)
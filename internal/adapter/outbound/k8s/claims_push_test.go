@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ClaimsPushConfigMapStoreTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	configMapRef domain.NamespacedName
+	pushes       []domain.NatsClaimsPush
+
+	unitUnderTest *ClaimsPushConfigMapStore
+}
+
+func TestClaimsPushConfigMapStore_TestSuite(t *testing.T) {
+	suite.Run(t, new(ClaimsPushConfigMapStoreTestSuite))
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.configMapRef = domain.NewNamespacedName(testNamespace, testutil.SanitizeTestName(t.T().Name()))
+	t.Require().NoError(t.configMapRef.Validate())
+	t.pushes = []domain.NatsClaimsPush{
+		{ClusterKey: "cluster", Token: "token-a", QueuedAt: time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)},
+		{ClusterKey: "cluster", Token: "token-b", QueuedAt: time.Date(2026, 10, 17, 8, 0, 1, 0, time.UTC)},
+	}
+	t.unitUnderTest = NewClaimsPushConfigMapStore(k8sClient, t.configMapRef)
+	t.Require().NoError(cleanConfigMap(t.ctx, t.configMapRef))
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) TearDownTest() {
+	t.Require().NoError(cleanConfigMap(t.ctx, t.configMapRef))
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) Test_LoadClaimsPushes_ShouldReturnEmpty_WhenConfigMapDoesNotExist() {
+	pushes, err := t.unitUnderTest.LoadClaimsPushes(t.ctx)
+
+	t.Require().NoError(err)
+	t.Empty(pushes)
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) Test_SaveClaimsPushes_ShouldCreate_WhenConfigMapDoesNotExist() {
+	err := t.unitUnderTest.SaveClaimsPushes(t.ctx, t.pushes)
+
+	t.Require().NoError(err)
+	t.Equal(t.pushes, t.load())
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) Test_SaveClaimsPushes_ShouldReplace_WhenConfigMapExists() {
+	t.Require().NoError(t.unitUnderTest.SaveClaimsPushes(t.ctx, t.pushes))
+
+	err := t.unitUnderTest.SaveClaimsPushes(t.ctx, t.pushes[1:])
+
+	t.Require().NoError(err)
+	t.Equal(t.pushes[1:], t.load())
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) Test_SaveClaimsPushes_ShouldFail_WhenConfigMapIsNotManaged() {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: t.configMapRef.Name, Namespace: t.configMapRef.Namespace},
+	}))
+
+	err := t.unitUnderTest.SaveClaimsPushes(t.ctx, t.pushes)
+
+	t.ErrorContains(err, "not managed by nauth")
+}
+
+func (t *ClaimsPushConfigMapStoreTestSuite) load() []domain.NatsClaimsPush {
+	pushes, err := t.unitUnderTest.LoadClaimsPushes(t.ctx)
+	t.Require().NoError(err)
+	return pushes
+}
+
+func Test_encodeClaimsPushes_ShouldDropPushesExceedingConfigMapSize(t *testing.T) {
+	token := strings.Repeat("x", 300*1024)
+	pushes := []domain.NatsClaimsPush{
+		{ClusterKey: "cluster", Token: token},
+		{ClusterKey: "cluster", Token: token},
+		{ClusterKey: "cluster", Token: token},
+		{ClusterKey: "cluster", Token: token},
+	}
+
+	data, dropped, err := encodeClaimsPushes(pushes)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, dropped)
+	require.LessOrEqual(t, len(data), claimsPushConfigMapMaxSize)
+}
@@ -3,12 +3,16 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/nats-io/nkeys"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -16,17 +20,20 @@ type ClusterClient struct {
 	k8sReader       client.Reader
 	secretReader    outbound.SecretReader
 	configMapReader outbound.ConfigMapReader
+	signerClient    outbound.OperatorSignerClient
 }
 
 func NewClusterClient(
 	k8sReader client.Reader,
 	secretReader outbound.SecretReader,
 	configMapReader outbound.ConfigMapReader,
+	signerClient outbound.OperatorSignerClient,
 ) *ClusterClient {
 	return &ClusterClient{
 		k8sReader:       k8sReader,
 		secretReader:    secretReader,
 		configMapReader: configMapReader,
+		signerClient:    signerClient,
 	}
 }
 
@@ -55,14 +62,46 @@ func (c *ClusterClient) ResolveClusterTarget(ctx context.Context, cluster *v1alp
 	if err != nil {
 		return nil, fmt.Errorf("resolve system account user creds for NatsCluster %s: %w", clusterRef, err)
 	}
-	opSigningKey, err := c.resolveOperatorSigningKey(ctx, cluster)
-	if err != nil {
-		return nil, fmt.Errorf("resolve operator signing key for NatsCluster %s: %w", clusterRef, err)
+	var opSigningKey domain.NatsOperatorSigningKey
+	var opSigningKeySelection *nauth.OperatorSigningKeySelection
+	if cluster.Spec.OperatorSigningKeys != nil {
+		opSigningKeySelection, err = c.resolveOperatorSigningKeySelection(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("resolve operator signing keys for NatsCluster %s: %w", clusterRef, err)
+		}
+		// Accounts get their key through the selection, the first key is only the default
+		opSigningKey = opSigningKeySelection.Candidates[0].Key
+	} else {
+		opSigningKey, err = c.resolveOperatorSigningKey(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("resolve operator signing key for NatsCluster %s: %w", clusterRef, err)
+		}
 	}
 	target, err := nauth.NewClusterTarget(string(cluster.UID), natsURL, *sysAdminCreds, opSigningKey)
 	if err != nil {
 		return nil, fmt.Errorf("create cluster target for NatsCluster %s: %w", clusterRef, err)
 	}
+	target.OperatorSigningKeySelection = opSigningKeySelection
+	if cluster.Spec.SystemAccount != nil {
+		target.ExpectedSystemAccountID = nauth.AccountID(cluster.Spec.SystemAccount.AccountID)
+	}
+	if cluster.Spec.ObserveInterval != nil {
+		target.ObserveInterval = cluster.Spec.ObserveInterval.Duration
+	}
+	target.Resolver = toNatsResolver(cluster.Spec.ResolverStrategy)
+	if target.MaintenanceWindows, err = toMaintenanceWindows(cluster.Spec.MaintenanceWindows); err != nil {
+		return nil, fmt.Errorf("resolve maintenance windows for NatsCluster %s: %w", clusterRef, err)
+	}
+	target.AccountDefaults = toAccountLimitDefaults(cluster.Spec.AccountDefaults)
+	if health := cluster.Status.Health; health != nil && health.JetStreamCapacity != nil {
+		target.JetStreamCapacity = &nauth.JetStreamCapacity{
+			MemoryStorage: health.JetStreamCapacity.MemoryStorage,
+			DiskStorage:   health.JetStreamCapacity.DiskStorage,
+		}
+	}
+	if target.TLS, err = c.resolveTLS(ctx, cluster); err != nil {
+		return nil, fmt.Errorf("resolve TLS configuration for NatsCluster %s: %w", clusterRef, err)
+	}
 	if err = target.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid cluster target resolved for NatsCluster %s: %w", clusterRef, err)
 	}
@@ -84,7 +123,17 @@ func (c *ClusterClient) resolveSysAdminCreds(ctx context.Context, cluster *v1alp
 }
 
 func (c *ClusterClient) resolveOperatorSigningKey(ctx context.Context, cluster *v1alpha1.NatsCluster) (domain.NatsOperatorSigningKey, error) {
+	if signer := cluster.Spec.Signer; signer != nil {
+		opSigningKey, err := c.signerClient.Connect(signer.URL, signer.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operator signer: %w", err)
+		}
+		return opSigningKey, nil
+	}
 	secretKeyRef := cluster.Spec.OperatorSigningKeySecretRef
+	if secretKeyRef == nil {
+		return nil, fmt.Errorf("one of operatorSigningKeySecretRef, signer or operatorSigningKeys is required")
+	}
 	secretRef := domain.NewNamespacedName(cluster.GetNamespace(), secretKeyRef.Name)
 	keyData, err := c.resolveSecret(ctx, secretRef, secretKeyRef.Key)
 	if err != nil {
@@ -92,11 +141,159 @@ func (c *ClusterClient) resolveOperatorSigningKey(ctx context.Context, cluster *
 	}
 	opSigningKey, err := nkeys.FromSeed(keyData)
 	if err != nil {
-		return nil, fmt.Errorf("invalid operator signing key: %w", err)
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("invalid operator signing key: %w", err))
 	}
 	return opSigningKey, nil
 }
 
+func (c *ClusterClient) resolveOperatorSigningKeySelection(ctx context.Context, cluster *v1alpha1.NatsCluster) (*nauth.OperatorSigningKeySelection, error) {
+	spec := cluster.Spec.OperatorSigningKeys
+	secrets, err := c.secretReader.GetByLabels(ctx, domain.Namespace(cluster.GetNamespace()), spec.SecretLabels)
+	if err != nil {
+		return nil, fmt.Errorf("list operator signing key secrets: %w", err)
+	}
+	if len(secrets.Items) == 0 {
+		return nil, fmt.Errorf("no secrets found with labels %v", spec.SecretLabels)
+	}
+	key := spec.Key
+	if key == "" {
+		key = DefaultSecretKeyName
+	}
+	items := slices.Clone(secrets.Items)
+	slices.SortFunc(items, func(a, b corev1.Secret) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	policy := nauth.OperatorSigningKeySelectionPolicy(spec.SelectionPolicy)
+	if policy == "" {
+		policy = nauth.OperatorSigningKeySelectionRoundRobin
+	}
+	result := &nauth.OperatorSigningKeySelection{Policy: policy, Label: spec.SelectionLabel}
+	for _, secret := range items {
+		keyData, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s does not contain key %q", secret.Namespace, secret.Name, key)
+		}
+		opSigningKey, err := nkeys.FromSeed(keyData)
+		if err != nil {
+			return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("invalid operator signing key in secret %s/%s: %w", secret.Namespace, secret.Name, err))
+		}
+		result.Candidates = append(result.Candidates, nauth.OperatorSigningKeyCandidate{
+			Name:   secret.Name,
+			Labels: secret.Labels,
+			Key:    opSigningKey,
+		})
+	}
+	return result, nil
+}
+
+func toNatsResolver(spec *v1alpha1.ResolverStrategySpec) domain.NatsResolver {
+	if spec == nil {
+		return domain.NatsResolver{}
+	}
+	result := domain.NatsResolver{
+		Type:      domain.NatsResolverType(spec.Type),
+		KVBucket:  spec.KVBucket,
+		Directory: spec.Directory,
+	}
+	if result.Type == domain.NatsResolverTypeKV && result.KVBucket == "" {
+		result.KVBucket = v1alpha1.DefaultResolverKVBucket
+	}
+	return result
+}
+
+// toAccountLimitDefaults copies the account defaults of a NatsCluster, so cached targets do not share the limits of
+// the informer cache object.
+func toAccountLimitDefaults(spec *v1alpha1.ClusterAccountDefaults) *nauth.AccountLimitDefaults {
+	if spec == nil {
+		return nil
+	}
+	spec = spec.DeepCopy()
+	result := &nauth.AccountLimitDefaults{}
+	if limits := spec.AccountLimits; limits != nil {
+		result.AccountLimits = &nauth.AccountLimits{
+			Imports:         limits.Imports,
+			Exports:         limits.Exports,
+			WildcardExports: limits.WildcardExports,
+			Conn:            limits.Conn,
+			LeafNodeConn:    limits.LeafNodeConn,
+		}
+	}
+	if limits := spec.JetStreamLimits; limits != nil {
+		result.JetStreamLimits = &nauth.JetStreamLimits{
+			MemoryStorage:        limits.MemoryStorage,
+			DiskStorage:          limits.DiskStorage,
+			Streams:              limits.Streams,
+			Consumer:             limits.Consumer,
+			MaxAckPending:        limits.MaxAckPending,
+			MemoryMaxStreamBytes: limits.MemoryMaxStreamBytes,
+			DiskMaxStreamBytes:   limits.DiskMaxStreamBytes,
+			MaxBytesRequired:     limits.MaxBytesRequired,
+		}
+	}
+	if limits := spec.NatsLimits; limits != nil {
+		result.NatsLimits = &nauth.NatsLimits{
+			Subs:    limits.Subs,
+			Data:    limits.Data,
+			Payload: limits.Payload,
+		}
+	}
+	return result
+}
+
+func toMaintenanceWindows(specs []v1alpha1.MaintenanceWindow) (nauth.MaintenanceWindows, error) {
+	var result nauth.MaintenanceWindows
+	for _, spec := range specs {
+		location, err := time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+		}
+		schedule, err := nauth.ParseCronSchedule(spec.Schedule, location)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, nauth.MaintenanceWindow{Schedule: schedule, Duration: spec.Duration.Duration})
+	}
+	return result, nil
+}
+
+func (c *ClusterClient) resolveTLS(ctx context.Context, cluster *v1alpha1.NatsCluster) (*domain.NatsTLSConfig, error) {
+	tlsSpec := cluster.Spec.TLS
+	if tlsSpec == nil {
+		return nil, nil
+	}
+
+	result := &domain.NatsTLSConfig{
+		InsecureSkipVerify: tlsSpec.InsecureSkipVerify,
+	}
+	if tlsSpec.CASecretRef != nil {
+		key := tlsSpec.CASecretRef.Key
+		if key == "" {
+			key = DefaultTLSCAKeyName
+		}
+		secretRef := domain.NewNamespacedName(cluster.GetNamespace(), tlsSpec.CASecretRef.Name)
+		caCert, err := c.resolveSecret(ctx, secretRef, key)
+		if err != nil {
+			return nil, err
+		}
+		result.CACert = caCert
+	}
+	if tlsSpec.CertSecretRef != nil {
+		secretRef := domain.NewNamespacedName(cluster.GetNamespace(), tlsSpec.CertSecretRef.Name)
+		clientCert, err := c.resolveSecret(ctx, secretRef, corev1.TLSCertKey)
+		if err != nil {
+			return nil, err
+		}
+		clientKey, err := c.resolveSecret(ctx, secretRef, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		result.ClientCert = clientCert
+		result.ClientKey = clientKey
+	}
+	return result, nil
+}
+
 func (c *ClusterClient) resolveSecret(ctx context.Context, namespacedName domain.NamespacedName, key string) ([]byte, error) {
 	secretData, found, err := c.secretReader.Get(ctx, namespacedName)
 	if err != nil {
@@ -164,7 +361,7 @@ func (c *ClusterClient) resolveNatsURLFromResource(ctx context.Context, urlFromR
 		}
 		return string(data), nil
 	default:
-		return "", fmt.Errorf("unsupported urlFrom.kind %q", urlFromRef.Kind)
+		return "", domain.ErrUnsupported.WithCause(fmt.Errorf("unsupported urlFrom.kind %q", urlFromRef.Kind))
 	}
 }
 
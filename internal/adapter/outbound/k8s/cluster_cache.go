@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// CachingClusterClient resolves cluster targets through a ClusterClient and keeps them in memory, so that reconciles
+// do not look up and parse the system account user creds and operator signing key of a NatsCluster every time. A
+// cached target is used while the generation of the NatsCluster is unchanged and none of the Secrets or ConfigMaps it
+// references have changed.
+//
+// Changes are observed through the informers of the manager, see Start. Targets are not cached until the event
+// handlers are registered. The Secret informer only sees the Secrets selected by SecretInformerSelector in
+// secretNamespace, so the Secrets referenced by a cached target are labelled with SecretTypeNatsClusterReference.
+// Targets referencing Secrets in other namespaces, or selecting their operator signing keys by labels, are resolved
+// on every call.
+type CachingClusterClient struct {
+	k8sReader       client.Reader
+	informers       cache.Informers
+	clusterClient   *ClusterClient
+	secretClient    outbound.SecretClient
+	secretNamespace string
+
+	mu       sync.Mutex
+	watching bool
+	entries  map[domain.NamespacedName]cachedClusterTarget
+}
+
+type cachedClusterTarget struct {
+	uid        types.UID
+	generation int64
+	// references are the Secrets and ConfigMaps the target was resolved from.
+	references []domain.NamespacedName
+	target     nauth.ClusterTarget
+}
+
+var _ outbound.ClusterReader = (*CachingClusterClient)(nil)
+var _ manager.Runnable = (*CachingClusterClient)(nil)
+var _ manager.LeaderElectionRunnable = (*CachingClusterClient)(nil)
+
+// NewCachingClusterClient returns a CachingClusterClient caching the targets whose Secrets are in secretNamespace, the
+// namespace watched by the Secret informer, labelling them through secretClient.
+func NewCachingClusterClient(k8sReader client.Reader, informers cache.Informers, clusterClient *ClusterClient,
+	secretClient outbound.SecretClient, secretNamespace string) *CachingClusterClient {
+	return &CachingClusterClient{
+		k8sReader:       k8sReader,
+		informers:       informers,
+		clusterClient:   clusterClient,
+		secretClient:    secretClient,
+		secretNamespace: secretNamespace,
+		entries:         make(map[domain.NamespacedName]cachedClusterTarget),
+	}
+}
+
+func (c *CachingClusterClient) GetTarget(ctx context.Context, clusterRef nauth.ClusterRef) (*nauth.ClusterTarget, error) {
+	namespacedNameRef, err := clusterRef.AsNamespacedName()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster reference %q (expected NamespacedName): %w", clusterRef, err)
+	}
+
+	cluster := &v1alpha1.NatsCluster{}
+	if err = c.k8sReader.Get(ctx, client.ObjectKey{Namespace: namespacedNameRef.Namespace, Name: namespacedNameRef.Name}, cluster); err != nil {
+		return nil, fmt.Errorf("failed getting NatsCluster resource %s: %w", namespacedNameRef.String(), err)
+	}
+
+	c.mu.Lock()
+	entry, found := c.entries[*namespacedNameRef]
+	watching := c.watching
+	c.mu.Unlock()
+	if found && entry.uid == cluster.UID && entry.generation == cluster.Generation {
+		target := entry.target
+		return &target, nil
+	}
+
+	target, err := c.clusterClient.ResolveClusterTarget(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if watching && c.watchReferences(ctx, cluster) {
+		c.mu.Lock()
+		c.entries[*namespacedNameRef] = cachedClusterTarget{
+			uid:        cluster.UID,
+			generation: cluster.Generation,
+			references: clusterReferences(cluster),
+			target:     *target,
+		}
+		c.mu.Unlock()
+	}
+	return target, nil
+}
+
+// watchReferences labels the Secrets referenced by cluster so that the Secret informer observes their changes, and
+// returns whether all of them are observed.
+func (c *CachingClusterClient) watchReferences(ctx context.Context, cluster *v1alpha1.NatsCluster) bool {
+	if cluster.Spec.OperatorSigningKeys != nil {
+		// Secrets added with the selected labels would not be observed
+		return false
+	}
+	secretRefs := clusterSecretReferences(cluster)
+	for _, secretRef := range secretRefs {
+		if secretRef.Namespace != c.secretNamespace {
+			return false
+		}
+	}
+	log := logf.FromContext(ctx)
+	for _, secretRef := range secretRefs {
+		secret := &corev1.Secret{}
+		if err := c.k8sReader.Get(ctx, client.ObjectKey{Namespace: secretRef.Namespace, Name: secretRef.Name}, secret); err != nil {
+			log.Info("Unable to get Secret referenced by NatsCluster, not caching its target", "secretRef", secretRef, "error", err)
+			return false
+		}
+		if _, ok := secret.Labels[LabelSecretType]; ok {
+			continue
+		}
+		if err := c.secretClient.Label(ctx, secretRef, map[string]string{LabelSecretType: SecretTypeNatsClusterReference}); err != nil {
+			log.Info("Unable to label Secret referenced by NatsCluster, not caching its target", "secretRef", secretRef, "error", err)
+			return false
+		}
+	}
+	return true
+}
+
+// Invalidate drops the cached targets resolved from the Secret or ConfigMap ref.
+func (c *CachingClusterClient) Invalidate(ref domain.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for clusterRef, entry := range c.entries {
+		for _, reference := range entry.references {
+			if reference == ref {
+				delete(c.entries, clusterRef)
+				break
+			}
+		}
+	}
+}
+
+// Start registers event handlers on the Secret and ConfigMap informers that invalidate cached targets, and enables
+// caching once both are registered.
+func (c *CachingClusterClient) Start(ctx context.Context) error {
+	for _, obj := range []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}} {
+		informer, err := c.informers.GetInformer(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("get informer for %T: %w", obj, err)
+		}
+		if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: c.invalidateObject,
+			UpdateFunc: func(_, newObj any) {
+				c.invalidateObject(newObj)
+			},
+			DeleteFunc: c.invalidateObject,
+		}); err != nil {
+			return fmt.Errorf("add event handler for %T: %w", obj, err)
+		}
+	}
+
+	c.mu.Lock()
+	c.watching = true
+	c.mu.Unlock()
+	logf.FromContext(ctx).WithName("cluster-target-cache").Info("Caching NatsCluster targets")
+
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Cluster targets are resolved by all replicas.
+func (c *CachingClusterClient) NeedLeaderElection() bool {
+	return false
+}
+
+func (c *CachingClusterClient) invalidateObject(obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if object, ok := obj.(client.Object); ok {
+		c.Invalidate(domain.NewNamespacedName(object.GetNamespace(), object.GetName()))
+	}
+}
+
+// clusterReferences returns the Secrets and ConfigMaps a target is resolved from, see ClusterClient.
+func clusterReferences(cluster *v1alpha1.NatsCluster) []domain.NamespacedName {
+	references := clusterSecretReferences(cluster)
+	if urlFrom := cluster.Spec.URLFrom; urlFrom != nil && urlFrom.Kind != v1alpha1.URLFromKindSecret {
+		references = append(references, urlFromReference(cluster))
+	}
+	return references
+}
+
+// clusterSecretReferences returns the Secrets referenced by name a target is resolved from.
+func clusterSecretReferences(cluster *v1alpha1.NatsCluster) []domain.NamespacedName {
+	namespace := cluster.GetNamespace()
+	references := []domain.NamespacedName{
+		domain.NewNamespacedName(namespace, cluster.Spec.SystemAccountUserCredsSecretRef.Name),
+	}
+	if secretKeyRef := cluster.Spec.OperatorSigningKeySecretRef; secretKeyRef != nil {
+		references = append(references, domain.NewNamespacedName(namespace, secretKeyRef.Name))
+	}
+	if tlsSpec := cluster.Spec.TLS; tlsSpec != nil {
+		if tlsSpec.CASecretRef != nil {
+			references = append(references, domain.NewNamespacedName(namespace, tlsSpec.CASecretRef.Name))
+		}
+		if tlsSpec.CertSecretRef != nil {
+			references = append(references, domain.NewNamespacedName(namespace, tlsSpec.CertSecretRef.Name))
+		}
+	}
+	if urlFrom := cluster.Spec.URLFrom; urlFrom != nil && urlFrom.Kind == v1alpha1.URLFromKindSecret {
+		references = append(references, urlFromReference(cluster))
+	}
+	return references
+}
+
+func urlFromReference(cluster *v1alpha1.NatsCluster) domain.NamespacedName {
+	urlFrom := cluster.Spec.URLFrom
+	urlFromNamespace := urlFrom.Namespace
+	if urlFromNamespace == "" {
+		urlFromNamespace = cluster.GetNamespace()
+	}
+	return domain.NewNamespacedName(urlFromNamespace, urlFrom.Name)
+}
@@ -3,8 +3,10 @@ package k8s
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/signer"
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/testutil"
@@ -12,6 +14,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type NatsClusterClientTestSuite struct {
@@ -39,14 +42,14 @@ func (t *NatsClusterClientTestSuite) SetupTest() {
 
 	secretReader := NewSecretClient(k8sClient)
 	configMapReader := NewConfigMapClient(k8sClient)
-	t.unitUnderTest = NewClusterClient(k8sClient, secretReader, configMapReader)
+	t.unitUnderTest = NewClusterClient(k8sClient, secretReader, configMapReader, signer.NewClient())
 }
 
 func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed() {
 	// Given
 	t.createNatsCluster(v1alpha1.NatsClusterSpec{
 		URL: "nats://nats:4222",
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "op-sign-secret",
 			Key:  "seed",
 		},
@@ -72,11 +75,163 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed() {
 	}, result)
 }
 
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenObserveIntervalConfigured() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL:                             "nats://nats:4222",
+		OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds-secret"},
+		ObserveInterval:                 &metav1.Duration{Duration: time.Minute},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{DefaultSecretKeyName: string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{DefaultSecretKeyName: string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(time.Minute, result.ObserveInterval)
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenAccountDefaultsConfigured() {
+	// Given
+	var streams, subs int64 = 10, 1000
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL:                             "nats://nats:4222",
+		OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds-secret"},
+		AccountDefaults: &v1alpha1.ClusterAccountDefaults{
+			JetStreamLimits: &v1alpha1.JetStreamLimits{Streams: &streams},
+			NatsLimits:      &v1alpha1.NatsLimits{Subs: &subs},
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{DefaultSecretKeyName: string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{DefaultSecretKeyName: string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().NotNil(result.AccountDefaults)
+	t.Nil(result.AccountDefaults.AccountLimits)
+	t.Require().NotNil(result.AccountDefaults.JetStreamLimits)
+	t.Equal(streams, *result.AccountDefaults.JetStreamLimits.Streams)
+	t.Equal(int64(-1), *result.AccountDefaults.JetStreamLimits.DiskStorage, "API server defaults unset fields to unlimited")
+	t.Require().NotNil(result.AccountDefaults.NatsLimits)
+	t.Equal(subs, *result.AccountDefaults.NatsLimits.Subs)
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenResolverStrategyConfigured() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL:                             "nats://nats:4222",
+		OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds-secret"},
+		ResolverStrategy:                &v1alpha1.ResolverStrategySpec{Type: v1alpha1.ResolverStrategyKV},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{DefaultSecretKeyName: string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{DefaultSecretKeyName: string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(domain.NatsResolver{Type: domain.NatsResolverTypeKV, KVBucket: v1alpha1.DefaultResolverKVBucket}, result.Resolver)
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenMaintenanceWindowsConfigured() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL:                             "nats://nats:4222",
+		OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds-secret"},
+		MaintenanceWindows: []v1alpha1.MaintenanceWindow{{
+			Schedule: "0 18 * * 5",
+			Duration: metav1.Duration{Duration: 6 * time.Hour},
+			TimeZone: "Europe/Stockholm",
+		}},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{DefaultSecretKeyName: string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{DefaultSecretKeyName: string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().Len(result.MaintenanceWindows, 1)
+	t.Equal("0 18 * * 5", result.MaintenanceWindows[0].Schedule.String())
+	end, active := result.MaintenanceWindows.ActiveUntil(time.Date(2026, time.October, 16, 17, 0, 0, 0, time.UTC))
+	t.True(active)
+	t.Equal(time.Date(2026, time.October, 16, 22, 0, 0, 0, time.UTC), end.UTC())
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldFail_WhenMaintenanceWindowScheduleInvalid() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL:                             "nats://nats:4222",
+		OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "op-sign-secret"},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "sau-creds-secret"},
+		MaintenanceWindows: []v1alpha1.MaintenanceWindow{{
+			Schedule: "0 18 * *",
+			Duration: metav1.Duration{Duration: time.Hour},
+		}},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{DefaultSecretKeyName: string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{DefaultSecretKeyName: string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.ErrorContains(err, "resolve maintenance windows")
+	t.Nil(result)
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenSignerIsConfigured() {
+	// Given
+	testData := t.generateTestSecrets()
+	opSignPublicKey, err := testData.opSign.Key.PublicKey()
+	t.Require().NoError(err)
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		Signer: &v1alpha1.OperatorSignerSpec{
+			URL:       "http://localhost:8090",
+			PublicKey: opSignPublicKey,
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+			Key:  "user.creds",
+		},
+	})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"user.creds": string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Require().NoError(err)
+	t.Require().NotNil(result.OperatorSigningKey)
+	publicKey, err := result.OperatorSigningKey.PublicKey()
+	t.NoError(err)
+	t.Equal(opSignPublicKey, publicKey)
+	_, err = result.OperatorSigningKey.Seed()
+	t.ErrorIs(err, signer.ErrSeedUnavailable)
+}
+
 func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenSecretsUsingDefaultKey() {
 	// Given
 	t.createNatsCluster(v1alpha1.NatsClusterSpec{
 		URL: "nats://nats:4222",
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "op-sign-secret",
 		},
 		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
@@ -100,6 +255,69 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenSecretsUsi
 	}, result)
 }
 
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenOperatorSigningKeysSelectedByLabels() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeys: &v1alpha1.OperatorSigningKeysSpec{
+			SecretLabels:    map[string]string{"nauth.io/operator-signing-key": "true"},
+			SelectionPolicy: v1alpha1.OperatorSigningKeySelectionLabel,
+			SelectionLabel:  "nauth.io/tenant",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	otherKey := testutil.CreateNatsTestOperatorKey()
+	t.createLabeledSecret(t.clusterNsN.Namespace, "op-sign-b",
+		map[string]string{"nauth.io/operator-signing-key": "true", "nauth.io/tenant": "b"},
+		map[string]string{"default": string(otherKey.Seed)})
+	t.createLabeledSecret(t.clusterNsN.Namespace, "op-sign-a",
+		map[string]string{"nauth.io/operator-signing-key": "true", "nauth.io/tenant": "a"},
+		map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.EqualClusterTarget(&nauth.ClusterTarget{
+		NatsURL:            "nats://nats:4222",
+		SystemAdminCreds:   testData.sauCreds,
+		OperatorSigningKey: testData.opSign.Key,
+	}, result)
+	t.Require().NotNil(result.OperatorSigningKeySelection)
+	t.Equal(nauth.OperatorSigningKeySelectionLabel, result.OperatorSigningKeySelection.Policy)
+	t.Equal("nauth.io/tenant", result.OperatorSigningKeySelection.Label)
+	t.Require().Len(result.OperatorSigningKeySelection.Candidates, 2)
+	t.Equal("op-sign-a", result.OperatorSigningKeySelection.Candidates[0].Name)
+	t.Equal("op-sign-b", result.OperatorSigningKeySelection.Candidates[1].Name)
+	t.Equal(otherKey.Key, result.OperatorSigningKeySelection.Candidates[1].Key)
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldFail_WhenNoOperatorSigningKeysMatchLabels() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeys: &v1alpha1.OperatorSigningKeysSpec{
+			SecretLabels: map[string]string{"nauth.io/operator-signing-key": "true"},
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+
+	// When
+	_, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.ErrorContains(err, "no secrets found with labels map[nauth.io/operator-signing-key:true]")
+}
+
 func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenNatsURLFromConfigMap() {
 	// Given
 	t.createNatsCluster(v1alpha1.NatsClusterSpec{
@@ -108,7 +326,7 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenNatsURLFro
 			Name: "url-configmap",
 			Key:  "nats.url",
 		},
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "op-sign-secret",
 			Key:  "seed",
 		},
@@ -145,7 +363,7 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenNatsURLFro
 			Namespace: configNamespace, // Explicit namespace different from cluster resource namespace
 			Key:       "nats.url",
 		},
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "op-sign-secret",
 			Key:  "seed",
 		},
@@ -180,7 +398,7 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenNatsURLFro
 			Name: "url-secret",
 			Key:  "nats.url",
 		},
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "op-sign-secret",
 			Key:  "seed",
 		},
@@ -217,7 +435,7 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenNatsURLFro
 			Namespace: secretNamespace, // Explicit namespace different from cluster resource namespace
 			Key:       "nats.url",
 		},
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "op-sign-secret",
 			Key:  "seed",
 		},
@@ -252,7 +470,7 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenAllDetails
 			Name: "cluster-secret",
 			Key:  "nats-url",
 		},
-		OperatorSigningKeySecretRef: v1alpha1.SecretKeyReference{
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
 			Name: "cluster-secret",
 			Key:  "op-sign-seed",
 		},
@@ -281,6 +499,94 @@ func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenAllDetails
 	}, result)
 }
 
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldSucceed_WhenTLSConfigured() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "tls://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+		TLS: &v1alpha1.NatsTLSSpec{
+			CASecretRef:   &v1alpha1.SecretKeyReference{Name: "nats-ca"},
+			CertSecretRef: &v1alpha1.TLSSecretReference{Name: "nats-client-tls"},
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	t.createSecret(t.clusterNsN.Namespace, "nats-ca", map[string]string{"ca.crt": "ca-pem"})
+	t.createSecret(t.clusterNsN.Namespace, "nats-client-tls", map[string]string{"tls.crt": "cert-pem", "tls.key": "key-pem"})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.Equal(&domain.NatsTLSConfig{
+		CACert:     []byte("ca-pem"),
+		ClientCert: []byte("cert-pem"),
+		ClientKey:  []byte("key-pem"),
+	}, result.TLS)
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldFail_WhenTLSClientKeyMissing() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "tls://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+		TLS: &v1alpha1.NatsTLSSpec{
+			CertSecretRef: &v1alpha1.TLSSecretReference{Name: "nats-client-tls"},
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	t.createSecret(t.clusterNsN.Namespace, "nats-client-tls", map[string]string{"tls.crt": "cert-pem"})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Nil(result)
+	t.ErrorContains(err, "does not contain key \"tls.key\"")
+}
+
+func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldFail_WhenSystemAccountDoesNotMatchCreds() {
+	// Given
+	otherAccount := testutil.CreateNatsTestAccount()
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+		SystemAccount: &v1alpha1.SystemAccountSpec{
+			AccountID: otherAccount.AccountID(),
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+
+	// When
+	result, err := t.unitUnderTest.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.Nil(result)
+	t.ErrorContains(err, "expected system account "+otherAccount.AccountID())
+}
+
 func (t *NatsClusterClientTestSuite) Test_GetTarget_ShouldFail_WhenClusterRefIsNotNamespacedName() {
 	// Given
 	clusterRef := nauth.ClusterRef("not a namespaced name")
@@ -337,6 +643,202 @@ func (t *NatsClusterClientTestSuite) Test_resolveNatsURL_ShouldFail_WhenURLAmbig
 
 // Helpers
 
+func (t *NatsClusterClientTestSuite) Test_CachingClusterClient_GetTarget_ShouldReuseTarget_UntilSecretInvalidated() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	cachingClient := t.newWatchingCachingClusterClient()
+	cached, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+	t.Require().NoError(err)
+	rotatedKey := testutil.CreateNatsTestOperatorKey()
+	t.updateSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(rotatedKey.Seed)})
+
+	// When
+	beforeInvalidate, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+	t.Require().NoError(err)
+	cachingClient.Invalidate(domain.NewNamespacedName(t.clusterNsN.Namespace, "op-sign-secret"))
+	afterInvalidate, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.Equal(cached.OperatorSigningKey, beforeInvalidate.OperatorSigningKey)
+	t.Equal(rotatedKey.Key, afterInvalidate.OperatorSigningKey)
+}
+
+func (t *NatsClusterClientTestSuite) Test_CachingClusterClient_GetTarget_ShouldResolveAgain_WhenNatsClusterChanged() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	cachingClient := t.newWatchingCachingClusterClient()
+	_, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+	t.Require().NoError(err)
+	cluster := &v1alpha1.NatsCluster{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.clusterNsN.Namespace, Name: t.clusterNsN.Name}, cluster))
+	cluster.Spec.URL = "nats://other-nats:4222"
+	t.Require().NoError(k8sClient.Update(t.ctx, cluster))
+
+	// When
+	result, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.Equal("nats://other-nats:4222", result.NatsURL)
+}
+
+func (t *NatsClusterClientTestSuite) Test_CachingClusterClient_GetTarget_ShouldNotCache_WhenNotWatching() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	cachingClient := NewCachingClusterClient(k8sClient, nil, t.unitUnderTest, NewSecretClient(k8sClient), t.clusterNsN.Namespace)
+	_, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+	t.Require().NoError(err)
+	rotatedKey := testutil.CreateNatsTestOperatorKey()
+	t.updateSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(rotatedKey.Seed)})
+
+	// When
+	result, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.Equal(rotatedKey.Key, result.OperatorSigningKey)
+}
+
+func (t *NatsClusterClientTestSuite) Test_CachingClusterClient_GetTarget_ShouldLabelReferencedSecrets() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createLabeledSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{LabelSecretType: "sau-creds"},
+		map[string]string{"default": string(testData.sauCredsData)})
+	cachingClient := t.newWatchingCachingClusterClient()
+
+	// When
+	_, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	opSignSecret := &k8sv1.Secret{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.clusterNsN.Namespace, Name: "op-sign-secret"}, opSignSecret))
+	t.Equal(SecretTypeNatsClusterReference, opSignSecret.Labels[LabelSecretType])
+	sauCredsSecret := &k8sv1.Secret{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.clusterNsN.Namespace, Name: "sau-creds-secret"}, sauCredsSecret))
+	t.Equal("sau-creds", sauCredsSecret.Labels[LabelSecretType])
+}
+
+func (t *NatsClusterClientTestSuite) Test_CachingClusterClient_GetTarget_ShouldNotCache_WhenOperatorSigningKeysSelectedByLabels() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeys: &v1alpha1.OperatorSigningKeysSpec{
+			SecretLabels: map[string]string{"nauth.io/operator-signing-key": "true"},
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	labels := map[string]string{"nauth.io/operator-signing-key": "true"}
+	t.createLabeledSecret(t.clusterNsN.Namespace, "op-sign-a", labels, map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	cachingClient := t.newWatchingCachingClusterClient()
+	_, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+	t.Require().NoError(err)
+	addedKey := testutil.CreateNatsTestOperatorKey()
+	t.createLabeledSecret(t.clusterNsN.Namespace, "op-sign-b", labels, map[string]string{"default": string(addedKey.Seed)})
+
+	// When
+	result, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.Len(result.OperatorSigningKeySelection.Candidates, 2)
+}
+
+func (t *NatsClusterClientTestSuite) Test_CachingClusterClient_GetTarget_ShouldNotCache_WhenSecretOutsideWatchedNamespace() {
+	// Given
+	t.createNatsCluster(v1alpha1.NatsClusterSpec{
+		URL: "nats://nats:4222",
+		OperatorSigningKeySecretRef: &v1alpha1.SecretKeyReference{
+			Name: "op-sign-secret",
+		},
+		SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{
+			Name: "sau-creds-secret",
+		},
+	})
+	testData := t.generateTestSecrets()
+	t.createSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(testData.opSign.Seed)})
+	t.createSecret(t.clusterNsN.Namespace, "sau-creds-secret", map[string]string{"default": string(testData.sauCredsData)})
+	cachingClient := NewCachingClusterClient(k8sClient, nil, t.unitUnderTest, NewSecretClient(k8sClient), "nauth-system")
+	cachingClient.watching = true
+	_, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+	t.Require().NoError(err)
+	rotatedKey := testutil.CreateNatsTestOperatorKey()
+	t.updateSecret(t.clusterNsN.Namespace, "op-sign-secret", map[string]string{"default": string(rotatedKey.Seed)})
+
+	// When
+	result, err := cachingClient.GetTarget(t.ctx, t.clusterRef)
+
+	// Then
+	t.NoError(err)
+	t.Equal(rotatedKey.Key, result.OperatorSigningKey)
+	opSignSecret := &k8sv1.Secret{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.clusterNsN.Namespace, Name: "op-sign-secret"}, opSignSecret))
+	t.NotContains(opSignSecret.Labels, LabelSecretType)
+}
+
+func (t *NatsClusterClientTestSuite) newWatchingCachingClusterClient() *CachingClusterClient {
+	cachingClient := NewCachingClusterClient(k8sClient, nil, t.unitUnderTest, NewSecretClient(k8sClient), t.clusterNsN.Namespace)
+	// Start is not called as the test environment has no informers, secret changes are invalidated explicitly
+	cachingClient.watching = true
+	return cachingClient
+}
+
+func (t *NatsClusterClientTestSuite) updateSecret(namespace string, resourceName string, data map[string]string) {
+	secret := &k8sv1.Secret{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: namespace, Name: resourceName}, secret))
+	secret.Data = nil
+	secret.StringData = data
+	t.Require().NoError(k8sClient.Update(t.ctx, secret))
+}
+
 func (t *NatsClusterClientTestSuite) createNatsCluster(spec v1alpha1.NatsClusterSpec) {
 	t.Require().NoError(ensureNamespace(t.ctx, t.clusterNsN.Namespace))
 	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.NatsCluster{
@@ -370,6 +872,18 @@ func (t *NatsClusterClientTestSuite) createSecret(namespace string, resourceName
 	}))
 }
 
+func (t *NatsClusterClientTestSuite) createLabeledSecret(namespace string, resourceName string, labels map[string]string, data map[string]string) {
+	t.Require().NoError(ensureNamespace(t.ctx, namespace))
+	t.Require().NoError(k8sClient.Create(t.ctx, &k8sv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		StringData: data,
+	}))
+}
+
 type clusterTestSecrets struct {
 	opSign       testutil.NatsTestOperatorKey
 	sauCredsData []byte
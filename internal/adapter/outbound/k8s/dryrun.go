@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DryRunSecretClient reads Secrets with a SecretClient but skips every write, logging the Secrets that would have
+// been applied, labelled or deleted.
+type DryRunSecretClient struct {
+	outbound.SecretReader
+}
+
+func NewDryRunSecretClient(client outbound.SecretClient) *DryRunSecretClient {
+	return &DryRunSecretClient{SecretReader: client}
+}
+
+func (k *DryRunSecretClient) Apply(ctx context.Context, _ metav1.Object, meta metav1.ObjectMeta, _ map[string]string) error {
+	logf.FromContext(ctx).Info("Dry run: skipped applying secret", "namespace", meta.Namespace, "name", meta.Name)
+	return nil
+}
+
+func (k *DryRunSecretClient) ApplyAll(ctx context.Context, _ metav1.Object, secrets []outbound.SecretApply) error {
+	for _, secret := range secrets {
+		logf.FromContext(ctx).Info("Dry run: skipped applying secret",
+			"namespace", secret.Meta.Namespace, "name", secret.Meta.Name)
+	}
+	return nil
+}
+
+func (k *DryRunSecretClient) Delete(ctx context.Context, secretRef domain.NamespacedName) error {
+	logf.FromContext(ctx).Info("Dry run: skipped deleting secret", "secretRef", secretRef)
+	return nil
+}
+
+func (k *DryRunSecretClient) DeleteByLabels(ctx context.Context, namespace domain.Namespace, labels map[string]string) error {
+	logf.FromContext(ctx).Info("Dry run: skipped deleting secrets", "namespace", namespace, "labels", labels)
+	return nil
+}
+
+func (k *DryRunSecretClient) Label(ctx context.Context, secretRef domain.NamespacedName, labels map[string]string) error {
+	logf.FromContext(ctx).Info("Dry run: skipped labelling secret", "secretRef", secretRef, "labels", labels)
+	return nil
+}
+
+// DryRunCredentialsSink skips delivering credentials to external secret stores, logging the Secrets that would have
+// been pushed or removed.
+type DryRunCredentialsSink struct{}
+
+func NewDryRunCredentialsSink() *DryRunCredentialsSink {
+	return &DryRunCredentialsSink{}
+}
+
+func (k *DryRunCredentialsSink) Push(ctx context.Context, _ metav1.Object, secretRef domain.NamespacedName, _ v1alpha1.CredentialsSink) error {
+	logf.FromContext(ctx).Info("Dry run: skipped pushing credentials", "secretRef", secretRef)
+	return nil
+}
+
+func (k *DryRunCredentialsSink) Remove(ctx context.Context, secretRef domain.NamespacedName) error {
+	logf.FromContext(ctx).Info("Dry run: skipped removing pushed credentials", "secretRef", secretRef)
+	return nil
+}
+
+// DryRunWorkloadRestarter skips rolling out workloads, logging the workloads that would have been restarted.
+type DryRunWorkloadRestarter struct{}
+
+func NewDryRunWorkloadRestarter() *DryRunWorkloadRestarter {
+	return &DryRunWorkloadRestarter{}
+}
+
+func (k *DryRunWorkloadRestarter) Restart(ctx context.Context, namespace domain.Namespace, target v1alpha1.RestartTarget, key string, _ string) (bool, error) {
+	logf.FromContext(ctx).Info("Dry run: skipped restarting workload",
+		"namespace", namespace, "kind", target.Kind, "name", target.Name, "annotation", key)
+	return true, nil
+}
+
+// Compile-time assertions that implementations satisfy the ports interfaces
+var _ outbound.SecretClient = (*DryRunSecretClient)(nil)
+var _ outbound.CredentialsSink = (*DryRunCredentialsSink)(nil)
+var _ outbound.WorkloadRestarter = (*DryRunWorkloadRestarter)(nil)
@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDryRunSecretClient_ShouldSkipWrites_AndPassThroughReads(t *testing.T) {
+	ctx := context.Background()
+	secretRef := domain.NewNamespacedName("account-namespace", "account-root")
+	client := &secretClientFake{data: map[string]string{DefaultSecretKeyName: "seed"}}
+	unitUnderTest := NewDryRunSecretClient(client)
+
+	require.NoError(t, unitUnderTest.Apply(ctx, nil, metav1.ObjectMeta{Namespace: "account-namespace", Name: "account-root"}, nil))
+	require.NoError(t, unitUnderTest.ApplyAll(ctx, nil, []outbound.SecretApply{{Meta: metav1.ObjectMeta{Name: "account-sign"}}}))
+	require.NoError(t, unitUnderTest.Label(ctx, secretRef, map[string]string{LabelManaged: LabelManagedValue}))
+	require.NoError(t, unitUnderTest.Delete(ctx, secretRef))
+	require.NoError(t, unitUnderTest.DeleteByLabels(ctx, "account-namespace", map[string]string{LabelManaged: LabelManagedValue}))
+	data, found, err := unitUnderTest.Get(ctx, secretRef)
+
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "seed", data[DefaultSecretKeyName])
+	require.Zero(t, client.writes)
+}
+
+type secretClientFake struct {
+	outbound.SecretClient
+	data   map[string]string
+	writes int
+}
+
+func (c *secretClientFake) Get(_ context.Context, _ domain.NamespacedName) (map[string]string, bool, error) {
+	return c.data, true, nil
+}
+
+func (c *secretClientFake) Apply(_ context.Context, _ metav1.Object, _ metav1.ObjectMeta, _ map[string]string) error {
+	c.writes++
+	return nil
+}
+
+func (c *secretClientFake) ApplyAll(_ context.Context, _ metav1.Object, _ []outbound.SecretApply) error {
+	c.writes++
+	return nil
+}
+
+func (c *secretClientFake) Delete(_ context.Context, _ domain.NamespacedName) error {
+	c.writes++
+	return nil
+}
+
+func (c *secretClientFake) DeleteByLabels(_ context.Context, _ domain.Namespace, _ map[string]string) error {
+	c.writes++
+	return nil
+}
+
+func (c *secretClientFake) Label(_ context.Context, _ domain.NamespacedName, _ map[string]string) error {
+	c.writes++
+	return nil
+}
@@ -1,7 +1,24 @@
 package k8s
 
+import (
+	"github.com/WirelessCar/nauth/internal/domain"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
 const (
-	LabelSecretType   = "nauth.io/secret-type"
-	LabelManaged      = "nauth.io/managed"
-	LabelManagedValue = "true"
+	LabelSecretType               = domain.LabelSecretType
+	LabelManaged                  = domain.LabelManaged
+	LabelManagedValue             = domain.LabelManagedValue
+	AnnotationCredentialsChecksum = domain.AnnotationCredentialsChecksum
 )
+
+// SecretInformerSelector selects the Secrets watched by the manager, those written or labelled by NAuth, so that it does
+// not list and watch every Secret of the cluster. Secrets are read directly from the API server.
+func SecretInformerSelector() labels.Selector {
+	requirement, err := labels.NewRequirement(LabelSecretType, selection.Exists, nil)
+	if err != nil {
+		panic(err)
+	}
+	return labels.NewSelector().Add(*requirement)
+}
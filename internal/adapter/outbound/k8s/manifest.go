@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestWriter writes resources as a multi-document YAML stream, which can be applied with kubectl apply -f.
+type ManifestWriter struct {
+	out       io.Writer
+	documents int
+}
+
+func NewManifestWriter(out io.Writer) *ManifestWriter {
+	return &ManifestWriter{out: out}
+}
+
+func (w *ManifestWriter) WriteAccount(account *v1alpha1.Account) error {
+	result := account.DeepCopy()
+	result.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "Account"}
+	return w.write(result)
+}
+
+func (w *ManifestWriter) WriteUser(user *v1alpha1.User) error {
+	result := user.DeepCopy()
+	result.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "User"}
+	return w.write(result)
+}
+
+func (w *ManifestWriter) WriteSecret(secret outbound.SecretApply) error {
+	return w.write(&v1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: secret.Meta,
+		StringData: secret.Data,
+	})
+}
+
+// write writes object without its status and without the fields only set by the API server.
+func (w *ManifestWriter) write(object any) error {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	var manifest map[string]any
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	delete(manifest, "status")
+	if metadata, ok := manifest["metadata"].(map[string]any); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	document, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if w.documents > 0 {
+		document = append([]byte("---\n"), document...)
+	}
+	if _, err := w.out.Write(document); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	w.documents++
+	return nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.ManifestWriter = (*ManifestWriter)(nil)
@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestManifestWriter(t *testing.T) {
+	var out bytes.Buffer
+	unitUnderTest := NewManifestWriter(&out)
+
+	require.NoError(t, unitUnderTest.WriteAccount(&v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "team-a"},
+		Spec:       v1alpha1.AccountSpec{DisplayName: "Orders"},
+	}))
+	require.NoError(t, unitUnderTest.WriteUser(&v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec:       v1alpha1.UserSpec{AccountName: "orders"},
+	}))
+	require.NoError(t, unitUnderTest.WriteSecret(outbound.SecretApply{
+		Meta: metav1.ObjectMeta{Name: "app-nats-user-creds", Namespace: "team-a", Labels: map[string]string{
+			LabelSecretType: SecretTypeUserCredentials,
+		}},
+		Data: map[string]string{UserCredentialSecretKeyName: "creds"},
+	}))
+
+	require.Equal(t, `apiVersion: nauth.io/v1alpha1
+kind: Account
+metadata:
+  name: orders
+  namespace: team-a
+spec:
+  displayName: Orders
+---
+apiVersion: nauth.io/v1alpha1
+kind: User
+metadata:
+  name: app
+  namespace: team-a
+spec:
+  accountName: orders
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  labels:
+    nauth.io/secret-type: user-creds
+  name: app-nats-user-creds
+  namespace: team-a
+stringData:
+  user.creds: creds
+`, out.String())
+}
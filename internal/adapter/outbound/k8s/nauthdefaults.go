@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NauthDefaultsClient reads the cluster-scoped NauthDefaults resource.
+type NauthDefaultsClient struct {
+	client client.Reader
+}
+
+// NewNauthDefaultsClient creates a new NauthDefaults client.
+func NewNauthDefaultsClient(c client.Reader) *NauthDefaultsClient {
+	return &NauthDefaultsClient{client: c}
+}
+
+func (c *NauthDefaultsClient) GetNauthDefaults(ctx context.Context) (*v1alpha1.NauthDefaultsSpec, error) {
+	defaults := &v1alpha1.NauthDefaults{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: v1alpha1.NauthDefaultsName}, defaults); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, domain.ErrUnknownError.WithCause(fmt.Errorf("failed to get NauthDefaults %q: %w", v1alpha1.NauthDefaultsName, err))
+	}
+	return &defaults.Spec, nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.NauthDefaultsReader = (*NauthDefaultsClient)(nil)
@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NauthDefaultsClientTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	unitUnderTest *NauthDefaultsClient
+}
+
+func TestNauthDefaultsClient_TestSuite(t *testing.T) {
+	suite.Run(t, new(NauthDefaultsClientTestSuite))
+}
+
+func (t *NauthDefaultsClientTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.unitUnderTest = NewNauthDefaultsClient(k8sClient)
+}
+
+func (t *NauthDefaultsClientTestSuite) Test_GetNauthDefaults_ShouldReturnNil_WhenNotFound() {
+	result, err := t.unitUnderTest.GetNauthDefaults(t.ctx)
+
+	t.NoError(err)
+	t.Nil(result)
+}
+
+func (t *NauthDefaultsClientTestSuite) Test_GetNauthDefaults_ShouldReturnSpec_WhenFound() {
+	subs := int64(100)
+	defaults := &v1alpha1.NauthDefaults{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.NauthDefaultsName},
+		Spec: v1alpha1.NauthDefaultsSpec{
+			User: &v1alpha1.UserPlatformDefaults{
+				NatsLimits: &v1alpha1.NatsLimits{Subs: &subs},
+			},
+			RequiredLabels: []string{"team"},
+		},
+	}
+	t.Require().NoError(k8sClient.Create(t.ctx, defaults))
+	defer func() {
+		t.Require().NoError(k8sClient.Delete(t.ctx, defaults))
+	}()
+
+	result, err := t.unitUnderTest.GetNauthDefaults(t.ctx)
+
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.Equal([]string{"team"}, result.RequiredLabels)
+	t.Equal(int64(100), *result.User.NatsLimits.Subs)
+}
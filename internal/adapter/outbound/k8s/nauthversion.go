@@ -0,0 +1,69 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type NauthVersionClient struct {
+	client client.Client
+	reader client.Reader
+}
+
+// NewNauthVersionClient creates a new NauthVersion client. The reader should bypass the manager cache
+// (e.g. mgr.GetAPIReader()) as the operator is only granted get/create on NauthVersions, not list/watch.
+func NewNauthVersionClient(client client.Client, reader client.Reader) *NauthVersionClient {
+	return &NauthVersionClient{
+		client: client,
+		reader: reader,
+	}
+}
+
+// WriteCapabilities creates or updates the cluster-scoped NauthVersion named after the operator namespace.
+func (c *NauthVersionClient) WriteCapabilities(ctx context.Context, capabilities nauth.Capabilities) error {
+	if err := capabilities.Validate(); err != nil {
+		return fmt.Errorf("invalid capabilities: %w", err)
+	}
+
+	nauthVersion := &v1alpha1.NauthVersion{}
+	key := client.ObjectKey{Name: capabilities.OperatorNamespace}
+	if err := c.reader.Get(ctx, key, nauthVersion); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get NauthVersion %q: %w", key.Name, err)
+		}
+		nauthVersion = &v1alpha1.NauthVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: key.Name,
+				Labels: map[string]string{
+					LabelManaged: LabelManagedValue,
+				},
+			},
+		}
+		if err = c.client.Create(ctx, nauthVersion); err != nil {
+			return fmt.Errorf("failed to create NauthVersion %q: %w", key.Name, err)
+		}
+	}
+
+	nauthVersion.Status = v1alpha1.NauthVersionStatus{
+		OperatorVersion:   capabilities.OperatorVersion,
+		OperatorNamespace: capabilities.OperatorNamespace,
+		FeatureGates:      capabilities.FeatureGates,
+		APIVersions:       capabilities.APIVersions,
+		ProviderTypes:     capabilities.ProviderTypes,
+		CryptoPolicy:      capabilities.CryptoPolicy,
+		StartTimestamp:    metav1.NewTime(capabilities.StartTime),
+	}
+	if err := c.client.Status().Update(ctx, nauthVersion); err != nil {
+		return fmt.Errorf("failed to update NauthVersion %q status: %w", key.Name, err)
+	}
+	return nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.CapabilitiesWriter = (*NauthVersionClient)(nil)
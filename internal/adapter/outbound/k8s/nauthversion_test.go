@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type NauthVersionClientTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	capabilities nauth.Capabilities
+
+	unitUnderTest *NauthVersionClient
+}
+
+func TestNauthVersionClient_TestSuite(t *testing.T) {
+	suite.Run(t, new(NauthVersionClientTestSuite))
+}
+
+func (t *NauthVersionClientTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.capabilities = nauth.Capabilities{
+		OperatorVersion:   "1.2.3",
+		OperatorNamespace: "nauth-version-test",
+		FeatureGates:      map[string]bool{"StrictCryptoPolicy": false},
+		APIVersions:       []string{"nauth.io/v1alpha1"},
+		ProviderTypes:     []string{"nats-resolver"},
+		CryptoPolicy:      "default",
+		StartTime:         time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	t.unitUnderTest = NewNauthVersionClient(k8sClient, k8sClient)
+	t.Require().NoError(t.cleanNauthVersion())
+}
+
+func (t *NauthVersionClientTestSuite) TearDownTest() {
+	t.Require().NoError(t.cleanNauthVersion())
+}
+
+func (t *NauthVersionClientTestSuite) Test_WriteCapabilities_ShouldCreate_WhenNauthVersionDoesNotExist() {
+	err := t.unitUnderTest.WriteCapabilities(t.ctx, t.capabilities)
+
+	t.Require().NoError(err)
+	result := &v1alpha1.NauthVersion{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Name: t.capabilities.OperatorNamespace}, result))
+	t.Equal("1.2.3", result.Status.OperatorVersion)
+	t.Equal([]string{"nauth.io/v1alpha1"}, result.Status.APIVersions)
+	t.Equal(map[string]bool{"StrictCryptoPolicy": false}, result.Status.FeatureGates)
+	t.Equal(LabelManagedValue, result.GetLabels()[LabelManaged])
+}
+
+func (t *NauthVersionClientTestSuite) Test_WriteCapabilities_ShouldUpdate_WhenNauthVersionExists() {
+	t.Require().NoError(t.unitUnderTest.WriteCapabilities(t.ctx, t.capabilities))
+	t.capabilities.OperatorVersion = "1.2.4"
+
+	err := t.unitUnderTest.WriteCapabilities(t.ctx, t.capabilities)
+
+	t.Require().NoError(err)
+	result := &v1alpha1.NauthVersion{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Name: t.capabilities.OperatorNamespace}, result))
+	t.Equal("1.2.4", result.Status.OperatorVersion)
+}
+
+func (t *NauthVersionClientTestSuite) Test_WriteCapabilities_ShouldFail_WhenCapabilitiesAreInvalid() {
+	err := t.unitUnderTest.WriteCapabilities(t.ctx, nauth.Capabilities{})
+
+	t.ErrorContains(err, "invalid capabilities")
+}
+
+func (t *NauthVersionClientTestSuite) cleanNauthVersion() error {
+	nauthVersion := &v1alpha1.NauthVersion{}
+	nauthVersion.Name = t.capabilities.OperatorNamespace
+	return client.IgnoreNotFound(k8sClient.Delete(t.ctx, nauthVersion))
+}
@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PermissionSetClient reads PermissionSet resources from the cluster.
+type PermissionSetClient struct {
+	client client.Reader
+}
+
+// NewPermissionSetClient creates a new PermissionSet client.
+func NewPermissionSetClient(c client.Reader) *PermissionSetClient {
+	return &PermissionSetClient{client: c}
+}
+
+// Get returns the referenced PermissionSet.
+func (c *PermissionSetClient) Get(ctx context.Context, permissionSetRef domain.NamespacedName) (*v1alpha1.PermissionSet, error) {
+	if err := permissionSetRef.Validate(); err != nil {
+		return nil, domain.ErrBadRequest.WithCause(fmt.Errorf("invalid PermissionSet reference %q: %w", permissionSetRef, err))
+	}
+	permissionSet := &v1alpha1.PermissionSet{}
+	key := client.ObjectKey{Namespace: permissionSetRef.Namespace, Name: permissionSetRef.Name}
+	if err := c.client.Get(ctx, key, permissionSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, domain.ErrPermissionSetNotFound.WithCause(fmt.Errorf("PermissionSet %s not found", permissionSetRef))
+		}
+		return nil, domain.ErrUnknownError.WithCause(fmt.Errorf("failed to get PermissionSet %s: %w", permissionSetRef, err))
+	}
+	return permissionSet, nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.PermissionSetReader = (*PermissionSetClient)(nil)
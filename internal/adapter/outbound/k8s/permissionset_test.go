@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PermissionSetClientTestSuite struct {
+	suite.Suite
+	ctx              context.Context
+	permissionSetRef domain.NamespacedName
+
+	unitUnderTest *PermissionSetClient
+}
+
+func TestPermissionSetClient_TestSuite(t *testing.T) {
+	suite.Run(t, new(PermissionSetClientTestSuite))
+}
+
+func (t *PermissionSetClientTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.permissionSetRef = domain.NewNamespacedName(testNamespace, testutil.SanitizeTestName(t.T().Name()))
+	t.Require().NoError(t.permissionSetRef.Validate())
+	t.unitUnderTest = NewPermissionSetClient(k8sClient)
+}
+
+func (t *PermissionSetClientTestSuite) Test_Get_ShouldFail_WhenPermissionSetDoesNotExist() {
+	result, err := t.unitUnderTest.Get(t.ctx, domain.NewNamespacedName(testNamespace, "non-existing-permission-set"))
+
+	t.ErrorIs(err, domain.ErrPermissionSetNotFound)
+	t.Nil(result)
+}
+
+func (t *PermissionSetClientTestSuite) Test_Get_ShouldFail_WhenReferenceIsInvalid() {
+	result, err := t.unitUnderTest.Get(t.ctx, domain.NewNamespacedName("", "missing-namespace"))
+
+	t.ErrorIs(err, domain.ErrBadRequest)
+	t.Nil(result)
+}
+
+func (t *PermissionSetClientTestSuite) Test_Get_ShouldSucceed() {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.PermissionSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.permissionSetRef.Name,
+			Namespace: t.permissionSetRef.Namespace,
+		},
+		Spec: v1alpha1.PermissionSetSpec{
+			Permissions: v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+		},
+	}))
+
+	result, err := t.unitUnderTest.Get(t.ctx, t.permissionSetRef)
+
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.Equal(v1alpha1.StringList{"orders.>"}, result.Spec.Permissions.Pub.Allow)
+}
@@ -0,0 +1,141 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PushSecretGVK is the External Secrets Operator PushSecret, handled as unstructured to not depend on the operator.
+var PushSecretGVK = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1alpha1", Kind: "PushSecret"}
+
+const defaultPushSecretRefreshInterval = time.Minute
+
+// PushSecretClient delivers user credentials to external secret stores with PushSecrets of the External Secrets
+// Operator. The PushSecret is named after, and owned by the owner of, the credentials Secret.
+type PushSecretClient struct {
+	client client.Client
+}
+
+func NewPushSecretClient(client client.Client) *PushSecretClient {
+	return &PushSecretClient{
+		client: client,
+	}
+}
+
+func (k *PushSecretClient) Push(ctx context.Context, owner metav1.Object, secretRef domain.NamespacedName, sink v1alpha1.CredentialsSink) error {
+	if err := secretRef.Validate(); err != nil {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("invalid secret reference %q: %w", secretRef, err))
+	}
+	push := sink.ExternalSecretsPush
+	if push == nil {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("credentials sink of secret %s has no supported sink", secretRef))
+	}
+
+	pushSecret := newPushSecret(secretRef)
+	_, err := controllerutil.CreateOrUpdate(ctx, k.client, pushSecret, func() error {
+		if pushSecret.GetResourceVersion() != "" && pushSecret.GetLabels()[LabelManaged] != LabelManagedValue {
+			return fmt.Errorf("existing PushSecret %s not managed by nauth", secretRef)
+		}
+		labels := pushSecret.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[LabelManaged] = LabelManagedValue
+		pushSecret.SetLabels(labels)
+		if err := unstructured.SetNestedField(pushSecret.Object, pushSecretSpec(secretRef, push), "spec"); err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(owner, pushSecret, k.client.Scheme())
+	})
+	if meta.IsNoMatchError(err) {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("failed to apply PushSecret %s, is the External Secrets Operator installed: %w", secretRef, err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply PushSecret %s: %w", secretRef, err)
+	}
+	return nil
+}
+
+func (k *PushSecretClient) Remove(ctx context.Context, secretRef domain.NamespacedName) error {
+	if err := secretRef.Validate(); err != nil {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("invalid secret reference %q: %w", secretRef, err))
+	}
+	pushSecret := newPushSecret(secretRef)
+	if err := k.client.Get(ctx, client.ObjectKeyFromObject(pushSecret), pushSecret); err != nil {
+		// Nothing was pushed when the External Secrets Operator is not installed
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PushSecret %s: %w", secretRef, err)
+	}
+	if pushSecret.GetLabels()[LabelManaged] != LabelManagedValue {
+		return nil
+	}
+
+	logf.FromContext(ctx).Info("Deleting PushSecret", "secretRef", secretRef)
+	if err := k.client.Delete(ctx, pushSecret); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PushSecret %s: %w", secretRef, err)
+	}
+	return nil
+}
+
+func newPushSecret(secretRef domain.NamespacedName) *unstructured.Unstructured {
+	pushSecret := &unstructured.Unstructured{}
+	pushSecret.SetGroupVersionKind(PushSecretGVK)
+	pushSecret.SetNamespace(secretRef.Namespace)
+	pushSecret.SetName(secretRef.Name)
+	return pushSecret
+}
+
+func pushSecretSpec(secretRef domain.NamespacedName, push *v1alpha1.ExternalSecretsPushSink) map[string]any {
+	refreshInterval := defaultPushSecretRefreshInterval
+	if push.RefreshInterval != nil {
+		refreshInterval = push.RefreshInterval.Duration
+	}
+	storeKind := push.SecretStoreRef.Kind
+	if storeKind == "" {
+		storeKind = "SecretStore"
+	}
+	return map[string]any{
+		"refreshInterval": refreshInterval.String(),
+		// Remove the credentials from the secret store together with the PushSecret
+		"deletionPolicy": "Delete",
+		"secretStoreRefs": []any{
+			map[string]any{
+				"name": push.SecretStoreRef.Name,
+				"kind": storeKind,
+			},
+		},
+		"selector": map[string]any{
+			"secret": map[string]any{
+				"name": secretRef.Name,
+			},
+		},
+		"data": []any{
+			map[string]any{
+				"match": map[string]any{
+					"secretKey": UserCredentialSecretKeyName,
+					"remoteRef": map[string]any{
+						"remoteKey": push.RemoteKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.CredentialsSink = (*PushSecretClient)(nil)
@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPushSecretClient_Push(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := newPushSecretFakeClient(t)
+	unitUnderTest := NewPushSecretClient(k8sClient)
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "my-user", Namespace: "my-namespace", UID: "user-uid"}}
+	secretRef := domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds")
+	sink := v1alpha1.CredentialsSink{
+		ExternalSecretsPush: &v1alpha1.ExternalSecretsPushSink{
+			SecretStoreRef: v1alpha1.SecretStoreReference{Name: "aws-secrets-manager", Kind: "ClusterSecretStore"},
+			RemoteKey:      "nats/my-user",
+		},
+	}
+
+	require.NoError(t, unitUnderTest.Push(ctx, user, secretRef, sink))
+
+	pushSecret := getPushSecret(t, k8sClient, secretRef)
+	require.Equal(t, LabelManagedValue, pushSecret.GetLabels()[LabelManaged])
+	require.Len(t, pushSecret.GetOwnerReferences(), 1)
+	require.Equal(t, user.UID, pushSecret.GetOwnerReferences()[0].UID)
+	require.Equal(t, map[string]any{
+		"refreshInterval": "1m0s",
+		"deletionPolicy":  "Delete",
+		"secretStoreRefs": []any{map[string]any{"name": "aws-secrets-manager", "kind": "ClusterSecretStore"}},
+		"selector":        map[string]any{"secret": map[string]any{"name": "my-user-nats-user-creds"}},
+		"data": []any{map[string]any{"match": map[string]any{
+			"secretKey": UserCredentialSecretKeyName,
+			"remoteRef": map[string]any{"remoteKey": "nats/my-user"},
+		}}},
+	}, pushSecret.Object["spec"])
+
+	// Update
+	sink.ExternalSecretsPush.RefreshInterval = &metav1.Duration{Duration: 10 * time.Second}
+	require.NoError(t, unitUnderTest.Push(ctx, user, secretRef, sink))
+
+	pushSecret = getPushSecret(t, k8sClient, secretRef)
+	refreshInterval, _, _ := unstructured.NestedString(pushSecret.Object, "spec", "refreshInterval")
+	require.Equal(t, "10s", refreshInterval)
+}
+
+func TestPushSecretClient_Push_ShouldFail_WhenPushSecretNotManaged(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := newPushSecretFakeClient(t)
+	secretRef := domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds")
+	require.NoError(t, k8sClient.Create(ctx, newPushSecret(secretRef)))
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "my-user", Namespace: "my-namespace", UID: "user-uid"}}
+
+	err := NewPushSecretClient(k8sClient).Push(ctx, user, secretRef, v1alpha1.CredentialsSink{
+		ExternalSecretsPush: &v1alpha1.ExternalSecretsPushSink{
+			SecretStoreRef: v1alpha1.SecretStoreReference{Name: "aws-secrets-manager"},
+			RemoteKey:      "nats/my-user",
+		},
+	})
+
+	require.ErrorContains(t, err, "not managed by nauth")
+}
+
+func TestPushSecretClient_Remove(t *testing.T) {
+	testCases := []struct {
+		name            string
+		labels          map[string]string
+		expectedDeleted bool
+	}{
+		{
+			name:            "managed",
+			labels:          map[string]string{LabelManaged: LabelManagedValue},
+			expectedDeleted: true,
+		},
+		{
+			name:            "not_managed",
+			expectedDeleted: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := newPushSecretFakeClient(t)
+			secretRef := domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds")
+			pushSecret := newPushSecret(secretRef)
+			pushSecret.SetLabels(tc.labels)
+			require.NoError(t, k8sClient.Create(ctx, pushSecret))
+
+			require.NoError(t, NewPushSecretClient(k8sClient).Remove(ctx, secretRef))
+
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pushSecret), newPushSecret(secretRef))
+			require.Equal(t, tc.expectedDeleted, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestPushSecretClient_Remove_ShouldSucceed_WhenPushSecretDoesNotExist(t *testing.T) {
+	err := NewPushSecretClient(newPushSecretFakeClient(t)).
+		Remove(context.Background(), domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds"))
+
+	require.NoError(t, err)
+}
+
+func newPushSecretFakeClient(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(PushSecretGVK, meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(restMapper).
+		Build()
+}
+
+func getPushSecret(t *testing.T, k8sClient client.Client, secretRef domain.NamespacedName) *unstructured.Unstructured {
+	pushSecret := newPushSecret(secretRef)
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pushSecret), pushSecret))
+	return pushSecret
+}
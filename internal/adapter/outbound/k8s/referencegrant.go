@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ReferenceGrantReader interface {
+	// CheckAccountReference returns domain.ErrReferenceNotGranted when a resource of fromKind in fromNamespace may not
+	// reference the Account accountRef. References within the same namespace are always allowed.
+	CheckAccountReference(ctx context.Context, fromKind v1alpha1.ReferenceGrantFromKind, fromNamespace domain.Namespace, accountRef domain.NamespacedName) error
+}
+
+// ReferenceGrantClient evaluates ReferenceGrant resources in the cluster.
+type ReferenceGrantClient struct {
+	client client.Reader
+}
+
+// NewReferenceGrantClient creates a new ReferenceGrant client.
+func NewReferenceGrantClient(c client.Reader) *ReferenceGrantClient {
+	return &ReferenceGrantClient{client: c}
+}
+
+func (c *ReferenceGrantClient) CheckAccountReference(ctx context.Context, fromKind v1alpha1.ReferenceGrantFromKind, fromNamespace domain.Namespace, accountRef domain.NamespacedName) error {
+	if accountRef.GetNamespace() == fromNamespace {
+		return nil
+	}
+	grants := &v1alpha1.ReferenceGrantList{}
+	if err := c.client.List(ctx, grants, client.InNamespace(accountRef.Namespace)); err != nil {
+		return domain.ErrUnknownError.WithCause(fmt.Errorf("failed to list ReferenceGrants in namespace %q: %w", accountRef.Namespace, err))
+	}
+	for _, grant := range grants.Items {
+		if grantsFrom(grant.Spec, fromKind, fromNamespace) && grantsTo(grant.Spec, v1alpha1.ReferenceGrantToKindAccount, accountRef.Name) {
+			return nil
+		}
+	}
+	return domain.ErrReferenceNotGranted.WithCause(fmt.Errorf("no ReferenceGrant in namespace %q allows %s in namespace %q to reference Account %s",
+		accountRef.Namespace, fromKind, fromNamespace, accountRef))
+}
+
+func grantsFrom(spec v1alpha1.ReferenceGrantSpec, kind v1alpha1.ReferenceGrantFromKind, namespace domain.Namespace) bool {
+	for _, from := range spec.From {
+		if from.Kind == kind && domain.Namespace(from.Namespace) == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func grantsTo(spec v1alpha1.ReferenceGrantSpec, kind v1alpha1.ReferenceGrantToKind, name string) bool {
+	for _, to := range spec.To {
+		if to.Kind == kind && (to.Name == "" || to.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile-time assertion that implementation satisfies the interface
+var _ ReferenceGrantReader = (*ReferenceGrantClient)(nil)
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReferenceGrantClientTestSuite struct {
+	suite.Suite
+	ctx           context.Context
+	fromNamespace domain.Namespace
+	accountRef    domain.NamespacedName
+
+	unitUnderTest *ReferenceGrantClient
+}
+
+func TestReferenceGrantClient_TestSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceGrantClientTestSuite))
+}
+
+func (t *ReferenceGrantClientTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.fromNamespace = domain.Namespace(testutil.ScopedTestName("from", t.T().Name()))
+	t.accountRef = domain.NewNamespacedName(testutil.ScopedTestName("to", t.T().Name()), "export-account")
+	t.Require().NoError(t.accountRef.Validate())
+	t.Require().NoError(ensureNamespace(t.ctx, t.accountRef.Namespace))
+
+	t.unitUnderTest = NewReferenceGrantClient(k8sClient)
+}
+
+func (t *ReferenceGrantClientTestSuite) Test_CheckAccountReference_ShouldSucceed_WhenSameNamespace() {
+	err := t.unitUnderTest.CheckAccountReference(t.ctx, v1alpha1.ReferenceGrantFromKindAccount,
+		t.accountRef.GetNamespace(), t.accountRef)
+
+	t.NoError(err)
+}
+
+func (t *ReferenceGrantClientTestSuite) Test_CheckAccountReference_ShouldFail_WhenNoReferenceGrant() {
+	err := t.unitUnderTest.CheckAccountReference(t.ctx, v1alpha1.ReferenceGrantFromKindAccount, t.fromNamespace, t.accountRef)
+
+	t.ErrorIs(err, domain.ErrReferenceNotGranted)
+}
+
+func (t *ReferenceGrantClientTestSuite) Test_CheckAccountReference_ShouldSucceed_WhenGranted() {
+	t.createReferenceGrant(v1alpha1.ReferenceGrantFromKindAccountImport, "")
+
+	err := t.unitUnderTest.CheckAccountReference(t.ctx, v1alpha1.ReferenceGrantFromKindAccountImport, t.fromNamespace, t.accountRef)
+
+	t.NoError(err)
+}
+
+func (t *ReferenceGrantClientTestSuite) Test_CheckAccountReference_ShouldFail_WhenGrantedToOtherKind() {
+	t.createReferenceGrant(v1alpha1.ReferenceGrantFromKindAccountImport, "")
+
+	err := t.unitUnderTest.CheckAccountReference(t.ctx, v1alpha1.ReferenceGrantFromKindAccount, t.fromNamespace, t.accountRef)
+
+	t.ErrorIs(err, domain.ErrReferenceNotGranted)
+}
+
+func (t *ReferenceGrantClientTestSuite) Test_CheckAccountReference_ShouldFail_WhenGrantedToOtherAccount() {
+	t.createReferenceGrant(v1alpha1.ReferenceGrantFromKindAccount, "other-account")
+
+	err := t.unitUnderTest.CheckAccountReference(t.ctx, v1alpha1.ReferenceGrantFromKindAccount, t.fromNamespace, t.accountRef)
+
+	t.ErrorIs(err, domain.ErrReferenceNotGranted)
+}
+
+func (t *ReferenceGrantClientTestSuite) createReferenceGrant(fromKind v1alpha1.ReferenceGrantFromKind, toName string) {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant",
+			Namespace: t.accountRef.Namespace,
+		},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: fromKind, Namespace: string(t.fromNamespace)}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: v1alpha1.ReferenceGrantToKindAccount, Name: toName}},
+		},
+	}))
+}
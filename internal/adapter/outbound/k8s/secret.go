@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 
@@ -11,8 +12,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -26,6 +30,11 @@ func NewSecretClient(client client.Client) *SecretClient {
 	}
 }
 
+// secretFieldOwner is the server-side apply field manager of Secrets, so fields written by other controllers, e.g.
+// annotations of a secret replicator, are left alone.
+const secretFieldOwner = client.FieldOwner("nauth")
+
+// Apply creates or updates a Secret managed by NAuth with server-side apply. Keys not in valueMap are removed.
 func (k *SecretClient) Apply(ctx context.Context, owner metav1.Object, meta metav1.ObjectMeta, valueMap map[string]string) error {
 	if !isManagedSecret(&meta) {
 		return fmt.Errorf("label %s not supplied by secret %s/%s", LabelManaged, meta.Namespace, meta.Name)
@@ -36,71 +45,101 @@ func (k *SecretClient) Apply(ctx context.Context, owner metav1.Object, meta meta
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to get secret: %w", err)
 		}
-		newSecret := &v1.Secret{
-			ObjectMeta: meta,
-			StringData: valueMap,
-		}
-		if owner != nil {
-			if err := controllerutil.SetControllerReference(owner, newSecret, k.client.Scheme()); err != nil {
-				return fmt.Errorf("failed to link secret to owner: %w", err)
-			}
-		}
-
-		if err := k.client.Create(ctx, newSecret); err != nil {
-			return fmt.Errorf("failed to create secret: %w", err)
-		}
-	} else {
-		if !isManagedSecret(&currentSecret.ObjectMeta) {
-			return fmt.Errorf("existing secret %s/%s not managed by nauth", meta.Namespace, meta.Name)
-		}
-		maps.Insert(currentSecret.Labels, maps.All(meta.Labels))
+		currentSecret = nil
+	} else if !isManagedSecret(&currentSecret.ObjectMeta) {
+		return fmt.Errorf("existing secret %s/%s not managed by nauth", meta.Namespace, meta.Name)
+	}
 
-		currentSecret.StringData = valueMap
-		if err := addOwnerReferenceIfNotExists(currentSecret, owner); err != nil {
+	data := make(map[string][]byte, len(valueMap))
+	for key, value := range valueMap {
+		data[key] = []byte(value)
+	}
+	secret := corev1ac.Secret(meta.Name, meta.Namespace).
+		WithLabels(meta.Labels).
+		WithData(data)
+	if len(meta.Annotations) > 0 {
+		secret.WithAnnotations(meta.Annotations)
+	}
+	if owner != nil {
+		ownerRef, err := k.ownerReference(owner, currentSecret)
+		if err != nil {
 			return err
 		}
+		secret.WithOwnerReferences(ownerRef)
+	}
+	if err := k.client.Apply(ctx, secret, secretFieldOwner, client.ForceOwnership); err != nil {
+		return fmt.Errorf("failed to apply secret: %w", err)
+	}
 
-		err = k.client.Update(ctx, currentSecret)
-		if err != nil {
-			return fmt.Errorf("failed to update secret: %w", err)
+	// Keys written before the Secret was applied server-side are not owned by NAuth, so apply does not remove them,
+	// e.g. the seed of a user switched to bearer JWTs
+	if currentSecret != nil {
+		if err := k.removeStaleKeys(ctx, currentSecret, valueMap); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
-func addOwnerReferenceIfNotExists(secret *v1.Secret, owner metav1.Object) error {
-	if owner == nil {
-		return nil
+// ApplyAll applies secrets in order with Apply, stopping at the first failure.
+func (k *SecretClient) ApplyAll(ctx context.Context, owner metav1.Object, secrets []outbound.SecretApply) error {
+	for _, secret := range secrets {
+		if err := k.Apply(ctx, owner, secret.Meta, secret.Data); err != nil {
+			return fmt.Errorf("failed to apply secret %s/%s: %w", secret.Meta.Namespace, secret.Meta.Name, err)
+		}
 	}
+	return nil
+}
 
+// ownerReference returns the reference to owner to apply to the Secret. A new Secret is controlled by owner, while
+// an existing Secret keeps its reference to owner as is or gets a plain one, as it may be controlled by another owner.
+func (k *SecretClient) ownerReference(owner metav1.Object, currentSecret *v1.Secret) (*metav1ac.OwnerReferenceApplyConfiguration, error) {
 	rtObj, ok := owner.(runtime.Object)
-
 	if !ok {
-		return fmt.Errorf("owner does not implement runtime.Object")
+		return nil, fmt.Errorf("owner does not implement runtime.Object")
 	}
-
-	ownerGVK := rtObj.GetObjectKind().GroupVersionKind()
-	ownerRef := metav1.OwnerReference{
-		APIVersion: ownerGVK.GroupVersion().String(),
-		Kind:       ownerGVK.Kind,
-		Name:       owner.GetName(),
-		UID:        owner.GetUID(),
+	ownerGVK, err := apiutil.GVKForObject(rtObj, k.client.Scheme())
+	if err != nil {
+		return nil, fmt.Errorf("failed to link secret to owner: %w", err)
+	}
+	ownerRef := metav1ac.OwnerReference().
+		WithAPIVersion(ownerGVK.GroupVersion().String()).
+		WithKind(ownerGVK.Kind).
+		WithName(owner.GetName()).
+		WithUID(owner.GetUID())
+
+	controller := currentSecret == nil
+	if currentSecret != nil {
+		for _, ref := range currentSecret.OwnerReferences {
+			if ref.UID == owner.GetUID() {
+				controller = ref.Controller != nil && *ref.Controller
+				break
+			}
+		}
 	}
+	if controller {
+		ownerRef.WithController(true).WithBlockOwnerDeletion(true)
+	}
+	return ownerRef, nil
+}
 
-	alreadyExists := false
-
-	for _, ref := range secret.OwnerReferences {
-		if ref.UID == ownerRef.UID && ref.Kind == ownerRef.Kind && ref.APIVersion == ownerRef.APIVersion && ref.Name == ownerRef.Name {
-			alreadyExists = true
-			break
+func (k *SecretClient) removeStaleKeys(ctx context.Context, currentSecret *v1.Secret, valueMap map[string]string) error {
+	staleKeys := make(map[string]any)
+	for key := range currentSecret.Data {
+		if _, ok := valueMap[key]; !ok {
+			staleKeys[key] = nil
 		}
 	}
-
-	if !alreadyExists {
-		secret.OwnerReferences = append(secret.OwnerReferences, ownerRef)
+	if len(staleKeys) == 0 {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]any{"data": staleKeys})
+	if err != nil {
+		return fmt.Errorf("failed to create secret patch: %w", err)
+	}
+	if err := k.client.Patch(ctx, currentSecret, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to remove stale secret keys: %w", err)
 	}
-
 	return nil
 }
 
@@ -144,24 +183,17 @@ func (k *SecretClient) Delete(ctx context.Context, secretRef domain.NamespacedNa
 	return nil
 }
 
+// DeleteByLabels deletes the Secrets in namespace matching labels with a single delete collection request.
 func (k *SecretClient) DeleteByLabels(ctx context.Context, namespace domain.Namespace, labels map[string]string) error {
 	log := logf.FromContext(ctx)
 
-	secrets, err := k.getSecretsByLabels(ctx, namespace, labels)
-	if err != nil {
-		return fmt.Errorf("failed to find secrets by label for deletion due to: %w", err)
+	if len(labels) == 0 {
+		return fmt.Errorf("labels are required to delete secrets by labels")
 	}
-	if len(secrets.Items) < 1 {
-		return nil
+	log.Info("Trying to delete secrets", "namespace", namespace, "labels", labels)
+	if err := k.client.DeleteAllOf(ctx, &v1.Secret{}, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return fmt.Errorf("failed to delete secrets: %w", err)
 	}
-
-	for _, secret := range secrets.Items {
-		log.Info("trying to delete secret", "secretName", secret.GetName())
-		if err := k.client.Delete(ctx, &secret); err != nil {
-			return fmt.Errorf("failed to delete secret: %w", err)
-		}
-	}
-
 	return nil
 }
 
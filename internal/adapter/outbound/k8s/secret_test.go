@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/WirelessCar/nauth/internal/testutil"
 	"github.com/stretchr/testify/suite"
 	v1 "k8s.io/api/core/v1"
@@ -149,6 +150,92 @@ func (t *SecretClientTestSuite) Test_Delete_ShouldSucceed_WhenSecretExists() {
 	t.Nil(result)
 }
 
+func (t *SecretClientTestSuite) Test_Apply_ShouldKeepAnnotationsOfOtherManagers() {
+	// Given
+	t.Require().NoError(t.unitUnderTest.Apply(t.ctx, nil, t.secretMeta, map[string]string{"key": "value"}))
+	existing := &v1.Secret{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.secretRef.Namespace, Name: t.secretRef.Name}, existing))
+	existing.Annotations = map[string]string{"replicator.example.com/replicate-to": "other-namespace"}
+	t.Require().NoError(k8sClient.Update(t.ctx, existing, client.FieldOwner("replicator")))
+
+	// When
+	err := t.unitUnderTest.Apply(t.ctx, nil, t.secretMeta, map[string]string{"key": "new value"})
+
+	// Then
+	t.NoError(err)
+	applied := &v1.Secret{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.secretRef.Namespace, Name: t.secretRef.Name}, applied))
+	t.Equal("other-namespace", applied.Annotations["replicator.example.com/replicate-to"])
+	t.Equal("new value", string(applied.Data["key"]))
+}
+
+func (t *SecretClientTestSuite) Test_Apply_ShouldRemoveKeysNotApplied() {
+	// Given
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1.Secret{
+		ObjectMeta: t.secretMeta,
+		StringData: map[string]string{"user.creds": "creds", "user.jwt": "jwt"},
+	}))
+
+	// When
+	err := t.unitUnderTest.Apply(t.ctx, nil, t.secretMeta, map[string]string{"user.jwt": "new jwt"})
+
+	// Then
+	t.NoError(err)
+	fetchedSecret, found, err := t.unitUnderTest.Get(t.ctx, t.secretRef)
+	t.NoError(err)
+	t.True(found)
+	t.Equal(map[string]string{"user.jwt": "new jwt"}, fetchedSecret)
+}
+
+func (t *SecretClientTestSuite) Test_ApplyAll_ShouldApplyEverySecret() {
+	// Given
+	otherMeta := *t.secretMeta.DeepCopy()
+	otherMeta.Name = t.secretName + "-other"
+	otherRef := domain.NewNamespacedName(otherMeta.Namespace, otherMeta.Name)
+	defer func() { t.NoError(cleanSecret(t.ctx, otherRef)) }()
+
+	// When
+	err := t.unitUnderTest.ApplyAll(t.ctx, nil, []outbound.SecretApply{
+		{Meta: t.secretMeta, Data: map[string]string{"key": "value"}},
+		{Meta: otherMeta, Data: map[string]string{"key": "other value"}},
+	})
+
+	// Then
+	t.NoError(err)
+	fetchedSecret, found, err := t.unitUnderTest.Get(t.ctx, t.secretRef)
+	t.NoError(err)
+	t.True(found)
+	t.Equal(map[string]string{"key": "value"}, fetchedSecret)
+	fetchedSecret, found, err = t.unitUnderTest.Get(t.ctx, otherRef)
+	t.NoError(err)
+	t.True(found)
+	t.Equal(map[string]string{"key": "other value"}, fetchedSecret)
+}
+
+func (t *SecretClientTestSuite) Test_DeleteByLabels_ShouldDeleteOnlyMatchingSecrets() {
+	// Given
+	matchingMeta := *t.secretMeta.DeepCopy()
+	matchingMeta.Labels["test.nauth.io/group"] = t.secretName
+	t.Require().NoError(t.unitUnderTest.Apply(t.ctx, nil, matchingMeta, map[string]string{"key": "value"}))
+	otherMeta := *t.secretMeta.DeepCopy()
+	otherMeta.Name = t.secretName + "-other"
+	otherRef := domain.NewNamespacedName(otherMeta.Namespace, otherMeta.Name)
+	t.Require().NoError(t.unitUnderTest.Apply(t.ctx, nil, otherMeta, map[string]string{"key": "value"}))
+	defer func() { t.NoError(cleanSecret(t.ctx, otherRef)) }()
+
+	// When
+	err := t.unitUnderTest.DeleteByLabels(t.ctx, domain.Namespace(testNamespace), map[string]string{"test.nauth.io/group": t.secretName})
+
+	// Then
+	t.NoError(err)
+	_, found, err := t.unitUnderTest.Get(t.ctx, t.secretRef)
+	t.NoError(err)
+	t.False(found)
+	_, found, err = t.unitUnderTest.Get(t.ctx, otherRef)
+	t.NoError(err)
+	t.True(found)
+}
+
 func cleanSecret(ctx context.Context, secretRef domain.NamespacedName) error {
 	k8sSecret := &v1.Secret{}
 
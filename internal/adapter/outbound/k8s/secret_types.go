@@ -1,14 +1,27 @@
 package k8s
 
+import "github.com/WirelessCar/nauth/internal/domain"
+
 const (
 	DeprecatedSecretNameAccountRootTemplate = "%s-ac-root"
 	DeprecatedSecretNameAccountSignTemplate = "%s-ac-sign"
+	SecretNameAccountPendingTemplate        = domain.SecretNameAccountPendingTemplate
 )
 
 const (
-	SecretTypeAccountRoot       = "account-root"
-	SecretTypeAccountSign       = "account-sign"
-	SecretTypeUserCredentials   = "user-creds"
-	DefaultSecretKeyName        = "default"
-	UserCredentialSecretKeyName = "user.creds"
+	SecretTypeAccountRoot          = domain.SecretTypeAccountRoot
+	SecretTypeAccountSign          = domain.SecretTypeAccountSign
+	SecretTypeAccountPending       = domain.SecretTypeAccountPending
+	SecretTypeUserCredentials      = domain.SecretTypeUserCredentials
+	DefaultSecretKeyName           = "default"
+	UserCredentialSecretKeyName    = domain.UserCredentialSecretKeyName
+	UserJWTSecretKeyName           = domain.UserJWTSecretKeyName
+	UserSeedSecretKeyName          = domain.UserSeedSecretKeyName
+	UserPublicKeySecretKeyName     = domain.UserPublicKeySecretKeyName
+	UserAuthorizationSecretKeyName = domain.UserAuthorizationSecretKeyName
+	DefaultTLSCAKeyName            = "ca.crt"
 )
+
+// SecretTypeNatsClusterReference labels the Secrets referenced by a NatsCluster whose target is cached, so that the
+// Secret informer observes their changes, see CachingClusterClient.
+const SecretTypeNatsClusterReference = "natscluster-reference"
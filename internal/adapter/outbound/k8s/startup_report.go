@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StartupReportConfigMapKey is the key of the JSON encoded report in the startup report ConfigMap.
+const StartupReportConfigMapKey = "report.json"
+
+// StartupReportConfigMapWriter writes the startup report to a ConfigMap, replacing the report of the previous start.
+type StartupReportConfigMapWriter struct {
+	client       client.Client
+	configMapRef domain.NamespacedName
+}
+
+func NewStartupReportConfigMapWriter(client client.Client, configMapRef domain.NamespacedName) *StartupReportConfigMapWriter {
+	return &StartupReportConfigMapWriter{
+		client:       client,
+		configMapRef: configMapRef,
+	}
+}
+
+func (w *StartupReportConfigMapWriter) WriteStartupReport(ctx context.Context, report nauth.StartupReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode startup report: %w", err)
+	}
+
+	configMap := &v1.ConfigMap{}
+	key := client.ObjectKey{Namespace: w.configMapRef.Namespace, Name: w.configMapRef.Name}
+	if err := w.client.Get(ctx, key, configMap); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get startup report ConfigMap %s: %w", w.configMapRef, err)
+		}
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      w.configMapRef.Name,
+				Namespace: w.configMapRef.Namespace,
+				Labels: map[string]string{
+					LabelManaged: LabelManagedValue,
+				},
+			},
+			Data: map[string]string{StartupReportConfigMapKey: string(data)},
+		}
+		if err := w.client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create startup report ConfigMap %s: %w", w.configMapRef, err)
+		}
+		return nil
+	}
+	if configMap.GetLabels()[LabelManaged] != LabelManagedValue {
+		return fmt.Errorf("existing ConfigMap %s not managed by nauth", w.configMapRef)
+	}
+	configMap.Data = map[string]string{StartupReportConfigMapKey: string(data)}
+	if err := w.client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update startup report ConfigMap %s: %w", w.configMapRef, err)
+	}
+	return nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.StartupReportWriter = (*StartupReportConfigMapWriter)(nil)
@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type StartupReportConfigMapWriterTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	configMapRef domain.NamespacedName
+	report       nauth.StartupReport
+
+	unitUnderTest *StartupReportConfigMapWriter
+}
+
+func TestStartupReportConfigMapWriter_TestSuite(t *testing.T) {
+	suite.Run(t, new(StartupReportConfigMapWriterTestSuite))
+}
+
+func (t *StartupReportConfigMapWriterTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.configMapRef = domain.NewNamespacedName(testNamespace, testutil.SanitizeTestName(t.T().Name()))
+	t.Require().NoError(t.configMapRef.Validate())
+	t.report = nauth.StartupReport{
+		GeneratedAt: time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC),
+		Accounts:    nauth.StartupReportCounts{Total: 3, InSync: 1, MissingSecrets: 1, Drifted: 1},
+		Users:       nauth.StartupReportCounts{Total: 2, InSync: 2},
+	}
+	t.unitUnderTest = NewStartupReportConfigMapWriter(k8sClient, t.configMapRef)
+	t.Require().NoError(cleanConfigMap(t.ctx, t.configMapRef))
+}
+
+func (t *StartupReportConfigMapWriterTestSuite) TearDownTest() {
+	t.Require().NoError(cleanConfigMap(t.ctx, t.configMapRef))
+}
+
+func (t *StartupReportConfigMapWriterTestSuite) Test_WriteStartupReport_ShouldCreate_WhenConfigMapDoesNotExist() {
+	err := t.unitUnderTest.WriteStartupReport(t.ctx, t.report)
+
+	t.Require().NoError(err)
+	t.Equal(t.report, t.readReport())
+}
+
+func (t *StartupReportConfigMapWriterTestSuite) Test_WriteStartupReport_ShouldReplace_WhenConfigMapExists() {
+	t.Require().NoError(t.unitUnderTest.WriteStartupReport(t.ctx, t.report))
+	t.report.Accounts.Drifted = 0
+	t.report.Accounts.InSync = 2
+
+	err := t.unitUnderTest.WriteStartupReport(t.ctx, t.report)
+
+	t.Require().NoError(err)
+	t.Equal(t.report, t.readReport())
+}
+
+func (t *StartupReportConfigMapWriterTestSuite) Test_WriteStartupReport_ShouldFail_WhenConfigMapIsNotManaged() {
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: t.configMapRef.Name, Namespace: t.configMapRef.Namespace},
+	}))
+
+	err := t.unitUnderTest.WriteStartupReport(t.ctx, t.report)
+
+	t.ErrorContains(err, "not managed by nauth")
+}
+
+func (t *StartupReportConfigMapWriterTestSuite) readReport() nauth.StartupReport {
+	configMap := &v1.ConfigMap{}
+	t.Require().NoError(k8sClient.Get(t.ctx, client.ObjectKey{Namespace: t.configMapRef.Namespace, Name: t.configMapRef.Name}, configMap))
+	t.Equal(LabelManagedValue, configMap.GetLabels()[LabelManaged])
+	report := nauth.StartupReport{}
+	t.Require().NoError(json.Unmarshal([]byte(configMap.Data[StartupReportConfigMapKey]), &report))
+	return report
+}
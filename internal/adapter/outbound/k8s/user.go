@@ -0,0 +1,33 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UserClient reads User resources from the cluster.
+type UserClient struct {
+	client client.Reader
+}
+
+// NewUserClient creates a new User client.
+func NewUserClient(c client.Reader) *UserClient {
+	return &UserClient{client: c}
+}
+
+// List returns the Users in namespace, or in all namespaces when namespace is empty.
+func (c *UserClient) List(ctx context.Context, namespace domain.Namespace) ([]v1alpha1.User, error) {
+	users := &v1alpha1.UserList{}
+	if err := c.client.List(ctx, users, client.InNamespace(string(namespace))); err != nil {
+		return nil, domain.ErrUnknownError.WithCause(fmt.Errorf("failed to list users in namespace %q: %w", namespace, err))
+	}
+	return users.Items, nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.UserLister = (*UserClient)(nil)
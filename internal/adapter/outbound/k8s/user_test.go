@@ -0,0 +1,61 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type UserClientTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	userRef domain.NamespacedName
+
+	unitUnderTest *UserClient
+}
+
+func TestUserClient_TestSuite(t *testing.T) {
+	suite.Run(t, new(UserClientTestSuite))
+}
+
+func (t *UserClientTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.userRef = domain.NewNamespacedName(testutil.ScopedTestName("ns", t.T().Name()), testutil.SanitizeTestName(t.T().Name()))
+	t.Require().NoError(t.userRef.Validate())
+
+	t.unitUnderTest = NewUserClient(k8sClient)
+
+	t.Require().NoError(ensureNamespace(t.ctx, t.userRef.Namespace))
+}
+
+func (t *UserClientTestSuite) Test_List_ShouldReturnUsersOfNamespace() {
+	// Given
+	t.Require().NoError(k8sClient.Create(t.ctx, &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.userRef.Name,
+			Namespace: t.userRef.Namespace,
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "account",
+		},
+	}))
+
+	// When
+	result, err := t.unitUnderTest.List(t.ctx, t.userRef.GetNamespace())
+
+	t.Require().NoError(err)
+	t.Require().Len(result, 1)
+	t.Equal(t.userRef.Name, result[0].Name)
+}
+
+func (t *UserClientTestSuite) Test_List_ShouldReturnEmpty_WhenNamespaceHasNoUsers() {
+	result, err := t.unitUnderTest.List(t.ctx, t.userRef.GetNamespace())
+
+	t.Require().NoError(err)
+	t.Empty(result)
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WorkloadClient rolls out Deployments and StatefulSets by patching the annotations of their pod template. Workloads
+// are patched without reading them, so the manager neither caches nor watches them.
+type WorkloadClient struct {
+	client client.Client
+}
+
+func NewWorkloadClient(client client.Client) *WorkloadClient {
+	return &WorkloadClient{client: client}
+}
+
+func (k *WorkloadClient) Restart(ctx context.Context, namespace domain.Namespace, target v1alpha1.RestartTarget, key string, value string) (bool, error) {
+	var workload client.Object
+	switch target.Kind {
+	case v1alpha1.RestartTargetKindDeployment:
+		workload = &appsv1.Deployment{}
+	case v1alpha1.RestartTargetKindStatefulSet:
+		workload = &appsv1.StatefulSet{}
+	default:
+		return false, domain.ErrBadRequest.WithCause(fmt.Errorf("unsupported restart target kind %q", target.Kind))
+	}
+	workload.SetNamespace(string(namespace))
+	workload.SetName(target.Name)
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{key: value},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode patch of %s %s/%s: %w", target.Kind, namespace, target.Name, err)
+	}
+	// An unchanged annotation leaves the pod template as is and does not roll the workload out
+	if err = k.client.Patch(ctx, workload, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to patch %s %s/%s: %w", target.Kind, namespace, target.Name, err)
+	}
+	return true, nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.WorkloadRestarter = (*WorkloadClient)(nil)
@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWorkloadClient_Restart(t *testing.T) {
+	testCases := []struct {
+		name     string
+		workload client.Object
+		kind     v1alpha1.RestartTargetKind
+	}{
+		{
+			name:     "deployment",
+			workload: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "my-namespace"}},
+			kind:     v1alpha1.RestartTargetKindDeployment,
+		},
+		{
+			name:     "stateful set",
+			workload: &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "my-namespace"}},
+			kind:     v1alpha1.RestartTargetKindStatefulSet,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := fake.NewClientBuilder().WithObjects(testCase.workload).Build()
+			unitUnderTest := NewWorkloadClient(k8sClient)
+			target := v1alpha1.RestartTarget{Kind: testCase.kind, Name: "my-app"}
+
+			found, err := unitUnderTest.Restart(ctx, "my-namespace", target, "credentials.nauth.io/my-user", "checksum")
+
+			require.NoError(t, err)
+			require.True(t, found)
+			require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(testCase.workload), testCase.workload))
+			var template corev1.PodTemplateSpec
+			switch workload := testCase.workload.(type) {
+			case *appsv1.Deployment:
+				template = workload.Spec.Template
+			case *appsv1.StatefulSet:
+				template = workload.Spec.Template
+			}
+			require.Equal(t, map[string]string{"credentials.nauth.io/my-user": "checksum"}, template.Annotations)
+		})
+	}
+}
+
+func TestWorkloadClient_Restart_ShouldKeepOtherAnnotations(t *testing.T) {
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "my-namespace"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"credentials.nauth.io/other-user": "other-checksum"},
+		}}},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(deployment).Build()
+	target := v1alpha1.RestartTarget{Kind: v1alpha1.RestartTargetKindDeployment, Name: "my-app"}
+
+	_, err := NewWorkloadClient(k8sClient).Restart(ctx, "my-namespace", target, "credentials.nauth.io/my-user", "checksum")
+
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment))
+	require.Equal(t, map[string]string{
+		"credentials.nauth.io/other-user": "other-checksum",
+		"credentials.nauth.io/my-user":    "checksum",
+	}, deployment.Spec.Template.Annotations)
+}
+
+func TestWorkloadClient_Restart_ShouldReportNotFound_WhenWorkloadDoesNotExist(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().Build()
+	target := v1alpha1.RestartTarget{Kind: v1alpha1.RestartTargetKindStatefulSet, Name: "my-app"}
+
+	found, err := NewWorkloadClient(k8sClient).Restart(context.Background(), "my-namespace", target,
+		"credentials.nauth.io/my-user", "checksum")
+
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestWorkloadClient_Restart_ShouldFail_WhenKindUnsupported(t *testing.T) {
+	target := v1alpha1.RestartTarget{Kind: "DaemonSet", Name: "my-app"}
+
+	_, err := NewWorkloadClient(fake.NewClientBuilder().Build()).Restart(context.Background(), "my-namespace", target,
+		"credentials.nauth.io/my-user", "checksum")
+
+	require.ErrorIs(t, err, domain.ErrBadRequest)
+}
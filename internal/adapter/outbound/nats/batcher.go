@@ -0,0 +1,280 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/go-logr/logr"
+	"github.com/nats-io/jwt/v2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultClaimsPushWindow = 200 * time.Millisecond
+	DefaultClaimsPushRate   = 20.0
+)
+
+// ClaimsPushBatcher coalesces account JWT pushes to $SYS.REQ.CLAIMS.UPDATE. Pushes for the same account that arrive
+// within the batching window are merged and only the most recently issued JWT is sent, every caller receives the result
+// of that push. Pushes are rate limited per operator to protect the NATS servers during mass reconciliation, e.g. after
+// an operator upgrade.
+//
+// The batcher keeps track of the pushes not sent yet, so that a ClaimsPushHandoff hands them over to the next leader.
+type ClaimsPushBatcher struct {
+	window  time.Duration
+	limiter *rate.Limiter
+	now     func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*pendingPush
+	// outstanding are the pushes not sent yet, including the pushes aborted on shutdown.
+	outstanding map[string]domain.NatsClaimsPush
+	// handedOver are the pushes handed over by the previous leader that were not resumed yet.
+	handedOver map[string]*handedOverPush
+	// revision changes with outstanding and handedOver.
+	revision uint64
+}
+
+type pendingPush struct {
+	token    string
+	issuedAt int64
+	done     chan struct{}
+	err      error
+}
+
+type handedOverPush struct {
+	push      domain.NatsClaimsPush
+	accountID string
+	issuedAt  int64
+	resuming  bool
+}
+
+// NewClaimsPushBatcher creates a batcher that waits window for further pushes of the same account and sends at most
+// pushesPerSecond JWTs per second. A pushesPerSecond of zero disables rate limiting.
+func NewClaimsPushBatcher(window time.Duration, pushesPerSecond float64) (*ClaimsPushBatcher, error) {
+	limit := rate.Inf
+	if pushesPerSecond > 0 {
+		limit = rate.Limit(pushesPerSecond)
+	}
+	batcher := &ClaimsPushBatcher{
+		window:      window,
+		limiter:     rate.NewLimiter(limit, 1),
+		now:         time.Now,
+		pending:     make(map[string]*pendingPush),
+		outstanding: make(map[string]domain.NatsClaimsPush),
+		handedOver:  make(map[string]*handedOverPush),
+	}
+	if err := batcher.validate(pushesPerSecond); err != nil {
+		return nil, err
+	}
+	return batcher, nil
+}
+
+func (b *ClaimsPushBatcher) validate(pushesPerSecond float64) error {
+	if b.window < 0 {
+		return fmt.Errorf("batching window must not be negative")
+	}
+	if pushesPerSecond < 0 {
+		return fmt.Errorf("push rate must not be negative")
+	}
+	return nil
+}
+
+// push queues token for the cluster identified by clusterKey and blocks until it, or a newer JWT of the same account,
+// has been sent with upload. shutdown is closed when the operator stops or loses leadership: the batching window ends
+// early and pushes still waiting for the rate limiter fail, so that the next leader reconciles them instead.
+func (b *ClaimsPushBatcher) push(clusterKey string, token string, shutdown <-chan struct{}, upload func(string) error) error {
+	claims, err := jwt.DecodeGeneric(token)
+	if err != nil {
+		return fmt.Errorf("failed to decode account JWT: %w", err)
+	}
+	return b.enqueue(clusterKey, claims.Subject, token, claims.IssuedAt, false, shutdown, upload)
+}
+
+// enqueue queues token for the account accountID in the cluster identified by clusterKey. A resumed push is skipped
+// when the account was pushed since it was handed over.
+func (b *ClaimsPushBatcher) enqueue(clusterKey string, accountID string, token string, issuedAt int64, resumed bool, shutdown <-chan struct{}, upload func(string) error) error {
+	key := clusterKey + "/" + accountID
+
+	b.mu.Lock()
+	if _, ok := b.handedOver[key]; ok {
+		delete(b.handedOver, key)
+		b.revision++
+	} else if resumed {
+		b.mu.Unlock()
+		return nil
+	}
+	if queued, ok := b.pending[key]; ok {
+		if issuedAt >= queued.issuedAt {
+			queued.token = token
+			queued.issuedAt = issuedAt
+			b.trackLocked(key, clusterKey, token)
+		}
+		b.mu.Unlock()
+		claimsPushCoalescedTotal.Inc()
+		<-queued.done
+		return queued.err
+	}
+	queued := &pendingPush{token: token, issuedAt: issuedAt, done: make(chan struct{})}
+	b.pending[key] = queued
+	b.trackLocked(key, clusterKey, token)
+	b.mu.Unlock()
+	claimsPushQueueDepth.Inc()
+
+	b.waitWindow(shutdown)
+
+	b.mu.Lock()
+	delete(b.pending, key)
+	token = queued.token
+	b.mu.Unlock()
+
+	queued.err = b.send(token, shutdown, upload)
+	claimsPushQueueDepth.Dec()
+
+	// Pushes aborted on shutdown stay outstanding for the next leader.
+	if !errors.Is(queued.err, context.Canceled) {
+		b.mu.Lock()
+		if outstanding, ok := b.outstanding[key]; ok && outstanding.Token == token {
+			delete(b.outstanding, key)
+			b.revision++
+		}
+		b.mu.Unlock()
+	}
+	close(queued.done)
+	return queued.err
+}
+
+func (b *ClaimsPushBatcher) trackLocked(key string, clusterKey string, token string) {
+	b.outstanding[key] = domain.NatsClaimsPush{ClusterKey: clusterKey, Token: token, QueuedAt: b.now()}
+	b.revision++
+}
+
+// outstandingPushes returns the pushes not sent yet and the pushes handed over that were not resumed yet, ordered by
+// cluster and account, with the revision of the batcher they reflect.
+func (b *ClaimsPushBatcher) outstandingPushes() ([]domain.NatsClaimsPush, uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	keys := make([]string, 0, len(b.outstanding)+len(b.handedOver))
+	for key := range b.outstanding {
+		keys = append(keys, key)
+	}
+	for key := range b.handedOver {
+		if _, ok := b.outstanding[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	pushes := make([]domain.NatsClaimsPush, 0, len(keys))
+	for _, key := range keys {
+		if push, ok := b.outstanding[key]; ok {
+			pushes = append(pushes, push)
+		} else {
+			pushes = append(pushes, b.handedOver[key].push)
+		}
+	}
+	return pushes, b.revision
+}
+
+// handOver takes over the pushes the previous leader did not send, returning the number of pushes taken over. They
+// are sent by resume once their cluster is connected, unless the account is pushed again before.
+func (b *ClaimsPushBatcher) handOver(pushes []domain.NatsClaimsPush) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	count := 0
+	for _, push := range pushes {
+		claims, err := jwt.DecodeGeneric(push.Token)
+		if err != nil {
+			continue
+		}
+		key := push.ClusterKey + "/" + claims.Subject
+		if _, ok := b.outstanding[key]; ok {
+			continue
+		}
+		if handedOver, ok := b.handedOver[key]; ok && handedOver.issuedAt > claims.IssuedAt {
+			continue
+		}
+		b.handedOver[key] = &handedOverPush{push: push, accountID: claims.Subject, issuedAt: claims.IssuedAt}
+		count++
+	}
+	if count > 0 {
+		b.revision++
+	}
+	return count
+}
+
+// hasHandedOver reports whether pushes handed over to the cluster identified by clusterKey wait to be resumed.
+func (b *ClaimsPushBatcher) hasHandedOver(clusterKey string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, handedOver := range b.handedOver {
+		if handedOver.push.ClusterKey == clusterKey && !handedOver.resuming {
+			return true
+		}
+	}
+	return false
+}
+
+// resume sends the pushes handed over to the cluster identified by clusterKey with upload and blocks until they are
+// sent. Failed pushes are logged, the Accounts are reconciled by the new leader anyway.
+func (b *ClaimsPushBatcher) resume(clusterKey string, shutdown <-chan struct{}, upload func(string) error, logger logr.Logger) {
+	b.mu.Lock()
+	var resumed []*handedOverPush
+	for _, handedOver := range b.handedOver {
+		if handedOver.push.ClusterKey == clusterKey && !handedOver.resuming {
+			handedOver.resuming = true
+			resumed = append(resumed, handedOver)
+		}
+	}
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, handedOver := range resumed {
+		wg.Go(func() {
+			push := handedOver.push
+			if err := b.enqueue(clusterKey, handedOver.accountID, push.Token, handedOver.issuedAt, true, shutdown, upload); err != nil {
+				logger.Info("Failed to resume account JWT push", "accountID", handedOver.accountID, "error", err.Error())
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func (b *ClaimsPushBatcher) waitWindow(shutdown <-chan struct{}) {
+	if b.window == 0 {
+		return
+	}
+	timer := time.NewTimer(b.window)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-shutdown:
+	}
+}
+
+func (b *ClaimsPushBatcher) send(token string, shutdown <-chan struct{}, upload func(string) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := b.limiter.Wait(ctx); err != nil {
+		claimsPushTotal.WithLabelValues(claimsPushResultAborted).Inc()
+		return fmt.Errorf("account JWT push aborted while rate limited: %w", err)
+	}
+	if err := upload(token); err != nil {
+		claimsPushTotal.WithLabelValues(claimsPushResultError).Inc()
+		return err
+	}
+	claimsPushTotal.WithLabelValues(claimsPushResultSuccess).Inc()
+	return nil
+}
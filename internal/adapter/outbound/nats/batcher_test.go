@@ -0,0 +1,188 @@
+package nats
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/go-logr/logr"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClaimsPushBatcher_ShouldFail_WhenSettingsInvalid(t *testing.T) {
+	_, err := NewClaimsPushBatcher(-time.Second, 1)
+	require.ErrorContains(t, err, "batching window must not be negative")
+
+	_, err = NewClaimsPushBatcher(time.Second, -1)
+	require.ErrorContains(t, err, "push rate must not be negative")
+}
+
+func TestClaimsPushBatcher_ShouldCoalescePushesOfSameAccount(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(200*time.Millisecond, 0)
+	require.NoError(t, err)
+	accountKey := newTestAccountKey(t)
+	first := encodeTestAccountJWT(t, accountKey, "first")
+	second := encodeTestAccountJWT(t, accountKey, "second")
+	uploads := &recordingUploader{}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Go(func() { errs[0] = batcher.push("cluster", first, nil, uploads.upload) })
+	require.Eventually(t, func() bool { return batcher.queued() == 1 }, time.Second, time.Millisecond)
+	wg.Go(func() { errs[1] = batcher.push("cluster", second, nil, uploads.upload) })
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.Equal(t, []string{second}, uploads.tokens)
+}
+
+func TestClaimsPushBatcher_ShouldNotCoalesceDifferentAccountsOrClusters(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+	accountA := encodeTestAccountJWT(t, newTestAccountKey(t), "a")
+	accountB := encodeTestAccountJWT(t, newTestAccountKey(t), "b")
+	uploads := &recordingUploader{}
+
+	require.NoError(t, batcher.push("cluster-1", accountA, nil, uploads.upload))
+	require.NoError(t, batcher.push("cluster-1", accountB, nil, uploads.upload))
+	require.NoError(t, batcher.push("cluster-2", accountA, nil, uploads.upload))
+
+	require.Equal(t, []string{accountA, accountB, accountA}, uploads.tokens)
+}
+
+func TestClaimsPushBatcher_ShouldReturnUploadError(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+	token := encodeTestAccountJWT(t, newTestAccountKey(t), "a")
+
+	err = batcher.push("cluster", token, nil, func(string) error { return errFailedUpload })
+
+	require.ErrorIs(t, err, errFailedUpload)
+}
+
+func TestClaimsPushBatcher_ShouldAbortRateLimitedPushes_WhenShutdown(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0.001)
+	require.NoError(t, err)
+	accountKey := newTestAccountKey(t)
+	first := encodeTestAccountJWT(t, accountKey, "first")
+	uploads := &recordingUploader{}
+	shutdown := make(chan struct{})
+
+	// The first push consumes the only token of the limiter.
+	require.NoError(t, batcher.push("cluster", first, shutdown, uploads.upload))
+	close(shutdown)
+	err = batcher.push("cluster", encodeTestAccountJWT(t, accountKey, "second"), shutdown, uploads.upload)
+
+	require.ErrorContains(t, err, "account JWT push aborted while rate limited")
+	require.Equal(t, []string{first}, uploads.tokens)
+}
+
+func TestClaimsPushBatcher_ShouldKeepAbortedPushesOutstanding(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0.001)
+	require.NoError(t, err)
+	accountKey := newTestAccountKey(t)
+	uploads := &recordingUploader{}
+	shutdown := make(chan struct{})
+
+	require.NoError(t, batcher.push("cluster", encodeTestAccountJWT(t, accountKey, "first"), shutdown, uploads.upload))
+	close(shutdown)
+	second := encodeTestAccountJWT(t, accountKey, "second")
+	require.Error(t, batcher.push("cluster", second, shutdown, uploads.upload))
+
+	pushes, _ := batcher.outstandingPushes()
+	require.Len(t, pushes, 1)
+	require.Equal(t, "cluster", pushes[0].ClusterKey)
+	require.Equal(t, second, pushes[0].Token)
+}
+
+func TestClaimsPushBatcher_ShouldResumeHandedOverPushesOfConnectedCluster(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+	accountA := encodeTestAccountJWT(t, newTestAccountKey(t), "a")
+	accountB := encodeTestAccountJWT(t, newTestAccountKey(t), "b")
+	uploads := &recordingUploader{}
+
+	require.Equal(t, 2, batcher.handOver([]domain.NatsClaimsPush{
+		{ClusterKey: "cluster-1", Token: accountA},
+		{ClusterKey: "cluster-2", Token: accountB},
+		{ClusterKey: "cluster-2", Token: "invalid"},
+	}))
+	require.True(t, batcher.hasHandedOver("cluster-1"))
+	batcher.resume("cluster-1", nil, uploads.upload, logr.Discard())
+
+	require.Equal(t, []string{accountA}, uploads.tokens)
+	require.False(t, batcher.hasHandedOver("cluster-1"))
+	pushes, _ := batcher.outstandingPushes()
+	require.Equal(t, []domain.NatsClaimsPush{{ClusterKey: "cluster-2", Token: accountB}}, pushes)
+}
+
+func TestClaimsPushBatcher_ShouldSkipHandedOverPush_WhenAccountPushedSince(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+	accountKey := newTestAccountKey(t)
+	handedOver := encodeTestAccountJWT(t, accountKey, "handed-over")
+	pushed := encodeTestAccountJWT(t, accountKey, "pushed")
+	uploads := &recordingUploader{}
+
+	batcher.handOver([]domain.NatsClaimsPush{{ClusterKey: "cluster", Token: handedOver}})
+	require.NoError(t, batcher.push("cluster", pushed, nil, uploads.upload))
+	batcher.resume("cluster", nil, uploads.upload, logr.Discard())
+
+	require.Equal(t, []string{pushed}, uploads.tokens)
+	pushes, _ := batcher.outstandingPushes()
+	require.Empty(t, pushes)
+}
+
+func TestClaimsPushBatcher_ShouldFail_WhenTokenIsNotJWT(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+
+	err = batcher.push("cluster", "invalid", nil, (&recordingUploader{}).upload)
+
+	require.ErrorContains(t, err, "failed to decode account JWT")
+}
+
+var errFailedUpload = errors.New("failed upload")
+
+type recordingUploader struct {
+	mu     sync.Mutex
+	tokens []string
+}
+
+func (r *recordingUploader) upload(token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (b *ClaimsPushBatcher) queued() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+func newTestAccountKey(t *testing.T) string {
+	t.Helper()
+	keyPair, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+	publicKey, err := keyPair.PublicKey()
+	require.NoError(t, err)
+	return publicKey
+}
+
+func encodeTestAccountJWT(t *testing.T, accountKey string, name string) string {
+	t.Helper()
+	operatorKeyPair, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+	claims := jwt.NewAccountClaims(accountKey)
+	claims.Name = name
+	token, err := claims.Encode(operatorKeyPair)
+	require.NoError(t, err)
+	return token
+}
@@ -2,6 +2,8 @@ package nats
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +18,9 @@ import (
 
 const (
 	natsMaxTimeout = 3 * time.Second
+	// natsPingStallTimeout is how long a request to all servers waits for another server to respond after the last
+	// response.
+	natsPingStallTimeout = 300 * time.Millisecond
 )
 
 type ServerAPIClaimUpdateResponse struct {
@@ -33,14 +38,64 @@ type ClaimUpdateError struct {
 	Description string `json:"description,omitempty"`
 }
 
+// ServerStatsMsg is the response of a server to $SYS.REQ.SERVER.PING.
+type ServerStatsMsg struct {
+	Server ServerStatsInfo `json:"server"`
+	Stats  ServerStats     `json:"statsz"`
+}
+
+type ServerStatsInfo struct {
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Version   string `json:"ver"`
+	JetStream bool   `json:"jetstream"`
+}
+
+type ServerStats struct {
+	Connections int                   `json:"connections"`
+	JetStream   *ServerJetStreamStats `json:"jetstream,omitempty"`
+}
+
+type ServerJetStreamStats struct {
+	Config *struct {
+		MaxMemory  int64 `json:"max_memory"`
+		MaxStorage int64 `json:"max_storage"`
+	} `json:"config,omitempty"`
+}
+
+// AccountStatzResponse is the response of a server to $SYS.REQ.ACCOUNT.<id>.STATZ.
+type AccountStatzResponse struct {
+	Data struct {
+		Accounts []AccountStat `json:"account_statz"`
+	} `json:"data"`
+	Error *ClaimUpdateError `json:"error,omitempty"`
+}
+
+type AccountStat struct {
+	Conns     int `json:"conns"`
+	LeafNodes int `json:"leafnodes"`
+	NumSubs   int `json:"num_subscriptions"`
+}
+
+// AccountJszResponse is the response of a server to $SYS.REQ.ACCOUNT.<id>.JSZ.
+type AccountJszResponse struct {
+	Data  *AccountJetStreamStats `json:"data,omitempty"`
+	Error *ClaimUpdateError      `json:"error,omitempty"`
+}
+
+type AccountJetStreamStats struct {
+	Memory uint64 `json:"memory"`
+	Store  uint64 `json:"storage"`
+}
+
 type SysClient struct{}
 
 func NewSysClient() *SysClient {
 	return &SysClient{}
 }
 
-func (n *SysClient) Connect(natsURL string, userCreds domain.NatsUserCreds) (outbound.NatsSysConnection, error) {
-	return connect(natsURL, userCreds)
+func (n *SysClient) Connect(_ context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	return connect(natsURL, userCreds, tlsConfig)
 }
 
 type AccountClient struct{}
@@ -49,11 +104,11 @@ func NewAccountClient() *AccountClient {
 	return &AccountClient{}
 }
 
-func (c AccountClient) Connect(natsURL string, userCreds domain.NatsUserCreds) (outbound.NatsAccountConnection, error) {
-	return connect(natsURL, userCreds)
+func (c AccountClient) Connect(_ context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsAccountConnection, error) {
+	return connect(natsURL, userCreds, tlsConfig)
 }
 
-func connect(natsURL string, userCreds domain.NatsUserCreds) (*connection, error) {
+func connect(natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (*connection, error) {
 	if natsURL == "" {
 		return nil, fmt.Errorf("NATS URL is required")
 	}
@@ -62,9 +117,19 @@ func connect(natsURL string, userCreds domain.NatsUserCreds) (*connection, error
 		return nil, fmt.Errorf("invalid NATS user credentials: %w", err)
 	}
 
+	var clientTLSConfig *tls.Config
+	if tlsConfig != nil {
+		var err error
+		clientTLSConfig, err = newClientTLSConfig(*tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS TLS configuration: %w", err)
+		}
+	}
+
 	c := &connection{
 		natsURL:   natsURL,
 		userCreds: userCreds,
+		tlsConfig: clientTLSConfig,
 	}
 	if err := c.EnsureConnected(); err != nil {
 		return nil, fmt.Errorf("failed to connect to NATS cluster: %w", err)
@@ -76,6 +141,7 @@ func connect(natsURL string, userCreds domain.NatsUserCreds) (*connection, error
 type connection struct {
 	natsURL   string
 	userCreds domain.NatsUserCreds
+	tlsConfig *tls.Config
 	conn      *nats.Conn
 }
 
@@ -100,6 +166,13 @@ func (n *connection) Disconnect() {
 	}
 }
 
+func (n *connection) MaxPayload() int64 {
+	if n.conn == nil {
+		return 0
+	}
+	return n.conn.MaxPayload()
+}
+
 func (n *connection) VerifySystemAccountAccess() error {
 	if n.conn == nil || !n.conn.IsConnected() {
 		return fmt.Errorf("NATS connection is not established or lost")
@@ -113,6 +186,117 @@ func (n *connection) VerifySystemAccountAccess() error {
 	return nil
 }
 
+func (n *connection) PingServers() ([]domain.NatsServerInfo, error) {
+	var servers []domain.NatsServerInfo
+	err := n.requestAll("$SYS.REQ.SERVER.PING", nil, func(data []byte) error {
+		res := &ServerStatsMsg{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return fmt.Errorf("failed to unmarshal ping response: %w", err)
+		}
+		server := domain.NatsServerInfo{
+			Name:        res.Server.Name,
+			Version:     res.Server.Version,
+			JetStream:   res.Server.JetStream,
+			Connections: res.Stats.Connections,
+		}
+		if jetStream := res.Stats.JetStream; jetStream != nil && jetStream.Config != nil {
+			server.JetStreamMaxMemory = jetStream.Config.MaxMemory
+			server.JetStreamMaxStorage = jetStream.Config.MaxStorage
+		}
+		servers = append(servers, server)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no server responded to system account ping")
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+	return servers, nil
+}
+
+func (n *connection) AccountUsage(accountID string) (*domain.NatsAccountUsage, error) {
+	usage := &domain.NatsAccountUsage{}
+	// Servers the account has no connections on respond as well, so the responses are not awaited until the timeout
+	statzRequest := []byte(`{"include_unused":true}`)
+	err := n.requestAll(fmt.Sprintf("$SYS.REQ.ACCOUNT.%s.STATZ", accountID), statzRequest, func(data []byte) error {
+		res := &AccountStatzResponse{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return fmt.Errorf("failed to unmarshal account statz response: %w", err)
+		}
+		if res.Error != nil {
+			return fmt.Errorf("account statz failed: %s", res.Error.Description)
+		}
+		for _, stat := range res.Data.Accounts {
+			usage.Connections += stat.Conns
+			usage.LeafNodes += stat.LeafNodes
+			usage.Subscriptions += stat.NumSubs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = n.requestAll(fmt.Sprintf("$SYS.REQ.ACCOUNT.%s.JSZ", accountID), nil, func(data []byte) error {
+		res := &AccountJszResponse{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return fmt.Errorf("failed to unmarshal account jsz response: %w", err)
+		}
+		// Servers without JetStream, or without JetStream for the account, respond with an error
+		if res.Error != nil || res.Data == nil {
+			return nil
+		}
+		// Every JetStream server tracks the usage of the account in the whole cluster
+		if usage.JetStream == nil {
+			usage.JetStream = &domain.NatsJetStreamUsage{}
+		}
+		usage.JetStream.MemoryBytes = max(usage.JetStream.MemoryBytes, res.Data.Memory)
+		usage.JetStream.StorageBytes = max(usage.JetStream.StorageBytes, res.Data.Store)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// requestAll sends a request to all servers of the cluster and passes each response to handle, until no further
+// response arrives for a while.
+func (n *connection) requestAll(subject string, data []byte, handle func(data []byte) error) error {
+	if n.conn == nil || !n.conn.IsConnected() {
+		return fmt.Errorf("NATS connection is not established or lost")
+	}
+
+	inbox := n.conn.NewRespInbox()
+	sub, err := n.conn.SubscribeSync(inbox)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to responses of %s: %w", subject, err)
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+	if err := n.conn.PublishRequest(subject, inbox, data); err != nil {
+		return fmt.Errorf("failed to send request %s: %w", subject, err)
+	}
+
+	timeout := natsMaxTimeout
+	for {
+		msg, err := sub.NextMsg(timeout)
+		if errors.Is(err, nats.ErrTimeout) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive response of %s: %w", subject, err)
+		}
+		timeout = natsPingStallTimeout
+		if err := handle(msg.Data); err != nil {
+			return err
+		}
+	}
+}
+
 func (n *connection) ListAccountStreams() ([]string, error) {
 	if n.conn == nil || !n.conn.IsConnected() {
 		return nil, fmt.Errorf("NATS connection is not established or lost")
@@ -203,13 +387,17 @@ func (n *connection) updateClaimsJWT(subject string, jwt string) error {
 func (n *connection) connect() error {
 	var err error
 
-	n.conn, err = nats.Connect(
-		n.natsURL,
+	options := []nats.Option{
 		nats.UserCredentialBytes(n.userCreds.Creds),
 		nats.RetryOnFailedConnect(true),
 		nats.MaxReconnects(7),
 		nats.ReconnectWait(time.Second),
-	)
+	}
+	if n.tlsConfig != nil {
+		options = append(options, nats.Secure(n.tlsConfig))
+	}
+
+	n.conn, err = nats.Connect(n.natsURL, options...)
 	if err != nil {
 		return fmt.Errorf("unable to connect to NATS cluster: %w", err)
 	}
@@ -217,6 +405,32 @@ func (n *connection) connect() error {
 	return err
 }
 
+func newClientTLSConfig(config domain.NatsTLSConfig) (*tls.Config, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if len(config.CACert) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(config.CACert) {
+			return nil, fmt.Errorf("no valid PEM encoded certificates found in CA bundle")
+		}
+		result.RootCAs = rootCAs
+	}
+	if len(config.ClientCert) > 0 {
+		clientCert, err := tls.X509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		result.Certificates = []tls.Certificate{clientCert}
+	}
+	return result, nil
+}
+
 // Compile-time assertion that implementations fulfills ports
 var _ outbound.NatsSysClient = (*SysClient)(nil)
 var _ outbound.NatsAccountClient = (*AccountClient)(nil)
@@ -87,6 +87,60 @@ func TestConnection_ListAccountStreams_ShouldFail_WhenConnectionIsLost(t *testin
 	require.Nil(t, names)
 }
 
+func TestConnection_PingServers_ShouldReturnRespondingServer(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{
+		serverJetStream: true,
+	})
+	nc := connectTestSystemAccount(t, server)
+
+	conn := &connection{conn: nc}
+
+	servers, err := conn.PingServers()
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	require.Equal(t, server.Name(), servers[0].Name)
+	require.NotEmpty(t, servers[0].Version)
+	require.True(t, servers[0].JetStream)
+	require.Positive(t, servers[0].Connections)
+	require.Positive(t, servers[0].JetStreamMaxMemory)
+	require.Positive(t, servers[0].JetStreamMaxStorage)
+}
+
+func TestConnection_AccountUsage_ShouldSumConnectionsAndReportJetStream(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{
+		serverJetStream:  true,
+		accountJetStream: true,
+	})
+	accountConn := connectTestAccount(t, server)
+	_, err := accountConn.SubscribeSync("orders.>")
+	require.NoError(t, err)
+	require.NoError(t, accountConn.Flush())
+	nc := connectTestSystemAccount(t, server)
+
+	conn := &connection{conn: nc}
+
+	usage, err := conn.AccountUsage("A")
+	require.NoError(t, err)
+	require.Equal(t, 1, usage.Connections)
+	require.Zero(t, usage.LeafNodes)
+	require.Positive(t, usage.Subscriptions)
+	require.NotNil(t, usage.JetStream)
+}
+
+func TestConnection_AccountUsage_ShouldOmitJetStream_WhenDisabledForAccount(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{
+		serverJetStream: true,
+	})
+	nc := connectTestSystemAccount(t, server)
+
+	conn := &connection{conn: nc}
+
+	usage, err := conn.AccountUsage("A")
+	require.NoError(t, err)
+	require.Zero(t, usage.Connections)
+	require.Nil(t, usage.JetStream)
+}
+
 type natsServerConfig struct {
 	serverJetStream  bool
 	accountJetStream bool
@@ -96,6 +150,7 @@ func runNatsServer(t *testing.T, cfg natsServerConfig) *natsserver.Server {
 	t.Helper()
 
 	account := natsserver.NewAccount("A")
+	systemAccount := natsserver.NewAccount("SYS")
 	opts := &natsserver.Options{
 		Host:                  "127.0.0.1",
 		Port:                  -1,
@@ -104,11 +159,16 @@ func runNatsServer(t *testing.T, cfg natsServerConfig) *natsserver.Server {
 		DisableShortFirstPing: true,
 		JetStream:             cfg.serverJetStream,
 		StoreDir:              filepath.Join(t.TempDir(), "store"),
-		Accounts:              []*natsserver.Account{account},
+		Accounts:              []*natsserver.Account{account, systemAccount},
+		SystemAccount:         systemAccount.Name,
 		Users: []*natsserver.User{{
 			Username: "foo",
 			Password: "bar",
 			Account:  account,
+		}, {
+			Username: "sys",
+			Password: "bar",
+			Account:  systemAccount,
 		}},
 	}
 
@@ -141,3 +201,13 @@ func connectTestAccount(t *testing.T, server *natsserver.Server) *nats.Conn {
 
 	return nc
 }
+
+func connectTestSystemAccount(t *testing.T, server *natsserver.Server) *nats.Conn {
+	t.Helper()
+
+	nc, err := nats.Connect(server.ClientURL(), nats.UserInfo("sys", "bar"))
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	return nc
+}
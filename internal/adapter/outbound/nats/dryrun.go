@@ -0,0 +1,100 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/go-logr/logr"
+	"github.com/nats-io/jwt/v2"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DryRunSysClient skips the writes to the account JWTs on the connections of a NatsSysClient, logging the accounts
+// that would have been uploaded or deleted. Lookups and the other reads reach NATS, so reconciles report what would
+// change.
+type DryRunSysClient struct {
+	client outbound.NatsSysClient
+}
+
+func NewDryRunSysClient(client outbound.NatsSysClient) *DryRunSysClient {
+	return &DryRunSysClient{client: client}
+}
+
+func (c *DryRunSysClient) Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	conn, err := c.client.Connect(ctx, natsURL, userCreds, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	log := logf.FromContext(ctx).WithValues("natsURL", natsURL)
+	return &dryRunSysConnection{dryRunStore: dryRunStore{store: conn, log: log}, NatsSysConnection: conn}, nil
+}
+
+type dryRunStore struct {
+	store outbound.AccountJWTStore
+	log   logr.Logger
+}
+
+func (s *dryRunStore) LookupAccountJWT(accountID string) (string, error) {
+	return s.store.LookupAccountJWT(accountID)
+}
+
+func (s *dryRunStore) UploadAccountJWT(token string) error {
+	s.log.Info("Dry run: skipped uploading account JWT", "accountID", dryRunSubject(token))
+	return nil
+}
+
+func (s *dryRunStore) DeleteAccountJWT(token string) error {
+	var accountIDs []string
+	if claims, err := jwt.DecodeGeneric(token); err == nil {
+		if accounts, ok := claims.Data["accounts"].([]interface{}); ok {
+			for _, account := range accounts {
+				if accountID, ok := account.(string); ok {
+					accountIDs = append(accountIDs, accountID)
+				}
+			}
+		}
+	}
+	s.log.Info("Dry run: skipped deleting account JWTs", "accountIDs", accountIDs)
+	return nil
+}
+
+// dryRunSubject returns the subject of token for logging, empty when token cannot be decoded.
+func dryRunSubject(token string) string {
+	claims, err := jwt.DecodeGeneric(token)
+	if err != nil {
+		return ""
+	}
+	return claims.Subject
+}
+
+type dryRunSysConnection struct {
+	dryRunStore
+	outbound.NatsSysConnection
+}
+
+func (c *dryRunSysConnection) LookupAccountJWT(accountID string) (string, error) {
+	return c.dryRunStore.LookupAccountJWT(accountID)
+}
+
+func (c *dryRunSysConnection) UploadAccountJWT(token string) error {
+	return c.dryRunStore.UploadAccountJWT(token)
+}
+
+func (c *dryRunSysConnection) DeleteAccountJWT(token string) error {
+	return c.dryRunStore.DeleteAccountJWT(token)
+}
+
+func (c *dryRunSysConnection) ResolverStore(resolver domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	store, err := c.NatsSysConnection.ResolverStore(resolver)
+	if err != nil {
+		return nil, err
+	}
+	if store == c.NatsSysConnection {
+		return c, nil
+	}
+	return &dryRunStore{store: store, log: c.log}, nil
+}
+
+// Compile-time assertion that the implementation satisfies the ports interface
+var _ outbound.NatsSysClient = (*DryRunSysClient)(nil)
@@ -0,0 +1,59 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunSysClient_ShouldSkipWrites_AndPassThroughLookups(t *testing.T) {
+	conn := &sysConnectionFake{jwts: map[string]string{"ACC": "account-jwt"}}
+	unitUnderTest := NewDryRunSysClient(&sysClientFake{conn: conn})
+
+	dryRunConn, err := unitUnderTest.Connect(context.Background(), "nats://nats:4222", testCreds("a"), nil)
+	require.NoError(t, err)
+	store, err := dryRunConn.ResolverStore(domain.NatsResolver{Type: domain.NatsResolverTypePush})
+	require.NoError(t, err)
+
+	require.NoError(t, store.UploadAccountJWT("new-account-jwt"))
+	require.NoError(t, store.DeleteAccountJWT("delete-jwt"))
+	accountJWT, err := store.LookupAccountJWT("ACC")
+	require.NoError(t, err)
+	require.Equal(t, "account-jwt", accountJWT)
+	require.Empty(t, conn.writes)
+}
+
+type sysClientFake struct {
+	conn *sysConnectionFake
+}
+
+func (c *sysClientFake) Connect(_ context.Context, _ string, _ domain.NatsUserCreds, _ *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	return c.conn, nil
+}
+
+type sysConnectionFake struct {
+	outbound.NatsSysConnection
+	jwts   map[string]string
+	writes []string
+}
+
+func (c *sysConnectionFake) LookupAccountJWT(accountID string) (string, error) {
+	return c.jwts[accountID], nil
+}
+
+func (c *sysConnectionFake) UploadAccountJWT(jwt string) error {
+	c.writes = append(c.writes, jwt)
+	return nil
+}
+
+func (c *sysConnectionFake) DeleteAccountJWT(jwt string) error {
+	c.writes = append(c.writes, jwt)
+	return nil
+}
+
+func (c *sysConnectionFake) ResolverStore(_ domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	return c, nil
+}
@@ -0,0 +1,277 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/nats.go"
+)
+
+// ErrInjectedFault is returned by operations failed by fault injection.
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultConfig configures the faults injected into NATS operations, to verify that the controllers converge under
+// flaky infrastructure. Each operation draws its fault independently, probabilities are between 0 and 1.
+type FaultConfig struct {
+	// Latency delays an operation with probability LatencyProbability.
+	Latency            time.Duration
+	LatencyProbability float64
+	// Timeout is waited before an operation fails with nats.ErrTimeout, with probability TimeoutProbability.
+	Timeout            time.Duration
+	TimeoutProbability float64
+	// ErrorProbability is the probability an operation fails immediately with ErrInjectedFault.
+	ErrorProbability float64
+}
+
+// ParseFaultConfig parses a comma separated list of faults, each a kind with a duration and a probability, e.g.
+// "latency=200ms:0.5,timeout=3s:0.05,error=0.1". Errors only take a probability.
+func ParseFaultConfig(value string) (FaultConfig, error) {
+	var config FaultConfig
+	for _, fault := range strings.Split(value, ",") {
+		fault = strings.TrimSpace(fault)
+		if fault == "" {
+			continue
+		}
+		kind, setting, found := strings.Cut(fault, "=")
+		if !found {
+			return FaultConfig{}, fmt.Errorf("invalid fault %q, expected kind=setting", fault)
+		}
+		var err error
+		switch strings.TrimSpace(kind) {
+		case "latency":
+			config.Latency, config.LatencyProbability, err = parseTimedFault(setting)
+		case "timeout":
+			config.Timeout, config.TimeoutProbability, err = parseTimedFault(setting)
+		case "error":
+			config.ErrorProbability, err = parseProbability(setting)
+		default:
+			return FaultConfig{}, fmt.Errorf("unknown fault kind %q, expected one of latency, timeout or error", kind)
+		}
+		if err != nil {
+			return FaultConfig{}, fmt.Errorf("invalid %s fault: %w", strings.TrimSpace(kind), err)
+		}
+	}
+	return config, nil
+}
+
+func parseTimedFault(setting string) (time.Duration, float64, error) {
+	rawDuration, rawProbability, found := strings.Cut(setting, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("expected duration:probability, got %q", setting)
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(rawDuration))
+	if err != nil {
+		return 0, 0, err
+	}
+	if duration < 0 {
+		return 0, 0, fmt.Errorf("duration must not be negative, got %s", duration)
+	}
+	probability, err := parseProbability(rawProbability)
+	if err != nil {
+		return 0, 0, err
+	}
+	return duration, probability, nil
+}
+
+func parseProbability(value string) (float64, error) {
+	probability, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if probability < 0 || probability > 1 {
+		return 0, fmt.Errorf("probability must be between 0 and 1, got %v", probability)
+	}
+	return probability, nil
+}
+
+// faultInjector draws the faults of operations.
+type faultInjector struct {
+	config FaultConfig
+	sleep  func(time.Duration)
+
+	mu   sync.Mutex
+	rand func() float64
+}
+
+func newFaultInjector(config FaultConfig) *faultInjector {
+	return &faultInjector{config: config, sleep: time.Sleep, rand: rand.Float64}
+}
+
+func (f *faultInjector) draw(probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rand() < probability
+}
+
+// inject delays operation or returns the error it fails with, if any.
+func (f *faultInjector) inject(operation string) error {
+	if f.draw(f.config.LatencyProbability) {
+		f.sleep(f.config.Latency)
+	}
+	if f.draw(f.config.TimeoutProbability) {
+		f.sleep(f.config.Timeout)
+		return fmt.Errorf("%s: %w", operation, nats.ErrTimeout)
+	}
+	if f.draw(f.config.ErrorProbability) {
+		return fmt.Errorf("%s: %w", operation, ErrInjectedFault)
+	}
+	return nil
+}
+
+// FaultInjectingSysClient injects faults into the connections of a NatsSysClient.
+type FaultInjectingSysClient struct {
+	client outbound.NatsSysClient
+	faults *faultInjector
+}
+
+func NewFaultInjectingSysClient(client outbound.NatsSysClient, config FaultConfig) *FaultInjectingSysClient {
+	return &FaultInjectingSysClient{client: client, faults: newFaultInjector(config)}
+}
+
+func (c *FaultInjectingSysClient) Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	if err := c.faults.inject("connect"); err != nil {
+		return nil, err
+	}
+	conn, err := c.client.Connect(ctx, natsURL, userCreds, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &faultInjectingSysConnection{faultInjectingStore: faultInjectingStore{store: conn, faults: c.faults}, conn: conn}, nil
+}
+
+// FaultInjectingAccountClient injects faults into the connections of a NatsAccountClient.
+type FaultInjectingAccountClient struct {
+	client outbound.NatsAccountClient
+	faults *faultInjector
+}
+
+func NewFaultInjectingAccountClient(client outbound.NatsAccountClient, config FaultConfig) *FaultInjectingAccountClient {
+	return &FaultInjectingAccountClient{client: client, faults: newFaultInjector(config)}
+}
+
+func (c *FaultInjectingAccountClient) Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsAccountConnection, error) {
+	if err := c.faults.inject("connect"); err != nil {
+		return nil, err
+	}
+	conn, err := c.client.Connect(ctx, natsURL, userCreds, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &faultInjectingAccountConnection{conn: conn, faults: c.faults}, nil
+}
+
+type faultInjectingStore struct {
+	store  outbound.AccountJWTStore
+	faults *faultInjector
+}
+
+func (s *faultInjectingStore) LookupAccountJWT(accountID string) (string, error) {
+	if err := s.faults.inject("lookup account JWT"); err != nil {
+		return "", err
+	}
+	return s.store.LookupAccountJWT(accountID)
+}
+
+func (s *faultInjectingStore) UploadAccountJWT(jwt string) error {
+	if err := s.faults.inject("upload account JWT"); err != nil {
+		return err
+	}
+	return s.store.UploadAccountJWT(jwt)
+}
+
+func (s *faultInjectingStore) DeleteAccountJWT(jwt string) error {
+	if err := s.faults.inject("delete account JWT"); err != nil {
+		return err
+	}
+	return s.store.DeleteAccountJWT(jwt)
+}
+
+type faultInjectingSysConnection struct {
+	faultInjectingStore
+	conn outbound.NatsSysConnection
+}
+
+func (c *faultInjectingSysConnection) Disconnect() {
+	c.conn.Disconnect()
+}
+
+func (c *faultInjectingSysConnection) EnsureConnected() error {
+	if err := c.faults.inject("ensure connected"); err != nil {
+		return err
+	}
+	return c.conn.EnsureConnected()
+}
+
+func (c *faultInjectingSysConnection) VerifySystemAccountAccess() error {
+	if err := c.faults.inject("verify system account access"); err != nil {
+		return err
+	}
+	return c.conn.VerifySystemAccountAccess()
+}
+
+func (c *faultInjectingSysConnection) PingServers() ([]domain.NatsServerInfo, error) {
+	if err := c.faults.inject("ping servers"); err != nil {
+		return nil, err
+	}
+	return c.conn.PingServers()
+}
+
+func (c *faultInjectingSysConnection) AccountUsage(accountID string) (*domain.NatsAccountUsage, error) {
+	if err := c.faults.inject("account usage"); err != nil {
+		return nil, err
+	}
+	return c.conn.AccountUsage(accountID)
+}
+
+func (c *faultInjectingSysConnection) MaxPayload() int64 {
+	return c.conn.MaxPayload()
+}
+
+func (c *faultInjectingSysConnection) ResolverStore(resolver domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	store, err := c.conn.ResolverStore(resolver)
+	if err != nil {
+		return nil, err
+	}
+	if store == c.conn {
+		return c, nil
+	}
+	return &faultInjectingStore{store: store, faults: c.faults}, nil
+}
+
+type faultInjectingAccountConnection struct {
+	conn   outbound.NatsAccountConnection
+	faults *faultInjector
+}
+
+func (c *faultInjectingAccountConnection) Disconnect() {
+	c.conn.Disconnect()
+}
+
+func (c *faultInjectingAccountConnection) EnsureConnected() error {
+	if err := c.faults.inject("ensure connected"); err != nil {
+		return err
+	}
+	return c.conn.EnsureConnected()
+}
+
+func (c *faultInjectingAccountConnection) ListAccountStreams() ([]string, error) {
+	if err := c.faults.inject("list account streams"); err != nil {
+		return nil, err
+	}
+	return c.conn.ListAccountStreams()
+}
+
+// Compile-time assertions that implementations satisfy the ports interfaces
+var _ outbound.NatsSysClient = (*FaultInjectingSysClient)(nil)
+var _ outbound.NatsAccountClient = (*FaultInjectingAccountClient)(nil)
@@ -0,0 +1,112 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFaultConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expected      FaultConfig
+		expectedError string
+	}{
+		{
+			name:  "all_faults",
+			value: "latency=200ms:0.5, timeout=3s:0.05, error=0.1",
+			expected: FaultConfig{
+				Latency:            200 * time.Millisecond,
+				LatencyProbability: 0.5,
+				Timeout:            3 * time.Second,
+				TimeoutProbability: 0.05,
+				ErrorProbability:   0.1,
+			},
+		},
+		{
+			name:     "empty",
+			value:    "",
+			expected: FaultConfig{},
+		},
+		{
+			name:          "unknown_kind",
+			value:         "drop=0.1",
+			expectedError: `unknown fault kind "drop", expected one of latency, timeout or error`,
+		},
+		{
+			name:          "missing_probability",
+			value:         "latency=200ms",
+			expectedError: `invalid latency fault: expected duration:probability, got "200ms"`,
+		},
+		{
+			name:          "probability_out_of_range",
+			value:         "error=1.5",
+			expectedError: "invalid error fault: probability must be between 0 and 1, got 1.5",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := ParseFaultConfig(tc.value)
+
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, config)
+		})
+	}
+}
+
+func TestFaultInjector_ShouldDelayAndTimeOut_WhenDrawn(t *testing.T) {
+	faults := newFaultInjector(FaultConfig{
+		Latency:            200 * time.Millisecond,
+		LatencyProbability: 0.5,
+		Timeout:            3 * time.Second,
+		TimeoutProbability: 0.5,
+		ErrorProbability:   0.5,
+	})
+	var slept []time.Duration
+	faults.sleep = func(d time.Duration) { slept = append(slept, d) }
+	draws := []float64{0.1, 0.1}
+	faults.rand = func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+
+	err := faults.inject("upload account JWT")
+
+	require.ErrorIs(t, err, nats.ErrTimeout)
+	require.EqualError(t, err, "upload account JWT: nats: timeout")
+	require.Equal(t, []time.Duration{200 * time.Millisecond, 3 * time.Second}, slept)
+}
+
+func TestFaultInjectingSysClient_ShouldFail_WhenErrorDrawn(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+	unitUnderTest := NewFaultInjectingSysClient(pool, FaultConfig{ErrorProbability: 1})
+
+	_, err := unitUnderTest.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+
+	require.ErrorIs(t, err, ErrInjectedFault)
+	require.Zero(t, *dials)
+}
+
+func TestFaultInjectingSysClient_ShouldPassThrough_WithoutFaults(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+	unitUnderTest := NewFaultInjectingSysClient(pool, FaultConfig{})
+
+	conn, err := unitUnderTest.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	defer conn.Disconnect()
+
+	require.NoError(t, conn.EnsureConnected())
+	require.Equal(t, 1, *dials)
+}
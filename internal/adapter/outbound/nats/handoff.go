@@ -0,0 +1,117 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	DefaultClaimsPushHandoffInterval = time.Second
+	DefaultClaimsPushHandoffMaxAge   = 10 * time.Minute
+)
+
+// ClaimsPushHandoff hands the account JWT pushes of a ClaimsPushBatcher over to the next leader. While the operator
+// is the leader, the pushes not sent yet are saved to a store every interval and when the operator stops. When the
+// next leader starts, it takes over the saved pushes and sends them as soon as their cluster is connected, instead of
+// waiting for their Accounts to be reconciled. Pushes saved longer than maxAge ago are dropped.
+type ClaimsPushHandoff struct {
+	batcher  *ClaimsPushBatcher
+	store    outbound.NatsClaimsPushStore
+	interval time.Duration
+	maxAge   time.Duration
+	now      func() time.Time
+}
+
+var _ manager.Runnable = (*ClaimsPushHandoff)(nil)
+var _ manager.LeaderElectionRunnable = (*ClaimsPushHandoff)(nil)
+
+func NewClaimsPushHandoff(batcher *ClaimsPushBatcher, store outbound.NatsClaimsPushStore, interval time.Duration, maxAge time.Duration) (*ClaimsPushHandoff, error) {
+	handoff := &ClaimsPushHandoff{
+		batcher:  batcher,
+		store:    store,
+		interval: interval,
+		maxAge:   maxAge,
+		now:      time.Now,
+	}
+	if err := handoff.validate(); err != nil {
+		return nil, err
+	}
+	return handoff, nil
+}
+
+func (h *ClaimsPushHandoff) validate() error {
+	if h.batcher == nil {
+		return fmt.Errorf("push batcher is required")
+	}
+	if h.store == nil {
+		return fmt.Errorf("push store is required")
+	}
+	if h.interval <= 0 {
+		return fmt.Errorf("handoff interval must be positive")
+	}
+	if h.maxAge <= 0 {
+		return fmt.Errorf("handoff max age must be positive")
+	}
+	return nil
+}
+
+// Start takes over the pushes saved by the previous leader and saves the outstanding pushes until ctx is done.
+func (h *ClaimsPushHandoff) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("nats-jwt-push-handoff")
+
+	if pushes, err := h.store.LoadClaimsPushes(ctx); err != nil {
+		logger.Error(err, "Failed to load account JWT pushes of the previous leader")
+	} else if count := h.batcher.handOver(h.recent(pushes)); count > 0 {
+		logger.Info("Resuming account JWT pushes of the previous leader", "pushes", count)
+	}
+
+	saved, ok := uint64(0), false
+	save := func(ctx context.Context) {
+		pushes, revision := h.batcher.outstandingPushes()
+		if ok && revision == saved {
+			return
+		}
+		if err := h.store.SaveClaimsPushes(ctx, pushes); err != nil {
+			logger.Error(err, "Failed to save outstanding account JWT pushes")
+			return
+		}
+		saved, ok = revision, true
+	}
+	save(ctx)
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Save the pushes aborted on shutdown for the next leader
+			shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			save(shutdownCtx)
+			cancel()
+			return nil
+		case <-ticker.C:
+			save(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the leader pushes account JWTs.
+func (h *ClaimsPushHandoff) NeedLeaderElection() bool {
+	return true
+}
+
+func (h *ClaimsPushHandoff) recent(pushes []domain.NatsClaimsPush) []domain.NatsClaimsPush {
+	result := make([]domain.NatsClaimsPush, 0, len(pushes))
+	for _, push := range pushes {
+		if h.now().Sub(push.QueuedAt) <= h.maxAge {
+			result = append(result, push)
+		}
+	}
+	return result
+}
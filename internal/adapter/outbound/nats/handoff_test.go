@@ -0,0 +1,94 @@
+package nats
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClaimsPushHandoff_ShouldFail_WhenSettingsInvalid(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+
+	_, err = NewClaimsPushHandoff(nil, &memoryClaimsPushStore{}, time.Second, time.Minute)
+	require.ErrorContains(t, err, "push batcher is required")
+
+	_, err = NewClaimsPushHandoff(batcher, nil, time.Second, time.Minute)
+	require.ErrorContains(t, err, "push store is required")
+
+	_, err = NewClaimsPushHandoff(batcher, &memoryClaimsPushStore{}, 0, time.Minute)
+	require.ErrorContains(t, err, "handoff interval must be positive")
+
+	_, err = NewClaimsPushHandoff(batcher, &memoryClaimsPushStore{}, time.Second, 0)
+	require.ErrorContains(t, err, "handoff max age must be positive")
+}
+
+func TestClaimsPushHandoff_ShouldTakeOverRecentPushesOfPreviousLeader(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(0, 0)
+	require.NoError(t, err)
+	now := time.Now()
+	recent := domain.NatsClaimsPush{
+		ClusterKey: "cluster", Token: encodeTestAccountJWT(t, newTestAccountKey(t), "recent"), QueuedAt: now,
+	}
+	expired := domain.NatsClaimsPush{
+		ClusterKey: "cluster", Token: encodeTestAccountJWT(t, newTestAccountKey(t), "expired"), QueuedAt: now.Add(-time.Hour),
+	}
+	store := &memoryClaimsPushStore{pushes: []domain.NatsClaimsPush{recent, expired}}
+	handoff, err := NewClaimsPushHandoff(batcher, store, time.Hour, time.Minute)
+	require.NoError(t, err)
+	handoff.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, handoff.Start(ctx))
+
+	require.True(t, batcher.hasHandedOver("cluster"))
+	require.Equal(t, []domain.NatsClaimsPush{recent}, store.saved())
+}
+
+func TestClaimsPushHandoff_ShouldSaveOutstandingPushes(t *testing.T) {
+	batcher, err := NewClaimsPushBatcher(500*time.Millisecond, 0)
+	require.NoError(t, err)
+	store := &memoryClaimsPushStore{}
+	handoff, err := NewClaimsPushHandoff(batcher, store, time.Millisecond, time.Minute)
+	require.NoError(t, err)
+	token := encodeTestAccountJWT(t, newTestAccountKey(t), "a")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var started, pushed sync.WaitGroup
+	started.Go(func() { _ = handoff.Start(ctx) })
+	pushed.Go(func() { _ = batcher.push("cluster", token, nil, (&recordingUploader{}).upload) })
+
+	require.Eventually(t, func() bool { return len(store.saved()) == 1 }, 400*time.Millisecond, time.Millisecond)
+	require.Equal(t, token, store.saved()[0].Token)
+	pushed.Wait()
+	cancel()
+	started.Wait()
+	require.Empty(t, store.saved())
+}
+
+type memoryClaimsPushStore struct {
+	mu     sync.Mutex
+	pushes []domain.NatsClaimsPush
+}
+
+func (s *memoryClaimsPushStore) LoadClaimsPushes(context.Context) ([]domain.NatsClaimsPush, error) {
+	return s.saved(), nil
+}
+
+func (s *memoryClaimsPushStore) SaveClaimsPushes(_ context.Context, pushes []domain.NatsClaimsPush) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pushes = pushes
+	return nil
+}
+
+func (s *memoryClaimsPushStore) saved() []domain.NatsClaimsPush {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pushes
+}
@@ -0,0 +1,37 @@
+package nats
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	claimsPushResultSuccess = "success"
+	claimsPushResultError   = "error"
+	claimsPushResultAborted = "aborted"
+)
+
+var (
+	claimsPushQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "nauth",
+		Subsystem: "nats",
+		Name:      "jwt_push_queue_depth",
+		Help:      "Number of account JWT pushes waiting in the batching window or for the rate limiter.",
+	})
+	claimsPushCoalescedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "nauth",
+		Subsystem: "nats",
+		Name:      "jwt_push_coalesced_total",
+		Help:      "Number of account JWT pushes merged into an already queued push of the same account.",
+	})
+	claimsPushTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "nauth",
+		Subsystem: "nats",
+		Name:      "jwt_push_total",
+		Help:      "Number of account JWT pushes sent to NATS, by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(claimsPushQueueDepth, claimsPushCoalescedTotal, claimsPushTotal)
+}
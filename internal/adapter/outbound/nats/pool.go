@@ -0,0 +1,313 @@
+package nats
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	DefaultPoolIdleTimeout         = 5 * time.Minute
+	DefaultPoolHealthCheckInterval = 30 * time.Second
+)
+
+type dialFunc func(natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (*connection, error)
+
+// SysConnectionPool keeps long-lived system account connections, one per NATS cluster, so that reconciles borrow an
+// established connection instead of dialing the cluster for every operation. Connections are keyed by the URL,
+// credentials and TLS settings of the cluster, so rotated credentials result in a new connection while the previous
+// one idles out. Disconnect on a borrowed connection returns it to the pool.
+//
+// Account JWT uploads through borrowed connections go through the optional ClaimsPushBatcher.
+//
+// The pool must be started, see Start, to evict idle and unhealthy connections and to close all connections on
+// shutdown.
+type SysConnectionPool struct {
+	idleTimeout         time.Duration
+	healthCheckInterval time.Duration
+	batcher             *ClaimsPushBatcher
+	dial                dialFunc
+	now                 func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*pooledConnection
+	closed   bool
+	shutdown chan struct{}
+}
+
+var _ outbound.NatsSysClient = (*SysConnectionPool)(nil)
+var _ manager.Runnable = (*SysConnectionPool)(nil)
+var _ manager.LeaderElectionRunnable = (*SysConnectionPool)(nil)
+
+// NewSysConnectionPool creates a connection pool. batcher is optional, without it account JWTs are pushed directly.
+func NewSysConnectionPool(idleTimeout time.Duration, healthCheckInterval time.Duration, batcher *ClaimsPushBatcher) (*SysConnectionPool, error) {
+	pool := &SysConnectionPool{
+		idleTimeout:         idleTimeout,
+		healthCheckInterval: healthCheckInterval,
+		batcher:             batcher,
+		dial:                connect,
+		now:                 time.Now,
+		entries:             make(map[string]*pooledConnection),
+		shutdown:            make(chan struct{}),
+	}
+	if err := pool.validate(); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+func (p *SysConnectionPool) validate() error {
+	if p.idleTimeout <= 0 {
+		return fmt.Errorf("idle timeout must be positive")
+	}
+	if p.healthCheckInterval <= 0 {
+		return fmt.Errorf("health check interval must be positive")
+	}
+	if p.dial == nil {
+		return fmt.Errorf("dial function is required")
+	}
+	return nil
+}
+
+// Connect borrows a pooled connection to natsURL, dialing a new one when none is available or the pooled one is
+// unhealthy.
+func (p *SysConnectionPool) Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	lease, err := p.lease(natsURL, userCreds, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	p.resumeHandedOver(ctx, lease.entry)
+	return lease, nil
+}
+
+func (p *SysConnectionPool) lease(natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (*pooledLease, error) {
+	key := poolKey(natsURL, userCreds, tlsConfig)
+
+	if lease, err := p.borrow(key); lease != nil || err != nil {
+		return lease, err
+	}
+
+	conn, err := p.dial(natsURL, userCreds, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		conn.Disconnect()
+		return nil, fmt.Errorf("NATS connection pool is closed")
+	}
+	if entry, ok := p.entries[key]; ok && entry.isConnected() {
+		// Another reconcile dialed the same cluster concurrently, keep the connection already pooled.
+		conn.Disconnect()
+		return p.leaseLocked(entry), nil
+	}
+	entry := &pooledConnection{key: key, conn: conn}
+	p.entries[key] = entry
+	return p.leaseLocked(entry), nil
+}
+
+// resumeHandedOver sends the pushes the batcher took over from the previous leader for the cluster of entry in the
+// background, holding a lease of its own until they are sent.
+func (p *SysConnectionPool) resumeHandedOver(ctx context.Context, entry *pooledConnection) {
+	if p.batcher == nil || !p.batcher.hasHandedOver(entry.key) {
+		return
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	lease := p.leaseLocked(entry)
+	p.mu.Unlock()
+
+	logger := log.FromContext(ctx).WithName("nats-connection-pool")
+	go func() {
+		defer lease.Disconnect()
+		p.batcher.resume(entry.key, p.shutdown, lease.connection.UploadAccountJWT, logger)
+	}()
+}
+
+func (p *SysConnectionPool) borrow(key string) (*pooledLease, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, fmt.Errorf("NATS connection pool is closed")
+	}
+	entry, ok := p.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if entry.isConnected() {
+		return p.leaseLocked(entry), nil
+	}
+	p.evictLocked(entry)
+	return nil, nil
+}
+
+func (p *SysConnectionPool) leaseLocked(entry *pooledConnection) *pooledLease {
+	entry.borrowers++
+	entry.lastUsed = p.now()
+	return &pooledLease{connection: entry.conn, pool: p, entry: entry}
+}
+
+func (p *SysConnectionPool) release(entry *pooledConnection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry.borrowers--
+	entry.lastUsed = p.now()
+	if entry.borrowers == 0 && (entry.evicted || p.closed) {
+		entry.conn.Disconnect()
+	}
+}
+
+// evictLocked removes entry from the pool. The connection is closed right away when idle, otherwise when the last
+// borrower returns it.
+func (p *SysConnectionPool) evictLocked(entry *pooledConnection) {
+	if current, ok := p.entries[entry.key]; ok && current == entry {
+		delete(p.entries, entry.key)
+	}
+	entry.evicted = true
+	if entry.borrowers == 0 {
+		entry.conn.Disconnect()
+	}
+}
+
+// Start runs the periodic health checks until ctx is done, then closes all pooled connections.
+func (p *SysConnectionPool) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.close()
+			return nil
+		case <-ticker.C:
+			p.checkConnections(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Connections are used by all replicas.
+func (p *SysConnectionPool) NeedLeaderElection() bool {
+	return false
+}
+
+// checkConnections evicts connections that have been idle for longer than the idle timeout and connections that no
+// longer respond to a ping.
+func (p *SysConnectionPool) checkConnections(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("nats-connection-pool")
+
+	p.mu.Lock()
+	idle := make([]*pooledConnection, 0, len(p.entries))
+	for _, entry := range p.entries {
+		if entry.borrowers > 0 {
+			continue
+		}
+		if p.now().Sub(entry.lastUsed) >= p.idleTimeout {
+			logger.V(1).Info("Closing idle NATS connection", "natsURL", entry.conn.natsURL)
+			p.evictLocked(entry)
+			continue
+		}
+		idle = append(idle, entry)
+	}
+	p.mu.Unlock()
+
+	for _, entry := range idle {
+		if err := entry.ping(); err != nil {
+			logger.Info("Evicting unhealthy NATS connection", "natsURL", entry.conn.natsURL, "error", err.Error())
+			p.mu.Lock()
+			p.evictLocked(entry)
+			p.mu.Unlock()
+		}
+	}
+}
+
+func (p *SysConnectionPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.shutdown)
+	for _, entry := range p.entries {
+		p.evictLocked(entry)
+	}
+}
+
+type pooledConnection struct {
+	key       string
+	conn      *connection
+	borrowers int
+	lastUsed  time.Time
+	evicted   bool
+}
+
+func (e *pooledConnection) isConnected() bool {
+	return e.conn.conn != nil && e.conn.conn.IsConnected()
+}
+
+func (e *pooledConnection) ping() error {
+	if !e.isConnected() {
+		return fmt.Errorf("NATS connection is not established or lost")
+	}
+	return e.conn.conn.FlushTimeout(natsMaxTimeout)
+}
+
+// pooledLease is a borrowed pooled connection. The underlying connection is shared, so it is never redialed or
+// closed through the lease.
+type pooledLease struct {
+	*connection
+	pool     *SysConnectionPool
+	entry    *pooledConnection
+	released sync.Once
+}
+
+func (l *pooledLease) EnsureConnected() error {
+	if !l.entry.isConnected() {
+		return fmt.Errorf("pooled NATS connection is lost")
+	}
+	return nil
+}
+
+// UploadAccountJWT pushes token through the pool's batcher, if any.
+func (l *pooledLease) UploadAccountJWT(token string) error {
+	if l.pool.batcher == nil {
+		return l.connection.UploadAccountJWT(token)
+	}
+	return l.pool.batcher.push(l.entry.key, token, l.pool.shutdown, l.connection.UploadAccountJWT)
+}
+
+// Disconnect returns the connection to the pool.
+func (l *pooledLease) Disconnect() {
+	l.released.Do(func() {
+		l.pool.release(l.entry)
+	})
+}
+
+func poolKey(natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) string {
+	hash := sha256.New()
+	writeField := func(value []byte) {
+		_, _ = fmt.Fprintf(hash, "%d:", len(value))
+		_, _ = hash.Write(value)
+	}
+	writeField([]byte(natsURL))
+	writeField(userCreds.Creds)
+	if tlsConfig != nil {
+		writeField(tlsConfig.CACert)
+		writeField(tlsConfig.ClientCert)
+		writeField(tlsConfig.ClientKey)
+		writeField([]byte(fmt.Sprint(tlsConfig.InsecureSkipVerify)))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,149 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSysConnectionPool_ShouldFail_WhenDurationsInvalid(t *testing.T) {
+	_, err := NewSysConnectionPool(0, time.Second, nil)
+	require.ErrorContains(t, err, "idle timeout must be positive")
+
+	_, err = NewSysConnectionPool(time.Second, 0, nil)
+	require.ErrorContains(t, err, "health check interval must be positive")
+}
+
+func TestSysConnectionPool_ShouldReuseConnection(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+
+	first, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	first.Disconnect()
+	second, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	defer second.Disconnect()
+
+	require.Equal(t, 1, *dials)
+	require.NoError(t, second.EnsureConnected())
+}
+
+func TestSysConnectionPool_ShouldShareConnectionBetweenConcurrentBorrowers(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+
+	first, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	second, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+
+	first.Disconnect()
+	first.Disconnect()
+	require.NoError(t, second.EnsureConnected())
+	second.Disconnect()
+
+	require.Equal(t, 1, *dials)
+}
+
+func TestSysConnectionPool_ShouldDialPerCredentials(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+
+	first, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	defer first.Disconnect()
+	second, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("b"), nil)
+	require.NoError(t, err)
+	defer second.Disconnect()
+
+	require.Equal(t, 2, *dials)
+}
+
+func TestSysConnectionPool_ShouldRedial_WhenPooledConnectionIsLost(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+
+	first, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	first.Disconnect()
+	first.(*pooledLease).conn.Close()
+
+	second, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	defer second.Disconnect()
+
+	require.Equal(t, 2, *dials)
+	require.NoError(t, second.EnsureConnected())
+}
+
+func TestSysConnectionPool_ShouldCloseIdleConnections(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, dials := newTestPool(t, server)
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+
+	borrowed, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	idle, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("b"), nil)
+	require.NoError(t, err)
+	idle.Disconnect()
+
+	now = now.Add(time.Hour)
+	pool.checkConnections(context.Background())
+
+	requireClosed(t, idle)
+	require.NoError(t, borrowed.EnsureConnected())
+	borrowed.Disconnect()
+
+	_, err = pool.Connect(context.Background(), server.ClientURL(), testCreds("b"), nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, *dials)
+}
+
+func TestSysConnectionPool_ShouldCloseConnections_WhenStopped(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, _ := newTestPool(t, server)
+
+	borrowed, err := pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, pool.Start(ctx))
+
+	require.False(t, borrowed.(*pooledLease).conn.IsClosed(), "borrowed connection must stay open until returned")
+	borrowed.Disconnect()
+	requireClosed(t, borrowed)
+
+	_, err = pool.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+	require.ErrorContains(t, err, "NATS connection pool is closed")
+}
+
+func requireClosed(t *testing.T, conn outbound.NatsSysConnection) {
+	t.Helper()
+	require.Eventually(t, conn.(*pooledLease).conn.IsClosed, 5*time.Second, 10*time.Millisecond)
+}
+
+func newTestPool(t *testing.T, server *natsserver.Server) (*SysConnectionPool, *int) {
+	t.Helper()
+
+	pool, err := NewSysConnectionPool(time.Minute, time.Minute, nil)
+	require.NoError(t, err)
+
+	dials := 0
+	pool.dial = func(natsURL string, _ domain.NatsUserCreds, _ *domain.NatsTLSConfig) (*connection, error) {
+		dials++
+		return &connection{natsURL: natsURL, conn: connectTestAccount(t, server)}, nil
+	}
+	return pool, &dials
+}
+
+func testCreds(value string) domain.NatsUserCreds {
+	return domain.NatsUserCreds{Creds: []byte(value)}
+}
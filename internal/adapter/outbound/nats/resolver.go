@@ -0,0 +1,222 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nkeys"
+)
+
+func (n *connection) ResolverStore(resolver domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	return resolverStore(n, n, resolver)
+}
+
+// ResolverStore returns the lease itself for the push resolver type, so uploads go through the pool's batcher.
+func (l *pooledLease) ResolverStore(resolver domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	return resolverStore(l, l.connection, resolver)
+}
+
+func resolverStore(push outbound.AccountJWTStore, conn *connection, resolver domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	if err := resolver.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resolver: %w", err)
+	}
+	switch resolver.Type {
+	case domain.NatsResolverTypeKV:
+		return &kvAccountJWTStore{conn: conn.conn, bucket: resolver.KVBucket}, nil
+	case domain.NatsResolverTypeDirectory:
+		return &directoryAccountJWTStore{directory: resolver.Directory}, nil
+	default:
+		return push, nil
+	}
+}
+
+// kvAccountJWTStore keeps account JWTs in a JetStream key-value bucket of the system account, keyed by account ID.
+// The bucket is not created by the store.
+type kvAccountJWTStore struct {
+	conn   *nats.Conn
+	bucket string
+}
+
+func (s *kvAccountJWTStore) LookupAccountJWT(accountID string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), natsMaxTimeout)
+	defer cancel()
+
+	kv, err := s.keyValue(ctx)
+	if err != nil {
+		return "", err
+	}
+	entry, err := kv.Get(ctx, accountID)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get account JWT from key-value bucket %s: %w", s.bucket, err)
+	}
+	return string(entry.Value()), nil
+}
+
+func (s *kvAccountJWTStore) UploadAccountJWT(token string) error {
+	claims, err := jwt.DecodeAccountClaims(token)
+	if err != nil {
+		return fmt.Errorf("failed to decode account JWT: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), natsMaxTimeout)
+	defer cancel()
+
+	kv, err := s.keyValue(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := kv.Put(ctx, claims.Subject, []byte(token)); err != nil {
+		return fmt.Errorf("failed to put account JWT to key-value bucket %s: %w", s.bucket, err)
+	}
+	return nil
+}
+
+func (s *kvAccountJWTStore) DeleteAccountJWT(token string) error {
+	accountIDs, err := deletedAccountIDs(token)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), natsMaxTimeout)
+	defer cancel()
+
+	kv, err := s.keyValue(ctx)
+	if err != nil {
+		return err
+	}
+	for _, accountID := range accountIDs {
+		if err := kv.Delete(ctx, accountID); err != nil {
+			return fmt.Errorf("failed to delete account JWT from key-value bucket %s: %w", s.bucket, err)
+		}
+	}
+	return nil
+}
+
+func (s *kvAccountJWTStore) keyValue(ctx context.Context) (jetstream.KeyValue, error) {
+	if s.conn == nil || !s.conn.IsConnected() {
+		return nil, fmt.Errorf("NATS connection is not established or lost")
+	}
+	js, err := jetstream.New(s.conn)
+	if err != nil {
+		return nil, fmt.Errorf("create JetStream client: %w", err)
+	}
+	kv, err := js.KeyValue(ctx, s.bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key-value bucket %s: %w", s.bucket, err)
+	}
+	return kv, nil
+}
+
+// directoryAccountJWTStore keeps account JWTs as <account ID>.jwt files in the directory of a NATS full resolver,
+// shared with the NATS servers through a volume.
+type directoryAccountJWTStore struct {
+	directory string
+}
+
+func (s *directoryAccountJWTStore) LookupAccountJWT(accountID string) (string, error) {
+	path, err := s.path(accountID)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read account JWT: %w", err)
+	}
+	return string(data), nil
+}
+
+func (s *directoryAccountJWTStore) UploadAccountJWT(token string) error {
+	claims, err := jwt.DecodeAccountClaims(token)
+	if err != nil {
+		return fmt.Errorf("failed to decode account JWT: %w", err)
+	}
+	path, err := s.path(claims.Subject)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so NATS servers never read a partially written JWT
+	file, err := os.CreateTemp(s.directory, ".nauth-*.jwt.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create account JWT file: %w", err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := file.WriteString(token); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write account JWT file: %w", err)
+	}
+	if err := file.Chmod(0o644); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write account JWT file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write account JWT file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to write account JWT file: %w", err)
+	}
+	return nil
+}
+
+func (s *directoryAccountJWTStore) DeleteAccountJWT(token string) error {
+	accountIDs, err := deletedAccountIDs(token)
+	if err != nil {
+		return err
+	}
+	for _, accountID := range accountIDs {
+		path, err := s.path(accountID)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete account JWT file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *directoryAccountJWTStore) path(accountID string) (string, error) {
+	// Also keeps the file within the directory, account IDs never contain path separators
+	if !nkeys.IsValidPublicAccountKey(accountID) {
+		return "", fmt.Errorf("invalid account ID %q", accountID)
+	}
+	return filepath.Join(s.directory, accountID+".jwt"), nil
+}
+
+// deletedAccountIDs returns the accounts listed in a claims delete request JWT.
+func deletedAccountIDs(token string) ([]string, error) {
+	claims, err := jwt.DecodeGeneric(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode delete request JWT: %w", err)
+	}
+	accounts, ok := claims.Data["accounts"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("delete request JWT has no accounts")
+	}
+	result := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountID, ok := account.(string)
+		if !ok {
+			return nil, fmt.Errorf("delete request JWT has invalid account %v", account)
+		}
+		result = append(result, accountID)
+	}
+	return result, nil
+}
+
+var _ outbound.AccountJWTStore = (*kvAccountJWTStore)(nil)
+var _ outbound.AccountJWTStore = (*directoryAccountJWTStore)(nil)
@@ -0,0 +1,131 @@
+package nats
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnection_ResolverStore(t *testing.T) {
+	conn := &connection{}
+	testCases := []struct {
+		name     string
+		resolver domain.NatsResolver
+		expected outbound.AccountJWTStore
+	}{
+		{
+			name:     "default",
+			expected: conn,
+		},
+		{
+			name:     "push",
+			resolver: domain.NatsResolver{Type: domain.NatsResolverTypePush},
+			expected: conn,
+		},
+		{
+			name:     "kv",
+			resolver: domain.NatsResolver{Type: domain.NatsResolverTypeKV, KVBucket: "jwts"},
+			expected: &kvAccountJWTStore{bucket: "jwts"},
+		},
+		{
+			name:     "directory",
+			resolver: domain.NatsResolver{Type: domain.NatsResolverTypeDirectory, Directory: "/data/jwt"},
+			expected: &directoryAccountJWTStore{directory: "/data/jwt"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := conn.ResolverStore(tc.resolver)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, store)
+		})
+	}
+}
+
+func TestConnection_ResolverStore_ShouldFail_WhenResolverInvalid(t *testing.T) {
+	_, err := (&connection{}).ResolverStore(domain.NatsResolver{Type: domain.NatsResolverTypeDirectory})
+
+	require.ErrorContains(t, err, "directory is required")
+}
+
+func TestKVAccountJWTStore(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{
+		serverJetStream:  true,
+		accountJetStream: true,
+	})
+	nc := connectTestAccount(t, server)
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+	_, err = js.CreateKeyValue(context.Background(), jetstream.KeyValueConfig{Bucket: "jwts"})
+	require.NoError(t, err)
+
+	verifyAccountJWTStore(t, &kvAccountJWTStore{conn: nc, bucket: "jwts"})
+}
+
+func TestKVAccountJWTStore_ShouldFail_WhenBucketNotFound(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{
+		serverJetStream:  true,
+		accountJetStream: true,
+	})
+	store := &kvAccountJWTStore{conn: connectTestAccount(t, server), bucket: "missing"}
+
+	_, err := store.LookupAccountJWT("ACCOUNT")
+
+	require.ErrorContains(t, err, "failed to get key-value bucket missing")
+}
+
+func TestDirectoryAccountJWTStore(t *testing.T) {
+	directory := t.TempDir()
+
+	accountID := verifyAccountJWTStore(t, &directoryAccountJWTStore{directory: directory})
+
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries, "temporary and deleted files should be removed")
+	_, err = os.Stat(filepath.Join(directory, accountID+".jwt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDirectoryAccountJWTStore_ShouldFail_WhenAccountIDInvalid(t *testing.T) {
+	store := &directoryAccountJWTStore{directory: t.TempDir()}
+
+	_, err := store.LookupAccountJWT("../secret")
+
+	require.ErrorContains(t, err, `invalid account ID "../secret"`)
+}
+
+// verifyAccountJWTStore uploads, looks up and deletes an account JWT through store, returning the account ID.
+func verifyAccountJWTStore(t *testing.T, store outbound.AccountJWTStore) string {
+	t.Helper()
+	op := newOperator(t)
+	acc := newAccount(t, op, nil)
+	accountID := acc.key.PublicKey
+
+	accountJWT, err := store.LookupAccountJWT(accountID)
+	require.NoError(t, err)
+	require.Empty(t, accountJWT, "unknown account should have no JWT")
+
+	require.NoError(t, store.UploadAccountJWT(acc.jwt))
+	accountJWT, err = store.LookupAccountJWT(accountID)
+	require.NoError(t, err)
+	require.Equal(t, acc.jwt, accountJWT)
+
+	deleteClaims := jwt.NewGenericClaims(op.rootKey.PublicKey)
+	deleteClaims.Data["accounts"] = []string{accountID}
+	deleteJWT, err := deleteClaims.Encode(op.rootKey.Key)
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteAccountJWT(deleteJWT))
+	accountJWT, err = store.LookupAccountJWT(accountID)
+	require.NoError(t, err)
+	require.Empty(t, accountJWT, "deleted account should have no JWT")
+
+	return accountID
+}
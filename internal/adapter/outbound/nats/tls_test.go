@@ -0,0 +1,113 @@
+package nats
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientTLSConfig(t *testing.T) {
+	certPEM, keyPEM := generateSelfSignedCert(t)
+
+	testCases := []struct {
+		testName      string
+		config        domain.NatsTLSConfig
+		expectedError string
+		verify        func(t *testing.T, result *tls.Config)
+	}{
+		{
+			testName: "should_use_system_roots_when_no_ca",
+			config:   domain.NatsTLSConfig{},
+			verify: func(t *testing.T, result *tls.Config) {
+				require.Nil(t, result.RootCAs)
+				require.Empty(t, result.Certificates)
+				require.Equal(t, uint16(tls.VersionTLS12), result.MinVersion)
+			},
+		},
+		{
+			testName: "should_use_custom_ca",
+			config:   domain.NatsTLSConfig{CACert: certPEM},
+			verify: func(t *testing.T, result *tls.Config) {
+				require.NotNil(t, result.RootCAs)
+			},
+		},
+		{
+			testName: "should_load_client_certificate",
+			config:   domain.NatsTLSConfig{ClientCert: certPEM, ClientKey: keyPEM},
+			verify: func(t *testing.T, result *tls.Config) {
+				require.Len(t, result.Certificates, 1)
+			},
+		},
+		{
+			testName: "should_skip_verify_when_requested",
+			config:   domain.NatsTLSConfig{InsecureSkipVerify: true},
+			verify: func(t *testing.T, result *tls.Config) {
+				require.True(t, result.InsecureSkipVerify)
+			},
+		},
+		{
+			testName:      "should_fail_when_ca_is_not_pem",
+			config:        domain.NatsTLSConfig{CACert: []byte("not a certificate")},
+			expectedError: "no valid PEM encoded certificates found in CA bundle",
+		},
+		{
+			testName:      "should_fail_when_client_key_missing",
+			config:        domain.NatsTLSConfig{ClientCert: certPEM},
+			expectedError: "client certificate and key must be provided together",
+		},
+		{
+			testName:      "should_fail_when_client_key_does_not_match",
+			config:        domain.NatsTLSConfig{ClientCert: certPEM, ClientKey: []byte("invalid")},
+			expectedError: "invalid client certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			result, err := newClientTLSConfig(tc.config)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.verify(t, result)
+		})
+	}
+}
+
+func generateSelfSignedCert(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "nauth-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
@@ -0,0 +1,199 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/WirelessCar/nauth/internal/adapter/outbound/nats"
+
+// TracingSysClient records the operations on the connections of a NatsSysClient as spans of the trace of the context
+// the connection was opened with, usually the reconcile borrowing it.
+type TracingSysClient struct {
+	client outbound.NatsSysClient
+	tracer trace.Tracer
+}
+
+// NewTracingSysClient wraps client with spans created by tracerProvider, the global provider when nil.
+func NewTracingSysClient(client outbound.NatsSysClient, tracerProvider trace.TracerProvider) *TracingSysClient {
+	return &TracingSysClient{client: client, tracer: newTracer(tracerProvider)}
+}
+
+func (c *TracingSysClient) Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
+	spans := &connectionSpans{ctx: ctx, tracer: c.tracer, natsURL: natsURL}
+	var conn outbound.NatsSysConnection
+	err := spans.record("connect", func() error {
+		var err error
+		conn, err = c.client.Connect(ctx, natsURL, userCreds, tlsConfig)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tracingSysConnection{tracingStore: tracingStore{store: conn, spans: spans}, conn: conn}, nil
+}
+
+// TracingAccountClient records the operations on the connections of a NatsAccountClient as spans, see
+// TracingSysClient.
+type TracingAccountClient struct {
+	client outbound.NatsAccountClient
+	tracer trace.Tracer
+}
+
+// NewTracingAccountClient wraps client with spans created by tracerProvider, the global provider when nil.
+func NewTracingAccountClient(client outbound.NatsAccountClient, tracerProvider trace.TracerProvider) *TracingAccountClient {
+	return &TracingAccountClient{client: client, tracer: newTracer(tracerProvider)}
+}
+
+func (c *TracingAccountClient) Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (outbound.NatsAccountConnection, error) {
+	spans := &connectionSpans{ctx: ctx, tracer: c.tracer, natsURL: natsURL}
+	var conn outbound.NatsAccountConnection
+	err := spans.record("connect", func() error {
+		var err error
+		conn, err = c.client.Connect(ctx, natsURL, userCreds, tlsConfig)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tracingAccountConnection{conn: conn, spans: spans}, nil
+}
+
+func newTracer(tracerProvider trace.TracerProvider) trace.Tracer {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	return tracerProvider.Tracer(tracerName)
+}
+
+// connectionSpans creates the spans of the operations of one connection.
+type connectionSpans struct {
+	ctx     context.Context
+	tracer  trace.Tracer
+	natsURL string
+}
+
+// record runs operation in a span named after it, marking the span failed when operation returns an error.
+func (s *connectionSpans) record(operation string, run func() error, attributes ...attribute.KeyValue) error {
+	_, span := s.tracer.Start(s.ctx, "nats "+operation, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attributes, attribute.String("server.address", s.natsURL))...))
+	defer span.End()
+	err := run()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+type tracingStore struct {
+	store outbound.AccountJWTStore
+	spans *connectionSpans
+}
+
+func (s *tracingStore) LookupAccountJWT(accountID string) (string, error) {
+	var jwt string
+	err := s.spans.record("lookup account JWT", func() error {
+		var err error
+		jwt, err = s.store.LookupAccountJWT(accountID)
+		return err
+	}, attribute.String("nauth.account.id", accountID))
+	return jwt, err
+}
+
+func (s *tracingStore) UploadAccountJWT(jwt string) error {
+	return s.spans.record("upload account JWT", func() error {
+		return s.store.UploadAccountJWT(jwt)
+	})
+}
+
+func (s *tracingStore) DeleteAccountJWT(jwt string) error {
+	return s.spans.record("delete account JWT", func() error {
+		return s.store.DeleteAccountJWT(jwt)
+	})
+}
+
+type tracingSysConnection struct {
+	tracingStore
+	conn outbound.NatsSysConnection
+}
+
+func (c *tracingSysConnection) Disconnect() {
+	c.conn.Disconnect()
+}
+
+func (c *tracingSysConnection) EnsureConnected() error {
+	return c.spans.record("ensure connected", c.conn.EnsureConnected)
+}
+
+func (c *tracingSysConnection) VerifySystemAccountAccess() error {
+	return c.spans.record("verify system account access", c.conn.VerifySystemAccountAccess)
+}
+
+func (c *tracingSysConnection) PingServers() ([]domain.NatsServerInfo, error) {
+	var servers []domain.NatsServerInfo
+	err := c.spans.record("ping servers", func() error {
+		var err error
+		servers, err = c.conn.PingServers()
+		return err
+	})
+	return servers, err
+}
+
+func (c *tracingSysConnection) AccountUsage(accountID string) (*domain.NatsAccountUsage, error) {
+	var usage *domain.NatsAccountUsage
+	err := c.spans.record("account usage", func() error {
+		var err error
+		usage, err = c.conn.AccountUsage(accountID)
+		return err
+	}, attribute.String("nauth.account.id", accountID))
+	return usage, err
+}
+
+func (c *tracingSysConnection) MaxPayload() int64 {
+	return c.conn.MaxPayload()
+}
+
+func (c *tracingSysConnection) ResolverStore(resolver domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	store, err := c.conn.ResolverStore(resolver)
+	if err != nil {
+		return nil, err
+	}
+	if store == c.conn {
+		return c, nil
+	}
+	return &tracingStore{store: store, spans: c.spans}, nil
+}
+
+type tracingAccountConnection struct {
+	conn  outbound.NatsAccountConnection
+	spans *connectionSpans
+}
+
+func (c *tracingAccountConnection) Disconnect() {
+	c.conn.Disconnect()
+}
+
+func (c *tracingAccountConnection) EnsureConnected() error {
+	return c.spans.record("ensure connected", c.conn.EnsureConnected)
+}
+
+func (c *tracingAccountConnection) ListAccountStreams() ([]string, error) {
+	var streams []string
+	err := c.spans.record("list account streams", func() error {
+		var err error
+		streams, err = c.conn.ListAccountStreams()
+		return err
+	})
+	return streams, err
+}
+
+// Compile-time assertions that implementations satisfy the ports interfaces
+var _ outbound.NatsSysClient = (*TracingSysClient)(nil)
+var _ outbound.NatsAccountClient = (*TracingAccountClient)(nil)
@@ -0,0 +1,51 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingSysClient_ShouldRecordOperations_AsChildrenOfConnectContext(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, _ := newTestPool(t, server)
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	unitUnderTest := NewTracingSysClient(pool, tracerProvider)
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "reconcile")
+	conn, err := unitUnderTest.Connect(ctx, server.ClientURL(), testCreds("a"), nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.EnsureConnected())
+	conn.Disconnect()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "nats connect", spans[0].Name())
+	require.Equal(t, "nats ensure connected", spans[1].Name())
+	for _, span := range spans[:2] {
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Equal(t, codes.Unset, span.Status().Code)
+	}
+}
+
+func TestTracingSysClient_ShouldMarkSpanFailed_WhenOperationFails(t *testing.T) {
+	server := runNatsServer(t, natsServerConfig{})
+	pool, _ := newTestPool(t, server)
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	unitUnderTest := NewTracingSysClient(NewFaultInjectingSysClient(pool, FaultConfig{ErrorProbability: 1}), tracerProvider)
+
+	_, err := unitUnderTest.Connect(context.Background(), server.ClientURL(), testCreds("a"), nil)
+
+	require.ErrorIs(t, err, ErrInjectedFault)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "connect: injected fault", spans[0].Status().Description)
+}
@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	deliveryResultSuccess = "success"
+	deliveryResultError   = "error"
+	deliveryResultDropped = "dropped"
+)
+
+var deliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "nauth",
+	Subsystem: "notification",
+	Name:      "deliveries_total",
+	Help:      "Number of notifications delivered to each notification sink, by result.",
+}, []string{"sink", "result"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(deliveriesTotal)
+}
@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+)
+
+// queueSize is the number of notifications waiting for delivery before new notifications are dropped.
+const queueSize = 256
+
+// Notifier delivers notifications to its sinks in the background, so a slow or unavailable external system never
+// delays a reconcile. Notifications are delivered once, in the order they were notified.
+type Notifier struct {
+	sinks []Sink
+	queue chan domain.Notification
+	now   func() time.Time
+}
+
+func NewNotifier(sinks ...Sink) (*Notifier, error) {
+	n := &Notifier{
+		sinks: sinks,
+		queue: make(chan domain.Notification, queueSize),
+		now:   time.Now,
+	}
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *Notifier) validate() error {
+	if len(n.sinks) == 0 {
+		return fmt.Errorf("at least one notification sink is required")
+	}
+	return nil
+}
+
+// Notify queues notification for delivery. A notification is dropped when the queue is full.
+func (n *Notifier) Notify(ctx context.Context, notification domain.Notification) {
+	if notification.Time.IsZero() {
+		notification.Time = n.now().UTC()
+	}
+	select {
+	case n.queue <- notification:
+	default:
+		logf.FromContext(ctx).Info("Dropped notification, the notification queue is full",
+			"type", notification.Type, "name", notification.Resource.Name)
+		for _, sink := range n.sinks {
+			deliveriesTotal.WithLabelValues(sink.Name(), deliveryResultDropped).Inc()
+		}
+	}
+}
+
+// Start delivers queued notifications until ctx is done.
+func (n *Notifier) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("notifier")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-n.queue:
+			n.deliver(logf.IntoContext(ctx, log), notification)
+		}
+	}
+}
+
+func (n *Notifier) deliver(ctx context.Context, notification domain.Notification) {
+	for _, sink := range n.sinks {
+		if err := sink.Send(ctx, notification); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to deliver notification", "sink", sink.Name(),
+				"type", notification.Type, "namespace", notification.Resource.Namespace, "name", notification.Resource.Name)
+			deliveriesTotal.WithLabelValues(sink.Name(), deliveryResultError).Inc()
+			continue
+		}
+		deliveriesTotal.WithLabelValues(sink.Name(), deliveryResultSuccess).Inc()
+	}
+}
+
+// NeedLeaderElection returns false, notifications are delivered by every instance running reconciles.
+func (n *Notifier) NeedLeaderElection() bool {
+	return false
+}
+
+type discardNotifier struct{}
+
+func (discardNotifier) Notify(context.Context, domain.Notification) {}
+
+// Discard is a Notifier that sends nothing, used when no notification sink is configured.
+var Discard outbound.Notifier = discardNotifier{}
+
+var _ outbound.Notifier = (*Notifier)(nil)
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+func TestNewNotifier_ShouldFail_WhenSinksMissing(t *testing.T) {
+	_, err := NewNotifier()
+	assert.EqualError(t, err, "at least one notification sink is required")
+}
+
+func TestNotifier_ShouldDeliverToAllSinks_WhenOneFails(t *testing.T) {
+	failing := &sinkFake{name: "failing", err: errors.New("unavailable")}
+	working := &sinkFake{name: "working"}
+	notifier, err := NewNotifier(failing, working)
+	require.NoError(t, err)
+	notifier.now = func() time.Time { return time.Date(2026, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600)) }
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = notifier.Start(ctx) }()
+
+	notifier.Notify(ctx, domain.Notification{Type: domain.NotificationTypeAccountCreated})
+	notifier.Notify(ctx, domain.Notification{Type: domain.NotificationTypeAccountDeleted})
+
+	require.Eventually(t, func() bool { return len(working.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	notifications := working.received()
+	assert.Equal(t, domain.NotificationTypeAccountCreated, notifications[0].Type)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), notifications[0].Time)
+	assert.Equal(t, domain.NotificationTypeAccountDeleted, notifications[1].Type)
+	assert.Len(t, failing.received(), 2)
+}
+
+func TestNotifier_Notify_ShouldDrop_WhenQueueIsFull(t *testing.T) {
+	notifier, err := NewNotifier(&sinkFake{name: "fake"})
+	require.NoError(t, err)
+
+	for range queueSize + 1 {
+		notifier.Notify(context.Background(), domain.Notification{Type: domain.NotificationTypeDriftDetected})
+	}
+
+	assert.Len(t, notifier.queue, queueSize)
+}
+
+func TestDiscard_ShouldNotifyNothing(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Discard.Notify(context.Background(), domain.Notification{Type: domain.NotificationTypeAccountCreated})
+	})
+}
+
+type sinkFake struct {
+	name string
+	err  error
+
+	mu            sync.Mutex
+	notifications []domain.Notification
+}
+
+func (s *sinkFake) Name() string {
+	return s.name
+}
+
+func (s *sinkFake) Send(_ context.Context, notification domain.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifications = append(s.notifications, notification)
+	return s.err
+}
+
+func (s *sinkFake) received() []domain.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.Notification(nil), s.notifications...)
+}
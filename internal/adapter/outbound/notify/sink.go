@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+const (
+	// DefaultNatsSubject is the subject notifications are published to when none is configured.
+	DefaultNatsSubject = "nauth.notifications"
+
+	sendTimeout = 5 * time.Second
+)
+
+// Sink delivers notifications to an external system.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	Send(ctx context.Context, notification domain.Notification) error
+}
+
+// WebhookSink posts notifications as JSON to an HTTP endpoint, for example the HTTP event collector of a SIEM.
+type WebhookSink struct {
+	client        *http.Client
+	url           string
+	authorization string
+}
+
+// NewWebhookSink posts to url, sending authorization as the Authorization header unless it is empty.
+func NewWebhookSink(url string, authorization string) (*WebhookSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("URL of the webhook notification sink is required")
+	}
+	return &WebhookSink{
+		client:        &http.Client{Timeout: sendTimeout},
+		url:           url,
+		authorization: authorization,
+	}, nil
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, notification domain.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to serialize notification: %w", err)
+	}
+	return post(ctx, s.client, s.url, s.authorization, body)
+}
+
+// SlackSink posts notifications as messages to a Slack incoming webhook.
+type SlackSink struct {
+	client *http.Client
+	url    string
+}
+
+func NewSlackSink(url string) (*SlackSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("URL of the Slack notification sink is required")
+	}
+	return &SlackSink{
+		client: &http.Client{Timeout: sendTimeout},
+		url:    url,
+	}, nil
+}
+
+func (s *SlackSink) Name() string {
+	return "slack"
+}
+
+func (s *SlackSink) Send(ctx context.Context, notification domain.Notification) error {
+	body, err := json.Marshal(map[string]string{"text": slackText(notification)})
+	if err != nil {
+		return fmt.Errorf("failed to serialize Slack message: %w", err)
+	}
+	return post(ctx, s.client, s.url, "", body)
+}
+
+func slackText(notification domain.Notification) string {
+	icon := ":information_source:"
+	if notification.Warning {
+		icon = ":warning:"
+	}
+	resource := notification.Resource
+	return fmt.Sprintf("%s *%s* %s %s/%s: %s", icon, notification.Type, resource.Kind, resource.Namespace,
+		resource.Name, notification.Message)
+}
+
+func post(ctx context.Context, client *http.Client, url string, authorization string, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("failed to post notification: unexpected status %s", response.Status)
+	}
+	return nil
+}
+
+// NatsSink publishes notifications as JSON to a NATS subject.
+type NatsSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNatsSink connects to natsURL, authenticating with the credentials file credsFile unless it is empty.
+func NewNatsSink(natsURL string, credsFile string, subject string) (*NatsSink, error) {
+	if natsURL == "" {
+		return nil, fmt.Errorf("NATS URL of the notification sink is required")
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("NATS subject of the notification sink is required")
+	}
+
+	options := []nats.Option{
+		nats.Name("nauth-notifications"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(time.Second),
+	}
+	if credsFile != "" {
+		options = append(options, nats.UserCredentials(credsFile))
+	}
+	conn, err := nats.Connect(natsURL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS for the notification sink: %w", err)
+	}
+	return &NatsSink{conn: conn, subject: subject}, nil
+}
+
+func (s *NatsSink) Name() string {
+	return "nats"
+}
+
+func (s *NatsSink) Send(ctx context.Context, notification domain.Notification) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to serialize notification: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := s.conn.Publish(s.subject, data); err != nil {
+		return fmt.Errorf("failed to publish notification to subject %s: %w", s.subject, err)
+	}
+	if err := s.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush notification to subject %s: %w", s.subject, err)
+	}
+	return nil
+}
+
+// Close drains the NATS connection.
+func (s *NatsSink) Close() {
+	if err := s.conn.Drain(); err != nil {
+		s.conn.Close()
+	}
+}
+
+var _ Sink = (*WebhookSink)(nil)
+var _ Sink = (*SlackSink)(nil)
+var _ Sink = (*NatsSink)(nil)
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+func TestWebhookSink_Send_ShouldPostNotification(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(server.URL, "Splunk token")
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), testNotification()))
+
+	request := <-requests
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, "Splunk token", request.Header.Get("Authorization"))
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "DriftDetected",
+		"time": "2026-01-02T03:04:05Z",
+		"resource": {"kind": "Account", "namespace": "team", "name": "orders"},
+		"warning": true,
+		"message": "Claims of observed account AC1 changed in NATS"
+	}`, string(<-bodies))
+}
+
+func TestWebhookSink_Send_ShouldFail_WhenEndpointRejectsNotification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(server.URL, "")
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), testNotification())
+
+	assert.EqualError(t, err, "failed to post notification: unexpected status 401 Unauthorized")
+}
+
+func TestSlackSink_Send_ShouldPostMessage(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+
+	sink, err := NewSlackSink(server.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), testNotification()))
+
+	var message map[string]string
+	require.NoError(t, json.Unmarshal(<-bodies, &message))
+	assert.Equal(t, ":warning: *DriftDetected* Account team/orders: Claims of observed account AC1 changed in NATS",
+		message["text"])
+}
+
+func TestNewSinks_ShouldFail_WhenConfigurationMissing(t *testing.T) {
+	_, err := NewWebhookSink("", "")
+	assert.EqualError(t, err, "URL of the webhook notification sink is required")
+	_, err = NewSlackSink("")
+	assert.EqualError(t, err, "URL of the Slack notification sink is required")
+	_, err = NewNatsSink("", "", DefaultNatsSubject)
+	assert.EqualError(t, err, "NATS URL of the notification sink is required")
+	_, err = NewNatsSink("nats://localhost:4222", "", "")
+	assert.EqualError(t, err, "NATS subject of the notification sink is required")
+}
+
+func TestNatsSink_Send_ShouldPublishToSubject(t *testing.T) {
+	server, err := natsserver.NewServer(&natsserver.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true})
+	require.NoError(t, err)
+	go server.Start()
+	require.True(t, server.ReadyForConnections(10*time.Second), "nats-server did not become ready in time")
+	t.Cleanup(server.Shutdown)
+
+	nc, err := nats.Connect(server.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	subscription, err := nc.SubscribeSync(DefaultNatsSubject)
+	require.NoError(t, err)
+	require.NoError(t, nc.Flush())
+
+	sink, err := NewNatsSink(server.ClientURL(), "", DefaultNatsSubject)
+	require.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	require.NoError(t, sink.Send(context.Background(), testNotification()))
+
+	msg, err := subscription.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	var notification domain.Notification
+	require.NoError(t, json.Unmarshal(msg.Data, &notification))
+	assert.Equal(t, testNotification(), notification)
+}
+
+func testNotification() domain.Notification {
+	return domain.Notification{
+		Type:     domain.NotificationTypeDriftDetected,
+		Time:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Resource: domain.AuditResource{Kind: "Account", Namespace: "team", Name: "orders"},
+		Warning:  true,
+		Message:  "Claims of observed account AC1 changed in NATS",
+	}
+}
@@ -0,0 +1,169 @@
+package nsc
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nats-io/nkeys"
+)
+
+// bundleHeader starts every bundle. It is followed by the public curve key of the ephemeral sender key and a newline,
+// then by the sealed archive.
+const bundleHeader = "nauth-bundle-v1\n"
+
+// SealBundle writes the nsc store in dir to out as a gzipped tar archive encrypted to the public curve key recipient
+// (nkeys prefix X), so that only the holder of its seed can open it.
+func SealBundle(dir string, recipient string, out io.Writer) error {
+	if !nkeys.IsValidPublicCurveKey(recipient) {
+		return fmt.Errorf("invalid bundle recipient %q, expected a public curve key", recipient)
+	}
+	archive, err := archiveDir(dir)
+	if err != nil {
+		return err
+	}
+
+	sender, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		return fmt.Errorf("failed to create bundle sender key: %w", err)
+	}
+	defer sender.Wipe()
+	senderPublicKey, err := sender.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get bundle sender public key: %w", err)
+	}
+	sealed, err := sender.Seal(archive, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt bundle: %w", err)
+	}
+
+	if _, err := io.WriteString(out, bundleHeader+senderPublicKey+"\n"); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if _, err := out.Write(sealed); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// OpenBundle decrypts a bundle written by SealBundle with the seed of its recipient and extracts the nsc store into
+// dir.
+func OpenBundle(in io.Reader, recipientSeed []byte, dir string) error {
+	recipient, err := nkeys.FromCurveSeed(recipientSeed)
+	if err != nil {
+		return fmt.Errorf("invalid bundle key, expected a curve seed: %w", err)
+	}
+
+	reader := bufio.NewReader(in)
+	header, err := reader.ReadString('\n')
+	if err != nil || header != bundleHeader {
+		return fmt.Errorf("not a NAuth bundle")
+	}
+	senderPublicKey, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read bundle sender: %w", err)
+	}
+	sealed, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	archive, err := recipient.Open(sealed, strings.TrimSuffix(senderPublicKey, "\n"))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt bundle: %w", err)
+	}
+	return extractArchive(archive, dir)
+}
+
+func archiveDir(dir string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name: filepath.ToSlash(name),
+			Mode: int64(info.Mode().Perm()),
+			Size: int64(len(data)),
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive nsc store %s: %w", dir, err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to archive nsc store %s: %w", dir, err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress nsc store %s: %w", dir, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func extractArchive(archive []byte, dir string) error {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return fmt.Errorf("failed to decompress bundle: %w", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read bundle archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return fmt.Errorf("unexpected entry %q in bundle archive", header.Name)
+		}
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid path %q in bundle archive", header.Name)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from bundle archive: %w", header.Name, err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		mode := secretMode
+		if os.FileMode(header.Mode)&0o044 != 0 {
+			mode = publicMode
+		}
+		if err := os.WriteFile(path, data, mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package nsc
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealBundle_ShouldRoundTripStore(t *testing.T) {
+	storeDir := t.TempDir()
+	writer, err := NewStoreWriter(storeDir)
+	require.NoError(t, err)
+	signingKey, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteOperatorSigningKey("nats.cluster", signingKey))
+	recipient, recipientPublicKey, recipientSeed := createCurveKey(t)
+	defer recipient.Wipe()
+
+	bundle := &bytes.Buffer{}
+	require.NoError(t, SealBundle(storeDir, recipientPublicKey, bundle))
+	require.NotContains(t, bundle.String(), "nats.cluster")
+
+	restoreDir := t.TempDir()
+	require.NoError(t, OpenBundle(bundle, recipientSeed, restoreDir))
+
+	require.JSONEq(t, `{"name":"nats.cluster","type":"operator"}`, readFile(t, restoreDir, "stores", "nats.cluster", ".nsc"))
+	requireKeyFile(t, restoreDir, signingKey)
+	publicKey, err := signingKey.PublicKey()
+	require.NoError(t, err)
+	info, err := os.Stat(filepath.Join(restoreDir, "keys", "keys", "O", publicKey[1:3], publicKey+".nk"))
+	require.NoError(t, err)
+	require.Equal(t, secretMode, info.Mode().Perm())
+}
+
+func TestOpenBundle_ShouldFail_WhenKeyDoesNotMatch(t *testing.T) {
+	_, recipientPublicKey, _ := createCurveKey(t)
+	_, _, otherSeed := createCurveKey(t)
+	bundle := &bytes.Buffer{}
+	require.NoError(t, SealBundle(t.TempDir(), recipientPublicKey, bundle))
+
+	err := OpenBundle(bundle, otherSeed, t.TempDir())
+
+	require.ErrorContains(t, err, "failed to decrypt bundle")
+}
+
+func TestOpenBundle_ShouldFail_WhenInputIsNotABundle(t *testing.T) {
+	_, _, recipientSeed := createCurveKey(t)
+
+	err := OpenBundle(bytes.NewBufferString("plain text\n"), recipientSeed, t.TempDir())
+
+	require.ErrorContains(t, err, "not a NAuth bundle")
+}
+
+func TestSealBundle_ShouldFail_WhenRecipientIsNotACurveKey(t *testing.T) {
+	operatorKey, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+	publicKey, err := operatorKey.PublicKey()
+	require.NoError(t, err)
+
+	err = SealBundle(t.TempDir(), publicKey, &bytes.Buffer{})
+
+	require.ErrorContains(t, err, "expected a public curve key")
+}
+
+func TestExtractArchive_ShouldRejectPathsOutsideDir(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "../escape", Mode: 0o600, Size: 1}))
+	_, err := tarWriter.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	err = extractArchive(buf.Bytes(), t.TempDir())
+
+	require.ErrorContains(t, err, `invalid path "../escape"`)
+}
+
+func createCurveKey(t *testing.T) (nkeys.KeyPair, string, []byte) {
+	t.Helper()
+	key, err := nkeys.CreateCurveKeys()
+	require.NoError(t, err)
+	publicKey, err := key.PublicKey()
+	require.NoError(t, err)
+	seed, err := key.Seed()
+	require.NoError(t, err)
+	return key, publicKey, seed
+}
@@ -0,0 +1,140 @@
+package nsc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+)
+
+const (
+	storesDirName   = "stores"
+	keysDirName     = "keys"
+	accountsDirName = "accounts"
+	usersDirName    = "users"
+	credsDirName    = "creds"
+	storeInfoFile   = ".nsc"
+
+	dirMode    os.FileMode = 0o700
+	publicMode os.FileMode = 0o644
+	secretMode os.FileMode = 0o600
+)
+
+// StoreWriter writes an nsc compatible directory tree. The layout mirrors the nsc home directory, so dir can be used
+// as NSC_HOME with the store in dir/stores and the keystore (NKEYS_PATH) in dir/keys.
+type StoreWriter struct {
+	dir string
+}
+
+// NewStoreWriter creates a StoreWriter writing to dir, the directory is created if it does not exist.
+func NewStoreWriter(dir string) (*StoreWriter, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("nsc store directory is required")
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, fmt.Errorf("failed to create nsc store directory %s: %w", dir, err)
+	}
+	return &StoreWriter{dir: dir}, nil
+}
+
+// WriteOperatorSigningKey stores the operator signing key in the keystore and prepares the operator store. The
+// operator JWT is not known to NAuth and has to be added to the store separately.
+func (w *StoreWriter) WriteOperatorSigningKey(operator string, signingKey nkeys.KeyPair) error {
+	if err := validateName("operator", operator); err != nil {
+		return err
+	}
+	info, err := json.Marshal(map[string]string{"name": operator, "type": "operator"})
+	if err != nil {
+		return fmt.Errorf("failed to encode store info for operator %s: %w", operator, err)
+	}
+	if err := w.writeFile(info, publicMode, storesDirName, operator, storeInfoFile); err != nil {
+		return err
+	}
+	return w.writeKey(signingKey)
+}
+
+// WriteAccount stores the account JWT in the operator store and the account key pairs in the keystore.
+func (w *StoreWriter) WriteAccount(operator string, account string, accountJWT string, keyPairs ...nkeys.KeyPair) error {
+	if err := validateName("operator", operator); err != nil {
+		return err
+	}
+	if err := validateName("account", account); err != nil {
+		return err
+	}
+	if err := w.writeFile([]byte(accountJWT), publicMode, storesDirName, operator, accountsDirName, account, account+".jwt"); err != nil {
+		return err
+	}
+	for _, keyPair := range keyPairs {
+		if err := w.writeKey(keyPair); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteUser stores the user JWT in the operator store and the user seed and credentials in the keystore.
+func (w *StoreWriter) WriteUser(operator string, account string, user string, userCreds []byte) error {
+	if err := validateName("operator", operator); err != nil {
+		return err
+	}
+	if err := validateName("account", account); err != nil {
+		return err
+	}
+	if err := validateName("user", user); err != nil {
+		return err
+	}
+	userJWT, err := jwt.ParseDecoratedJWT(userCreds)
+	if err != nil {
+		return fmt.Errorf("failed to parse JWT from credentials of user %s: %w", user, err)
+	}
+	userKeyPair, err := jwt.ParseDecoratedUserNKey(userCreds)
+	if err != nil {
+		return fmt.Errorf("failed to parse seed from credentials of user %s: %w", user, err)
+	}
+	if err := w.writeFile([]byte(userJWT), publicMode, storesDirName, operator, accountsDirName, account, usersDirName, user+".jwt"); err != nil {
+		return err
+	}
+	if err := w.writeKey(userKeyPair); err != nil {
+		return err
+	}
+	return w.writeFile(userCreds, secretMode, keysDirName, credsDirName, operator, account, user+".creds")
+}
+
+// writeKey stores the seed of keyPair in the keystore using the nsc layout keys/<kind>/<shard>/<public key>.nk.
+func (w *StoreWriter) writeKey(keyPair nkeys.KeyPair) error {
+	publicKey, err := keyPair.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+	seed, err := keyPair.Seed()
+	if err != nil {
+		return fmt.Errorf("failed to get seed of %s: %w", publicKey, err)
+	}
+	return w.writeFile(seed, secretMode, keysDirName, keysDirName, publicKey[0:1], publicKey[1:3], publicKey+".nk")
+}
+
+func (w *StoreWriter) writeFile(data []byte, mode os.FileMode, elem ...string) error {
+	path := filepath.Join(append([]string{w.dir}, elem...)...)
+	if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func validateName(kind string, name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid %s name %q", kind, name)
+	}
+	return nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.NscStoreWriter = (*StoreWriter)(nil)
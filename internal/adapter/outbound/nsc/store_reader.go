@@ -0,0 +1,162 @@
+package nsc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/nkeys"
+)
+
+// StoreReader reads an nsc directory tree in the layout written by StoreWriter, which is also the layout of the nsc
+// home directory: the store in dir/stores and the keystore in dir/keys.
+type StoreReader struct {
+	dir string
+}
+
+// NewStoreReader creates a StoreReader reading from dir.
+func NewStoreReader(dir string) (*StoreReader, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("nsc store directory is required")
+	}
+	info, err := os.Stat(filepath.Join(dir, storesDirName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open nsc store in %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("nsc store %s is not a directory", filepath.Join(dir, storesDirName))
+	}
+	return &StoreReader{dir: dir}, nil
+}
+
+// ListOperators returns the names of the operators in the store.
+func (r *StoreReader) ListOperators() ([]string, error) {
+	return r.listDirs(storesDirName)
+}
+
+// ReadOperator returns the operator JWT together with the accounts and users of operator, sorted by name. Users
+// without a credentials file in the keystore are returned without credentials.
+func (r *StoreReader) ReadOperator(operator string) (*nauth.NscOperator, error) {
+	if err := validateName("operator", operator); err != nil {
+		return nil, err
+	}
+	operatorJWT, err := r.readFile(storesDirName, operator, operator+".jwt")
+	if err != nil {
+		return nil, err
+	}
+	accountNames, err := r.listDirs(storesDirName, operator, accountsDirName)
+	if err != nil {
+		return nil, err
+	}
+	result := &nauth.NscOperator{Name: operator, JWT: string(operatorJWT)}
+	for _, accountName := range accountNames {
+		accountJWT, err := r.readFile(storesDirName, operator, accountsDirName, accountName, accountName+".jwt")
+		if err != nil {
+			return nil, err
+		}
+		account := nauth.NscAccount{Name: accountName, JWT: string(accountJWT)}
+		userFiles, err := r.listFiles(".jwt", storesDirName, operator, accountsDirName, accountName, usersDirName)
+		if err != nil {
+			return nil, err
+		}
+		for _, userName := range userFiles {
+			userJWT, err := r.readFile(storesDirName, operator, accountsDirName, accountName, usersDirName, userName+".jwt")
+			if err != nil {
+				return nil, err
+			}
+			userCreds, err := r.readFile(keysDirName, credsDirName, operator, accountName, userName+".creds")
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			account.Users = append(account.Users, nauth.NscUser{Name: userName, JWT: string(userJWT), Creds: userCreds})
+		}
+		result.Accounts = append(result.Accounts, account)
+	}
+	return result, nil
+}
+
+// ReadKey returns the key pair of publicKey from the keystore, or false when the keystore holds no seed of publicKey.
+func (r *StoreReader) ReadKey(publicKey string) (nkeys.KeyPair, bool, error) {
+	if len(publicKey) < 3 || strings.ContainsAny(publicKey, `/\.`) {
+		return nil, false, fmt.Errorf("invalid public key %q", publicKey)
+	}
+	seed, err := r.readFile(keysDirName, keysDirName, publicKey[0:1], publicKey[1:3], publicKey+".nk")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	keyPair, err := nkeys.FromSeed([]byte(strings.TrimSpace(string(seed))))
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid seed of %s: %w", publicKey, err)
+	}
+	keyPublicKey, err := keyPair.PublicKey()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get public key of seed of %s: %w", publicKey, err)
+	}
+	if keyPublicKey != publicKey {
+		return nil, false, fmt.Errorf("seed stored for %s belongs to %s", publicKey, keyPublicKey)
+	}
+	return keyPair, true, nil
+}
+
+// listDirs returns the sorted names of the directories in the directory elem, or none when it does not exist.
+func (r *StoreReader) listDirs(elem ...string) ([]string, error) {
+	entries, err := r.readDir(elem...)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			result = append(result, entry.Name())
+		}
+	}
+	return result, nil
+}
+
+// listFiles returns the sorted names, without suffix, of the files ending with suffix in the directory elem, or none
+// when it does not exist.
+func (r *StoreReader) listFiles(suffix string, elem ...string) ([]string, error) {
+	entries, err := r.readDir(elem...)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), suffix) {
+			result = append(result, strings.TrimSuffix(entry.Name(), suffix))
+		}
+	}
+	return result, nil
+}
+
+func (r *StoreReader) readDir(elem ...string) ([]os.DirEntry, error) {
+	path := filepath.Join(append([]string{r.dir}, elem...)...)
+	entries, err := os.ReadDir(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func (r *StoreReader) readFile(elem ...string) ([]byte, error) {
+	path := filepath.Join(append([]string{r.dir}, elem...)...)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// Compile-time assertion that implementation satisfies the ports interface
+var _ outbound.NscStoreReader = (*StoreReader)(nil)
@@ -0,0 +1,95 @@
+package nsc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStoreReader_ShouldFail_WhenStoreIsMissing(t *testing.T) {
+	_, err := NewStoreReader(t.TempDir())
+
+	require.ErrorContains(t, err, "failed to open nsc store")
+}
+
+func TestStoreReader_ShouldReadStoreWrittenByStoreWriter(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewStoreWriter(dir)
+	require.NoError(t, err)
+	operatorKey, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteOperatorSigningKey("nats.cluster", operatorKey))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stores", "nats.cluster", "nats.cluster.jwt"), []byte("operator-jwt"), 0o644))
+	accountKey, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteAccount("nats.cluster", "team.orders", "account-jwt", accountKey))
+	require.NoError(t, writer.WriteAccount("nats.cluster", "team.billing", "billing-jwt"))
+	userKey, err := nkeys.CreateUser()
+	require.NoError(t, err)
+	userPublicKey, err := userKey.PublicKey()
+	require.NoError(t, err)
+	userJWT, err := jwt.NewUserClaims(userPublicKey).Encode(accountKey)
+	require.NoError(t, err)
+	userSeed, err := userKey.Seed()
+	require.NoError(t, err)
+	userCreds, err := jwt.FormatUserConfig(userJWT, userSeed)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteUser("nats.cluster", "team.orders", "team.app", userCreds))
+
+	reader, err := NewStoreReader(dir)
+	require.NoError(t, err)
+
+	operators, err := reader.ListOperators()
+	require.NoError(t, err)
+	require.Equal(t, []string{"nats.cluster"}, operators)
+	operator, err := reader.ReadOperator("nats.cluster")
+	require.NoError(t, err)
+	require.Equal(t, &nauth.NscOperator{
+		Name: "nats.cluster",
+		JWT:  "operator-jwt",
+		Accounts: []nauth.NscAccount{
+			{Name: "team.billing", JWT: "billing-jwt"},
+			{Name: "team.orders", JWT: "account-jwt", Users: []nauth.NscUser{
+				{Name: "team.app", JWT: userJWT, Creds: userCreds},
+			}},
+		},
+	}, operator)
+	accountPublicKey, err := accountKey.PublicKey()
+	require.NoError(t, err)
+	keyPair, found, err := reader.ReadKey(accountPublicKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, accountKey, keyPair)
+}
+
+func TestStoreReader_ReadKey_ShouldReturnNotFound_WhenSeedIsMissing(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "stores"), 0o700))
+	reader, err := NewStoreReader(dir)
+	require.NoError(t, err)
+	accountKey, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+	accountPublicKey, err := accountKey.PublicKey()
+	require.NoError(t, err)
+
+	_, found, err := reader.ReadKey(accountPublicKey)
+
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestStoreReader_ReadOperator_ShouldFail_WhenNameIsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "stores"), 0o700))
+	reader, err := NewStoreReader(dir)
+	require.NoError(t, err)
+
+	_, err = reader.ReadOperator("..")
+
+	require.ErrorContains(t, err, "invalid operator name")
+}
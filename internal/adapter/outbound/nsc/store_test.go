@@ -0,0 +1,112 @@
+package nsc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStoreWriter_ShouldFail_WhenDirIsEmpty(t *testing.T) {
+	_, err := NewStoreWriter(" ")
+
+	require.ErrorContains(t, err, "nsc store directory is required")
+}
+
+func TestStoreWriter_WriteOperatorSigningKey(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewStoreWriter(dir)
+	require.NoError(t, err)
+	signingKey, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteOperatorSigningKey("nats.cluster", signingKey))
+
+	require.JSONEq(t, `{"name":"nats.cluster","type":"operator"}`, readFile(t, dir, "stores", "nats.cluster", ".nsc"))
+	requireKeyFile(t, dir, signingKey)
+}
+
+func TestStoreWriter_WriteAccount(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewStoreWriter(dir)
+	require.NoError(t, err)
+	rootKey, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+	signKey, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteAccount("nats.cluster", "team.orders", "account-jwt", rootKey, signKey))
+
+	require.Equal(t, "account-jwt", readFile(t, dir, "stores", "nats.cluster", "accounts", "team.orders", "team.orders.jwt"))
+	requireKeyFile(t, dir, rootKey)
+	requireKeyFile(t, dir, signKey)
+}
+
+func TestStoreWriter_WriteUser(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewStoreWriter(dir)
+	require.NoError(t, err)
+	accountKey, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+	userKey, err := nkeys.CreateUser()
+	require.NoError(t, err)
+	userPublicKey, err := userKey.PublicKey()
+	require.NoError(t, err)
+	userJWT, err := jwt.NewUserClaims(userPublicKey).Encode(accountKey)
+	require.NoError(t, err)
+	userSeed, err := userKey.Seed()
+	require.NoError(t, err)
+	userCreds, err := jwt.FormatUserConfig(userJWT, userSeed)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteUser("nats.cluster", "team.orders", "team.app", userCreds))
+
+	require.Equal(t, userJWT, readFile(t, dir, "stores", "nats.cluster", "accounts", "team.orders", "users", "team.app.jwt"))
+	require.Equal(t, string(userCreds), readFile(t, dir, "keys", "creds", "nats.cluster", "team.orders", "team.app.creds"))
+	requireKeyFile(t, dir, userKey)
+}
+
+func TestStoreWriter_ShouldFail_WhenNameIsInvalid(t *testing.T) {
+	writer, err := NewStoreWriter(t.TempDir())
+	require.NoError(t, err)
+
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`} {
+		t.Run(name, func(t *testing.T) {
+			err := writer.WriteAccount("operator", name, "account-jwt")
+
+			require.ErrorContains(t, err, "invalid account name")
+		})
+	}
+}
+
+func TestStoreWriter_WriteUser_ShouldFail_WhenCredsAreInvalid(t *testing.T) {
+	writer, err := NewStoreWriter(t.TempDir())
+	require.NoError(t, err)
+
+	err = writer.WriteUser("operator", "account", "user", []byte("invalid"))
+
+	require.ErrorContains(t, err, "failed to parse")
+}
+
+func readFile(t *testing.T, elem ...string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(elem...))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func requireKeyFile(t *testing.T, dir string, keyPair nkeys.KeyPair) {
+	t.Helper()
+	publicKey, err := keyPair.PublicKey()
+	require.NoError(t, err)
+	seed, err := keyPair.Seed()
+	require.NoError(t, err)
+	path := filepath.Join(dir, "keys", "keys", publicKey[0:1], publicKey[1:3], publicKey+".nk")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	require.Equal(t, string(seed), readFile(t, path))
+}
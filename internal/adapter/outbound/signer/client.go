@@ -0,0 +1,143 @@
+// Package signer signs JWTs through an external signing service, such as a sidecar or KMS plugin holding the operator
+// signing key, so that the seed never has to be stored in Kubernetes.
+//
+// The service is called with POST <url>/sign and a JSON body {"publicKey": "<public key>", "data": "<base64>"}, and
+// must respond with 200 and {"signature": "<base64>"}, the ed25519 signature of data made with the seed of publicKey.
+package signer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/nkeys"
+)
+
+const signTimeout = 10 * time.Second
+
+// ErrSeedUnavailable is returned for operations that need the seed of a key held by a signing service.
+var ErrSeedUnavailable = errors.New("the seed of a key held by an external signing service is not available")
+
+type signRequest struct {
+	PublicKey string `json:"publicKey"`
+	Data      string `json:"data"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type Client struct {
+	httpClient *http.Client
+}
+
+var _ outbound.OperatorSignerClient = (*Client)(nil)
+
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: signTimeout},
+	}
+}
+
+func (c *Client) Connect(url string, publicKey string) (domain.NatsOperatorSigningKey, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("signing service URL %q must use the http or https scheme", url)
+	}
+	if !nkeys.IsValidPublicOperatorKey(publicKey) {
+		return nil, fmt.Errorf("invalid operator public key %q", publicKey)
+	}
+	verifier, err := nkeys.FromPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator public key: %w", err)
+	}
+	return &remoteKeyPair{
+		httpClient: c.httpClient,
+		signURL:    strings.TrimSuffix(url, "/") + "/sign",
+		publicKey:  publicKey,
+		verifier:   verifier,
+	}, nil
+}
+
+// remoteKeyPair is an nkeys.KeyPair whose signatures are made by a signing service. Signatures are verified against
+// the public key before they are returned.
+type remoteKeyPair struct {
+	httpClient *http.Client
+	signURL    string
+	publicKey  string
+	verifier   nkeys.KeyPair
+}
+
+var _ nkeys.KeyPair = (*remoteKeyPair)(nil)
+
+func (k *remoteKeyPair) PublicKey() (string, error) {
+	return k.publicKey, nil
+}
+
+func (k *remoteKeyPair) Sign(input []byte) ([]byte, error) {
+	body, err := json.Marshal(signRequest{
+		PublicKey: k.publicKey,
+		Data:      base64.StdEncoding.EncodeToString(input),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sign request: %w", err)
+	}
+	response, err := k.httpClient.Post(k.signURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call signing service: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing service response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signing service responded with %s: %s", response.Status, strings.TrimSpace(string(responseBody)))
+	}
+	result := signResponse{}
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signing service response: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature from signing service: %w", err)
+	}
+	if err := k.verifier.Verify(input, signature); err != nil {
+		return nil, fmt.Errorf("signing service did not sign with %s: %w", k.publicKey, err)
+	}
+	return signature, nil
+}
+
+func (k *remoteKeyPair) Verify(input []byte, sig []byte) error {
+	return k.verifier.Verify(input, sig)
+}
+
+func (k *remoteKeyPair) Seed() ([]byte, error) {
+	return nil, ErrSeedUnavailable
+}
+
+func (k *remoteKeyPair) PrivateKey() ([]byte, error) {
+	return nil, ErrSeedUnavailable
+}
+
+func (k *remoteKeyPair) Wipe() {}
+
+func (k *remoteKeyPair) Seal(_ []byte, _ string) ([]byte, error) {
+	return nil, nkeys.ErrInvalidNKeyOperation
+}
+
+func (k *remoteKeyPair) SealWithRand(_ []byte, _ string, _ io.Reader) ([]byte, error) {
+	return nil, nkeys.ErrInvalidNKeyOperation
+}
+
+func (k *remoteKeyPair) Open(_ []byte, _ string) ([]byte, error) {
+	return nil, nkeys.ErrInvalidNKeyOperation
+}
@@ -0,0 +1,121 @@
+package signer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSigningService(t *testing.T, key nkeys.KeyPair) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := signRequest{}
+		if r.Method != http.MethodPost || r.URL.Path != "/sign" || json.NewDecoder(r.Body).Decode(&request) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		data, err := base64.StdEncoding.DecodeString(request.Data)
+		if err != nil {
+			http.Error(w, "bad data", http.StatusBadRequest)
+			return
+		}
+		signature, err := key.Sign(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(signResponse{Signature: base64.StdEncoding.EncodeToString(signature)})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Connect_ShouldSignAccountJWT(t *testing.T) {
+	opSign := testutil.CreateNatsTestOperatorKey()
+	publicKey, err := opSign.Key.PublicKey()
+	require.NoError(t, err)
+	server := newSigningService(t, opSign.Key)
+
+	signingKey, err := NewClient().Connect(server.URL+"/", publicKey)
+	require.NoError(t, err)
+
+	claims := jwt.NewAccountClaims(testutil.NatsTestAccountA.AccountID())
+	accountJWT, err := claims.Encode(signingKey)
+	require.NoError(t, err)
+
+	decoded, err := jwt.DecodeAccountClaims(accountJWT)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, decoded.Issuer)
+}
+
+func TestClient_Connect_ShouldFail_WhenServiceSignsWithOtherKey(t *testing.T) {
+	opSign := testutil.CreateNatsTestOperatorKey()
+	publicKey, err := opSign.Key.PublicKey()
+	require.NoError(t, err)
+	server := newSigningService(t, testutil.CreateNatsTestOperatorKey().Key)
+
+	signingKey, err := NewClient().Connect(server.URL, publicKey)
+	require.NoError(t, err)
+
+	_, err = signingKey.Sign([]byte("data"))
+	assert.ErrorContains(t, err, "signing service did not sign with "+publicKey)
+}
+
+func TestClient_Connect_ShouldFail_WhenServiceFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "key unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	publicKey, err := testutil.CreateNatsTestOperatorKey().Key.PublicKey()
+	require.NoError(t, err)
+
+	signingKey, err := NewClient().Connect(server.URL, publicKey)
+	require.NoError(t, err)
+
+	_, err = signingKey.Sign([]byte("data"))
+	assert.ErrorContains(t, err, "signing service responded with 503 Service Unavailable: key unavailable")
+}
+
+func TestClient_Connect_ShouldFail(t *testing.T) {
+	operatorPublicKey, err := testutil.CreateNatsTestOperatorKey().Key.PublicKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName  string
+		url       string
+		publicKey string
+		expected  string
+	}{
+		{testName: "unsupported_scheme", url: "unix:///var/run/signer.sock", publicKey: operatorPublicKey, expected: "must use the http or https scheme"},
+		{testName: "account_public_key", url: "http://localhost:8090", publicKey: testutil.NatsTestAccountA.AccountID(), expected: "invalid operator public key"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			_, err := NewClient().Connect(tc.url, tc.publicKey)
+
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestClient_Connect_ShouldNotExposeSeed(t *testing.T) {
+	publicKey, err := testutil.CreateNatsTestOperatorKey().Key.PublicKey()
+	require.NoError(t, err)
+
+	signingKey, err := NewClient().Connect("http://localhost:8090", publicKey)
+	require.NoError(t, err)
+
+	_, err = signingKey.Seed()
+	assert.ErrorIs(t, err, ErrSeedUnavailable)
+	_, err = signingKey.PrivateKey()
+	assert.ErrorIs(t, err, ErrSeedUnavailable)
+}
@@ -12,14 +12,27 @@ import (
 	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nkeys"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	// defaultNatsMaxPayload is the max_payload of NATS servers that do not configure it.
+	defaultNatsMaxPayload = 1024 * 1024
+	// accountJWTSizeWarningRatio is the share of the max_payload of the NATS servers above which the size of an
+	// account JWT is reported.
+	accountJWTSizeWarningRatio = 0.75
+)
+
 type AccountManager struct {
 	natsSysClient   outbound.NatsSysClient
 	natsAccClient   outbound.NatsAccountClient
 	accountIDReader outbound.AccountIDReader
 	secretManager   secretManager
+	auditRecorder   outbound.AuditRecorder
+	claimsMutator   outbound.AccountClaimsMutator
+	config          *Config
 }
 
 func NewAccountManager(
@@ -27,12 +40,18 @@ func NewAccountManager(
 	natsAccClient outbound.NatsAccountClient,
 	accountIDReader outbound.AccountIDReader,
 	secretClient outbound.SecretClient,
+	auditRecorder outbound.AuditRecorder,
+	claimsMutator outbound.AccountClaimsMutator,
+	config *Config,
 ) (*AccountManager, error) {
-	sm, err := newSecretManagerImpl(secretClient)
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	sm, err := newSecretManagerImpl(secretClient, config.CryptoPolicy, config.AccountSecretsNamespace, config.AccountSecretNameTemplate)
 	if err != nil {
 		return nil, err
 	}
-	return newAccountManager(natsSysClient, natsAccClient, accountIDReader, sm)
+	return newAccountManager(natsSysClient, natsAccClient, accountIDReader, sm, auditRecorder, claimsMutator, config)
 }
 
 func newAccountManager(
@@ -40,12 +59,18 @@ func newAccountManager(
 	natsAccClient outbound.NatsAccountClient,
 	accountIDReader outbound.AccountIDReader,
 	secretManager secretManager,
+	auditRecorder outbound.AuditRecorder,
+	claimsMutator outbound.AccountClaimsMutator,
+	config *Config,
 ) (*AccountManager, error) {
 	m := &AccountManager{
 		natsSysClient:   natsSysClient,
 		natsAccClient:   natsAccClient,
 		accountIDReader: accountIDReader,
 		secretManager:   secretManager,
+		auditRecorder:   auditRecorder,
+		claimsMutator:   claimsMutator,
+		config:          config,
 	}
 	if err := m.validate(); err != nil {
 		return nil, err
@@ -66,6 +91,12 @@ func (a *AccountManager) validate() error {
 	if a.natsAccClient == nil {
 		return errors.New("natsAccClient is required")
 	}
+	if a.auditRecorder == nil {
+		return errors.New("auditRecorder is required")
+	}
+	if a.config == nil {
+		return errors.New("config is required")
+	}
 
 	return nil
 }
@@ -76,18 +107,21 @@ func (a *AccountManager) CreateOrUpdate(ctx context.Context, request nauth.Accou
 	}
 
 	cluster := request.ClusterTarget
+	if err := checkJetStreamCapacity(request, cluster.AccountDefaults, cluster.JetStreamCapacity); err != nil {
+		return nil, err
+	}
 	fixedAccountID := string(request.AccountID)
 	accountSecrets, found, err := a.secretManager.GetSecrets(ctx, request.AccountRef, fixedAccountID)
 	if fixedAccountID != "" {
 		// Update
-		if !found {
+		if !found && request.KeySecrets == nil {
 			return nil, fmt.Errorf("account secrets not found for account %s", fixedAccountID)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to get account secrets for account %s: %w", fixedAccountID, err)
 		}
-		if fixedAccountID == cluster.SystemAdminCreds.AccountID {
-			return nil, fmt.Errorf("reconciling system account is not supported")
+		if cluster.IsSystemAccount(request.AccountID) {
+			return nil, domain.ErrSystemAccountConflict.WithCause(fmt.Errorf("reconciling system account is not supported"))
 		}
 	} else if found && err != nil {
 		// Create
@@ -104,17 +138,28 @@ func (a *AccountManager) CreateOrUpdate(ctx context.Context, request nauth.Accou
 			return nil, fmt.Errorf("failed to extract account root public key from existing secret: %w", err)
 		}
 		accountSigningKeyPair = accountSecrets.Sign
+		if fixedAccountID == "" {
+			// A previous creation applied the account secrets, but may have failed to delete the pending secret
+			if err = a.secretManager.DeletePendingSecrets(ctx, request.AccountRef); err != nil {
+				return nil, fmt.Errorf("failed to delete pending account secret: %w", err)
+			}
+		}
 	} else {
-		accountKeyPair, err = nkeys.CreateAccount()
+		var newSecrets *Secrets
+		if request.KeySecrets != nil {
+			newSecrets, err = a.getProvidedSecrets(ctx, request)
+		} else {
+			newSecrets, err = a.getOrCreatePendingSecrets(ctx, request.AccountRef)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to create account root key pair: %w", err)
+			return nil, err
 		}
-		accountPublicKey, _ = accountKeyPair.PublicKey() // Safe due to new nkey
-
-		accountSigningKeyPair, err = nkeys.CreateAccount()
+		accountKeyPair = newSecrets.Root
+		accountPublicKey, err = accountKeyPair.PublicKey()
 		if err != nil {
-			return nil, fmt.Errorf("failed to create account signing key pair: %w", err)
+			return nil, fmt.Errorf("failed to extract account root public key from new secrets: %w", err)
 		}
+		accountSigningKeyPair = newSecrets.Sign
 
 		err = a.secretManager.ApplyRootSecret(ctx, request.AccountRef, accountKeyPair)
 		if err != nil {
@@ -125,76 +170,384 @@ func (a *AccountManager) CreateOrUpdate(ctx context.Context, request nauth.Accou
 		if err != nil {
 			return nil, fmt.Errorf("failed to apply account signing secret: %w", err)
 		}
+
+		if err = a.secretManager.DeletePendingSecrets(ctx, request.AccountRef); err != nil {
+			return nil, fmt.Errorf("failed to delete pending account secret: %w", err)
+		}
 	}
 
 	accountSigningPublicKey, err := accountSigningKeyPair.PublicKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract account signing public key: %w", err)
 	}
+	if !found {
+		action := domain.AuditActionAccountKeysCreated
+		if request.KeySecrets != nil {
+			action = domain.AuditActionAccountKeysProvided
+		}
+		a.auditRecorder.Record(ctx, domain.AuditEvent{
+			Action:   action,
+			Resource: auditResource(auditKindAccount, request.AccountRef),
+			Subject:  accountPublicKey,
+			Keys:     []string{accountPublicKey, accountSigningPublicKey},
+		})
+	}
 
 	operatorSigningPublicKey, err := cluster.OperatorSigningKey.PublicKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get operator signing public key: %w", err)
 	}
 
+	var liveClaims *jwt.AccountClaims
+	if len(request.ObservedClaims) > 0 && found {
+		if liveClaims, err = a.lookupLiveAccountClaims(ctx, cluster, accountPublicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	natsClaims, signedJwt, adoptions, mutations, err := a.buildAccountJWT(ctx, request, accountPublicKey, accountSigningPublicKey, liveClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	claimsHash, err := hashSignedAccountJWTClaims(signedJwt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash account claims: %w", err)
+	}
+
+	log := logf.FromContext(ctx)
+	prevClaimsHash := request.ClaimsHash
+	jwtPushed := false
+	var liveJWT *nauth.JWTMetadata
+	var pendingChanges *nauth.AccountPendingChanges
+	var jwtSize *nauth.JWTSize
+	push := request.ForcePush || prevClaimsHash == "" || prevClaimsHash != claimsHash
+	if !push && found {
+		push = a.accountJWTMissing(ctx, cluster, accountPublicKey)
+	}
+	if push {
+		sysConn, err := a.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
+		if err != nil {
+			return nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("failed to connect to NATS cluster: %w", err))
+		}
+		defer sysConn.Disconnect()
+		jwtStore, err := sysConn.ResolverStore(cluster.Resolver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open account JWT store: %w", err)
+		}
+		if jwtSize, err = checkAccountJWTSize(signedJwt, sysConn.MaxPayload()); err != nil {
+			return nil, err
+		}
+
+		upToDate := false
+		previousJWT := ""
+		if found && !request.ForcePush {
+			previousJWT, upToDate, err = accountJWTUpToDate(jwtStore, accountPublicKey, claimsHash)
+			if err != nil {
+				log.Info("Failed to compare Account JWT with NATS, uploading it", "accountID", accountPublicKey, "error", err)
+			}
+		}
+		until, frozen := cluster.MaintenanceWindows.ActiveUntil(time.Now())
+		if upToDate {
+			log.Info("Account JWT in NATS is up to date, skipping upload", "accountID", accountPublicKey, "claimsHash", claimsHash)
+			if liveJWT, err = decodeAccountJWTMetadata(previousJWT); err != nil {
+				return nil, fmt.Errorf("failed to decode account jwt in NATS: %w", err)
+			}
+		} else if frozen {
+			log.Info("Maintenance window active, deferring upload of Account JWT",
+				"accountID", accountPublicKey, "claimsHash", claimsHash, "until", until)
+			pendingChanges = &nauth.AccountPendingChanges{
+				ClaimsHash: claimsHash,
+				Fields:     accountClaimsChanges(previousJWT, natsClaims),
+				Until:      until,
+			}
+		} else {
+			err = jwtStore.UploadAccountJWT(signedJwt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload account jwt: %w", err)
+			}
+			log.Info("Uploaded Account JWT to NATS",
+				"accountID", accountPublicKey, "prevClaimsHash", prevClaimsHash, "claimsHash", claimsHash, "forced", request.ForcePush)
+			jwtPushed = true
+			if liveJWT, err = decodeAccountJWTMetadata(signedJwt); err != nil {
+				return nil, fmt.Errorf("failed to decode account jwt: %w", err)
+			}
+			if len(mutations) > 0 {
+				a.auditRecorder.Record(ctx, domain.AuditEvent{
+					Action:     domain.AuditActionAccountClaimsMutated,
+					Resource:   auditResource(auditKindAccount, request.AccountRef),
+					Subject:    accountPublicKey,
+					ClaimsHash: claimsHash,
+					Changes:    mutations,
+				})
+			}
+			a.auditRecorder.Record(ctx, domain.AuditEvent{
+				Action:     domain.AuditActionAccountJWTPushed,
+				Resource:   auditResource(auditKindAccount, request.AccountRef),
+				Subject:    accountPublicKey,
+				Issuer:     operatorSigningPublicKey,
+				ClaimsHash: claimsHash,
+				Changes:    accountClaimsChanges(previousJWT, natsClaims),
+			})
+		}
+	}
+
+	nauthClaims, err := convertNatsAccountClaims(natsClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert NATS account claims: %w", err)
+	}
+	return &nauth.AccountResult{
+		AccountID:         accountPublicKey,
+		AccountSignedBy:   operatorSigningPublicKey,
+		Claims:            &nauthClaims,
+		ClaimsHash:        claimsHash,
+		JWT:               liveJWT,
+		Adoptions:         adoptions,
+		SigningKeyCreated: !found,
+		JWTPushed:         jwtPushed,
+		PendingChanges:    pendingChanges,
+		JWTSize:           jwtSize,
+	}, nil
+}
+
+// buildAccountJWT builds the NATS claims of request, keeping its observed claims from liveClaims and adopting its export
+// and import groups, passes them to the claims mutation hook and signs them with the operator signing key of the
+// cluster. It also returns the claim fields changed by the hook.
+func (a *AccountManager) buildAccountJWT(ctx context.Context, request nauth.AccountRequest, accountPublicKey string, accountSigningPublicKey string, liveClaims *jwt.AccountClaims) (_ *jwt.AccountClaims, _ string, _ *nauth.AccountAdoptions, _ []string, err error) {
+	_, span := tracer.Start(ctx, "build account claims", trace.WithAttributes(attribute.String("nauth.account.id", accountPublicKey)))
+	defer func() { endSpan(span, err) }()
+
+	cluster := request.ClusterTarget
 	claimsBuilder := newAccountClaimsBuilder(accountPublicKey, request.JetStreamEnabled).
 		displayName(getDisplayName(request)).
+		info(request.Description, request.InfoURL, request.Tags).
 		signingKey(accountSigningPublicKey).
+		limitDefaults(cluster.AccountDefaults).
 		accountLimits(request.AccountLimits).
 		jetStreamLimits(request.JetStreamLimits).
-		natsLimits(request.NatsLimits)
+		jetStreamTieredLimits(request.JetStreamTieredLimits).
+		natsLimits(request.NatsLimits).
+		mappings(request.Mappings).
+		authorization(request.DisallowBearer, request.DefaultPermissions).
+		trace(request.Trace).
+		clusterTraffic(request.ClusterTraffic).
+		observed(request.ObservedClaims, liveClaims)
 
 	adoptions := nauth.NewAccountAdoptions()
 	if err = adoptExportGroups(request.ExportGroups, claimsBuilder, adoptions); err != nil {
-		return nil, fmt.Errorf("failed to adopt export groups: %w", err)
+		return nil, "", nil, nil, fmt.Errorf("failed to adopt export groups: %w", err)
 	}
 	if err = adoptImportGroups(request.ImportGroups, claimsBuilder, adoptions); err != nil {
-		return nil, fmt.Errorf("failed to adopt import groups: %w", err)
+		return nil, "", nil, nil, fmt.Errorf("failed to adopt import groups: %w", err)
 	}
 
 	natsClaims, err := claimsBuilder.build()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build NATS account claims: %w", err)
+		return nil, "", nil, nil, fmt.Errorf("failed to build NATS account claims: %w", err)
+	}
+	natsClaims, mutations, err := a.mutateAccountClaims(ctx, request.AccountRef, natsClaims)
+	if err != nil {
+		return nil, "", nil, nil, err
 	}
 
 	signedJwt, err := signAccountJWT(natsClaims, cluster.OperatorSigningKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign account jwt: %w", err)
+		return nil, "", nil, nil, fmt.Errorf("failed to sign account jwt: %w", err)
 	}
+	return natsClaims, signedJwt, adoptions, mutations, nil
+}
 
-	claimsHash, err := hashSignedAccountJWTClaims(signedJwt)
+// lookupLiveAccountClaims returns the claims of the account JWT of accountID in NATS, nil when NATS has no JWT of the
+// account.
+func (a *AccountManager) lookupLiveAccountClaims(ctx context.Context, cluster nauth.ClusterTarget, accountID string) (*jwt.AccountClaims, error) {
+	sysConn, err := a.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash account claims: %w", err)
+		return nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("failed to connect to NATS cluster: %w", err))
 	}
+	defer sysConn.Disconnect()
+	jwtStore, err := sysConn.ResolverStore(cluster.Resolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open account JWT store: %w", err)
+	}
+	accountJWT, err := jwtStore.LookupAccountJWT(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup account jwt for account %s: %w", accountID, err)
+	}
+	if len(accountJWT) == 0 {
+		return nil, nil
+	}
+	if err = a.config.CryptoPolicy.verifyJWTAlgorithm(accountJWT); err != nil {
+		return nil, fmt.Errorf("account jwt for account %s rejected: %w", accountID, err)
+	}
+	claims, err := jwt.DecodeAccountClaims(accountJWT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode account jwt for account %s: %w", accountID, err)
+	}
+	return claims, nil
+}
 
-	log := logf.FromContext(ctx)
-	prevClaimsHash := request.ClaimsHash
-	if prevClaimsHash == "" || prevClaimsHash != claimsHash {
-		sysConn, err := a.natsSysClient.Connect(cluster.NatsURL, cluster.SystemAdminCreds)
-		if err != nil {
-			return nil, fmt.Errorf("failed to connect to NATS cluster: %w", err)
+// checkJetStreamCapacity returns domain.ErrClusterCapacityExceeded when the JetStream storage limits of request, merged
+// with the cluster defaults, exceed capacity, since NATS does not honor them. Tiered limits are summed over the tiers.
+// Nothing is checked while the capacity is not known.
+func checkJetStreamCapacity(request nauth.AccountRequest, defaults *nauth.AccountLimitDefaults, capacity *nauth.JetStreamCapacity) error {
+	if capacity == nil || (request.JetStreamEnabled != nil && !*request.JetStreamEnabled) {
+		return nil
+	}
+	var memoryStorage, diskStorage int64
+	addStorage := func(limits nauth.JetStreamLimits) {
+		if limits.MemoryStorage != nil && *limits.MemoryStorage > 0 {
+			memoryStorage += *limits.MemoryStorage
 		}
-		defer sysConn.Disconnect()
-
-		err = sysConn.UploadAccountJWT(signedJwt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to upload account jwt: %w", err)
+		if limits.DiskStorage != nil && *limits.DiskStorage > 0 {
+			diskStorage += *limits.DiskStorage
 		}
-		log.Info("Uploaded Account JWT to NATS",
-			"accountID", accountPublicKey, "prevClaimsHash", prevClaimsHash, "claimsHash", claimsHash)
+	}
+	if len(request.JetStreamTieredLimits) == 0 {
+		var limits nauth.JetStreamLimits
+		if defaults != nil && defaults.JetStreamLimits != nil {
+			limits = *defaults.JetStreamLimits
+		}
+		if request.JetStreamLimits != nil {
+			if request.JetStreamLimits.MemoryStorage != nil {
+				limits.MemoryStorage = request.JetStreamLimits.MemoryStorage
+			}
+			if request.JetStreamLimits.DiskStorage != nil {
+				limits.DiskStorage = request.JetStreamLimits.DiskStorage
+			}
+		}
+		addStorage(limits)
+	}
+	for _, tier := range request.JetStreamTieredLimits {
+		addStorage(tier)
 	}
 
-	nauthClaims, err := convertNatsAccountClaims(natsClaims)
+	if diskStorage > capacity.DiskStorage {
+		return domain.ErrClusterCapacityExceeded.WithCause(fmt.Errorf(
+			"JetStream disk storage of %d bytes exceeds the %d bytes of the NATS cluster", diskStorage, capacity.DiskStorage))
+	}
+	if memoryStorage > capacity.MemoryStorage {
+		return domain.ErrClusterCapacityExceeded.WithCause(fmt.Errorf(
+			"JetStream memory storage of %d bytes exceeds the %d bytes of the NATS cluster", memoryStorage, capacity.MemoryStorage))
+	}
+	return nil
+}
+
+// checkAccountJWTSize returns domain.ErrJWTTooLarge when token is larger than maxPayload, the largest message the NATS
+// servers accept, and the size of token when it exceeds accountJWTSizeWarningRatio of maxPayload. Many imports, exports
+// or revocations make account JWTs grow towards the limit. The NATS default is assumed when maxPayload is unknown.
+func checkAccountJWTSize(token string, maxPayload int64) (*nauth.JWTSize, error) {
+	if maxPayload <= 0 {
+		maxPayload = defaultNatsMaxPayload
+	}
+	size := len(token)
+	if int64(size) > maxPayload {
+		return nil, domain.ErrJWTTooLarge.WithCause(fmt.Errorf(
+			"account JWT of %d bytes exceeds the %d bytes accepted by the NATS servers, "+
+				"reduce the imports, exports or revocations of the account", size, maxPayload))
+	}
+	if float64(size) > accountJWTSizeWarningRatio*float64(maxPayload) {
+		return &nauth.JWTSize{Bytes: size, Limit: maxPayload}, nil
+	}
+	return nil, nil
+}
+
+// getOrCreatePendingSecrets returns the keys of an interrupted creation of the account, or creates new keys. New keys
+// are stored in a pending secret before the account secrets are applied, so a retry never creates a second account.
+func (a *AccountManager) getOrCreatePendingSecrets(ctx context.Context, accountRef domain.NamespacedName) (*Secrets, error) {
+	pendingSecrets, found, err := a.secretManager.GetPendingSecrets(ctx, accountRef)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert NATS account claims: %w", err)
+		return nil, fmt.Errorf("failed to get pending account secret: %w", err)
 	}
-	return &nauth.AccountResult{
-		AccountID:       accountPublicKey,
-		AccountSignedBy: operatorSigningPublicKey,
-		Claims:          &nauthClaims,
-		ClaimsHash:      claimsHash,
-		Adoptions:       adoptions,
-	}, nil
+	if found {
+		logf.FromContext(ctx).Info("Resuming account creation with pending keys", "accountRef", accountRef)
+		return pendingSecrets, nil
+	}
+
+	rootKeyPair, err := a.config.CryptoPolicy.createKeyPair(ctx, nkeys.PrefixByteAccount, "account root")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account root key pair: %w", err)
+	}
+	signingKeyPair, err := a.config.CryptoPolicy.createKeyPair(ctx, nkeys.PrefixByteAccount, "account signing")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account signing key pair: %w", err)
+	}
+	pendingSecrets = &Secrets{Root: rootKeyPair, Sign: signingKeyPair}
+	if err = a.secretManager.ApplyPendingSecrets(ctx, accountRef, pendingSecrets); err != nil {
+		return nil, fmt.Errorf("failed to apply pending account secret: %w", err)
+	}
+	return pendingSecrets, nil
+}
+
+// getProvidedSecrets returns the account keys generated outside of NAuth referenced by request. The root key must match
+// the account ID of the request, when set, and the keys must not be stored for another Account, which would then share
+// the account.
+func (a *AccountManager) getProvidedSecrets(ctx context.Context, request nauth.AccountRequest) (*Secrets, error) {
+	secrets, err := a.secretManager.GetProvidedSecrets(ctx, request.AccountRef, *request.KeySecrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provided account keys: %w", err)
+	}
+	accountID, err := secrets.Root.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract account root public key from provided secret: %w", err)
+	}
+	if request.AccountID != "" && string(request.AccountID) != accountID {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("provided account root key %s does not match account ID %s",
+			accountID, request.AccountID))
+	}
+	accountRef, found, err := a.secretManager.FindAccountRef(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up secrets of account %s: %w", accountID, err)
+	}
+	if found && accountRef != request.AccountRef {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("keys of account %s are already stored for Account %s",
+			accountID, accountRef))
+	}
+	return secrets, nil
+}
+
+// accountJWTMissing reports whether NATS has no JWT of accountID, e.g. after it was deleted out-of-band, so it is pushed
+// again although its claims are unchanged. A failed check is logged and reported as not missing, the JWT was pushed with
+// the same claims before.
+func (a *AccountManager) accountJWTMissing(ctx context.Context, cluster nauth.ClusterTarget, accountID string) bool {
+	log := logf.FromContext(ctx)
+	sysConn, err := a.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
+	if err != nil {
+		log.Info("Failed to connect to NATS cluster to check the Account JWT", "accountID", accountID, "error", err)
+		return false
+	}
+	defer sysConn.Disconnect()
+	jwtStore, err := sysConn.ResolverStore(cluster.Resolver)
+	if err != nil {
+		log.Info("Failed to open account JWT store to check the Account JWT", "accountID", accountID, "error", err)
+		return false
+	}
+	accountJWT, err := jwtStore.LookupAccountJWT(accountID)
+	if err != nil {
+		log.Info("Failed to lookup the Account JWT in NATS", "accountID", accountID, "error", err)
+		return false
+	}
+	if accountJWT != "" {
+		return false
+	}
+	log.Info("Account JWT missing in NATS, uploading it again", "accountID", accountID)
+	return true
+}
+
+// accountJWTUpToDate returns the account JWT stored in NATS and reports whether it has equivalent claims, compared by
+// claimsHash.
+func accountJWTUpToDate(jwtStore outbound.AccountJWTStore, accountID string, claimsHash string) (string, bool, error) {
+	currentJWT, err := jwtStore.LookupAccountJWT(accountID)
+	if err != nil {
+		return "", false, err
+	}
+	if currentJWT == "" {
+		return "", false, nil
+	}
+	currentClaimsHash, err := hashSignedAccountJWTClaims(currentJWT)
+	if err != nil {
+		return currentJWT, false, err
+	}
+	return currentJWT, currentClaimsHash == claimsHash, nil
 }
 
 func (a *AccountManager) FindAccountID(ctx context.Context, reference nauth.AccountReference) (nauth.AccountID, bool, error) {
@@ -274,6 +627,9 @@ func (a *AccountManager) Import(ctx context.Context, reference nauth.AccountRefe
 	if accountID == "" {
 		return nil, fmt.Errorf("account ID is missing for account %s during import", accountRef)
 	}
+	if cluster.IsSystemAccount(reference.AccountID) {
+		return nil, domain.ErrSystemAccountConflict.WithCause(fmt.Errorf("importing system account %s as account %s is not supported", accountID, accountRef))
+	}
 
 	secrets, found, err := a.secretManager.GetSecrets(ctx, accountRef, accountID)
 	if err != nil {
@@ -292,18 +648,25 @@ func (a *AccountManager) Import(ctx context.Context, reference nauth.AccountRefe
 		return nil, fmt.Errorf("account root seed does not match account ID during import: expected %s, got %s", accountID, accountRootPublicKey)
 	}
 
-	sysConn, err := a.natsSysClient.Connect(cluster.NatsURL, cluster.SystemAdminCreds)
+	sysConn, err := a.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to NATS cluster during import: %w", err)
+		return nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("failed to connect to NATS cluster during import: %w", err))
 	}
 	defer sysConn.Disconnect()
-	accountJWT, err := sysConn.LookupAccountJWT(accountID)
+	jwtStore, err := sysConn.ResolverStore(cluster.Resolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open account JWT store during import: %w", err)
+	}
+	accountJWT, err := jwtStore.LookupAccountJWT(accountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to lookup account jwt for account %s during import: %w", accountID, err)
 	}
 	if len(accountJWT) == 0 {
 		return nil, fmt.Errorf("account jwt for account %s not found during import", accountID)
 	}
+	if err = a.config.CryptoPolicy.verifyJWTAlgorithm(accountJWT); err != nil {
+		return nil, fmt.Errorf("account jwt for account %s rejected during import: %w", accountID, err)
+	}
 	natsClaims, err := jwt.DecodeAccountClaims(accountJWT)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode account jwt for account %s during import: %w", accountID, err)
@@ -317,14 +680,57 @@ func (a *AccountManager) Import(ctx context.Context, reference nauth.AccountRefe
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash account claims during import: %w", err)
 	}
+	liveJWT, err := decodeAccountJWTMetadata(accountJWT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode account jwt for account %s during import: %w", accountID, err)
+	}
 	return &nauth.AccountResult{
 		AccountID:       accountID,
 		AccountSignedBy: natsClaims.Issuer,
 		Claims:          &nauthClaims,
 		ClaimsHash:      claimsHash,
+		JWT:             liveJWT,
 	}, nil
 }
 
+// CheckSync compares the account in NATS with claimsHash, the hash of the claims it was last pushed or imported with.
+func (a *AccountManager) CheckSync(ctx context.Context, reference nauth.AccountReference, claimsHash string) (nauth.AccountSyncState, error) {
+	if err := reference.Validate(); err != nil {
+		return "", fmt.Errorf("invalid account reference: %w", err)
+	}
+	cluster := reference.ClusterTarget
+
+	accountID := string(reference.AccountID)
+	if accountID == "" {
+		return "", fmt.Errorf("account ID is missing for account %s", reference.AccountRef)
+	}
+	_, found, err := a.secretManager.GetSecrets(ctx, reference.AccountRef, accountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secrets for account %s: %w", accountID, err)
+	}
+	if !found {
+		return nauth.AccountSyncStateMissingSecrets, nil
+	}
+
+	sysConn, err := a.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
+	if err != nil {
+		return "", domain.ErrClusterUnreachable.WithCause(fmt.Errorf("failed to connect to NATS cluster: %w", err))
+	}
+	defer sysConn.Disconnect()
+	jwtStore, err := sysConn.ResolverStore(cluster.Resolver)
+	if err != nil {
+		return "", fmt.Errorf("failed to open account JWT store: %w", err)
+	}
+	_, upToDate, err := accountJWTUpToDate(jwtStore, accountID, claimsHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup account jwt for account %s: %w", accountID, err)
+	}
+	if !upToDate {
+		return nauth.AccountSyncStateDrifted, nil
+	}
+	return nauth.AccountSyncStateInSync, nil
+}
+
 func (a *AccountManager) Delete(ctx context.Context, reference nauth.AccountReference) error {
 	if err := reference.Validate(); err != nil {
 		return fmt.Errorf("invalid account reference: %w", err)
@@ -340,6 +746,13 @@ func (a *AccountManager) Delete(ctx context.Context, reference nauth.AccountRefe
 	if accountID == "" {
 		return fmt.Errorf("account ID is missing for account %s", reference.AccountRef)
 	}
+	if cluster.IsSystemAccount(reference.AccountID) {
+		return domain.ErrSystemAccountConflict.WithCause(fmt.Errorf("deleting system account %s is not supported", accountID))
+	}
+	if until, active := cluster.MaintenanceWindows.ActiveUntil(time.Now()); active {
+		return domain.ErrMaintenanceWindow.WithCause(fmt.Errorf("deleting account %s is deferred until %s", accountID,
+			until.Format(time.RFC3339)))
+	}
 
 	accountSecrets, found, err := a.secretManager.GetSecrets(ctx, reference.AccountRef, accountID)
 	if err != nil {
@@ -349,12 +762,12 @@ func (a *AccountManager) Delete(ctx context.Context, reference nauth.AccountRefe
 		// Account secrets may already be gone if secretManager.DeleteAll partially failed during previous attempt.
 		// Then we won't be able to sign a JWT to lookup account streams, but we can skip the check since the account
 		// is effectively already deleted in NATS.
-		streams, err := a.listAccountStreams(cluster, accountSecrets, accountID)
+		streams, err := a.listAccountStreams(ctx, cluster, accountSecrets, accountID)
 		if err != nil {
 			return fmt.Errorf("failed to list account streams: %w", err)
 		}
 		if len(streams) > 0 {
-			return fmt.Errorf("account deletion aborted due to %d JetStream Stream(s) still exist for account: %s", len(streams), streams)
+			return domain.ErrJetStreamResourcesExist.WithCause(fmt.Errorf("account deletion aborted due to %d JetStream Stream(s) still exist for account: %s", len(streams), streams))
 		}
 	}
 
@@ -367,32 +780,115 @@ func (a *AccountManager) Delete(ctx context.Context, reference nauth.AccountRefe
 		return fmt.Errorf("failed to sign account JWT: %w", err)
 	}
 
-	sysConn, err := a.natsSysClient.Connect(cluster.NatsURL, cluster.SystemAdminCreds)
+	sysConn, err := a.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
 	if err != nil {
-		return fmt.Errorf("failed to connect to NATS: %w", err)
+		return domain.ErrClusterUnreachable.WithCause(fmt.Errorf("failed to connect to NATS: %w", err))
 	}
 	defer sysConn.Disconnect()
+	jwtStore, err := sysConn.ResolverStore(cluster.Resolver)
+	if err != nil {
+		return fmt.Errorf("failed to open account JWT store: %w", err)
+	}
 
-	err = sysConn.DeleteAccountJWT(deleteJwt)
+	err = jwtStore.DeleteAccountJWT(deleteJwt)
 	if err != nil {
 		return fmt.Errorf("failed to delete account JWT in NATS: %w", err)
 	}
+	a.auditRecorder.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionAccountJWTDeleted,
+		Resource: auditResource(auditKindAccount, reference.AccountRef),
+		Subject:  accountID,
+		Issuer:   operatorPublicKey,
+	})
 
 	err = a.secretManager.DeleteAll(ctx, reference.AccountRef, accountID)
 	if err != nil {
 		return fmt.Errorf("failed to delete account secrets: %w", err)
 	}
+	a.recordAccountKeysDeleted(ctx, reference.AccountRef, accountID)
 
 	return nil
 }
 
-func (a *AccountManager) listAccountStreams(cluster nauth.ClusterTarget, accountSecrets *Secrets, accountID string) ([]string, error) {
+func (a *AccountManager) Orphan(ctx context.Context, reference nauth.AccountReference) error {
+	if err := reference.Validate(); err != nil {
+		return fmt.Errorf("invalid account reference: %w", err)
+	}
+	accountID := string(reference.AccountID)
+	if accountID == "" {
+		return fmt.Errorf("account ID is missing for account %s", reference.AccountRef)
+	}
+	if reference.ClusterTarget.IsSystemAccount(reference.AccountID) {
+		return domain.ErrSystemAccountConflict.WithCause(fmt.Errorf("orphaning system account %s is not supported", accountID))
+	}
+
+	if err := a.secretManager.DeleteAll(ctx, reference.AccountRef, accountID); err != nil {
+		return fmt.Errorf("failed to delete account secrets: %w", err)
+	}
+	a.recordAccountKeysDeleted(ctx, reference.AccountRef, accountID)
+	return nil
+}
+
+// FindAdoptionSource returns the Account the secrets of the account of reference are held for, when it is another
+// Account than reference, e.g. the Account deleted before moving it to another namespace.
+func (a *AccountManager) FindAdoptionSource(ctx context.Context, reference nauth.AccountReference) (*domain.NamespacedName, error) {
+	if err := reference.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid account reference: %w", err)
+	}
+	accountID := string(reference.AccountID)
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID is missing for account %s", reference.AccountRef)
+	}
+	source, found, err := a.secretManager.FindAccountRef(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account secrets: %w", err)
+	}
+	if !found || source == reference.AccountRef {
+		return nil, nil
+	}
+	return &source, nil
+}
+
+// Adopt moves the secrets of the account of reference held for the Account source to reference. The caller makes
+// sure source no longer exists and may be adopted by reference.
+func (a *AccountManager) Adopt(ctx context.Context, reference nauth.AccountReference, source domain.NamespacedName) error {
+	if err := reference.Validate(); err != nil {
+		return fmt.Errorf("invalid account reference: %w", err)
+	}
+	accountID := string(reference.AccountID)
+	if accountID == "" {
+		return fmt.Errorf("account ID is missing for account %s", reference.AccountRef)
+	}
+	if reference.ClusterTarget.IsSystemAccount(reference.AccountID) {
+		return domain.ErrSystemAccountConflict.WithCause(fmt.Errorf("adopting system account %s is not supported", accountID))
+	}
+
+	if err := a.secretManager.MoveSecrets(ctx, source, reference.AccountRef, accountID); err != nil {
+		return fmt.Errorf("failed to move account secrets from %s: %w", source, err)
+	}
+	a.auditRecorder.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionAccountKeysAdopted,
+		Resource: auditResource(auditKindAccount, reference.AccountRef),
+		Subject:  accountID,
+	})
+	return nil
+}
+
+func (a *AccountManager) recordAccountKeysDeleted(ctx context.Context, accountRef domain.NamespacedName, accountID string) {
+	a.auditRecorder.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionAccountKeysDeleted,
+		Resource: auditResource(auditKindAccount, accountRef),
+		Subject:  accountID,
+	})
+}
+
+func (a *AccountManager) listAccountStreams(ctx context.Context, cluster nauth.ClusterTarget, accountSecrets *Secrets, accountID string) ([]string, error) {
 	tempUserCreds, err := createTempJetStreamCreds(accountID, accountSecrets.Root)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temporary account JetStream credentials: %w", err)
 	}
 
-	accConn, err := a.natsAccClient.Connect(cluster.NatsURL, *tempUserCreds)
+	accConn, err := a.natsAccClient.Connect(ctx, cluster.NatsURL, *tempUserCreds, cluster.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to NATS cluster for JetStream streams lookup: %w", err)
 	}
@@ -487,4 +983,5 @@ func getDisplayName(request nauth.AccountRequest) string {
 }
 
 var _ inbound.AccountManager = (*AccountManager)(nil)
+var _ inbound.AccountSyncChecker = (*AccountManager)(nil)
 var _ UserJWTSigner = (*AccountManager)(nil)
@@ -7,15 +7,27 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
+	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/nats-io/jwt/v2"
 	"k8s.io/apimachinery/pkg/util/json"
 )
 
+// unlimitedJetStreamTier is the base of every tier of tiered JetStream limits.
+var unlimitedJetStreamTier = jwt.JetStreamLimits{
+	DiskStorage:   jwt.NoLimit,
+	MemoryStorage: jwt.NoLimit,
+	Streams:       jwt.NoLimit,
+	Consumer:      jwt.NoLimit,
+	MaxAckPending: jwt.NoLimit,
+}
+
 type accountClaimsBuilder struct {
 	jetStreamRequested *bool
 	claim              *jwt.AccountClaims
+	observedFields     map[nauth.AccountClaimField]bool
 	errs               []error
 }
 
@@ -45,6 +57,29 @@ func (b *accountClaimsBuilder) displayName(name string) *accountClaimsBuilder {
 	return b
 }
 
+// info sets the description, info URL and tags the account is listed with.
+func (b *accountClaimsBuilder) info(description string, infoURL string, tags []string) *accountClaimsBuilder {
+	b.claim.Description = description
+	b.claim.InfoURL = infoURL
+	b.claim.Tags.Add(tags...)
+	return b
+}
+
+// limitDefaults applies the limits of the cluster defaults. It is applied before the limits of the account, so every
+// field the account sets replaces the field of the default. JetStream defaults are skipped when JetStream is disabled,
+// and replaced as a whole by tiered JetStream limits.
+func (b *accountClaimsBuilder) limitDefaults(defaults *nauth.AccountLimitDefaults) *accountClaimsBuilder {
+	if defaults == nil {
+		return b
+	}
+	b.accountLimits(defaults.AccountLimits)
+	if b.jetStreamRequested == nil || *b.jetStreamRequested {
+		b.jetStreamLimits(defaults.JetStreamLimits)
+	}
+	b.natsLimits(defaults.NatsLimits)
+	return b
+}
+
 func (b *accountClaimsBuilder) accountLimits(limits *nauth.AccountLimits) *accountClaimsBuilder {
 	if limits != nil {
 		if limits.Imports != nil {
@@ -82,36 +117,137 @@ func (b *accountClaimsBuilder) natsLimits(limits *nauth.NatsLimits) *accountClai
 }
 
 func (b *accountClaimsBuilder) jetStreamLimits(limits *nauth.JetStreamLimits) *accountClaimsBuilder {
-	if limits != nil {
-		if limits.MemoryStorage != nil {
-			b.claim.Limits.MemoryStorage = *limits.MemoryStorage
-		}
-		if limits.DiskStorage != nil {
-			b.claim.Limits.DiskStorage = *limits.DiskStorage
-		}
-		if limits.Streams != nil {
-			b.claim.Limits.Streams = *limits.Streams
-		}
-		if limits.Consumer != nil {
-			b.claim.Limits.Consumer = *limits.Consumer
-		}
-		if limits.MaxAckPending != nil {
-			b.claim.Limits.MaxAckPending = *limits.MaxAckPending
-		}
-		if limits.MemoryMaxStreamBytes != nil {
-			b.claim.Limits.MemoryMaxStreamBytes = *limits.MemoryMaxStreamBytes
-		}
-		if limits.DiskMaxStreamBytes != nil {
-			b.claim.Limits.DiskMaxStreamBytes = *limits.DiskMaxStreamBytes
-		}
-		if limits.MaxBytesRequired != nil {
-			b.claim.Limits.MaxBytesRequired = *limits.MaxBytesRequired
+	applyJetStreamLimits(&b.claim.Limits.JetStreamLimits, limits)
+	return b
+}
+
+// jetStreamTieredLimits replaces the account-wide JetStream limits with limits per replication tier, as NATS does not
+// accept both. Each tier starts from unlimited JetStream.
+func (b *accountClaimsBuilder) jetStreamTieredLimits(limits nauth.JetStreamTieredLimits) *accountClaimsBuilder {
+	if len(limits) == 0 {
+		return b
+	}
+	b.claim.Limits.JetStreamLimits = jwt.JetStreamLimits{}
+	b.claim.Limits.JetStreamTieredLimits = make(jwt.JetStreamTieredLimits, len(limits))
+	for tier, tierLimits := range limits {
+		result := unlimitedJetStreamTier
+		applyJetStreamLimits(&result, &tierLimits)
+		b.claim.Limits.JetStreamTieredLimits[tier] = result
+	}
+	return b
+}
+
+// mappings sets the subject mappings of the account, a later mapping of the same subject replacing an earlier one.
+func (b *accountClaimsBuilder) mappings(mappings nauth.SubjectMappings) *accountClaimsBuilder {
+	for _, mapping := range mappings {
+		destinations := make([]jwt.WeightedMapping, 0, len(mapping.Destinations))
+		for _, destination := range mapping.Destinations {
+			destinations = append(destinations, jwt.WeightedMapping{
+				Subject: jwt.Subject(destination.Subject.Normalize()),
+				Weight:  destination.Weight,
+				Cluster: destination.Cluster,
+			})
 		}
+		b.claim.AddMapping(jwt.Subject(mapping.Subject.Normalize()), destinations...)
+	}
+	return b
+}
+
+// authorization sets whether users of the account may connect with bearer JWTs and the permissions of users whose JWT
+// has none of its own.
+func (b *accountClaimsBuilder) authorization(disallowBearer bool, defaultPermissions *nauth.Permissions) *accountClaimsBuilder {
+	b.claim.Limits.DisallowBearer = disallowBearer
+	if defaultPermissions != nil {
+		b.claim.DefaultPermissions = toJWTPermissions(*defaultPermissions)
+	}
+	return b
+}
+
+// trace sends traces of sampled messages of the account to the destination of trace, tracing all sampled messages
+// unless trace sets a sampling percentage.
+func (b *accountClaimsBuilder) trace(trace *nauth.MessageTrace) *accountClaimsBuilder {
+	if trace == nil {
+		return b
+	}
+	sampling := trace.Sampling
+	if sampling == 0 {
+		sampling = 100
+	}
+	b.claim.Trace = &jwt.MsgTrace{Destination: jwt.Subject(trace.Destination.Normalize()), Sampling: sampling}
+	return b
+}
+
+// clusterTraffic selects the account whose connections carry the traffic of the account between the servers of a
+// cluster.
+func (b *accountClaimsBuilder) clusterTraffic(clusterTraffic string) *accountClaimsBuilder {
+	b.claim.ClusterTraffic = jwt.ClusterTraffic(clusterTraffic)
+	return b
+}
+
+// observed replaces the fields of the claims in fields with the fields of live, the claims of the account JWT in NATS,
+// and rejects export and import groups added later for observed exports and imports. It must be applied after the
+// other fields, so the observed fields win over the defaults. Without live claims, before the account is first pushed,
+// observed fields keep their defaults. Bearer JWTs stay managed with the limits observed.
+func (b *accountClaimsBuilder) observed(fields []nauth.AccountClaimField, live *jwt.AccountClaims) *accountClaimsBuilder {
+	b.observedFields = make(map[nauth.AccountClaimField]bool, len(fields))
+	for _, field := range fields {
+		b.observedFields[field] = true
+	}
+	if live == nil {
+		return b
+	}
+	if b.observedFields[nauth.AccountClaimExports] {
+		b.claim.Exports = live.Exports
+	}
+	if b.observedFields[nauth.AccountClaimImports] {
+		b.claim.Imports = live.Imports
+	}
+	if b.observedFields[nauth.AccountClaimLimits] {
+		disallowBearer := b.claim.Limits.DisallowBearer
+		b.claim.Limits = live.Limits
+		b.claim.Limits.DisallowBearer = disallowBearer
+		b.jetStreamRequested = nil
+	}
+	if b.observedFields[nauth.AccountClaimMappings] {
+		b.claim.Mappings = live.Mappings
 	}
 	return b
 }
 
+func applyJetStreamLimits(target *jwt.JetStreamLimits, limits *nauth.JetStreamLimits) {
+	if limits == nil {
+		return
+	}
+	if limits.MemoryStorage != nil {
+		target.MemoryStorage = *limits.MemoryStorage
+	}
+	if limits.DiskStorage != nil {
+		target.DiskStorage = *limits.DiskStorage
+	}
+	if limits.Streams != nil {
+		target.Streams = *limits.Streams
+	}
+	if limits.Consumer != nil {
+		target.Consumer = *limits.Consumer
+	}
+	if limits.MaxAckPending != nil {
+		target.MaxAckPending = *limits.MaxAckPending
+	}
+	if limits.MemoryMaxStreamBytes != nil {
+		target.MemoryMaxStreamBytes = *limits.MemoryMaxStreamBytes
+	}
+	if limits.DiskMaxStreamBytes != nil {
+		target.DiskMaxStreamBytes = *limits.DiskMaxStreamBytes
+	}
+	if limits.MaxBytesRequired != nil {
+		target.MaxBytesRequired = *limits.MaxBytesRequired
+	}
+}
+
 func (b *accountClaimsBuilder) addImportGroup(group nauth.ImportGroup) error {
+	if b.observedFields[nauth.AccountClaimImports] {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("imports of the account are observed"))
+	}
 	imports, err := toJWTImports(group.Imports)
 	if err != nil {
 		return err
@@ -130,6 +266,9 @@ func (b *accountClaimsBuilder) addImportGroup(group nauth.ImportGroup) error {
 }
 
 func (b *accountClaimsBuilder) addExportGroup(group nauth.ExportGroup) error {
+	if b.observedFields[nauth.AccountClaimExports] {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("exports of the account are observed"))
+	}
 	exports, err := toJWTExports(group.Exports)
 	if err != nil {
 		return err
@@ -137,6 +276,9 @@ func (b *accountClaimsBuilder) addExportGroup(group nauth.ExportGroup) error {
 	if err = validateJWTExports(exports); err != nil {
 		return err
 	}
+	if err = group.Exports.ValidateWildcards(b.claim.Limits.WildcardExports); err != nil {
+		return domain.ErrBadRequest.WithCause(err)
+	}
 
 	result := jwt.Exports(mergeJWTItems(b.claim.Exports, exports, true))
 	err = validateJWTExports(result)
@@ -156,6 +298,21 @@ func (b *accountClaimsBuilder) build() (*jwt.AccountClaims, error) {
 	if err := validateJetStreamLimits(b.jetStreamRequested, b.claim.Limits); err != nil {
 		b.errs = append(b.errs, err)
 	}
+	if err := validateJWTMappings(b.claim.Mappings); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	if err := validateJWTInfo(b.claim.Info); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	if err := validateJWTPermissions(b.claim.DefaultPermissions); err != nil {
+		b.errs = append(b.errs, fmt.Errorf("invalid default permissions: %w", err))
+	}
+	if err := validateJWTTrace(b.claim.Trace); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	if err := b.claim.ClusterTraffic.Valid(); err != nil {
+		b.errs = append(b.errs, err)
+	}
 	if err := errors.Join(b.errs...); err != nil {
 		return nil, err
 	}
@@ -194,6 +351,23 @@ func hashSignedAccountJWTClaims(accountJWT string) (string, error) {
 	return hex.EncodeToString(sum[:]), nil
 }
 
+// decodeAccountJWTMetadata returns the ID, issue time, expiry and issuer of a signed account JWT.
+func decodeAccountJWTMetadata(accountJWT string) (*nauth.JWTMetadata, error) {
+	claims, err := jwt.DecodeAccountClaims(accountJWT)
+	if err != nil {
+		return nil, err
+	}
+	result := &nauth.JWTMetadata{
+		ID:       claims.ID,
+		IssuedAt: time.Unix(claims.IssuedAt, 0).UTC(),
+		Issuer:   claims.Issuer,
+	}
+	if claims.Expires != 0 {
+		result.ExpiresAt = new(time.Unix(claims.Expires, 0).UTC())
+	}
+	return result, nil
+}
+
 func toPointerDefaultNil[V int64 | bool](value V, defaultValue V) *V {
 	if value != defaultValue {
 		return &value
@@ -209,6 +383,11 @@ func convertNatsAccountClaims(claims *jwt.AccountClaims) (nauth.AccountClaims, e
 	claimsDefaults := jwt.NewAccountClaims("N/A")
 	out := nauth.AccountClaims{}
 	out.DisplayName = claims.Name
+	out.Description = claims.Description
+	out.InfoURL = claims.InfoURL
+	if len(claims.Tags) > 0 {
+		out.Tags = append([]string(nil), claims.Tags...)
+	}
 
 	jetStreamEnabled := claims.Limits.IsJSEnabled()
 	out.JetStreamEnabled = &jetStreamEnabled
@@ -244,18 +423,47 @@ func convertNatsAccountClaims(claims *jwt.AccountClaims) (nauth.AccountClaims, e
 		source := claims.Limits.JetStreamLimits
 		defaults := claimsDefaults.Limits.JetStreamLimits
 		if source != defaults {
-			out.JetStreamLimits = &nauth.JetStreamLimits{}
-			out.JetStreamLimits.MemoryStorage = toPointerDefaultNil(source.MemoryStorage, defaults.MemoryStorage)
-			out.JetStreamLimits.DiskStorage = toPointerDefaultNil(source.DiskStorage, defaults.DiskStorage)
-			out.JetStreamLimits.Streams = toPointerDefaultNil(source.Streams, defaults.Streams)
-			out.JetStreamLimits.Consumer = toPointerDefaultNil(source.Consumer, defaults.Consumer)
-			out.JetStreamLimits.MaxAckPending = toPointerDefaultNil(source.MaxAckPending, defaults.MaxAckPending)
-			out.JetStreamLimits.MemoryMaxStreamBytes = toPointerDefaultNil(source.MemoryMaxStreamBytes, defaults.MemoryMaxStreamBytes)
-			out.JetStreamLimits.DiskMaxStreamBytes = toPointerDefaultNil(source.DiskMaxStreamBytes, defaults.DiskMaxStreamBytes)
-			out.JetStreamLimits.MaxBytesRequired = toPointerDefaultNil(source.MaxBytesRequired, defaults.MaxBytesRequired)
+			out.JetStreamLimits = toNAuthJetStreamLimits(source, defaults)
 		}
 	}
 
+	// JetStreamTieredLimits
+	if len(claims.Limits.JetStreamTieredLimits) > 0 {
+		out.JetStreamTieredLimits = make(nauth.JetStreamTieredLimits, len(claims.Limits.JetStreamTieredLimits))
+		for tier, source := range claims.Limits.JetStreamTieredLimits {
+			out.JetStreamTieredLimits[tier] = *toNAuthJetStreamLimits(source, unlimitedJetStreamTier)
+		}
+	}
+
+	// Mappings
+	if len(claims.Mappings) > 0 {
+		mappings := make(nauth.SubjectMappings, 0, len(claims.Mappings))
+		for subject, destinations := range claims.Mappings {
+			mapping := nauth.SubjectMapping{Subject: nauth.Subject(subject)}
+			for _, destination := range destinations {
+				mapping.Destinations = append(mapping.Destinations, nauth.WeightedDestination{
+					Subject: nauth.Subject(destination.Subject),
+					Weight:  destination.Weight,
+					Cluster: destination.Cluster,
+				})
+			}
+			mappings = append(mappings, mapping)
+		}
+		sort.Slice(mappings, func(i, j int) bool {
+			return mappings[i].Subject < mappings[j].Subject
+		})
+		out.Mappings = mappings
+	}
+
+	out.DisallowBearer = claims.Limits.DisallowBearer
+	if claims.Trace != nil {
+		out.Trace = &nauth.MessageTrace{Destination: nauth.Subject(claims.Trace.Destination), Sampling: claims.Trace.Sampling}
+	}
+	out.ClusterTraffic = string(claims.ClusterTraffic)
+	if !reflect.DeepEqual(claims.DefaultPermissions, jwt.Permissions{}) {
+		out.DefaultPermissions = toNAuthPermissions(claims.DefaultPermissions)
+	}
+
 	// Signing Keys
 	if len(claims.SigningKeys) > 0 {
 		signingKeys := make(nauth.SigningKeys, 0, len(claims.SigningKeys))
@@ -315,7 +523,11 @@ func validateExports(exports nauth.Exports) error {
 	if err != nil {
 		return err
 	}
-	return validateJWTExports(jwtExports)
+	if err = validateJWTExports(jwtExports); err != nil {
+		return err
+	}
+	// The account limits are only known to the Account, which validates them when adopting the exports
+	return exports.ValidateWildcards(true)
 }
 
 func validateJWTExports(exports jwt.Exports) error {
@@ -324,6 +536,57 @@ func validateJWTExports(exports jwt.Exports) error {
 	if valResults.IsBlocking(false) {
 		return errors.Join(valResults.Errors()...)
 	}
+	// The NATS JWT library accepts misplaced wildcards and partially overlapping exports, NATS only rejects them at runtime
+	subjects := make(nauth.Exports, 0, len(exports))
+	for _, export := range exports {
+		exportType, err := toNAuthExportType(export.Type)
+		if err != nil {
+			return err
+		}
+		subjects = append(subjects, &nauth.Export{Subject: nauth.Subject(export.Subject), Type: exportType})
+	}
+	return subjects.ValidateSubjects()
+}
+
+func validateJWTMappings(mappings jwt.Mapping) error {
+	valResults := &jwt.ValidationResults{}
+	mappings.Validate(valResults)
+	if valResults.IsBlocking(false) {
+		return errors.Join(valResults.Errors()...)
+	}
+	var errs []error
+	for subject := range mappings {
+		if err := nauth.Subject(subject).Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid mapping: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateJWTInfo(info jwt.Info) error {
+	valResults := &jwt.ValidationResults{}
+	info.Validate(valResults)
+	if valResults.IsBlocking(false) {
+		return errors.Join(valResults.Errors()...)
+	}
+	return nil
+}
+
+func validateJWTTrace(trace *jwt.MsgTrace) error {
+	if trace == nil {
+		return nil
+	}
+	valResults := &jwt.ValidationResults{}
+	trace.Destination.Validate(valResults)
+	if trace.Destination.HasWildCards() {
+		valResults.AddError("destination %q contains wildcards", trace.Destination)
+	}
+	if trace.Sampling < 1 || trace.Sampling > 100 {
+		valResults.AddError("sampling %d is not between 1 and 100", trace.Sampling)
+	}
+	if valResults.IsBlocking(false) {
+		return fmt.Errorf("invalid trace: %w", errors.Join(valResults.Errors()...))
+	}
 	return nil
 }
 
@@ -341,7 +604,11 @@ func validateJWTImports(importAccountID string, imports jwt.Imports) error {
 	if valResults.IsBlocking(false) {
 		return errors.Join(valResults.Errors()...)
 	}
-	return nil
+	subjects := make(nauth.Imports, 0, len(imports))
+	for _, imp := range imports {
+		subjects = append(subjects, &nauth.Import{Subject: nauth.Subject(imp.Subject), LocalSubject: nauth.Subject(imp.LocalSubject)})
+	}
+	return subjects.ValidateSubjects()
 }
 
 func mergeJWTItems[T jwt.Import | jwt.Export](existing []*T, additions []*T, mergeDuplicates bool) []*T {
@@ -386,9 +653,9 @@ func toJWTImport(source nauth.Import) (*jwt.Import, error) {
 	return &jwt.Import{
 		Account:      string(source.AccountID),
 		Name:         source.Name,
-		Subject:      jwt.Subject(source.Subject),
+		Subject:      jwt.Subject(source.Subject.Normalize()),
 		Type:         exportType,
-		LocalSubject: jwt.RenamingSubject(source.LocalSubject),
+		LocalSubject: jwt.RenamingSubject(source.LocalSubject.Normalize()),
 		Share:        source.Share,
 		AllowTrace:   source.AllowTrace,
 	}, nil
@@ -413,7 +680,7 @@ func toJWTExport(source nauth.Export) (*jwt.Export, error) {
 	}
 	return &jwt.Export{
 		Name:                 source.Name,
-		Subject:              jwt.Subject(source.Subject),
+		Subject:              jwt.Subject(source.Subject.Normalize()),
 		Type:                 exportType,
 		TokenReq:             source.TokenReq,
 		Revocations:          jwt.RevocationList(source.Revocations),
@@ -476,9 +743,24 @@ func toNAuthExport(source jwt.Export) (*nauth.Export, error) {
 		Latency:              toNAuthServiceLatency(source.Latency),
 		Advertise:            source.Advertise,
 		AllowTrace:           source.AllowTrace,
+		// Wildcard exports of a JWT were acknowledged when they were built, or by whoever signed it
+		AllowWildcard: nauth.Subject(source.Subject).HasWildcards(),
 	}, nil
 }
 
+func toNAuthJetStreamLimits(source jwt.JetStreamLimits, defaults jwt.JetStreamLimits) *nauth.JetStreamLimits {
+	return &nauth.JetStreamLimits{
+		MemoryStorage:        toPointerDefaultNil(source.MemoryStorage, defaults.MemoryStorage),
+		DiskStorage:          toPointerDefaultNil(source.DiskStorage, defaults.DiskStorage),
+		Streams:              toPointerDefaultNil(source.Streams, defaults.Streams),
+		Consumer:             toPointerDefaultNil(source.Consumer, defaults.Consumer),
+		MaxAckPending:        toPointerDefaultNil(source.MaxAckPending, defaults.MaxAckPending),
+		MemoryMaxStreamBytes: toPointerDefaultNil(source.MemoryMaxStreamBytes, defaults.MemoryMaxStreamBytes),
+		DiskMaxStreamBytes:   toPointerDefaultNil(source.DiskMaxStreamBytes, defaults.DiskMaxStreamBytes),
+		MaxBytesRequired:     toPointerDefaultNil(source.MaxBytesRequired, defaults.MaxBytesRequired),
+	}
+}
+
 func toNAuthResponseType(source jwt.ResponseType) (nauth.ResponseType, error) {
 	if source == "" {
 		return "", nil
@@ -549,3 +831,47 @@ func toNAuthExportType(source jwt.ExportType) (nauth.ExportType, error) {
 	}
 	return result, nil
 }
+
+func toJWTPermissions(permissions nauth.Permissions) jwt.Permissions {
+	result := jwt.Permissions{
+		Pub: jwt.Permission{Allow: toJWTSubjects(permissions.Pub.Allow), Deny: toJWTSubjects(permissions.Pub.Deny)},
+		Sub: jwt.Permission{Allow: toJWTSubjects(permissions.Sub.Allow), Deny: toJWTSubjects(permissions.Sub.Deny)},
+	}
+	if permissions.Resp != nil {
+		result.Resp = &jwt.ResponsePermission{MaxMsgs: permissions.Resp.MaxMsgs, Expires: permissions.Resp.Expires}
+	}
+	return result
+}
+
+func toJWTSubjects(subjects []nauth.Subject) jwt.StringList {
+	if subjects == nil {
+		return nil
+	}
+	result := make(jwt.StringList, 0, len(subjects))
+	for _, subject := range subjects {
+		result = append(result, string(subject.Normalize()))
+	}
+	return result
+}
+
+func toNAuthPermissions(permissions jwt.Permissions) *nauth.Permissions {
+	result := &nauth.Permissions{
+		Pub: nauth.Permission{Allow: toNAuthSubjects(permissions.Pub.Allow), Deny: toNAuthSubjects(permissions.Pub.Deny)},
+		Sub: nauth.Permission{Allow: toNAuthSubjects(permissions.Sub.Allow), Deny: toNAuthSubjects(permissions.Sub.Deny)},
+	}
+	if permissions.Resp != nil {
+		result.Resp = &nauth.ResponsePermission{MaxMsgs: permissions.Resp.MaxMsgs, Expires: permissions.Resp.Expires}
+	}
+	return result
+}
+
+func toNAuthSubjects(subjects jwt.StringList) []nauth.Subject {
+	if len(subjects) == 0 {
+		return nil
+	}
+	result := make([]nauth.Subject, 0, len(subjects))
+	for _, subject := range subjects {
+		result = append(result, nauth.Subject(subject))
+	}
+	return result
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/testutil"
 	approvals "github.com/approvals/go-approval-tests"
@@ -25,12 +26,17 @@ const (
 )
 
 type TestAccountClaimsSpec struct {
-	AccountLimits    *nauth.AccountLimits   `json:"accountLimits,omitempty"`
-	JetStreamLimits  *nauth.JetStreamLimits `json:"jetStreamLimits,omitempty"`
-	JetStreamEnabled *bool                  `json:"jetStreamEnabled,omitempty"`
-	NatsLimits       *nauth.NatsLimits      `json:"natsLimits,omitempty"`
-	Exports          nauth.Exports          `json:"exports,omitempty"`
-	Imports          nauth.Imports          `json:"imports,omitempty"`
+	Description           string                      `json:"description,omitempty"`
+	InfoURL               string                      `json:"infoURL,omitempty"`
+	Tags                  []string                    `json:"tags,omitempty"`
+	AccountLimits         *nauth.AccountLimits        `json:"accountLimits,omitempty"`
+	JetStreamLimits       *nauth.JetStreamLimits      `json:"jetStreamLimits,omitempty"`
+	JetStreamTieredLimits nauth.JetStreamTieredLimits `json:"jetStreamTieredLimits,omitempty"`
+	JetStreamEnabled      *bool                       `json:"jetStreamEnabled,omitempty"`
+	NatsLimits            *nauth.NatsLimits           `json:"natsLimits,omitempty"`
+	Mappings              nauth.SubjectMappings       `json:"mappings,omitempty"`
+	Exports               nauth.Exports               `json:"exports,omitempty"`
+	Imports               nauth.Imports               `json:"imports,omitempty"`
 }
 
 func Test_AccountClaims(t *testing.T) {
@@ -47,9 +53,12 @@ func Test_AccountClaims(t *testing.T) {
 			unitUnderTest := func(spec *TestAccountClaimsSpec) (*jwt.AccountClaims, error) {
 				builder := newAccountClaimsBuilder(testClaimsAccountPubKey, spec.JetStreamEnabled).
 					displayName(testClaimsDisplayName).
+					info(spec.Description, spec.InfoURL, spec.Tags).
 					accountLimits(spec.AccountLimits).
 					jetStreamLimits(spec.JetStreamLimits).
-					natsLimits(spec.NatsLimits)
+					jetStreamTieredLimits(spec.JetStreamTieredLimits).
+					natsLimits(spec.NatsLimits).
+					mappings(spec.Mappings)
 				require.NoError(t, builder.addExportGroup(nauth.ExportGroup{Exports: spec.Exports}))
 				require.NoError(t, builder.addImportGroup(nauth.ImportGroup{Imports: spec.Imports}))
 				builder.signingKey(testClaimsSigningKey01)
@@ -85,12 +94,17 @@ func Test_AccountClaims(t *testing.T) {
 
 			// Verify that the resulting NAuth AccountClaim generates the same NATS JWT when encoded
 			rebuiltNatsClaims := &TestAccountClaimsSpec{
-				JetStreamEnabled: nauthClaims.JetStreamEnabled,
-				AccountLimits:    nauthClaims.AccountLimits,
-				JetStreamLimits:  nauthClaims.JetStreamLimits,
-				NatsLimits:       nauthClaims.NatsLimits,
-				Exports:          nauthClaims.Exports,
-				Imports:          nauthClaims.Imports,
+				Description:           nauthClaims.Description,
+				InfoURL:               nauthClaims.InfoURL,
+				Tags:                  nauthClaims.Tags,
+				JetStreamEnabled:      nauthClaims.JetStreamEnabled,
+				AccountLimits:         nauthClaims.AccountLimits,
+				JetStreamLimits:       nauthClaims.JetStreamLimits,
+				JetStreamTieredLimits: nauthClaims.JetStreamTieredLimits,
+				NatsLimits:            nauthClaims.NatsLimits,
+				Mappings:              nauthClaims.Mappings,
+				Exports:               nauthClaims.Exports,
+				Imports:               nauthClaims.Imports,
 			}
 			natsClaimsRebuilt, err := unitUnderTest(rebuiltNatsClaims)
 			require.NoError(t, err)
@@ -112,8 +126,9 @@ func Test_AccountClaims_addExportGroup_ShouldNotAlterExistingRulesOnConflict(t *
 		Name: "initial",
 		Exports: nauth.Exports{
 			{
-				Subject: "foo.>",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "foo.>",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 		}}))
 
@@ -122,12 +137,14 @@ func Test_AccountClaims_addExportGroup_ShouldNotAlterExistingRulesOnConflict(t *
 		Name: "conflicting",
 		Exports: nauth.Exports{
 			{
-				Subject: "bar.>",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "bar.>",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 			{
-				Subject: "foo.*",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "foo.*",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 		}})
 
@@ -142,6 +159,103 @@ func Test_AccountClaims_addExportGroup_ShouldNotAlterExistingRulesOnConflict(t *
 	require.Equal(t, expected, builder.claim.Exports)
 }
 
+func Test_AccountClaims_addExportGroup_ShouldMapAllExportFields(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder(testClaimsAccountPubKey, nil)
+	export := &nauth.Export{
+		Name:                 "orders",
+		Subject:              "orders.*.get",
+		Type:                 nauth.ExportTypeService,
+		AllowWildcard:        true,
+		TokenReq:             true,
+		Revocations:          nauth.RevocationList{"*": 1700000000},
+		ResponseType:         nauth.ResponseTypeStream,
+		ResponseThreshold:    3 * time.Second,
+		Latency:              &nauth.ServiceLatency{Sampling: 50, Results: "latency.orders"},
+		AccountTokenPosition: 2,
+		Advertise:            true,
+		AllowTrace:           true,
+	}
+
+	// When
+	err := builder.addExportGroup(nauth.ExportGroup{Name: "orders", Exports: nauth.Exports{export}})
+
+	// Then
+	require.NoError(t, err)
+	expected := jwt.Exports{
+		{
+			Name:                 "orders",
+			Subject:              "orders.*.get",
+			Type:                 jwt.Service,
+			TokenReq:             true,
+			Revocations:          jwt.RevocationList{"*": 1700000000},
+			ResponseType:         jwt.ResponseTypeStream,
+			ResponseThreshold:    3 * time.Second,
+			Latency:              &jwt.ServiceLatency{Sampling: 50, Results: "latency.orders"},
+			AccountTokenPosition: 2,
+			Advertise:            true,
+			AllowTrace:           true,
+		},
+	}
+	require.Equal(t, expected, builder.claim.Exports)
+
+	roundTrip, err := toNAuthExport(*builder.claim.Exports[0])
+	require.NoError(t, err)
+	require.Equal(t, export, roundTrip)
+}
+
+func Test_AccountClaims_addImportGroup_ShouldMapAllImportFields(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder(testClaimsAccountPubKey, nil)
+	imports := nauth.Imports{
+		{
+			AccountID:    testClaimsSigningKey01,
+			Name:         "orders",
+			Subject:      "orders.get",
+			LocalSubject: "remote.orders.get",
+			Type:         nauth.ExportTypeService,
+			Share:        true,
+		},
+		{
+			AccountID:  testClaimsSigningKey01,
+			Name:       "events",
+			Subject:    "events.>",
+			Type:       nauth.ExportTypeStream,
+			AllowTrace: true,
+		},
+	}
+
+	// When
+	err := builder.addImportGroup(nauth.ImportGroup{Name: "orders", Imports: imports})
+
+	// Then
+	require.NoError(t, err)
+	expected := jwt.Imports{
+		{
+			Account:      testClaimsSigningKey01,
+			Name:         "orders",
+			Subject:      "orders.get",
+			LocalSubject: "remote.orders.get",
+			Type:         jwt.Service,
+			Share:        true,
+		},
+		{
+			Account:    testClaimsSigningKey01,
+			Name:       "events",
+			Subject:    "events.>",
+			Type:       jwt.Stream,
+			AllowTrace: true,
+		},
+	}
+	require.Equal(t, expected, builder.claim.Imports)
+
+	for i, imp := range builder.claim.Imports {
+		roundTrip, err := toNAuthImport(*imp)
+		require.NoError(t, err)
+		require.Equal(t, imports[i], roundTrip)
+	}
+}
+
 func Test_AccountClaims_convertNatsAccountClaims_ShouldSucceed_WhenMinimal(t *testing.T) {
 	// Given
 	claims := jwt.NewAccountClaims("ACCID")
@@ -216,20 +330,248 @@ func Test_AccountClaims_builder_ShouldReturnErrorWhenJetStreamEnablementConflict
 	require.Nil(t, claims)
 }
 
+func Test_AccountClaims_builder_ShouldMergeLimitDefaults(t *testing.T) {
+	// Given
+	var defaultStreams, defaultConsumer, defaultConn, defaultSubs int64 = 10, 100, 50, 1000
+	var streams, conn int64 = 20, 5
+	defaults := &nauth.AccountLimitDefaults{
+		AccountLimits:   &nauth.AccountLimits{Conn: &defaultConn},
+		JetStreamLimits: &nauth.JetStreamLimits{Streams: &defaultStreams, Consumer: &defaultConsumer},
+		NatsLimits:      &nauth.NatsLimits{Subs: &defaultSubs},
+	}
+
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		limitDefaults(defaults).
+		accountLimits(&nauth.AccountLimits{Conn: &conn}).
+		jetStreamLimits(&nauth.JetStreamLimits{Streams: &streams})
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, conn, claims.Limits.Conn)
+	assert.Equal(t, streams, claims.Limits.Streams)
+	assert.Equal(t, defaultConsumer, claims.Limits.Consumer)
+	assert.Equal(t, int64(jwt.NoLimit), claims.Limits.DiskStorage)
+	assert.Equal(t, defaultSubs, claims.Limits.Subs)
+}
+
+func Test_AccountClaims_builder_ShouldSkipJetStreamLimitDefaults_WhenJetStreamDisabled(t *testing.T) {
+	// Given
+	var defaultStreams, defaultDiskStorage int64 = 10, 1024
+	jetStreamEnabled := false
+
+	builder := newAccountClaimsBuilder("ACCID", &jetStreamEnabled).
+		limitDefaults(&nauth.AccountLimitDefaults{
+			JetStreamLimits: &nauth.JetStreamLimits{Streams: &defaultStreams, DiskStorage: &defaultDiskStorage},
+		})
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, claims.Limits.IsJSEnabled())
+	assert.Zero(t, claims.Limits.Streams)
+}
+
+func Test_AccountClaims_builder_ShouldReplaceJetStreamLimitDefaults_WhenTiered(t *testing.T) {
+	// Given
+	var defaultStreams, tierStreams int64 = 10, 3
+
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		limitDefaults(&nauth.AccountLimitDefaults{JetStreamLimits: &nauth.JetStreamLimits{Streams: &defaultStreams}}).
+		jetStreamTieredLimits(nauth.JetStreamTieredLimits{"R3": {Streams: &tierStreams}})
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.NoError(t, err)
+	assert.Zero(t, claims.Limits.Streams)
+	assert.Equal(t, tierStreams, claims.Limits.JetStreamTieredLimits["R3"].Streams)
+}
+
+func Test_AccountClaims_builder_ShouldKeepObservedLimits(t *testing.T) {
+	// Given
+	var liveSubs, subs int64 = 100, 10
+	live, err := newAccountClaimsBuilder("ACCID", nil).
+		natsLimits(&nauth.NatsLimits{Subs: &liveSubs}).
+		mappings(nauth.SubjectMappings{{Subject: "orders", Destinations: []nauth.WeightedDestination{{Subject: "orders.v2"}}}}).
+		build()
+	require.NoError(t, err)
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		natsLimits(&nauth.NatsLimits{Subs: &subs}).
+		authorization(true, nil).
+		observed([]nauth.AccountClaimField{nauth.AccountClaimLimits}, live)
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, liveSubs, claims.Limits.Subs)
+	require.True(t, claims.Limits.DisallowBearer, "bearer JWTs must stay managed")
+	require.Empty(t, claims.Mappings, "mappings must stay managed")
+}
+
+func Test_AccountClaims_builder_ShouldKeepDefaults_WhenObservedClaimsNotInNats(t *testing.T) {
+	// Given
+	var subs int64 = 10
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		limitDefaults(&nauth.AccountLimitDefaults{NatsLimits: &nauth.NatsLimits{Subs: &subs}}).
+		observed([]nauth.AccountClaimField{nauth.AccountClaimLimits, nauth.AccountClaimExports}, nil)
+
+	// When
+	err := builder.addExportGroup(nauth.ExportGroup{
+		Ref:     "export-group",
+		Exports: nauth.Exports{{Subject: "orders.created", Type: nauth.ExportTypeStream}},
+	})
+	claims, buildErr := builder.build()
+
+	// Then
+	require.ErrorIs(t, err, domain.ErrBadRequest)
+	require.ErrorContains(t, err, "exports of the account are observed")
+	require.NoError(t, buildErr)
+	require.Equal(t, subs, claims.Limits.Subs)
+	require.Empty(t, claims.Exports)
+}
+
+func Test_AccountClaims_builder_ShouldReturnErrorWhenMappingWeightsExceed100(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		mappings(nauth.SubjectMappings{{
+			Subject: "orders.*",
+			Destinations: []nauth.WeightedDestination{
+				{Subject: "orders.v1.{{wildcard(1)}}", Weight: 90},
+				{Subject: "orders.v2.{{wildcard(1)}}", Weight: 20},
+			},
+		}})
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.ErrorContains(t, err, `Mapping "orders.*" exceeds 100%`)
+	require.Nil(t, claims)
+}
+
+func Test_AccountClaims_builder_ShouldReturnErrorWhenInfoURLInvalid(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		info("", "::not a url", nil)
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.ErrorContains(t, err, "error parsing info url")
+	require.Nil(t, claims)
+}
+
+func Test_AccountClaims_builder_ShouldRoundTripAuthorization(t *testing.T) {
+	// Given
+	defaultPermissions := &nauth.Permissions{
+		Pub:  nauth.Permission{Allow: []nauth.Subject{"orders.>"}},
+		Sub:  nauth.Permission{Allow: []nauth.Subject{"orders.>", "_INBOX.>"}, Deny: []nauth.Subject{"orders.internal.>"}},
+		Resp: &nauth.ResponsePermission{MaxMsgs: 1, Expires: time.Minute},
+	}
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		authorization(true, defaultPermissions)
+
+	// When
+	claims, err := builder.build()
+	require.NoError(t, err)
+	result, err := convertNatsAccountClaims(claims)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, claims.Limits.DisallowBearer)
+	require.True(t, result.DisallowBearer)
+	require.Equal(t, defaultPermissions, result.DefaultPermissions)
+}
+
+func Test_AccountClaims_builder_ShouldReturnErrorWhenDefaultPermissionsInvalid(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		authorization(false, &nauth.Permissions{Pub: nauth.Permission{Allow: []nauth.Subject{"orders. >"}}})
+
+	// When
+	claims, err := builder.build()
+
+	// Then
+	require.ErrorContains(t, err, "invalid default permissions")
+	require.Nil(t, claims)
+}
+
+func Test_AccountClaims_builder_ShouldRoundTripTraceAndClusterTraffic(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder("ACCID", nil).
+		trace(&nauth.MessageTrace{Destination: "traces.orders"}).
+		clusterTraffic(jwt.ClusterTrafficOwner)
+
+	// When
+	claims, err := builder.build()
+	require.NoError(t, err)
+	result, err := convertNatsAccountClaims(claims)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, &jwt.MsgTrace{Destination: "traces.orders", Sampling: 100}, claims.Trace)
+	require.Equal(t, jwt.ClusterTraffic(jwt.ClusterTrafficOwner), claims.ClusterTraffic)
+	require.Equal(t, &nauth.MessageTrace{Destination: "traces.orders", Sampling: 100}, result.Trace)
+	require.Equal(t, jwt.ClusterTrafficOwner, result.ClusterTraffic)
+}
+
+func Test_AccountClaims_builder_ShouldReturnErrorWhenTraceOrClusterTrafficInvalid(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trace          *nauth.MessageTrace
+		clusterTraffic string
+		expectErr      string
+	}{
+		{name: "wildcard destination", trace: &nauth.MessageTrace{Destination: "traces.>"},
+			expectErr: `destination "traces.>" contains wildcards`},
+		{name: "sampling out of range", trace: &nauth.MessageTrace{Destination: "traces", Sampling: 101},
+			expectErr: "sampling 101 is not between 1 and 100"},
+		{name: "unknown cluster traffic", clusterTraffic: "leaf",
+			expectErr: `unknown cluster traffic option: "leaf"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			builder := newAccountClaimsBuilder("ACCID", nil).
+				trace(tc.trace).
+				clusterTraffic(tc.clusterTraffic)
+
+			// When
+			claims, err := builder.build()
+
+			// Then
+			require.ErrorContains(t, err, tc.expectErr)
+			require.Nil(t, claims)
+		})
+	}
+}
+
 func Test_validateExports_ShouldReturnErrorWhenDuplicatesProvided(t *testing.T) {
 	// Given
 	exports := nauth.Exports{
 		{
-			Subject: "foo.*",
-			Type:    nauth.ExportTypeStream,
+			Subject:       "foo.*",
+			Type:          nauth.ExportTypeStream,
+			AllowWildcard: true,
 		},
 		{
-			Subject: "bar.>",
-			Type:    nauth.ExportTypeStream,
+			Subject:       "bar.>",
+			Type:          nauth.ExportTypeStream,
+			AllowWildcard: true,
 		},
 		{
-			Subject: "foo.*",
-			Type:    nauth.ExportTypeStream,
+			Subject:       "foo.*",
+			Type:          nauth.ExportTypeStream,
+			AllowWildcard: true,
 		},
 	}
 
@@ -248,8 +590,9 @@ func Test_addExportGroup_ShouldSucceed_WhenDuplicatesProvided(t *testing.T) {
 		Name: "initial",
 		Exports: nauth.Exports{
 			{
-				Subject: "foo.>",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "foo.>",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 		}}))
 
@@ -258,16 +601,19 @@ func Test_addExportGroup_ShouldSucceed_WhenDuplicatesProvided(t *testing.T) {
 		Name: "conflicting",
 		Exports: nauth.Exports{
 			{
-				Subject: "bar.>",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "bar.>",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 			{
-				Subject: "foo.>",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "foo.>",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 			{
-				Subject: "baz.>",
-				Type:    nauth.ExportTypeStream,
+				Subject:       "baz.>",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
 			},
 		}})
 
@@ -570,3 +916,114 @@ func normalizeClaimsForApproval(claims *jwt.AccountClaims) *jwt.AccountClaims {
 	result.ID = "TEST-JWT-ID-STATIC-FOR-APPROVAL-TESTS"
 	return result
 }
+
+func Test_addExportGroup_ShouldFail_WhenExportsOfGroupsOverlap(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder(testClaimsAccountPubKey, nil)
+	require.NoError(t, builder.addExportGroup(nauth.ExportGroup{
+		Name: "initial",
+		Exports: nauth.Exports{
+			{
+				Subject:       "foo.*.baz",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
+			},
+		}}))
+
+	// When
+	err := builder.addExportGroup(nauth.ExportGroup{
+		Name: "overlapping",
+		Exports: nauth.Exports{
+			{
+				Subject:       "foo.bar.*",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
+			},
+		}})
+
+	// Then
+	require.EqualError(t, err, "export subject \"foo.bar.*\" overlaps export subject \"foo.*.baz\"")
+	require.Len(t, builder.claim.Exports, 1)
+}
+
+func Test_addExportGroup_ShouldNormalizeSubjects(t *testing.T) {
+	// Given
+	builder := newAccountClaimsBuilder(testClaimsAccountPubKey, nil)
+
+	// When
+	err := builder.addExportGroup(nauth.ExportGroup{
+		Name: "padded",
+		Exports: nauth.Exports{
+			{
+				Subject:       " foo.> ",
+				Type:          nauth.ExportTypeStream,
+				AllowWildcard: true,
+			},
+		}})
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, jwt.Subject("foo.>"), builder.claim.Exports[0].Subject)
+}
+
+func Test_addExportGroup_ShouldFail_WhenWildcardIsNotAllowed(t *testing.T) {
+	testCases := []struct {
+		name            string
+		allowWildcard   bool
+		wildcardExports bool
+		expectedError   string
+	}{
+		{
+			name:            "not_acknowledged",
+			wildcardExports: true,
+			expectedError:   "wildcard subject \"foo.>\" requires allowWildcard",
+		},
+		{
+			name:            "not_allowed_by_limits",
+			allowWildcard:   true,
+			wildcardExports: false,
+			expectedError:   "wildcard subject \"foo.>\" is not allowed by account limit wildcards",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			builder := newAccountClaimsBuilder(testClaimsAccountPubKey, nil).
+				accountLimits(&nauth.AccountLimits{WildcardExports: &tc.wildcardExports})
+
+			// When
+			err := builder.addExportGroup(nauth.ExportGroup{
+				Name: "wildcard",
+				Exports: nauth.Exports{
+					{
+						Subject:       "foo.>",
+						Type:          nauth.ExportTypeStream,
+						AllowWildcard: tc.allowWildcard,
+					},
+				}})
+
+			// Then
+			require.ErrorIs(t, err, domain.ErrBadRequest)
+			require.ErrorContains(t, err, tc.expectedError)
+			require.Empty(t, builder.claim.Exports)
+		})
+	}
+}
+
+func Test_validateExports_ShouldFail_WhenWildcardIsMisplaced(t *testing.T) {
+	// Given
+	exports := nauth.Exports{
+		{
+			Subject:       "foo.>.bar",
+			Type:          nauth.ExportTypeStream,
+			AllowWildcard: true,
+		},
+	}
+
+	// When
+	err := validateExports(exports)
+
+	// Then
+	require.ErrorContains(t, err, "subject \"foo.>.bar\" can only have \">\" as its last token")
+}
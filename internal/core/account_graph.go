@@ -0,0 +1,108 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+)
+
+// AccountGraphManager describes the import/export dependencies between the managed accounts, from the claims in their
+// status, to assess the blast radius of changing or deleting an export.
+type AccountGraphManager struct {
+	accountLister outbound.AccountLister
+}
+
+func NewAccountGraphManager(accountLister outbound.AccountLister) (*AccountGraphManager, error) {
+	if accountLister == nil {
+		return nil, errors.New("accountLister is required")
+	}
+	return &AccountGraphManager{accountLister: accountLister}, nil
+}
+
+// GetGraph returns the graph of the Accounts in all namespaces. Accounts without an account ID are not in NATS yet
+// and left out.
+func (m *AccountGraphManager) GetGraph(ctx context.Context) (*nauth.AccountGraph, error) {
+	accounts, err := m.accountLister.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	return buildAccountGraph(accounts), nil
+}
+
+func buildAccountGraph(accounts []v1alpha1.Account) *nauth.AccountGraph {
+	graph := &nauth.AccountGraph{
+		Accounts: []nauth.AccountGraphNode{},
+		Imports:  []nauth.AccountGraphImport{},
+	}
+	for _, account := range accounts {
+		accountID := account.GetLabel(v1alpha1.AccountLabelAccountID)
+		if accountID == "" {
+			continue
+		}
+		node := nauth.AccountGraphNode{
+			AccountID: accountID,
+			Namespace: account.Namespace,
+			Name:      account.Name,
+		}
+		if claims := account.Status.Claims; claims != nil {
+			for _, export := range claims.Exports {
+				if export != nil {
+					node.Exports = append(node.Exports, nauth.AccountGraphExport{
+						Subject: nauth.Subject(export.Subject),
+						Type:    nauth.ExportType(export.Type),
+					})
+				}
+			}
+			for _, imp := range claims.Imports {
+				if imp != nil {
+					graph.Imports = append(graph.Imports, nauth.AccountGraphImport{
+						Importer:     accountID,
+						Exporter:     imp.Account,
+						Subject:      nauth.Subject(imp.Subject),
+						LocalSubject: nauth.Subject(imp.LocalSubject),
+						Type:         nauth.ExportType(imp.Type),
+					})
+				}
+			}
+		}
+		graph.Accounts = append(graph.Accounts, node)
+	}
+
+	slices.SortFunc(graph.Accounts, func(a, b nauth.AccountGraphNode) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	exporters := make(map[string]int, len(graph.Accounts))
+	for i, node := range graph.Accounts {
+		exporters[node.AccountID] = i
+	}
+	for i := range graph.Imports {
+		imp := &graph.Imports[i]
+		exporter, found := exporters[imp.Exporter]
+		if !found {
+			continue
+		}
+		for j := range graph.Accounts[exporter].Exports {
+			export := &graph.Accounts[exporter].Exports[j]
+			if export.Type == imp.Type && jwt.Subject(imp.Subject).IsContainedIn(jwt.Subject(export.Subject)) {
+				imp.Export = export.Subject
+				export.Importers++
+				break
+			}
+		}
+	}
+	slices.SortFunc(graph.Imports, func(a, b nauth.AccountGraphImport) int {
+		return cmp.Or(cmp.Compare(a.Importer, b.Importer), cmp.Compare(a.Exporter, b.Exporter),
+			cmp.Compare(a.Subject, b.Subject))
+	})
+	return graph
+}
+
+var _ inbound.AccountGraphReader = (*AccountGraphManager)(nil)
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AccountGraphManager_GetGraph(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	accountListerMock := NewAccountListerMock()
+	orders := newTestAccount(domain.NewNamespacedName("team-a", "orders"), "ACCOUNT_ORDERS")
+	orders.Status.Claims = &v1alpha1.AccountClaims{
+		Exports: v1alpha1.Exports{
+			{Subject: "orders.>", Type: v1alpha1.Stream},
+			{Subject: "orders.api", Type: v1alpha1.Service},
+		},
+	}
+	billing := newTestAccount(domain.NewNamespacedName("team-b", "billing"), "ACCOUNT_BILLING")
+	billing.Status.Claims = &v1alpha1.AccountClaims{
+		Imports: v1alpha1.Imports{
+			{Account: "ACCOUNT_ORDERS", Subject: "orders.created", Type: v1alpha1.Stream},
+			{Account: "ACCOUNT_ORDERS", Subject: "orders.api", LocalSubject: "billing.orders", Type: v1alpha1.Service},
+			{Account: "ACCOUNT_EXTERNAL", Subject: "partner.>", Type: v1alpha1.Stream},
+		},
+	}
+	accountListerMock.mockList(ctx, "", []v1alpha1.Account{
+		billing,
+		orders,
+		newTestAccount(domain.NewNamespacedName("team-a", "pending"), ""),
+	})
+	unitUnderTest, err := NewAccountGraphManager(accountListerMock)
+	require.NoError(t, err)
+
+	// When
+	graph, err := unitUnderTest.GetGraph(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []nauth.AccountGraphNode{
+		{
+			AccountID: "ACCOUNT_ORDERS",
+			Namespace: "team-a",
+			Name:      "orders",
+			Exports: []nauth.AccountGraphExport{
+				{Subject: "orders.>", Type: nauth.ExportTypeStream, Importers: 1},
+				{Subject: "orders.api", Type: nauth.ExportTypeService, Importers: 1},
+			},
+		},
+		{AccountID: "ACCOUNT_BILLING", Namespace: "team-b", Name: "billing"},
+	}, graph.Accounts)
+	assert.Equal(t, []nauth.AccountGraphImport{
+		{Importer: "ACCOUNT_BILLING", Exporter: "ACCOUNT_EXTERNAL", Subject: "partner.>", Type: nauth.ExportTypeStream},
+		{Importer: "ACCOUNT_BILLING", Exporter: "ACCOUNT_ORDERS", Subject: "orders.api", LocalSubject: "billing.orders",
+			Type: nauth.ExportTypeService, Export: "orders.api"},
+		{Importer: "ACCOUNT_BILLING", Exporter: "ACCOUNT_ORDERS", Subject: "orders.created", Type: nauth.ExportTypeStream,
+			Export: "orders.>"},
+	}, graph.Imports)
+}
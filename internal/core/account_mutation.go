@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/nats-io/jwt/v2"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// mutateAccountClaims passes a copy of claims to the claims mutation hook and returns the claims it returned, along
+// with the claim fields it changed. claims are returned as is when no hook is configured. The hook may not change the
+// subject or the signing keys of the account, and the claims it returns must pass the validation of the claims built
+// from Accounts, otherwise domain.ErrInvalidMutatedClaims is returned.
+func (a *AccountManager) mutateAccountClaims(ctx context.Context, accountRef domain.NamespacedName, claims *jwt.AccountClaims) (*jwt.AccountClaims, []string, error) {
+	if a.claimsMutator == nil {
+		return claims, nil, nil
+	}
+	claimsCopy, err := copyAccountClaims(claims)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to copy account claims: %w", err)
+	}
+	mutatedClaims, err := a.claimsMutator.MutateAccountClaims(ctx, accountRef, claimsCopy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to mutate account claims: %w", err)
+	}
+	if err = validateMutatedAccountClaims(claims, mutatedClaims); err != nil {
+		return nil, nil, domain.ErrInvalidMutatedClaims.WithCause(
+			fmt.Errorf("claims mutation hook returned invalid account claims: %w", err))
+	}
+	changes := accountClaimsFieldChanges(claims, mutatedClaims)
+	if len(changes) > 0 {
+		logf.FromContext(ctx).V(1).Info("Claims mutation hook changed account claims",
+			"accountID", claims.Subject, "changes", changes)
+	}
+	return mutatedClaims, changes, nil
+}
+
+func copyAccountClaims(claims *jwt.AccountClaims) (*jwt.AccountClaims, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	claimsCopy := &jwt.AccountClaims{}
+	if err = json.Unmarshal(data, claimsCopy); err != nil {
+		return nil, err
+	}
+	return claimsCopy, nil
+}
+
+func validateMutatedAccountClaims(claims *jwt.AccountClaims, mutatedClaims *jwt.AccountClaims) error {
+	if mutatedClaims == nil {
+		return errors.New("no claims returned")
+	}
+	if mutatedClaims.Subject != claims.Subject {
+		return fmt.Errorf("subject changed from %s to %s", claims.Subject, mutatedClaims.Subject)
+	}
+	if !reflect.DeepEqual(mutatedClaims.SigningKeys, claims.SigningKeys) {
+		return fmt.Errorf("signing keys changed from %v to %v", claims.SigningKeys.Keys(), mutatedClaims.SigningKeys.Keys())
+	}
+
+	valResults := &jwt.ValidationResults{}
+	mutatedClaims.Validate(valResults)
+	if valResults.IsBlocking(false) {
+		return errors.Join(valResults.Errors()...)
+	}
+	var errs []error
+	if err := validateJWTExports(mutatedClaims.Exports); err != nil {
+		errs = append(errs, fmt.Errorf("invalid exports: %w", err))
+	}
+	if err := validateJWTImports(mutatedClaims.Subject, mutatedClaims.Imports); err != nil {
+		errs = append(errs, fmt.Errorf("invalid imports: %w", err))
+	}
+	if err := validateJWTMappings(mutatedClaims.Mappings); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateJWTInfo(mutatedClaims.Info); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateJWTPermissions(mutatedClaims.DefaultPermissions); err != nil {
+		errs = append(errs, fmt.Errorf("invalid default permissions: %w", err))
+	}
+	if err := validateJWTTrace(mutatedClaims.Trace); err != nil {
+		errs = append(errs, err)
+	}
+	if err := mutatedClaims.ClusterTraffic.Valid(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
@@ -12,7 +14,9 @@ import (
 	approvals "github.com/approvals/go-approval-tests"
 	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"sigs.k8s.io/yaml"
 )
@@ -32,6 +36,7 @@ type AccountManagerTestSuite struct {
 	natsAccClientMock   *NatsAccountClientMock
 	natsAccConnMock     *NatsAccConnectionMock
 	secretManagerMock   *secretManagerMock
+	auditRecorderFake   *AuditRecorderFake
 
 	unitUnderTest *AccountManager
 }
@@ -58,6 +63,7 @@ func (t *AccountManagerTestSuite) SetupTest() {
 	t.natsSysConnMock = NewNatsSysConnectionMock()
 	t.natsAccClientMock = NewNatsAccountClientMock()
 	t.natsAccConnMock = NewNatsAccountConnectionMock()
+	t.auditRecorderFake = NewAuditRecorderFake()
 
 	var err error
 	t.unitUnderTest, err = newAccountManager(
@@ -65,6 +71,9 @@ func (t *AccountManagerTestSuite) SetupTest() {
 		t.natsAccClientMock,
 		t.accountIDReaderMock,
 		t.secretManagerMock,
+		t.auditRecorderFake,
+		nil,
+		&Config{CryptoPolicy: CryptoPolicyDefault},
 	)
 	t.NoError(err)
 }
@@ -112,6 +121,9 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed() {
 	var natsLimitsSubs int64 = 100
 
 	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, "")
+	t.secretManagerMock.mockGetPendingSecretsMissing(t.ctx, accountRef)
+	t.secretManagerMock.mockApplyPendingSecretsUnknown(t.ctx, accountRef)
+	t.secretManagerMock.mockDeletePendingSecrets(t.ctx, accountRef)
 	t.secretManagerMock.mockApplyRootSecretUnknown(t.ctx, accountRef, func(rootKeyPair nkeys.KeyPair) {
 		caughtRootKeyPair = rootKeyPair
 	})
@@ -140,6 +152,26 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed() {
 
 	t.Equal(result.AccountID, caughtSignAccountID)
 	t.Equal(natsLimitsSubs, jwtClaims.Limits.Subs)
+	t.True(result.SigningKeyCreated)
+	t.True(result.JWTPushed)
+
+	operatorSigningPublicKey, _ := t.clusterTarget.OperatorSigningKey.PublicKey()
+	signingPublicKey, _ := caughtSignKeyPair.PublicKey()
+	t.Equal([]domain.AuditEvent{
+		{
+			Action:   domain.AuditActionAccountKeysCreated,
+			Resource: domain.AuditResource{Kind: "Account", Namespace: "account-namespace", Name: "account-name"},
+			Subject:  result.AccountID,
+			Keys:     []string{result.AccountID, signingPublicKey},
+		},
+		{
+			Action:     domain.AuditActionAccountJWTPushed,
+			Resource:   domain.AuditResource{Kind: "Account", Namespace: "account-namespace", Name: "account-name"},
+			Subject:    result.AccountID,
+			Issuer:     operatorSigningPublicKey,
+			ClaimsHash: result.ClaimsHash,
+		},
+	}, t.auditRecorderFake.events)
 }
 
 func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed_WhenAccountExplicitCluster() {
@@ -155,6 +187,9 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed_WhenAccountExplicitC
 	natsLimitsSubs := int64(100)
 
 	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, "")
+	t.secretManagerMock.mockGetPendingSecretsMissing(t.ctx, accountRef)
+	t.secretManagerMock.mockApplyPendingSecretsUnknown(t.ctx, accountRef)
+	t.secretManagerMock.mockDeletePendingSecrets(t.ctx, accountRef)
 	t.secretManagerMock.mockApplyRootSecretUnknown(t.ctx, accountRef, func(rootKeyPair nkeys.KeyPair) {
 		caughtRootKeyPair = rootKeyPair
 	})
@@ -185,6 +220,186 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed_WhenAccountExplicitC
 	t.Equal(natsLimitsSubs, jwtClaims.Limits.Subs)
 }
 
+func (t *AccountManagerTestSuite) Test_Create_ShouldResumeWithPendingSecrets() {
+	// Given
+	var (
+		caughtAccountJWT    string
+		caughtRootKeyPair   nkeys.KeyPair
+		caughtSignAccountID string
+	)
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, "")
+	t.secretManagerMock.mockGetPendingSecrets(t.ctx, accountRef, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.secretManagerMock.mockApplyRootSecretUnknown(t.ctx, accountRef, func(rootKeyPair nkeys.KeyPair) {
+		caughtRootKeyPair = rootKeyPair
+	})
+	t.secretManagerMock.mockApplySignSecretUnknown(t.ctx, accountRef, func(accountID string, _ nkeys.KeyPair) {
+		caughtSignAccountID = accountID
+	})
+	t.secretManagerMock.mockDeletePendingSecrets(t.ctx, accountRef)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.verifyAccountResult(result, caughtAccountJWT, testutil.NatsTestAccountA.Root.Key, testutil.NatsTestAccountA.Sign.Key)
+	t.Equal(testutil.NatsTestAccountA.Root.Key, caughtRootKeyPair)
+	t.Equal(testutil.NatsTestAccountA.AccountID(), caughtSignAccountID)
+	t.secretManagerMock.AssertNotCalled(t.T(), "ApplyPendingSecrets", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_Create_ShouldUseProvidedSecrets_WhenKeySecretsSet() {
+	// Given
+	var (
+		caughtAccountJWT    string
+		caughtRootKeyPair   nkeys.KeyPair
+		caughtSignAccountID string
+		caughtSignKeyPair   nkeys.KeyPair
+	)
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	keySecrets := nauth.AccountKeySecrets{
+		Root:    nauth.SecretKeyRef{Name: "hsm-account-root"},
+		Signing: nauth.SecretKeyRef{Name: "hsm-account-signing", Key: "seed"},
+	}
+
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, "")
+	t.secretManagerMock.mockGetProvidedSecrets(t.ctx, accountRef, keySecrets, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.secretManagerMock.mockFindAccountRefMissing(t.ctx, testutil.NatsTestAccountA.AccountID())
+	t.secretManagerMock.mockApplyRootSecretUnknown(t.ctx, accountRef, func(rootKeyPair nkeys.KeyPair) {
+		caughtRootKeyPair = rootKeyPair
+	})
+	t.secretManagerMock.mockApplySignSecretUnknown(t.ctx, accountRef, func(accountID string, signKeyPair nkeys.KeyPair) {
+		caughtSignAccountID = accountID
+		caughtSignKeyPair = signKeyPair
+	})
+	t.secretManagerMock.mockDeletePendingSecrets(t.ctx, accountRef)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		ClusterTarget: t.clusterTarget,
+		KeySecrets:    &keySecrets,
+	})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.verifyAccountResult(result, caughtAccountJWT, testutil.NatsTestAccountA.Root.Key, testutil.NatsTestAccountA.Sign.Key)
+	t.Equal(testutil.NatsTestAccountA.Root.Key, caughtRootKeyPair)
+	t.Equal(testutil.NatsTestAccountA.AccountID(), caughtSignAccountID)
+	t.Equal(testutil.NatsTestAccountA.Sign.Key, caughtSignKeyPair)
+	t.Equal([]domain.AuditAction{domain.AuditActionAccountKeysProvided, domain.AuditActionAccountJWTPushed},
+		t.auditRecorderFake.actions())
+	t.secretManagerMock.AssertNotCalled(t.T(), "GetPendingSecrets", mock.Anything, mock.Anything)
+	t.secretManagerMock.AssertNotCalled(t.T(), "ApplyPendingSecrets", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_Create_ShouldFail_WhenProvidedRootKeyDoesNotMatchAccountID() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.AnyNatsTestAccountID()
+	keySecrets := nauth.AccountKeySecrets{
+		Root:    nauth.SecretKeyRef{Name: "hsm-account-root"},
+		Signing: nauth.SecretKeyRef{Name: "hsm-account-signing"},
+	}
+
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, accountID)
+	t.secretManagerMock.mockGetProvidedSecrets(t.ctx, accountRef, keySecrets, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+		KeySecrets:    &keySecrets,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrInvalidConfig)
+	t.ErrorContains(err, "does not match account ID")
+	t.Nil(result)
+	t.secretManagerMock.AssertNotCalled(t.T(), "ApplyRootSecret", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_Create_ShouldFail_WhenProvidedKeysAreStoredForAnotherAccount() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	keySecrets := nauth.AccountKeySecrets{
+		Root:    nauth.SecretKeyRef{Name: "hsm-account-root"},
+		Signing: nauth.SecretKeyRef{Name: "hsm-account-signing"},
+	}
+
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, "")
+	t.secretManagerMock.mockGetProvidedSecrets(t.ctx, accountRef, keySecrets, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.secretManagerMock.mockFindAccountRef(t.ctx, testutil.NatsTestAccountA.AccountID(),
+		domain.NewNamespacedName("other-namespace", "other-account"))
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		ClusterTarget: t.clusterTarget,
+		KeySecrets:    &keySecrets,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrInvalidConfig)
+	t.ErrorContains(err, "already stored for Account other-namespace/other-account")
+	t.Nil(result)
+	t.secretManagerMock.AssertNotCalled(t.T(), "ApplyRootSecret", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_Create_ShouldKeepPendingSecrets_WhenApplyingSecretsFails() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	var pendingSecrets *Secrets
+
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, "")
+	t.secretManagerMock.mockGetPendingSecretsMissing(t.ctx, accountRef)
+	t.secretManagerMock.On("ApplyPendingSecrets", t.ctx, accountRef, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) { pendingSecrets = args.Get(2).(*Secrets) })
+	t.secretManagerMock.mockApplyRootSecretUnknown(t.ctx, accountRef, nil)
+	t.secretManagerMock.On("ApplySignSecret", t.ctx, accountRef, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("API server unavailable"))
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorContains(err, "failed to apply account signing secret")
+	t.Nil(result)
+	t.Require().NotNil(pendingSecrets)
+	t.secretManagerMock.AssertCalled(t.T(), "ApplyRootSecret", t.ctx, accountRef, pendingSecrets.Root)
+	t.secretManagerMock.AssertNotCalled(t.T(), "DeletePendingSecrets", mock.Anything, mock.Anything)
+}
+
 func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed_WhenSecretsAlreadyExist() {
 	// Given
 	var (
@@ -197,7 +412,9 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed_WhenSecretsAlreadyEx
 		Root: testutil.NatsTestAccountA.Root.Key,
 		Sign: testutil.NatsTestAccountA.Sign.Key,
 	})
+	t.secretManagerMock.mockDeletePendingSecrets(t.ctx, accountRef)
 	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
 	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
 	t.natsSysConnMock.mockDisconnect()
 
@@ -217,6 +434,8 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldSucceed_WhenSecretsAlreadyEx
 	jwtClaims := t.verifyAccountResult(result, caughtAccountJWT, testutil.NatsTestAccountA.Root.Key, testutil.NatsTestAccountA.Sign.Key)
 
 	t.Equal(natsLimitsSubs, jwtClaims.Limits.Subs)
+	t.False(result.SigningKeyCreated)
+	t.True(result.JWTPushed)
 }
 
 func (t *AccountManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_Adoptions() {
@@ -238,8 +457,10 @@ func (t *AccountManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_Adoptions()
 				Root: testutil.NatsTestAccountA.Root.Key,
 				Sign: testutil.NatsTestAccountA.Sign.Key,
 			})
+			t.secretManagerMock.mockDeletePendingSecrets(t.ctx, input.AccountRef)
 			t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
 			var caughtAccountJWT string
+			t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
 			t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
 			t.natsSysConnMock.mockDisconnect()
 
@@ -256,6 +477,8 @@ func (t *AccountManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_Adoptions()
 			t.NotEmpty(result.ClaimsHash)
 			t.verifyAccountResult(result, caughtAccountJWT, testutil.NatsTestAccountA.Root.Key, testutil.NatsTestAccountA.Sign.Key)
 
+			// The JWT ID and issue time change with every signing
+			result.JWT = nil
 			resultYaml, err := yaml.Marshal(result)
 			t.Require().NoError(err)
 			approvals.VerifyString(t.T(), string(resultYaml), approvalOptionsForTestSuite(&t.Suite).
@@ -282,6 +505,52 @@ func (t *AccountManagerTestSuite) Test_Create_ShouldFail_WhenExistingSecretsAreI
 	t.ErrorContains(err, "root secret is malformed")
 }
 
+func (t *AccountManagerTestSuite) Test_CreateOrUpdate_ShouldFail_WhenJetStreamLimitsExceedClusterCapacity() {
+	// Given
+	clusterTarget := t.clusterTarget
+	clusterTarget.JetStreamCapacity = &nauth.JetStreamCapacity{MemoryStorage: 1024, DiskStorage: 4096}
+	var memoryStorage, diskStorage int64 = 512, 2048
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		ClusterTarget: clusterTarget,
+		JetStreamTieredLimits: nauth.JetStreamTieredLimits{
+			"R1": {MemoryStorage: &memoryStorage, DiskStorage: &diskStorage},
+			"R3": {DiskStorage: &diskStorage, MemoryStorage: &memoryStorage},
+			"R5": {DiskStorage: &diskStorage},
+		},
+	})
+
+	// Then
+	t.Nil(result)
+	t.ErrorIs(err, domain.ErrClusterCapacityExceeded)
+	t.ErrorContains(err, "JetStream disk storage of 6144 bytes exceeds the 4096 bytes of the NATS cluster")
+	t.secretManagerMock.AssertNotCalled(t.T(), "GetSecrets", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_CreateOrUpdate_ShouldFail_WhenClusterJetStreamDefaultsExceedClusterCapacity() {
+	// Given
+	clusterTarget := t.clusterTarget
+	clusterTarget.JetStreamCapacity = &nauth.JetStreamCapacity{MemoryStorage: 1024, DiskStorage: 4096}
+	var defaultDiskStorage, memoryStorage int64 = 8192, 512
+	clusterTarget.AccountDefaults = &nauth.AccountLimitDefaults{
+		JetStreamLimits: &nauth.JetStreamLimits{DiskStorage: &defaultDiskStorage},
+	}
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:      domain.NewNamespacedName("account-namespace", "account-name"),
+		ClusterTarget:   clusterTarget,
+		JetStreamLimits: &nauth.JetStreamLimits{MemoryStorage: &memoryStorage},
+	})
+
+	// Then
+	t.Nil(result)
+	t.ErrorIs(err, domain.ErrClusterCapacityExceeded)
+	t.ErrorContains(err, "JetStream disk storage of 8192 bytes exceeds the 4096 bytes of the NATS cluster")
+}
+
 func (t *AccountManagerTestSuite) Test_Update_ShouldSucceed() {
 	// Given
 	var (
@@ -295,6 +564,7 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldSucceed() {
 		Sign: testutil.NatsTestAccountA.Sign.Key,
 	})
 	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
 	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
 	t.natsSysConnMock.mockDisconnect()
 
@@ -312,6 +582,152 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldSucceed() {
 	t.verifyAccountResult(result, caughtAccountJWT, testutil.NatsTestAccountA.Root.Key, testutil.NatsTestAccountA.Sign.Key)
 }
 
+func (t *AccountManagerTestSuite) Test_Update_ShouldFail_WhenAccountJWTExceedsMaxPayload() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.maxPayload = 64
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	_, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrJWTTooLarge)
+	t.ErrorContains(err, "exceeds the 64 bytes accepted by the NATS servers")
+	t.natsSysConnMock.AssertNotCalled(t.T(), "UploadAccountJWT", mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldReportJWTSize_WhenCloseToMaxPayload() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	var caughtAccountJWT string
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.maxPayload = 1200
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(&nauth.JWTSize{Bytes: len(caughtAccountJWT), Limit: 1200}, result.JWTSize)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldSignMutatedClaims_WhenClaimsMutatorConfigured() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	var caughtAccountJWT string
+
+	t.unitUnderTest.claimsMutator = AccountClaimsMutatorFunc(func(claims *jwt.AccountClaims) *jwt.AccountClaims {
+		claims.Tags.Add("cost-center:1234")
+		claims.DefaultPermissions.Pub.Deny.Add("restricted.>")
+		return claims
+	})
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Require().NoError(err)
+	accountClaims, err := jwt.DecodeAccountClaims(caughtAccountJWT)
+	t.Require().NoError(err)
+	t.Equal(jwt.TagList{"cost-center:1234"}, accountClaims.Tags)
+	t.Equal(jwt.StringList{"restricted.>"}, accountClaims.DefaultPermissions.Pub.Deny)
+	t.Equal([]nauth.Subject{"restricted.>"}, result.Claims.DefaultPermissions.Pub.Deny)
+	t.Equal([]domain.AuditAction{domain.AuditActionAccountClaimsMutated, domain.AuditActionAccountJWTPushed},
+		t.auditRecorderFake.actions())
+	t.Equal([]string{"default_permissions", "tags"}, t.auditRecorderFake.events[0].Changes)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldFail_WhenClaimsMutatorChangesSigningKeys() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+
+	t.unitUnderTest.claimsMutator = AccountClaimsMutatorFunc(func(claims *jwt.AccountClaims) *jwt.AccountClaims {
+		claims.SigningKeys.Add(testutil.CreateNatsTestAccount().Sign.PublicKey)
+		return claims
+	})
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+
+	// When
+	_, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrInvalidMutatedClaims)
+	t.ErrorContains(err, "signing keys changed")
+	t.Empty(t.auditRecorderFake.events)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldFail_WhenClaimsMutatorReturnsInvalidClaims() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+
+	t.unitUnderTest.claimsMutator = AccountClaimsMutatorFunc(func(claims *jwt.AccountClaims) *jwt.AccountClaims {
+		claims.DefaultPermissions.Sub.Deny.Add("foo..bar")
+		return claims
+	})
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+
+	// When
+	_, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrInvalidMutatedClaims)
+	t.ErrorContains(err, "cannot contain consecutive")
+}
+
 func (t *AccountManagerTestSuite) Test_Update_ShouldSkipUpload_WhenClaimsHashUnchanged() {
 	// Given
 	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
@@ -322,6 +738,57 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldSkipUpload_WhenClaimsHashUnc
 		Sign: testutil.NatsTestAccountA.Sign.Key,
 	})
 	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
+	var pushedJWT string
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { pushedJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	initialResult, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+	t.Require().NoError(err)
+	t.Require().NotNil(initialResult)
+	t.Require().NotEmpty(initialResult.ClaimsHash)
+	t.assertAndResetAllMock()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), pushedJWT)
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(accountID),
+		ClaimsHash:    initialResult.ClaimsHash,
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.NoError(err)
+	t.NotNil(result)
+	t.Equal(initialResult.ClaimsHash, result.ClaimsHash)
+	t.True(initialResult.JWTPushed)
+	t.False(result.JWTPushed)
+	t.Nil(result.JWT, "live account JWT is only reported when uploaded or compared")
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldUpload_WhenAccountJWTDeletedFromNats() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
 	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) {})
 	t.natsSysConnMock.mockDisconnect()
 
@@ -335,23 +802,310 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldSkipUpload_WhenClaimsHashUnc
 	t.Require().NotEmpty(initialResult.ClaimsHash)
 	t.assertAndResetAllMock()
 
-	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
-		Root: testutil.NatsTestAccountA.Root.Key,
-		Sign: testutil.NatsTestAccountA.Sign.Key,
-	})
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
+	var repushedJWT string
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { repushedJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(accountID),
+		ClaimsHash:    initialResult.ClaimsHash,
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.NoError(err)
+	t.NotNil(result)
+	t.Equal(initialResult.ClaimsHash, result.ClaimsHash)
+	t.True(result.JWTPushed)
+	repushedClaims, err := jwt.DecodeAccountClaims(repushedJWT)
+	t.Require().NoError(err)
+	t.Equal(accountID, repushedClaims.Subject)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldSkipUpload_WhenNatsHasEquivalentAccountJWT() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	secrets := &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	}
+	request := nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	}
+
+	var natsAccountJWT string
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { natsAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+	_, err := t.unitUnderTest.CreateOrUpdate(t.ctx, request)
+	t.Require().NoError(err)
+	t.Require().NotEmpty(natsAccountJWT)
+	t.assertAndResetAllMock()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, natsAccountJWT)
+	t.natsSysConnMock.mockDisconnect()
+
+	// When (no claims hash in status, e.g. after a restore)
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, request)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.False(result.JWTPushed)
+	t.natsSysConnMock.AssertNotCalled(t.T(), "UploadAccountJWT", mock.Anything)
+	natsAccountClaims, err := jwt.DecodeAccountClaims(natsAccountJWT)
+	t.Require().NoError(err)
+	t.Require().NotNil(result.JWT)
+	t.Equal(natsAccountClaims.ID, result.JWT.ID, "account JWT in NATS should be described in result")
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldAuditChangedClaims() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	secrets := &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	}
+
+	var natsAccountJWT string
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { natsAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+	_, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+	t.Require().NoError(err)
+	t.Require().NotEmpty(natsAccountJWT)
+	t.assertAndResetAllMock()
+	t.auditRecorderFake.events = nil
+
+	var subs int64 = 10
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, natsAccountJWT)
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(string) {})
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+		NatsLimits:    &nauth.NatsLimits{Subs: &subs},
+	})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.Require().Len(t.auditRecorderFake.events, 1)
+	t.Equal(domain.AuditActionAccountJWTPushed, t.auditRecorderFake.events[0].Action)
+	t.Equal(result.ClaimsHash, t.auditRecorderFake.events[0].ClaimsHash)
+	t.Equal([]string{"limits"}, t.auditRecorderFake.events[0].Changes)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldKeepObservedClaims() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	secrets := &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	}
+	var liveSubs int64 = 100
+	liveClaims, err := newAccountClaimsBuilder(accountID, nil).
+		natsLimits(&nauth.NatsLimits{Subs: &liveSubs}).
+		signingKey(testutil.NatsTestAccountA.Sign.PublicKey).
+		build()
+	t.Require().NoError(err)
+	liveImport := &jwt.Import{Account: testutil.CreateNatsTestAccount().AccountID(), Subject: "orders.>", Type: jwt.Stream}
+	liveClaims.Imports.Add(liveImport)
+	liveJWT, err := liveClaims.Encode(testutil.NatsTestAccountA.Sign.Key)
+	t.Require().NoError(err)
+
+	var natsAccountJWT string
+	var subs int64 = 10
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, liveJWT)
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { natsAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:     accountRef,
+		AccountID:      nauth.AccountID(accountID),
+		ClusterTarget:  t.clusterTarget,
+		NatsLimits:     &nauth.NatsLimits{Subs: &subs},
+		ObservedClaims: []nauth.AccountClaimField{nauth.AccountClaimImports},
+		ImportGroups: nauth.ImportGroups{{
+			Ref:     "import-group",
+			Imports: nauth.Imports{{AccountID: nauth.AccountID(testutil.CreateNatsTestAccount().AccountID()), Subject: "payments.>", Type: nauth.ExportTypeStream}},
+		}},
+	})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.True(result.JWTPushed)
+	natsAccountClaims, err := jwt.DecodeAccountClaims(natsAccountJWT)
+	t.Require().NoError(err)
+	t.Equal(jwt.Imports{liveImport}, natsAccountClaims.Imports, "imports must be kept from NATS")
+	t.Equal(subs, natsAccountClaims.Limits.Subs, "limits must be managed")
+	adoption := result.Adoptions.Imports.Get("import-group")
+	t.Require().NotNil(adoption)
+	t.Equal(nauth.AdoptionFailureConflict, adoption.Failure)
+	t.Contains(adoption.Message, "imports of the account are observed")
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldDeferUpload_WhenMaintenanceWindowActive() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	secrets := &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	}
+
+	var natsAccountJWT string
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { natsAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+	_, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+	t.Require().NoError(err)
+	t.Require().NotEmpty(natsAccountJWT)
+	t.assertAndResetAllMock()
+	t.auditRecorderFake.events = nil
+
+	always, err := nauth.ParseCronSchedule("* * * * *", nil)
+	t.Require().NoError(err)
+	clusterTarget := t.clusterTarget
+	clusterTarget.MaintenanceWindows = nauth.MaintenanceWindows{{Schedule: always, Duration: time.Hour}}
+	var subs int64 = 10
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, natsAccountJWT)
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: clusterTarget,
+		NatsLimits:    &nauth.NatsLimits{Subs: &subs},
+	})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.False(result.JWTPushed)
+	t.natsSysConnMock.AssertNotCalled(t.T(), "UploadAccountJWT", mock.Anything)
+	t.Empty(t.auditRecorderFake.events)
+	t.Require().NotNil(result.PendingChanges)
+	t.Equal(result.ClaimsHash, result.PendingChanges.ClaimsHash)
+	t.Equal([]string{"limits"}, result.PendingChanges.Fields)
+	t.True(result.PendingChanges.Until.After(time.Now()))
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldUploadWithoutLookup_WhenForcePush() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	var caughtAccountJWT string
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+		ForcePush:     true,
+	})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	t.True(result.JWTPushed)
+	t.NotEmpty(caughtAccountJWT)
+	t.natsSysConnMock.AssertNotCalled(t.T(), "LookupAccountJWT", mock.Anything)
+}
+
+func (t *AccountManagerTestSuite) Test_Update_ShouldUpload_WhenForcePushAndClaimsHashUnchanged() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	secrets := &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	}
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(string) {})
+	t.natsSysConnMock.mockDisconnect()
+	initialResult, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+	t.Require().NoError(err)
+	t.Require().NotEmpty(initialResult.ClaimsHash)
+	t.assertAndResetAllMock()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	var caughtAccountJWT string
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
 
 	// When
 	result, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
-		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountRef:    accountRef,
 		AccountID:     nauth.AccountID(accountID),
 		ClaimsHash:    initialResult.ClaimsHash,
 		ClusterTarget: t.clusterTarget,
+		ForcePush:     true,
 	})
 
 	// Then
 	t.NoError(err)
-	t.NotNil(result)
+	t.Require().NotNil(result)
 	t.Equal(initialResult.ClaimsHash, result.ClaimsHash)
+	t.True(result.JWTPushed)
+	t.NotEmpty(caughtAccountJWT)
 }
 
 func (t *AccountManagerTestSuite) Test_Update_ShouldUploadNewAccountJWT_WhenOperatorSigningKeyHashChanged() {
@@ -365,6 +1119,7 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldUploadNewAccountJWT_WhenOper
 		Sign: testutil.NatsTestAccountA.Sign.Key,
 	})
 	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
 	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) {})
 	t.natsSysConnMock.mockDisconnect()
 
@@ -386,6 +1141,7 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldUploadNewAccountJWT_WhenOper
 		Sign: testutil.NatsTestAccountA.Sign.Key,
 	})
 	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(testutil.NatsTestAccountA.AccountID(), "")
 	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { caughtAccountJWT = jwt })
 	t.natsSysConnMock.mockDisconnect()
 
@@ -449,6 +1205,7 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldFail_WhenUpdatingSystemAccou
 
 	// Then
 	t.Nil(result)
+	t.ErrorIs(err, domain.ErrSystemAccountConflict)
 	t.ErrorContains(err, "reconciling system account is not supported")
 }
 
@@ -496,6 +1253,20 @@ func (t *AccountManagerTestSuite) Test_Update_ShouldFail_WhenAccountClaimsAreInv
 	t.ErrorContains(err, "overlapping subject namespace for \"foo\" and \"foo\"")
 }
 
+func (t *AccountManagerTestSuite) Test_Import_ShouldFail_WhenImportingSystemAccount() {
+	// When
+	result, err := t.unitUnderTest.Import(t.ctx, nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(t.sauCreds.AccountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Nil(result)
+	t.ErrorIs(err, domain.ErrSystemAccountConflict)
+	t.ErrorContains(err, "importing system account")
+}
+
 func (t *AccountManagerTestSuite) Test_Import_ShouldSucceed() {
 	// Given
 	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
@@ -531,6 +1302,9 @@ func (t *AccountManagerTestSuite) Test_Import_ShouldSucceed() {
 	t.Equal(account.AccountID(), result.AccountID)
 	t.Equal(account.Sign.PublicKey, result.AccountSignedBy)
 	t.Equal(existingNatsLimitsSubs, *result.Claims.NatsLimits.Subs)
+	t.Require().NotNil(result.JWT)
+	t.Equal(existingClaims.ID, result.JWT.ID)
+	t.Equal(account.Sign.PublicKey, result.JWT.Issuer)
 }
 
 func (t *AccountManagerTestSuite) Test_FindAccountID_ShouldReturnIDFromAccountSecrets() {
@@ -569,6 +1343,88 @@ func (t *AccountManagerTestSuite) Test_FindAccountID_ShouldReturnNotFoundWhenAcc
 	t.Empty(result)
 }
 
+func (t *AccountManagerTestSuite) Test_CheckSync_ShouldReportInSync_WhenNatsHasPushedClaims() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	secrets := &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	}
+
+	var natsAccountJWT string
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockUploadAccountJWTCatch(func(jwt string) { natsAccountJWT = jwt })
+	t.natsSysConnMock.mockDisconnect()
+	pushed, err := t.unitUnderTest.CreateOrUpdate(t.ctx, nauth.AccountRequest{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	})
+	t.Require().NoError(err)
+	t.assertAndResetAllMock()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, secrets)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, natsAccountJWT)
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CheckSync(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	}, pushed.ClaimsHash)
+
+	// Then
+	t.NoError(err)
+	t.Equal(nauth.AccountSyncStateInSync, result)
+}
+
+func (t *AccountManagerTestSuite) Test_CheckSync_ShouldReportDrifted_WhenNatsHasNoAccountJWT() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, accountID, &Secrets{
+		Root: testutil.NatsTestAccountA.Root.Key,
+		Sign: testutil.NatsTestAccountA.Sign.Key,
+	})
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(accountID, "")
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	result, err := t.unitUnderTest.CheckSync(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	}, "claims-hash")
+
+	// Then
+	t.NoError(err)
+	t.Equal(nauth.AccountSyncStateDrifted, result)
+}
+
+func (t *AccountManagerTestSuite) Test_CheckSync_ShouldReportMissingSecrets() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	accountID := testutil.NatsTestAccountA.AccountID()
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, accountRef, accountID)
+
+	// When
+	result, err := t.unitUnderTest.CheckSync(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(accountID),
+		ClusterTarget: t.clusterTarget,
+	}, "claims-hash")
+
+	// Then
+	t.NoError(err)
+	t.Equal(nauth.AccountSyncStateMissingSecrets, result)
+}
+
 func (t *AccountManagerTestSuite) Test_FindAccountID_ShouldFailWhenAccountSecretsAreInvalid() {
 	// Given
 	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
@@ -585,6 +1441,38 @@ func (t *AccountManagerTestSuite) Test_FindAccountID_ShouldFailWhenAccountSecret
 	t.Empty(result)
 }
 
+func (t *AccountManagerTestSuite) Test_Delete_ShouldFail_WhenDeletingSystemAccount() {
+	// When
+	err := t.unitUnderTest.Delete(t.ctx, nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(t.sauCreds.AccountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrSystemAccountConflict)
+	t.ErrorContains(err, "deleting system account")
+}
+
+func (t *AccountManagerTestSuite) Test_Delete_ShouldFail_WhenMaintenanceWindowActive() {
+	// Given
+	always, err := nauth.ParseCronSchedule("* * * * *", nil)
+	t.Require().NoError(err)
+	clusterTarget := t.clusterTarget
+	clusterTarget.MaintenanceWindows = nauth.MaintenanceWindows{{Schedule: always, Duration: time.Hour}}
+
+	// When
+	err = t.unitUnderTest.Delete(t.ctx, nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(testutil.NatsTestAccountA.AccountID()),
+		ClusterTarget: clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrMaintenanceWindow)
+	t.ErrorContains(err, "is deferred until")
+}
+
 func (t *AccountManagerTestSuite) Test_Delete_ShouldSucceed() {
 	// Given
 	var (
@@ -640,6 +1528,170 @@ func (t *AccountManagerTestSuite) Test_Delete_ShouldSucceed() {
 	deleteClaims, err := jwt.DecodeGeneric(caughtDeleteJWT)
 	t.Require().NoError(err, "failed to decode deletion JWT")
 	t.Equal([]interface{}{account.AccountID()}, deleteClaims.Data["accounts"])
+	t.Equal([]domain.AuditAction{domain.AuditActionAccountJWTDeleted, domain.AuditActionAccountKeysDeleted},
+		t.auditRecorderFake.actions())
+	t.Equal(account.AccountID(), t.auditRecorderFake.events[0].Subject)
+	t.Equal(deleteClaims.Issuer, t.auditRecorderFake.events[0].Issuer)
+}
+
+func (t *AccountManagerTestSuite) Test_Delete_ShouldFail_WhenJetStreamStreamsExist() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	account := testutil.CreateNatsTestAccount()
+
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, account.AccountID(), &Secrets{
+		Root: account.Root.Key,
+		Sign: account.Sign.Key,
+	})
+	t.natsAccClientMock.mockConnectMatchingCreds(t.natsURL, func(domain.NatsUserCreds) bool { return true }, t.natsAccConnMock).Once()
+	t.natsAccConnMock.mockListAccountStreams([]string{"orders"}).Once()
+	t.natsAccConnMock.mockDisconnect().Once()
+
+	// When
+	err := t.unitUnderTest.Delete(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrJetStreamResourcesExist)
+	t.ErrorContains(err, "orders")
+}
+
+func (t *AccountManagerTestSuite) Test_Orphan_ShouldDeleteSecretsOnly() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	account := testutil.CreateNatsTestAccount()
+	t.secretManagerMock.mockDeleteAll(t.ctx, accountRef, account.AccountID()).Once()
+
+	// When
+	err := t.unitUnderTest.Orphan(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Require().NoError(err)
+	t.natsSysClientMock.AssertNotCalled(t.T(), "Connect", mock.Anything, mock.Anything, mock.Anything)
+	t.Equal([]domain.AuditAction{domain.AuditActionAccountKeysDeleted}, t.auditRecorderFake.actions())
+}
+
+func (t *AccountManagerTestSuite) Test_Orphan_ShouldFail_WhenOrphaningSystemAccount() {
+	// When
+	err := t.unitUnderTest.Orphan(t.ctx, nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(t.sauCreds.AccountID),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.ErrorIs(err, domain.ErrSystemAccountConflict)
+}
+
+func (t *AccountManagerTestSuite) Test_FindAdoptionSource_ShouldReturnPreviousAccount() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	previousRef := domain.NewNamespacedName("previous-namespace", "previous-name")
+	account := testutil.CreateNatsTestAccount()
+	t.secretManagerMock.mockFindAccountRef(t.ctx, account.AccountID(), previousRef)
+
+	// When
+	source, err := t.unitUnderTest.FindAdoptionSource(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(&previousRef, source)
+}
+
+func (t *AccountManagerTestSuite) Test_FindAdoptionSource_ShouldReturnNil_WhenSecretsBelongToAccount() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	account := testutil.CreateNatsTestAccount()
+	t.secretManagerMock.mockFindAccountRef(t.ctx, account.AccountID(), accountRef)
+
+	// When
+	source, err := t.unitUnderTest.FindAdoptionSource(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Require().NoError(err)
+	t.Nil(source)
+}
+
+func (t *AccountManagerTestSuite) Test_FindAdoptionSource_ShouldReturnNil_WhenSecretsAreMissing() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	t.secretManagerMock.mockFindAccountRefMissing(t.ctx, account.AccountID())
+
+	// When
+	source, err := t.unitUnderTest.FindAdoptionSource(t.ctx, nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	})
+
+	// Then
+	t.Require().NoError(err)
+	t.Nil(source)
+}
+
+func (t *AccountManagerTestSuite) Test_Adopt_ShouldMoveSecrets() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	previousRef := domain.NewNamespacedName("previous-namespace", "previous-name")
+	account := testutil.CreateNatsTestAccount()
+	t.secretManagerMock.mockMoveSecrets(t.ctx, previousRef, accountRef, account.AccountID(), nil)
+
+	// When
+	err := t.unitUnderTest.Adopt(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	}, previousRef)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal([]domain.AuditAction{domain.AuditActionAccountKeysAdopted}, t.auditRecorderFake.actions())
+}
+
+func (t *AccountManagerTestSuite) Test_Adopt_ShouldFail_WhenMovingSecretsFails() {
+	// Given
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	previousRef := domain.NewNamespacedName("previous-namespace", "previous-name")
+	account := testutil.CreateNatsTestAccount()
+	t.secretManagerMock.mockMoveSecrets(t.ctx, previousRef, accountRef, account.AccountID(), domain.ErrSecretNameCollision)
+
+	// When
+	err := t.unitUnderTest.Adopt(t.ctx, nauth.AccountReference{
+		AccountRef:    accountRef,
+		AccountID:     nauth.AccountID(account.AccountID()),
+		ClusterTarget: t.clusterTarget,
+	}, previousRef)
+
+	// Then
+	t.ErrorIs(err, domain.ErrSecretNameCollision)
+	t.Empty(t.auditRecorderFake.actions())
+}
+
+func (t *AccountManagerTestSuite) Test_Adopt_ShouldFail_WhenAdoptingSystemAccount() {
+	// When
+	err := t.unitUnderTest.Adopt(t.ctx, nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("account-namespace", "account-name"),
+		AccountID:     nauth.AccountID(t.sauCreds.AccountID),
+		ClusterTarget: t.clusterTarget,
+	}, domain.NewNamespacedName("previous-namespace", "previous-name"))
+
+	// Then
+	t.ErrorIs(err, domain.ErrSystemAccountConflict)
 }
 
 func (t *AccountManagerTestSuite) Test_Delete_ShouldSucceed_WhenAccountSecretsAreMissing() {
@@ -806,6 +1858,12 @@ func (t *AccountManagerTestSuite) verifyAccountResult(result *nauth.AccountResul
 
 	t.Equal([]string{signKeyPublic}, accountClaims.SigningKeys.Keys(), "account claims should contain the expected signing key")
 
+	t.Require().NotNil(result.JWT, "pushed account JWT should be described in result")
+	t.Equal(accountClaims.ID, result.JWT.ID)
+	t.Equal(accountClaims.IssuedAt, result.JWT.IssuedAt.Unix())
+	t.Equal(accountClaims.Issuer, result.JWT.Issuer)
+	t.Nil(result.JWT.ExpiresAt)
+
 	return accountClaims
 }
 
@@ -880,4 +1938,108 @@ func (m *secretManagerMock) mockGetSecretsMissing(ctx context.Context, accountRe
 	m.On("GetSecrets", ctx, accountRef, accountID).Return(nil, false, nil)
 }
 
+func (m *secretManagerMock) GetPendingSecrets(ctx context.Context, accountRef domain.NamespacedName) (*Secrets, bool, error) {
+	args := m.Called(ctx, accountRef)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*Secrets), args.Bool(1), args.Error(2)
+}
+
+func (m *secretManagerMock) mockGetPendingSecrets(ctx context.Context, accountRef domain.NamespacedName, result *Secrets) {
+	m.On("GetPendingSecrets", ctx, accountRef).Return(result, true, nil)
+}
+
+func (m *secretManagerMock) mockGetPendingSecretsMissing(ctx context.Context, accountRef domain.NamespacedName) {
+	m.On("GetPendingSecrets", ctx, accountRef).Return(nil, false, nil)
+}
+
+func (m *secretManagerMock) ApplyPendingSecrets(ctx context.Context, accountRef domain.NamespacedName, secrets *Secrets) error {
+	args := m.Called(ctx, accountRef, secrets)
+	return args.Error(0)
+}
+
+func (m *secretManagerMock) mockApplyPendingSecretsUnknown(ctx context.Context, accountRef domain.NamespacedName) {
+	m.On("ApplyPendingSecrets", ctx, accountRef, mock.Anything).Return(nil)
+}
+
+func (m *secretManagerMock) DeletePendingSecrets(ctx context.Context, accountRef domain.NamespacedName) error {
+	args := m.Called(ctx, accountRef)
+	return args.Error(0)
+}
+
+func (m *secretManagerMock) mockDeletePendingSecrets(ctx context.Context, accountRef domain.NamespacedName) {
+	m.On("DeletePendingSecrets", ctx, accountRef).Return(nil)
+}
+
+func (m *secretManagerMock) MigrateSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (bool, error) {
+	args := m.Called(ctx, accountRef, accountID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *secretManagerMock) mockMigrateSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string, migrated bool, err error) {
+	m.On("MigrateSecrets", ctx, accountRef, accountID).Return(migrated, err)
+}
+
+func (m *secretManagerMock) FindAccountRef(ctx context.Context, accountID string) (domain.NamespacedName, bool, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).(domain.NamespacedName), args.Bool(1), args.Error(2)
+}
+
+func (m *secretManagerMock) mockFindAccountRef(ctx context.Context, accountID string, accountRef domain.NamespacedName) {
+	m.On("FindAccountRef", ctx, accountID).Return(accountRef, true, nil)
+}
+
+func (m *secretManagerMock) mockFindAccountRefMissing(ctx context.Context, accountID string) {
+	m.On("FindAccountRef", ctx, accountID).Return(domain.NamespacedName{}, false, nil)
+}
+
+func (m *secretManagerMock) MoveSecrets(ctx context.Context, from domain.NamespacedName, to domain.NamespacedName, accountID string) error {
+	args := m.Called(ctx, from, to, accountID)
+	return args.Error(0)
+}
+
+func (m *secretManagerMock) mockMoveSecrets(ctx context.Context, from domain.NamespacedName, to domain.NamespacedName, accountID string, err error) {
+	m.On("MoveSecrets", ctx, from, to, accountID).Return(err)
+}
+
+func (m *secretManagerMock) GetProvidedSecrets(ctx context.Context, accountRef domain.NamespacedName, keySecrets nauth.AccountKeySecrets) (*Secrets, error) {
+	args := m.Called(ctx, accountRef, keySecrets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Secrets), args.Error(1)
+}
+
+func (m *secretManagerMock) mockGetProvidedSecrets(ctx context.Context, accountRef domain.NamespacedName, keySecrets nauth.AccountKeySecrets, result *Secrets) {
+	m.On("GetProvidedSecrets", ctx, accountRef, keySecrets).Return(result, nil)
+}
+
 var _ secretManager = (*secretManagerMock)(nil)
+
+func Test_checkAccountJWTSize(t *testing.T) {
+	token := strings.Repeat("a", 800)
+	tests := []struct {
+		name       string
+		maxPayload int64
+		want       *nauth.JWTSize
+		wantErr    error
+	}{
+		{name: "well below the limit", maxPayload: 2000},
+		{name: "close to the limit", maxPayload: 1000, want: &nauth.JWTSize{Bytes: 800, Limit: 1000}},
+		{name: "at the limit", maxPayload: 800, want: &nauth.JWTSize{Bytes: 800, Limit: 800}},
+		{name: "above the limit", maxPayload: 799, wantErr: domain.ErrJWTTooLarge},
+		{name: "unknown limit defaults to the NATS default", maxPayload: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkAccountJWTSize(token, tt.maxPayload)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
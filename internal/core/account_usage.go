@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+)
+
+// AccountUsageManager reads the current usage of accounts from the NATS servers through the system account.
+type AccountUsageManager struct {
+	natsSysClient outbound.NatsSysClient
+}
+
+func NewAccountUsageManager(natsSysClient outbound.NatsSysClient) (*AccountUsageManager, error) {
+	m := &AccountUsageManager{
+		natsSysClient: natsSysClient,
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid AccountUsageManager: %w", err)
+	}
+	return m, nil
+}
+
+func (m *AccountUsageManager) validate() error {
+	if m.natsSysClient == nil {
+		return fmt.Errorf("natsSysClient is required")
+	}
+	return nil
+}
+
+// GetUsage returns the current connections, subscriptions and JetStream usage of the referenced account.
+func (m *AccountUsageManager) GetUsage(ctx context.Context, reference nauth.AccountReference) (*domain.NatsAccountUsage, error) {
+	if err := reference.Validate(); err != nil {
+		return nil, domain.ErrBadRequest.WithCause(fmt.Errorf("invalid account reference: %w", err))
+	}
+	if reference.AccountID == "" {
+		return nil, domain.ErrAccountNotReady.WithCause(fmt.Errorf("account ID is missing for account %s", reference.AccountRef))
+	}
+	cluster := reference.ClusterTarget
+
+	sysConn, err := m.natsSysClient.Connect(ctx, cluster.NatsURL, cluster.SystemAdminCreds, cluster.TLS)
+	if err != nil {
+		return nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("failed to connect to NATS cluster: %w", err))
+	}
+	defer sysConn.Disconnect()
+
+	usage, err := sysConn.AccountUsage(string(reference.AccountID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage of account %s: %w", reference.AccountID, err)
+	}
+	return usage, nil
+}
+
+var _ inbound.AccountUsageManager = (*AccountUsageManager)(nil)
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+type AccountUsageTestSuite struct {
+	suite.Suite
+	ctx               context.Context
+	natsSysClientMock *NatsSysClientMock
+	natsSysConnMock   *NatsSysConnectionMock
+	reference         nauth.AccountReference
+	unitUnderTest     *AccountUsageManager
+}
+
+func TestAccountUsageManager_TestSuite(t *testing.T) {
+	suite.Run(t, new(AccountUsageTestSuite))
+}
+
+func (t *AccountUsageTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.natsSysClientMock = NewNatsSysClientMock()
+	t.natsSysConnMock = NewNatsSysConnectionMock()
+	t.reference = nauth.AccountReference{
+		AccountRef:    domain.NewNamespacedName("my-namespace", "my-account"),
+		AccountID:     nauth.AccountID(testutil.NatsTestAccountA.AccountID()),
+		ClusterTarget: t.generateClusterTarget(),
+	}
+
+	var err error
+	t.unitUnderTest, err = NewAccountUsageManager(t.natsSysClientMock)
+	t.Require().NoError(err)
+}
+
+func (t *AccountUsageTestSuite) TearDownTest() {
+	t.natsSysClientMock.AssertExpectations(t.T())
+	t.natsSysConnMock.AssertExpectations(t.T())
+}
+
+func (t *AccountUsageTestSuite) Test_GetUsage_ShouldReturnUsageFromNats() {
+	// Given
+	cluster := t.reference.ClusterTarget
+	expected := &domain.NatsAccountUsage{
+		Connections:   3,
+		Subscriptions: 12,
+		JetStream:     &domain.NatsJetStreamUsage{StorageBytes: 1024},
+	}
+	t.natsSysClientMock.mockConnect(cluster.NatsURL, cluster.SystemAdminCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockAccountUsage(string(t.reference.AccountID), expected, nil)
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	usage, err := t.unitUnderTest.GetUsage(t.ctx, t.reference)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(expected, usage)
+}
+
+func (t *AccountUsageTestSuite) Test_GetUsage_ShouldFail_WhenClusterUnreachable() {
+	// Given
+	cluster := t.reference.ClusterTarget
+	t.natsSysClientMock.mockConnectError(cluster.NatsURL, cluster.SystemAdminCreds, fmt.Errorf("connection refused"))
+
+	// When
+	usage, err := t.unitUnderTest.GetUsage(t.ctx, t.reference)
+
+	// Then
+	t.Nil(usage)
+	t.ErrorIs(err, domain.ErrClusterUnreachable)
+}
+
+func (t *AccountUsageTestSuite) Test_GetUsage_ShouldFail_WhenAccountIDMissing() {
+	// Given
+	t.reference.AccountID = ""
+
+	// When
+	usage, err := t.unitUnderTest.GetUsage(t.ctx, t.reference)
+
+	// Then
+	t.Nil(usage)
+	t.ErrorIs(err, domain.ErrAccountNotReady)
+}
+
+func (t *AccountUsageTestSuite) generateClusterTarget() nauth.ClusterTarget {
+	operator := testutil.CreateNatsTestOperator()
+	ac := testutil.CreateNatsTestAccount()
+	sau := testutil.CreateNatsTestUserKey()
+
+	sauClaims := jwt.NewUserClaims(sau.PublicKey)
+	sauClaims.IssuerAccount = ac.Root.PublicKey
+	sauJwt, err := sauClaims.Encode(ac.Root.Key)
+	t.Require().NoError(err)
+	sauCreds, err := jwt.FormatUserConfig(sauJwt, sau.Seed)
+	t.Require().NoError(err)
+	sauNatsUserCreds, err := domain.NewNatsUserCreds(sauCreds)
+	t.Require().NoError(err)
+
+	return nauth.ClusterTarget{
+		UID:                string(uuid.NewUUID()),
+		NatsURL:            "nats://my-cluster:4222",
+		OperatorSigningKey: operator.Sign.Key,
+		SystemAdminCreds:   *sauNatsUserCreds,
+	}
+}
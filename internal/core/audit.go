@@ -0,0 +1,79 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/nats-io/jwt/v2"
+)
+
+const (
+	auditKindAccount = "Account"
+	auditKindUser    = "User"
+)
+
+func auditResource(kind string, ref domain.NamespacedName) domain.AuditResource {
+	return domain.AuditResource{Kind: kind, Namespace: ref.Namespace, Name: ref.Name}
+}
+
+// accountClaimsChanges returns the claim fields of claims that differ from the account JWT previousJWT. It returns nil
+// when previousJWT is unknown or cannot be decoded.
+func accountClaimsChanges(previousJWT string, claims *jwt.AccountClaims) []string {
+	if previousJWT == "" {
+		return nil
+	}
+	previousClaims, err := jwt.DecodeAccountClaims(previousJWT)
+	if err != nil {
+		return nil
+	}
+	return accountClaimsFieldChanges(previousClaims, claims)
+}
+
+// accountClaimsFieldChanges returns the claim fields of claims that differ from previousClaims.
+func accountClaimsFieldChanges(previousClaims *jwt.AccountClaims, claims *jwt.AccountClaims) []string {
+	changes := changedFields(previousClaims.Account, claims.Account)
+	if previousClaims.Name != claims.Name {
+		changes = append([]string{"name"}, changes...)
+	}
+	return changes
+}
+
+// changedFields returns the sorted names of the top-level JSON fields that differ between previous and current.
+func changedFields(previous any, current any) []string {
+	previousFields, err := jsonFields(previous)
+	if err != nil {
+		return nil
+	}
+	currentFields, err := jsonFields(current)
+	if err != nil {
+		return nil
+	}
+
+	var changes []string
+	for name, value := range currentFields {
+		if !bytes.Equal(previousFields[name], value) {
+			changes = append(changes, name)
+		}
+	}
+	for name := range previousFields {
+		if _, ok := currentFields[name]; !ok {
+			changes = append(changes, name)
+		}
+	}
+	slices.Sort(changes)
+	return changes
+}
+
+func jsonFields(value any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
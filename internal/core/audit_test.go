@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedFields(t *testing.T) {
+	testCases := []struct {
+		testName string
+		previous v1alpha1.UserClaims
+		current  v1alpha1.UserClaims
+		expected []string
+	}{
+		{
+			testName: "should_return_nothing_when_equal",
+			previous: v1alpha1.UserClaims{DisplayName: "user"},
+			current:  v1alpha1.UserClaims{DisplayName: "user"},
+		},
+		{
+			testName: "should_return_changed_field",
+			previous: v1alpha1.UserClaims{DisplayName: "user"},
+			current:  v1alpha1.UserClaims{DisplayName: "renamed"},
+			expected: []string{"displayName"},
+		},
+		{
+			testName: "should_return_added_and_removed_fields",
+			previous: v1alpha1.UserClaims{DisplayName: "user"},
+			current:  v1alpha1.UserClaims{BearerToken: true},
+			expected: []string{"bearerToken", "displayName"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, changedFields(tc.previous, tc.current))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+)
+
+const (
+	FeatureGateOperatorNatsCluster         = "OperatorNatsCluster"
+	FeatureGateOperatorNatsClusterOptional = "OperatorNatsClusterOptional"
+	FeatureGateStrictCryptoPolicy          = "StrictCryptoPolicy"
+
+	ProviderTypeNatsResolver = "nats-resolver"
+)
+
+// NewCapabilities describes the operator as configured by config.
+func NewCapabilities(operatorVersion string, config *Config, startTime time.Time) nauth.Capabilities {
+	return nauth.Capabilities{
+		OperatorVersion:   operatorVersion,
+		OperatorNamespace: string(config.OperatorNamespace),
+		FeatureGates:      config.featureGates(),
+		APIVersions:       []string{v1alpha1.GroupVersion.String()},
+		ProviderTypes:     []string{ProviderTypeNatsResolver},
+		CryptoPolicy:      string(config.CryptoPolicy),
+		StartTime:         startTime.UTC(),
+	}
+}
+
+func (c *Config) featureGates() map[string]bool {
+	return map[string]bool{
+		FeatureGateOperatorNatsCluster:         c.OperatorNatsCluster != nil,
+		FeatureGateOperatorNatsClusterOptional: c.OperatorNatsCluster != nil && c.OperatorNatsCluster.Optional,
+		FeatureGateStrictCryptoPolicy:          c.CryptoPolicy.IsStrict(),
+	}
+}
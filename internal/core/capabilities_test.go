@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewCapabilities(t *testing.T) {
+	startTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("should_describe_default_configuration", func(t *testing.T) {
+		config, err := NewConfig(nil, "nauth", "", "", "")
+		require.NoError(t, err)
+
+		result := NewCapabilities("1.2.3", config, startTime)
+
+		assert.Equal(t, "1.2.3", result.OperatorVersion)
+		assert.Equal(t, "nauth", result.OperatorNamespace)
+		assert.Equal(t, []string{"nauth.io/v1alpha1"}, result.APIVersions)
+		assert.Equal(t, []string{ProviderTypeNatsResolver}, result.ProviderTypes)
+		assert.Equal(t, "default", result.CryptoPolicy)
+		assert.Equal(t, startTime, result.StartTime)
+		assert.Equal(t, map[string]bool{
+			FeatureGateOperatorNatsCluster:         false,
+			FeatureGateOperatorNatsClusterOptional: false,
+			FeatureGateStrictCryptoPolicy:          false,
+		}, result.FeatureGates)
+	})
+
+	t.Run("should_report_enabled_feature_gates", func(t *testing.T) {
+		operatorNatsCluster, err := NewOperatorNatsCluster("nauth/nats", true)
+		require.NoError(t, err)
+		config, err := NewConfig(operatorNatsCluster, "nauth", CryptoPolicyStrict, "", "")
+		require.NoError(t, err)
+
+		result := NewCapabilities("1.2.3", config, startTime)
+
+		assert.Equal(t, map[string]bool{
+			FeatureGateOperatorNatsCluster:         true,
+			FeatureGateOperatorNatsClusterOptional: true,
+			FeatureGateStrictCryptoPolicy:          true,
+		}, result.FeatureGates)
+	})
+}
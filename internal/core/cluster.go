@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
 	"github.com/WirelessCar/nauth/internal/ports/outbound"
@@ -56,9 +57,10 @@ func (r *ClusterManager) Validate(ctx context.Context, target nauth.ClusterTarge
 		return fmt.Errorf("invalid cluster target: %w", err)
 	}
 
-	sysConn, err := r.natsSysClient.Connect(target.NatsURL, target.SystemAdminCreds)
+	sysConn, err := r.natsSysClient.Connect(ctx, target.NatsURL, target.SystemAdminCreds, target.TLS)
 	if err != nil {
-		return fmt.Errorf("connect to NATS cluster using System Account User Credentials: %w", err)
+		return domain.ErrClusterUnreachable.WithCause(
+			fmt.Errorf("connect to NATS cluster using System Account User Credentials: %w", err))
 	}
 
 	defer sysConn.Disconnect()
@@ -68,6 +70,34 @@ func (r *ClusterManager) Validate(ctx context.Context, target nauth.ClusterTarge
 	return nil
 }
 
+// CheckHealth pings the servers of the cluster through its system account. Returns domain.ErrClusterUnreachable when
+// the cluster cannot be connected to or no server responds.
+func (r *ClusterManager) CheckHealth(ctx context.Context, target nauth.ClusterTarget) (*nauth.ClusterHealth, error) {
+	if err := target.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cluster target: %w", err)
+	}
+
+	sysConn, err := r.natsSysClient.Connect(ctx, target.NatsURL, target.SystemAdminCreds, target.TLS)
+	if err != nil {
+		return nil, domain.ErrClusterUnreachable.WithCause(
+			fmt.Errorf("connect to NATS cluster using System Account User Credentials: %w", err))
+	}
+	defer sysConn.Disconnect()
+
+	servers, err := sysConn.PingServers()
+	if err != nil {
+		return nil, domain.ErrClusterUnreachable.WithCause(fmt.Errorf("ping NATS servers: %w", err))
+	}
+	resolverType := target.Resolver.Type
+	if resolverType == "" {
+		resolverType = domain.NatsResolverTypePush
+	}
+	return &nauth.ClusterHealth{
+		Servers:      servers,
+		ResolverType: resolverType,
+	}, nil
+}
+
 func (r *ClusterManager) GetClusterTarget(ctx context.Context, accountClusterRef *nauth.ClusterRef) (*nauth.ClusterTarget, error) {
 	opClusterRef, opClusterRequired := r.opClusterConfig()
 	clusterRef, err := getEffectiveClusterRef(accountClusterRef, opClusterRef, opClusterRequired)
@@ -86,10 +116,14 @@ func (r *ClusterManager) GetClusterTarget(ctx context.Context, accountClusterRef
 }
 
 func (r *ClusterManager) opClusterConfig() (clusterRef *nauth.ClusterRef, required bool) {
-	if r.config == nil {
+	return operatorClusterConfig(r.config)
+}
+
+func operatorClusterConfig(config *Config) (clusterRef *nauth.ClusterRef, required bool) {
+	if config == nil {
 		return nil, false
 	}
-	cluster := r.config.OperatorNatsCluster
+	cluster := config.OperatorNatsCluster
 	if cluster == nil {
 		return nil, false
 	}
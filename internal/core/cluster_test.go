@@ -233,6 +233,7 @@ func (t *ClusterTestSuite) Test_Validate_ShouldFail_WhenNatsConnectionFails() {
 
 	// Then
 	t.ErrorContains(err, "connect to NATS cluster using System Account User Credentials: authentication failed")
+	t.ErrorIs(err, domain.ErrClusterUnreachable)
 }
 
 func (t *ClusterTestSuite) Test_Validate_ShouldFail_WhenVerifySystemAccountAccessFails() {
@@ -250,6 +251,59 @@ func (t *ClusterTestSuite) Test_Validate_ShouldFail_WhenVerifySystemAccountAcces
 	t.ErrorContains(err, "verify NATS System Account access: permission denied")
 }
 
+func (t *ClusterTestSuite) Test_CheckHealth_ShouldReturnRespondingServers() {
+	// Given
+	unitUnderTest := t.newUnitUnderTestWithDefaults()
+	clusterTarget := t.generateClusterTarget()
+	servers := []domain.NatsServerInfo{
+		{Name: "nats-0", Version: "2.12.0", JetStream: true, Connections: 3},
+		{Name: "nats-1", Version: "2.12.0", JetStream: true, Connections: 2},
+	}
+	t.natsSysClientMock.mockConnect(clusterTarget.NatsURL, clusterTarget.SystemAdminCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockPingServers(servers, nil)
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	health, err := unitUnderTest.CheckHealth(t.ctx, clusterTarget)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(servers, health.Servers)
+	t.Equal(domain.NatsResolverTypePush, health.ResolverType)
+}
+
+func (t *ClusterTestSuite) Test_CheckHealth_ShouldFail_WhenNatsConnectionFails() {
+	// Given
+	unitUnderTest := t.newUnitUnderTestWithDefaults()
+	clusterTarget := t.generateClusterTarget()
+	t.natsSysClientMock.mockConnectError(clusterTarget.NatsURL, clusterTarget.SystemAdminCreds, fmt.Errorf("connection refused"))
+
+	// When
+	health, err := unitUnderTest.CheckHealth(t.ctx, clusterTarget)
+
+	// Then
+	t.Nil(health)
+	t.ErrorIs(err, domain.ErrClusterUnreachable)
+	t.ErrorContains(err, "connection refused")
+}
+
+func (t *ClusterTestSuite) Test_CheckHealth_ShouldFail_WhenNoServerResponds() {
+	// Given
+	unitUnderTest := t.newUnitUnderTestWithDefaults()
+	clusterTarget := t.generateClusterTarget()
+	t.natsSysClientMock.mockConnect(clusterTarget.NatsURL, clusterTarget.SystemAdminCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockPingServers(nil, fmt.Errorf("no server responded to system account ping"))
+	t.natsSysConnMock.mockDisconnect()
+
+	// When
+	health, err := unitUnderTest.CheckHealth(t.ctx, clusterTarget)
+
+	// Then
+	t.Nil(health)
+	t.ErrorIs(err, domain.ErrClusterUnreachable)
+	t.ErrorContains(err, "ping NATS servers: no server responded to system account ping")
+}
+
 func (t *ClusterTestSuite) newUnitUnderTestWithDefaults() *ClusterManager {
 	return t.newUnitUnderTest(nil, false, "")
 }
@@ -265,7 +319,7 @@ func (t *ClusterTestSuite) newUnitUnderTest(opClusterRef *nauth.ClusterRef, opCl
 		}
 	}
 
-	config, err := NewConfig(operatorNatsCluster, opNamespace)
+	config, err := NewConfig(operatorNatsCluster, opNamespace, CryptoPolicyDefault, "", "")
 	if err != nil {
 		t.Failf("failed to create operator config", "error: %v", err)
 		return nil
@@ -11,12 +11,29 @@ type Config struct {
 	OperatorNatsCluster *OperatorNatsCluster
 	// OperatorNamespace is the Kubernetes namespace where the operator is deployed.
 	OperatorNamespace domain.Namespace
+	// CryptoPolicy controls which cryptographic primitives the operator may use. Empty means CryptoPolicyDefault.
+	CryptoPolicy CryptoPolicy
+	// AccountSecretsNamespace stores the root and signing key seeds of all accounts when set, instead of the namespace
+	// of each Account.
+	AccountSecretsNamespace domain.Namespace
+	// AccountSecretNameTemplate names the root and signing key secrets of accounts. Empty means
+	// DefaultSecretNameTemplate.
+	AccountSecretNameTemplate SecretNameTemplate
 }
 
-func NewConfig(operatorNatsCluster *OperatorNatsCluster, operatorNamespace domain.Namespace) (*Config, error) {
+func NewConfig(operatorNatsCluster *OperatorNatsCluster, operatorNamespace domain.Namespace, cryptoPolicy CryptoPolicy, accountSecretsNamespace domain.Namespace, accountSecretNameTemplate SecretNameTemplate) (*Config, error) {
+	if cryptoPolicy == "" {
+		cryptoPolicy = CryptoPolicyDefault
+	}
+	if accountSecretNameTemplate == "" {
+		accountSecretNameTemplate = DefaultSecretNameTemplate
+	}
 	config := &Config{
-		OperatorNatsCluster: operatorNatsCluster,
-		OperatorNamespace:   operatorNamespace,
+		OperatorNatsCluster:       operatorNatsCluster,
+		OperatorNamespace:         operatorNamespace,
+		CryptoPolicy:              cryptoPolicy,
+		AccountSecretsNamespace:   accountSecretsNamespace,
+		AccountSecretNameTemplate: accountSecretNameTemplate,
 	}
 	if err := config.validate(); err != nil {
 		return nil, err
@@ -35,6 +52,21 @@ func (c *Config) validate() error {
 			return fmt.Errorf("invalid operator namespace %q: %s", c.OperatorNamespace, err)
 		}
 	}
+	if c.CryptoPolicy != "" {
+		if err := c.CryptoPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid crypto policy: %w", err)
+		}
+	}
+	if c.AccountSecretsNamespace != "" {
+		if err := c.AccountSecretsNamespace.Validate(); err != nil {
+			return fmt.Errorf("invalid account secrets namespace %q: %s", c.AccountSecretsNamespace, err)
+		}
+	}
+	if c.AccountSecretNameTemplate != "" {
+		if err := c.AccountSecretNameTemplate.Validate(); err != nil {
+			return fmt.Errorf("invalid account secret name template: %w", err)
+		}
+	}
 
 	return nil
 }
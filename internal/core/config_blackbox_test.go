@@ -59,7 +59,7 @@ func TestNewOperatorNatsCluster(t *testing.T) {
 
 func TestNewConfig(t *testing.T) {
 	t.Run("should_succeed_when_all_values_are_empty", func(t *testing.T) {
-		config, err := core.NewConfig(nil, "")
+		config, err := core.NewConfig(nil, "", "", "", "")
 		if err != nil {
 			t.Fatalf("expected success, got error: %v", err)
 		}
@@ -77,7 +77,7 @@ func TestNewConfig(t *testing.T) {
 	t.Run("should_fail_when_operator_cluster_is_invalid_even_if_constructed_directly", func(t *testing.T) {
 		config, err := core.NewConfig(&core.OperatorNatsCluster{
 			ClusterRef: "invalid_namespace/nats-main",
-		}, "")
+		}, "", "", "", "")
 		if err == nil {
 			t.Fatalf("expected error, got success with config=%+v", config)
 		}
@@ -87,7 +87,7 @@ func TestNewConfig(t *testing.T) {
 	})
 
 	t.Run("should_fail_when_operator_namespace_is_invalid", func(t *testing.T) {
-		config, err := core.NewConfig(nil, " invalid_namespace ")
+		config, err := core.NewConfig(nil, " invalid_namespace ", "", "", "")
 		if err == nil {
 			t.Fatalf("expected error, got success with config=%+v", config)
 		}
@@ -95,4 +95,45 @@ func TestNewConfig(t *testing.T) {
 			t.Fatalf("expected operator namespace validation error, got %q", err.Error())
 		}
 	})
+
+	t.Run("should_default_crypto_policy_when_empty", func(t *testing.T) {
+		config, err := core.NewConfig(nil, "", "", "", "")
+		if err != nil {
+			t.Fatalf("expected success, got error: %v", err)
+		}
+		if config.CryptoPolicy != core.CryptoPolicyDefault {
+			t.Fatalf("expected crypto policy %q, got %q", core.CryptoPolicyDefault, config.CryptoPolicy)
+		}
+	})
+
+	t.Run("should_fail_when_crypto_policy_is_invalid", func(t *testing.T) {
+		config, err := core.NewConfig(nil, "", "md5", "", "")
+		if err == nil {
+			t.Fatalf("expected error, got success with config=%+v", config)
+		}
+		if !strings.Contains(err.Error(), "invalid crypto policy") {
+			t.Fatalf("expected crypto policy validation error, got %q", err.Error())
+		}
+	})
+
+	t.Run("should_fail_when_account_secrets_namespace_is_invalid", func(t *testing.T) {
+		config, err := core.NewConfig(nil, "", "", " invalid_namespace ", "")
+		if err == nil {
+			t.Fatalf("expected error, got success with config=%+v", config)
+		}
+		if !strings.Contains(err.Error(), "invalid account secrets namespace") {
+			t.Fatalf("expected account secrets namespace validation error, got %q", err.Error())
+		}
+	})
+
+	t.Run("should_fail_when_account_secret_name_template_is_invalid", func(t *testing.T) {
+		config, err := core.NewConfig(nil, "", "", "", "{account}-{type}")
+		if err == nil {
+			t.Fatalf("expected error, got success with config=%+v", config)
+		}
+		if !strings.Contains(err.Error(), "invalid account secret name template") {
+			t.Fatalf("expected account secret name template validation error, got %q", err.Error())
+		}
+	})
+
 }
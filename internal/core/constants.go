@@ -1,6 +0,0 @@
-package core
-
-const (
-	SecretNameAccountRootTemplate = "%s-ac-root-%s"
-	SecretNameAccountSignTemplate = "%s-ac-sign-%s"
-)
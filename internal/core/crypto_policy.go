@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CryptoPolicy controls which cryptographic primitives the operator is allowed to use.
+type CryptoPolicy string
+
+const (
+	// CryptoPolicyDefault keeps the historical behaviour, including MD5-based secret name suffixes.
+	CryptoPolicyDefault CryptoPolicy = "default"
+	// CryptoPolicyStrict only allows approved primitives: SHA-256 based secret name suffixes, key generation from
+	// crypto/rand with an audit log entry, and JWTs signed with the ed25519-nkey algorithm.
+	CryptoPolicyStrict CryptoPolicy = "strict"
+)
+
+const cryptoPolicyKeySource = "crypto/rand"
+
+func (p CryptoPolicy) Validate() error {
+	switch p {
+	case CryptoPolicyDefault, CryptoPolicyStrict:
+		return nil
+	default:
+		return fmt.Errorf("unsupported crypto policy %q, expected one of: %s, %s", p, CryptoPolicyDefault, CryptoPolicyStrict)
+	}
+}
+
+func (p CryptoPolicy) IsStrict() bool {
+	return p == CryptoPolicyStrict
+}
+
+// ParseCryptoPolicy parses a crypto policy name, treating an empty value as CryptoPolicyDefault.
+func ParseCryptoPolicy(value string) (CryptoPolicy, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return CryptoPolicyDefault, nil
+	}
+	policy := CryptoPolicy(strings.ToLower(value))
+	if err := policy.Validate(); err != nil {
+		return "", err
+	}
+	return policy, nil
+}
+
+// shortHashFromID returns a short, stable hash used to make generated secret names unique per account ID.
+func (p CryptoPolicy) shortHashFromID(ID string) string {
+	var hash string
+	if p.IsStrict() {
+		sum := sha256.Sum256([]byte(ID))
+		hash = hex.EncodeToString(sum[:])
+	} else {
+		sum := md5.Sum([]byte(ID))
+		hash = hex.EncodeToString(sum[:])
+	}
+	if len(hash) > 6 {
+		return hash[:6]
+	}
+	return hash
+}
+
+// createKeyPair creates a new NKey pair from crypto/rand. Under the strict policy every generated key is recorded in
+// the operator log together with its entropy source, giving auditors a trail of key material created by nauth.
+func (p CryptoPolicy) createKeyPair(ctx context.Context, prefix nkeys.PrefixByte, purpose string) (nkeys.KeyPair, error) {
+	keyPair, err := nkeys.CreatePairWithRand(prefix, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsStrict() {
+		publicKey, err := keyPair.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key of generated %s key pair: %w", purpose, err)
+		}
+		logf.FromContext(ctx).Info("Generated key pair", "purpose", purpose, "publicKey", publicKey,
+			"entropySource", cryptoPolicyKeySource, "cryptoPolicy", p)
+	}
+	return keyPair, nil
+}
+
+// verifyJWTAlgorithm pins the signature algorithm of JWTs read from NATS under the strict policy. The legacy
+// "ed25519" algorithm of v1 JWTs is rejected, only "ed25519-nkey" is accepted.
+func (p CryptoPolicy) verifyJWTAlgorithm(token string) error {
+	if !p.IsStrict() {
+		return nil
+	}
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return fmt.Errorf("expected JWT to have 3 segments, got %d", len(segments))
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(segments[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode JWT header: %w", err)
+	}
+	header := jwt.Header{}
+	if err = json.Unmarshal(rawHeader, &header); err != nil {
+		return fmt.Errorf("failed to parse JWT header: %w", err)
+	}
+	if header.Algorithm != jwt.AlgorithmNkey {
+		return fmt.Errorf("JWT signature algorithm %q is not allowed by crypto policy %q, expected %q",
+			header.Algorithm, p, jwt.AlgorithmNkey)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCryptoPolicy(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		value         string
+		expected      CryptoPolicy
+		expectedError string
+	}{
+		{testName: "should_default_when_empty", value: "", expected: CryptoPolicyDefault},
+		{testName: "should_parse_default", value: "default", expected: CryptoPolicyDefault},
+		{testName: "should_parse_strict_ignoring_case_and_space", value: " Strict ", expected: CryptoPolicyStrict},
+		{testName: "should_fail_when_unknown", value: "fips", expectedError: "unsupported crypto policy \"fips\""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			result, err := ParseCryptoPolicy(tc.value)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func Test_CryptoPolicy_shortHashFromID(t *testing.T) {
+	const accountID = "AAJCK7774DXTQZAFJLSQIVU76UHGXFZNJVWMT4F7PNRBCYM75LS75UYE"
+
+	t.Run("default_policy_should_keep_md5_based_names", func(t *testing.T) {
+		assert.Equal(t, "9b94e3", CryptoPolicyDefault.shortHashFromID(accountID))
+	})
+
+	t.Run("strict_policy_should_use_sha256_based_names", func(t *testing.T) {
+		assert.Equal(t, "5d5004", CryptoPolicyStrict.shortHashFromID(accountID))
+	})
+}
+
+func Test_CryptoPolicy_createKeyPair(t *testing.T) {
+	for _, policy := range []CryptoPolicy{CryptoPolicyDefault, CryptoPolicyStrict} {
+		t.Run(string(policy), func(t *testing.T) {
+			keyPair, err := policy.createKeyPair(context.Background(), nkeys.PrefixByteAccount, "account root")
+			require.NoError(t, err)
+
+			publicKey, err := keyPair.PublicKey()
+			require.NoError(t, err)
+			assert.True(t, nkeys.IsValidPublicAccountKey(publicKey))
+		})
+	}
+}
+
+func Test_CryptoPolicy_verifyJWTAlgorithm(t *testing.T) {
+	accountKeyPair, err := nkeys.CreateAccount()
+	require.NoError(t, err)
+	accountPublicKey, err := accountKeyPair.PublicKey()
+	require.NoError(t, err)
+	operatorKeyPair, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+
+	token, err := jwt.NewAccountClaims(accountPublicKey).Encode(operatorKeyPair)
+	require.NoError(t, err)
+	legacyHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ed25519"}`))
+	legacyToken := legacyHeader + token[strings.Index(token, "."):]
+
+	t.Run("default_policy_should_accept_any_algorithm", func(t *testing.T) {
+		assert.NoError(t, CryptoPolicyDefault.verifyJWTAlgorithm(legacyToken))
+	})
+
+	t.Run("strict_policy_should_accept_ed25519_nkey", func(t *testing.T) {
+		assert.NoError(t, CryptoPolicyStrict.verifyJWTAlgorithm(token))
+	})
+
+	t.Run("strict_policy_should_reject_legacy_algorithm", func(t *testing.T) {
+		err := CryptoPolicyStrict.verifyJWTAlgorithm(legacyToken)
+		assert.ErrorContains(t, err, "JWT signature algorithm \"ed25519\" is not allowed by crypto policy \"strict\"")
+	})
+
+	t.Run("strict_policy_should_reject_malformed_token", func(t *testing.T) {
+		err := CryptoPolicyStrict.verifyJWTAlgorithm("not-a-jwt")
+		assert.ErrorContains(t, err, "expected JWT to have 3 segments")
+	})
+}
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTestAccount returns the Account accountRef, labelled with accountID unless it is empty.
+func newTestAccount(accountRef domain.NamespacedName, accountID string) v1alpha1.Account {
+	account := v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      accountRef.Name,
+			Namespace: accountRef.Namespace,
+		},
+	}
+	if accountID != "" {
+		account.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+	}
+	return account
+}
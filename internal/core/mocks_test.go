@@ -3,12 +3,15 @@ package core
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/WirelessCar/nauth/api/v1alpha1"
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +46,15 @@ func (s *SecretClientMock) mockApplyWithCatch(ctx interface{}, owner interface{}
 		Return(nil)
 }
 
+func (s *SecretClientMock) ApplyAll(ctx context.Context, owner metav1.Object, secrets []outbound.SecretApply) error {
+	args := s.Called(ctx, owner, secrets)
+	return args.Error(0)
+}
+
+func (s *SecretClientMock) mockApplyAll(arguments ...interface{}) *mock.Call {
+	return s.On("ApplyAll", arguments...)
+}
+
 func (s *SecretClientMock) Get(ctx context.Context, namespacedName domain.NamespacedName) (map[string]string, bool, error) {
 	args := s.Called(ctx, namespacedName)
 	if args.Get(0) == nil {
@@ -193,7 +205,7 @@ type NatsSysClientMock struct {
 	mock.Mock
 }
 
-func (n *NatsSysClientMock) Connect(natsURL string, userCreds domain.NatsUserCreds) (outbound.NatsSysConnection, error) {
+func (n *NatsSysClientMock) Connect(_ context.Context, natsURL string, userCreds domain.NatsUserCreds, _ *domain.NatsTLSConfig) (outbound.NatsSysConnection, error) {
 	args := n.Called(natsURL, userCreds)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -221,6 +233,7 @@ func NewNatsSysConnectionMock() *NatsSysConnectionMock {
 
 type NatsSysConnectionMock struct {
 	mock.Mock
+	maxPayload int64
 }
 
 func (n *NatsSysConnectionMock) LookupAccountJWT(accountID string) (string, error) {
@@ -255,6 +268,27 @@ func (n *NatsSysConnectionMock) mockVerifySystemAccountAccessError(err error) {
 	n.On("VerifySystemAccountAccess").Return(err)
 }
 
+func (n *NatsSysConnectionMock) PingServers() ([]domain.NatsServerInfo, error) {
+	args := n.Called()
+	return args.Get(0).([]domain.NatsServerInfo), args.Error(1)
+}
+
+func (n *NatsSysConnectionMock) mockPingServers(servers []domain.NatsServerInfo, err error) {
+	n.On("PingServers").Return(servers, err)
+}
+
+func (n *NatsSysConnectionMock) AccountUsage(accountID string) (*domain.NatsAccountUsage, error) {
+	args := n.Called(accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NatsAccountUsage), args.Error(1)
+}
+
+func (n *NatsSysConnectionMock) mockAccountUsage(accountID string, usage *domain.NatsAccountUsage, err error) {
+	n.On("AccountUsage", accountID).Return(usage, err)
+}
+
 func (n *NatsSysConnectionMock) Disconnect() {
 	n.Called()
 }
@@ -289,6 +323,16 @@ func (n *NatsSysConnectionMock) mockDeleteAccountJWTCatch(catch func(jwt string)
 		})
 }
 
+// MaxPayload returns maxPayload, zero unless set by the test.
+func (n *NatsSysConnectionMock) MaxPayload() int64 {
+	return n.maxPayload
+}
+
+// ResolverStore returns the connection itself, the store of the push resolver type.
+func (n *NatsSysConnectionMock) ResolverStore(domain.NatsResolver) (outbound.AccountJWTStore, error) {
+	return n, nil
+}
+
 var _ outbound.NatsSysConnection = (*NatsSysConnectionMock)(nil)
 
 /* ********
@@ -303,7 +347,7 @@ type NatsAccountClientMock struct {
 	mock.Mock
 }
 
-func (n *NatsAccountClientMock) Connect(natsURL string, userCreds domain.NatsUserCreds) (outbound.NatsAccountConnection, error) {
+func (n *NatsAccountClientMock) Connect(_ context.Context, natsURL string, userCreds domain.NatsUserCreds, _ *domain.NatsTLSConfig) (outbound.NatsAccountConnection, error) {
 	args := n.Called(natsURL, userCreds)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -408,3 +452,318 @@ func (m *ClusterReaderMock) mockGetTargetError(ctx context.Context, clusterRef n
 }
 
 var _ outbound.ClusterReader = (*ClusterReaderMock)(nil)
+
+/* ****************************************************
+* PermissionSet Reader
+*****************************************************/
+type PermissionSetReaderMock struct {
+	mock.Mock
+}
+
+func NewPermissionSetReaderMock() *PermissionSetReaderMock {
+	return &PermissionSetReaderMock{}
+}
+
+func (m *PermissionSetReaderMock) Get(ctx context.Context, permissionSetRef domain.NamespacedName) (*v1alpha1.PermissionSet, error) {
+	args := m.Called(ctx, permissionSetRef)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*v1alpha1.PermissionSet), args.Error(1)
+}
+
+func (m *PermissionSetReaderMock) mockGet(ctx context.Context, permissionSetRef domain.NamespacedName, result *v1alpha1.PermissionSet) *mock.Call {
+	return m.On("Get", ctx, permissionSetRef).Return(result, nil)
+}
+
+func (m *PermissionSetReaderMock) mockGetError(ctx context.Context, permissionSetRef domain.NamespacedName, err error) *mock.Call {
+	return m.On("Get", ctx, permissionSetRef).Return(nil, err)
+}
+
+var _ outbound.PermissionSetReader = (*PermissionSetReaderMock)(nil)
+
+/* ****************************************************
+* Account User Defaults Reader
+*****************************************************/
+type AccountUserDefaultsReaderMock struct {
+	mock.Mock
+}
+
+func NewAccountUserDefaultsReaderMock() *AccountUserDefaultsReaderMock {
+	return &AccountUserDefaultsReaderMock{}
+}
+
+func (m *AccountUserDefaultsReaderMock) GetUserDefaults(ctx context.Context, accountRef domain.NamespacedName) (*v1alpha1.UserDefaults, error) {
+	args := m.Called(ctx, accountRef)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*v1alpha1.UserDefaults), args.Error(1)
+}
+
+func (m *AccountUserDefaultsReaderMock) mockGetUserDefaults(ctx context.Context, accountRef domain.NamespacedName, result *v1alpha1.UserDefaults) *mock.Call {
+	return m.On("GetUserDefaults", ctx, accountRef).Return(result, nil)
+}
+
+func (m *AccountUserDefaultsReaderMock) mockGetUserDefaultsError(ctx context.Context, accountRef domain.NamespacedName, err error) *mock.Call {
+	return m.On("GetUserDefaults", ctx, accountRef).Return(nil, err)
+}
+
+var _ outbound.AccountUserDefaultsReader = (*AccountUserDefaultsReaderMock)(nil)
+
+/* ****************************************************
+* NauthDefaults Reader
+*****************************************************/
+type NauthDefaultsReaderFake struct {
+	defaults *v1alpha1.NauthDefaultsSpec
+	err      error
+}
+
+func NewNauthDefaultsReaderFake() *NauthDefaultsReaderFake {
+	return &NauthDefaultsReaderFake{}
+}
+
+func (f *NauthDefaultsReaderFake) GetNauthDefaults(_ context.Context) (*v1alpha1.NauthDefaultsSpec, error) {
+	return f.defaults, f.err
+}
+
+var _ outbound.NauthDefaultsReader = (*NauthDefaultsReaderFake)(nil)
+
+/* ****************************************************
+* Account and User Listers
+*****************************************************/
+type AccountListerMock struct {
+	mock.Mock
+}
+
+func NewAccountListerMock() *AccountListerMock {
+	return &AccountListerMock{}
+}
+
+func (m *AccountListerMock) List(ctx context.Context, namespace domain.Namespace) ([]v1alpha1.Account, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]v1alpha1.Account), args.Error(1)
+}
+
+func (m *AccountListerMock) mockList(ctx context.Context, namespace domain.Namespace, result []v1alpha1.Account) *mock.Call {
+	return m.On("List", ctx, namespace).Return(result, nil)
+}
+
+var _ outbound.AccountLister = (*AccountListerMock)(nil)
+
+type UserListerMock struct {
+	mock.Mock
+}
+
+func NewUserListerMock() *UserListerMock {
+	return &UserListerMock{}
+}
+
+func (m *UserListerMock) List(ctx context.Context, namespace domain.Namespace) ([]v1alpha1.User, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]v1alpha1.User), args.Error(1)
+}
+
+func (m *UserListerMock) mockList(ctx context.Context, namespace domain.Namespace, result []v1alpha1.User) *mock.Call {
+	return m.On("List", ctx, namespace).Return(result, nil)
+}
+
+var _ outbound.UserLister = (*UserListerMock)(nil)
+
+/* ****************************************************
+* NSC Store Writer
+*****************************************************/
+type NscStoreWriterMock struct {
+	mock.Mock
+}
+
+func NewNscStoreWriterMock() *NscStoreWriterMock {
+	return &NscStoreWriterMock{}
+}
+
+func (m *NscStoreWriterMock) WriteOperatorSigningKey(operator string, signingKey nkeys.KeyPair) error {
+	args := m.Called(operator, signingKey)
+	return args.Error(0)
+}
+
+func (m *NscStoreWriterMock) mockWriteOperatorSigningKey(operator string, signingKey nkeys.KeyPair) *mock.Call {
+	return m.On("WriteOperatorSigningKey", operator, signingKey).Return(nil)
+}
+
+func (m *NscStoreWriterMock) WriteAccount(operator string, account string, accountJWT string, keyPairs ...nkeys.KeyPair) error {
+	args := m.Called(operator, account, accountJWT, keyPairs)
+	return args.Error(0)
+}
+
+func (m *NscStoreWriterMock) mockWriteAccount(operator string, account string, accountJWT string, keyPairs ...nkeys.KeyPair) *mock.Call {
+	return m.On("WriteAccount", operator, account, accountJWT, keyPairs).Return(nil)
+}
+
+func (m *NscStoreWriterMock) WriteUser(operator string, account string, user string, userCreds []byte) error {
+	args := m.Called(operator, account, user, userCreds)
+	return args.Error(0)
+}
+
+func (m *NscStoreWriterMock) mockWriteUser(operator string, account string, user string, userCreds []byte) *mock.Call {
+	return m.On("WriteUser", operator, account, user, userCreds).Return(nil)
+}
+
+var _ outbound.NscStoreWriter = (*NscStoreWriterMock)(nil)
+
+type NscStoreReaderFake struct {
+	operators map[string]*nauth.NscOperator
+	keys      map[string]nkeys.KeyPair
+}
+
+func NewNscStoreReaderFake() *NscStoreReaderFake {
+	return &NscStoreReaderFake{operators: map[string]*nauth.NscOperator{}, keys: map[string]nkeys.KeyPair{}}
+}
+
+func (f *NscStoreReaderFake) ListOperators() ([]string, error) {
+	var result []string
+	for name := range f.operators {
+		result = append(result, name)
+	}
+	slices.Sort(result)
+	return result, nil
+}
+
+func (f *NscStoreReaderFake) ReadOperator(operator string) (*nauth.NscOperator, error) {
+	result, found := f.operators[operator]
+	if !found {
+		return nil, fmt.Errorf("operator %s not found", operator)
+	}
+	return result, nil
+}
+
+func (f *NscStoreReaderFake) ReadKey(publicKey string) (nkeys.KeyPair, bool, error) {
+	keyPair, found := f.keys[publicKey]
+	return keyPair, found, nil
+}
+
+func (f *NscStoreReaderFake) addKeys(keyPairs ...nkeys.KeyPair) {
+	for _, keyPair := range keyPairs {
+		publicKey, _ := keyPair.PublicKey()
+		f.keys[publicKey] = keyPair
+	}
+}
+
+var _ outbound.NscStoreReader = (*NscStoreReaderFake)(nil)
+
+type ManifestWriterFake struct {
+	accounts []*v1alpha1.Account
+	users    []*v1alpha1.User
+	secrets  []outbound.SecretApply
+}
+
+func NewManifestWriterFake() *ManifestWriterFake {
+	return &ManifestWriterFake{}
+}
+
+func (f *ManifestWriterFake) WriteAccount(account *v1alpha1.Account) error {
+	f.accounts = append(f.accounts, account)
+	return nil
+}
+
+func (f *ManifestWriterFake) WriteUser(user *v1alpha1.User) error {
+	f.users = append(f.users, user)
+	return nil
+}
+
+func (f *ManifestWriterFake) WriteSecret(secret outbound.SecretApply) error {
+	f.secrets = append(f.secrets, secret)
+	return nil
+}
+
+var _ outbound.ManifestWriter = (*ManifestWriterFake)(nil)
+
+/* ****************************************************
+* Audit Recorder
+*****************************************************/
+type AuditRecorderFake struct {
+	events []domain.AuditEvent
+}
+
+func NewAuditRecorderFake() *AuditRecorderFake {
+	return &AuditRecorderFake{}
+}
+
+func (f *AuditRecorderFake) Record(_ context.Context, event domain.AuditEvent) {
+	f.events = append(f.events, event)
+}
+
+func (f *AuditRecorderFake) actions() []domain.AuditAction {
+	actions := make([]domain.AuditAction, 0, len(f.events))
+	for _, event := range f.events {
+		actions = append(actions, event.Action)
+	}
+	return actions
+}
+
+var _ outbound.AuditRecorder = (*AuditRecorderFake)(nil)
+
+/* ****************************************************
+* Account Claims Mutator
+*****************************************************/
+type AccountClaimsMutatorFunc func(claims *jwt.AccountClaims) *jwt.AccountClaims
+
+func (f AccountClaimsMutatorFunc) MutateAccountClaims(_ context.Context, _ domain.NamespacedName, claims *jwt.AccountClaims) (*jwt.AccountClaims, error) {
+	return f(claims), nil
+}
+
+var _ outbound.AccountClaimsMutator = AccountClaimsMutatorFunc(nil)
+
+type CredentialsSinkFake struct {
+	pushed  map[domain.NamespacedName]v1alpha1.CredentialsSink
+	removed []domain.NamespacedName
+}
+
+func NewCredentialsSinkFake() *CredentialsSinkFake {
+	return &CredentialsSinkFake{pushed: map[domain.NamespacedName]v1alpha1.CredentialsSink{}}
+}
+
+func (f *CredentialsSinkFake) Push(_ context.Context, _ metav1.Object, secretRef domain.NamespacedName, sink v1alpha1.CredentialsSink) error {
+	f.pushed[secretRef] = sink
+	return nil
+}
+
+func (f *CredentialsSinkFake) Remove(_ context.Context, secretRef domain.NamespacedName) error {
+	delete(f.pushed, secretRef)
+	f.removed = append(f.removed, secretRef)
+	return nil
+}
+
+var _ outbound.CredentialsSink = (*CredentialsSinkFake)(nil)
+
+type WorkloadRestarterFake struct {
+	// existing lists the restart targets that exist, other targets are reported as missing
+	existing    []v1alpha1.RestartTarget
+	annotations map[v1alpha1.RestartTarget]map[string]string
+	restarts    int
+}
+
+func NewWorkloadRestarterFake() *WorkloadRestarterFake {
+	return &WorkloadRestarterFake{annotations: map[v1alpha1.RestartTarget]map[string]string{}}
+}
+
+func (f *WorkloadRestarterFake) Restart(_ context.Context, _ domain.Namespace, target v1alpha1.RestartTarget, key string, value string) (bool, error) {
+	if !slices.Contains(f.existing, target) {
+		return false, nil
+	}
+	if f.annotations[target] == nil {
+		f.annotations[target] = map[string]string{}
+	}
+	if f.annotations[target][key] != value {
+		f.restarts++
+	}
+	f.annotations[target][key] = value
+	return true, nil
+}
+
+var _ outbound.WorkloadRestarter = (*WorkloadRestarterFake)(nil)
@@ -0,0 +1,272 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NscExportManager exports the state managed by NAuth into an nsc compatible store, so the auth state of the NATS
+// servers can be reconstructed if the Kubernetes cluster is lost.
+type NscExportManager struct {
+	accountLister outbound.AccountLister
+	userLister    outbound.UserLister
+	clusterReader outbound.ClusterReader
+	natsSysClient outbound.NatsSysClient
+	secretReader  outbound.SecretReader
+	secretManager secretManager
+	config        *Config
+}
+
+func NewNscExportManager(
+	accountLister outbound.AccountLister,
+	userLister outbound.UserLister,
+	clusterReader outbound.ClusterReader,
+	natsSysClient outbound.NatsSysClient,
+	secretClient outbound.SecretClient,
+	config *Config,
+) (*NscExportManager, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	sm, err := newSecretManagerImpl(secretClient, config.CryptoPolicy, config.AccountSecretsNamespace, config.AccountSecretNameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return newNscExportManager(accountLister, userLister, clusterReader, natsSysClient, secretClient, sm, config)
+}
+
+func newNscExportManager(
+	accountLister outbound.AccountLister,
+	userLister outbound.UserLister,
+	clusterReader outbound.ClusterReader,
+	natsSysClient outbound.NatsSysClient,
+	secretReader outbound.SecretReader,
+	secretManager secretManager,
+	config *Config,
+) (*NscExportManager, error) {
+	m := &NscExportManager{
+		accountLister: accountLister,
+		userLister:    userLister,
+		clusterReader: clusterReader,
+		natsSysClient: natsSysClient,
+		secretReader:  secretReader,
+		secretManager: secretManager,
+		config:        config,
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (e *NscExportManager) validate() error {
+	if e.accountLister == nil {
+		return errors.New("accountLister is required")
+	}
+	if e.userLister == nil {
+		return errors.New("userLister is required")
+	}
+	if e.clusterReader == nil {
+		return errors.New("clusterReader is required")
+	}
+	if e.natsSysClient == nil {
+		return errors.New("natsSysClient is required")
+	}
+	if e.secretReader == nil {
+		return errors.New("secretReader is required")
+	}
+	if e.secretManager == nil {
+		return errors.New("secretManager is required")
+	}
+	if e.config == nil {
+		return errors.New("config is required")
+	}
+	return nil
+}
+
+// nscExportCluster is a NATS cluster that accounts are exported from. Each cluster becomes an operator in the store.
+type nscExportCluster struct {
+	operator string
+	target   *nauth.ClusterTarget
+	sysConn  outbound.NatsSysConnection
+}
+
+// Export writes the Accounts and Users in namespace, or in all namespaces when namespace is empty, to store.
+// Failing resources do not stop the export, their errors are joined and returned together with the result.
+func (e *NscExportManager) Export(ctx context.Context, namespace domain.Namespace, store outbound.NscStoreWriter) (*nauth.NscExportResult, error) {
+	log := logf.FromContext(ctx)
+	if store == nil {
+		return nil, fmt.Errorf("nsc store writer is required")
+	}
+
+	accounts, err := e.accountLister.List(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	users, err := e.userLister.List(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	result := &nauth.NscExportResult{}
+	clusters := make(map[nauth.ClusterRef]*nscExportCluster)
+	defer func() {
+		for _, cluster := range clusters {
+			cluster.sysConn.Disconnect()
+		}
+	}()
+
+	var errs []error
+	accountOperators := make(map[domain.NamespacedName]string, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		accountRef := domain.NewNamespacedName(account.GetNamespace(), account.GetName())
+		accountID := nauth.AccountID(account.GetLabel(v1alpha1.AccountLabelAccountID))
+		if accountID == "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("account %s: not ready", accountRef))
+			continue
+		}
+		cluster, err := e.getCluster(ctx, clusters, account, store)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to export account %s: %w", accountRef, err))
+			continue
+		}
+		if cluster.target.IsSystemAccount(accountID) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("account %s: system account", accountRef))
+			continue
+		}
+		if err := e.exportAccount(ctx, cluster, accountRef, accountID, store); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export account %s: %w", accountRef, err))
+			continue
+		}
+		accountOperators[accountRef] = cluster.operator
+		result.Accounts++
+	}
+	result.Operators = len(clusters)
+
+	for i := range users {
+		user := &users[i]
+		userRef := domain.NewNamespacedName(user.GetNamespace(), user.GetName())
+		userAccountRef := user.GetAccountRef()
+		accountRef := domain.NewNamespacedName(userAccountRef.Namespace, userAccountRef.Name)
+		operator, ok := accountOperators[accountRef]
+		if !ok {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("user %s: account %s not exported", userRef, accountRef))
+			continue
+		}
+		exported, err := e.exportUser(ctx, user, operator, accountRef, store)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to export user %s: %w", userRef, err))
+			continue
+		}
+		if !exported {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("user %s: credentials not found", userRef))
+			continue
+		}
+		result.Users++
+	}
+
+	log.Info("exported nsc store", "operators", result.Operators, "accounts", result.Accounts, "users", result.Users,
+		"skipped", len(result.Skipped), "failed", len(errs))
+	return result, errors.Join(errs...)
+}
+
+func (e *NscExportManager) getCluster(ctx context.Context, clusters map[nauth.ClusterRef]*nscExportCluster, account *v1alpha1.Account, store outbound.NscStoreWriter) (*nscExportCluster, error) {
+	var accountClusterRef *nauth.ClusterRef
+	if ref := account.Spec.NatsClusterRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = account.GetNamespace()
+		}
+		clusterRef, err := nauth.NewClusterRef(domain.NewNamespacedName(namespace, ref.Name).String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid NatsClusterRef: %w", err)
+		}
+		accountClusterRef = &clusterRef
+	}
+	opClusterRef, opClusterRequired := operatorClusterConfig(e.config)
+	clusterRef, err := getEffectiveClusterRef(accountClusterRef, opClusterRef, opClusterRequired)
+	if err != nil {
+		return nil, err
+	}
+	if cluster, ok := clusters[*clusterRef]; ok {
+		return cluster, nil
+	}
+
+	clusterName, err := clusterRef.AsNamespacedName()
+	if err != nil {
+		return nil, err
+	}
+	target, err := e.clusterReader.GetTarget(ctx, *clusterRef)
+	if err != nil {
+		return nil, fmt.Errorf("resolve cluster target %q: %w", *clusterRef, err)
+	}
+	if err = target.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cluster target: %w", err)
+	}
+	operator := nscStoreName(*clusterName)
+	if err = store.WriteOperatorSigningKey(operator, target.OperatorSigningKey); err != nil {
+		return nil, fmt.Errorf("failed to write operator signing key of cluster %q: %w", *clusterRef, err)
+	}
+	sysConn, err := e.natsSysClient.Connect(ctx, target.NatsURL, target.SystemAdminCreds, target.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS cluster %q: %w", *clusterRef, err)
+	}
+	cluster := &nscExportCluster{
+		operator: operator,
+		target:   target,
+		sysConn:  sysConn,
+	}
+	clusters[*clusterRef] = cluster
+	return cluster, nil
+}
+
+func (e *NscExportManager) exportAccount(ctx context.Context, cluster *nscExportCluster, accountRef domain.NamespacedName, accountID nauth.AccountID, store outbound.NscStoreWriter) error {
+	secrets, found, err := e.secretManager.GetSecrets(ctx, accountRef, string(accountID))
+	if err != nil {
+		return fmt.Errorf("failed to get account secrets: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("account secrets not found for account %s", accountID)
+	}
+	accountJWT, err := cluster.sysConn.LookupAccountJWT(string(accountID))
+	if err != nil {
+		return fmt.Errorf("failed to lookup account jwt for account %s: %w", accountID, err)
+	}
+	if accountJWT == "" {
+		return fmt.Errorf("account jwt for account %s not found", accountID)
+	}
+	return store.WriteAccount(cluster.operator, nscStoreName(accountRef), accountJWT, secrets.Root, secrets.Sign)
+}
+
+func (e *NscExportManager) exportUser(ctx context.Context, user *v1alpha1.User, operator string, accountRef domain.NamespacedName, store outbound.NscStoreWriter) (bool, error) {
+	secretRef := domain.NewNamespacedName(user.GetNamespace(), user.GetUserSecretName())
+	secret, found, err := e.secretReader.Get(ctx, secretRef)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user credentials secret %s: %w", secretRef, err)
+	}
+	userCreds := secret[domain.UserCredentialSecretKeyName]
+	if !found || userCreds == "" {
+		return false, nil
+	}
+	userRef := domain.NewNamespacedName(user.GetNamespace(), user.GetName())
+	if err := store.WriteUser(operator, nscStoreName(accountRef), nscStoreName(userRef), []byte(userCreds)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// nscStoreName names a resource in the nsc store. Namespaces cannot contain dots, so the name is unique.
+func nscStoreName(ref domain.NamespacedName) string {
+	return fmt.Sprintf("%s.%s", ref.Namespace, ref.Name)
+}
+
+var _ inbound.NscExportManager = (*NscExportManager)(nil)
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NscExportManagerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	sauCreds      domain.NatsUserCreds
+	natsURL       string
+	clusterRef    nauth.ClusterRef
+	clusterTarget *nauth.ClusterTarget
+
+	accountListerMock *AccountListerMock
+	userListerMock    *UserListerMock
+	clusterReaderMock *ClusterReaderMock
+	natsSysClientMock *NatsSysClientMock
+	natsSysConnMock   *NatsSysConnectionMock
+	secretClientMock  *SecretClientMock
+	secretManagerMock *secretManagerMock
+	storeMock         *NscStoreWriterMock
+
+	unitUnderTest *NscExportManager
+}
+
+func TestNscExportManager_TestSuite(t *testing.T) {
+	suite.Run(t, new(NscExportManagerTestSuite))
+}
+
+func (t *NscExportManagerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.sauCreds = domain.NatsUserCreds{
+		Creds:     []byte("FAKE_CREDENTIALS"),
+		AccountID: "FAKE_SYS_ACCOUNT_ID",
+	}
+	t.natsURL = "nats://nats:4222"
+	t.clusterRef = "nats/cluster"
+	t.clusterTarget = &nauth.ClusterTarget{
+		UID:                "cluster-target-uid",
+		NatsURL:            t.natsURL,
+		OperatorSigningKey: testutil.NatsTestOperatorA.Sign.Key,
+		SystemAdminCreds:   t.sauCreds,
+	}
+
+	t.accountListerMock = NewAccountListerMock()
+	t.userListerMock = NewUserListerMock()
+	t.clusterReaderMock = NewClusterReaderMock()
+	t.natsSysClientMock = NewNatsSysClientMock()
+	t.natsSysConnMock = NewNatsSysConnectionMock()
+	t.secretClientMock = NewSecretClientMock()
+	t.secretManagerMock = newSecretManagerMock()
+	t.storeMock = NewNscStoreWriterMock()
+
+	operatorNatsCluster, err := NewOperatorNatsCluster(t.clusterRef, true)
+	t.Require().NoError(err)
+	t.unitUnderTest, err = newNscExportManager(
+		t.accountListerMock,
+		t.userListerMock,
+		t.clusterReaderMock,
+		t.natsSysClientMock,
+		t.secretClientMock,
+		t.secretManagerMock,
+		&Config{OperatorNatsCluster: operatorNatsCluster, CryptoPolicy: CryptoPolicyDefault},
+	)
+	t.Require().NoError(err)
+}
+
+func (t *NscExportManagerTestSuite) TearDownTest() {
+	t.accountListerMock.AssertExpectations(t.T())
+	t.userListerMock.AssertExpectations(t.T())
+	t.clusterReaderMock.AssertExpectations(t.T())
+	t.natsSysClientMock.AssertExpectations(t.T())
+	t.natsSysConnMock.AssertExpectations(t.T())
+	t.secretClientMock.AssertExpectations(t.T())
+	t.secretManagerMock.AssertExpectations(t.T())
+	t.storeMock.AssertExpectations(t.T())
+}
+
+func (t *NscExportManagerTestSuite) Test_Export_ShouldExportAccountsAndUsers() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	accountRef := domain.NewNamespacedName("team", "orders")
+	user := newExportTestUser("team", "app", "orders")
+	userCreds := []byte("USER_CREDS")
+
+	t.accountListerMock.mockList(t.ctx, "", []v1alpha1.Account{newTestAccount(accountRef, account.AccountID())})
+	t.userListerMock.mockList(t.ctx, "", []v1alpha1.User{user})
+	t.clusterReaderMock.mockGetTarget(t.ctx, t.clusterRef, t.clusterTarget)
+	t.storeMock.mockWriteOperatorSigningKey("nats.cluster", t.clusterTarget.OperatorSigningKey)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(account.AccountID(), "ACCOUNT_JWT")
+	t.natsSysConnMock.mockDisconnect().Once()
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, account.AccountID(), &Secrets{Root: account.Root.Key, Sign: account.Sign.Key})
+	t.storeMock.mockWriteAccount("nats.cluster", "team.orders", "ACCOUNT_JWT", account.Root.Key, account.Sign.Key)
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("team", user.GetUserSecretName()), map[string]string{
+		k8s.UserCredentialSecretKeyName: string(userCreds),
+	})
+	t.storeMock.mockWriteUser("nats.cluster", "team.orders", "team.app", userCreds)
+
+	// When
+	result, err := t.unitUnderTest.Export(t.ctx, "", t.storeMock)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(&nauth.NscExportResult{Operators: 1, Accounts: 1, Users: 1}, result)
+}
+
+func (t *NscExportManagerTestSuite) Test_Export_ShouldSkipNotReadyAccountsAndTheirUsers() {
+	// Given
+	accountRef := domain.NewNamespacedName("team", "orders")
+	t.accountListerMock.mockList(t.ctx, "team", []v1alpha1.Account{newTestAccount(accountRef, "")})
+	t.userListerMock.mockList(t.ctx, "team", []v1alpha1.User{newExportTestUser("team", "app", "orders")})
+
+	// When
+	result, err := t.unitUnderTest.Export(t.ctx, "team", t.storeMock)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(&nauth.NscExportResult{Skipped: []string{
+		"account team/orders: not ready",
+		"user team/app: account team/orders not exported",
+	}}, result)
+}
+
+func (t *NscExportManagerTestSuite) Test_Export_ShouldSkipSystemAccount() {
+	// Given
+	accountRef := domain.NewNamespacedName("nats", "system")
+	t.accountListerMock.mockList(t.ctx, "", []v1alpha1.Account{newTestAccount(accountRef, t.sauCreds.AccountID)})
+	t.userListerMock.mockList(t.ctx, "", nil)
+	t.clusterReaderMock.mockGetTarget(t.ctx, t.clusterRef, t.clusterTarget)
+	t.storeMock.mockWriteOperatorSigningKey("nats.cluster", t.clusterTarget.OperatorSigningKey)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockDisconnect().Once()
+
+	// When
+	result, err := t.unitUnderTest.Export(t.ctx, "", t.storeMock)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(&nauth.NscExportResult{Operators: 1, Skipped: []string{"account nats/system: system account"}}, result)
+}
+
+func (t *NscExportManagerTestSuite) Test_Export_ShouldContinue_WhenAccountFails() {
+	// Given
+	failing := testutil.CreateNatsTestAccount()
+	failingRef := domain.NewNamespacedName("team", "failing")
+	account := testutil.CreateNatsTestAccount()
+	accountRef := domain.NewNamespacedName("team", "orders")
+
+	t.accountListerMock.mockList(t.ctx, "", []v1alpha1.Account{
+		newTestAccount(failingRef, failing.AccountID()),
+		newTestAccount(accountRef, account.AccountID()),
+	})
+	t.userListerMock.mockList(t.ctx, "", nil)
+	t.clusterReaderMock.mockGetTarget(t.ctx, t.clusterRef, t.clusterTarget).Once()
+	t.storeMock.mockWriteOperatorSigningKey("nats.cluster", t.clusterTarget.OperatorSigningKey).Once()
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock).Once()
+	t.natsSysConnMock.mockDisconnect().Once()
+	t.secretManagerMock.mockGetSecretsMissing(t.ctx, failingRef, failing.AccountID())
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, account.AccountID(), &Secrets{Root: account.Root.Key, Sign: account.Sign.Key})
+	t.natsSysConnMock.mockLookupAccountJWT(account.AccountID(), "ACCOUNT_JWT")
+	t.storeMock.mockWriteAccount("nats.cluster", "team.orders", "ACCOUNT_JWT", account.Root.Key, account.Sign.Key)
+
+	// When
+	result, err := t.unitUnderTest.Export(t.ctx, "", t.storeMock)
+
+	// Then
+	t.ErrorContains(err, fmt.Sprintf("failed to export account team/failing: account secrets not found for account %s", failing.AccountID()))
+	t.Equal(&nauth.NscExportResult{Operators: 1, Accounts: 1}, result)
+}
+
+func (t *NscExportManagerTestSuite) Test_Export_ShouldSkipUser_WhenCredentialsAreMissing() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	accountRef := domain.NewNamespacedName("team", "orders")
+	user := newExportTestUser("team", "app", "orders")
+
+	t.accountListerMock.mockList(t.ctx, "", []v1alpha1.Account{newTestAccount(accountRef, account.AccountID())})
+	t.userListerMock.mockList(t.ctx, "", []v1alpha1.User{user})
+	t.clusterReaderMock.mockGetTarget(t.ctx, t.clusterRef, t.clusterTarget)
+	t.storeMock.mockWriteOperatorSigningKey("nats.cluster", t.clusterTarget.OperatorSigningKey)
+	t.natsSysClientMock.mockConnect(t.natsURL, t.sauCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockLookupAccountJWT(account.AccountID(), "ACCOUNT_JWT")
+	t.natsSysConnMock.mockDisconnect().Once()
+	t.secretManagerMock.mockGetSecrets(t.ctx, accountRef, account.AccountID(), &Secrets{Root: account.Root.Key, Sign: account.Sign.Key})
+	t.storeMock.mockWriteAccount("nats.cluster", "team.orders", "ACCOUNT_JWT", account.Root.Key, account.Sign.Key)
+	t.secretClientMock.mockGetNotFound(domain.NewNamespacedName("team", user.GetUserSecretName()))
+
+	// When
+	result, err := t.unitUnderTest.Export(t.ctx, "", t.storeMock)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(&nauth.NscExportResult{Operators: 1, Accounts: 1, Skipped: []string{"user team/app: credentials not found"}}, result)
+}
+
+func newExportTestUser(namespace, name, accountName string) v1alpha1.User {
+	return v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: accountName,
+		},
+	}
+}
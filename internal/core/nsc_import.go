@@ -0,0 +1,507 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NscImportManager converts the accounts and users of an nsc store into Accounts, Users and their Secrets, to migrate
+// accounts managed with nsc to NAuth.
+type NscImportManager struct {
+	// secretManager only names and labels the account secrets, they are written as manifests instead of applied.
+	secretManager *secretManagerImpl
+	config        *Config
+	now           func() time.Time
+}
+
+func NewNscImportManager(config *Config) (*NscImportManager, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	nameTemplate := config.AccountSecretNameTemplate
+	if nameTemplate == "" {
+		nameTemplate = DefaultSecretNameTemplate
+	}
+	return &NscImportManager{
+		secretManager: &secretManagerImpl{
+			cryptoPolicy:     config.CryptoPolicy,
+			secretsNamespace: config.AccountSecretsNamespace,
+			nameTemplate:     nameTemplate,
+		},
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// nscImportAccount is an account of the store that is imported, with the keys of its secrets.
+type nscImportAccount struct {
+	source nauth.NscAccount
+	ref    domain.NamespacedName
+	claims *jwt.AccountClaims
+	root   nkeys.KeyPair
+	sign   nkeys.KeyPair
+}
+
+// Import writes an Account with its root and signing key Secrets for each account of the operator of the store, and
+// a User with its credentials Secret for each of their users. The system account and accounts whose seeds are not in
+// the keystore are skipped. Failing resources do not stop the import, their errors are joined and returned together
+// with the result.
+func (m *NscImportManager) Import(ctx context.Context, request nauth.NscImportRequest, store outbound.NscStoreReader, manifests outbound.ManifestWriter) (*nauth.NscImportResult, error) {
+	log := logf.FromContext(ctx)
+	if store == nil {
+		return nil, errors.New("nsc store reader is required")
+	}
+	if manifests == nil {
+		return nil, errors.New("manifest writer is required")
+	}
+	if err := request.Namespace.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid namespace %q: %w", request.Namespace, err)
+	}
+	operatorName, err := resolveNscOperator(request.Operator, store)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := store.ReadOperator(operatorName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator %s: %w", operatorName, err)
+	}
+	operatorClaims, err := jwt.DecodeOperatorClaims(operator.JWT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jwt of operator %s: %w", operatorName, err)
+	}
+
+	result := &nauth.NscImportResult{}
+	var errs []error
+	// Imports reference the Accounts of their exporters, so all accounts are resolved before any is written
+	var accounts []*nscImportAccount
+	accountNames := make(map[string]string)
+	accountRefs := make(map[string]domain.NamespacedName)
+	for _, source := range operator.Accounts {
+		account, skipped, err := m.resolveAccount(source, operatorClaims, request.Namespace, store)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to import account %s: %w", source.Name, err))
+			continue
+		}
+		if skipped != "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("account %s: %s", source.Name, skipped))
+			continue
+		}
+		if other, taken := accountNames[account.ref.Name]; taken {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("account %s: Account %s is generated for account %s",
+				source.Name, account.ref.Name, other))
+			continue
+		}
+		accountNames[account.ref.Name] = source.Name
+		accountRefs[account.claims.Subject] = account.ref
+		accounts = append(accounts, account)
+	}
+
+	userNames := make(map[string]string)
+	for _, account := range accounts {
+		warnings, err := m.writeAccount(account, request.Observe, accountRefs, manifests)
+		result.Warnings = append(result.Warnings, warnings...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to import account %s: %w", account.source.Name, err))
+			continue
+		}
+		result.Accounts++
+
+		for _, user := range account.source.Users {
+			userName := nscResourceName(user.Name)
+			if other, taken := userNames[userName]; taken {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("user %s: User %s is generated for user %s",
+					user.Name, userName, other))
+				continue
+			}
+			skipped, warning, err := m.writeUser(account, user, userName, store, manifests)
+			if warning != "" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("user %s: %s", user.Name, warning))
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to import user %s: %w", user.Name, err))
+				continue
+			}
+			if skipped != "" {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("user %s: %s", user.Name, skipped))
+				continue
+			}
+			userNames[userName] = user.Name
+			result.Users++
+		}
+	}
+
+	log.Info("imported nsc store", "operator", operatorName, "accounts", result.Accounts, "users", result.Users,
+		"skipped", len(result.Skipped), "failed", len(errs))
+	return result, errors.Join(errs...)
+}
+
+// resolveNscOperator returns operator, or the only operator of the store when operator is empty.
+func resolveNscOperator(operator string, store outbound.NscStoreReader) (string, error) {
+	if operator != "" {
+		return operator, nil
+	}
+	operators, err := store.ListOperators()
+	if err != nil {
+		return "", fmt.Errorf("failed to list operators: %w", err)
+	}
+	if len(operators) != 1 {
+		return "", fmt.Errorf("the nsc store holds %d operators, select one of [%s]", len(operators),
+			strings.Join(operators, ", "))
+	}
+	return operators[0], nil
+}
+
+// resolveAccount decodes an account of the store and reads its keys, or returns why the account is skipped.
+func (m *NscImportManager) resolveAccount(source nauth.NscAccount, operatorClaims *jwt.OperatorClaims, namespace domain.Namespace, store outbound.NscStoreReader) (*nscImportAccount, string, error) {
+	if err := m.config.CryptoPolicy.verifyJWTAlgorithm(source.JWT); err != nil {
+		return nil, "", fmt.Errorf("account jwt rejected: %w", err)
+	}
+	claims, err := jwt.DecodeAccountClaims(source.JWT)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode account jwt: %w", err)
+	}
+	if !operatorClaims.DidSign(claims) {
+		return nil, fmt.Sprintf("not signed by operator %s", operatorClaims.Name), nil
+	}
+	if claims.Subject == operatorClaims.SystemAccount {
+		return nil, "system account", nil
+	}
+	name := nscResourceName(source.Name)
+	if name == "" {
+		return nil, "name cannot be converted to a resource name", nil
+	}
+
+	root, found, err := store.ReadKey(claims.Subject)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read root seed: %w", err)
+	}
+	if !found {
+		return nil, "root seed not found in keystore", nil
+	}
+	// NAuth signs users with a signing key, the first signing key with a seed in the keystore is used
+	var sign nkeys.KeyPair
+	signingKeys := claims.SigningKeys.Keys()
+	slices.Sort(signingKeys)
+	for _, signingKey := range signingKeys {
+		keyPair, found, err := store.ReadKey(signingKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read signing key seed: %w", err)
+		}
+		if found {
+			sign = keyPair
+			break
+		}
+	}
+	if sign == nil {
+		return nil, "no signing key seed found in keystore, add a signing key with nsc edit account --sk generate", nil
+	}
+	return &nscImportAccount{
+		source: source,
+		ref:    domain.NewNamespacedName(string(namespace), name),
+		claims: claims,
+		root:   root,
+		sign:   sign,
+	}, "", nil
+}
+
+// writeAccount writes the account secrets followed by the Account, and returns the claims of the account JWT that
+// the Account does not carry over.
+func (m *NscImportManager) writeAccount(account *nscImportAccount, observe bool, accountRefs map[string]domain.NamespacedName, manifests outbound.ManifestWriter) ([]string, error) {
+	accountID := account.claims.Subject
+	rootSecret, err := m.secretManager.rootSecret(account.ref, account.root)
+	if err != nil {
+		return nil, err
+	}
+	signSecret, err := m.secretManager.accountSecret(account.ref, accountID, domain.SecretTypeAccountSign, account.sign)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      account.ref.Name,
+			Namespace: account.ref.Namespace,
+		},
+	}
+	result.SetLabel(v1alpha1.AccountLabelAccountID, accountID)
+	var warnings []string
+	if observe {
+		result.SetLabel(v1alpha1.AccountLabelManagementPolicy, v1alpha1.AccountManagementPolicyObserve)
+	} else {
+		signPublicKey, err := account.sign.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get signing key public key: %w", err)
+		}
+		for _, signingKey := range account.claims.SigningKeys.Keys() {
+			if signingKey != signPublicKey {
+				warnings = append(warnings, fmt.Sprintf("account %s: signing key %s is replaced by %s, users signed by it "+
+					"stop working", account.source.Name, signingKey, signPublicKey))
+			}
+		}
+		spec, specWarnings, err := toNscImportAccountSpec(account.claims, accountRefs)
+		if err != nil {
+			return warnings, err
+		}
+		for _, warning := range specWarnings {
+			warnings = append(warnings, fmt.Sprintf("account %s: %s", account.source.Name, warning))
+		}
+		result.Spec = *spec
+	}
+
+	if err := manifests.WriteSecret(rootSecret); err != nil {
+		return warnings, err
+	}
+	if err := manifests.WriteSecret(signSecret); err != nil {
+		return warnings, err
+	}
+	return warnings, manifests.WriteAccount(result)
+}
+
+// writeUser writes the credentials Secret of a user followed by the User, or returns why the user is skipped. Users
+// without credentials in the store are written without Secret, NAuth issues their credentials.
+func (m *NscImportManager) writeUser(account *nscImportAccount, source nauth.NscUser, name string, store outbound.NscStoreReader, manifests outbound.ManifestWriter) (string, string, error) {
+	if name == "" {
+		return "name cannot be converted to a resource name", "", nil
+	}
+	if err := m.config.CryptoPolicy.verifyJWTAlgorithm(source.JWT); err != nil {
+		return "", "", fmt.Errorf("user jwt rejected: %w", err)
+	}
+	claims, err := jwt.DecodeUserClaims(source.JWT)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode user jwt: %w", err)
+	}
+	if !account.claims.DidSign(claims) {
+		return fmt.Sprintf("not signed by account %s", account.source.Name), "", nil
+	}
+	if claims.Expires != 0 && !time.Unix(claims.Expires, 0).After(m.now()) {
+		return "expired", "", nil
+	}
+
+	userClaims := toNAuthUserClaims(claims)
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: account.ref.Namespace,
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName:            account.ref.Name,
+			DisplayName:            userClaims.DisplayName,
+			Tags:                   userClaims.Tags,
+			ExpiresAt:              userClaims.ExpiresAt,
+			Permissions:            userClaims.Permissions,
+			UserLimits:             userClaims.UserLimits,
+			NatsLimits:             userClaims.NatsLimits,
+			BearerToken:            userClaims.BearerToken,
+			AllowedConnectionTypes: userClaims.AllowedConnectionTypes,
+		},
+	}
+
+	var warning string
+	userCreds := source.Creds
+	if len(userCreds) == 0 {
+		userKeyPair, found, err := store.ReadKey(claims.Subject)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read user seed: %w", err)
+		}
+		if found {
+			userSeed, err := userKeyPair.Seed()
+			if err != nil {
+				return "", "", fmt.Errorf("failed to get user seed: %w", err)
+			}
+			if userCreds, err = jwt.FormatUserConfig(source.JWT, userSeed); err != nil {
+				return "", "", fmt.Errorf("failed to format user credentials: %w", err)
+			}
+		}
+	}
+	if len(userCreds) == 0 {
+		warning = "no credentials found in keystore, NAuth issues new credentials"
+	} else {
+		secret := outbound.SecretApply{
+			Meta: metav1.ObjectMeta{
+				Name:      user.GetUserSecretName(),
+				Namespace: user.Namespace,
+				Labels: map[string]string{
+					domain.LabelSecretType: domain.SecretTypeUserCredentials,
+					domain.LabelManaged:    domain.LabelManagedValue,
+				},
+			},
+			Data: map[string]string{domain.UserCredentialSecretKeyName: string(userCreds)},
+		}
+		if err := manifests.WriteSecret(secret); err != nil {
+			return "", warning, err
+		}
+	}
+	return "", warning, manifests.WriteUser(user)
+}
+
+// toNscImportAccountSpec returns the AccountSpec producing the claims of an account JWT, and the claims it does not
+// carry over. Imports reference the Accounts in accountRefs, keyed by account ID.
+func toNscImportAccountSpec(claims *jwt.AccountClaims, accountRefs map[string]domain.NamespacedName) (*v1alpha1.AccountSpec, []string, error) {
+	nauthClaims, err := convertNatsAccountClaims(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	var warnings []string
+	spec := &v1alpha1.AccountSpec{
+		DisplayName:      nauthClaims.DisplayName,
+		Description:      nauthClaims.Description,
+		InfoURL:          nauthClaims.InfoURL,
+		Tags:             nauthClaims.Tags,
+		JetStreamEnabled: nauthClaims.JetStreamEnabled,
+		DisallowBearer:   nauthClaims.DisallowBearer,
+		ClusterTraffic:   v1alpha1.AccountClusterTraffic(nauthClaims.ClusterTraffic),
+	}
+	if trace := nauthClaims.Trace; trace != nil {
+		spec.Trace = &v1alpha1.MessageTrace{Destination: v1alpha1.Subject(trace.Destination), Sampling: trace.Sampling}
+	}
+	if limits := nauthClaims.AccountLimits; limits != nil {
+		spec.AccountLimits = &v1alpha1.AccountLimits{
+			Imports:         limits.Imports,
+			Exports:         limits.Exports,
+			WildcardExports: limits.WildcardExports,
+			Conn:            limits.Conn,
+			LeafNodeConn:    limits.LeafNodeConn,
+		}
+	}
+	if limits := nauthClaims.NatsLimits; limits != nil {
+		spec.NatsLimits = &v1alpha1.NatsLimits{Subs: limits.Subs, Data: limits.Data, Payload: limits.Payload}
+	}
+	spec.JetStreamLimits = toNscImportJetStreamLimits(nauthClaims.JetStreamLimits)
+	for tier, limits := range nauthClaims.JetStreamTieredLimits {
+		if spec.JetStreamTieredLimits == nil {
+			spec.JetStreamTieredLimits = make(v1alpha1.JetStreamTieredLimits, len(nauthClaims.JetStreamTieredLimits))
+		}
+		spec.JetStreamTieredLimits[tier] = *toNscImportJetStreamLimits(&limits)
+	}
+	for _, mapping := range nauthClaims.Mappings {
+		result := v1alpha1.SubjectMapping{Subject: v1alpha1.Subject(mapping.Subject)}
+		for _, destination := range mapping.Destinations {
+			resultDestination := v1alpha1.WeightedDestination{
+				Subject: v1alpha1.Subject(destination.Subject),
+				Cluster: destination.Cluster,
+			}
+			if destination.Weight != 0 {
+				resultDestination.Weight = new(int32(destination.Weight))
+			}
+			result.Destinations = append(result.Destinations, resultDestination)
+		}
+		spec.Mappings = append(spec.Mappings, result)
+	}
+	if permissions := nauthClaims.DefaultPermissions; permissions != nil {
+		spec.DefaultPermissions = &v1alpha1.Permissions{
+			Pub: v1alpha1.Permission{Allow: toNscImportSubjects(permissions.Pub.Allow), Deny: toNscImportSubjects(permissions.Pub.Deny)},
+			Sub: v1alpha1.Permission{Allow: toNscImportSubjects(permissions.Sub.Allow), Deny: toNscImportSubjects(permissions.Sub.Deny)},
+		}
+		if resp := permissions.Resp; resp != nil {
+			spec.DefaultPermissions.Resp = &v1alpha1.ResponsePermission{MaxMsgs: resp.MaxMsgs, Expires: resp.Expires}
+		}
+	}
+
+	// Export and response types are named alike in the JWT and the API
+	for _, export := range claims.Exports {
+		if export == nil {
+			continue
+		}
+		result := &v1alpha1.Export{
+			Name:                 export.Name,
+			Subject:              v1alpha1.Subject(export.Subject),
+			Type:                 v1alpha1.ExportType(export.Type.String()),
+			TokenReq:             export.TokenReq,
+			Revocations:          v1alpha1.RevocationList(export.Revocations),
+			ResponseType:         v1alpha1.ResponseType(export.ResponseType),
+			ResponseThreshold:    export.ResponseThreshold,
+			AccountTokenPosition: export.AccountTokenPosition,
+			Advertise:            export.Advertise,
+			AllowTrace:           export.AllowTrace,
+			AllowWildcard:        export.Subject.HasWildCards(),
+		}
+		if latency := export.Latency; latency != nil {
+			result.Latency = &v1alpha1.ServiceLatency{
+				Sampling: v1alpha1.SamplingRate(latency.Sampling),
+				Results:  v1alpha1.Subject(latency.Results),
+			}
+		}
+		spec.Exports = append(spec.Exports, result)
+	}
+	for _, imp := range claims.Imports {
+		if imp == nil {
+			continue
+		}
+		accountRef, found := accountRefs[imp.Account]
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("import of %q from account %s is dropped, the account is not imported",
+				imp.Subject, imp.Account))
+			continue
+		}
+		if imp.Token != "" {
+			warnings = append(warnings, fmt.Sprintf("activation token of the import of %q is dropped", imp.Subject))
+		}
+		spec.Imports = append(spec.Imports, &v1alpha1.Import{
+			AccountRef:   v1alpha1.AccountRef{Name: accountRef.Name, Namespace: accountRef.Namespace},
+			Name:         imp.Name,
+			Subject:      v1alpha1.Subject(imp.Subject),
+			LocalSubject: v1alpha1.RenamingSubject(imp.LocalSubject),
+			Type:         v1alpha1.ExportType(imp.Type.String()),
+			Share:        imp.Share,
+			AllowTrace:   imp.AllowTrace,
+		})
+	}
+	if len(claims.Revocations) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d user revocations are dropped", len(claims.Revocations)))
+	}
+	return spec, warnings, nil
+}
+
+func toNscImportJetStreamLimits(limits *nauth.JetStreamLimits) *v1alpha1.JetStreamLimits {
+	if limits == nil {
+		return nil
+	}
+	return &v1alpha1.JetStreamLimits{
+		MemoryStorage:        limits.MemoryStorage,
+		DiskStorage:          limits.DiskStorage,
+		Streams:              limits.Streams,
+		Consumer:             limits.Consumer,
+		MaxAckPending:        limits.MaxAckPending,
+		MemoryMaxStreamBytes: limits.MemoryMaxStreamBytes,
+		DiskMaxStreamBytes:   limits.DiskMaxStreamBytes,
+		MaxBytesRequired:     limits.MaxBytesRequired,
+	}
+}
+
+func toNscImportSubjects(subjects []nauth.Subject) v1alpha1.StringList {
+	var result v1alpha1.StringList
+	for _, subject := range subjects {
+		result = append(result, string(subject))
+	}
+	return result
+}
+
+var nscResourceNameInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// nscResourceName converts the name of an nsc account or user into a resource name, e.g. "Team.Orders" into
+// "team-orders". The result is empty when nothing of name is left.
+func nscResourceName(name string) string {
+	result := nscResourceNameInvalidChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(result) > validation.DNS1123LabelMaxLength {
+		result = result[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(result, "-")
+}
+
+var _ inbound.NscImportManager = (*NscImportManager)(nil)
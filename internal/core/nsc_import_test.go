@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type NscImportManagerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	operator testutil.NatsTestOperator
+	sys      testutil.NatsTestAccount
+	orders   testutil.NatsTestAccount
+	billing  testutil.NatsTestAccount
+
+	storeFake     *NscStoreReaderFake
+	manifestsFake *ManifestWriterFake
+
+	unitUnderTest *NscImportManager
+}
+
+func TestNscImportManager_TestSuite(t *testing.T) {
+	suite.Run(t, new(NscImportManagerTestSuite))
+}
+
+func (t *NscImportManagerTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.operator = testutil.CreateNatsTestOperator()
+	t.sys = testutil.CreateNatsTestAccount()
+	t.orders = testutil.CreateNatsTestAccount()
+	t.billing = testutil.CreateNatsTestAccount()
+
+	operatorClaims := jwt.NewOperatorClaims(t.operator.Root.PublicKey)
+	operatorClaims.Name = "main"
+	operatorClaims.SystemAccount = t.sys.AccountID()
+	operatorClaims.SigningKeys.Add(t.operator.Sign.PublicKey)
+	operatorJWT, err := operatorClaims.Encode(t.operator.Root.Key)
+	t.Require().NoError(err)
+
+	ordersClaims := t.newAccountClaims("Orders", t.orders)
+	ordersClaims.Exports.Add(&jwt.Export{Subject: "orders.>", Type: jwt.Stream})
+	billingClaims := t.newAccountClaims("Billing", t.billing)
+	billingClaims.Imports.Add(
+		&jwt.Import{Account: t.orders.AccountID(), Subject: "orders.>", Type: jwt.Stream},
+		&jwt.Import{Account: testutil.AnyNatsTestAccountID(), Subject: "partner.>", Type: jwt.Stream},
+	)
+	userKey := testutil.CreateNatsTestUserKey()
+	userClaims := jwt.NewUserClaims(userKey.PublicKey)
+	userClaims.Name = "app"
+	userClaims.IssuerAccount = t.billing.AccountID()
+	userClaims.Pub.Allow.Add("billing.>")
+	userJWT, err := userClaims.Encode(t.billing.Sign.Key)
+	t.Require().NoError(err)
+
+	t.storeFake = NewNscStoreReaderFake()
+	t.storeFake.operators["main"] = &nauth.NscOperator{
+		Name: "main",
+		JWT:  operatorJWT,
+		Accounts: []nauth.NscAccount{
+			{Name: "Billing", JWT: t.encodeAccountClaims(billingClaims), Users: []nauth.NscUser{{Name: "app", JWT: userJWT}}},
+			{Name: "Orders", JWT: t.encodeAccountClaims(ordersClaims)},
+			{Name: "SYS", JWT: t.encodeAccountClaims(t.newAccountClaims("SYS", t.sys))},
+		},
+	}
+	t.storeFake.addKeys(t.sys.Root.Key, t.sys.Sign.Key, t.orders.Root.Key, t.orders.Sign.Key, t.billing.Root.Key,
+		t.billing.Sign.Key, userKey.Key)
+	t.manifestsFake = NewManifestWriterFake()
+
+	t.unitUnderTest, err = NewNscImportManager(&Config{CryptoPolicy: CryptoPolicyDefault})
+	t.Require().NoError(err)
+}
+
+func (t *NscImportManagerTestSuite) Test_Import_ShouldWriteAccountsUsersAndSecrets() {
+	// When
+	result, err := t.unitUnderTest.Import(t.ctx, nauth.NscImportRequest{Namespace: "team"}, t.storeFake, t.manifestsFake)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(1, result.Users)
+	t.Equal(2, result.Accounts)
+	t.Equal([]string{"account SYS: system account"}, result.Skipped)
+	t.Require().Len(result.Warnings, 1)
+	t.Contains(result.Warnings[0], `account Billing: import of "partner.>" from account`)
+
+	t.Require().Len(t.manifestsFake.accounts, 2)
+	billing := t.manifestsFake.accounts[0]
+	t.Equal("billing", billing.Name)
+	t.Equal("team", billing.Namespace)
+	t.Equal(map[string]string{string(v1alpha1.AccountLabelAccountID): t.billing.AccountID()}, billing.Labels)
+	t.Equal("Billing", billing.Spec.DisplayName)
+	t.Equal(v1alpha1.Imports{{
+		AccountRef: v1alpha1.AccountRef{Name: "orders", Namespace: "team"},
+		Subject:    "orders.>",
+		Type:       v1alpha1.Stream,
+	}}, billing.Spec.Imports)
+	orders := t.manifestsFake.accounts[1]
+	t.Equal("orders", orders.Name)
+	t.Equal(v1alpha1.Exports{{Subject: "orders.>", Type: v1alpha1.Stream, AllowWildcard: true}}, orders.Spec.Exports)
+
+	t.Require().Len(t.manifestsFake.users, 1)
+	user := t.manifestsFake.users[0]
+	t.Equal("app", user.Name)
+	t.Equal("team", user.Namespace)
+	t.Equal("billing", user.Spec.AccountName)
+	t.Equal(&v1alpha1.Permissions{Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"billing.>"}}}, user.Spec.Permissions)
+
+	t.Require().Len(t.manifestsFake.secrets, 5)
+	billingRoot := t.manifestsFake.secrets[0]
+	t.Equal(map[string]string{
+		SecretLabelAccountID:   t.billing.AccountID(),
+		SecretLabelAccountName: "billing",
+		k8s.LabelSecretType:    k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:       k8s.LabelManagedValue,
+	}, billingRoot.Meta.Labels)
+	t.Equal(map[string]string{k8s.DefaultSecretKeyName: string(t.billing.Root.Seed)}, billingRoot.Data)
+	billingSign := t.manifestsFake.secrets[1]
+	t.Equal(k8s.SecretTypeAccountSign, billingSign.Meta.Labels[k8s.LabelSecretType])
+	t.Equal(map[string]string{k8s.DefaultSecretKeyName: string(t.billing.Sign.Seed)}, billingSign.Data)
+	userSecret := t.manifestsFake.secrets[2]
+	t.Equal("app-nats-user-creds", userSecret.Meta.Name)
+	t.Equal(k8s.SecretTypeUserCredentials, userSecret.Meta.Labels[k8s.LabelSecretType])
+	t.Contains(userSecret.Data[k8s.UserCredentialSecretKeyName], "BEGIN NATS USER JWT")
+}
+
+func (t *NscImportManagerTestSuite) Test_Import_ShouldWriteObservedAccounts_WhenObserve() {
+	// When
+	result, err := t.unitUnderTest.Import(t.ctx, nauth.NscImportRequest{Operator: "main", Namespace: "team", Observe: true},
+		t.storeFake, t.manifestsFake)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(2, result.Accounts)
+	t.Empty(result.Warnings)
+	for _, account := range t.manifestsFake.accounts {
+		t.Equal(v1alpha1.AccountManagementPolicyObserve, account.GetLabel(v1alpha1.AccountLabelManagementPolicy))
+		t.Equal(v1alpha1.AccountSpec{}, account.Spec)
+	}
+}
+
+func (t *NscImportManagerTestSuite) Test_Import_ShouldSkipAccount_WhenSigningKeySeedIsMissing() {
+	// Given
+	delete(t.storeFake.keys, t.orders.Sign.PublicKey)
+
+	// When
+	result, err := t.unitUnderTest.Import(t.ctx, nauth.NscImportRequest{Namespace: "team"}, t.storeFake, t.manifestsFake)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(1, result.Accounts)
+	t.Contains(result.Skipped, "account Orders: no signing key seed found in keystore, add a signing key with nsc edit account --sk generate")
+	t.Require().Len(result.Warnings, 2)
+	t.Contains(result.Warnings[0], `account Billing: import of "orders.>" from account`)
+}
+
+func (t *NscImportManagerTestSuite) Test_Import_ShouldSkipUser_WhenExpired() {
+	// Given
+	userKey := testutil.CreateNatsTestUserKey()
+	userClaims := jwt.NewUserClaims(userKey.PublicKey)
+	userClaims.IssuerAccount = t.orders.AccountID()
+	userClaims.Expires = time.Now().Add(-time.Hour).Unix()
+	userJWT, err := userClaims.Encode(t.orders.Sign.Key)
+	t.Require().NoError(err)
+	t.storeFake.operators["main"].Accounts[1].Users = []nauth.NscUser{{Name: "old", JWT: userJWT}}
+
+	// When
+	result, err := t.unitUnderTest.Import(t.ctx, nauth.NscImportRequest{Namespace: "team"}, t.storeFake, t.manifestsFake)
+
+	// Then
+	t.Require().NoError(err)
+	t.Equal(1, result.Users)
+	t.Contains(result.Skipped, "user old: expired")
+}
+
+func (t *NscImportManagerTestSuite) Test_Import_ShouldFail_WhenOperatorIsAmbiguous() {
+	// Given
+	t.storeFake.operators["other"] = &nauth.NscOperator{Name: "other"}
+
+	// When
+	_, err := t.unitUnderTest.Import(t.ctx, nauth.NscImportRequest{Namespace: "team"}, t.storeFake, t.manifestsFake)
+
+	// Then
+	t.ErrorContains(err, "the nsc store holds 2 operators, select one of [main, other]")
+}
+
+func (t *NscImportManagerTestSuite) newAccountClaims(name string, account testutil.NatsTestAccount) *jwt.AccountClaims {
+	claims := jwt.NewAccountClaims(account.AccountID())
+	claims.Name = name
+	claims.SigningKeys.Add(account.Sign.PublicKey)
+	return claims
+}
+
+func (t *NscImportManagerTestSuite) encodeAccountClaims(claims *jwt.AccountClaims) string {
+	accountJWT, err := claims.Encode(t.operator.Sign.Key)
+	t.Require().NoError(err)
+	return accountJWT
+}
+
+func Test_nscResourceName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "orders", expected: "orders"},
+		{name: "Team.Orders", expected: "team-orders"},
+		{name: "my_app (prod)", expected: "my-app-prod"},
+		{name: "..", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q", tc.name), func(t *testing.T) {
+			assert.Equal(t, tc.expected, nscResourceName(tc.name))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+	"github.com/nats-io/jwt/v2"
+)
+
+// ResolverConfigManager renders the resolver configuration of NATS servers from the account JWTs NAuth has pushed, so
+// servers can be bootstrapped with a MEMORY resolver instead of a running full resolver.
+type ResolverConfigManager struct {
+	natsSysClient outbound.NatsSysClient
+}
+
+func NewResolverConfigManager(natsSysClient outbound.NatsSysClient) (*ResolverConfigManager, error) {
+	m := &ResolverConfigManager{
+		natsSysClient: natsSysClient,
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid ResolverConfigManager: %w", err)
+	}
+	return m, nil
+}
+
+func (m *ResolverConfigManager) validate() error {
+	if m.natsSysClient == nil {
+		return fmt.Errorf("natsSysClient is required")
+	}
+	return nil
+}
+
+// Render returns the resolver configuration preloading the system account and the requested accounts with the JWTs
+// currently known by the NATS cluster. The configuration is stable for unchanged JWTs.
+func (m *ResolverConfigManager) Render(ctx context.Context, request nauth.ResolverConfigRequest) (string, error) {
+	if err := request.Validate(); err != nil {
+		return "", domain.ErrBadRequest.WithCause(fmt.Errorf("invalid resolver config request: %w", err))
+	}
+	target := request.ClusterTarget
+	if err := verifyOperatorJWT(request.OperatorJWT, target.OperatorSigningKey); err != nil {
+		return "", domain.ErrBadRequest.WithCause(err)
+	}
+
+	sysConn, err := m.natsSysClient.Connect(ctx, target.NatsURL, target.SystemAdminCreds, target.TLS)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to NATS cluster: %w", err)
+	}
+	defer sysConn.Disconnect()
+
+	systemAccountID := target.SystemAccountID()
+	accountIDs := []nauth.AccountID{systemAccountID}
+	for _, accountID := range request.AccountIDs {
+		if accountID != "" && !slices.Contains(accountIDs, accountID) {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	slices.Sort(accountIDs)
+
+	accountJWTs := make(map[nauth.AccountID]string, len(accountIDs))
+	for _, accountID := range accountIDs {
+		accountJWT, err := sysConn.LookupAccountJWT(string(accountID))
+		if err != nil {
+			return "", fmt.Errorf("failed to lookup account jwt for account %s: %w", accountID, err)
+		}
+		if accountJWT == "" {
+			return "", fmt.Errorf("account jwt for account %s not found", accountID)
+		}
+		accountJWTs[accountID] = accountJWT
+	}
+	return renderResolverConfig(request.OperatorJWT, systemAccountID, accountIDs, accountJWTs), nil
+}
+
+// verifyOperatorJWT verifies that operatorJWT is the JWT of the operator signingKey belongs to.
+func verifyOperatorJWT(operatorJWT string, signingKey domain.NatsOperatorSigningKey) error {
+	claims, err := jwt.DecodeOperatorClaims(operatorJWT)
+	if err != nil {
+		return fmt.Errorf("failed to decode operator JWT: %w", err)
+	}
+	publicKey, err := signingKey.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get operator signing key public key: %w", err)
+	}
+	if claims.Subject != publicKey && !claims.SigningKeys.Contains(publicKey) {
+		return fmt.Errorf("operator JWT of %s does not contain operator signing key %s", claims.Subject, publicKey)
+	}
+	return nil
+}
+
+func renderResolverConfig(operatorJWT string, systemAccountID nauth.AccountID, accountIDs []nauth.AccountID, accountJWTs map[nauth.AccountID]string) string {
+	var config strings.Builder
+	config.WriteString("# Generated by nauth, do not edit.\n")
+	fmt.Fprintf(&config, "operator: %s\n", operatorJWT)
+	fmt.Fprintf(&config, "system_account: %s\n", systemAccountID)
+	config.WriteString("resolver: MEMORY\n")
+	config.WriteString("resolver_preload: {\n")
+	for _, accountID := range accountIDs {
+		fmt.Fprintf(&config, "  %s: %s\n", accountID, accountJWTs[accountID])
+	}
+	config.WriteString("}\n")
+	return config.String()
+}
+
+var _ inbound.ResolverConfigManager = (*ResolverConfigManager)(nil)
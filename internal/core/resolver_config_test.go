@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+type ResolverConfigTestSuite struct {
+	suite.Suite
+	ctx               context.Context
+	natsSysClientMock *NatsSysClientMock
+	natsSysConnMock   *NatsSysConnectionMock
+	operator          testutil.NatsTestOperator
+	target            nauth.ClusterTarget
+	unitUnderTest     *ResolverConfigManager
+}
+
+func TestResolverConfigManager_TestSuite(t *testing.T) {
+	suite.Run(t, new(ResolverConfigTestSuite))
+}
+
+func (t *ResolverConfigTestSuite) SetupTest() {
+	t.ctx = context.Background()
+	t.natsSysClientMock = NewNatsSysClientMock()
+	t.natsSysConnMock = NewNatsSysConnectionMock()
+	t.operator = testutil.CreateNatsTestOperator()
+	t.target = t.generateClusterTarget()
+
+	var err error
+	t.unitUnderTest, err = NewResolverConfigManager(t.natsSysClientMock)
+	t.Require().NoError(err)
+}
+
+func (t *ResolverConfigTestSuite) TearDownTest() {
+	t.natsSysClientMock.AssertExpectations(t.T())
+	t.natsSysConnMock.AssertExpectations(t.T())
+}
+
+func (t *ResolverConfigTestSuite) Test_Render_ShouldPreloadSystemAccountAndAccounts() {
+	// Given
+	operatorJWT := t.operatorJWT(t.operator.Sign.PublicKey)
+	systemAccountID := string(t.target.SystemAccountID())
+	t.natsSysClientMock.mockConnect(t.target.NatsURL, t.target.SystemAdminCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockDisconnect()
+	t.natsSysConnMock.mockLookupAccountJWT(systemAccountID, "SYS-JWT")
+	t.natsSysConnMock.mockLookupAccountJWT("ACCOUNT-B", "B-JWT")
+	t.natsSysConnMock.mockLookupAccountJWT("ACCOUNT-A", "A-JWT")
+
+	// When
+	result, err := t.unitUnderTest.Render(t.ctx, nauth.ResolverConfigRequest{
+		ClusterTarget: t.target,
+		OperatorJWT:   operatorJWT,
+		AccountIDs:    []nauth.AccountID{"ACCOUNT-B", "ACCOUNT-A", "ACCOUNT-B", nauth.AccountID(systemAccountID)},
+	})
+
+	// Then
+	require.NoError(t.T(), err)
+	accountJWTs := map[string]string{systemAccountID: "SYS-JWT", "ACCOUNT-A": "A-JWT", "ACCOUNT-B": "B-JWT"}
+	accountIDs := slices.Sorted(maps.Keys(accountJWTs))
+	expected := "# Generated by nauth, do not edit.\n" +
+		"operator: " + operatorJWT + "\n" +
+		"system_account: " + systemAccountID + "\n" +
+		"resolver: MEMORY\n" +
+		"resolver_preload: {\n"
+	for _, accountID := range accountIDs {
+		expected += "  " + accountID + ": " + accountJWTs[accountID] + "\n"
+	}
+	expected += "}\n"
+	require.Equal(t.T(), expected, result)
+}
+
+func (t *ResolverConfigTestSuite) Test_Render_ShouldFail_WhenOperatorJWTDoesNotMatchSigningKey() {
+	// When
+	_, err := t.unitUnderTest.Render(t.ctx, nauth.ResolverConfigRequest{
+		ClusterTarget: t.target,
+		OperatorJWT:   t.operatorJWT(testutil.CreateNatsTestOperatorKey().PublicKey),
+	})
+
+	// Then
+	require.ErrorIs(t.T(), err, domain.ErrBadRequest)
+	require.ErrorContains(t.T(), err, "does not contain operator signing key")
+}
+
+func (t *ResolverConfigTestSuite) Test_Render_ShouldFail_WhenOperatorJWTMissing() {
+	// When
+	_, err := t.unitUnderTest.Render(t.ctx, nauth.ResolverConfigRequest{ClusterTarget: t.target})
+
+	// Then
+	require.ErrorIs(t.T(), err, domain.ErrBadRequest)
+	require.ErrorContains(t.T(), err, "operator JWT is required")
+}
+
+func (t *ResolverConfigTestSuite) Test_Render_ShouldFail_WhenAccountJWTNotFound() {
+	// Given
+	t.natsSysClientMock.mockConnect(t.target.NatsURL, t.target.SystemAdminCreds, t.natsSysConnMock)
+	t.natsSysConnMock.mockDisconnect()
+	// The system account is only looked up when it sorts before the missing account
+	t.natsSysConnMock.On("LookupAccountJWT", string(t.target.SystemAccountID())).Return("SYS-JWT", nil).Maybe()
+	t.natsSysConnMock.mockLookupAccountJWT("ACCOUNT-A", "")
+
+	// When
+	_, err := t.unitUnderTest.Render(t.ctx, nauth.ResolverConfigRequest{
+		ClusterTarget: t.target,
+		OperatorJWT:   t.operatorJWT(t.operator.Sign.PublicKey),
+		AccountIDs:    []nauth.AccountID{"ACCOUNT-A"},
+	})
+
+	// Then
+	require.ErrorContains(t.T(), err, "account jwt for account ACCOUNT-A not found")
+}
+
+func (t *ResolverConfigTestSuite) Test_Render_ShouldFail_WhenConnectFails() {
+	// Given
+	t.natsSysClientMock.mockConnectError(t.target.NatsURL, t.target.SystemAdminCreds, fmt.Errorf("connection refused"))
+
+	// When
+	_, err := t.unitUnderTest.Render(t.ctx, nauth.ResolverConfigRequest{
+		ClusterTarget: t.target,
+		OperatorJWT:   t.operatorJWT(t.operator.Sign.PublicKey),
+	})
+
+	// Then
+	require.ErrorContains(t.T(), err, "failed to connect to NATS cluster: connection refused")
+}
+
+func (t *ResolverConfigTestSuite) operatorJWT(signingKeys ...string) string {
+	claims := jwt.NewOperatorClaims(t.operator.Root.PublicKey)
+	claims.SigningKeys.Add(signingKeys...)
+	operatorJWT, err := claims.Encode(t.operator.Root.Key)
+	t.Require().NoError(err)
+	return operatorJWT
+}
+
+func (t *ResolverConfigTestSuite) generateClusterTarget() nauth.ClusterTarget {
+	ac := testutil.CreateNatsTestAccount()
+	sau := testutil.CreateNatsTestUserKey()
+
+	sauClaims := jwt.NewUserClaims(sau.PublicKey)
+	sauClaims.IssuerAccount = ac.Root.PublicKey
+	sauJwt, err := sauClaims.Encode(ac.Root.Key)
+	t.Require().NoError(err)
+	sauCreds, err := jwt.FormatUserConfig(sauJwt, sau.Seed)
+	t.Require().NoError(err)
+	sauNatsUserCreds, err := domain.NewNatsUserCreds(sauCreds)
+	t.Require().NoError(err)
+
+	return nauth.ClusterTarget{
+		UID:                string(uuid.NewUUID()),
+		NatsURL:            "nats://my-cluster:4222",
+		OperatorSigningKey: t.operator.Sign.Key,
+		SystemAdminCreds:   *sauNatsUserCreds,
+	}
+}
@@ -2,15 +2,15 @@ package core
 
 import (
 	"context"
-	"crypto/md5"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	"io"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
 	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/nats-io/nkeys"
 	v1 "k8s.io/api/core/v1"
@@ -21,6 +21,8 @@ import (
 const (
 	SecretLabelAccountID   = "account.nauth.io/id"
 	SecretLabelAccountName = "account.nauth.io/name"
+	// SecretLabelAccountNamespace is the namespace of the Account of secrets stored in the account secrets namespace.
+	SecretLabelAccountNamespace = "account.nauth.io/namespace"
 )
 
 type Secrets struct {
@@ -33,43 +35,94 @@ type secretManager interface {
 	ApplySignSecret(ctx context.Context, accountRef domain.NamespacedName, accountID string, signKeyPair nkeys.KeyPair) error
 	DeleteAll(ctx context.Context, accountRef domain.NamespacedName, accountID string) error
 	GetSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (*Secrets, bool, error)
+	// GetPendingSecrets returns the keys of an account whose creation was interrupted, stored by ApplyPendingSecrets.
+	GetPendingSecrets(ctx context.Context, accountRef domain.NamespacedName) (*Secrets, bool, error)
+	// ApplyPendingSecrets stores the keys of an account before its root and signing key secrets are applied, so an
+	// interrupted creation is resumed with the same keys.
+	ApplyPendingSecrets(ctx context.Context, accountRef domain.NamespacedName, secrets *Secrets) error
+	// DeletePendingSecrets deletes the keys stored by ApplyPendingSecrets once the account secrets are applied.
+	DeletePendingSecrets(ctx context.Context, accountRef domain.NamespacedName) error
+	// MigrateSecrets renames the secrets of an account to the names of the secret name template, and reports whether
+	// any secret was renamed.
+	MigrateSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (bool, error)
+	// FindAccountRef returns the Account the secrets of accountID are stored for, in any namespace.
+	FindAccountRef(ctx context.Context, accountID string) (domain.NamespacedName, bool, error)
+	// MoveSecrets moves the secrets of accountID from the Account from to the Account to.
+	MoveSecrets(ctx context.Context, from domain.NamespacedName, to domain.NamespacedName, accountID string) error
+	// GetProvidedSecrets returns the account keys generated outside of NAuth, held by the Secrets keySecrets in the
+	// namespace of the Account.
+	GetProvidedSecrets(ctx context.Context, accountRef domain.NamespacedName, keySecrets nauth.AccountKeySecrets) (*Secrets, error)
 }
 
 type secretManagerImpl struct {
 	secretClient outbound.SecretClient
+	cryptoPolicy CryptoPolicy
+	// secretsNamespace stores the secrets of all accounts when set, instead of the namespace of each Account.
+	secretsNamespace domain.Namespace
+	nameTemplate     SecretNameTemplate
 }
 
-func newSecretManagerImpl(secretClient outbound.SecretClient) (*secretManagerImpl, error) {
+func newSecretManagerImpl(secretClient outbound.SecretClient, cryptoPolicy CryptoPolicy, secretsNamespace domain.Namespace, nameTemplate SecretNameTemplate) (*secretManagerImpl, error) {
 	if secretClient == nil {
 		return nil, fmt.Errorf("secret client is required")
 	}
+	if err := cryptoPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if nameTemplate == "" {
+		nameTemplate = DefaultSecretNameTemplate
+	}
+	if err := nameTemplate.Validate(); err != nil {
+		return nil, err
+	}
+
+	if secretsNamespace != "" {
+		if err := secretsNamespace.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid account secrets namespace %q: %w", secretsNamespace, err)
+		}
+	}
 
 	return &secretManagerImpl{
-		secretClient: secretClient,
+		secretClient:     secretClient,
+		cryptoPolicy:     cryptoPolicy,
+		secretsNamespace: secretsNamespace,
+		nameTemplate:     nameTemplate,
 	}, nil
 }
 
 func (m *secretManagerImpl) ApplyRootSecret(ctx context.Context, accountRef domain.NamespacedName, rootKeyPair nkeys.KeyPair) error {
-	accountID, err := rootKeyPair.PublicKey()
+	secret, err := m.rootSecret(accountRef, rootKeyPair)
 	if err != nil {
-		return fmt.Errorf("failed to get public key from account root secret: %w", err)
+		return err
 	}
-	return m.applyAccountSecret(ctx, accountRef, accountID, SecretNameAccountRootTemplate, k8s.SecretTypeAccountRoot, rootKeyPair)
+	return m.applyAccountSecret(ctx, secret)
 }
 
 func (m *secretManagerImpl) ApplySignSecret(ctx context.Context, accountRef domain.NamespacedName, accountID string, signKeyPair nkeys.KeyPair) error {
-	return m.applyAccountSecret(ctx, accountRef, accountID, SecretNameAccountSignTemplate, k8s.SecretTypeAccountSign, signKeyPair)
+	secret, err := m.accountSecret(accountRef, accountID, k8s.SecretTypeAccountSign, signKeyPair)
+	if err != nil {
+		return err
+	}
+	return m.applyAccountSecret(ctx, secret)
+}
+
+func (m *secretManagerImpl) rootSecret(accountRef domain.NamespacedName, rootKeyPair nkeys.KeyPair) (outbound.SecretApply, error) {
+	accountID, err := rootKeyPair.PublicKey()
+	if err != nil {
+		return outbound.SecretApply{}, fmt.Errorf("failed to get public key from account root secret: %w", err)
+	}
+	return m.accountSecret(accountRef, accountID, k8s.SecretTypeAccountRoot, rootKeyPair)
 }
 
-func (m *secretManagerImpl) applyAccountSecret(ctx context.Context, accountRef domain.NamespacedName, accountID, nameTemplate, secretType string, keyPair nkeys.KeyPair) error {
+func (m *secretManagerImpl) accountSecret(accountRef domain.NamespacedName, accountID, secretType string, keyPair nkeys.KeyPair) (outbound.SecretApply, error) {
 	if err := accountRef.Validate(); err != nil {
-		return fmt.Errorf("invalid account reference %s: %w", accountRef, err)
+		return outbound.SecretApply{}, fmt.Errorf("invalid account reference %s: %w", accountRef, err)
 	}
 	if accountID == "" {
-		return fmt.Errorf("account ID cannot be empty")
+		return outbound.SecretApply{}, fmt.Errorf("account ID cannot be empty")
 	}
 
-	secretName := fmt.Sprintf(nameTemplate, accountRef.Name, mustGenerateShortHashFromID(accountID))
+	secretName := m.nameTemplate.secretName(accountRef, accountID, secretType, m.cryptoPolicy.shortHashFromID(accountID))
 	secretMeta := metav1.ObjectMeta{
 		Name:      secretName,
 		Namespace: accountRef.Namespace,
@@ -80,20 +133,131 @@ func (m *secretManagerImpl) applyAccountSecret(ctx context.Context, accountRef d
 			k8s.LabelManaged:       k8s.LabelManagedValue,
 		},
 	}
+	if m.secretsNamespace != "" {
+		secretMeta.Namespace = string(m.secretsNamespace)
+		secretMeta.Labels[SecretLabelAccountNamespace] = accountRef.Namespace
+	}
 	seed, err := keyPair.Seed()
 	if err != nil {
-		return fmt.Errorf("failed to get seed from key pair: %w", err)
+		return outbound.SecretApply{}, fmt.Errorf("failed to get seed from key pair: %w", err)
 	}
-	accountSecretValue := map[string]string{k8s.DefaultSecretKeyName: string(seed)}
+	return outbound.SecretApply{
+		Meta: secretMeta,
+		Data: map[string]string{k8s.DefaultSecretKeyName: string(seed)},
+	}, nil
+}
 
-	// Intentionally do not set an owner reference on account secrets. If the Account resource is deleted by mistake,
-	// the secrets should remain so the same account can be recreated from the preserved root seed.
-	if err = m.secretClient.Apply(ctx, nil, secretMeta, accountSecretValue); err != nil {
+// Intentionally do not set an owner reference on account secrets. If the Account resource is deleted by mistake,
+// the secrets should remain so the same account can be recreated from the preserved root seed.
+func (m *secretManagerImpl) applyAccountSecret(ctx context.Context, secret outbound.SecretApply) error {
+	if err := m.checkNameCollision(ctx, secret, nil); err != nil {
+		return err
+	}
+	if err := m.secretClient.Apply(ctx, nil, secret.Meta, secret.Data); err != nil {
 		return fmt.Errorf("unable to apply secret: %w", err)
 	}
 	return nil
 }
 
+// checkNameCollision returns domain.ErrSecretNameCollision when the name of secret is taken by a secret of another
+// account, e.g. when the short hashes of two account IDs collide. The secret of the same account stored for movedFrom,
+// optional, is replaced when moving the secrets and does not collide.
+func (m *secretManagerImpl) checkNameCollision(ctx context.Context, secret outbound.SecretApply, movedFrom *domain.NamespacedName) error {
+	existing, err := m.secretClient.GetByLabels(ctx, domain.Namespace(secret.Meta.Namespace), map[string]string{
+		k8s.LabelSecretType: secret.Meta.Labels[k8s.LabelSecretType],
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check secret %s/%s for name collisions: %w", secret.Meta.Namespace, secret.Meta.Name, err)
+	}
+	for _, item := range existing.Items {
+		if item.Name != secret.Meta.Name {
+			continue
+		}
+		accountID := item.Labels[SecretLabelAccountID]
+		accountNamespace := item.Labels[SecretLabelAccountNamespace]
+		if movedFrom != nil && accountID == secret.Meta.Labels[SecretLabelAccountID] && accountNamespace == movedFrom.Namespace {
+			continue
+		}
+		if accountID != secret.Meta.Labels[SecretLabelAccountID] || accountNamespace != secret.Meta.Labels[SecretLabelAccountNamespace] {
+			return domain.ErrSecretNameCollision.WithCause(fmt.Errorf("secret %s/%s already holds the keys of account %s",
+				secret.Meta.Namespace, secret.Meta.Name, accountID))
+		}
+	}
+	return nil
+}
+
+func (m *secretManagerImpl) GetPendingSecrets(ctx context.Context, accountRef domain.NamespacedName) (*Secrets, bool, error) {
+	secretRef, err := m.pendingSecretRef(accountRef)
+	if err != nil {
+		return nil, false, err
+	}
+	data, found, err := m.secretClient.Get(ctx, secretRef)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	result, err := m.toAccountSecrets(map[string]map[string]string{
+		k8s.SecretTypeAccountRoot: {k8s.DefaultSecretKeyName: data[k8s.SecretTypeAccountRoot]},
+		k8s.SecretTypeAccountSign: {k8s.DefaultSecretKeyName: data[k8s.SecretTypeAccountSign]},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid pending account secret %s: %w", secretRef, err)
+	}
+	return result, true, nil
+}
+
+func (m *secretManagerImpl) ApplyPendingSecrets(ctx context.Context, accountRef domain.NamespacedName, secrets *Secrets) error {
+	secretRef, err := m.pendingSecretRef(accountRef)
+	if err != nil {
+		return err
+	}
+	rootSeed, err := secrets.Root.Seed()
+	if err != nil {
+		return fmt.Errorf("failed to get seed from account root key pair: %w", err)
+	}
+	signSeed, err := secrets.Sign.Seed()
+	if err != nil {
+		return fmt.Errorf("failed to get seed from account signing key pair: %w", err)
+	}
+	secretMeta := metav1.ObjectMeta{
+		Name:      secretRef.Name,
+		Namespace: secretRef.Namespace,
+		Labels: map[string]string{
+			domain.LabelSecretType: domain.SecretTypeAccountPending,
+			domain.LabelManaged:    domain.LabelManagedValue,
+		},
+	}
+	if err := m.secretClient.Apply(ctx, nil, secretMeta, map[string]string{
+		domain.SecretTypeAccountRoot: string(rootSeed),
+		domain.SecretTypeAccountSign: string(signSeed),
+	}); err != nil {
+		return fmt.Errorf("unable to apply pending account secret %s: %w", secretRef, err)
+	}
+	return nil
+}
+
+func (m *secretManagerImpl) DeletePendingSecrets(ctx context.Context, accountRef domain.NamespacedName) error {
+	secretRef, err := m.pendingSecretRef(accountRef)
+	if err != nil {
+		return err
+	}
+	return m.secretClient.Delete(ctx, secretRef)
+}
+
+// pendingSecretRef names the pending secret after the Account, as the account ID is not known to a retry. The pending
+// secret has no account labels, so it is never taken for a secret of the account.
+func (m *secretManagerImpl) pendingSecretRef(accountRef domain.NamespacedName) (domain.NamespacedName, error) {
+	if err := accountRef.Validate(); err != nil {
+		return domain.NamespacedName{}, fmt.Errorf("invalid account reference %s: %w", accountRef, err)
+	}
+	if m.secretsNamespace != "" {
+		// Namespaces contain no dots, so the name is unique for the Account
+		name := fmt.Sprintf(domain.SecretNameAccountPendingTemplate, accountRef.Namespace+"."+accountRef.Name)
+		return m.secretsNamespace.WithName(name), nil
+	}
+	return accountRef.GetNamespace().WithName(fmt.Sprintf(domain.SecretNameAccountPendingTemplate, accountRef.Name)), nil
+}
+
 func (m *secretManagerImpl) DeleteAll(ctx context.Context, accountRef domain.NamespacedName, accountID string) error {
 	if err := accountRef.Validate(); err != nil {
 		return fmt.Errorf("invalid account reference %s: %w", accountRef, err)
@@ -108,28 +272,405 @@ func (m *secretManagerImpl) DeleteAll(ctx context.Context, accountRef domain.Nam
 	// they are not labelled correctly. This also allows for better error handling and logging of which secrets were
 	// attempted to be deleted.
 	// TODO: Consider secrets labelled nauth.io/managed=true, should we only delete those?
+	if m.secretsNamespace != "" {
+		labels[SecretLabelAccountNamespace] = accountRef.Namespace
+		if err := m.secretClient.DeleteByLabels(ctx, m.secretsNamespace, labels); err != nil {
+			return err
+		}
+		// Secrets are not listed in the namespace of the Account, secrets not moved yet are deleted by name
+		for _, secretRef := range m.accountNamespaceSecretRefs(accountRef, accountID) {
+			if err := m.secretClient.Delete(ctx, secretRef); err != nil {
+				return fmt.Errorf("failed to delete secret %s: %w", secretRef, err)
+			}
+		}
+		return nil
+	}
 	return m.secretClient.DeleteByLabels(ctx, accountRef.GetNamespace(), labels)
 }
 
-func mustGenerateShortHashFromID(ID string) string {
-	hasher := md5.New()
-	_, err := io.WriteString(hasher, ID)
+// accountNamespaceSecretRefs returns the names the secrets of an account may have in the namespace of the Account,
+// see templateSecretRefs and deprecatedSecretRefs.
+func (m *secretManagerImpl) accountNamespaceSecretRefs(accountRef domain.NamespacedName, accountID string) []domain.NamespacedName {
+	var secretRefs []domain.NamespacedName
+	if accountID != "" {
+		secretRefs = m.templateSecretRefs(accountRef, accountID)
+	}
+	return append(secretRefs, deprecatedSecretRefs(accountRef)...)
+}
+
+// templateSecretRefs returns the references of the root and signing key secrets of an account in the namespace of the
+// Account, named by the secret name template.
+func (m *secretManagerImpl) templateSecretRefs(accountRef domain.NamespacedName, accountID string) []domain.NamespacedName {
+	namespace := accountRef.GetNamespace()
+	hash := m.cryptoPolicy.shortHashFromID(accountID)
+	return []domain.NamespacedName{
+		namespace.WithName(m.nameTemplate.secretName(accountRef, accountID, k8s.SecretTypeAccountRoot, hash)),
+		namespace.WithName(m.nameTemplate.secretName(accountRef, accountID, k8s.SecretTypeAccountSign, hash)),
+	}
+}
+
+// deprecatedSecretRefs returns the references of the root and signing key secrets of an account with deprecated names.
+func deprecatedSecretRefs(accountRef domain.NamespacedName) []domain.NamespacedName {
+	namespace := accountRef.GetNamespace()
+	return []domain.NamespacedName{
+		namespace.WithName(fmt.Sprintf(k8s.DeprecatedSecretNameAccountRootTemplate, accountRef.Name)),
+		namespace.WithName(fmt.Sprintf(k8s.DeprecatedSecretNameAccountSignTemplate, accountRef.Name)),
+	}
+}
+
+func (m *secretManagerImpl) MigrateSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (bool, error) {
+	if accountID == "" {
+		return false, fmt.Errorf("account ID cannot be empty")
+	}
+	// Looking up the secrets labels secrets with deprecated names and moves secrets to the account secrets namespace
+	secrets, found, err := m.GetSecrets(ctx, accountRef, accountID)
+	if err != nil || !found {
+		return false, err
+	}
+	rootSecret, err := m.rootSecret(accountRef, secrets.Root)
+	if err != nil {
+		return false, err
+	}
+	signSecret, err := m.accountSecret(accountRef, accountID, k8s.SecretTypeAccountSign, secrets.Sign)
+	if err != nil {
+		return false, err
+	}
+
+	namespace := domain.Namespace(rootSecret.Meta.Namespace)
+	labels := map[string]string{
+		SecretLabelAccountID: accountID,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}
+	if m.secretsNamespace != "" {
+		labels[SecretLabelAccountNamespace] = accountRef.Namespace
+	}
+	existing, err := m.secretClient.GetByLabels(ctx, namespace, labels)
+	if err != nil {
+		return false, fmt.Errorf("failed to get account secrets from namespace %s: %w", namespace, err)
+	}
+	var renamed []domain.NamespacedName
+	for _, item := range existing.Items {
+		if item.Name != rootSecret.Meta.Name && item.Name != signSecret.Meta.Name {
+			renamed = append(renamed, namespace.WithName(item.Name))
+		}
+	}
+	if len(renamed) == 0 {
+		return false, nil
+	}
+
+	for _, secret := range []outbound.SecretApply{rootSecret, signSecret} {
+		if err := m.checkNameCollision(ctx, secret, nil); err != nil {
+			return false, err
+		}
+	}
+	// The secrets are deleted only once both are stored under their new names, so a failed migration can be rerun
+	if err := m.secretClient.ApplyAll(ctx, nil, []outbound.SecretApply{rootSecret, signSecret}); err != nil {
+		return false, err
+	}
+	for _, secretRef := range renamed {
+		if err := m.secretClient.Delete(ctx, secretRef); err != nil {
+			return false, fmt.Errorf("failed to delete renamed secret %s: %w", secretRef, err)
+		}
+	}
+	logf.FromContext(ctx).Info("Renamed account secrets", "accountRef", accountRef, "accountID", accountID,
+		"renamed", renamed)
+	return true, nil
+}
+
+// FindAccountRef returns the Account the secrets of accountID are stored for, looking in all namespaces, or only in the
+// account secrets namespace when set. Returns false when there are no secrets of accountID.
+func (m *secretManagerImpl) FindAccountRef(ctx context.Context, accountID string) (domain.NamespacedName, bool, error) {
+	if accountID == "" {
+		return domain.NamespacedName{}, false, fmt.Errorf("account ID cannot be empty")
+	}
+	k8sSecrets, err := m.secretClient.GetByLabels(ctx, m.secretsNamespace, map[string]string{
+		SecretLabelAccountID: accountID,
+		k8s.LabelSecretType:  k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	})
+	if err != nil {
+		return domain.NamespacedName{}, false, fmt.Errorf("failed to get secrets of account %s: %w", accountID, err)
+	}
+	var accountRefs []domain.NamespacedName
+	for _, item := range k8sSecrets.Items {
+		accountNamespace := item.Labels[SecretLabelAccountNamespace]
+		if accountNamespace == "" {
+			accountNamespace = item.Namespace
+		}
+		accountRef := domain.NewNamespacedName(accountNamespace, item.Labels[SecretLabelAccountName])
+		if accountRef.Name == "" || slices.Contains(accountRefs, accountRef) {
+			continue
+		}
+		accountRefs = append(accountRefs, accountRef)
+	}
+	switch len(accountRefs) {
+	case 0:
+		return domain.NamespacedName{}, false, nil
+	case 1:
+		return accountRefs[0], true, nil
+	default:
+		return domain.NamespacedName{}, false, fmt.Errorf("secrets of account %s are stored for several Accounts: %v", accountID, accountRefs)
+	}
+}
+
+// MoveSecrets stores the secrets of accountID kept for the Account from for the Account to, and deletes the secrets of
+// from once both are stored, so a failed move can be rerun.
+func (m *secretManagerImpl) MoveSecrets(ctx context.Context, from domain.NamespacedName, to domain.NamespacedName, accountID string) error {
+	secrets, found, err := m.GetSecrets(ctx, from, accountID)
 	if err != nil {
-		panic(fmt.Sprintf("failed to generate hash from ID: %v", err))
+		return fmt.Errorf("failed to get account secrets of %s: %w", from, err)
+	}
+	if !found {
+		return fmt.Errorf("account secrets of %s not found for account %s", from, accountID)
+	}
+	rootSecret, err := m.rootSecret(to, secrets.Root)
+	if err != nil {
+		return err
+	}
+	signSecret, err := m.accountSecret(to, accountID, k8s.SecretTypeAccountSign, secrets.Sign)
+	if err != nil {
+		return err
+	}
+	for _, secret := range []outbound.SecretApply{rootSecret, signSecret} {
+		if err := m.checkNameCollision(ctx, secret, &from); err != nil {
+			return err
+		}
+	}
+	if err := m.secretClient.ApplyAll(ctx, nil, []outbound.SecretApply{rootSecret, signSecret}); err != nil {
+		return err
+	}
+	if err := m.DeleteAll(ctx, from, accountID); err != nil {
+		return fmt.Errorf("failed to delete account secrets of %s: %w", from, err)
 	}
+	logf.FromContext(ctx).Info("Moved account secrets", "from", from, "to", to, "accountID", accountID)
+	return nil
+}
 
-	hash := hex.EncodeToString(hasher.Sum(nil))
-	if len(hash) > 6 {
-		return hash[:6]
+func (m *secretManagerImpl) GetProvidedSecrets(ctx context.Context, accountRef domain.NamespacedName, keySecrets nauth.AccountKeySecrets) (*Secrets, error) {
+	if err := accountRef.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid account reference %s: %w", accountRef, err)
+	}
+	root, err := m.getProvidedKeyPair(ctx, accountRef.GetNamespace(), keySecrets.Root, "root")
+	if err != nil {
+		return nil, err
+	}
+	sign, err := m.getProvidedKeyPair(ctx, accountRef.GetNamespace(), keySecrets.Signing, "signing")
+	if err != nil {
+		return nil, err
 	}
-	return hash
+	rootPublicKey, err := root.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of the account root key: %w", err)
+	}
+	signPublicKey, err := sign.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of the account signing key: %w", err)
+	}
+	if rootPublicKey == signPublicKey {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("account root and signing key must differ"))
+	}
+	return &Secrets{Root: root, Sign: sign}, nil
+}
+
+// getProvidedKeyPair returns the account key pair of the seed in the key secretKey of a Secret in namespace.
+func (m *secretManagerImpl) getProvidedKeyPair(ctx context.Context, namespace domain.Namespace, secretKey nauth.SecretKeyRef, keyName string) (nkeys.KeyPair, error) {
+	secretRef := namespace.WithName(secretKey.Name)
+	key := secretKey.Key
+	if key == "" {
+		key = k8s.DefaultSecretKeyName
+	}
+	data, found, err := m.secretClient.Get(ctx, secretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account %s key secret %s: %w", keyName, secretRef, err)
+	}
+	if !found {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("account %s key secret %s not found", keyName, secretRef))
+	}
+	seed, ok := data[key]
+	if !ok {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("account %s key secret %s does not contain key %q", keyName, secretRef, key))
+	}
+	// Seeds written to files by key ceremonies usually end with a newline
+	keyPair, err := nkeys.FromSeed([]byte(strings.TrimSpace(seed)))
+	if err != nil {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("account %s key secret %s holds an invalid seed: %w", keyName, secretRef, err))
+	}
+	publicKey, err := keyPair.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of the account %s key: %w", keyName, err)
+	}
+	if !nkeys.IsValidPublicAccountKey(publicKey) {
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("account %s key secret %s holds no account seed", keyName, secretRef))
+	}
+	return keyPair, nil
 }
 
 func (m *secretManagerImpl) GetSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (*Secrets, bool, error) {
-	var err error
-	if err = accountRef.Validate(); err != nil {
+	if err := accountRef.Validate(); err != nil {
 		return nil, false, fmt.Errorf("invalid account reference %s: %w", accountRef, err)
 	}
+	if err := m.renameLegacyHashedSecrets(ctx, accountRef, accountID); err != nil {
+		return nil, false, err
+	}
+	if m.secretsNamespace == "" {
+		return m.getAccountNamespaceSecrets(ctx, accountRef, accountID)
+	}
+
+	result, found, err := m.getIsolatedAccountSecrets(ctx, accountRef, accountID)
+	if err != nil || found {
+		return result, found, err
+	}
+	// Secrets stored before the account secrets namespace was configured are moved there on first use
+	result, secretRefs, found, err := m.getAccountNamespaceSecretsByName(ctx, accountRef, accountID)
+	if err != nil || !found {
+		return result, found, err
+	}
+	if err := m.moveToSecretsNamespace(ctx, accountRef, result, secretRefs); err != nil {
+		return nil, false, fmt.Errorf("failed to move account secrets to namespace %s: %w", m.secretsNamespace, err)
+	}
+	return result, true, nil
+}
+
+// renameLegacyHashedSecrets renames the secrets of an account named with the MD5 hash of the default crypto policy to
+// the SHA-256 names of the strict policy. Otherwise the secrets applied under the strict policy would be stored next to
+// the ones created before the policy was enabled, and the keys of the account would be ambiguous. The secrets are
+// deleted only once stored under their new names, so a failed rename is completed by the next lookup.
+func (m *secretManagerImpl) renameLegacyHashedSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) error {
+	if !m.cryptoPolicy.IsStrict() || accountID == "" {
+		return nil
+	}
+	namespace := accountRef.GetNamespace()
+	labels := map[string]string{
+		SecretLabelAccountID: accountID,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}
+	if m.secretsNamespace != "" {
+		namespace = m.secretsNamespace
+		labels[SecretLabelAccountNamespace] = accountRef.Namespace
+	}
+	k8sSecrets, err := m.secretClient.GetByLabels(ctx, namespace, labels)
+	if err != nil {
+		return fmt.Errorf("failed to get account secrets from namespace %s: %w", namespace, err)
+	}
+	legacyHash := CryptoPolicyDefault.shortHashFromID(accountID)
+	for _, item := range k8sSecrets.Items {
+		secretType := item.Labels[k8s.LabelSecretType]
+		if item.Name != m.nameTemplate.secretName(accountRef, accountID, secretType, legacyHash) {
+			continue
+		}
+		secretName := m.nameTemplate.secretName(accountRef, accountID, secretType, m.cryptoPolicy.shortHashFromID(accountID))
+		if secretName == item.Name {
+			continue
+		}
+		secretValue := make(map[string]string, len(item.Data))
+		for k, v := range item.Data {
+			secretValue[k] = string(v)
+		}
+		secretMeta := metav1.ObjectMeta{Name: secretName, Namespace: item.Namespace, Labels: item.Labels}
+		if err := m.secretClient.Apply(ctx, nil, secretMeta, secretValue); err != nil {
+			return fmt.Errorf("failed to rename secret %s/%s to %s: %w", item.Namespace, item.Name, secretName, err)
+		}
+		legacyRef := namespace.WithName(item.Name)
+		if err := m.secretClient.Delete(ctx, legacyRef); err != nil {
+			return fmt.Errorf("failed to delete renamed secret %s: %w", legacyRef, err)
+		}
+		logf.FromContext(ctx).Info("Renamed account secret named with the hash of the default crypto policy",
+			"accountRef", accountRef, "accountID", accountID, "from", item.Name, "to", secretName)
+	}
+	return nil
+}
+
+// getIsolatedAccountSecrets looks up the secrets of an account in the account secrets namespace. The lookup is always
+// limited to the namespace of the Account, so an Account cannot claim the secrets of an account in another namespace.
+func (m *secretManagerImpl) getIsolatedAccountSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (*Secrets, bool, error) {
+	labels := map[string]string{
+		SecretLabelAccountNamespace: accountRef.Namespace,
+		k8s.LabelManaged:            k8s.LabelManagedValue,
+	}
+	if accountID != "" {
+		labels[SecretLabelAccountID] = accountID
+	} else {
+		labels[SecretLabelAccountName] = accountRef.Name
+	}
+	k8sSecrets, err := m.secretClient.GetByLabels(ctx, m.secretsNamespace, labels)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get account secrets from namespace %s: %w", m.secretsNamespace, err)
+	}
+	result, found, err := m.getAccountSecretsFromK8sSecrets(k8sSecrets)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	result, err = m.validatedResult(result, accountID)
+	return result, true, err
+}
+
+// getAccountNamespaceSecretsByName looks up the secrets of an account in the namespace of the Account by name, so the
+// manager does not need to list Secrets in the namespaces of Accounts when an account secrets namespace is set.
+// Returns the secrets found along with their references.
+func (m *secretManagerImpl) getAccountNamespaceSecretsByName(ctx context.Context, accountRef domain.NamespacedName, accountID string) (*Secrets, []domain.NamespacedName, bool, error) {
+	if accountID != "" {
+		secretRefs := m.templateSecretRefs(accountRef, accountID)
+		secrets := make(map[string]map[string]string, len(secretRefs))
+		for i, secretType := range []string{k8s.SecretTypeAccountRoot, k8s.SecretTypeAccountSign} {
+			secret, found, err := m.secretClient.Get(ctx, secretRefs[i])
+			if err != nil {
+				return nil, nil, false, fmt.Errorf("failed to get account secret %s: %w", secretRefs[i], err)
+			}
+			if found {
+				secrets[secretType] = secret
+			}
+		}
+		if len(secrets) == len(secretRefs) {
+			result, err := m.toAccountSecrets(secrets)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			result, err = m.validatedResult(result, accountID)
+			return result, secretRefs, true, err
+		}
+	}
+
+	result, found, err := m.getDeprecatedAccountSecretsByName(ctx, accountRef, accountID)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to get account secrets by secret name (deprecated) for account name %q: %w", accountRef.Name, err)
+	}
+	if !found {
+		return nil, nil, false, nil
+	}
+	result, err = m.validatedResult(result, accountID)
+	return result, deprecatedSecretRefs(accountRef), true, err
+}
+
+// moveToSecretsNamespace stores secrets in the account secrets namespace and deletes the secrets at secretRefs, in the
+// namespace of the Account, once stored.
+func (m *secretManagerImpl) moveToSecretsNamespace(ctx context.Context, accountRef domain.NamespacedName, secrets *Secrets, secretRefs []domain.NamespacedName) error {
+	accountID, err := secrets.Root.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get public key from account root secret: %w", err)
+	}
+	rootSecret, err := m.rootSecret(accountRef, secrets.Root)
+	if err != nil {
+		return err
+	}
+	signSecret, err := m.accountSecret(accountRef, accountID, k8s.SecretTypeAccountSign, secrets.Sign)
+	if err != nil {
+		return err
+	}
+	if err := m.secretClient.ApplyAll(ctx, nil, []outbound.SecretApply{rootSecret, signSecret}); err != nil {
+		return err
+	}
+	for _, secretRef := range secretRefs {
+		if err := m.secretClient.Delete(ctx, secretRef); err != nil {
+			return fmt.Errorf("failed to delete moved secret %s: %w", secretRef, err)
+		}
+	}
+	logf.FromContext(ctx).Info("Moved account secrets to the account secrets namespace",
+		"accountRef", accountRef, "accountID", accountID, "namespace", m.secretsNamespace)
+	return nil
+}
+
+func (m *secretManagerImpl) getAccountNamespaceSecrets(ctx context.Context, accountRef domain.NamespacedName, accountID string) (*Secrets, bool, error) {
+	var err error
 	if accountID != "" {
 		secretsByAccountID, found, errByAccountID := m.getAccountSecretsByAccountID(ctx, accountRef.GetNamespace(), accountID)
 		if errByAccountID == nil && found {
@@ -346,7 +887,7 @@ func (m *secretManagerImpl) toKeyPair(secrets map[string]map[string]string, secr
 	}
 	keyPair, err := nkeys.FromSeed([]byte(seed))
 	if err != nil {
-		return nil, fmt.Errorf("create key pair from secret of type '%s': %w", secretType, err)
+		return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("create key pair from secret of type '%s': %w", secretType, err))
 	}
 	return keyPair, nil
 }
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/inbound"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
+)
+
+// SecretMigrationManager renames the root and signing key secrets of accounts to the names of the configured secret
+// name template, e.g. secrets with the deprecated names without a hash or named by a previous template. The labels of
+// the secrets are kept.
+type SecretMigrationManager struct {
+	accountLister outbound.AccountLister
+	secretManager secretManager
+}
+
+func NewSecretMigrationManager(accountLister outbound.AccountLister, secretClient outbound.SecretClient, config *Config) (*SecretMigrationManager, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	sm, err := newSecretManagerImpl(secretClient, config.CryptoPolicy, config.AccountSecretsNamespace, config.AccountSecretNameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return newSecretMigrationManager(accountLister, sm)
+}
+
+func newSecretMigrationManager(accountLister outbound.AccountLister, secretManager secretManager) (*SecretMigrationManager, error) {
+	if accountLister == nil {
+		return nil, errors.New("accountLister is required")
+	}
+	if secretManager == nil {
+		return nil, errors.New("secretManager is required")
+	}
+	return &SecretMigrationManager{
+		accountLister: accountLister,
+		secretManager: secretManager,
+	}, nil
+}
+
+// Migrate renames the secrets of the Accounts in namespace, or in all namespaces when namespace is empty. Failing
+// Accounts do not stop the migration, their errors are joined and returned together with the result.
+func (m *SecretMigrationManager) Migrate(ctx context.Context, namespace domain.Namespace) (*nauth.SecretMigrationResult, error) {
+	accounts, err := m.accountLister.List(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	result := &nauth.SecretMigrationResult{}
+	var errs []error
+	for i := range accounts {
+		account := &accounts[i]
+		accountRef := domain.NewNamespacedName(account.GetNamespace(), account.GetName())
+		accountID := account.GetLabel(v1alpha1.AccountLabelAccountID)
+		if accountID == "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("account %s: not ready", accountRef))
+			continue
+		}
+		migrated, err := m.secretManager.MigrateSecrets(ctx, accountRef, accountID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to migrate secrets of account %s: %w", accountRef, err))
+			continue
+		}
+		result.Accounts++
+		if migrated {
+			result.Migrated++
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+var _ inbound.SecretMigrationManager = (*SecretMigrationManager)(nil)
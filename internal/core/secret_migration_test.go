@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SecretMigrationManager_Migrate(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	accountListerMock := NewAccountListerMock()
+	secretManagerMock := newSecretManagerMock()
+	accountListerMock.mockList(ctx, "team-a", []v1alpha1.Account{
+		newTestAccount(domain.NewNamespacedName("team-a", "renamed"), "ACCOUNT_A"),
+		newTestAccount(domain.NewNamespacedName("team-a", "unchanged"), "ACCOUNT_B"),
+		newTestAccount(domain.NewNamespacedName("team-a", "failing"), "ACCOUNT_C"),
+		newTestAccount(domain.NewNamespacedName("team-a", "pending"), ""),
+	})
+	secretManagerMock.mockMigrateSecrets(ctx, domain.NewNamespacedName("team-a", "renamed"), "ACCOUNT_A", true, nil)
+	secretManagerMock.mockMigrateSecrets(ctx, domain.NewNamespacedName("team-a", "unchanged"), "ACCOUNT_B", false, nil)
+	secretManagerMock.mockMigrateSecrets(ctx, domain.NewNamespacedName("team-a", "failing"), "ACCOUNT_C", false,
+		errors.New("secret name collision"))
+	unitUnderTest, err := newSecretMigrationManager(accountListerMock, secretManagerMock)
+	require.NoError(t, err)
+
+	// When
+	result, err := unitUnderTest.Migrate(ctx, "team-a")
+
+	// Then
+	require.ErrorContains(t, err, "failed to migrate secrets of account team-a/failing: secret name collision")
+	assert.Equal(t, 2, result.Accounts)
+	assert.Equal(t, 1, result.Migrated)
+	assert.Equal(t, []string{"account team-a/pending: not ready"}, result.Skipped)
+	secretManagerMock.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SecretNameTemplate names the root and signing key secrets of an account. The placeholders {account} and
+// {namespace} are replaced by the name and namespace of the Account, {type} by root or sign, {hash} by a short hash
+// of the account ID and {id} by the lower case account ID.
+type SecretNameTemplate string
+
+// DefaultSecretNameTemplate is the secret name template used when none is configured.
+const DefaultSecretNameTemplate SecretNameTemplate = "{account}-ac-{type}-{hash}"
+
+const (
+	secretNamePlaceholderAccount   = "{account}"
+	secretNamePlaceholderNamespace = "{namespace}"
+	secretNamePlaceholderType      = "{type}"
+	secretNamePlaceholderHash      = "{hash}"
+	secretNamePlaceholderID        = "{id}"
+)
+
+// Validate checks that the template names the root and signing key secrets of an account differently, and
+// distinguishes accounts with the same name by their account ID.
+func (t SecretNameTemplate) Validate() error {
+	template := string(t)
+	if !strings.Contains(template, secretNamePlaceholderType) {
+		return fmt.Errorf("secret name template %q must contain %s", t, secretNamePlaceholderType)
+	}
+	if !strings.Contains(template, secretNamePlaceholderHash) && !strings.Contains(template, secretNamePlaceholderID) {
+		return fmt.Errorf("secret name template %q must contain %s or %s", t, secretNamePlaceholderHash, secretNamePlaceholderID)
+	}
+	example := t.secretName(domain.NewNamespacedName("namespace", "account"), "account", domain.SecretTypeAccountRoot, "hash")
+	if strings.ContainsAny(example, "{}") {
+		return fmt.Errorf("secret name template %q contains an unknown placeholder", t)
+	}
+	if errs := validation.IsDNS1123Subdomain(example); len(errs) > 0 {
+		return fmt.Errorf("secret name template %q does not produce valid secret names: %s", t, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (t SecretNameTemplate) secretName(accountRef domain.NamespacedName, accountID string, secretType string, hash string) string {
+	return strings.NewReplacer(
+		secretNamePlaceholderAccount, accountRef.Name,
+		secretNamePlaceholderNamespace, accountRef.Namespace,
+		secretNamePlaceholderType, strings.TrimPrefix(secretType, "account-"),
+		secretNamePlaceholderHash, hash,
+		secretNamePlaceholderID, strings.ToLower(accountID),
+	).Replace(string(t))
+}
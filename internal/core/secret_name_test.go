@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SecretNameTemplate_Validate(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		template      SecretNameTemplate
+		expectedError string
+	}{
+		{testName: "should_accept_default", template: DefaultSecretNameTemplate},
+		{testName: "should_accept_account_id", template: "{namespace}-{account}-{type}-{id}"},
+		{testName: "should_fail_without_type", template: "{account}-{hash}", expectedError: "must contain {type}"},
+		{testName: "should_fail_without_hash_or_id", template: "{account}-{type}", expectedError: "must contain {hash} or {id}"},
+		{testName: "should_fail_with_unknown_placeholder", template: "{cluster}-{type}-{hash}", expectedError: "unknown placeholder"},
+		{testName: "should_fail_with_invalid_name", template: "{account}_{type}_{hash}", expectedError: "does not produce valid secret names"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := tc.template.Validate()
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_SecretNameTemplate_secretName(t *testing.T) {
+	accountRef := domain.NewNamespacedName("team-a", "orders")
+
+	assert.Equal(t, "orders-ac-sign-9b94e3",
+		DefaultSecretNameTemplate.secretName(accountRef, "ACCOUNTID", k8s.SecretTypeAccountSign, "9b94e3"))
+	assert.Equal(t, "team-a-orders-root-accountid",
+		SecretNameTemplate("{namespace}-{account}-{type}-{id}").secretName(accountRef, "ACCOUNTID", k8s.SecretTypeAccountRoot, "9b94e3"))
+}
@@ -3,13 +3,18 @@ package core
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
 	"testing"
 
 	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
 	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/WirelessCar/nauth/internal/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,7 +31,7 @@ func (t *SecretManagerTestSuite) SetupTest() {
 	t.secretClientMock = NewSecretClientMock()
 
 	var err error
-	t.unitUnderTest, err = newSecretManagerImpl(t.secretClientMock)
+	t.unitUnderTest, err = newSecretManagerImpl(t.secretClientMock, CryptoPolicyDefault, "", "")
 	t.NoError(err)
 }
 
@@ -282,10 +287,54 @@ func (t *SecretManagerTestSuite) Test_GetSecrets_ShouldFail_WhenSecretRootPubKey
 	t.ErrorContains(err, fmt.Sprintf("account root public key (%s) in found secret does not match expected account ID (%s)", secretRoot.PublicKey, account.Root.PublicKey))
 }
 
+func (t *SecretManagerTestSuite) Test_GetSecrets_ShouldRenameSecrets_WhenCryptoPolicyBecomesStrict() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest, err := newSecretManagerImpl(t.secretClientMock, CryptoPolicyStrict, "", "")
+	t.Require().NoError(err)
+
+	legacyHash := CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey)
+	strictHash := CryptoPolicyStrict.shortHashFromID(account.Root.PublicKey)
+	labels := map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}
+	t.secretClientMock.mockGetByLabelsSimplified("account-namespace", labels, []mockSecret{
+		{Name: "account-name-ac-root-" + legacyHash, SecretType: k8s.SecretTypeAccountRoot, Value: account.Root.Seed},
+		{Name: "account-name-ac-sign-" + legacyHash, SecretType: k8s.SecretTypeAccountSign, Value: account.Sign.Seed},
+	})
+	for secretType, seed := range map[string][]byte{
+		k8s.SecretTypeAccountRoot: account.Root.Seed,
+		k8s.SecretTypeAccountSign: account.Sign.Seed,
+	} {
+		typeName := strings.TrimPrefix(secretType, "account-")
+		secretLabels := maps.Clone(labels)
+		secretLabels[k8s.LabelSecretType] = secretType
+		t.secretClientMock.mockApply(t.ctx, nil, metav1.ObjectMeta{
+			Name:      "account-name-ac-" + typeName + "-" + strictHash,
+			Namespace: "account-namespace",
+			Labels:    secretLabels,
+		}, map[string]string{k8s.DefaultSecretKeyName: string(seed)}).Return(nil)
+		t.secretClientMock.mockDelete(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-ac-"+typeName+"-"+legacyHash))
+	}
+
+	// When
+	result, found, err := unitUnderTest.GetSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.True(found)
+	t.Equal(&Secrets{Root: account.Root.Key, Sign: account.Sign.Key}, result)
+}
+
 func (t *SecretManagerTestSuite) Test_ApplyRootSecret_ShouldSucceed() {
 	// Given
 	account := testutil.CreateNatsTestAccount()
 
+	t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+		k8s.LabelSecretType: k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	}, []mockSecret{})
 	var caughtMeta metav1.ObjectMeta
 	t.secretClientMock.mockApply(
 		t.ctx,
@@ -316,6 +365,10 @@ func (t *SecretManagerTestSuite) Test_ApplySignSecret_ShouldSucceed() {
 	// Given
 	account := testutil.CreateNatsTestAccount()
 
+	t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+		k8s.LabelSecretType: k8s.SecretTypeAccountSign,
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	}, []mockSecret{})
 	var caughtMeta metav1.ObjectMeta
 	t.secretClientMock.mockApply(
 		t.ctx,
@@ -342,6 +395,218 @@ func (t *SecretManagerTestSuite) Test_ApplySignSecret_ShouldSucceed() {
 	t.Equal(k8s.LabelManagedValue, caughtMeta.Labels[k8s.LabelManaged])
 }
 
+func (t *SecretManagerTestSuite) Test_ApplyRootSecret_ShouldFail_WhenSecretNameCollides() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	otherAccount := testutil.CreateNatsTestAccount()
+	secretName := DefaultSecretNameTemplate.secretName(domain.NewNamespacedName("account-namespace", "account-name"),
+		account.Root.PublicKey, k8s.SecretTypeAccountRoot, CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey))
+	t.secretClientMock.mockGetByLabels("account-namespace", map[string]string{
+		k8s.LabelSecretType: k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	}, &corev1.SecretList{Items: []corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: "account-namespace",
+			Labels:    map[string]string{SecretLabelAccountID: otherAccount.Root.PublicKey},
+		},
+	}}})
+
+	// When
+	err := t.unitUnderTest.ApplyRootSecret(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.Key)
+
+	// Then
+	t.ErrorIs(err, domain.ErrSecretNameCollision)
+	t.ErrorContains(err, otherAccount.Root.PublicKey)
+}
+
+func (t *SecretManagerTestSuite) Test_ApplyRootSecret_ShouldUseNameTemplate() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest, err := newSecretManagerImpl(t.secretClientMock, CryptoPolicyDefault, "", "{namespace}-{account}-{type}-{id}")
+	t.Require().NoError(err)
+	t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+		k8s.LabelSecretType: k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	}, []mockSecret{})
+	var caughtMeta metav1.ObjectMeta
+	t.secretClientMock.mockApply(t.ctx, nil, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		caughtMeta = args.Get(2).(metav1.ObjectMeta)
+	}).Return(nil)
+
+	// When
+	err = unitUnderTest.ApplyRootSecret(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.Key)
+
+	// Then
+	t.NoError(err)
+	t.Equal("account-namespace-account-name-root-"+strings.ToLower(account.Root.PublicKey), caughtMeta.Name)
+}
+
+func (t *SecretManagerTestSuite) Test_MigrateSecrets_ShouldRenameSecrets_WhenNamedByPreviousTemplate() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}, []mockSecret{
+		{Name: "account-name-root", SecretType: k8s.SecretTypeAccountRoot, Value: account.Root.Seed},
+		{Name: "account-name-sign", SecretType: k8s.SecretTypeAccountSign, Value: account.Sign.Seed},
+	})
+	for _, secretType := range []string{k8s.SecretTypeAccountRoot, k8s.SecretTypeAccountSign} {
+		t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+			k8s.LabelSecretType: secretType,
+			k8s.LabelManaged:    k8s.LabelManagedValue,
+		}, []mockSecret{})
+	}
+	var caughtNames []string
+	t.secretClientMock.mockApplyAll(t.ctx, nil, mock.Anything).Run(func(args mock.Arguments) {
+		for _, secret := range args.Get(2).([]outbound.SecretApply) {
+			caughtNames = append(caughtNames, secret.Meta.Name)
+			t.Equal(account.Root.PublicKey, secret.Meta.Labels[SecretLabelAccountID])
+			t.Equal("account-name", secret.Meta.Labels[SecretLabelAccountName])
+		}
+	}).Return(nil).Once()
+	t.secretClientMock.mockDelete(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-root"))
+	t.secretClientMock.mockDelete(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-sign"))
+
+	// When
+	migrated, err := t.unitUnderTest.MigrateSecrets(t.ctx, accountRef, account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.True(migrated)
+	hash := CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey)
+	t.Equal([]string{"account-name-ac-root-" + hash, "account-name-ac-sign-" + hash}, caughtNames)
+}
+
+func (t *SecretManagerTestSuite) Test_MigrateSecrets_ShouldDoNothing_WhenNamedByTemplate() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	accountRef := domain.NewNamespacedName("account-namespace", "account-name")
+	hash := CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey)
+	t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}, []mockSecret{
+		{Name: "account-name-ac-root-" + hash, SecretType: k8s.SecretTypeAccountRoot, Value: account.Root.Seed},
+		{Name: "account-name-ac-sign-" + hash, SecretType: k8s.SecretTypeAccountSign, Value: account.Sign.Seed},
+	})
+
+	// When
+	migrated, err := t.unitUnderTest.MigrateSecrets(t.ctx, accountRef, account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.False(migrated)
+}
+
+func (t *SecretManagerTestSuite) Test_FindAccountRef_ShouldReturnAccountOfSecrets() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	labels := map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelSecretType:  k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}
+	t.secretClientMock.mockGetByLabels("", labels, &corev1.SecretList{Items: []corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "previous-name-ac-root",
+				Namespace: "nauth-secrets",
+				Labels: map[string]string{
+					SecretLabelAccountID:        account.Root.PublicKey,
+					SecretLabelAccountNamespace: "previous-namespace",
+					SecretLabelAccountName:      "previous-name",
+				},
+			},
+		},
+	}})
+
+	// When
+	accountRef, found, err := t.unitUnderTest.FindAccountRef(t.ctx, account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.True(found)
+	t.Equal(domain.NewNamespacedName("previous-namespace", "previous-name"), accountRef)
+}
+
+func (t *SecretManagerTestSuite) Test_FindAccountRef_ShouldReturnNotFound_WhenSecretsAreMissing() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	t.secretClientMock.mockGetByLabelsSimplified("", map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelSecretType:  k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}, []mockSecret{})
+
+	// When
+	_, found, err := t.unitUnderTest.FindAccountRef(t.ctx, account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.False(found)
+}
+
+func (t *SecretManagerTestSuite) Test_FindAccountRef_ShouldOnlyLookInSecretsNamespace_WhenIsolated() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest := t.newIsolatedSecretManager()
+	t.secretClientMock.mockGetByLabelsSimplified("nauth-system", map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelSecretType:  k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}, []mockSecret{})
+
+	// When
+	_, found, err := unitUnderTest.FindAccountRef(t.ctx, account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.False(found)
+}
+
+func (t *SecretManagerTestSuite) Test_MoveSecrets_ShouldStoreSecretsForNewAccount() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	from := domain.NewNamespacedName("previous-namespace", "previous-name")
+	to := domain.NewNamespacedName("account-namespace", "account-name")
+	t.secretClientMock.mockGetByLabelsSimplified("previous-namespace", map[string]string{
+		SecretLabelAccountID: account.Root.PublicKey,
+		k8s.LabelManaged:     k8s.LabelManagedValue,
+	}, []mockSecret{
+		{SecretType: k8s.SecretTypeAccountRoot, Value: account.Root.Seed},
+		{SecretType: k8s.SecretTypeAccountSign, Value: account.Sign.Seed},
+	})
+	for _, secretType := range []string{k8s.SecretTypeAccountRoot, k8s.SecretTypeAccountSign} {
+		t.secretClientMock.mockGetByLabelsSimplified("account-namespace", map[string]string{
+			k8s.LabelSecretType: secretType,
+			k8s.LabelManaged:    k8s.LabelManagedValue,
+		}, []mockSecret{})
+	}
+	var caughtSecrets []outbound.SecretApply
+	t.secretClientMock.mockApplyAll(t.ctx, nil, mock.Anything).Run(func(args mock.Arguments) {
+		caughtSecrets = args.Get(2).([]outbound.SecretApply)
+	}).Return(nil).Once()
+	t.secretClientMock.mockDeleteByLabels("previous-namespace", map[string]string{
+		SecretLabelAccountID:   account.Root.PublicKey,
+		SecretLabelAccountName: "previous-name",
+	})
+
+	// When
+	err := t.unitUnderTest.MoveSecrets(t.ctx, from, to, account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.Require().Len(caughtSecrets, 2)
+	for _, secret := range caughtSecrets {
+		t.Equal("account-namespace", secret.Meta.Namespace)
+		t.Equal(account.Root.PublicKey, secret.Meta.Labels[SecretLabelAccountID])
+		t.Equal("account-name", secret.Meta.Labels[SecretLabelAccountName])
+	}
+}
+
 func (t *SecretManagerTestSuite) Test_DeleteAll_ShouldSucceed() {
 	// Given
 	account := testutil.CreateNatsTestAccount()
@@ -357,3 +622,327 @@ func (t *SecretManagerTestSuite) Test_DeleteAll_ShouldSucceed() {
 	// Then
 	t.NoError(err)
 }
+
+func (t *SecretManagerTestSuite) Test_ApplyPendingSecrets_ShouldSucceed() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	var caughtMeta metav1.ObjectMeta
+	t.secretClientMock.mockApply(
+		t.ctx,
+		nil,
+		mock.Anything,
+		map[string]string{
+			k8s.SecretTypeAccountRoot: string(account.Root.Seed),
+			k8s.SecretTypeAccountSign: string(account.Sign.Seed),
+		},
+	).Run(func(args mock.Arguments) {
+		caughtMeta = args.Get(2).(metav1.ObjectMeta)
+	}).Return(nil)
+
+	// When
+	err := t.unitUnderTest.ApplyPendingSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"),
+		&Secrets{Root: account.Root.Key, Sign: account.Sign.Key})
+
+	// Then
+	t.NoError(err)
+	t.Equal("account-namespace", caughtMeta.Namespace)
+	t.Equal("account-name-ac-pending", caughtMeta.Name)
+	t.Equal(map[string]string{
+		k8s.LabelSecretType: domain.SecretTypeAccountPending,
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	}, caughtMeta.Labels)
+}
+
+func (t *SecretManagerTestSuite) Test_GetPendingSecrets_ShouldSucceed() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-ac-pending"), map[string]string{
+		k8s.SecretTypeAccountRoot: string(account.Root.Seed),
+		k8s.SecretTypeAccountSign: string(account.Sign.Seed),
+	})
+
+	// When
+	result, found, err := t.unitUnderTest.GetPendingSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"))
+
+	// Then
+	t.NoError(err)
+	t.True(found)
+	t.Equal(&Secrets{Root: account.Root.Key, Sign: account.Sign.Key}, result)
+}
+
+func (t *SecretManagerTestSuite) Test_GetPendingSecrets_ShouldReturnNotFound_WhenSecretIsMissing() {
+	// Given
+	t.secretClientMock.mockGetNotFound(domain.NewNamespacedName("account-namespace", "account-name-ac-pending"))
+
+	// When
+	result, found, err := t.unitUnderTest.GetPendingSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"))
+
+	// Then
+	t.NoError(err)
+	t.False(found)
+	t.Nil(result)
+}
+
+func (t *SecretManagerTestSuite) Test_GetPendingSecrets_ShouldFail_WhenSecretIsInvalid() {
+	// Given
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-ac-pending"), map[string]string{})
+
+	// When
+	_, _, err := t.unitUnderTest.GetPendingSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"))
+
+	// Then
+	t.ErrorContains(err, "invalid pending account secret account-namespace/account-name-ac-pending")
+}
+
+func (t *SecretManagerTestSuite) Test_DeletePendingSecrets_ShouldDeleteFromSecretsNamespace_WhenIsolated() {
+	// Given
+	unitUnderTest := t.newIsolatedSecretManager()
+	t.secretClientMock.mockDelete(t.ctx, domain.NewNamespacedName("nauth-system", "account-namespace.account-name-ac-pending"))
+
+	// When
+	err := unitUnderTest.DeletePendingSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"))
+
+	// Then
+	t.NoError(err)
+}
+
+func (t *SecretManagerTestSuite) Test_ApplyRootSecret_ShouldStoreInSecretsNamespace_WhenIsolated() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest := t.newIsolatedSecretManager()
+
+	t.secretClientMock.mockGetByLabelsSimplified("nauth-system", map[string]string{
+		k8s.LabelSecretType: k8s.SecretTypeAccountRoot,
+		k8s.LabelManaged:    k8s.LabelManagedValue,
+	}, []mockSecret{})
+	var caughtMeta metav1.ObjectMeta
+	t.secretClientMock.mockApply(
+		t.ctx,
+		nil,
+		mock.Anything,
+		map[string]string{
+			k8s.DefaultSecretKeyName: string(account.Root.Seed),
+		},
+	).Run(func(args mock.Arguments) {
+		caughtMeta = args.Get(2).(metav1.ObjectMeta)
+	}).Return(nil)
+
+	// When
+	err := unitUnderTest.ApplyRootSecret(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.Key)
+
+	// Then
+	t.NoError(err)
+	t.Equal("nauth-system", caughtMeta.Namespace)
+	t.Contains(caughtMeta.Name, "account-name-ac-root-")
+	t.Equal(account.Root.PublicKey, caughtMeta.Labels[SecretLabelAccountID])
+	t.Equal("account-name", caughtMeta.Labels[SecretLabelAccountName])
+	t.Equal("account-namespace", caughtMeta.Labels[SecretLabelAccountNamespace])
+}
+
+func (t *SecretManagerTestSuite) Test_GetSecrets_ShouldLookupInSecretsNamespace_WhenIsolated() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest := t.newIsolatedSecretManager()
+
+	t.secretClientMock.mockGetByLabelsSimplified("nauth-system", map[string]string{
+		SecretLabelAccountID:        account.Root.PublicKey,
+		SecretLabelAccountNamespace: "account-namespace",
+		k8s.LabelManaged:            k8s.LabelManagedValue,
+	}, []mockSecret{
+		{SecretType: k8s.SecretTypeAccountRoot, Value: account.Root.Seed},
+		{SecretType: k8s.SecretTypeAccountSign, Value: account.Sign.Seed},
+	})
+
+	// When
+	result, found, err := unitUnderTest.GetSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.True(found)
+	t.Equal(&Secrets{Root: account.Root.Key, Sign: account.Sign.Key}, result)
+}
+
+func (t *SecretManagerTestSuite) Test_GetSecrets_ShouldMoveSecretsFromAccountNamespace_WhenIsolated() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest := t.newIsolatedSecretManager()
+
+	t.secretClientMock.mockGetByLabelsSimplified("nauth-system", map[string]string{
+		SecretLabelAccountID:        account.Root.PublicKey,
+		SecretLabelAccountNamespace: "account-namespace",
+		k8s.LabelManaged:            k8s.LabelManagedValue,
+	}, []mockSecret{})
+	hash := CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey)
+	rootRef := domain.NewNamespacedName("account-namespace", "account-name-ac-root-"+hash)
+	signRef := domain.NewNamespacedName("account-namespace", "account-name-ac-sign-"+hash)
+	t.secretClientMock.mockGet(t.ctx, rootRef, map[string]string{k8s.DefaultSecretKeyName: string(account.Root.Seed)})
+	t.secretClientMock.mockGet(t.ctx, signRef, map[string]string{k8s.DefaultSecretKeyName: string(account.Sign.Seed)})
+	var caughtNamespaces []string
+	t.secretClientMock.mockApplyAll(t.ctx, nil, mock.Anything).Run(func(args mock.Arguments) {
+		for _, secret := range args.Get(2).([]outbound.SecretApply) {
+			caughtNamespaces = append(caughtNamespaces, secret.Meta.Namespace)
+		}
+	}).Return(nil).Once()
+	t.secretClientMock.mockDelete(t.ctx, rootRef)
+	t.secretClientMock.mockDelete(t.ctx, signRef)
+
+	// When
+	result, found, err := unitUnderTest.GetSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+	t.True(found)
+	t.Equal(&Secrets{Root: account.Root.Key, Sign: account.Sign.Key}, result)
+	t.Equal([]string{"nauth-system", "nauth-system"}, caughtNamespaces)
+}
+
+func (t *SecretManagerTestSuite) Test_GetSecrets_ShouldKeepSecretsInAccountNamespace_WhenMoveFails() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest := t.newIsolatedSecretManager()
+
+	t.secretClientMock.mockGetByLabelsSimplified("nauth-system", map[string]string{
+		SecretLabelAccountID:        account.Root.PublicKey,
+		SecretLabelAccountNamespace: "account-namespace",
+		k8s.LabelManaged:            k8s.LabelManagedValue,
+	}, []mockSecret{})
+	hash := CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey)
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-ac-root-"+hash),
+		map[string]string{k8s.DefaultSecretKeyName: string(account.Root.Seed)})
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("account-namespace", "account-name-ac-sign-"+hash),
+		map[string]string{k8s.DefaultSecretKeyName: string(account.Sign.Seed)})
+	t.secretClientMock.mockApplyAll(t.ctx, nil, mock.Anything).Return(fmt.Errorf("forbidden")).Once()
+
+	// When
+	result, found, err := unitUnderTest.GetSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.PublicKey)
+
+	// Then
+	t.ErrorContains(err, "failed to move account secrets to namespace nauth-system: forbidden")
+	t.False(found)
+	t.Nil(result)
+	t.secretClientMock.AssertNotCalled(t.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (t *SecretManagerTestSuite) Test_DeleteAll_ShouldDeleteFromBothNamespaces_WhenIsolated() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	unitUnderTest := t.newIsolatedSecretManager()
+
+	t.secretClientMock.mockDeleteByLabels("nauth-system", map[string]string{
+		SecretLabelAccountID:        account.Root.PublicKey,
+		SecretLabelAccountName:      "account-name",
+		SecretLabelAccountNamespace: "account-namespace",
+	})
+	hash := CryptoPolicyDefault.shortHashFromID(account.Root.PublicKey)
+	for _, name := range []string{"account-name-ac-root-" + hash, "account-name-ac-sign-" + hash, "account-name-ac-root", "account-name-ac-sign"} {
+		t.secretClientMock.mockDelete(t.ctx, domain.NewNamespacedName("account-namespace", name))
+	}
+
+	// When
+	err := unitUnderTest.DeleteAll(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), account.Root.PublicKey)
+
+	// Then
+	t.NoError(err)
+}
+
+func (t *SecretManagerTestSuite) Test_GetProvidedSecrets_ShouldReturnKeysOfReferencedSecrets() {
+	// Given
+	account := testutil.CreateNatsTestAccount()
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("account-namespace", "hsm-account-root"), map[string]string{
+		k8s.DefaultSecretKeyName: string(account.Root.Seed) + "\n",
+	})
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("account-namespace", "hsm-account-signing"), map[string]string{
+		"seed": string(account.Sign.Seed),
+	})
+
+	// When
+	result, err := t.unitUnderTest.GetProvidedSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"),
+		nauth.AccountKeySecrets{
+			Root:    nauth.SecretKeyRef{Name: "hsm-account-root"},
+			Signing: nauth.SecretKeyRef{Name: "hsm-account-signing", Key: "seed"},
+		})
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(result)
+	rootPublicKey, err := result.Root.PublicKey()
+	t.Require().NoError(err)
+	t.Equal(account.Root.PublicKey, rootPublicKey)
+	signPublicKey, err := result.Sign.PublicKey()
+	t.Require().NoError(err)
+	t.Equal(account.Sign.PublicKey, signPublicKey)
+}
+
+func (t *SecretManagerTestSuite) Test_GetProvidedSecrets_ShouldFail_WhenSecretsInvalid() {
+	account := testutil.CreateNatsTestAccount()
+	user := testutil.CreateNatsTestUserKey()
+	keySecrets := nauth.AccountKeySecrets{
+		Root:    nauth.SecretKeyRef{Name: "hsm-account-root"},
+		Signing: nauth.SecretKeyRef{Name: "hsm-account-signing"},
+	}
+	rootRef := domain.NewNamespacedName("account-namespace", "hsm-account-root")
+	signRef := domain.NewNamespacedName("account-namespace", "hsm-account-signing")
+	testCases := []struct {
+		name          string
+		root          map[string]string
+		sign          map[string]string
+		expectedError string
+	}{
+		{
+			name:          "missing root secret",
+			expectedError: "account root key secret account-namespace/hsm-account-root not found",
+		},
+		{
+			name:          "missing key",
+			root:          map[string]string{"seed": string(account.Root.Seed)},
+			expectedError: `does not contain key "default"`,
+		},
+		{
+			name:          "invalid seed",
+			root:          map[string]string{k8s.DefaultSecretKeyName: "not-a-seed"},
+			expectedError: "holds an invalid seed",
+		},
+		{
+			name:          "user seed",
+			root:          map[string]string{k8s.DefaultSecretKeyName: string(account.Root.Seed)},
+			sign:          map[string]string{k8s.DefaultSecretKeyName: string(user.Seed)},
+			expectedError: "account signing key secret account-namespace/hsm-account-signing holds no account seed",
+		},
+		{
+			name:          "same root and signing key",
+			root:          map[string]string{k8s.DefaultSecretKeyName: string(account.Root.Seed)},
+			sign:          map[string]string{k8s.DefaultSecretKeyName: string(account.Root.Seed)},
+			expectedError: "account root and signing key must differ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			// Given
+			secretClientMock := NewSecretClientMock()
+			for secretRef, data := range map[domain.NamespacedName]map[string]string{rootRef: tc.root, signRef: tc.sign} {
+				if data == nil {
+					secretClientMock.mockGetNotFound(secretRef)
+				} else {
+					secretClientMock.mockGet(t.ctx, secretRef, data)
+				}
+			}
+			unitUnderTest, err := newSecretManagerImpl(secretClientMock, CryptoPolicyDefault, "", "")
+			t.Require().NoError(err)
+
+			// When
+			result, err := unitUnderTest.GetProvidedSecrets(t.ctx, domain.NewNamespacedName("account-namespace", "account-name"), keySecrets)
+
+			// Then
+			t.ErrorIs(err, domain.ErrInvalidConfig)
+			t.ErrorContains(err, tc.expectedError)
+			t.Nil(result)
+		})
+	}
+}
+
+func (t *SecretManagerTestSuite) newIsolatedSecretManager() *secretManagerImpl {
+	unitUnderTest, err := newSecretManagerImpl(t.secretClientMock, CryptoPolicyDefault, "nauth-system", "")
+	t.Require().NoError(err)
+	return unitUnderTest
+}
@@ -3,14 +3,16 @@ package core
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
-	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s" // TODO: [#185] Core must not depend on adapter code
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/ports/inbound"
 	"github.com/WirelessCar/nauth/internal/ports/outbound"
 	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nkeys"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -26,27 +28,116 @@ type UserJWTSigner interface {
 }
 
 type UserManager struct {
-	userJWTSigner UserJWTSigner
-	secretClient  outbound.SecretClient
+	userJWTSigner       UserJWTSigner
+	secretClient        outbound.SecretClient
+	credentialsSink     outbound.CredentialsSink
+	workloadRestarter   outbound.WorkloadRestarter
+	permissionSetReader outbound.PermissionSetReader
+	userDefaultsReader  outbound.AccountUserDefaultsReader
+	nauthDefaultsReader outbound.NauthDefaultsReader
+	auditRecorder       outbound.AuditRecorder
+	config              *Config
 }
 
-func NewUserManager(userJWTSigner UserJWTSigner, secretClient outbound.SecretClient) *UserManager {
-	return &UserManager{
-		userJWTSigner: userJWTSigner,
-		secretClient:  secretClient,
+func NewUserManager(
+	userJWTSigner UserJWTSigner,
+	secretClient outbound.SecretClient,
+	credentialsSink outbound.CredentialsSink,
+	workloadRestarter outbound.WorkloadRestarter,
+	permissionSetReader outbound.PermissionSetReader,
+	userDefaultsReader outbound.AccountUserDefaultsReader,
+	nauthDefaultsReader outbound.NauthDefaultsReader,
+	auditRecorder outbound.AuditRecorder,
+	config *Config,
+) (*UserManager, error) {
+	m := &UserManager{
+		userJWTSigner:       userJWTSigner,
+		secretClient:        secretClient,
+		credentialsSink:     credentialsSink,
+		workloadRestarter:   workloadRestarter,
+		permissionSetReader: permissionSetReader,
+		userDefaultsReader:  userDefaultsReader,
+		nauthDefaultsReader: nauthDefaultsReader,
+		auditRecorder:       auditRecorder,
+		config:              config,
 	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (u *UserManager) validate() error {
+	if u.userJWTSigner == nil {
+		return fmt.Errorf("userJWTSigner is required")
+	}
+	if u.secretClient == nil {
+		return fmt.Errorf("secretClient is required")
+	}
+	if u.credentialsSink == nil {
+		return fmt.Errorf("credentialsSink is required")
+	}
+	if u.workloadRestarter == nil {
+		return fmt.Errorf("workloadRestarter is required")
+	}
+	if u.permissionSetReader == nil {
+		return fmt.Errorf("permissionSetReader is required")
+	}
+	if u.userDefaultsReader == nil {
+		return fmt.Errorf("userDefaultsReader is required")
+	}
+	if u.nauthDefaultsReader == nil {
+		return fmt.Errorf("nauthDefaultsReader is required")
+	}
+	if u.auditRecorder == nil {
+		return fmt.Errorf("auditRecorder is required")
+	}
+	if u.config == nil {
+		return fmt.Errorf("config is required")
+	}
+	return nil
 }
 
 func (u *UserManager) CreateOrUpdate(ctx context.Context, state *v1alpha1.User) error {
+	if state.Spec.NKey != nil {
+		return u.createOrUpdateNKeyUser(ctx, state)
+	}
 	userRef := domain.NewNamespacedName(state.Namespace, state.Name)
-	accountRef := domain.NewNamespacedName(state.Namespace, state.Spec.AccountName)
+	stateAccountRef := state.GetAccountRef()
+	accountRef := domain.NewNamespacedName(stateAccountRef.Namespace, stateAccountRef.Name)
 	if err := accountRef.Validate(); err != nil {
 		return fmt.Errorf("invalid account reference %q: %w", accountRef, err)
 	}
 
 	existingUserAccountID := state.GetLabel(v1alpha1.UserLabelAccountID)
+	existingUserID := state.GetLabel(v1alpha1.UserLabelUserID)
 
-	userKeyPair, err := nkeys.CreateUser()
+	userDefaults, err := u.userDefaultsReader.GetUserDefaults(ctx, accountRef)
+	if err != nil {
+		return fmt.Errorf("failed to get user defaults of account %s: %w", accountRef, err)
+	}
+	nauthDefaults, err := u.nauthDefaultsReader.GetNauthDefaults(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get NauthDefaults: %w", err)
+	}
+	var platformDefaults *v1alpha1.UserPlatformDefaults
+	if nauthDefaults != nil {
+		platformDefaults = nauthDefaults.User
+	}
+	userSpec := applyUserPlatformDefaults(applyUserDefaults(state.Spec, userDefaults), platformDefaults)
+
+	permissions, observedPermissionSets, err := u.resolvePermissions(ctx, state.GetNamespace(), userSpec)
+	if err != nil {
+		return err
+	}
+	userSpec.Permissions = addDenySubjects(permissions, platformDefaults)
+	if userSpec.Bearer != nil {
+		// A bearer JWT authenticates without proof of the user key, so it is kept short-lived instead
+		userSpec.BearerToken = true
+		userSpec.ExpiresAt = new(metav1.NewTime(time.Now().Add(userSpec.Bearer.GetTTL())))
+	}
+
+	userKeyPair, err := u.config.CryptoPolicy.createKeyPair(ctx, nkeys.PrefixByteUser, "user")
 	if err != nil {
 		return fmt.Errorf("failed to create user key pair: %w", err)
 	}
@@ -59,35 +150,104 @@ func (u *UserManager) CreateOrUpdate(ctx context.Context, state *v1alpha1.User)
 		return fmt.Errorf("failed to get user seed: %w", err)
 	}
 
-	natsClaims := newUserClaimsBuilder(u.getUserDisplayName(state), state.Spec, userPublicKey, existingUserAccountID).
-		build()
-	signedUserJWT, err := u.userJWTSigner.SignUserJWT(ctx, accountRef, natsClaims)
+	natsClaims, signedUserJWT, err := u.buildUserJWT(ctx, userRef, accountRef, u.getUserDisplayName(state), userSpec, userPublicKey, existingUserAccountID)
 	if err != nil {
+		return err
+	}
+	if err := checkUserSigningKey(nauthDefaults, signedUserJWT); err != nil {
 		return fmt.Errorf("failed to sign user jwt for %s: %w", userRef, err)
 	}
-
-	userCreds, err := jwt.FormatUserConfig(signedUserJWT.UserJWT, userSeed)
+	claimsHash, err := hashSignedUserJWTClaims(signedUserJWT.UserJWT)
+	if err != nil {
+		return fmt.Errorf("failed to hash user claims for %s: %w", userRef, err)
+	}
+	userJWTMetadata, err := decodeUserJWTMetadata(signedUserJWT.UserJWT)
 	if err != nil {
-		return fmt.Errorf("failed to format user credentials: %w", err)
+		return fmt.Errorf("failed to decode user jwt for %s: %w", userRef, err)
+	}
+	userClaims := toNAuthUserClaims(natsClaims)
+	var changes []string
+	if existingUserID != "" {
+		changes = changedFields(state.Status.Claims, userClaims)
+	}
+	u.auditRecorder.Record(ctx, domain.AuditEvent{
+		Action:    domain.AuditActionUserJWTSigned,
+		Resource:  auditResource(auditKindUser, userRef),
+		Subject:   userPublicKey,
+		AccountID: signedUserJWT.AccountID,
+		Issuer:    signedUserJWT.SignedBy,
+		Changes:   changes,
+	})
+
+	secretValue := map[string]string{
+		domain.UserJWTSecretKeyName: signedUserJWT.UserJWT,
+	}
+	if userSpec.Bearer == nil {
+		userCreds, err := jwt.FormatUserConfig(signedUserJWT.UserJWT, userSeed)
+		if err != nil {
+			return fmt.Errorf("failed to format user credentials: %w", err)
+		}
+		secretValue = map[string]string{
+			domain.UserCredentialSecretKeyName: string(userCreds),
+		}
+		if userSpec.CredentialsFormat == v1alpha1.CredentialsFormatExpanded {
+			secretValue[domain.UserJWTSecretKeyName] = signedUserJWT.UserJWT
+			secretValue[domain.UserSeedSecretKeyName] = string(userSeed)
+			secretValue[domain.UserPublicKeySecretKeyName] = userPublicKey
+		}
 	}
 
+	checksum := credentialsChecksum(secretValue)
 	secretMeta := metav1.ObjectMeta{
 		Name:      state.GetUserSecretName(),
 		Namespace: state.GetNamespace(),
 		Labels: map[string]string{
-			k8s.LabelSecretType: k8s.SecretTypeUserCredentials,
-			k8s.LabelManaged:    k8s.LabelManagedValue,
+			domain.LabelSecretType: domain.SecretTypeUserCredentials,
+			domain.LabelManaged:    domain.LabelManagedValue,
+		},
+		Annotations: map[string]string{
+			domain.AnnotationCredentialsChecksum: checksum,
 		},
-	}
-	secretValue := map[string]string{
-		k8s.UserCredentialSecretKeyName: string(userCreds),
 	}
 	err = u.secretClient.Apply(ctx, state, secretMeta, secretValue)
 	if err != nil {
 		return err
 	}
+	if err = u.restartWorkloads(ctx, state, checksum); err != nil {
+		return err
+	}
+	secretRef := domain.NewNamespacedName(secretMeta.Namespace, secretMeta.Name)
+	if state.Spec.CredentialsSink != nil {
+		if err := u.credentialsSink.Push(ctx, state, secretRef, *state.Spec.CredentialsSink); err != nil {
+			return fmt.Errorf("failed to push user credentials to credentials sink: %w", err)
+		}
+	} else if err := u.credentialsSink.Remove(ctx, secretRef); err != nil {
+		return fmt.Errorf("failed to remove user credentials from credentials sink: %w", err)
+	}
+	credentialsEvent := domain.AuditEvent{
+		Action:    domain.AuditActionUserCredentialsCreated,
+		Resource:  auditResource(auditKindUser, userRef),
+		Subject:   userPublicKey,
+		AccountID: signedUserJWT.AccountID,
+		Keys:      []string{userPublicKey},
+	}
+	if existingUserID != "" {
+		credentialsEvent.Action = domain.AuditActionUserCredentialsRotated
+		credentialsEvent.Keys = append(credentialsEvent.Keys, existingUserID)
+	}
+	u.auditRecorder.Record(ctx, credentialsEvent)
 
-	state.Status.Claims = toNAuthUserClaims(natsClaims)
+	state.Status.Claims = userClaims
+	state.Status.ClaimsHash = claimsHash
+	state.Status.JWT = userJWTMetadata
+	state.Status.PermissionSets = observedPermissionSets
+	state.Status.AccountUserDefaults = userDefaults.DeepCopy()
+	state.Status.PlatformUserDefaults = platformDefaults.DeepCopy()
+	state.Status.CredentialsExpireAt = nil
+	if state.Spec.CredentialsTTL != nil {
+		expireAt := metav1.NewTime(time.Now().Add(state.Spec.CredentialsTTL.Duration))
+		state.Status.CredentialsExpireAt = &expireAt
+	}
 	state.SetLabel(v1alpha1.UserLabelUserID, userPublicKey)
 	state.SetLabel(v1alpha1.UserLabelAccountID, signedUserJWT.AccountID)
 	state.SetLabel(v1alpha1.UserLabelSignedBy, signedUserJWT.SignedBy)
@@ -98,18 +258,74 @@ func (u *UserManager) CreateOrUpdate(ctx context.Context, state *v1alpha1.User)
 	return nil
 }
 
+// buildUserJWT builds the NATS claims of userSpec and has them signed by the account of accountRef.
+func (u *UserManager) buildUserJWT(ctx context.Context, userRef domain.NamespacedName, accountRef domain.NamespacedName, displayName string, userSpec v1alpha1.UserSpec, userPublicKey string, existingUserAccountID string) (_ *jwt.UserClaims, _ *SignedUserJWT, err error) {
+	_, span := tracer.Start(ctx, "build user claims", trace.WithAttributes(attribute.String("nauth.user.id", userPublicKey)))
+	defer func() { endSpan(span, err) }()
+
+	natsClaims, err := newUserClaimsBuilder(displayName, userSpec, userPublicKey, existingUserAccountID).
+		build()
+	if err != nil {
+		return nil, nil, domain.ErrBadRequest.WithCause(fmt.Errorf("failed to build NATS user claims for %s: %w", userRef, err))
+	}
+	signedUserJWT, err := u.userJWTSigner.SignUserJWT(ctx, accountRef, natsClaims)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign user jwt for %s: %w", userRef, err)
+	}
+	return natsClaims, signedUserJWT, nil
+}
+
+// checkUserSigningKey returns domain.ErrKeyPolicyViolation when signedUserJWT was signed with the account root key
+// while the KeyPolicy of the NauthDefaults requires a signing key.
+func checkUserSigningKey(nauthDefaults *v1alpha1.NauthDefaultsSpec, signedUserJWT *SignedUserJWT) error {
+	if nauthDefaults == nil || nauthDefaults.KeyPolicy == nil ||
+		nauthDefaults.KeyPolicy.UserSigning != v1alpha1.UserSigningPolicySigningKeyOnly {
+		return nil
+	}
+	if signedUserJWT.SignedBy == signedUserJWT.AccountID {
+		return domain.ErrKeyPolicyViolation.WithCause(fmt.Errorf(
+			"signing key of account %s is its root key, NauthDefaults allow signing users with signing keys only",
+			signedUserJWT.AccountID))
+	}
+	return nil
+}
+
 func (u *UserManager) Delete(ctx context.Context, state *v1alpha1.User) error {
 	log := logf.FromContext(ctx)
 	log.Info("Delete user", "userName", state.GetName())
 
+	return u.deleteCredentials(ctx, state)
+}
+
+func (u *UserManager) ExpireCredentials(ctx context.Context, state *v1alpha1.User) error {
+	log := logf.FromContext(ctx)
+	log.Info("Expire user credentials", "userName", state.GetName())
+
+	if err := u.deleteCredentials(ctx, state); err != nil {
+		return err
+	}
+	state.Status.CredentialsExpireAt = nil
+	return nil
+}
+
+func (u *UserManager) deleteCredentials(ctx context.Context, state *v1alpha1.User) error {
 	secretRef := domain.NewNamespacedName(state.Namespace, state.GetUserSecretName())
 	if err := secretRef.Validate(); err != nil {
 		return fmt.Errorf("invalid secret reference %q: %w", secretRef, err)
 	}
+	if err := u.credentialsSink.Remove(ctx, secretRef); err != nil {
+		return fmt.Errorf("failed to remove user credentials from credentials sink: %w", err)
+	}
 	err := u.secretClient.Delete(ctx, secretRef)
 	if err != nil {
 		return fmt.Errorf("failed to delete user secret %s: %w", secretRef, err)
 	}
+	u.auditRecorder.Record(ctx, domain.AuditEvent{
+		Action:    domain.AuditActionUserCredentialsDeleted,
+		Resource:  auditResource(auditKindUser, domain.NewNamespacedName(state.Namespace, state.Name)),
+		Subject:   state.GetLabel(v1alpha1.UserLabelUserID),
+		AccountID: state.GetLabel(v1alpha1.UserLabelAccountID),
+	})
 
 	return nil
 }
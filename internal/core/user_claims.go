@@ -1,7 +1,14 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
 	"github.com/nats-io/jwt/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -18,6 +25,7 @@ func newUserClaimsBuilder(
 ) *userClaimsBuilder {
 	claim := jwt.NewUserClaims(userPublicKey)
 	claim.Name = displayName
+	claim.Tags.Add(nauth.EncodeTags(spec.Tags, spec.TagValues)...)
 	if spec.ExpiresAt != nil {
 		claim.Expires = spec.ExpiresAt.Unix()
 	}
@@ -25,12 +33,12 @@ func newUserClaimsBuilder(
 	// Permissions
 	if spec.Permissions != nil {
 		claim.Pub = jwt.Permission{
-			Allow: jwt.StringList(spec.Permissions.Pub.Allow),
-			Deny:  jwt.StringList(spec.Permissions.Pub.Deny),
+			Allow: normalizeSubjects(spec.Permissions.Pub.Allow),
+			Deny:  normalizeSubjects(spec.Permissions.Pub.Deny),
 		}
 		claim.Sub = jwt.Permission{
-			Allow: jwt.StringList(spec.Permissions.Sub.Allow),
-			Deny:  jwt.StringList(spec.Permissions.Sub.Deny),
+			Allow: normalizeSubjects(spec.Permissions.Sub.Allow),
+			Deny:  normalizeSubjects(spec.Permissions.Sub.Deny),
 		}
 		if spec.Permissions.Resp != nil {
 			claim.Resp = &jwt.ResponsePermission{
@@ -68,6 +76,12 @@ func newUserClaimsBuilder(
 		}
 	}
 
+	// Connection
+	claim.BearerToken = spec.BearerToken
+	for _, connectionType := range spec.AllowedConnectionTypes {
+		claim.AllowedConnectionTypes.Add(string(connectionType))
+	}
+
 	claim.IssuerAccount = issuerAccountId
 
 	return &userClaimsBuilder{
@@ -75,8 +89,68 @@ func newUserClaimsBuilder(
 	}
 }
 
-func (u *userClaimsBuilder) build() *jwt.UserClaims {
-	return u.claim
+func (u *userClaimsBuilder) build() (*jwt.UserClaims, error) {
+	if err := validateJWTPermissions(u.claim.Permissions); err != nil {
+		return nil, err
+	}
+	return u.claim, nil
+}
+
+// normalizeSubjects trims the whitespace around each permission subject. Subscribe permissions may name a queue group
+// after the subject, so the subjects are not validated further.
+func normalizeSubjects[T ~[]string](subjects T) jwt.StringList {
+	if subjects == nil {
+		return nil
+	}
+	result := make(jwt.StringList, 0, len(subjects))
+	for _, subject := range subjects {
+		result = append(result, string(nauth.Subject(subject).Normalize()))
+	}
+	return result
+}
+
+func validateJWTPermissions(permissions jwt.Permissions) error {
+	valResults := &jwt.ValidationResults{}
+	permissions.Validate(valResults)
+	if valResults.IsBlocking(false) {
+		return errors.Join(valResults.Errors()...)
+	}
+	return nil
+}
+
+func hashSignedUserJWTClaims(userJWT string) (string, error) {
+	claims, err := jwt.DecodeUserClaims(userJWT)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode user JWT claims for hashing: %w", err)
+	}
+	// Exclude unstable JWT metadata so equivalent user content hashes the same across signings.
+	claims.IssuedAt = 0
+	claims.ID = ""
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// decodeUserJWTMetadata returns the ID, issue time, expiry and issuer of a signed user JWT.
+func decodeUserJWTMetadata(userJWT string) (*v1alpha1.JWTMetadata, error) {
+	claims, err := jwt.DecodeUserClaims(userJWT)
+	if err != nil {
+		return nil, err
+	}
+	result := &v1alpha1.JWTMetadata{
+		ID:       claims.ID,
+		IssuedAt: metav1.Unix(claims.IssuedAt, 0),
+		Issuer:   claims.Issuer,
+	}
+	if claims.Expires != 0 {
+		result.ExpiresAt = new(metav1.Unix(claims.Expires, 0))
+	}
+	return result, nil
 }
 
 func toNAuthUserClaims(claims *jwt.UserClaims) v1alpha1.UserClaims {
@@ -167,5 +241,14 @@ func toNAuthUserClaims(claims *jwt.UserClaims) v1alpha1.UserClaims {
 		}
 	}
 
+	// Connection
+	result.BearerToken = claims.BearerToken
+	for _, connectionType := range claims.AllowedConnectionTypes {
+		result.AllowedConnectionTypes = append(result.AllowedConnectionTypes, v1alpha1.ConnectionType(connectionType))
+	}
+	if len(claims.Tags) > 0 {
+		result.Tags = append([]string(nil), claims.Tags...)
+	}
+
 	return result
 }
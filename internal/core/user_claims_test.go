@@ -38,7 +38,8 @@ func TestClaims(t *testing.T) {
 			// Build NATS JWT UserClaims from UserSpec
 			builder := newUserClaimsBuilder(userClaimsTestDisplayName, *spec, userClaimsTestUserPubKey, userClaimsTestAccountPubKey)
 
-			natsClaims := builder.build()
+			natsClaims, err := builder.build()
+			require.NoError(t, err)
 			require.NotNil(t, natsClaims)
 			// Ensure that the NATS JWT can be encoded
 			natsJwt, err := natsClaims.Encode(acSigningKey)
@@ -66,10 +67,14 @@ func TestClaims(t *testing.T) {
 				Permissions: nauthClaims.Permissions,
 				UserLimits:  nauthClaims.UserLimits,
 				NatsLimits:  nauthClaims.NatsLimits,
+
+				BearerToken:            nauthClaims.BearerToken,
+				AllowedConnectionTypes: nauthClaims.AllowedConnectionTypes,
+				Tags:                   nauthClaims.Tags,
 			}
 			rebuilder := newUserClaimsBuilder(userClaimsTestDisplayName, *rebuiltNatsClaims, userClaimsTestUserPubKey, userClaimsTestAccountPubKey)
 
-			natsClaimsRebuilt := rebuilder.build()
+			natsClaimsRebuilt, err := rebuilder.build()
 			require.NoError(t, err)
 			require.NotNil(t, natsClaimsRebuilt)
 			// Sign the JWT to ensure matching issuer details
@@ -101,7 +106,8 @@ func TestUserClaimsBuilder_ExpiresAt(t *testing.T) {
 				ExpiresAt:   &tc.expiresAt,
 			}
 
-			claims := newUserClaimsBuilder(userClaimsTestDisplayName, spec, userClaimsTestUserPubKey, userClaimsTestAccountPubKey).build()
+			claims, err := newUserClaimsBuilder(userClaimsTestDisplayName, spec, userClaimsTestUserPubKey, userClaimsTestAccountPubKey).build()
+			require.NoError(t, err)
 			require.Equal(t, tc.expiresAt.Unix(), claims.Expires)
 
 			nauthClaims := toNAuthUserClaims(claims)
@@ -111,6 +117,39 @@ func TestUserClaimsBuilder_ExpiresAt(t *testing.T) {
 	}
 }
 
+func TestUserClaimsBuilder_ShouldFail_WhenPermissionSubjectInvalid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		permissions v1alpha1.Permissions
+	}{
+		{
+			name:        "pub_allow_with_space",
+			permissions: v1alpha1.Permissions{Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"foo bar"}}},
+		},
+		{
+			name:        "pub_deny_with_queue",
+			permissions: v1alpha1.Permissions{Pub: v1alpha1.Permission{Deny: v1alpha1.StringList{"foo q"}}},
+		},
+		{
+			name:        "sub_allow_empty",
+			permissions: v1alpha1.Permissions{Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{""}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := v1alpha1.UserSpec{
+				AccountName: "test-account",
+				Permissions: &tc.permissions,
+			}
+
+			claims, err := newUserClaimsBuilder(userClaimsTestDisplayName, spec, userClaimsTestUserPubKey, userClaimsTestAccountPubKey).build()
+			require.Error(t, err)
+			require.Nil(t, claims)
+		})
+	}
+}
+
 func loadUserSpec(filePath string) (*v1alpha1.UserSpec, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
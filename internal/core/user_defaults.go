@@ -0,0 +1,49 @@
+package core
+
+import (
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+)
+
+// applyUserDefaults returns spec with every field it leaves unset taken from the account user defaults.
+// Fields set in spec replace the corresponding default as a whole.
+func applyUserDefaults(spec v1alpha1.UserSpec, defaults *v1alpha1.UserDefaults) v1alpha1.UserSpec {
+	if defaults == nil {
+		return spec
+	}
+	if spec.Permissions == nil && defaults.Permissions != nil {
+		spec.Permissions = defaults.Permissions.DeepCopy()
+	}
+	if spec.NatsLimits == nil && defaults.NatsLimits != nil {
+		spec.NatsLimits = defaults.NatsLimits.DeepCopy()
+	}
+	if spec.ExpiresAt == nil && defaults.ExpiresAt != nil {
+		spec.ExpiresAt = defaults.ExpiresAt.DeepCopy()
+	}
+	return spec
+}
+
+// applyUserPlatformDefaults returns spec with natsLimits taken from the NauthDefaults when neither the User nor the
+// account user defaults set them.
+func applyUserPlatformDefaults(spec v1alpha1.UserSpec, defaults *v1alpha1.UserPlatformDefaults) v1alpha1.UserSpec {
+	if defaults == nil {
+		return spec
+	}
+	if spec.NatsLimits == nil && defaults.NatsLimits != nil {
+		spec.NatsLimits = defaults.NatsLimits.DeepCopy()
+	}
+	return spec
+}
+
+// addDenySubjects returns permissions with the deny subjects of the NauthDefaults added to its deny lists.
+func addDenySubjects(permissions *v1alpha1.Permissions, defaults *v1alpha1.UserPlatformDefaults) *v1alpha1.Permissions {
+	if defaults == nil || defaults.DenySubjects == nil {
+		return permissions
+	}
+	result := &v1alpha1.Permissions{}
+	if permissions != nil {
+		result = permissions.DeepCopy()
+	}
+	result.Pub.Deny = appendUnique(result.Pub.Deny, defaults.DenySubjects.Pub)
+	result.Sub.Deny = appendUnique(result.Sub.Deny, defaults.DenySubjects.Sub)
+	return result
+}
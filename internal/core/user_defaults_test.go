@@ -0,0 +1,159 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_applyUserDefaults(t *testing.T) {
+	subs := int64(10)
+	userSubs := int64(20)
+	defaultExpiresAt := metav1.Unix(1000, 0)
+	userExpiresAt := metav1.Unix(2000, 0)
+	defaults := &v1alpha1.UserDefaults{
+		Permissions: &v1alpha1.Permissions{Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}}},
+		NatsLimits:  &v1alpha1.NatsLimits{Subs: &subs},
+		ExpiresAt:   &defaultExpiresAt,
+	}
+
+	testCases := []struct {
+		testName string
+		spec     v1alpha1.UserSpec
+		defaults *v1alpha1.UserDefaults
+		expected v1alpha1.UserSpec
+	}{
+		{
+			testName: "should_keep_spec_when_no_defaults",
+			spec:     v1alpha1.UserSpec{AccountName: "my-account"},
+			expected: v1alpha1.UserSpec{AccountName: "my-account"},
+		},
+		{
+			testName: "should_apply_defaults_to_unset_fields",
+			spec:     v1alpha1.UserSpec{AccountName: "my-account"},
+			defaults: defaults,
+			expected: v1alpha1.UserSpec{
+				AccountName: "my-account",
+				Permissions: defaults.Permissions,
+				NatsLimits:  defaults.NatsLimits,
+				ExpiresAt:   defaults.ExpiresAt,
+			},
+		},
+		{
+			testName: "should_keep_fields_set_in_spec",
+			spec: v1alpha1.UserSpec{
+				AccountName: "my-account",
+				Permissions: &v1alpha1.Permissions{Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}}},
+				NatsLimits:  &v1alpha1.NatsLimits{Subs: &userSubs},
+				ExpiresAt:   &userExpiresAt,
+			},
+			defaults: defaults,
+			expected: v1alpha1.UserSpec{
+				AccountName: "my-account",
+				Permissions: &v1alpha1.Permissions{Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}}},
+				NatsLimits:  &v1alpha1.NatsLimits{Subs: &userSubs},
+				ExpiresAt:   &userExpiresAt,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, applyUserDefaults(tc.spec, tc.defaults))
+		})
+	}
+}
+
+func Test_applyUserPlatformDefaults(t *testing.T) {
+	subs := int64(10)
+	userSubs := int64(20)
+	defaults := &v1alpha1.UserPlatformDefaults{
+		NatsLimits: &v1alpha1.NatsLimits{Subs: &subs},
+	}
+
+	testCases := []struct {
+		testName string
+		spec     v1alpha1.UserSpec
+		defaults *v1alpha1.UserPlatformDefaults
+		expected v1alpha1.UserSpec
+	}{
+		{
+			testName: "should_keep_spec_when_no_defaults",
+			spec:     v1alpha1.UserSpec{AccountName: "my-account"},
+			expected: v1alpha1.UserSpec{AccountName: "my-account"},
+		},
+		{
+			testName: "should_apply_nats_limits_when_unset",
+			spec:     v1alpha1.UserSpec{AccountName: "my-account"},
+			defaults: defaults,
+			expected: v1alpha1.UserSpec{AccountName: "my-account", NatsLimits: defaults.NatsLimits},
+		},
+		{
+			testName: "should_keep_nats_limits_set_in_spec",
+			spec:     v1alpha1.UserSpec{AccountName: "my-account", NatsLimits: &v1alpha1.NatsLimits{Subs: &userSubs}},
+			defaults: defaults,
+			expected: v1alpha1.UserSpec{AccountName: "my-account", NatsLimits: &v1alpha1.NatsLimits{Subs: &userSubs}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, applyUserPlatformDefaults(tc.spec, tc.defaults))
+		})
+	}
+}
+
+func Test_addDenySubjects(t *testing.T) {
+	defaults := &v1alpha1.UserPlatformDefaults{
+		DenySubjects: &v1alpha1.DenySubjects{
+			Pub: v1alpha1.StringList{"$SYS.>"},
+			Sub: v1alpha1.StringList{"$SYS.>", "secret.>"},
+		},
+	}
+
+	testCases := []struct {
+		testName    string
+		permissions *v1alpha1.Permissions
+		defaults    *v1alpha1.UserPlatformDefaults
+		expected    *v1alpha1.Permissions
+	}{
+		{
+			testName: "should_keep_permissions_when_no_deny_subjects",
+			permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+			defaults: &v1alpha1.UserPlatformDefaults{},
+			expected: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+		},
+		{
+			testName: "should_deny_subjects_when_no_permissions",
+			defaults: defaults,
+			expected: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Deny: v1alpha1.StringList{"$SYS.>"}},
+				Sub: v1alpha1.Permission{Deny: v1alpha1.StringList{"$SYS.>", "secret.>"}},
+			},
+		},
+		{
+			testName: "should_add_deny_subjects_without_duplicates",
+			permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+				Sub: v1alpha1.Permission{Deny: v1alpha1.StringList{"secret.>"}},
+			},
+			defaults: defaults,
+			expected: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}, Deny: v1alpha1.StringList{"$SYS.>"}},
+				Sub: v1alpha1.Permission{Deny: v1alpha1.StringList{"secret.>", "$SYS.>"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, addDenySubjects(tc.permissions, tc.defaults))
+		})
+	}
+}
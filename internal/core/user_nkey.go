@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/nats-io/nkeys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createOrUpdateNKeyUser writes the NKey of a user with spec.nkey to its credentials Secret without signing a JWT, for
+// NATS servers that authorize users in their config file. The key pair in the Secret is kept, since the servers have
+// to be reconfigured for a new public key.
+func (u *UserManager) createOrUpdateNKeyUser(ctx context.Context, state *v1alpha1.User) error {
+	userRef := domain.NewNamespacedName(state.Namespace, state.Name)
+	secretRef := domain.NewNamespacedName(state.Namespace, state.GetUserSecretName())
+	if err := secretRef.Validate(); err != nil {
+		return fmt.Errorf("invalid secret reference %q: %w", secretRef, err)
+	}
+
+	nauthDefaults, err := u.nauthDefaultsReader.GetNauthDefaults(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get NauthDefaults: %w", err)
+	}
+	var platformDefaults *v1alpha1.UserPlatformDefaults
+	if nauthDefaults != nil {
+		platformDefaults = nauthDefaults.User
+	}
+	permissions, observedPermissionSets, err := u.resolvePermissions(ctx, state.GetNamespace(), state.Spec)
+	if err != nil {
+		return err
+	}
+	permissions = addDenySubjects(permissions, platformDefaults)
+
+	userKeyPair, err := u.getOrCreateNKeyUserKeyPair(ctx, secretRef)
+	if err != nil {
+		return err
+	}
+	userPublicKey, err := userKeyPair.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get user public key: %w", err)
+	}
+	userSeed, err := userKeyPair.Seed()
+	if err != nil {
+		return fmt.Errorf("failed to get user seed: %w", err)
+	}
+	// The permissions are validated as for a user JWT
+	spec := v1alpha1.UserSpec{Permissions: permissions}
+	if _, err := newUserClaimsBuilder(u.getUserDisplayName(state), spec, userPublicKey, "").build(); err != nil {
+		return domain.ErrBadRequest.WithCause(fmt.Errorf("invalid permissions for %s: %w", userRef, err))
+	}
+
+	secretValue := map[string]string{
+		domain.UserSeedSecretKeyName:      string(userSeed),
+		domain.UserPublicKeySecretKeyName: userPublicKey,
+	}
+	if state.Spec.NKey.Authorization {
+		authorization, err := renderNKeyUserAuthorization(userPublicKey, permissions, state.Spec.AllowedConnectionTypes)
+		if err != nil {
+			return fmt.Errorf("failed to render authorization of %s: %w", userRef, err)
+		}
+		secretValue[domain.UserAuthorizationSecretKeyName] = authorization
+	}
+	checksum := credentialsChecksum(secretValue)
+	secretMeta := metav1.ObjectMeta{
+		Name:      secretRef.Name,
+		Namespace: secretRef.Namespace,
+		Labels: map[string]string{
+			domain.LabelSecretType: domain.SecretTypeUserCredentials,
+			domain.LabelManaged:    domain.LabelManagedValue,
+		},
+		Annotations: map[string]string{
+			domain.AnnotationCredentialsChecksum: checksum,
+		},
+	}
+	if err := u.secretClient.Apply(ctx, state, secretMeta, secretValue); err != nil {
+		return err
+	}
+	if err := u.restartWorkloads(ctx, state, checksum); err != nil {
+		return err
+	}
+	// Credentials sinks deliver user.creds, which an NKey user does not have
+	if err := u.credentialsSink.Remove(ctx, secretRef); err != nil {
+		return fmt.Errorf("failed to remove user credentials from credentials sink: %w", err)
+	}
+	existingUserID := state.GetLabel(v1alpha1.UserLabelUserID)
+	if existingUserID != userPublicKey {
+		credentialsEvent := domain.AuditEvent{
+			Action:   domain.AuditActionUserCredentialsCreated,
+			Resource: auditResource(auditKindUser, userRef),
+			Subject:  userPublicKey,
+			Keys:     []string{userPublicKey},
+		}
+		if existingUserID != "" {
+			credentialsEvent.Action = domain.AuditActionUserCredentialsRotated
+			credentialsEvent.Keys = append(credentialsEvent.Keys, existingUserID)
+		}
+		u.auditRecorder.Record(ctx, credentialsEvent)
+	}
+
+	state.Status.Claims = v1alpha1.UserClaims{
+		Permissions:            permissions,
+		AllowedConnectionTypes: state.Spec.AllowedConnectionTypes,
+	}
+	state.Status.ClaimsHash = ""
+	state.Status.JWT = nil
+	state.Status.PermissionSets = observedPermissionSets
+	state.Status.AccountUserDefaults = nil
+	state.Status.PlatformUserDefaults = platformDefaults.DeepCopy()
+	state.Status.CredentialsExpireAt = nil
+	if state.Spec.CredentialsTTL != nil {
+		expireAt := metav1.NewTime(time.Now().Add(state.Spec.CredentialsTTL.Duration))
+		state.Status.CredentialsExpireAt = &expireAt
+	}
+	state.SetLabel(v1alpha1.UserLabelUserID, userPublicKey)
+	delete(state.Labels, string(v1alpha1.UserLabelAccountID))
+	delete(state.Labels, string(v1alpha1.UserLabelSignedBy))
+
+	state.Status.ObservedGeneration = state.Generation
+	state.Status.ReconcileTimestamp = metav1.Now()
+
+	return nil
+}
+
+// getOrCreateNKeyUserKeyPair returns the key pair in the credentials Secret of an NKey user, or a new one when the
+// Secret holds none.
+func (u *UserManager) getOrCreateNKeyUserKeyPair(ctx context.Context, secretRef domain.NamespacedName) (nkeys.KeyPair, error) {
+	secret, found, err := u.secretClient.Get(ctx, secretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user secret %s: %w", secretRef, err)
+	}
+	if seed := secret[domain.UserSeedSecretKeyName]; found && seed != "" {
+		keyPair, err := nkeys.FromSeed([]byte(seed))
+		if err != nil {
+			return nil, domain.ErrInvalidConfig.WithCause(fmt.Errorf("invalid user seed in secret %s: %w", secretRef, err))
+		}
+		return keyPair, nil
+	}
+	keyPair, err := u.config.CryptoPolicy.createKeyPair(ctx, nkeys.PrefixByteUser, "user")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user key pair: %w", err)
+	}
+	return keyPair, nil
+}
+
+// nkeyUserAuthorization is an entry of the users list of an authorization or account block of the NATS server config.
+type nkeyUserAuthorization struct {
+	NKey                   string                    `json:"nkey"`
+	Permissions            *nkeyUserPermissions      `json:"permissions,omitempty"`
+	AllowedConnectionTypes []v1alpha1.ConnectionType `json:"allowed_connection_types,omitempty"`
+}
+
+type nkeyUserPermissions struct {
+	Publish        *nkeyUserPermission         `json:"publish,omitempty"`
+	Subscribe      *nkeyUserPermission         `json:"subscribe,omitempty"`
+	AllowResponses *nkeyUserResponsePermission `json:"allow_responses,omitempty"`
+}
+
+type nkeyUserPermission struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+type nkeyUserResponsePermission struct {
+	MaxMsgs int    `json:"max"`
+	Expires string `json:"expires,omitempty"`
+}
+
+// renderNKeyUserAuthorization renders the NATS server config entry of an NKey user. The server config format accepts
+// JSON.
+func renderNKeyUserAuthorization(
+	userPublicKey string,
+	permissions *v1alpha1.Permissions,
+	allowedConnectionTypes []v1alpha1.ConnectionType,
+) (string, error) {
+	authorization := nkeyUserAuthorization{
+		NKey:                   userPublicKey,
+		AllowedConnectionTypes: allowedConnectionTypes,
+	}
+	if permissions != nil {
+		result := &nkeyUserPermissions{
+			Publish:   toNKeyUserPermission(permissions.Pub),
+			Subscribe: toNKeyUserPermission(permissions.Sub),
+		}
+		if resp := permissions.Resp; resp != nil {
+			result.AllowResponses = &nkeyUserResponsePermission{MaxMsgs: resp.MaxMsgs}
+			if resp.Expires > 0 {
+				result.AllowResponses.Expires = resp.Expires.String()
+			}
+		}
+		if result.Publish != nil || result.Subscribe != nil || result.AllowResponses != nil {
+			authorization.Permissions = result
+		}
+	}
+	// The wildcard '>' is kept as is, the NATS config parser does not read JSON unicode escapes
+	var rendered strings.Builder
+	encoder := json.NewEncoder(&rendered)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(authorization); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func toNKeyUserPermission(permission v1alpha1.Permission) *nkeyUserPermission {
+	if permission.Empty() {
+		return nil
+	}
+	return &nkeyUserPermission{
+		Allow: normalizeSubjects(permission.Allow),
+		Deny:  normalizeSubjects(permission.Deny),
+	}
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+// resolvePermissions returns the effective permissions of the user spec and the PermissionSets they were resolved from.
+func (u *UserManager) resolvePermissions(ctx context.Context, namespace string, spec v1alpha1.UserSpec) (*v1alpha1.Permissions, []v1alpha1.ObservedPermissionSet, error) {
+	if len(spec.PermissionSetRefs) == 0 {
+		return spec.Permissions, nil, nil
+	}
+
+	permissionSets := make([]v1alpha1.PermissionSet, 0, len(spec.PermissionSetRefs))
+	observed := make([]v1alpha1.ObservedPermissionSet, 0, len(spec.PermissionSetRefs))
+	for _, ref := range spec.PermissionSetRefs {
+		permissionSetRef := domain.NewNamespacedName(namespace, ref.Name)
+		permissionSet, err := u.permissionSetReader.Get(ctx, permissionSetRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve PermissionSet %s: %w", permissionSetRef, err)
+		}
+		permissionSets = append(permissionSets, *permissionSet)
+		observed = append(observed, v1alpha1.ObservedPermissionSet{
+			Name:               permissionSet.GetName(),
+			ObservedGeneration: permissionSet.GetGeneration(),
+		})
+	}
+	return mergePermissions(spec.Permissions, permissionSets), observed, nil
+}
+
+// mergePermissions merges the inline permissions with the permissions of permissionSets, in that order. Subjects keep
+// the position of their first occurrence and duplicates are dropped. The first defined response permission is used.
+func mergePermissions(inline *v1alpha1.Permissions, permissionSets []v1alpha1.PermissionSet) *v1alpha1.Permissions {
+	sources := make([]v1alpha1.Permissions, 0, len(permissionSets)+1)
+	if inline != nil {
+		sources = append(sources, *inline)
+	}
+	for _, permissionSet := range permissionSets {
+		sources = append(sources, permissionSet.Spec.Permissions)
+	}
+
+	result := &v1alpha1.Permissions{}
+	for _, source := range sources {
+		result.Pub.Allow = appendUnique(result.Pub.Allow, source.Pub.Allow)
+		result.Pub.Deny = appendUnique(result.Pub.Deny, source.Pub.Deny)
+		result.Sub.Allow = appendUnique(result.Sub.Allow, source.Sub.Allow)
+		result.Sub.Deny = appendUnique(result.Sub.Deny, source.Sub.Deny)
+		if result.Resp == nil && source.Resp != nil {
+			resp := *source.Resp
+			result.Resp = &resp
+		}
+	}
+	if result.Pub.Empty() && result.Sub.Empty() && result.Resp == nil {
+		return nil
+	}
+	return result
+}
+
+func appendUnique(list v1alpha1.StringList, values v1alpha1.StringList) v1alpha1.StringList {
+	for _, value := range values {
+		if !list.Contains(value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergePermissions(t *testing.T) {
+	permissionSet := func(permissions v1alpha1.Permissions) v1alpha1.PermissionSet {
+		return v1alpha1.PermissionSet{Spec: v1alpha1.PermissionSetSpec{Permissions: permissions}}
+	}
+
+	testCases := []struct {
+		testName       string
+		inline         *v1alpha1.Permissions
+		permissionSets []v1alpha1.PermissionSet
+		expected       *v1alpha1.Permissions
+	}{
+		{
+			testName: "should_return_nil_when_nothing_defined",
+			expected: nil,
+		},
+		{
+			testName: "should_merge_in_order_without_duplicates",
+			inline: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"b.>", "a.>"}},
+			},
+			permissionSets: []v1alpha1.PermissionSet{
+				permissionSet(v1alpha1.Permissions{
+					Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"a.>", "c.>"}, Deny: v1alpha1.StringList{"a.secret"}},
+				}),
+				permissionSet(v1alpha1.Permissions{
+					Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}},
+					Pub: v1alpha1.Permission{Deny: v1alpha1.StringList{"a.secret", "b.secret"}},
+				}),
+			},
+			expected: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{
+					Allow: v1alpha1.StringList{"b.>", "a.>", "c.>"},
+					Deny:  v1alpha1.StringList{"a.secret", "b.secret"},
+				},
+				Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}},
+			},
+		},
+		{
+			testName: "should_use_first_defined_response_permission",
+			permissionSets: []v1alpha1.PermissionSet{
+				permissionSet(v1alpha1.Permissions{}),
+				permissionSet(v1alpha1.Permissions{Resp: &v1alpha1.ResponsePermission{MaxMsgs: 1, Expires: time.Second}}),
+				permissionSet(v1alpha1.Permissions{Resp: &v1alpha1.ResponsePermission{MaxMsgs: 5, Expires: time.Minute}}),
+			},
+			expected: &v1alpha1.Permissions{
+				Resp: &v1alpha1.ResponsePermission{MaxMsgs: 1, Expires: time.Second},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergePermissions(tc.inline, tc.permissionSets))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// credentialsChecksum returns the SHA-256 checksum of the data of a credentials Secret, hashing the keys in order.
+func credentialsChecksum(data map[string]string) string {
+	hash := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write([]byte(data[key]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// restartWorkloads sets the restart annotation of the restart targets of state to checksum, rolling out the targets
+// that were started with other credentials. Missing targets are skipped, they start with the current credentials.
+func (u *UserManager) restartWorkloads(ctx context.Context, state *v1alpha1.User, checksum string) error {
+	log := logf.FromContext(ctx)
+	for _, target := range state.Spec.RestartTargets {
+		found, err := u.workloadRestarter.Restart(ctx, domain.Namespace(state.GetNamespace()), target,
+			state.GetRestartAnnotation(), checksum)
+		if err != nil {
+			return fmt.Errorf("failed to restart %s %s: %w", target.Kind, target.Name, err)
+		}
+		if !found {
+			log.Info("Restart target not found", "kind", target.Kind, "name", target.Name)
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/testutil"
 	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,8 +20,14 @@ type UserManagerTestSuite struct {
 	suite.Suite
 	ctx context.Context
 
-	userJWTSignerMock *UserJWTSignerMock
-	secretClientMock  *SecretClientMock
+	userJWTSignerMock       *UserJWTSignerMock
+	secretClientMock        *SecretClientMock
+	credentialsSinkFake     *CredentialsSinkFake
+	workloadRestarterFake   *WorkloadRestarterFake
+	permissionSetReaderMock *PermissionSetReaderMock
+	userDefaultsReaderMock  *AccountUserDefaultsReaderMock
+	nauthDefaultsReaderFake *NauthDefaultsReaderFake
+	auditRecorderFake       *AuditRecorderFake
 
 	unitUnderTest *UserManager
 }
@@ -30,13 +37,24 @@ func (t *UserManagerTestSuite) SetupTest() {
 
 	t.userJWTSignerMock = NewUserJWTSignerMock()
 	t.secretClientMock = NewSecretClientMock()
+	t.credentialsSinkFake = NewCredentialsSinkFake()
+	t.workloadRestarterFake = NewWorkloadRestarterFake()
+	t.permissionSetReaderMock = NewPermissionSetReaderMock()
+	t.userDefaultsReaderMock = NewAccountUserDefaultsReaderMock()
+	t.nauthDefaultsReaderFake = NewNauthDefaultsReaderFake()
+	t.auditRecorderFake = NewAuditRecorderFake()
 
-	t.unitUnderTest = NewUserManager(t.userJWTSignerMock, t.secretClientMock)
+	var err error
+	t.unitUnderTest, err = NewUserManager(t.userJWTSignerMock, t.secretClientMock, t.credentialsSinkFake, t.workloadRestarterFake,
+		t.permissionSetReaderMock, t.userDefaultsReaderMock, t.nauthDefaultsReaderFake, t.auditRecorderFake, &Config{CryptoPolicy: CryptoPolicyDefault})
+	t.NoError(err)
 }
 
 func (t *UserManagerTestSuite) TearDownTest() {
 	t.userJWTSignerMock.AssertExpectations(t.T())
 	t.secretClientMock.AssertExpectations(t.T())
+	t.permissionSetReaderMock.AssertExpectations(t.T())
+	t.userDefaultsReaderMock.AssertExpectations(t.T())
 }
 
 func TestUserManager_TestSuite(t *testing.T) {
@@ -59,6 +77,7 @@ func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_WhenNewUser() {
 		},
 	}
 
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
 	var signedUserJWT *SignedUserJWT = nil
 	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
 		func(claims *jwt.UserClaims) *SignedUserJWT {
@@ -100,6 +119,31 @@ func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_WhenNewUser() {
 	t.Equal(accountKeys.AccountID(), user.GetLabel(v1alpha1.UserLabelAccountID))
 	t.Equal(accountKeys.Sign.PublicKey, user.GetLabel(v1alpha1.UserLabelSignedBy))
 	t.verifySecret(accountKeys.Sign.PublicKey, accountKeys.AccountID(), userID, &expiresAt, caughtSecrets)
+	userClaims, err := jwt.DecodeUserClaims(signedUserJWT.UserJWT)
+	t.Require().NoError(err)
+	t.NotEmpty(user.Status.ClaimsHash)
+	t.Require().NotNil(user.Status.JWT)
+	t.Equal(userClaims.ID, user.Status.JWT.ID)
+	t.Equal(userClaims.IssuedAt, user.Status.JWT.IssuedAt.Unix())
+	t.Equal(expiresAt.Unix(), user.Status.JWT.ExpiresAt.Unix())
+	t.Equal(accountKeys.Sign.PublicKey, user.Status.JWT.Issuer)
+	resource := domain.AuditResource{Kind: "User", Namespace: "my-namespace", Name: "my-user"}
+	t.Equal([]domain.AuditEvent{
+		{
+			Action:    domain.AuditActionUserJWTSigned,
+			Resource:  resource,
+			Subject:   userID,
+			AccountID: accountKeys.AccountID(),
+			Issuer:    accountKeys.Sign.PublicKey,
+		},
+		{
+			Action:    domain.AuditActionUserCredentialsCreated,
+			Resource:  resource,
+			Subject:   userID,
+			AccountID: accountKeys.AccountID(),
+			Keys:      []string{userID},
+		},
+	}, t.auditRecorderFake.events)
 }
 
 func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_WhenUpdatedUser() {
@@ -121,6 +165,7 @@ func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_WhenUpdatedUser
 		},
 	}
 
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
 	var signedUserJWT *SignedUserJWT = nil
 	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
 		func(claims *jwt.UserClaims) *SignedUserJWT {
@@ -161,6 +206,661 @@ func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldSucceed_WhenUpdatedUser
 	t.Equal(accountKeys.AccountID(), user.GetLabel(v1alpha1.UserLabelAccountID))
 	t.Equal(accountKeys.Sign.PublicKey, user.GetLabel(v1alpha1.UserLabelSignedBy))
 	t.verifySecret(accountKeys.Sign.PublicKey, accountKeys.AccountID(), userID, nil, caughtSecrets)
+	t.Equal([]domain.AuditAction{domain.AuditActionUserJWTSigned, domain.AuditActionUserCredentialsRotated},
+		t.auditRecorderFake.actions())
+	t.Equal([]string{userID, "fake-prev-user-pub-key"}, t.auditRecorderFake.events[1].Keys)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldMergePermissionSets() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+			Permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+			PermissionSetRefs: []v1alpha1.PermissionSetReference{{Name: "common"}},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.permissionSetReaderMock.mockGet(t.ctx, domain.NewNamespacedName("my-namespace", "common"), &v1alpha1.PermissionSet{
+		ObjectMeta: v1.ObjectMeta{Name: "common", Namespace: "my-namespace", Generation: 3},
+		Spec: v1alpha1.PermissionSetSpec{
+			Permissions: v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>", "_INBOX.>"}},
+				Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}},
+			},
+		},
+	}).Once()
+
+	var signedClaims *jwt.UserClaims
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			signedClaims = claims
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{
+				UserJWT:   userJWT,
+				AccountID: accountKeys.AccountID(),
+				SignedBy:  accountKeys.Sign.PublicKey,
+			}
+		})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(signedClaims)
+	t.Equal(jwt.StringList{"orders.>", "_INBOX.>"}, signedClaims.Pub.Allow)
+	t.Equal(jwt.StringList{"_INBOX.>"}, signedClaims.Sub.Allow)
+	t.Equal([]v1alpha1.ObservedPermissionSet{{Name: "common", ObservedGeneration: 3}}, user.Status.PermissionSets)
+	t.Equal(v1alpha1.StringList{"orders.>"}, user.Spec.Permissions.Pub.Allow, "spec must not be modified")
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldFail_WhenPermissionSetNotFound() {
+	// Given
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName:       "my-account",
+			PermissionSetRefs: []v1alpha1.PermissionSetReference{{Name: "missing"}},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.permissionSetReaderMock.mockGetError(t.ctx, domain.NewNamespacedName("my-namespace", "missing"),
+		domain.ErrPermissionSetNotFound).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.ErrorIs(err, domain.ErrPermissionSetNotFound)
+	t.ErrorContains(err, "failed to resolve PermissionSet my-namespace/missing")
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldApplyAccountUserDefaults() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	expiresAt := futureUserExpiresAt()
+	subs := int64(10)
+
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+			Permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+		},
+	}
+	userDefaults := &v1alpha1.UserDefaults{
+		Permissions: &v1alpha1.Permissions{
+			Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"_INBOX.>"}},
+		},
+		NatsLimits: &v1alpha1.NatsLimits{Subs: &subs},
+		ExpiresAt:  &expiresAt,
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), userDefaults)
+
+	var signedClaims *jwt.UserClaims
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			signedClaims = claims
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{
+				UserJWT:   userJWT,
+				AccountID: accountKeys.AccountID(),
+				SignedBy:  accountKeys.Sign.PublicKey,
+			}
+		})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(signedClaims)
+	t.Equal(jwt.StringList{"orders.>"}, signedClaims.Pub.Allow, "user permissions should override the default")
+	t.Empty(signedClaims.Sub.Allow)
+	t.Equal(int64(10), signedClaims.Subs)
+	t.Equal(expiresAt.Unix(), signedClaims.Expires)
+	t.Equal(userDefaults, user.Status.AccountUserDefaults)
+	t.Nil(user.Spec.NatsLimits, "spec must not be modified")
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldMergeResponsePermissionOfAccountUserDefaults() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName:       "my-account",
+			PermissionSetRefs: []v1alpha1.PermissionSetReference{{Name: "common"}},
+		},
+	}
+	userDefaults := &v1alpha1.UserDefaults{
+		Permissions: &v1alpha1.Permissions{
+			Sub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			Resp: &v1alpha1.ResponsePermission{
+				MaxMsgs: 1,
+				Expires: 5 * time.Second,
+			},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), userDefaults)
+	t.permissionSetReaderMock.mockGet(t.ctx, domain.NewNamespacedName("my-namespace", "common"), &v1alpha1.PermissionSet{
+		ObjectMeta: v1.ObjectMeta{Name: "common", Namespace: "my-namespace"},
+		Spec: v1alpha1.PermissionSetSpec{
+			Permissions: v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Deny: v1alpha1.StringList{"$SYS.>"}},
+				Resp: &v1alpha1.ResponsePermission{
+					MaxMsgs: 10,
+					Expires: time.Minute,
+				},
+			},
+		},
+	}).Once()
+
+	var signedClaims *jwt.UserClaims
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			signedClaims = claims
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{
+				UserJWT:   userJWT,
+				AccountID: accountKeys.AccountID(),
+				SignedBy:  accountKeys.Sign.PublicKey,
+			}
+		})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(signedClaims)
+	t.Equal(jwt.StringList{"orders.>"}, signedClaims.Sub.Allow)
+	t.Equal(jwt.StringList{"$SYS.>"}, signedClaims.Pub.Deny)
+	t.Equal(&jwt.ResponsePermission{MaxMsgs: 1, Expires: 5 * time.Second}, signedClaims.Resp,
+		"response permission of the account user defaults should be used first")
+	t.Require().NotNil(user.Status.Claims.Permissions)
+	t.Equal(&v1alpha1.ResponsePermission{MaxMsgs: 1, Expires: 5 * time.Second}, user.Status.Claims.Permissions.Resp)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldFail_WhenPermissionSubjectInvalid() {
+	// Given
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+			Permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders .>"}},
+			},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.ErrorIs(err, domain.ErrBadRequest)
+	t.ErrorContains(err, "failed to build NATS user claims for my-namespace/my-user")
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldApplyNauthDefaults() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	subs := int64(10)
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+			Permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.nauthDefaultsReaderFake.defaults = &v1alpha1.NauthDefaultsSpec{
+		User: &v1alpha1.UserPlatformDefaults{
+			NatsLimits: &v1alpha1.NatsLimits{Subs: &subs},
+			DenySubjects: &v1alpha1.DenySubjects{
+				Pub: v1alpha1.StringList{"$SYS.>"},
+				Sub: v1alpha1.StringList{"$SYS.>"},
+			},
+		},
+	}
+
+	var signedClaims *jwt.UserClaims
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			signedClaims = claims
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{
+				UserJWT:   userJWT,
+				AccountID: accountKeys.AccountID(),
+				SignedBy:  accountKeys.Sign.PublicKey,
+			}
+		})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(signedClaims)
+	t.Equal(jwt.StringList{"orders.>"}, signedClaims.Pub.Allow)
+	t.Equal(jwt.StringList{"$SYS.>"}, signedClaims.Pub.Deny)
+	t.Equal(jwt.StringList{"$SYS.>"}, signedClaims.Sub.Deny)
+	t.Equal(int64(10), signedClaims.Subs)
+	t.Empty(user.Spec.Permissions.Pub.Deny, "spec must not be modified")
+	t.Equal(t.nauthDefaultsReaderFake.defaults.User, user.Status.PlatformUserDefaults)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldFail_WhenSignedWithRootKeyAndKeyPolicyRequiresSigningKey() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.nauthDefaultsReaderFake.defaults = &v1alpha1.NauthDefaultsSpec{
+		KeyPolicy: &v1alpha1.KeyPolicy{UserSigning: v1alpha1.UserSigningPolicySigningKeyOnly},
+	}
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Root.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{
+				UserJWT:   userJWT,
+				AccountID: accountKeys.AccountID(),
+				SignedBy:  accountKeys.Root.PublicKey,
+			}
+		})
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.ErrorIs(err, domain.ErrKeyPolicyViolation)
+	t.ErrorContains(err, "NauthDefaults allow signing users with signing keys only")
+	t.Empty(user.GetLabel(v1alpha1.UserLabelUserID), "credentials must not be issued")
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldPushToCredentialsSink() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	sink := v1alpha1.CredentialsSink{
+		ExternalSecretsPush: &v1alpha1.ExternalSecretsPushSink{
+			SecretStoreRef: v1alpha1.SecretStoreReference{Name: "aws-secrets-manager"},
+			RemoteKey:      "nats/my-user",
+		},
+	}
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName:     "my-account",
+			CredentialsSink: &sink,
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{UserJWT: userJWT, AccountID: accountKeys.AccountID(), SignedBy: accountKeys.Sign.PublicKey}
+		})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Equal(map[domain.NamespacedName]v1alpha1.CredentialsSink{
+		domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds"): sink,
+	}, t.credentialsSinkFake.pushed)
+
+	// When (sink removed)
+	user.Spec.CredentialsSink = nil
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+	err = t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Empty(t.credentialsSinkFake.pushed)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldSetCredentialsExpireAt_WhenCredentialsTTLSet() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName:    "my-account",
+			CredentialsTTL: &v1.Duration{Duration: 10 * time.Minute},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{UserJWT: userJWT, AccountID: accountKeys.AccountID(), SignedBy: accountKeys.Sign.PublicKey}
+		})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().NotNil(user.Status.CredentialsExpireAt)
+	t.WithinDuration(time.Now().Add(10*time.Minute), user.Status.CredentialsExpireAt.Time, time.Minute)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldStoreOnlyJWT_WhenBearer() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+			Bearer:      &v1alpha1.BearerCredentials{TTL: &v1.Duration{Duration: 15 * time.Minute}},
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{UserJWT: userJWT, AccountID: accountKeys.AccountID(), SignedBy: accountKeys.Sign.PublicKey}
+		})
+	var caughtSecrets map[string]string
+	t.secretClientMock.mockApplyWithCatch(t.ctx, user, mock.Anything, mock.AnythingOfType("map[string]string"),
+		func(secret map[string]string) {
+			caughtSecrets = secret
+		})
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().Len(caughtSecrets, 1)
+	userClaims, err := jwt.DecodeUserClaims(caughtSecrets[domain.UserJWTSecretKeyName])
+	t.Require().NoError(err)
+	t.True(userClaims.BearerToken)
+	t.Equal(user.GetLabel(v1alpha1.UserLabelUserID), userClaims.Subject)
+	t.WithinDuration(time.Now().Add(15*time.Minute), time.Unix(userClaims.Expires, 0), time.Minute)
+	t.Require().NotNil(user.Status.JWT)
+	t.NotNil(user.Status.JWT.ExpiresAt)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldStoreJWTAndNKey_WhenCredentialsFormatExpanded() {
+	// Given
+	accountKeys := testutil.CreateNatsTestAccount()
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName:       "my-account",
+			CredentialsFormat: v1alpha1.CredentialsFormatExpanded,
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaults(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"), nil)
+	t.userJWTSignerMock.mockSignUserJWT(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		func(claims *jwt.UserClaims) *SignedUserJWT {
+			claims.IssuerAccount = accountKeys.Root.PublicKey
+			userJWT, err := claims.Encode(accountKeys.Sign.Key)
+			t.NoError(err, "claims.Encode should not return an error")
+			return &SignedUserJWT{UserJWT: userJWT, AccountID: accountKeys.AccountID(), SignedBy: accountKeys.Sign.PublicKey}
+		})
+	var caughtSecrets map[string]string
+	t.secretClientMock.mockApplyWithCatch(t.ctx, user, mock.Anything, mock.AnythingOfType("map[string]string"),
+		func(secret map[string]string) {
+			caughtSecrets = secret
+		})
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().Len(caughtSecrets, 4)
+	userJWT, err := jwt.ParseDecoratedJWT([]byte(caughtSecrets[domain.UserCredentialSecretKeyName]))
+	t.Require().NoError(err)
+	t.Equal(userJWT, caughtSecrets[domain.UserJWTSecretKeyName])
+	userKeyPair, err := jwt.ParseDecoratedNKey([]byte(caughtSecrets[domain.UserCredentialSecretKeyName]))
+	t.Require().NoError(err)
+	seed, err := userKeyPair.Seed()
+	t.Require().NoError(err)
+	t.Equal(string(seed), caughtSecrets[domain.UserSeedSecretKeyName])
+	t.Equal(user.GetLabel(v1alpha1.UserLabelUserID), caughtSecrets[domain.UserPublicKeySecretKeyName])
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldStoreNKeyWithoutJWT_WhenNKey() {
+	// Given
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+			Labels:    map[string]string{string(v1alpha1.UserLabelAccountID): "ACCOUNT_ID"},
+		},
+		Spec: v1alpha1.UserSpec{
+			NKey: &v1alpha1.NKeyUser{Authorization: true},
+			Permissions: &v1alpha1.Permissions{
+				Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+			},
+			AllowedConnectionTypes: []v1alpha1.ConnectionType{v1alpha1.ConnectionTypeStandard},
+		},
+	}
+	t.secretClientMock.mockGetNotFound(domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds"))
+	var caughtSecrets map[string]string
+	t.secretClientMock.mockApplyWithCatch(t.ctx, user, mock.Anything, mock.AnythingOfType("map[string]string"),
+		func(secret map[string]string) {
+			caughtSecrets = secret
+		})
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	userID := user.GetLabel(v1alpha1.UserLabelUserID)
+	t.Require().Len(caughtSecrets, 3)
+	t.Equal(userID, caughtSecrets[domain.UserPublicKeySecretKeyName])
+	keyPair, err := nkeys.FromSeed([]byte(caughtSecrets[domain.UserSeedSecretKeyName]))
+	t.Require().NoError(err)
+	publicKey, err := keyPair.PublicKey()
+	t.Require().NoError(err)
+	t.Equal(userID, publicKey)
+	t.Equal(fmt.Sprintf(`{
+  "nkey": "%s",
+  "permissions": {
+    "publish": {
+      "allow": [
+        "orders.>"
+      ]
+    }
+  },
+  "allowed_connection_types": [
+    "STANDARD"
+  ]
+}
+`, userID), caughtSecrets[domain.UserAuthorizationSecretKeyName])
+	t.Empty(user.GetLabel(v1alpha1.UserLabelAccountID))
+	t.Nil(user.Status.JWT)
+	t.userJWTSignerMock.AssertNotCalled(t.T(), "SignUserJWT", mock.Anything, mock.Anything, mock.Anything)
+	t.Equal([]domain.AuditEvent{{
+		Action:   domain.AuditActionUserCredentialsCreated,
+		Resource: domain.AuditResource{Kind: "User", Namespace: "my-namespace", Name: "my-user"},
+		Subject:  userID,
+		Keys:     []string{userID},
+	}}, t.auditRecorderFake.events)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldKeepNKey_WhenNKeyUserUpdated() {
+	// Given
+	userKeyPair, err := nkeys.CreateUser()
+	t.Require().NoError(err)
+	userSeed, err := userKeyPair.Seed()
+	t.Require().NoError(err)
+	userPublicKey, err := userKeyPair.PublicKey()
+	t.Require().NoError(err)
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+			Labels:    map[string]string{string(v1alpha1.UserLabelUserID): userPublicKey},
+		},
+		Spec: v1alpha1.UserSpec{
+			NKey: &v1alpha1.NKeyUser{},
+		},
+	}
+	t.secretClientMock.mockGet(t.ctx, domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds"),
+		map[string]string{domain.UserSeedSecretKeyName: string(userSeed)})
+	var caughtSecrets map[string]string
+	t.secretClientMock.mockApplyWithCatch(t.ctx, user, mock.Anything, mock.AnythingOfType("map[string]string"),
+		func(secret map[string]string) {
+			caughtSecrets = secret
+		})
+
+	// When
+	err = t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Equal(map[string]string{
+		domain.UserSeedSecretKeyName:      string(userSeed),
+		domain.UserPublicKeySecretKeyName: userPublicKey,
+	}, caughtSecrets)
+	t.Equal(userPublicKey, user.GetLabel(v1alpha1.UserLabelUserID))
+	t.Empty(t.auditRecorderFake.events)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldRestartWorkloadsOnce_WhenCredentialsUnchanged() {
+	// Given
+	deployment := v1alpha1.RestartTarget{Kind: v1alpha1.RestartTargetKindDeployment, Name: "my-app"}
+	missing := v1alpha1.RestartTarget{Kind: v1alpha1.RestartTargetKindStatefulSet, Name: "not-deployed"}
+	t.workloadRestarterFake.existing = []v1alpha1.RestartTarget{deployment}
+	userKeyPair, err := nkeys.CreateUser()
+	t.Require().NoError(err)
+	userSeed, err := userKeyPair.Seed()
+	t.Require().NoError(err)
+	userPublicKey, err := userKeyPair.PublicKey()
+	t.Require().NoError(err)
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+			Labels:    map[string]string{string(v1alpha1.UserLabelUserID): userPublicKey},
+		},
+		Spec: v1alpha1.UserSpec{
+			NKey:           &v1alpha1.NKeyUser{},
+			RestartTargets: []v1alpha1.RestartTarget{deployment, missing},
+		},
+	}
+	secretRef := domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds")
+	var checksums []string
+	t.secretClientMock.mockGet(t.ctx, secretRef, map[string]string{domain.UserSeedSecretKeyName: string(userSeed)})
+	t.secretClientMock.mockApply(t.ctx, user, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			checksums = append(checksums, args.Get(2).(v1.ObjectMeta).Annotations[domain.AnnotationCredentialsChecksum])
+		}).
+		Return(nil)
+
+	// When
+	err = t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+	t.Require().NoError(err)
+	err = t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Require().Len(checksums, 2)
+	t.NotEmpty(checksums[0])
+	t.Equal(checksums[0], checksums[1], "checksum must be stable while the credentials are unchanged")
+	t.Equal(map[v1alpha1.RestartTarget]map[string]string{
+		deployment: {"credentials.nauth.io/my-user": checksums[0]},
+	}, t.workloadRestarterFake.annotations)
+	t.Equal(1, t.workloadRestarterFake.restarts)
+}
+
+func (t *UserManagerTestSuite) Test_CreateOrUpdate_ShouldFail_WhenAccountNotFound() {
+	// Given
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+		},
+	}
+	t.userDefaultsReaderMock.mockGetUserDefaultsError(t.ctx, domain.NewNamespacedName("my-namespace", "my-account"),
+		domain.ErrAccountNotFound)
+
+	// When
+	err := t.unitUnderTest.CreateOrUpdate(t.ctx, user)
+
+	// Then
+	t.ErrorIs(err, domain.ErrAccountNotFound)
 }
 
 func (t *UserManagerTestSuite) Test_Delete_ShouldSucceed() {
@@ -181,6 +881,32 @@ func (t *UserManagerTestSuite) Test_Delete_ShouldSucceed() {
 
 	// Then
 	t.NoError(err)
+	t.Equal([]domain.NamespacedName{domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds")}, t.credentialsSinkFake.removed)
+	t.Equal([]domain.AuditAction{domain.AuditActionUserCredentialsDeleted}, t.auditRecorderFake.actions())
+}
+
+func (t *UserManagerTestSuite) Test_ExpireCredentials_ShouldDeleteSecret() {
+	// Given
+	expireAt := v1.Now()
+	user := &v1alpha1.User{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-user",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.UserSpec{
+			AccountName: "my-account",
+		},
+		Status: v1alpha1.UserStatus{CredentialsExpireAt: &expireAt},
+	}
+	t.secretClientMock.mockDelete(t.ctx, domain.NewNamespacedName("my-namespace", "my-user-nats-user-creds"))
+
+	// When
+	err := t.unitUnderTest.ExpireCredentials(t.ctx, user)
+
+	// Then
+	t.NoError(err)
+	t.Nil(user.Status.CredentialsExpireAt)
+	t.Equal([]domain.AuditAction{domain.AuditActionUserCredentialsDeleted}, t.auditRecorderFake.actions())
 }
 
 func (t *UserManagerTestSuite) Test_Delete_ShouldFail_WhenDeleteSecretFails() {
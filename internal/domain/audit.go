@@ -0,0 +1,58 @@
+package domain
+
+// AuditAction is a mutation of NATS authorization state recorded in the audit log.
+type AuditAction string
+
+const (
+	// AuditActionAccountKeysCreated records new account root and signing keys written to Secrets.
+	AuditActionAccountKeysCreated AuditAction = "AccountKeysCreated"
+	// AuditActionAccountKeysProvided records account root and signing keys generated outside of NAuth written to the
+	// Secrets of the account.
+	AuditActionAccountKeysProvided AuditAction = "AccountKeysProvided"
+	// AuditActionAccountKeysDeleted records the deletion of the Secrets holding the account keys.
+	AuditActionAccountKeysDeleted AuditAction = "AccountKeysDeleted"
+	// AuditActionAccountKeysAdopted records the Secrets holding the account keys moved from a deleted Account to the
+	// Account adopting the account.
+	AuditActionAccountKeysAdopted AuditAction = "AccountKeysAdopted"
+	// AuditActionAccountJWTPushed records an account JWT signed by the operator and uploaded to NATS.
+	AuditActionAccountJWTPushed AuditAction = "AccountJWTPushed"
+	// AuditActionAccountClaimsMutated records account claims changed by the claims mutation hook before they were signed
+	// and pushed. Changes lists the claim fields the hook changed.
+	AuditActionAccountClaimsMutated AuditAction = "AccountClaimsMutated"
+	// AuditActionAccountJWTDeleted records the deletion of an account JWT from NATS.
+	AuditActionAccountJWTDeleted AuditAction = "AccountJWTDeleted"
+	// AuditActionUserJWTSigned records a user JWT signed by an account signing key.
+	AuditActionUserJWTSigned AuditAction = "UserJWTSigned"
+	// AuditActionUserCredentialsCreated records the first user credentials written to a Secret.
+	AuditActionUserCredentialsCreated AuditAction = "UserCredentialsCreated"
+	// AuditActionUserCredentialsRotated records user credentials replaced in a Secret. Keys lists the new and the replaced
+	// user public key.
+	AuditActionUserCredentialsRotated AuditAction = "UserCredentialsRotated"
+	// AuditActionUserCredentialsDeleted records the deletion of the Secret holding user credentials.
+	AuditActionUserCredentialsDeleted AuditAction = "UserCredentialsDeleted"
+)
+
+// AuditResource is the Kubernetes resource whose reconciliation caused an audited mutation.
+type AuditResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// AuditEvent describes a mutation of NATS authorization state.
+type AuditEvent struct {
+	Action   AuditAction   `json:"action"`
+	Resource AuditResource `json:"resource"`
+	// Subject is the public key of the account or user the mutation applies to.
+	Subject string `json:"subject,omitempty"`
+	// AccountID is the account of a user subject.
+	AccountID string `json:"accountID,omitempty"`
+	// Issuer is the public key of the key that signed the JWT.
+	Issuer string `json:"issuer,omitempty"`
+	// Keys are the public keys created or deleted.
+	Keys []string `json:"keys,omitempty"`
+	// ClaimsHash is the hash of the account claims pushed to NATS.
+	ClaimsHash string `json:"claimsHash,omitempty"`
+	// Changes lists the claim fields that differ from the previous JWT, when it is known.
+	Changes []string `json:"changes,omitempty"`
+}
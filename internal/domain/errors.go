@@ -8,13 +8,91 @@ import (
 type Error string
 
 const (
-	ErrUnknownError      Error = "UnknownError"
-	ErrBadRequest        Error = "BadRequest"
-	ErrAccountNotFound   Error = "AccountNotFound"
-	ErrAccountNotReady   Error = "AccountNotReady"
-	ErrConfigMapNotFound Error = "ConfigMapNotFound"
+	ErrUnknownError          Error = "UnknownError"
+	ErrBadRequest            Error = "BadRequest"
+	ErrAccountNotFound       Error = "AccountNotFound"
+	ErrAccountNotReady       Error = "AccountNotReady"
+	ErrConfigMapNotFound     Error = "ConfigMapNotFound"
+	ErrPermissionSetNotFound Error = "PermissionSetNotFound"
+	// ErrSystemAccountConflict is returned when an operation would modify or adopt the NATS system account.
+	ErrSystemAccountConflict Error = "SystemAccountConflict"
+	// ErrReferenceNotGranted is returned when a cross-namespace reference is not allowed by a ReferenceGrant.
+	ErrReferenceNotGranted Error = "ReferenceNotGranted"
+	// ErrJetStreamResourcesExist is returned when an account cannot be deleted while it still has JetStream resources.
+	ErrJetStreamResourcesExist Error = "JetStreamResourcesExist"
+	// ErrRequiredLabelsMissing is returned when a resource lacks a label required by the NauthDefaults.
+	ErrRequiredLabelsMissing Error = "RequiredLabelsMissing"
+	// ErrQuotaExceeded is returned when a resource does not fit a NauthQuota of its namespace.
+	ErrQuotaExceeded Error = "QuotaExceeded"
+	// ErrPolicyViolation is returned when an account exports or imports a subject not allowed by a NauthPolicy.
+	ErrPolicyViolation Error = "PolicyViolation"
+	// ErrClusterUnreachable is returned when the NATS cluster cannot be connected to with the system account.
+	ErrClusterUnreachable Error = "ClusterUnreachable"
+	// ErrSecretNameCollision is returned when the name of an account secret is taken by a secret of another account.
+	ErrSecretNameCollision Error = "SecretNameCollision"
+	// ErrMaintenanceWindow is returned when an operation would change NATS during a maintenance window of the cluster.
+	ErrMaintenanceWindow Error = "MaintenanceWindow"
+	// ErrClusterCapacityExceeded is returned when the JetStream limits of an account exceed the capacity of its cluster.
+	ErrClusterCapacityExceeded Error = "ClusterCapacityExceeded"
+	// ErrAccountStillBound is returned when an account is adopted while the Account it belongs to still exists.
+	ErrAccountStillBound Error = "AccountStillBound"
+	// ErrJWTTooLarge is returned when an account JWT is larger than the NATS servers accept.
+	ErrJWTTooLarge Error = "JWTTooLarge"
+	// ErrInvalidMutatedClaims is returned when the claims mutation hook returns account claims that cannot be signed.
+	ErrInvalidMutatedClaims Error = "InvalidMutatedClaims"
+	// ErrKeyPolicyViolation is returned when a JWT would be signed with a key forbidden by the NauthDefaults.
+	ErrKeyPolicyViolation Error = "KeyPolicyViolation"
 )
 
+// Error classes tell whether retrying a failed operation can succeed. Errors without a more specific domain error wrap
+// a class directly, other domain errors are classified by ClassOf.
+const (
+	// ErrTransient is returned when an operation may succeed when retried, e.g. while NATS is unreachable.
+	ErrTransient Error = "Transient"
+	// ErrInvalidConfig is returned when an operation fails because of a misconfiguration, e.g. a malformed seed.
+	ErrInvalidConfig Error = "InvalidConfig"
+	// ErrConflict is returned when an operation conflicts with another resource or with the state of NATS.
+	ErrConflict Error = "Conflict"
+	// ErrUnsupported is returned when an operation requests something NAuth does not support.
+	ErrUnsupported Error = "Unsupported"
+)
+
+var errorClasses = map[Error]Error{
+	ErrTransient:               ErrTransient,
+	ErrInvalidConfig:           ErrInvalidConfig,
+	ErrConflict:                ErrConflict,
+	ErrUnsupported:             ErrUnsupported,
+	ErrBadRequest:              ErrInvalidConfig,
+	ErrReferenceNotGranted:     ErrInvalidConfig,
+	ErrRequiredLabelsMissing:   ErrInvalidConfig,
+	ErrPolicyViolation:         ErrInvalidConfig,
+	ErrJWTTooLarge:             ErrInvalidConfig,
+	ErrInvalidMutatedClaims:    ErrInvalidConfig,
+	ErrKeyPolicyViolation:      ErrInvalidConfig,
+	ErrSystemAccountConflict:   ErrConflict,
+	ErrSecretNameCollision:     ErrConflict,
+	ErrAccountStillBound:       ErrConflict,
+	ErrQuotaExceeded:           ErrConflict,
+	ErrClusterCapacityExceeded: ErrConflict,
+}
+
+// ClassOf returns the class of the domain error wrapped by err. Errors not known to be permanent are ErrTransient.
+func ClassOf(err error) Error {
+	var domainErr Error
+	if errors.As(err, &domainErr) {
+		if class, ok := errorClasses[domainErr]; ok {
+			return class
+		}
+	}
+	return ErrTransient
+}
+
+// IsTransient reports whether retrying the operation that failed with err may succeed without changes to the resources
+// involved.
+func IsTransient(err error) bool {
+	return ClassOf(err) == ErrTransient
+}
+
 func (e Error) Error() string {
 	return string(e)
 }
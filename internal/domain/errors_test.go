@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,3 +40,24 @@ func Test_Error_WithCause(t *testing.T) {
 	require.ErrorAs(t, err, &domainErr)
 	require.Equal(t, ErrBadRequest, domainErr)
 }
+
+func Test_ClassOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected Error
+	}{
+		{name: "plain error", err: errors.New("failed"), expected: ErrTransient},
+		{name: "unclassified domain error", err: ErrClusterUnreachable.WithCause(errors.New("timeout")), expected: ErrTransient},
+		{name: "class", err: ErrInvalidConfig.WithCause(errors.New("malformed seed")), expected: ErrInvalidConfig},
+		{name: "classified domain error", err: ErrBadRequest, expected: ErrInvalidConfig},
+		{name: "wrapped classified domain error", err: fmt.Errorf("failed: %w", ErrSecretNameCollision), expected: ErrConflict},
+		{name: "unsupported", err: ErrUnsupported.WithCause(errors.New("unknown kind")), expected: ErrUnsupported},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ClassOf(tc.err))
+			require.Equal(t, tc.expected == ErrTransient, IsTransient(tc.err))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nkeys"
@@ -55,3 +56,94 @@ func (n *NatsUserCreds) Validate() error {
 	}
 	return nil
 }
+
+// NatsTLSConfig holds the PEM encoded TLS material used when connecting to a NATS cluster.
+type NatsTLSConfig struct {
+	// CACert is the CA bundle used to verify the NATS servers. Empty means the system roots are used.
+	CACert []byte
+	// ClientCert and ClientKey are presented to the NATS servers for mutual TLS.
+	ClientCert []byte
+	ClientKey  []byte
+	// InsecureSkipVerify disables verification of the NATS server certificates.
+	InsecureSkipVerify bool
+}
+
+func (t *NatsTLSConfig) Validate() error {
+	if (len(t.ClientCert) == 0) != (len(t.ClientKey) == 0) {
+		return fmt.Errorf("client certificate and key must be provided together")
+	}
+	return nil
+}
+
+// NatsResolverType selects where account JWTs are written for the NATS servers to resolve accounts from.
+type NatsResolverType string
+
+const (
+	// NatsResolverTypePush uploads account JWTs to the NATS servers through the system account.
+	NatsResolverTypePush NatsResolverType = "push"
+	// NatsResolverTypeKV writes account JWTs to a JetStream key-value bucket of the system account.
+	NatsResolverTypeKV NatsResolverType = "kv"
+	// NatsResolverTypeDirectory writes account JWTs to a resolver directory, shared with the NATS servers.
+	NatsResolverTypeDirectory NatsResolverType = "directory"
+)
+
+// NatsServerInfo describes a NATS server that responded to a ping of the system account.
+type NatsServerInfo struct {
+	Name        string
+	Version     string
+	JetStream   bool
+	Connections int
+	// JetStreamMaxMemory and JetStreamMaxStorage are the JetStream storage the server is configured with, in bytes.
+	// They are zero when JetStream is not enabled or the server does not report them.
+	JetStreamMaxMemory  int64
+	JetStreamMaxStorage int64
+}
+
+// NatsAccountUsage is the current usage of an account, summed over the servers of its cluster.
+type NatsAccountUsage struct {
+	Connections   int
+	LeafNodes     int
+	Subscriptions int
+	// JetStream is nil when JetStream is not enabled for the account.
+	JetStream *NatsJetStreamUsage
+}
+
+// NatsJetStreamUsage is the JetStream storage used by an account.
+type NatsJetStreamUsage struct {
+	MemoryBytes  uint64
+	StorageBytes uint64
+}
+
+// NatsClaimsPush is an account JWT queued for upload to a NATS cluster but not sent yet.
+type NatsClaimsPush struct {
+	// ClusterKey identifies the NATS cluster by a hash of its URL, credentials and TLS settings.
+	ClusterKey string    `json:"clusterKey"`
+	Token      string    `json:"token"`
+	QueuedAt   time.Time `json:"queuedAt"`
+}
+
+// NatsResolver configures how account JWTs reach the NATS servers. The zero value pushes them.
+type NatsResolver struct {
+	Type NatsResolverType
+	// KVBucket is the key-value bucket of the kv resolver type.
+	KVBucket string
+	// Directory is the resolver directory of the directory resolver type.
+	Directory string
+}
+
+func (r *NatsResolver) Validate() error {
+	switch r.Type {
+	case "", NatsResolverTypePush:
+	case NatsResolverTypeKV:
+		if r.KVBucket == "" {
+			return fmt.Errorf("key-value bucket is required for resolver type %s", r.Type)
+		}
+	case NatsResolverTypeDirectory:
+		if r.Directory == "" {
+			return fmt.Errorf("directory is required for resolver type %s", r.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported resolver type %q", r.Type)
+	}
+	return nil
+}
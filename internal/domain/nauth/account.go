@@ -8,17 +8,32 @@ import (
 )
 
 type AccountRequest struct {
-	AccountRef       domain.NamespacedName `json:"accountRef,omitempty"`
-	AccountID        AccountID             `json:"accountId,omitempty"`
-	ClaimsHash       string                `json:"claimsHash,omitempty"`
-	DisplayName      string                `json:"displayName,omitempty"`
-	ClusterTarget    ClusterTarget         `json:"clusterTarget,omitempty"`
-	AccountLimits    *AccountLimits        `json:"accountLimits,omitempty"`
-	JetStreamEnabled *bool                 `json:"jetStreamEnabled,omitempty"`
-	JetStreamLimits  *JetStreamLimits      `json:"jetStreamLimits,omitempty"`
-	NatsLimits       *NatsLimits           `json:"natsLimits,omitempty"`
-	ExportGroups     ExportGroups          `json:"exportGroups,omitempty"`
-	ImportGroups     ImportGroups          `json:"importGroups,omitempty"`
+	AccountRef            domain.NamespacedName `json:"accountRef,omitempty"`
+	AccountID             AccountID             `json:"accountId,omitempty"`
+	ClaimsHash            string                `json:"claimsHash,omitempty"`
+	DisplayName           string                `json:"displayName,omitempty"`
+	Description           string                `json:"description,omitempty"`
+	InfoURL               string                `json:"infoURL,omitempty"`
+	Tags                  []string              `json:"tags,omitempty"`
+	ClusterTarget         ClusterTarget         `json:"clusterTarget,omitempty"`
+	AccountLimits         *AccountLimits        `json:"accountLimits,omitempty"`
+	JetStreamEnabled      *bool                 `json:"jetStreamEnabled,omitempty"`
+	JetStreamLimits       *JetStreamLimits      `json:"jetStreamLimits,omitempty"`
+	JetStreamTieredLimits JetStreamTieredLimits `json:"jetStreamTieredLimits,omitempty"`
+	NatsLimits            *NatsLimits           `json:"natsLimits,omitempty"`
+	Mappings              SubjectMappings       `json:"mappings,omitempty"`
+	DisallowBearer        bool                  `json:"disallowBearer,omitempty"`
+	DefaultPermissions    *Permissions          `json:"defaultPermissions,omitempty"`
+	Trace                 *MessageTrace         `json:"trace,omitempty"`
+	ClusterTraffic        string                `json:"clusterTraffic,omitempty"`
+	ExportGroups          ExportGroups          `json:"exportGroups,omitempty"`
+	ImportGroups          ImportGroups          `json:"importGroups,omitempty"`
+	// ObservedClaims are kept as they are in the account JWT in NATS instead of being built from the request.
+	ObservedClaims []AccountClaimField `json:"observedClaims,omitempty"`
+	// ForcePush uploads the account JWT even when NATS already has equivalent claims.
+	ForcePush bool `json:"forcePush,omitempty"`
+	// KeySecrets creates the account with keys generated outside of NAuth instead of generating them.
+	KeySecrets *AccountKeySecrets `json:"keySecrets,omitempty"`
 }
 
 func (r AccountRequest) Validate() error {
@@ -30,6 +45,15 @@ func (r AccountRequest) Validate() error {
 		return fmt.Errorf("invalid cluster target: %w", err)
 	}
 
+	if r.JetStreamLimits != nil && len(r.JetStreamTieredLimits) > 0 {
+		return fmt.Errorf("JetStream limits and tiered JetStream limits are mutually exclusive")
+	}
+	for tier := range r.JetStreamTieredLimits {
+		if tier == "" {
+			return fmt.Errorf("tiered JetStream limits require a tier name")
+		}
+	}
+
 	exportGroupNames := make(map[Ref]struct{})
 	for _, exportGroup := range r.ExportGroups {
 		if exportGroup.Ref == "" {
@@ -54,6 +78,29 @@ func (r AccountRequest) Validate() error {
 	return nil
 }
 
+// AccountKeySecrets references the Secrets holding the seeds of account keys, in the namespace of the Account.
+type AccountKeySecrets struct {
+	Root    SecretKeyRef `json:"root"`
+	Signing SecretKeyRef `json:"signing"`
+}
+
+// SecretKeyRef references a key of a Secret. An empty key selects the default key.
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// AccountClaimField selects a part of the account claims.
+type AccountClaimField string
+
+const (
+	AccountClaimExports AccountClaimField = "Exports"
+	AccountClaimImports AccountClaimField = "Imports"
+	// AccountClaimLimits covers the account, JetStream and NATS limits.
+	AccountClaimLimits   AccountClaimField = "Limits"
+	AccountClaimMappings AccountClaimField = "Mappings"
+)
+
 type AccountReference struct {
 	AccountRef    domain.NamespacedName
 	AccountID     AccountID
@@ -76,7 +123,41 @@ type AccountResult struct {
 	AccountSignedBy string
 	Claims          *AccountClaims
 	ClaimsHash      string
-	Adoptions       *AccountAdoptions
+	// JWT describes the account JWT known to be live in NATS. Unset when NATS was not contacted by this operation.
+	JWT       *JWTMetadata
+	Adoptions *AccountAdoptions
+	// SigningKeyCreated is set when the account keys were created by this operation.
+	SigningKeyCreated bool
+	// JWTPushed is set when the account JWT was uploaded to the NATS cluster by this operation.
+	JWTPushed bool
+	// PendingChanges is set when the account JWT was not uploaded because a maintenance window of the cluster is
+	// active. Claims and ClaimsHash then describe the claims waiting to be pushed.
+	PendingChanges *AccountPendingChanges
+	// JWTSize is set when the account JWT is close to the size limit of the NATS servers.
+	JWTSize *JWTSize
+}
+
+// JWTSize is the size of an encoded JWT and the largest size the NATS servers accept, in bytes.
+type JWTSize struct {
+	Bytes int
+	Limit int64
+}
+
+// AccountPendingChanges describes account claims not pushed to NATS because a maintenance window is active.
+type AccountPendingChanges struct {
+	ClaimsHash string
+	// Fields lists the claims that differ from the account JWT in NATS, when known.
+	Fields []string
+	// Until is the end of the maintenance window.
+	Until time.Time
+}
+
+// JWTMetadata describes a signed JWT.
+type JWTMetadata struct {
+	ID        string
+	IssuedAt  time.Time
+	ExpiresAt *time.Time
+	Issuer    string
 }
 
 type Ref string
@@ -126,6 +207,49 @@ type JetStreamLimits struct {
 	MaxBytesRequired     *bool  `json:"maxBytesRequired,omitempty"`
 }
 
+// JetStreamTieredLimits holds JetStream limits by replication tier name, for example R1 or R3.
+type JetStreamTieredLimits map[string]JetStreamLimits
+
+// MessageTrace sends traces of sampled messages of an account to Destination.
+type MessageTrace struct {
+	Destination Subject `json:"destination,omitempty"`
+	// Sampling is the percentage of messages with a sampled traceparent header that are traced, 100 when zero.
+	Sampling int `json:"sampling,omitempty"`
+}
+
+// Permissions restrict the subjects a user can publish and subscribe to.
+type Permissions struct {
+	Pub  Permission          `json:"pub,omitempty"`
+	Sub  Permission          `json:"sub,omitempty"`
+	Resp *ResponsePermission `json:"resp,omitempty"`
+}
+
+type Permission struct {
+	Allow []Subject `json:"allow,omitempty"`
+	Deny  []Subject `json:"deny,omitempty"`
+}
+
+// ResponsePermission allows publishing up to MaxMsgs replies within Expires to the reply subject of a received request.
+type ResponsePermission struct {
+	MaxMsgs int           `json:"maxMsgs,omitempty"`
+	Expires time.Duration `json:"expires,omitempty"`
+}
+
+type SubjectMappings []SubjectMapping
+
+// SubjectMapping maps messages published to Subject to one of the weighted Destinations.
+type SubjectMapping struct {
+	Subject      Subject               `json:"subject"`
+	Destinations []WeightedDestination `json:"destinations"`
+}
+
+type WeightedDestination struct {
+	Subject Subject `json:"subject"`
+	// Weight is the percentage of messages mapped to the destination, 0 means 100.
+	Weight  uint8  `json:"weight,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+}
+
 type NatsLimits struct {
 	Subs    *int64 `json:"subs,omitempty"`
 	Data    *int64 `json:"data,omitempty"`
@@ -193,6 +317,9 @@ type Export struct {
 	Advertise bool `json:"advertise,omitempty"`
 	// +optional
 	AllowTrace bool `json:"allowTrace,omitempty"`
+	// AllowWildcard acknowledges that Subject has wildcards, as broad wildcard exports easily share internal subjects.
+	// +optional
+	AllowWildcard bool `json:"allowWildcard,omitempty"`
 }
 type ServiceLatency struct {
 	Sampling SamplingRate `json:"sampling"`
@@ -200,15 +327,24 @@ type ServiceLatency struct {
 }
 
 type AccountClaims struct {
-	AccountID        AccountID        `json:"accountId,omitempty"`
-	DisplayName      string           `json:"displayName,omitempty"`
-	AccountLimits    *AccountLimits   `json:"accountLimits,omitempty"`
-	JetStreamEnabled *bool            `json:"jetStreamEnabled,omitempty"`
-	JetStreamLimits  *JetStreamLimits `json:"jetStreamLimits,omitempty"`
-	NatsLimits       *NatsLimits      `json:"natsLimits,omitempty"`
-	SigningKeys      SigningKeys      `json:"signingKeys,omitempty"`
-	Exports          Exports          `json:"exports,omitempty"`
-	Imports          Imports          `json:"imports,omitempty"`
+	AccountID             AccountID             `json:"accountId,omitempty"`
+	DisplayName           string                `json:"displayName,omitempty"`
+	Description           string                `json:"description,omitempty"`
+	InfoURL               string                `json:"infoURL,omitempty"`
+	Tags                  []string              `json:"tags,omitempty"`
+	AccountLimits         *AccountLimits        `json:"accountLimits,omitempty"`
+	JetStreamEnabled      *bool                 `json:"jetStreamEnabled,omitempty"`
+	JetStreamLimits       *JetStreamLimits      `json:"jetStreamLimits,omitempty"`
+	JetStreamTieredLimits JetStreamTieredLimits `json:"jetStreamTieredLimits,omitempty"`
+	NatsLimits            *NatsLimits           `json:"natsLimits,omitempty"`
+	Mappings              SubjectMappings       `json:"mappings,omitempty"`
+	DisallowBearer        bool                  `json:"disallowBearer,omitempty"`
+	DefaultPermissions    *Permissions          `json:"defaultPermissions,omitempty"`
+	Trace                 *MessageTrace         `json:"trace,omitempty"`
+	ClusterTraffic        string                `json:"clusterTraffic,omitempty"`
+	SigningKeys           SigningKeys           `json:"signingKeys,omitempty"`
+	Exports               Exports               `json:"exports,omitempty"`
+	Imports               Imports               `json:"imports,omitempty"`
 }
 
 type AccountAdoptions struct {
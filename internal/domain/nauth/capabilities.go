@@ -0,0 +1,27 @@
+package nauth
+
+import (
+	"fmt"
+	"time"
+)
+
+// Capabilities describes a running nauth operator installation for fleet inventory purposes.
+type Capabilities struct {
+	OperatorVersion   string          `json:"operatorVersion"`
+	OperatorNamespace string          `json:"operatorNamespace"`
+	FeatureGates      map[string]bool `json:"featureGates"`
+	APIVersions       []string        `json:"apiVersions"`
+	ProviderTypes     []string        `json:"providerTypes"`
+	CryptoPolicy      string          `json:"cryptoPolicy"`
+	StartTime         time.Time       `json:"startTime"`
+}
+
+func (c *Capabilities) Validate() error {
+	if c.OperatorNamespace == "" {
+		return fmt.Errorf("operator namespace is required")
+	}
+	if len(c.APIVersions) == 0 {
+		return fmt.Errorf("at least one API version is required")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package nauth
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/WirelessCar/nauth/internal/domain"
 )
@@ -11,6 +12,32 @@ type ClusterTarget struct {
 	NatsURL            string
 	SystemAdminCreds   domain.NatsUserCreds
 	OperatorSigningKey domain.NatsOperatorSigningKey
+	// TLS is optional, nil means the connection is established without client side TLS configuration.
+	TLS *domain.NatsTLSConfig
+	// ExpectedSystemAccountID optionally pins the system account the SystemAdminCreds must belong to.
+	ExpectedSystemAccountID AccountID
+	// ObserveInterval is how often observed accounts are imported again, zero means the default interval.
+	ObserveInterval time.Duration
+	// Resolver configures how account JWTs reach the NATS servers, the zero value pushes them.
+	Resolver domain.NatsResolver
+	// MaintenanceWindows are the periods during which account JWTs are not pushed to or deleted from the cluster.
+	MaintenanceWindows MaintenanceWindows
+	// JetStreamCapacity is the JetStream storage of the cluster as last checked, nil when it is not known.
+	JetStreamCapacity *JetStreamCapacity
+	// OperatorSigningKeySelection chooses the operator signing key of each account when the cluster has several keys,
+	// nil when accounts are signed with OperatorSigningKey.
+	OperatorSigningKeySelection *OperatorSigningKeySelection
+	// AccountDefaults are the limits of the accounts bound to the cluster that do not set them, nil when there are none.
+	AccountDefaults *AccountLimitDefaults
+}
+
+// AccountLimitDefaults holds limits applied to accounts that do not set them. Each field set by an account replaces the
+// field of the default.
+type AccountLimitDefaults struct {
+	AccountLimits *AccountLimits
+	// JetStreamLimits apply to accounts that do not disable JetStream and set no tiered JetStream limits.
+	JetStreamLimits *JetStreamLimits
+	NatsLimits      *NatsLimits
 }
 
 func NewClusterTarget(uid string, natsURL string, systemAdminCreds domain.NatsUserCreds, operatorSigningKey domain.NatsOperatorSigningKey) (*ClusterTarget, error) {
@@ -39,9 +66,65 @@ func (c *ClusterTarget) Validate() error {
 	if c.OperatorSigningKey == nil {
 		return fmt.Errorf("operator signing key is required")
 	}
+	if c.OperatorSigningKeySelection != nil && len(c.OperatorSigningKeySelection.Candidates) == 0 {
+		return fmt.Errorf("operator signing key selection requires at least one key")
+	}
+	if c.TLS != nil {
+		if err := c.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid TLS configuration: %w", err)
+		}
+	}
+	if err := c.Resolver.Validate(); err != nil {
+		return fmt.Errorf("invalid resolver: %w", err)
+	}
+	if c.ExpectedSystemAccountID != "" && c.ExpectedSystemAccountID != c.SystemAccountID() {
+		return fmt.Errorf("system account user credentials belong to account %s, expected system account %s",
+			c.SystemAccountID(), c.ExpectedSystemAccountID)
+	}
 	return nil
 }
 
+// SystemAccountID returns the ID of the system account the cluster is administered through.
+func (c *ClusterTarget) SystemAccountID() AccountID {
+	return AccountID(c.SystemAdminCreds.AccountID)
+}
+
+// IsSystemAccount reports whether accountID is the system account of the cluster.
+func (c *ClusterTarget) IsSystemAccount(accountID AccountID) bool {
+	return accountID != "" && accountID == c.SystemAccountID()
+}
+
+// ClusterHealth describes a NATS cluster as seen through its system account.
+type ClusterHealth struct {
+	// Servers are the servers that responded to a ping, sorted by name.
+	Servers []domain.NatsServerInfo
+	// ResolverType is how account JWTs reach the servers.
+	ResolverType domain.NatsResolverType
+}
+
+// JetStreamCapacity sums the JetStream storage of the servers with JetStream enabled. Returns nil when no server reports
+// its JetStream storage.
+func (h *ClusterHealth) JetStreamCapacity() *JetStreamCapacity {
+	var capacity *JetStreamCapacity
+	for _, server := range h.Servers {
+		if !server.JetStream || server.JetStreamMaxStorage <= 0 {
+			continue
+		}
+		if capacity == nil {
+			capacity = &JetStreamCapacity{}
+		}
+		capacity.MemoryStorage += server.JetStreamMaxMemory
+		capacity.DiskStorage += server.JetStreamMaxStorage
+	}
+	return capacity
+}
+
+// JetStreamCapacity is the JetStream storage of a cluster, in bytes.
+type JetStreamCapacity struct {
+	MemoryStorage int64
+	DiskStorage   int64
+}
+
 type ClusterRefType int64
 
 const (
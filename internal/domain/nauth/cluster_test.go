@@ -0,0 +1,45 @@
+package nauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+func Test_ClusterHealth_JetStreamCapacity(t *testing.T) {
+	testCases := []struct {
+		name     string
+		servers  []domain.NatsServerInfo
+		expected *JetStreamCapacity
+	}{
+		{
+			name: "sums_jetstream_servers",
+			servers: []domain.NatsServerInfo{
+				{Name: "nats-0", JetStream: true, JetStreamMaxMemory: 1024, JetStreamMaxStorage: 4096},
+				{Name: "nats-1", JetStream: true, JetStreamMaxMemory: 1024, JetStreamMaxStorage: 4096},
+				{Name: "nats-2"},
+			},
+			expected: &JetStreamCapacity{MemoryStorage: 2048, DiskStorage: 8192},
+		},
+		{
+			name:     "unknown_without_jetstream",
+			servers:  []domain.NatsServerInfo{{Name: "nats-0"}},
+			expected: nil,
+		},
+		{
+			name:     "unknown_when_not_reported",
+			servers:  []domain.NatsServerInfo{{Name: "nats-0", JetStream: true}},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			health := &ClusterHealth{Servers: tc.servers}
+
+			assert.Equal(t, tc.expected, health.JetStreamCapacity())
+		})
+	}
+}
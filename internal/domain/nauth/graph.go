@@ -0,0 +1,39 @@
+package nauth
+
+// AccountGraph describes which managed accounts import which subjects from which accounts.
+type AccountGraph struct {
+	// Accounts are the managed accounts with an account ID, sorted by namespace and name.
+	Accounts []AccountGraphNode `json:"accounts"`
+	// Imports are the imports of the managed accounts, sorted by importer, exporter and subject.
+	Imports []AccountGraphImport `json:"imports"`
+}
+
+// AccountGraphNode is a managed account and the subjects it exports.
+type AccountGraphNode struct {
+	AccountID string               `json:"accountID"`
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+	Exports   []AccountGraphExport `json:"exports,omitempty"`
+}
+
+// AccountGraphExport is a subject exported by an account.
+type AccountGraphExport struct {
+	Subject Subject    `json:"subject"`
+	Type    ExportType `json:"type"`
+	// Importers is the number of imports of managed accounts served by the export.
+	Importers int `json:"importers"`
+}
+
+// AccountGraphImport is a subject imported by an account from another account.
+type AccountGraphImport struct {
+	// Importer is the account ID of the importing account.
+	Importer string `json:"importer"`
+	// Exporter is the account ID of the exporting account, which may not be managed by NAuth.
+	Exporter     string     `json:"exporter"`
+	Subject      Subject    `json:"subject"`
+	LocalSubject Subject    `json:"localSubject,omitempty"`
+	Type         ExportType `json:"type"`
+	// Export is the subject of the export of the exporter serving the import, empty when the exporter is not managed
+	// or exports no matching subject.
+	Export Subject `json:"export,omitempty"`
+}
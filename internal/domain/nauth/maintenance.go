@@ -0,0 +1,172 @@
+package nauth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow is a recurring period during which NAuth does not change the accounts of a NATS cluster.
+type MaintenanceWindow struct {
+	// Schedule gives the start of each occurrence of the window.
+	Schedule CronSchedule
+	// Duration is how long each occurrence lasts.
+	Duration time.Duration
+}
+
+// MaintenanceWindows are the maintenance windows of a NATS cluster.
+type MaintenanceWindows []MaintenanceWindow
+
+// ActiveUntil reports whether a window is active at now, and when the last of the active windows ends.
+func (w MaintenanceWindows) ActiveUntil(now time.Time) (time.Time, bool) {
+	var end time.Time
+	for _, window := range w {
+		// The latest occurrence started at most Duration ago, the earliest of them is enough to find it.
+		for start := window.Schedule.Next(now.Add(-window.Duration)); !start.IsZero() && !start.After(now); start = window.Schedule.Next(start) {
+			if occurrenceEnd := start.Add(window.Duration); occurrenceEnd.After(end) {
+				end = occurrenceEnd
+			}
+		}
+	}
+	return end, end.After(now)
+}
+
+// CronSchedule matches times by a standard five field cron expression: minute, hour, day of month, month and day of
+// week. Fields are `*`, values, ranges and lists of them, each optionally followed by a `/step`. Day of week 0 and 7
+// are Sunday. As in cron, a time matches either day field when both are restricted.
+type CronSchedule struct {
+	expression string
+	location   *time.Location
+
+	minutes    uint64
+	hours      uint64
+	daysOfMon  uint64
+	months     uint64
+	daysOfWeek uint64
+	anyDom     bool
+	anyDow     bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ParseCronSchedule parses a five field cron expression evaluated in location, UTC when nil.
+func ParseCronSchedule(expression string, location *time.Location) (CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return CronSchedule{}, fmt.Errorf("cron expression %q must have %d fields", expression, len(cronFields))
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	schedule := CronSchedule{expression: expression, location: location}
+	bits := [5]*uint64{&schedule.minutes, &schedule.hours, &schedule.daysOfMon, &schedule.months, &schedule.daysOfWeek}
+	for i, field := range cronFields {
+		value, err := field.parse(fields[i])
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+		}
+		*bits[i] = value
+	}
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1
+	}
+	schedule.anyDom = strings.HasPrefix(fields[2], "*")
+	schedule.anyDow = strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+func (f cronField) parse(expression string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepExpr)
+			}
+		}
+		low, high := f.min, f.max
+		if rangeExpr != "*" {
+			lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = f.value(lowExpr); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = f.value(highExpr); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				high = f.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rangeExpr)
+			}
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(expression string) (int, error) {
+	value, err := strconv.Atoi(expression)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s %q must be a number from %d to %d", f.name, expression, f.min, f.max)
+	}
+	return value, nil
+}
+
+// String returns the cron expression of the schedule.
+func (s CronSchedule) String() string {
+	return s.expression
+}
+
+// Next returns the first time after t matched by the schedule, or the zero time when there is none within five
+// years.
+func (s CronSchedule) Next(t time.Time) time.Time {
+	if s.location == nil {
+		return time.Time{}
+	}
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.daysOfMon&(1<<uint(t.Day())) != 0
+	dow := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDom || s.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
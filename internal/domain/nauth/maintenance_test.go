@@ -0,0 +1,131 @@
+package nauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCronSchedule_Invalid(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expression    string
+		expectedError string
+	}{
+		{name: "too_few_fields", expression: "0 18 * *", expectedError: "must have 5 fields"},
+		{name: "out_of_range", expression: "60 18 * * *", expectedError: "minute \"60\" must be a number from 0 to 59"},
+		{name: "not_a_number", expression: "0 18 * * fri", expectedError: "day of week \"fri\" must be a number from 0 to 7"},
+		{name: "reversed_range", expression: "0 18-6 * * *", expectedError: "invalid hour range \"18-6\""},
+		{name: "zero_step", expression: "*/0 * * * *", expectedError: "invalid minute step \"0\""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCronSchedule(tc.expression, nil)
+
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_CronSchedule_Next(t *testing.T) {
+	after := time.Date(2026, time.October, 16, 10, 30, 20, 0, time.UTC) // a Friday
+	testCases := []struct {
+		name       string
+		expression string
+		expected   time.Time
+	}{
+		{name: "every_minute", expression: "* * * * *", expected: time.Date(2026, time.October, 16, 10, 31, 0, 0, time.UTC)},
+		{name: "step", expression: "*/15 * * * *", expected: time.Date(2026, time.October, 16, 10, 45, 0, 0, time.UTC)},
+		{name: "later_today", expression: "0 18 * * *", expected: time.Date(2026, time.October, 16, 18, 0, 0, 0, time.UTC)},
+		{name: "tomorrow", expression: "0 6 * * *", expected: time.Date(2026, time.October, 17, 6, 0, 0, 0, time.UTC)},
+		{name: "day_of_week", expression: "0 0 * * 1-5", expected: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "sunday_as_seven", expression: "0 0 * * 7", expected: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "day_of_month_or_week", expression: "0 0 1 * 6", expected: time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "next_year", expression: "0 0 1 1 *", expected: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "list", expression: "0,30 9,11 * * *", expected: time.Date(2026, time.October, 16, 11, 0, 0, 0, time.UTC)},
+		{name: "never", expression: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tc.expression, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, schedule.Next(after))
+		})
+	}
+}
+
+func Test_CronSchedule_Next_ShouldUseLocation(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Stockholm")
+	require.NoError(t, err)
+	schedule, err := ParseCronSchedule("0 18 * * *", location)
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2026, time.October, 16, 10, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2026, time.October, 16, 16, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func Test_MaintenanceWindows_ActiveUntil(t *testing.T) {
+	friday, err := ParseCronSchedule("0 18 * * 5", nil)
+	require.NoError(t, err)
+	hourly, err := ParseCronSchedule("0 * * * *", nil)
+	require.NoError(t, err)
+	windows := MaintenanceWindows{{Schedule: friday, Duration: 6 * time.Hour}}
+
+	testCases := []struct {
+		name           string
+		windows        MaintenanceWindows
+		now            time.Time
+		expectedActive bool
+		expectedEnd    time.Time
+	}{
+		{name: "no_windows", now: time.Date(2026, time.October, 16, 20, 0, 0, 0, time.UTC)},
+		{name: "before_window", windows: windows, now: time.Date(2026, time.October, 16, 17, 59, 0, 0, time.UTC)},
+		{
+			name:           "at_start",
+			windows:        windows,
+			now:            time.Date(2026, time.October, 16, 18, 0, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedEnd:    time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "during_window",
+			windows:        windows,
+			now:            time.Date(2026, time.October, 16, 23, 59, 59, 0, time.UTC),
+			expectedActive: true,
+			expectedEnd:    time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "at_end", windows: windows, now: time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)},
+		{
+			name:           "overlapping_occurrences",
+			windows:        MaintenanceWindows{{Schedule: hourly, Duration: 90 * time.Minute}},
+			now:            time.Date(2026, time.October, 16, 10, 15, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedEnd:    time.Date(2026, time.October, 16, 11, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "latest_end_of_windows",
+			windows: append(MaintenanceWindows{{Schedule: hourly, Duration: 30 * time.Minute}},
+				windows...),
+			now:            time.Date(2026, time.October, 16, 18, 10, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedEnd:    time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			end, active := tc.windows.ActiveUntil(tc.now)
+
+			assert.Equal(t, tc.expectedActive, active)
+			if tc.expectedActive {
+				assert.Equal(t, tc.expectedEnd, end)
+			}
+		})
+	}
+}
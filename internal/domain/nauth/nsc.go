@@ -0,0 +1,54 @@
+package nauth
+
+import "github.com/WirelessCar/nauth/internal/domain"
+
+// NscExportResult summarizes an nsc store export.
+type NscExportResult struct {
+	Operators int
+	Accounts  int
+	Users     int
+	// Skipped lists the resources that were intentionally not exported, with the reason.
+	Skipped []string
+}
+
+// NscOperator is an operator read from an nsc store, together with its accounts and users.
+type NscOperator struct {
+	Name     string
+	JWT      string
+	Accounts []NscAccount
+}
+
+// NscAccount is an account read from an nsc store.
+type NscAccount struct {
+	Name  string
+	JWT   string
+	Users []NscUser
+}
+
+// NscUser is a user read from an nsc store. Creds is empty when the keystore holds no credentials file of the user.
+type NscUser struct {
+	Name  string
+	JWT   string
+	Creds []byte
+}
+
+// NscImportRequest selects the operator of an nsc store to import and how its accounts are imported.
+type NscImportRequest struct {
+	// Operator is the operator to import, may be empty when the store holds a single operator.
+	Operator string
+	// Namespace is the namespace of the generated Accounts, Users and Secrets.
+	Namespace domain.Namespace
+	// Observe generates Accounts with the observe management policy, so NAuth reads the accounts from NATS instead of
+	// pushing new JWTs.
+	Observe bool
+}
+
+// NscImportResult summarizes an nsc store import.
+type NscImportResult struct {
+	Accounts int
+	Users    int
+	// Skipped lists the resources that were intentionally not imported, with the reason.
+	Skipped []string
+	// Warnings lists claims of imported resources that NAuth does not carry over and have to be reviewed.
+	Warnings []string
+}
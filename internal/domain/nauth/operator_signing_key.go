@@ -0,0 +1,119 @@
+package nauth
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+// OperatorSigningKeySelectionPolicy is how the operator signing key of an account is chosen from several keys.
+type OperatorSigningKeySelectionPolicy string
+
+const (
+	// OperatorSigningKeySelectionLabel selects the key whose value of the selection label equals the value on the
+	// account.
+	OperatorSigningKeySelectionLabel OperatorSigningKeySelectionPolicy = "Label"
+	// OperatorSigningKeySelectionAnnotation selects the key named by the operator signing key annotation of the account.
+	OperatorSigningKeySelectionAnnotation OperatorSigningKeySelectionPolicy = "Annotation"
+	// OperatorSigningKeySelectionRoundRobin spreads the accounts over the keys.
+	OperatorSigningKeySelectionRoundRobin OperatorSigningKeySelectionPolicy = "RoundRobin"
+)
+
+// OperatorSigningKeyCandidate is one of several operator signing keys of a cluster.
+type OperatorSigningKeyCandidate struct {
+	// Name is the name of the Secret holding the key.
+	Name   string
+	Labels map[string]string
+	Key    domain.NatsOperatorSigningKey
+}
+
+// OperatorSigningKeySelection chooses the operator signing key of each account from several keys.
+type OperatorSigningKeySelection struct {
+	Policy OperatorSigningKeySelectionPolicy
+	// Label is the label compared by the Label policy.
+	Label string
+	// Candidates are the keys to choose from, sorted by name.
+	Candidates []OperatorSigningKeyCandidate
+}
+
+// OperatorSigningKeySelector describes the account an operator signing key is selected for.
+type OperatorSigningKeySelector struct {
+	AccountRef domain.NamespacedName
+	Labels     map[string]string
+	// Annotation is the value of the operator signing key annotation of the account.
+	Annotation string
+	// SignedBy is the public key of the operator signing key the account is signed with, empty for a new account.
+	SignedBy string
+}
+
+// SelectOperatorSigningKey sets the operator signing key of c to the key selected for account and returns the name of
+// the selected key. Targets with a single operator signing key are not changed, and return an empty name.
+func (c *ClusterTarget) SelectOperatorSigningKey(account OperatorSigningKeySelector) (string, error) {
+	if c.OperatorSigningKeySelection == nil {
+		return "", nil
+	}
+	candidate, err := c.OperatorSigningKeySelection.selectFor(account)
+	if err != nil {
+		return "", err
+	}
+	c.OperatorSigningKey = candidate.Key
+	return candidate.Name, nil
+}
+
+// OperatorSigningPublicKeys returns the public keys of the operator signing keys accounts can be signed with.
+func (c *ClusterTarget) OperatorSigningPublicKeys() ([]string, error) {
+	if c.OperatorSigningKeySelection == nil {
+		publicKey, err := c.OperatorSigningKey.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operator signing public key: %w", err)
+		}
+		return []string{publicKey}, nil
+	}
+	publicKeys := make([]string, 0, len(c.OperatorSigningKeySelection.Candidates))
+	for _, candidate := range c.OperatorSigningKeySelection.Candidates {
+		publicKey, err := candidate.Key.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key of operator signing key %s: %w", candidate.Name, err)
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+// selectFor returns the key of account. Accounts without the label or annotation the policy selects by get a key as by
+// the RoundRobin policy.
+func (s *OperatorSigningKeySelection) selectFor(account OperatorSigningKeySelector) (*OperatorSigningKeyCandidate, error) {
+	switch s.Policy {
+	case OperatorSigningKeySelectionLabel:
+		if value, ok := account.Labels[s.Label]; ok {
+			for i := range s.Candidates {
+				if candidateValue, ok := s.Candidates[i].Labels[s.Label]; ok && candidateValue == value {
+					return &s.Candidates[i], nil
+				}
+			}
+			return nil, fmt.Errorf("no operator signing key has label %s=%s", s.Label, value)
+		}
+	case OperatorSigningKeySelectionAnnotation:
+		if account.Annotation != "" {
+			for i := range s.Candidates {
+				if s.Candidates[i].Name == account.Annotation {
+					return &s.Candidates[i], nil
+				}
+			}
+			return nil, fmt.Errorf("operator signing key %q is not one of the keys of the cluster", account.Annotation)
+		}
+	}
+
+	// An account keeps its key, so adding a key does not sign existing accounts again
+	if account.SignedBy != "" {
+		for i := range s.Candidates {
+			if publicKey, err := s.Candidates[i].Key.PublicKey(); err == nil && publicKey == account.SignedBy {
+				return &s.Candidates[i], nil
+			}
+		}
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(account.AccountRef.String()))
+	return &s.Candidates[hash.Sum32()%uint32(len(s.Candidates))], nil
+}
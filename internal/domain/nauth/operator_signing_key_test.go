@@ -0,0 +1,136 @@
+package nauth
+
+import (
+	"testing"
+
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+func Test_ClusterTarget_SelectOperatorSigningKey(t *testing.T) {
+	keyA := newOperatorSigningKey(t)
+	keyB := newOperatorSigningKey(t)
+	publicKeyB, err := keyB.PublicKey()
+	require.NoError(t, err)
+	candidates := []OperatorSigningKeyCandidate{
+		{Name: "op-sign-a", Labels: map[string]string{"nauth.io/tenant": "a"}, Key: keyA},
+		{Name: "op-sign-b", Labels: map[string]string{"nauth.io/tenant": "b"}, Key: keyB},
+	}
+	accountRef := domain.NewNamespacedName("team", "orders")
+
+	testCases := []struct {
+		name          string
+		policy        OperatorSigningKeySelectionPolicy
+		account       OperatorSigningKeySelector
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "label",
+			policy:   OperatorSigningKeySelectionLabel,
+			account:  OperatorSigningKeySelector{AccountRef: accountRef, Labels: map[string]string{"nauth.io/tenant": "b"}},
+			expected: "op-sign-b",
+		},
+		{
+			name:          "label_without_key",
+			policy:        OperatorSigningKeySelectionLabel,
+			account:       OperatorSigningKeySelector{AccountRef: accountRef, Labels: map[string]string{"nauth.io/tenant": "c"}},
+			expectedError: "no operator signing key has label nauth.io/tenant=c",
+		},
+		{
+			name:     "annotation",
+			policy:   OperatorSigningKeySelectionAnnotation,
+			account:  OperatorSigningKeySelector{AccountRef: accountRef, Annotation: "op-sign-b"},
+			expected: "op-sign-b",
+		},
+		{
+			name:          "annotation_unknown_key",
+			policy:        OperatorSigningKeySelectionAnnotation,
+			account:       OperatorSigningKeySelector{AccountRef: accountRef, Annotation: "op-sign-c"},
+			expectedError: `operator signing key "op-sign-c" is not one of the keys of the cluster`,
+		},
+		{
+			name:     "round_robin_keeps_signing_key",
+			policy:   OperatorSigningKeySelectionRoundRobin,
+			account:  OperatorSigningKeySelector{AccountRef: accountRef, SignedBy: publicKeyB},
+			expected: "op-sign-b",
+		},
+		{
+			name:     "annotation_missing_keeps_signing_key",
+			policy:   OperatorSigningKeySelectionAnnotation,
+			account:  OperatorSigningKeySelector{AccountRef: accountRef, SignedBy: publicKeyB},
+			expected: "op-sign-b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := &ClusterTarget{
+				OperatorSigningKey: keyA,
+				OperatorSigningKeySelection: &OperatorSigningKeySelection{
+					Policy:     tc.policy,
+					Label:      "nauth.io/tenant",
+					Candidates: candidates,
+				},
+			}
+
+			name, err := target.SelectOperatorSigningKey(tc.account)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, name)
+			for _, candidate := range candidates {
+				if candidate.Name == tc.expected {
+					assert.Equal(t, candidate.Key, target.OperatorSigningKey)
+				}
+			}
+		})
+	}
+}
+
+func Test_ClusterTarget_SelectOperatorSigningKey_ShouldSpreadAccounts_WhenRoundRobin(t *testing.T) {
+	selection := &OperatorSigningKeySelection{
+		Policy: OperatorSigningKeySelectionRoundRobin,
+		Candidates: []OperatorSigningKeyCandidate{
+			{Name: "op-sign-a", Key: newOperatorSigningKey(t)},
+			{Name: "op-sign-b", Key: newOperatorSigningKey(t)},
+		},
+	}
+	selected := make(map[string]int)
+	for _, name := range []string{"orders", "billing", "payments", "shipping", "audit", "search", "invoices", "users"} {
+		target := &ClusterTarget{OperatorSigningKeySelection: selection}
+		account := OperatorSigningKeySelector{AccountRef: domain.NewNamespacedName("team", name)}
+
+		first, err := target.SelectOperatorSigningKey(account)
+		require.NoError(t, err)
+		second, err := target.SelectOperatorSigningKey(account)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		selected[first]++
+	}
+	assert.Len(t, selected, 2)
+}
+
+func Test_ClusterTarget_SelectOperatorSigningKey_ShouldKeepKey_WhenNoSelection(t *testing.T) {
+	key := newOperatorSigningKey(t)
+	target := &ClusterTarget{OperatorSigningKey: key}
+
+	name, err := target.SelectOperatorSigningKey(OperatorSigningKeySelector{AccountRef: domain.NewNamespacedName("team", "orders")})
+
+	require.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Equal(t, key, target.OperatorSigningKey)
+}
+
+func newOperatorSigningKey(t *testing.T) domain.NatsOperatorSigningKey {
+	key, err := nkeys.CreateOperator()
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,22 @@
+package nauth
+
+import "fmt"
+
+// ResolverConfigRequest describes the resolver configuration of the NATS servers of a cluster.
+type ResolverConfigRequest struct {
+	ClusterTarget ClusterTarget
+	// OperatorJWT is the JWT of the operator, which is not managed by NAuth.
+	OperatorJWT string
+	// AccountIDs are the accounts preloaded in addition to the system account.
+	AccountIDs []AccountID
+}
+
+func (r *ResolverConfigRequest) Validate() error {
+	if err := r.ClusterTarget.Validate(); err != nil {
+		return fmt.Errorf("invalid cluster target: %w", err)
+	}
+	if r.OperatorJWT == "" {
+		return fmt.Errorf("operator JWT is required")
+	}
+	return nil
+}
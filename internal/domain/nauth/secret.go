@@ -0,0 +1,11 @@
+package nauth
+
+// SecretMigrationResult summarizes a migration of account secrets to the configured secret name template.
+type SecretMigrationResult struct {
+	// Accounts is the number of accounts whose secrets were checked.
+	Accounts int
+	// Migrated is the number of accounts whose secrets were renamed.
+	Migrated int
+	// Skipped lists the accounts that were intentionally not migrated, with the reason.
+	Skipped []string
+}
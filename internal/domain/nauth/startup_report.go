@@ -0,0 +1,33 @@
+package nauth
+
+import "time"
+
+// AccountSyncState compares an account in NATS with the state last recorded for its Account resource.
+type AccountSyncState string
+
+const (
+	AccountSyncStateInSync AccountSyncState = "InSync"
+	// AccountSyncStateMissingSecrets means the Secrets holding the keys of the account are gone.
+	AccountSyncStateMissingSecrets AccountSyncState = "MissingSecrets"
+	// AccountSyncStateDrifted means NATS holds no account JWT or one with other claims than last pushed.
+	AccountSyncStateDrifted AccountSyncState = "Drifted"
+)
+
+// StartupReport summarizes the Accounts and Users found when the operator starts, before their reconciles surface
+// problems one by one.
+type StartupReport struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Accounts    StartupReportCounts `json:"accounts"`
+	Users       StartupReportCounts `json:"users"`
+}
+
+// StartupReportCounts counts the resources of one kind by state. A resource is in sync when it is ready for its
+// current generation, its Secrets exist and, for an account, NATS holds the claims it was last pushed with.
+type StartupReportCounts struct {
+	Total          int `json:"total"`
+	InSync         int `json:"inSync"`
+	MissingSecrets int `json:"missingSecrets"`
+	Drifted        int `json:"drifted"`
+	// Unchecked resources could not be compared with NATS, e.g. as their cluster is unreachable.
+	Unchecked int `json:"unchecked"`
+}
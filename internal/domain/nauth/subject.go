@@ -0,0 +1,133 @@
+package nauth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	subjectTokenSeparator = "."
+	subjectTokenWildcard  = "*"
+	subjectFullWildcard   = ">"
+)
+
+// Normalize returns the subject without the whitespace around it, which NATS would otherwise treat as part of its first
+// or last token.
+func (s Subject) Normalize() Subject {
+	return Subject(strings.TrimSpace(string(s)))
+}
+
+// Validate reports whether the subject is a valid NATS subject: non-empty tokens separated by `.`, no whitespace, `*`
+// only as a whole token and `>` only as the whole last token.
+func (s Subject) Validate() error {
+	if s == "" {
+		return fmt.Errorf("subject cannot be empty")
+	}
+	if strings.ContainsFunc(string(s), unicode.IsSpace) {
+		return fmt.Errorf("subject %q cannot contain whitespace", s)
+	}
+	tokens := strings.Split(string(s), subjectTokenSeparator)
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return fmt.Errorf("subject %q cannot contain empty tokens", s)
+		case token == subjectFullWildcard:
+			if i != len(tokens)-1 {
+				return fmt.Errorf("subject %q can only have %q as its last token", s, subjectFullWildcard)
+			}
+		case token == subjectTokenWildcard:
+		case strings.ContainsAny(token, subjectTokenWildcard+subjectFullWildcard):
+			return fmt.Errorf("subject %q can only have wildcards as whole tokens", s)
+		}
+	}
+	return nil
+}
+
+// HasWildcards reports whether the subject has a `*` or `>` token.
+func (s Subject) HasWildcards() bool {
+	for _, token := range strings.Split(string(s), subjectTokenSeparator) {
+		if token == subjectTokenWildcard || token == subjectFullWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps reports whether a message subject exists that is matched by both subjects. Both subjects must be valid.
+func (s Subject) Overlaps(other Subject) bool {
+	tokens := strings.Split(string(s), subjectTokenSeparator)
+	otherTokens := strings.Split(string(other), subjectTokenSeparator)
+	for i := 0; ; i++ {
+		if i == len(tokens) || i == len(otherTokens) {
+			return len(tokens) == len(otherTokens)
+		}
+		token, otherToken := tokens[i], otherTokens[i]
+		if token == subjectFullWildcard || otherToken == subjectFullWildcard {
+			return true
+		}
+		if token != subjectTokenWildcard && otherToken != subjectTokenWildcard && token != otherToken {
+			return false
+		}
+	}
+}
+
+// ValidateSubjects validates the subject of each export and rejects exports of the same type with overlapping
+// subjects, as an importing account could not tell which of them a message is exported by.
+func (e Exports) ValidateSubjects() error {
+	var errs []error
+	for i, export := range e {
+		if err := export.Subject.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("export at index %d: %w", i, err))
+			continue
+		}
+		for _, other := range e[:i] {
+			if other.Subject.Validate() != nil || export.isService() != other.isService() {
+				continue
+			}
+			if export.Subject.Overlaps(other.Subject) {
+				errs = append(errs, fmt.Errorf("export subject %q overlaps export subject %q", export.Subject, other.Subject))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateWildcards rejects exports with wildcard subjects that do not set AllowWildcard, and any wildcard export when
+// wildcardExports is false, as NATS then rejects the account.
+func (e Exports) ValidateWildcards(wildcardExports bool) error {
+	var errs []error
+	for i, export := range e {
+		if !export.Subject.Normalize().HasWildcards() {
+			continue
+		}
+		if !export.AllowWildcard {
+			errs = append(errs, fmt.Errorf("export at index %d: wildcard subject %q requires allowWildcard", i, export.Subject))
+		} else if !wildcardExports {
+			errs = append(errs, fmt.Errorf("export at index %d: wildcard subject %q is not allowed by account limit wildcards", i, export.Subject))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isService reports whether the export is a service export. Exports of any other type are exported as streams.
+func (e *Export) isService() bool {
+	return e.Type == ExportTypeService
+}
+
+// ValidateSubjects validates the subject and local subject of each import.
+func (i Imports) ValidateSubjects() error {
+	var errs []error
+	for index, imp := range i {
+		if err := imp.Subject.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("import at index %d: %w", index, err))
+		}
+		if imp.LocalSubject != "" {
+			if err := imp.LocalSubject.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("import at index %d: local %w", index, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
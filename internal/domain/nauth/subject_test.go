@@ -0,0 +1,179 @@
+package nauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Subject_Normalize(t *testing.T) {
+	require.Equal(t, Subject("foo.bar"), Subject(" \tfoo.bar\n").Normalize())
+}
+
+func Test_Subject_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		subject       Subject
+		expectedError string
+	}{
+		{name: "literal", subject: "foo.bar"},
+		{name: "token_wildcard", subject: "foo.*.bar"},
+		{name: "full_wildcard", subject: "foo.>"},
+		{name: "only_full_wildcard", subject: ">"},
+		{name: "empty", subject: "", expectedError: "subject cannot be empty"},
+		{name: "whitespace", subject: "foo bar", expectedError: "cannot contain whitespace"},
+		{name: "tab", subject: "foo.\tbar", expectedError: "cannot contain whitespace"},
+		{name: "leading_separator", subject: ".foo", expectedError: "cannot contain empty tokens"},
+		{name: "consecutive_separators", subject: "foo..bar", expectedError: "cannot contain empty tokens"},
+		{name: "full_wildcard_not_last", subject: "foo.>.bar", expectedError: "can only have \">\" as its last token"},
+		{name: "partial_token_wildcard", subject: "foo.b*r", expectedError: "can only have wildcards as whole tokens"},
+		{name: "partial_full_wildcard", subject: "foo.bar>", expectedError: "can only have wildcards as whole tokens"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.subject.Validate()
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func Test_Subject_Overlaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		subject  Subject
+		other    Subject
+		expected bool
+	}{
+		{name: "equal", subject: "foo.bar", other: "foo.bar", expected: true},
+		{name: "different", subject: "foo.bar", other: "foo.baz", expected: false},
+		{name: "token_wildcard", subject: "foo.*", other: "foo.bar", expected: true},
+		{name: "crossing_token_wildcards", subject: "foo.*.baz", other: "foo.bar.*", expected: true},
+		{name: "full_wildcard", subject: "foo.>", other: "foo.bar.baz", expected: true},
+		{name: "full_wildcard_needs_a_token", subject: "foo.>", other: "foo", expected: false},
+		{name: "different_length", subject: "foo.*", other: "foo.bar.baz", expected: false},
+		{name: "different_prefix", subject: "foo.>", other: "bar.>", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.subject.Overlaps(tc.other))
+			require.Equal(t, tc.expected, tc.other.Overlaps(tc.subject))
+		})
+	}
+}
+
+func Test_Exports_ValidateSubjects(t *testing.T) {
+	testCases := []struct {
+		name          string
+		exports       Exports
+		expectedError string
+	}{
+		{
+			name: "disjoint",
+			exports: Exports{
+				{Subject: "foo.*", Type: ExportTypeStream},
+				{Subject: "bar.>", Type: ExportTypeStream},
+			},
+		},
+		{
+			name: "overlapping_exports_of_different_types",
+			exports: Exports{
+				{Subject: "foo.*", Type: ExportTypeStream},
+				{Subject: "foo.bar", Type: ExportTypeService},
+			},
+		},
+		{
+			name: "overlapping_exports_of_same_type",
+			exports: Exports{
+				{Subject: "foo.*.baz", Type: ExportTypeService},
+				{Subject: "foo.bar.*", Type: ExportTypeService},
+			},
+			expectedError: "export subject \"foo.bar.*\" overlaps export subject \"foo.*.baz\"",
+		},
+		{
+			name: "invalid_subject",
+			exports: Exports{
+				{Subject: "foo.>.bar", Type: ExportTypeStream},
+			},
+			expectedError: "export at index 0: subject \"foo.>.bar\" can only have \">\" as its last token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.exports.ValidateSubjects()
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func Test_Exports_ValidateWildcards(t *testing.T) {
+	testCases := []struct {
+		name            string
+		exports         Exports
+		wildcardExports bool
+		expectedError   string
+	}{
+		{
+			name:            "literal_subject",
+			exports:         Exports{{Subject: "foo.bar"}},
+			wildcardExports: false,
+		},
+		{
+			name:            "acknowledged_wildcard",
+			exports:         Exports{{Subject: "foo.*", AllowWildcard: true}},
+			wildcardExports: true,
+		},
+		{
+			name:            "unacknowledged_wildcard",
+			exports:         Exports{{Subject: "foo.bar"}, {Subject: "foo.>"}},
+			wildcardExports: true,
+			expectedError:   "export at index 1: wildcard subject \"foo.>\" requires allowWildcard",
+		},
+		{
+			name:            "wildcards_not_allowed_by_limits",
+			exports:         Exports{{Subject: "foo.*", AllowWildcard: true}},
+			wildcardExports: false,
+			expectedError:   "export at index 0: wildcard subject \"foo.*\" is not allowed by account limit wildcards",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.exports.ValidateWildcards(tc.wildcardExports)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func Test_Imports_ValidateSubjects(t *testing.T) {
+	// Given
+	imports := Imports{
+		{Subject: "foo.>", LocalSubject: "local.foo.>"},
+		{Subject: "bar.*"},
+		{Subject: "baz.b*", LocalSubject: "local..baz"},
+	}
+
+	// When
+	err := imports.ValidateSubjects()
+
+	// Then
+	require.EqualError(t, err, "import at index 2: subject \"baz.b*\" can only have wildcards as whole tokens\n"+
+		"import at index 2: local subject \"local..baz\" cannot contain empty tokens")
+}
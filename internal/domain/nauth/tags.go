@@ -0,0 +1,23 @@
+package nauth
+
+import (
+	"maps"
+	"slices"
+)
+
+// TagValueSeparator separates the key from the value in the JWT tags encoding tag values.
+const TagValueSeparator = ":"
+
+// EncodeTags returns the JWT tags of tags and tag values. JWTs have no field for custom data, so each tag value is
+// encoded as a "key:value" tag after the plain tags, sorted by key.
+func EncodeTags[V ~string](tags []string, values map[string]V) []string {
+	if len(values) == 0 {
+		return tags
+	}
+	result := make([]string, 0, len(tags)+len(values))
+	result = append(result, tags...)
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		result = append(result, key+TagValueSeparator+string(values[key]))
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package nauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EncodeTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tags     []string
+		values   map[string]string
+		expected []string
+	}{
+		{name: "none"},
+		{name: "tags_only", tags: []string{"prod"}, expected: []string{"prod"}},
+		{
+			name:     "values_sorted_by_key",
+			tags:     []string{"prod"},
+			values:   map[string]string{"team": "orders", "cost-center": "4711"},
+			expected: []string{"prod", "cost-center:4711", "team:orders"},
+		},
+		{name: "empty_value", values: map[string]string{"edge": ""}, expected: []string{"edge:"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, EncodeTags(tc.tags, tc.values))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// NotificationType is a lifecycle event of NATS authorization state that is sent to external systems.
+type NotificationType string
+
+const (
+	// NotificationTypeAccountCreated notifies that the keys of a new account were created.
+	NotificationTypeAccountCreated NotificationType = "AccountCreated"
+	// NotificationTypeAccountDeleted notifies that an account was deleted from NATS.
+	NotificationTypeAccountDeleted NotificationType = "AccountDeleted"
+	// NotificationTypeCredentialsRotated notifies that the credentials of a user were replaced.
+	NotificationTypeCredentialsRotated NotificationType = "CredentialsRotated"
+	// NotificationTypeDriftDetected notifies that the claims of an observed account changed in NATS.
+	NotificationTypeDriftDetected NotificationType = "DriftDetected"
+	// NotificationTypeDeletionBlocked notifies that the deletion of an account is blocked by its users.
+	NotificationTypeDeletionBlocked NotificationType = "DeletionBlocked"
+)
+
+// Notification describes a lifecycle event of a Kubernetes resource managed by NAuth.
+type Notification struct {
+	Type     NotificationType `json:"type"`
+	Time     time.Time        `json:"time"`
+	Resource AuditResource    `json:"resource"`
+	// Warning is set for events that need attention.
+	Warning bool   `json:"warning,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+// Labels and annotations of the Secrets written by NAuth.
+const (
+	LabelSecretType   = "nauth.io/secret-type"
+	LabelManaged      = "nauth.io/managed"
+	LabelManagedValue = "true"
+	// AnnotationCredentialsChecksum holds the checksum of the data of a credentials Secret, e.g. for tools restarting
+	// the workloads mounting it.
+	AnnotationCredentialsChecksum = "nauth.io/credentials-checksum"
+)
+
+// Types of the Secrets written by NAuth, the value of their nauth.io/secret-type label.
+const (
+	SecretTypeAccountRoot     = "account-root"
+	SecretTypeAccountSign     = "account-sign"
+	SecretTypeAccountPending  = "account-pending"
+	SecretTypeUserCredentials = "user-creds"
+)
+
+// SecretNameAccountPendingTemplate names the secret holding the keys of an account being created.
+const SecretNameAccountPendingTemplate = "%s-ac-pending"
+
+// Keys of the data of the Secrets written by NAuth. They are decided by core and stored by the Kubernetes adapter.
+const (
+	// UserCredentialSecretKeyName holds the creds file of a user.
+	UserCredentialSecretKeyName = "user.creds"
+	// UserJWTSecretKeyName holds the JWT of a user issued as bearer JWT, which has no seed, or with the Expanded
+	// credentials format.
+	UserJWTSecretKeyName = "user.jwt"
+	// UserSeedSecretKeyName and UserPublicKeySecretKeyName hold the NKey of a user issued without a JWT or with the
+	// Expanded credentials format.
+	UserSeedSecretKeyName      = "user.nk"
+	UserPublicKeySecretKeyName = "user.pub"
+	// UserAuthorizationSecretKeyName holds the NATS server config entry of a user issued without a JWT.
+	UserAuthorizationSecretKeyName = "authorization.conf"
+)
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/domain"
 	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/WirelessCar/nauth/internal/ports/outbound"
 )
 
 type AccountManager interface {
@@ -12,6 +14,18 @@ type AccountManager interface {
 	Import(ctx context.Context, reference nauth.AccountReference) (*nauth.AccountResult, error)
 	FindAccountID(ctx context.Context, reference nauth.AccountReference) (nauth.AccountID, bool, error)
 	Delete(ctx context.Context, reference nauth.AccountReference) error
+	// Orphan deletes the secrets of the account but keeps the account in NATS.
+	Orphan(ctx context.Context, reference nauth.AccountReference) error
+	// FindAdoptionSource returns the Account another Account with the account ID of reference holds the secrets for,
+	// or nil when the secrets are held for reference itself or do not exist.
+	FindAdoptionSource(ctx context.Context, reference nauth.AccountReference) (*domain.NamespacedName, error)
+	// Adopt moves the secrets of the account held for the Account source to reference.
+	Adopt(ctx context.Context, reference nauth.AccountReference, source domain.NamespacedName) error
+}
+
+type AccountSyncChecker interface {
+	// CheckSync compares the account in NATS with claimsHash, the hash of the claims it was last pushed with.
+	CheckSync(ctx context.Context, reference nauth.AccountReference, claimsHash string) (nauth.AccountSyncState, error)
 }
 
 type AccountExportManager interface {
@@ -25,9 +39,40 @@ type AccountImportManager interface {
 type UserManager interface {
 	CreateOrUpdate(ctx context.Context, state *v1alpha1.User) error
 	Delete(ctx context.Context, desired *v1alpha1.User) error
+	// ExpireCredentials deletes the credentials Secret of a user with spec.credentialsTTL, keeping the user.
+	ExpireCredentials(ctx context.Context, state *v1alpha1.User) error
+}
+
+type NscExportManager interface {
+	Export(ctx context.Context, namespace domain.Namespace, store outbound.NscStoreWriter) (*nauth.NscExportResult, error)
+}
+
+type NscImportManager interface {
+	Import(ctx context.Context, request nauth.NscImportRequest, store outbound.NscStoreReader, manifests outbound.ManifestWriter) (*nauth.NscImportResult, error)
+}
+
+type SecretMigrationManager interface {
+	Migrate(ctx context.Context, namespace domain.Namespace) (*nauth.SecretMigrationResult, error)
+}
+
+type AccountGraphReader interface {
+	// GetGraph returns the import/export dependencies between the managed accounts.
+	GetGraph(ctx context.Context) (*nauth.AccountGraph, error)
 }
 
 type ClusterManager interface {
 	GetClusterTarget(ctx context.Context, accountClusterRef *nauth.ClusterRef) (*nauth.ClusterTarget, error)
 	Validate(ctx context.Context, target nauth.ClusterTarget) error
+	// CheckHealth pings the servers of the cluster through its system account.
+	CheckHealth(ctx context.Context, target nauth.ClusterTarget) (*nauth.ClusterHealth, error)
+}
+
+type AccountUsageManager interface {
+	// GetUsage returns the current usage of an account, summed over the servers of its cluster.
+	GetUsage(ctx context.Context, reference nauth.AccountReference) (*domain.NatsAccountUsage, error)
+}
+
+type ResolverConfigManager interface {
+	// Render returns the resolver configuration of the NATS servers of a cluster.
+	Render(ctx context.Context, request nauth.ResolverConfigRequest) (string, error)
 }
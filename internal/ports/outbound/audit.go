@@ -0,0 +1,13 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+// AuditRecorder records mutations of NATS authorization state. Recording never fails the mutation, failures to write
+// a record are reported by the recorder itself.
+type AuditRecorder interface {
+	Record(ctx context.Context, event domain.AuditEvent)
+}
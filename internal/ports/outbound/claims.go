@@ -0,0 +1,15 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+	"github.com/nats-io/jwt/v2"
+)
+
+// AccountClaimsMutator applies organization-specific mutations to the account claims built by the operator before
+// they are signed, e.g. injecting mandatory deny subjects or tags.
+type AccountClaimsMutator interface {
+	// MutateAccountClaims returns the claims to sign for the account of the Account accountRef in place of claims.
+	MutateAccountClaims(ctx context.Context, accountRef domain.NamespacedName, claims *jwt.AccountClaims) (*jwt.AccountClaims, error)
+}
@@ -3,6 +3,7 @@ package outbound
 import (
 	"context"
 
+	"github.com/WirelessCar/nauth/api/v1alpha1"
 	"github.com/WirelessCar/nauth/internal/domain"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,15 +17,58 @@ type ConfigMapReader interface {
 	Get(ctx context.Context, configMapRef domain.NamespacedName) (map[string]string, error)
 }
 
+type UserLister interface {
+	// List returns the Users in namespace, or in all namespaces when namespace is empty.
+	List(ctx context.Context, namespace domain.Namespace) ([]v1alpha1.User, error)
+}
+
+type PermissionSetReader interface {
+	// Get returns the PermissionSet.
+	// Returns domain.ErrBadRequest if the permissionSetRef is invalid.
+	// Returns domain.ErrPermissionSetNotFound if the PermissionSet does not exist.
+	Get(ctx context.Context, permissionSetRef domain.NamespacedName) (*v1alpha1.PermissionSet, error)
+}
+
 type SecretReader interface {
 	Get(ctx context.Context, secretRef domain.NamespacedName) (map[string]string, bool, error)
+	// GetByLabels lists the secrets with labels in namespace, or in all namespaces when namespace is empty.
 	GetByLabels(ctx context.Context, namespace domain.Namespace, labels map[string]string) (*v1.SecretList, error)
 }
 
 type SecretClient interface {
 	SecretReader
 	Apply(ctx context.Context, owner metav1.Object, meta metav1.ObjectMeta, valueMap map[string]string) error
+	ApplyAll(ctx context.Context, owner metav1.Object, secrets []SecretApply) error
 	Delete(ctx context.Context, secretRef domain.NamespacedName) error
 	DeleteByLabels(ctx context.Context, namespace domain.Namespace, labels map[string]string) error
 	Label(ctx context.Context, secretRef domain.NamespacedName, labels map[string]string) error
 }
+
+// SecretApply is a Secret applied by SecretClient.ApplyAll.
+type SecretApply struct {
+	Meta metav1.ObjectMeta
+	Data map[string]string
+}
+
+type CredentialsSink interface {
+	// Push delivers the credentials Secret secretRef of owner to the secret store configured by sink, and keeps
+	// delivering it as the Secret is rotated.
+	Push(ctx context.Context, owner metav1.Object, secretRef domain.NamespacedName, sink v1alpha1.CredentialsSink) error
+	// Remove stops delivering the credentials Secret secretRef and removes it from the secret store.
+	Remove(ctx context.Context, secretRef domain.NamespacedName) error
+}
+
+type WorkloadRestarter interface {
+	// Restart sets the pod template annotation key of the workload target in namespace to value, which rolls the
+	// workload out when value changed. Returns false when the workload does not exist.
+	// Returns domain.ErrBadRequest if the kind of target is not supported.
+	Restart(ctx context.Context, namespace domain.Namespace, target v1alpha1.RestartTarget, key string, value string) (bool, error)
+}
+
+// ManifestWriter writes resources as Kubernetes manifests instead of applying them, e.g. to review them or commit them
+// to a GitOps repository.
+type ManifestWriter interface {
+	WriteAccount(account *v1alpha1.Account) error
+	WriteUser(user *v1alpha1.User) error
+	WriteSecret(secret SecretApply) error
+}
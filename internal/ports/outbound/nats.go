@@ -1,6 +1,10 @@
 package outbound
 
-import "github.com/WirelessCar/nauth/internal/domain"
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
 
 type NatsConnection interface {
 	Disconnect()
@@ -9,21 +13,48 @@ type NatsConnection interface {
 
 // NatsSysClient is used for connecting to a NATS SYS account
 type NatsSysClient interface {
-	Connect(natsURL string, userCreds domain.NatsUserCreds) (NatsSysConnection, error)
+	// Connect connects to natsURL, tlsConfig is optional. The operations of the connection are traced as part of ctx.
+	Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (NatsSysConnection, error)
 }
 
 // NatsSysConnection represents a NATS connection bound to a SYS account
 type NatsSysConnection interface {
 	NatsConnection
+	AccountJWTStore
 	VerifySystemAccountAccess() error
+	// PingServers returns the servers of the cluster that respond to a ping of the system account.
+	PingServers() ([]domain.NatsServerInfo, error)
+	// AccountUsage returns the current usage of accountID, summed over the servers of the cluster.
+	AccountUsage(accountID string) (*domain.NatsAccountUsage, error)
+	// MaxPayload returns the largest message the servers accept, which limits the size of the account JWTs uploaded
+	// through the connection. Zero when unknown.
+	MaxPayload() int64
+	// ResolverStore returns the store account JWTs are written to for resolver. The connection itself is the store of
+	// the push resolver type.
+	ResolverStore(resolver domain.NatsResolver) (AccountJWTStore, error)
+}
+
+// AccountJWTStore holds the account JWTs the NATS servers resolve accounts from
+type AccountJWTStore interface {
+	// LookupAccountJWT returns the account JWT of accountID, or an empty string when there is none.
 	LookupAccountJWT(accountID string) (string, error)
 	UploadAccountJWT(jwt string) error
+	// DeleteAccountJWT deletes the accounts listed in jwt, a generic claims JWT signed by the operator.
 	DeleteAccountJWT(jwt string) error
 }
 
+// NatsClaimsPushStore keeps the account JWT pushes not sent yet, so that another replica resumes them after a failover
+type NatsClaimsPushStore interface {
+	// LoadClaimsPushes returns the pushes saved last, empty when none were saved.
+	LoadClaimsPushes(ctx context.Context) ([]domain.NatsClaimsPush, error)
+	// SaveClaimsPushes replaces the saved pushes with pushes.
+	SaveClaimsPushes(ctx context.Context, pushes []domain.NatsClaimsPush) error
+}
+
 // NatsAccountClient is used for connecting to a regular NATS account
 type NatsAccountClient interface {
-	Connect(natsURL string, userCreds domain.NatsUserCreds) (NatsAccountConnection, error)
+	// Connect connects to natsURL, tlsConfig is optional. The operations of the connection are traced as part of ctx.
+	Connect(ctx context.Context, natsURL string, userCreds domain.NatsUserCreds, tlsConfig *domain.NatsTLSConfig) (NatsAccountConnection, error)
 }
 
 // NatsAccountConnection represents a NATS connection bound to a regular (non-sys) account
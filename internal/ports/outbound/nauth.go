@@ -18,6 +18,11 @@ type AccountReader interface {
 	Get(ctx context.Context, accountRef domain.NamespacedName) (*v1alpha1.Account, error)
 }
 
+type AccountLister interface {
+	// List returns the NAuth Accounts in namespace, or in all namespaces when namespace is empty.
+	List(ctx context.Context, namespace domain.Namespace) ([]v1alpha1.Account, error)
+}
+
 type AccountIDReader interface {
 	// GetAccountID returns the NAuth Account ID for the given account reference.
 	// Returns domain.ErrBadRequest if the accountRef is invalid.
@@ -25,3 +30,25 @@ type AccountIDReader interface {
 	// Returns domain.ErrAccountNotReady if the Account is not ready or does not have an Account ID label.
 	GetAccountID(ctx context.Context, accountRef domain.NamespacedName) (nauth.AccountID, error)
 }
+
+type AccountUserDefaultsReader interface {
+	// GetUserDefaults returns the user defaults of the NAuth Account, or nil when the Account defines none.
+	// Returns domain.ErrBadRequest if the accountRef is invalid.
+	// Returns domain.ErrAccountNotFound if the Account does not exist.
+	GetUserDefaults(ctx context.Context, accountRef domain.NamespacedName) (*v1alpha1.UserDefaults, error)
+}
+
+type NauthDefaultsReader interface {
+	// GetNauthDefaults returns the spec of the NauthDefaults resource, or nil when it does not exist.
+	GetNauthDefaults(ctx context.Context) (*v1alpha1.NauthDefaultsSpec, error)
+}
+
+type CapabilitiesWriter interface {
+	// WriteCapabilities publishes the capabilities of the running operator installation.
+	WriteCapabilities(ctx context.Context, capabilities nauth.Capabilities) error
+}
+
+type StartupReportWriter interface {
+	// WriteStartupReport publishes the report of the resources found when the operator started.
+	WriteStartupReport(ctx context.Context, report nauth.StartupReport) error
+}
@@ -0,0 +1,13 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/WirelessCar/nauth/internal/domain"
+)
+
+// Notifier sends notifications of lifecycle events to external systems. Notifying never fails the reconcile that
+// caused it, failures to deliver are reported by the notifier itself.
+type Notifier interface {
+	Notify(ctx context.Context, notification domain.Notification)
+}
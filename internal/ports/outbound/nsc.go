@@ -0,0 +1,26 @@
+package outbound
+
+import (
+	"github.com/WirelessCar/nauth/internal/domain/nauth"
+	"github.com/nats-io/nkeys"
+)
+
+// NscStoreWriter writes operators, accounts and users to an nsc compatible store and keystore.
+type NscStoreWriter interface {
+	// WriteOperatorSigningKey stores the operator signing key used to sign the accounts of operator.
+	WriteOperatorSigningKey(operator string, signingKey nkeys.KeyPair) error
+	// WriteAccount stores the account JWT and the account key pairs.
+	WriteAccount(operator string, account string, accountJWT string, keyPairs ...nkeys.KeyPair) error
+	// WriteUser stores the user JWT, seed and credentials extracted from userCreds.
+	WriteUser(operator string, account string, user string, userCreds []byte) error
+}
+
+// NscStoreReader reads operators, accounts and users from an nsc store and keystore.
+type NscStoreReader interface {
+	// ListOperators returns the names of the operators in the store.
+	ListOperators() ([]string, error)
+	// ReadOperator returns the operator JWT together with the accounts and users of operator, sorted by name.
+	ReadOperator(operator string) (*nauth.NscOperator, error)
+	// ReadKey returns the key pair of publicKey, or false when the keystore holds no seed of publicKey.
+	ReadKey(publicKey string) (nkeys.KeyPair, bool, error)
+}
@@ -0,0 +1,10 @@
+package outbound
+
+import "github.com/WirelessCar/nauth/internal/domain"
+
+// OperatorSignerClient is used for signing with an operator signing key held by an external signing service.
+type OperatorSignerClient interface {
+	// Connect returns the operator signing key publicKey, signing through the signing service at url. The seed of the
+	// returned key is not available.
+	Connect(url string, publicKey string) (domain.NatsOperatorSigningKey, error)
+}
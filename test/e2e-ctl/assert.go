@@ -123,8 +123,8 @@ func runAssertOperatorSecrets(ctx context.Context, opts assertOperatorSecretsOpt
 	if err != nil {
 		return err
 	}
-	if cluster.Spec.OperatorSigningKeySecretRef.Name != opts.operatorSignSecret {
-		return fmt.Errorf("expected operator signing key Secret %q, got %q", opts.operatorSignSecret, cluster.Spec.OperatorSigningKeySecretRef.Name)
+	if ref := cluster.Spec.OperatorSigningKeySecretRef; ref == nil || ref.Name != opts.operatorSignSecret {
+		return fmt.Errorf("expected operator signing key Secret %q, got %v", opts.operatorSignSecret, ref)
 	}
 	if cluster.Spec.SystemAccountUserCredsSecretRef.Name != opts.systemCredsSecret {
 		return fmt.Errorf("expected system account user creds Secret %q, got %q", opts.systemCredsSecret, cluster.Spec.SystemAccountUserCredsSecretRef.Name)
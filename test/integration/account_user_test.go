@@ -0,0 +1,136 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const reconcileTimeout = 30 * time.Second
+
+func TestAccountAndUser_ShouldAuthorizeConnections(t *testing.T) {
+	// Given
+	natsServer := runNatsOperatorServer(t)
+	namespace := testutil.ScopedTestName("integration", t.Name())
+	createNatsCluster(t, namespace, natsServer)
+	createAccount(t, namespace, "orders")
+	subscriber := createUser(t, namespace, "subscriber", "orders", nil)
+	publisher := createUser(t, namespace, "publisher", "orders", &v1alpha1.Permissions{
+		Pub: v1alpha1.Permission{Allow: v1alpha1.StringList{"orders.>"}},
+	})
+
+	// When
+	subscriberConn := natsServer.connect(t, waitForUserCreds(t, subscriber))
+	publisherConn := natsServer.connect(t, waitForUserCreds(t, publisher))
+
+	// Then
+	sub, err := subscriberConn.SubscribeSync(">")
+	require.NoError(t, err)
+	require.NoError(t, subscriberConn.Flush())
+	require.NoError(t, publisherConn.Publish("payments.created", []byte("denied")))
+	require.NoError(t, publisherConn.Publish("orders.created", []byte("allowed")))
+	require.NoError(t, publisherConn.Flush())
+
+	msg, err := sub.NextMsg(time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "orders.created", msg.Subject)
+	_, err = sub.NextMsg(200 * time.Millisecond)
+	require.ErrorIs(t, err, nats.ErrTimeout)
+}
+
+func TestAccount_ShouldIsolateUsersOfDifferentAccounts(t *testing.T) {
+	// Given
+	natsServer := runNatsOperatorServer(t)
+	namespace := testutil.ScopedTestName("integration", t.Name())
+	createNatsCluster(t, namespace, natsServer)
+	createAccount(t, namespace, "team-a")
+	createAccount(t, namespace, "team-b")
+	userA := createUser(t, namespace, "user-a", "team-a", nil)
+	userB := createUser(t, namespace, "user-b", "team-b", nil)
+
+	// When
+	connA := natsServer.connect(t, waitForUserCreds(t, userA))
+	connB := natsServer.connect(t, waitForUserCreds(t, userB))
+
+	// Then
+	sub, err := connB.SubscribeSync("events.>")
+	require.NoError(t, err)
+	require.NoError(t, connB.Flush())
+	require.NoError(t, connA.Publish("events.created", []byte("team-a")))
+	require.NoError(t, connA.Flush())
+
+	_, err = sub.NextMsg(200 * time.Millisecond)
+	require.ErrorIs(t, err, nats.ErrTimeout)
+}
+
+func createNatsCluster(t *testing.T, namespace string, natsServer *natsOperatorServer) {
+	t.Helper()
+	require.NoError(t, ensureNamespace(ctx, namespace))
+	createSecret(t, namespace, "operator-signing-key", map[string]string{
+		k8s.DefaultSecretKeyName: string(natsServer.signingKey.Seed),
+	})
+	createSecret(t, namespace, "system-account-user-creds", map[string]string{
+		k8s.DefaultSecretKeyName: string(natsServer.sysUserCreds),
+	})
+	require.NoError(t, k8sClient.Create(ctx, &v1alpha1.NatsCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "nats", Namespace: namespace},
+		Spec: v1alpha1.NatsClusterSpec{
+			URL:                             natsServer.server.ClientURL(),
+			OperatorSigningKeySecretRef:     &v1alpha1.SecretKeyReference{Name: "operator-signing-key"},
+			SystemAccountUserCredsSecretRef: v1alpha1.SecretKeyReference{Name: "system-account-user-creds"},
+		},
+	}))
+}
+
+func createSecret(t *testing.T, namespace string, name string, data map[string]string) {
+	t.Helper()
+	require.NoError(t, k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		StringData: data,
+	}))
+}
+
+func createAccount(t *testing.T, namespace string, name string) {
+	t.Helper()
+	require.NoError(t, k8sClient.Create(ctx, &v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1alpha1.AccountSpec{
+			NatsClusterRef: &v1alpha1.NatsClusterRef{Name: "nats"},
+		},
+	}))
+}
+
+func createUser(t *testing.T, namespace string, name string, accountName string, permissions *v1alpha1.Permissions) *v1alpha1.User {
+	t.Helper()
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1alpha1.UserSpec{
+			AccountName: accountName,
+			Permissions: permissions,
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, user))
+	return user
+}
+
+// waitForUserCreds returns the credentials NAuth issued for user once they are written to its credentials Secret.
+func waitForUserCreds(t *testing.T, user *v1alpha1.User) []byte {
+	t.Helper()
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: user.GetNamespace(), Name: user.GetUserSecretName()}
+	require.Eventually(t, func() bool {
+		if err := k8sClient.Get(ctx, secretKey, secret); err != nil {
+			return false
+		}
+		return len(secret.Data[k8s.UserCredentialSecretKeyName]) > 0
+	}, reconcileTimeout, 250*time.Millisecond, "credentials Secret %s was not written", secretKey)
+	return secret.Data[k8s.UserCredentialSecretKeyName]
+}
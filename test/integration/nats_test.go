@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WirelessCar/nauth/internal/testutil"
+	"github.com/nats-io/jwt/v2"
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+// natsOperatorServer is an embedded nats-server trusting an operator whose signing key NAuth signs accounts with.
+type natsOperatorServer struct {
+	server *natsserver.Server
+	// signingKey is the operator signing key given to NAuth through the NatsCluster.
+	signingKey testutil.NatsTestOperatorKey
+	// sysUserCreds are the credentials of a system account user given to NAuth through the NatsCluster.
+	sysUserCreds []byte
+}
+
+// runNatsOperatorServer starts a nats-server in operator mode with a full account resolver, so that account JWTs
+// pushed by NAuth through the system account are stored and enforced like in a real deployment.
+func runNatsOperatorServer(t *testing.T) *natsOperatorServer {
+	t.Helper()
+
+	op := testutil.CreateNatsTestOperator()
+	opClaims := jwt.NewOperatorClaims(op.Root.PublicKey)
+	opClaims.SigningKeys.Add(op.Sign.PublicKey)
+	opJWT, err := opClaims.Encode(op.Root.Key)
+	require.NoError(t, err)
+	opClaims, err = jwt.DecodeOperatorClaims(opJWT)
+	require.NoError(t, err)
+
+	sysAccount := testutil.CreateNatsTestAccountKey()
+	sysClaims := jwt.NewAccountClaims(sysAccount.PublicKey)
+	sysClaims.Name = "SYS"
+	sysJWT, err := sysClaims.Encode(op.Sign.Key)
+	require.NoError(t, err)
+
+	resolver, err := natsserver.NewDirAccResolver(t.TempDir(), 0, time.Minute, natsserver.NoDelete)
+	require.NoError(t, err)
+	require.NoError(t, resolver.Store(sysAccount.PublicKey, sysJWT))
+
+	server, err := natsserver.NewServer(&natsserver.Options{
+		Host:                  "127.0.0.1",
+		Port:                  -1,
+		NoLog:                 true,
+		NoSigs:                true,
+		DisableShortFirstPing: true,
+		TrustedOperators:      []*jwt.OperatorClaims{opClaims},
+		AccountResolver:       resolver,
+		SystemAccount:         sysAccount.PublicKey,
+	})
+	require.NoError(t, err)
+	go server.Start()
+	require.True(t, server.ReadyForConnections(3*time.Second), "nats-server did not become ready in time")
+	t.Cleanup(func() {
+		server.Shutdown()
+		server.WaitForShutdown()
+		resolver.Close()
+	})
+
+	return &natsOperatorServer{
+		server:       server,
+		signingKey:   op.Sign,
+		sysUserCreds: newUserCreds(t, sysAccount),
+	}
+}
+
+func (s *natsOperatorServer) connect(t *testing.T, creds []byte) *nats.Conn {
+	t.Helper()
+	nc, err := nats.Connect(s.server.ClientURL(), nats.UserCredentialBytes(creds))
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+func newUserCreds(t *testing.T, account testutil.NatsTestAccountKey) []byte {
+	t.Helper()
+	user := testutil.CreateNatsTestUserKey()
+	claims := jwt.NewUserClaims(user.PublicKey)
+	claims.IssuerAccount = account.PublicKey
+	userJWT, err := claims.Encode(account.Key)
+	require.NoError(t, err)
+	creds, err := jwt.FormatUserConfig(userJWT, user.Seed)
+	require.NoError(t, err)
+	return creds
+}
@@ -0,0 +1,156 @@
+// Package integration runs the real controllers against envtest and an embedded nats-server in operator mode, and
+// verifies that the JWTs pushed by NAuth authorize connections to NATS.
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/WirelessCar/nauth/api/v1alpha1"
+	"github.com/WirelessCar/nauth/internal/adapter/inbound/controller"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/audit"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/k8s"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/nats"
+	"github.com/WirelessCar/nauth/internal/adapter/outbound/signer"
+	"github.com/WirelessCar/nauth/internal/core"
+	"github.com/WirelessCar/nauth/internal/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+const operatorNamespace = "nauth-system"
+
+var (
+	ctx       context.Context
+	cancel    context.CancelFunc
+	testEnv   *envtest.Environment
+	k8sClient client.Client
+)
+
+func TestMain(m *testing.M) {
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	ctx, cancel = context.WithCancel(context.Background())
+
+	if err := v1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     testutil.GetProjectCRDDirectoryPaths(),
+		ErrorIfCRDPathMissing: true,
+	}
+	if binaryAssetsDir := testutil.GetProjectBinaryAssetsDir(); binaryAssetsDir != "" {
+		testEnv.BinaryAssetsDirectory = binaryAssetsDir
+	}
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		panic(err)
+	}
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		panic(err)
+	}
+	if err := ensureNamespace(ctx, operatorNamespace); err != nil {
+		panic(err)
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                 scheme.Scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err := setupControllers(mgr); err != nil {
+		panic(err)
+	}
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	code := m.Run()
+
+	cancel()
+	if err := testEnv.Stop(); err != nil {
+		panic(err)
+	}
+
+	os.Exit(code)
+}
+
+// setupControllers wires the Account, User and NatsCluster controllers the way cmd/main.go does, talking to NATS
+// through the real system account connection pool.
+func setupControllers(mgr ctrl.Manager) error {
+	coreConfig, err := core.NewConfig(nil, operatorNamespace, core.CryptoPolicyDefault, "", "")
+	if err != nil {
+		return err
+	}
+
+	secretClient := k8s.NewSecretClient(mgr.GetClient())
+	accountClient := k8s.NewAccountClient(mgr.GetClient())
+	nauthDefaultsClient := k8s.NewNauthDefaultsClient(mgr.GetClient())
+	clusterClient := k8s.NewClusterClient(mgr.GetClient(), secretClient, k8s.NewConfigMapClient(mgr.GetClient()),
+		signer.NewClient())
+	natsSysClient, err := nats.NewSysConnectionPool(nats.DefaultPoolIdleTimeout, nats.DefaultPoolHealthCheckInterval, nil)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(natsSysClient); err != nil {
+		return err
+	}
+
+	clusterManager, err := core.NewClusterManager(clusterClient, natsSysClient, coreConfig)
+	if err != nil {
+		return err
+	}
+	accountManager, err := core.NewAccountManager(natsSysClient, nats.NewAccountClient(), accountClient, secretClient,
+		audit.Discard, nil, coreConfig)
+	if err != nil {
+		return err
+	}
+	userManager, err := core.NewUserManager(accountManager, secretClient, k8s.NewPushSecretClient(mgr.GetClient()),
+		k8s.NewWorkloadClient(mgr.GetClient()), k8s.NewPermissionSetClient(mgr.GetClient()), accountClient, nauthDefaultsClient, audit.Discard, coreConfig)
+	if err != nil {
+		return err
+	}
+
+	var requeuePolicies controller.RequeuePolicies
+	if err := controller.NewNatsClusterReconciler(mgr.GetClient(), mgr.GetScheme(), clusterManager, clusterClient,
+		mgr.GetEventRecorder("natscluster-controller"),
+	).SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindNatsCluster), 1); err != nil {
+		return err
+	}
+	if err := controller.NewAccountReconciler(mgr.GetClient(), mgr.GetScheme(), accountManager, clusterManager,
+		accountClient, k8s.NewReferenceGrantClient(mgr.GetClient()), nauthDefaultsClient,
+		mgr.GetEventRecorder("account-controller"), nil, false,
+	).SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindAccount), 1); err != nil {
+		return err
+	}
+	return controller.NewUserReconciler(mgr.GetClient(), mgr.GetScheme(), userManager,
+		k8s.NewReferenceGrantClient(mgr.GetClient()), nauthDefaultsClient, mgr.GetEventRecorder("user-controller"), nil,
+	).SetupWithManager(mgr, requeuePolicies.For(controller.RequeueKindUser), 1)
+}
+
+func ensureNamespace(ctx context.Context, namespace string) error {
+	err := k8sClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+	})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
@@ -44,7 +44,9 @@ export default defineConfig({
 					items: [
 						{ label: "Getting Started", slug: "guides/getting-started" },
 						{ label: "Observe Existing Accounts", slug: "guides/observe-existing-accounts" },
+						{ label: "Migrate from nsc", slug: "guides/migrate-from-nsc" },
 						{ label: "Observability", slug: "guides/observability" },
+						{ label: "Disaster Recovery", slug: "guides/disaster-recovery" },
 					],
 				},
 				{